@@ -0,0 +1,88 @@
+// Package main は既存圃場の重心・H3インデックスを再計算するバックフィルのエントリポイント
+//
+// 重心の算出方法を変更した後に1回実行し、H3セルが変わった圃場についてクラスタージョブをエンキューする
+//   - -dry-run: 更新・エンキューを行わず、対象件数と影響セル数のみ出力する
+//   - -batch-size: 1回に取得する圃場数
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/mktkhr/field-manager-api/internal/config"
+	clusterUsecase "github.com/mktkhr/field-manager-api/internal/features/cluster/application/usecase"
+	clusterRepo "github.com/mktkhr/field-manager-api/internal/features/cluster/infrastructure/repository"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/usecase"
+	fieldRepo "github.com/mktkhr/field-manager-api/internal/features/field/infrastructure/repository"
+	"github.com/mktkhr/field-manager-api/internal/infrastructure/postgres"
+	"github.com/mktkhr/field-manager-api/internal/logger"
+)
+
+func main() {
+	batchSize := flag.Int("batch-size", usecase.DefaultCentroidBackfillBatchSize, "1回に取得する圃場数")
+	dryRun := flag.Bool("dry-run", false, "更新・エンキューを行わず件数のみ出力する")
+	flag.Parse()
+
+	// 設定読み込み
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("設定の読み込みに失敗しました: %v", err)
+	}
+
+	// ログ設定
+	logger.Setup(cfg.Logger)
+
+	// コンテキスト設定（シグナルハンドリング）
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	// DB接続
+	pool, err := postgres.CreateConnectionPool(ctx, &cfg.Database)
+	if err != nil {
+		log.Fatalf("DB接続に失敗しました: %v", err)
+	}
+	defer pool.Close()
+
+	// クラスタージョブエンキューアー作成
+	clusterJobRepository := clusterRepo.NewClusterJobPostgresRepository(pool, slog.Default())
+	enqueueJobUC := clusterUsecase.NewEnqueueJobUseCase(clusterJobRepository, slog.Default())
+	clusterJobEnqueuer := clusterUsecase.NewClusterJobEnqueuer(enqueueJobUC)
+
+	// ユースケース作成
+	backfillUC := usecase.NewBackfillFieldCentroidsUseCase(
+		fieldRepo.NewFieldCentroidRepository(pool, slog.Default()),
+		clusterJobEnqueuer,
+		slog.Default(),
+	)
+
+	slog.Info("圃場の重心バックフィルを開始します",
+		slog.Int("batch_size", *batchSize),
+		slog.Bool("dry_run", *dryRun))
+
+	output, err := backfillUC.Execute(ctx, usecase.BackfillFieldCentroidsInput{
+		BatchSize: *batchSize,
+		DryRun:    *dryRun,
+	})
+	if err != nil {
+		slog.Error("圃場の重心バックフィルに失敗しました",
+			slog.String("error", err.Error()))
+		if output != nil {
+			slog.Info("失敗までの処理件数",
+				slog.Int("scanned", output.Scanned),
+				slog.Int("updated", output.Updated))
+		}
+		pool.Close()
+		os.Exit(1)
+	}
+
+	for method, count := range output.Methods {
+		slog.Info("重心の算出方法",
+			slog.String("method", string(method)),
+			slog.Int("fields", count))
+	}
+}
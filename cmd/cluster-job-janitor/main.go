@@ -0,0 +1,76 @@
+// Package main は保持期間を過ぎたクラスタージョブを削除するジャニターのエントリポイント
+//
+// 1回実行して終了するため、K8s CronJobなどで定期的に実行する。
+// 保留中・処理中のジョブは削除しない
+//   - COMPLETED_JOB_RETENTION: 完了済みジョブの保持期間(例: 168h)
+//   - FAILED_JOB_RETENTION: 失敗・取り消し済みジョブの保持期間(例: 720h)
+package main
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mktkhr/field-manager-api/internal/config"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/usecase"
+	clusterRepo "github.com/mktkhr/field-manager-api/internal/features/cluster/infrastructure/repository"
+	"github.com/mktkhr/field-manager-api/internal/infrastructure/postgres"
+	"github.com/mktkhr/field-manager-api/internal/logger"
+)
+
+func main() {
+	// 設定読み込み
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("設定の読み込みに失敗しました: %v", err)
+	}
+
+	// ログ設定
+	logger.Setup(cfg.Logger)
+
+	// コンテキスト設定（シグナルハンドリング）
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	// DB接続
+	pool, err := postgres.CreateConnectionPool(ctx, &cfg.Database)
+	if err != nil {
+		log.Fatalf("DB接続に失敗しました: %v", err)
+	}
+	defer pool.Close()
+
+	cleanupUC := usecase.NewCleanupJobsUseCase(
+		clusterRepo.NewClusterJobPostgresRepository(pool, slog.Default()),
+		slog.Default(),
+	)
+
+	input := usecase.CleanupJobsInput{
+		CompletedRetention: getEnvDuration("COMPLETED_JOB_RETENTION", usecase.DefaultCompletedJobRetention),
+		FailedRetention:    getEnvDuration("FAILED_JOB_RETENTION", usecase.DefaultFailedJobRetention),
+	}
+
+	slog.Info("クラスタージョブの削除を開始します",
+		slog.Duration("completed_retention", input.CompletedRetention),
+		slog.Duration("failed_retention", input.FailedRetention))
+
+	if _, err := cleanupUC.Execute(ctx, input); err != nil {
+		slog.Error("クラスタージョブの削除に失敗しました",
+			slog.String("error", err.Error()))
+		pool.Close()
+		os.Exit(1)
+	}
+}
+
+// getEnvDuration は環境変数からDurationを取得する
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
@@ -2,11 +2,23 @@
 //
 // このワーカーは以下のモードで動作可能:
 //   - RUN_ONCE=true: 1回実行して終了（Lambda/K8s Job向け）
-//   - RUN_ONCE=false: デーモンモードでポーリング実行
+//   - RUN_ONCE=false: デーモンモードで保留中ジョブの通知(LISTEN/NOTIFY)を待ち受けて実行
+//
+// デーモンモードではDEBOUNCE_INTERVALの間に届いた通知を1回にまとめて処理する。
+// 通知を取りこぼした場合に備えて、POLL_INTERVALごとのポーリングも併用する。
+//
+// SIGINT/SIGTERMを受信すると新しいジョブの取得をやめ、処理中のジョブを完了させてから終了する。
+// 再度シグナルを受信した場合は処理中のジョブを中断して終了する(ジョブはリース期限切れ後に再実行される)。
+//
+// 複数ワーカーを同時に起動でき、ジョブはWORKER_IDごとのリースで排他制御される。
+// LEASE_DURATIONの間ハートビートが途絶えたジョブは、他のワーカーがMAX_ATTEMPTS回まで再実行する
+//
+// ジョブが完了するたびに市区町村・都道府県の圃場統計を再集計する
 package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"log/slog"
 	"os"
@@ -17,7 +29,11 @@ import (
 
 	"github.com/mktkhr/field-manager-api/internal/config"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 	clusterRepo "github.com/mktkhr/field-manager-api/internal/features/cluster/infrastructure/repository"
+	fieldRepo "github.com/mktkhr/field-manager-api/internal/features/field/infrastructure/repository"
+	statsUsecase "github.com/mktkhr/field-manager-api/internal/features/stats/application/usecase"
+	statsRepo "github.com/mktkhr/field-manager-api/internal/features/stats/infrastructure/repository"
 	"github.com/mktkhr/field-manager-api/internal/infrastructure/cache"
 	"github.com/mktkhr/field-manager-api/internal/infrastructure/postgres"
 	"github.com/mktkhr/field-manager-api/internal/logger"
@@ -25,8 +41,9 @@ import (
 )
 
 const (
-	defaultBatchSize    = 10
-	defaultPollInterval = 60 * time.Second
+	defaultBatchSize        = 10
+	defaultPollInterval     = 5 * time.Minute
+	defaultDebounceInterval = 2 * time.Second
 )
 
 func main() {
@@ -42,14 +59,20 @@ func main() {
 	slog.Info("クラスターワーカーを起動しています...")
 
 	// コンテキスト設定（シグナルハンドリング）
+	// 1回目のシグナルでshutdownを閉じて新しいジョブの取得をやめ、2回目のシグナルで処理中のジョブも中断する
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	shutdown := make(chan struct{})
 
-	sigCh := make(chan os.Signal, 1)
+	sigCh := make(chan os.Signal, 2)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		<-sigCh
-		slog.Info("シャットダウンシグナルを受信しました")
+		slog.Info("シャットダウンシグナルを受信しました。処理中のジョブの完了を待って停止します")
+		close(shutdown)
+
+		<-sigCh
+		slog.Warn("再度シャットダウンシグナルを受信しました。処理中のジョブを中断します")
 		cancel()
 	}()
 
@@ -72,65 +95,137 @@ func main() {
 	// リポジトリ作成
 	clusterRepository := clusterRepo.NewClusterPostgresRepository(pool, slog.Default())
 	clusterCacheRepository := clusterRepo.NewClusterCacheRedisRepository(cacheClient, slog.Default())
-	clusterJobRepository := clusterRepo.NewClusterJobPostgresRepository(pool)
+	clusterJobRepository := clusterRepo.NewClusterJobPostgresRepository(pool, slog.Default())
+	fieldTileCacheRepository := fieldRepo.NewFieldTileCacheRedisRepository(cacheClient, slog.Default())
+	fieldStatsRepository := statsRepo.NewFieldStatsPostgresRepository(pool, slog.Default())
+	fieldStatsCacheRepository := statsRepo.NewFieldStatsCacheRedisRepository(cacheClient, slog.Default())
+
+	// 集計方法(centroid/coverage/area_share)
+	aggregationMode, err := entity.ParseAggregationMode(getEnvString("CLUSTER_AGGREGATION_MODE", ""))
+	if err != nil {
+		log.Fatalf("CLUSTER_AGGREGATION_MODEが不正です: %v", err)
+	}
+
+	// クラスターを計算するH3解像度
+	resolutions, err := entity.ParseResolutions(cfg.Cluster.Resolutions)
+	if err != nil {
+		log.Fatalf("CLUSTER_RESOLUTIONSが不正です: %v", err)
+	}
 
 	// ユースケース作成
-	calculateUC := usecase.NewCalculateClustersUseCase(
+	calculateUC := usecase.NewCalculateClustersUseCaseWithCoverage(
 		clusterRepository,
 		clusterCacheRepository,
+		clusterRepo.NewH3CoveragePostgresRepository(pool, slog.Default()),
+		fieldTileCacheRepository,
+		aggregationMode,
+		resolutions,
 		slog.Default(),
 	)
 
-	processJobsUC := usecase.NewProcessJobsUseCase(
+	// 完了したジョブごとに市区町村・都道府県の圃場統計を再集計する
+	fieldStatsRefresher := statsUsecase.NewFieldStatsRefresher(
+		statsUsecase.NewRefreshFieldStatsUseCase(fieldStatsRepository, fieldStatsCacheRepository, slog.Default()),
+	)
+
+	processJobsUC := usecase.NewProcessJobsUseCaseWithStatsRefresher(
 		clusterJobRepository,
 		calculateUC,
+		fieldStatsRefresher,
 		slog.Default(),
 	)
 
 	// 環境変数から設定を読み込み
 	batchSize := getEnvInt("BATCH_SIZE", defaultBatchSize)
 	runOnce := getEnvBool("RUN_ONCE", false)
+	workerID := getEnvString("WORKER_ID", defaultWorkerID())
+	leaseDuration := getEnvDuration("LEASE_DURATION", usecase.DefaultJobLeaseDuration)
+	maxAttempts := getEnvInt("MAX_ATTEMPTS", int(usecase.DefaultJobMaxAttempts))
+
+	processInput := usecase.ProcessJobsInput{
+		BatchSize:     utils.SafeIntToInt32(batchSize),
+		WorkerID:      workerID,
+		LeaseDuration: leaseDuration,
+		MaxAttempts:   utils.SafeIntToInt32(maxAttempts),
+		Shutdown:      shutdown,
+	}
 
 	slog.Info("ワーカー設定",
 		slog.Int("batch_size", batchSize),
-		slog.Bool("run_once", runOnce))
+		slog.Bool("run_once", runOnce),
+		slog.String("worker_id", workerID),
+		slog.Duration("lease_duration", leaseDuration),
+		slog.Int("max_attempts", maxAttempts),
+		slog.String("aggregation_mode", string(aggregationMode)),
+		slog.Any("resolutions", resolutions))
 
 	if runOnce {
 		// 1回実行モード（Lambda/K8s Job向け）
 		slog.Info("1回実行モードで起動します")
-		if err := processJobsUC.Execute(ctx, usecase.ProcessJobsInput{
-			BatchSize: utils.SafeIntToInt32(batchSize),
-		}); err != nil {
+		if err := processJobsUC.Execute(ctx, processInput); err != nil {
 			log.Fatalf("ジョブ処理に失敗しました: %v", err)
 		}
 		slog.Info("1回実行モードが完了しました")
 		return
 	}
 
-	// デーモンモード（ポーリング）
+	// デーモンモード（通知待ち受け + フォールバックのポーリング）
 	pollInterval := getEnvDuration("POLL_INTERVAL", defaultPollInterval)
+	debounceInterval := getEnvDuration("DEBOUNCE_INTERVAL", defaultDebounceInterval)
 	slog.Info("デーモンモードで起動します",
-		slog.Duration("poll_interval", pollInterval))
+		slog.Duration("poll_interval", pollInterval),
+		slog.Duration("debounce_interval", debounceInterval))
+
+	// 接続直後にも通知されるため、起動時の保留中ジョブもここで処理される
+	listener := postgres.NewListener(pool, clusterRepo.ClusterJobPendingChannel, slog.Default())
+	wakeups := listener.Listen(ctx, debounceInterval)
 
 	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ctx.Done():
+		case <-shutdown:
 			slog.Info("ワーカーを停止します")
 			return
-		case <-ticker.C:
-			if err := processJobsUC.Execute(ctx, usecase.ProcessJobsInput{
-				BatchSize: utils.SafeIntToInt32(batchSize),
-			}); err != nil {
-				slog.Error("ジョブ処理に失敗しました",
-					slog.String("error", err.Error()))
+		case _, ok := <-wakeups:
+			if !ok {
+				// 待ち受けが終了した場合はポーリングのみで処理を続ける
+				wakeups = nil
+				continue
 			}
+			processJobs(ctx, processJobsUC, processInput)
+		case <-ticker.C:
+			processJobs(ctx, processJobsUC, processInput)
 		}
 	}
 }
 
+// processJobs は保留中のジョブを処理し、失敗した場合はログに記録する
+func processJobs(ctx context.Context, uc *usecase.ProcessJobsUseCase, input usecase.ProcessJobsInput) {
+	if err := uc.Execute(ctx, input); err != nil {
+		slog.Error("ジョブ処理に失敗しました",
+			slog.String("error", err.Error()))
+	}
+}
+
+// defaultWorkerID はホスト名とプロセスIDからワーカーIDを生成する
+func defaultWorkerID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// getEnvString は環境変数から文字列を取得する
+func getEnvString(key string, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
+
 // getEnvInt は環境変数から整数値を取得する
 func getEnvInt(key string, defaultValue int) int {
 	if v := os.Getenv(key); v != "" {
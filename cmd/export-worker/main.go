@@ -0,0 +1,184 @@
+// Package main は圃場エクスポートワーカーのエントリポイント
+//
+// このワーカーは以下のモードで動作可能:
+//   - RUN_ONCE=true: 1回実行して終了（Lambda/K8s Job向け）
+//   - RUN_ONCE=false: デーモンモードでポーリング実行
+//
+// 複数ワーカーを同時に起動でき、ジョブはWORKER_IDごとのリースで排他制御される。
+// LEASE_DURATIONの間ハートビートが途絶えたジョブ(異常終了やシャットダウンで中断したジョブ)は、
+// 他のワーカーがMAX_ATTEMPTS回まで再実行する
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/mktkhr/field-manager-api/internal/config"
+	"github.com/mktkhr/field-manager-api/internal/features/export/application/usecase"
+	exportQuery "github.com/mktkhr/field-manager-api/internal/features/export/infrastructure/query"
+	exportRepo "github.com/mktkhr/field-manager-api/internal/features/export/infrastructure/repository"
+	"github.com/mktkhr/field-manager-api/internal/features/import/infrastructure/external"
+	"github.com/mktkhr/field-manager-api/internal/infrastructure/postgres"
+	"github.com/mktkhr/field-manager-api/internal/logger"
+	"github.com/mktkhr/field-manager-api/internal/utils"
+)
+
+const (
+	defaultBatchSize    = 5
+	defaultPollInterval = 30 * time.Second
+)
+
+func main() {
+	// 設定読み込み
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("設定の読み込みに失敗しました: %v", err)
+	}
+
+	// ログ設定
+	logger.Setup(cfg.Logger)
+
+	slog.Info("エクスポートワーカーを起動しています...")
+
+	// コンテキスト設定（シグナルハンドリング）
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		slog.Info("シャットダウンシグナルを受信しました")
+		cancel()
+	}()
+
+	// DB接続
+	pool, err := postgres.CreateConnectionPool(ctx, &cfg.Database)
+	if err != nil {
+		log.Fatalf("DB接続に失敗しました: %v", err)
+	}
+	defer pool.Close()
+
+	// ストレージクライアント作成
+	storageClient, err := external.NewS3ClientFromStorageConfig(ctx, &cfg.Storage)
+	if err != nil {
+		log.Fatalf("ストレージクライアントの作成に失敗しました: %v", err)
+	}
+
+	// ユースケース作成
+	processExportJobsUC := usecase.NewProcessExportJobsUseCase(
+		exportRepo.NewExportJobRepository(pool),
+		exportQuery.NewExportFieldQuery(pool),
+		storageClient,
+		slog.Default(),
+	)
+
+	// 環境変数から設定を読み込み
+	batchSize := getEnvInt("BATCH_SIZE", defaultBatchSize)
+	pageSize := getEnvInt("PAGE_SIZE", usecase.DefaultExportPageSize)
+	runOnce := getEnvBool("RUN_ONCE", false)
+	workerID := getEnvString("WORKER_ID", defaultWorkerID())
+	leaseDuration := getEnvDuration("LEASE_DURATION", usecase.DefaultExportJobLeaseDuration)
+	maxAttempts := getEnvInt("MAX_ATTEMPTS", int(usecase.DefaultExportJobMaxAttempts))
+
+	slog.Info("ワーカー設定",
+		slog.Int("batch_size", batchSize),
+		slog.Int("page_size", pageSize),
+		slog.Bool("run_once", runOnce),
+		slog.String("worker_id", workerID),
+		slog.Duration("lease_duration", leaseDuration),
+		slog.Int("max_attempts", maxAttempts))
+
+	input := usecase.ProcessExportJobsInput{
+		MaxJobs:       batchSize,
+		PageSize:      pageSize,
+		WorkerID:      workerID,
+		LeaseDuration: leaseDuration,
+		MaxAttempts:   utils.SafeIntToInt32(maxAttempts),
+	}
+
+	if runOnce {
+		// 1回実行モード（Lambda/K8s Job向け）
+		slog.Info("1回実行モードで起動します")
+		if err := processExportJobsUC.Execute(ctx, input); err != nil {
+			log.Fatalf("ジョブ処理に失敗しました: %v", err)
+		}
+		slog.Info("1回実行モードが完了しました")
+		return
+	}
+
+	// デーモンモード（ポーリング）
+	pollInterval := getEnvDuration("POLL_INTERVAL", defaultPollInterval)
+	slog.Info("デーモンモードで起動します",
+		slog.Duration("poll_interval", pollInterval))
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("ワーカーを停止します")
+			return
+		case <-ticker.C:
+			if err := processExportJobsUC.Execute(ctx, input); err != nil {
+				slog.Error("ジョブ処理に失敗しました",
+					slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// defaultWorkerID はホスト名とプロセスIDからワーカーIDを生成する
+func defaultWorkerID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// getEnvString は環境変数から文字列を取得する
+func getEnvString(key string, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
+
+// getEnvInt は環境変数から整数値を取得する
+func getEnvInt(key string, defaultValue int) int {
+	if v := os.Getenv(key); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+// getEnvBool は環境変数からブール値を取得する
+func getEnvBool(key string, defaultValue bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+// getEnvDuration は環境変数からDurationを取得する
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
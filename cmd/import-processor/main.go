@@ -13,6 +13,7 @@ import (
 	"github.com/mktkhr/field-manager-api/internal/config"
 	clusterUsecase "github.com/mktkhr/field-manager-api/internal/features/cluster/application/usecase"
 	clusterRepo "github.com/mktkhr/field-manager-api/internal/features/cluster/infrastructure/repository"
+	fieldUsecase "github.com/mktkhr/field-manager-api/internal/features/field/application/usecase"
 	fieldRepo "github.com/mktkhr/field-manager-api/internal/features/field/infrastructure/repository"
 	importExternal "github.com/mktkhr/field-manager-api/internal/features/import/infrastructure/external"
 	importRepo "github.com/mktkhr/field-manager-api/internal/features/import/infrastructure/repository"
@@ -98,18 +99,24 @@ func run(ctx context.Context, importJobID uuid.UUID, s3Key string, batchSize int
 	// リポジトリ作成
 	importJobRepository := importRepo.NewImportJobRepository(pool, logger)
 	fieldRepository := fieldRepo.NewFieldRepository(pool, logger)
-	clusterJobRepository := clusterRepo.NewClusterJobPostgresRepository(pool)
+	clusterJobRepository := clusterRepo.NewClusterJobPostgresRepository(pool, slog.Default())
 
 	// クラスタージョブエンキューアー作成
 	enqueueJobUC := clusterUsecase.NewEnqueueJobUseCase(clusterJobRepository, logger)
 	clusterJobEnqueuer := clusterUsecase.NewClusterJobEnqueuer(enqueueJobUC)
 
+	// 圃場オーバーラップ検出器作成
+	detectFieldOverlapsUC := fieldUsecase.NewDetectFieldOverlapsUseCase(fieldRepo.NewFieldOverlapRepository(pool, logger), logger)
+	overlapDetector := fieldUsecase.NewFieldOverlapDetector(detectFieldOverlapsUC)
+
 	// ユースケース作成
 	processImportUC := usecase.NewProcessImportUseCase(
 		importJobRepository,
 		s3Client,
 		fieldRepository,
 		clusterJobEnqueuer,
+		overlapDetector,
+		importRepo.NewGeometryRepairer(pool),
 		logger,
 	)
 
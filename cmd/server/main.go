@@ -7,6 +7,8 @@ import (
 	"log/slog"
 
 	"github.com/mktkhr/field-manager-api/internal/config"
+	clusterEntity "github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/import/infrastructure/external"
 	"github.com/mktkhr/field-manager-api/internal/infrastructure/cache"
 	"github.com/mktkhr/field-manager-api/internal/infrastructure/postgres"
 	"github.com/mktkhr/field-manager-api/internal/logger"
@@ -48,9 +50,21 @@ func main() {
 		}
 	}()
 
+	// ストレージクライアント作成
+	storageClient, err := external.NewS3ClientFromStorageConfig(ctx, &cfg.Storage)
+	if err != nil {
+		log.Fatalf("ストレージクライアントの作成に失敗しました: %v", err)
+	}
+
+	// クラスターの解像度
+	clusterResolutions, err := clusterEntity.ParseResolutions(cfg.Cluster.Resolutions)
+	if err != nil {
+		log.Fatalf("CLUSTER_RESOLUTIONSが不正です: %v", err)
+	}
+
 	// ハンドラー作成
 	appLogger := slog.Default()
-	handler := server.NewStrictServerHandler(pool, cacheClient, appLogger)
+	handler := server.NewStrictServerHandler(pool, cacheClient, storageClient, cfg.Storage.PresignedURLExpiry, clusterResolutions, appLogger)
 
 	// ルーターセットアップ
 	router := server.SetupRouter(handler)
@@ -0,0 +1,50 @@
+-- エクスポートジョブ管理テーブル
+CREATE TABLE export_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    format VARCHAR(20) NOT NULL CHECK (format IN ('geojson', 'csv', 'kml', 'gpkg')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+
+    -- 絞り込み条件(NULLの場合は絞り込まない)
+    city_code VARCHAR(10),
+    soil_small_code VARCHAR(20),
+    sw_lat DOUBLE PRECISION,
+    sw_lng DOUBLE PRECISION,
+    ne_lat DOUBLE PRECISION,
+    ne_lng DOUBLE PRECISION,
+
+    total_records INTEGER,
+    s3_key TEXT,
+    error_message TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+
+    -- バウンディングボックスは4辺すべて指定するか、すべて未指定とする
+    CONSTRAINT chk_export_jobs_bbox CHECK (
+        (sw_lat IS NULL AND sw_lng IS NULL AND ne_lat IS NULL AND ne_lng IS NULL)
+        OR (sw_lat IS NOT NULL AND sw_lng IS NOT NULL AND ne_lat IS NOT NULL AND ne_lng IS NOT NULL)
+    )
+);
+
+-- インデックス
+CREATE INDEX idx_export_jobs_status_created_at ON export_jobs(status, created_at);
+CREATE INDEX idx_export_jobs_created_at ON export_jobs(created_at DESC);
+
+-- コメント
+COMMENT ON TABLE export_jobs IS '圃場エクスポートジョブ管理テーブル';
+COMMENT ON COLUMN export_jobs.id IS '主キー';
+COMMENT ON COLUMN export_jobs.format IS '出力形式(geojson/csv/kml/gpkg)';
+COMMENT ON COLUMN export_jobs.status IS 'ステータス(pending/processing/completed/failed)';
+COMMENT ON COLUMN export_jobs.city_code IS '絞り込み条件: 市区町村コード';
+COMMENT ON COLUMN export_jobs.soil_small_code IS '絞り込み条件: 土壌小分類コード';
+COMMENT ON COLUMN export_jobs.sw_lat IS '絞り込み条件: 南西端の緯度';
+COMMENT ON COLUMN export_jobs.sw_lng IS '絞り込み条件: 南西端の経度';
+COMMENT ON COLUMN export_jobs.ne_lat IS '絞り込み条件: 北東端の緯度';
+COMMENT ON COLUMN export_jobs.ne_lng IS '絞り込み条件: 北東端の経度';
+COMMENT ON COLUMN export_jobs.total_records IS '出力したレコード数';
+COMMENT ON COLUMN export_jobs.s3_key IS '出力ファイルのS3キー';
+COMMENT ON COLUMN export_jobs.error_message IS 'エラーメッセージ';
+COMMENT ON COLUMN export_jobs.created_at IS '作成日時';
+COMMENT ON COLUMN export_jobs.started_at IS '処理開始日時';
+COMMENT ON COLUMN export_jobs.completed_at IS '処理完了日時';
@@ -0,0 +1,3 @@
+-- fieldsテーブルからretired_atカラムを削除
+DROP INDEX IF EXISTS idx_fields_active;
+ALTER TABLE fields DROP COLUMN retired_at;
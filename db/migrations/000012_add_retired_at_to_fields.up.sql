@@ -0,0 +1,8 @@
+-- fieldsテーブルにretired_atカラムを追加
+-- 分筆・合筆で役目を終えた圃場は系譜を保持するため削除せず、廃止日時を記録して一覧・集計から除外する
+ALTER TABLE fields ADD COLUMN retired_at TIMESTAMPTZ;
+
+-- 有効な圃場の絞り込み用部分インデックス
+CREATE INDEX idx_fields_active ON fields(id) WHERE retired_at IS NULL;
+
+COMMENT ON COLUMN fields.retired_at IS '廃止日時(分筆・合筆により廃止された場合に設定、NULLの場合は有効)';
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trg_field_overlaps_updated_at ON field_overlaps;
+DROP TABLE IF EXISTS field_overlaps;
@@ -0,0 +1,56 @@
+-- 圃場オーバーラップ検知記録テーブル
+-- 有効な圃場同士のポリゴンの重なりを圃場ペア単位で記録する
+CREATE TABLE field_overlaps (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    -- 重なっている圃場のペア(重複登録を防ぐため field_id_a < field_id_b に正規化する)
+    field_id_a UUID NOT NULL REFERENCES fields(id) ON DELETE CASCADE,
+    field_id_b UUID NOT NULL REFERENCES fields(id) ON DELETE CASCADE,
+
+    -- 重なりの大きさ
+    overlap_area_sqm DOUBLE PRECISION NOT NULL,
+    overlap_ratio DOUBLE PRECISION NOT NULL,
+
+    -- 対応状況
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'accepted', 'clipped')),
+    clipped_field_id UUID REFERENCES fields(id) ON DELETE SET NULL,
+    note TEXT,
+
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ,
+    resolved_by UUID,
+
+    -- 監査
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_field_overlaps_pair_order CHECK (field_id_a < field_id_b),
+    CONSTRAINT uq_field_overlaps_pair UNIQUE (field_id_a, field_id_b)
+);
+
+-- インデックス
+-- field_id_aはユニーク制約のインデックスで検索できるため、field_id_bのみ追加する
+CREATE INDEX idx_field_overlaps_field_id_b ON field_overlaps(field_id_b);
+CREATE INDEX idx_field_overlaps_status_detected_at ON field_overlaps(status, detected_at DESC);
+
+-- コメント
+COMMENT ON TABLE field_overlaps IS 'オーバーラップ検知記録';
+COMMENT ON COLUMN field_overlaps.id IS '主キー';
+COMMENT ON COLUMN field_overlaps.field_id_a IS '重なっている圃場のうちIDが小さい方';
+COMMENT ON COLUMN field_overlaps.field_id_b IS '重なっている圃場のうちIDが大きい方';
+COMMENT ON COLUMN field_overlaps.overlap_area_sqm IS '重なり部分の面積(平方メートル)';
+COMMENT ON COLUMN field_overlaps.overlap_ratio IS '面積が小さい方の圃場に対する重なり面積の割合(0-1)';
+COMMENT ON COLUMN field_overlaps.status IS '対応状況(open: 未対応/accepted: 許容/clipped: クリップで解消)';
+COMMENT ON COLUMN field_overlaps.clipped_field_id IS 'クリップで形状を修正した圃場';
+COMMENT ON COLUMN field_overlaps.note IS '対応時の備考';
+COMMENT ON COLUMN field_overlaps.detected_at IS '最終検知日時';
+COMMENT ON COLUMN field_overlaps.resolved_at IS '対応日時';
+COMMENT ON COLUMN field_overlaps.resolved_by IS '対応者ID';
+COMMENT ON COLUMN field_overlaps.created_at IS '作成日時';
+COMMENT ON COLUMN field_overlaps.updated_at IS '更新日時';
+
+-- updated_at自動更新トリガー
+CREATE TRIGGER trg_field_overlaps_updated_at
+    BEFORE UPDATE ON field_overlaps
+    FOR EACH ROW
+    EXECUTE FUNCTION refresh_updated_at();
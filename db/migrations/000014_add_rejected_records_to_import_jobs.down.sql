@@ -0,0 +1,2 @@
+-- import_jobsテーブルからrejected_recordsカラムを削除
+ALTER TABLE import_jobs DROP COLUMN rejected_records;
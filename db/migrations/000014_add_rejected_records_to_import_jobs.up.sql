@@ -0,0 +1,5 @@
+-- import_jobsテーブルにrejected_recordsカラムを追加
+-- ジオメトリ検証で取り込みを拒否したレコードを、ID・不備の種類・理由とともにバッチごとに追記する
+ALTER TABLE import_jobs ADD COLUMN rejected_records JSONB;
+
+COMMENT ON COLUMN import_jobs.rejected_records IS 'ジオメトリ検証で拒否したレコードのJSON配列([{id, defect, reason}])';
@@ -0,0 +1,13 @@
+-- fields.geometryの型をPOLYGONに戻す
+-- 複数区画の圃場は先頭の区画のみを残す(2番目以降の区画は失われる)
+ALTER TABLE fields DROP COLUMN area_sqm;
+
+ALTER TABLE fields
+    ALTER COLUMN geometry TYPE GEOMETRY(POLYGON, 4326)
+    USING ST_GeometryN(geometry, 1);
+
+ALTER TABLE fields
+    ADD COLUMN area_sqm DOUBLE PRECISION GENERATED ALWAYS AS (ST_Area(geometry::geography)) STORED;
+
+COMMENT ON COLUMN fields.geometry IS 'ポリゴン形状(SRID: 4326 = WGS84)';
+COMMENT ON COLUMN fields.area_sqm IS '面積(平方メートル、自動計算)';
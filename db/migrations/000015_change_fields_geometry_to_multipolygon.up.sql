@@ -0,0 +1,16 @@
+-- fields.geometryの型をPOLYGONからMULTIPOLYGONに変更
+-- 内周リング(池・建物などの除外部分)を持つ圃場や、複数の区画からなる圃場(飛び地)を欠落なく保持する
+-- 既存のPOLYGONはST_Multiで1区画のMULTIPOLYGONに変換する
+
+-- 生成列area_sqmはgeometryに依存しており型変更できないため、一度削除して再作成する
+ALTER TABLE fields DROP COLUMN area_sqm;
+
+ALTER TABLE fields
+    ALTER COLUMN geometry TYPE GEOMETRY(MULTIPOLYGON, 4326)
+    USING ST_Multi(geometry);
+
+ALTER TABLE fields
+    ADD COLUMN area_sqm DOUBLE PRECISION GENERATED ALWAYS AS (ST_Area(geometry::geography)) STORED;
+
+COMMENT ON COLUMN fields.geometry IS 'マルチポリゴン形状(SRID: 4326 = WGS84、穴あき・複数区画を含む)';
+COMMENT ON COLUMN fields.area_sqm IS '面積(平方メートル、自動計算)';
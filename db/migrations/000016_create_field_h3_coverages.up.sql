@@ -0,0 +1,28 @@
+-- 圃場H3被覆テーブル
+-- 圃場ポリゴンが覆うH3セルを解像度ごとに全て保持し、セルごとの面積比率とともに記録する
+-- 重心のセル1つにしか計上されない大きな圃場を、被覆するすべてのセルで集計する被覆モード用
+-- 圃場の削除・廃止はクラスターワーカーが検知して行を削除するため、外部キーは設けない
+CREATE TABLE field_h3_coverages (
+    field_id UUID NOT NULL,
+    resolution INT NOT NULL CHECK (resolution IN (3, 5, 7, 9)),
+    h3_index VARCHAR(15) NOT NULL,
+    area_share DOUBLE PRECISION NOT NULL CHECK (area_share > 0 AND area_share <= 1),
+
+    -- 被覆の計算に使用した圃場のupdated_at(圃場の更新を検知して再計算する)
+    source_updated_at TIMESTAMPTZ NOT NULL,
+    calculated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (field_id, resolution, h3_index)
+);
+
+-- インデックス
+CREATE INDEX idx_field_h3_coverages_resolution_h3_index ON field_h3_coverages(resolution, h3_index);
+
+-- コメント
+COMMENT ON TABLE field_h3_coverages IS '圃場が覆うH3セル(被覆モードのクラスター集計用)';
+COMMENT ON COLUMN field_h3_coverages.field_id IS '圃場ID';
+COMMENT ON COLUMN field_h3_coverages.resolution IS 'H3解像度(3, 5, 7, 9)';
+COMMENT ON COLUMN field_h3_coverages.h3_index IS 'H3インデックス(16進数文字列)';
+COMMENT ON COLUMN field_h3_coverages.area_share IS '圃場の面積のうちこのセルに含まれる割合(解像度ごとの合計は1)';
+COMMENT ON COLUMN field_h3_coverages.source_updated_at IS '計算に使用した圃場の更新日時';
+COMMENT ON COLUMN field_h3_coverages.calculated_at IS '計算日時';
@@ -0,0 +1,2 @@
+-- cluster_resultsテーブルからtotal_area_sqmカラムを削除
+ALTER TABLE cluster_results DROP COLUMN total_area_sqm;
@@ -0,0 +1,5 @@
+-- cluster_resultsテーブルにtotal_area_sqmカラムを追加
+-- 圃場数に加えてクラスター内の圃場の合計面積を保持する(被覆モードではセルに含まれる部分の面積)
+ALTER TABLE cluster_results ADD COLUMN total_area_sqm DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN cluster_results.total_area_sqm IS 'クラスターに含まれる圃場の合計面積(平方メートル)';
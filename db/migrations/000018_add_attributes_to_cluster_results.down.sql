@@ -0,0 +1,5 @@
+-- cluster_resultsテーブルから圃場の属性別の集計カラムを削除
+ALTER TABLE cluster_results
+    DROP COLUMN land_category_counts,
+    DROP COLUMN idle_field_count,
+    DROP COLUMN dominant_soil_large_code;
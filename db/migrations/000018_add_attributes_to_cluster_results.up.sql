@@ -0,0 +1,10 @@
+-- cluster_resultsテーブルに圃場の属性別の集計カラムを追加
+-- 地図上で土地種別(田/畑)の内訳、遊休農地の圃場数、主な土壌を表示するため
+ALTER TABLE cluster_results
+    ADD COLUMN land_category_counts JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN idle_field_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN dominant_soil_large_code VARCHAR(10);
+
+COMMENT ON COLUMN cluster_results.land_category_counts IS '土地種別コードごとの圃場数({"土地種別コード": 圃場数})';
+COMMENT ON COLUMN cluster_results.idle_field_count IS '遊休農地状況が登録された農地台帳を持つ圃場数';
+COMMENT ON COLUMN cluster_results.dominant_soil_large_code IS '合計面積が最大の土壌大分類コード';
@@ -0,0 +1,44 @@
+-- fieldsテーブルのH3インデックスを解像度3, 5, 7, 9の4カラムに戻す
+-- 3, 5, 7, 9以外の解像度のクラスター結果・H3被覆は削除する
+DELETE FROM cluster_results WHERE resolution NOT IN (3, 5, 7, 9);
+ALTER TABLE cluster_results DROP CONSTRAINT cluster_results_resolution_check;
+ALTER TABLE cluster_results ADD CONSTRAINT cluster_results_resolution_check CHECK (resolution IN (3, 5, 7, 9));
+COMMENT ON COLUMN cluster_results.resolution IS 'H3解像度(3, 5, 7, 9)';
+
+DELETE FROM field_h3_coverages WHERE resolution NOT IN (3, 5, 7, 9);
+ALTER TABLE field_h3_coverages DROP CONSTRAINT field_h3_coverages_resolution_check;
+ALTER TABLE field_h3_coverages ADD CONSTRAINT field_h3_coverages_resolution_check CHECK (resolution IN (3, 5, 7, 9));
+COMMENT ON COLUMN field_h3_coverages.resolution IS 'H3解像度(3, 5, 7, 9)';
+
+ALTER TABLE fields
+    ADD COLUMN h3_index_res3 VARCHAR(15),
+    ADD COLUMN h3_index_res5 VARCHAR(15),
+    ADD COLUMN h3_index_res7 VARCHAR(15),
+    ADD COLUMN h3_index_res9 VARCHAR(15);
+
+ALTER TABLE fields DISABLE TRIGGER trg_fields_updated_at;
+
+UPDATE fields
+SET
+    h3_index_res3 = h3_cell_to_parent(h3_index, 3),
+    h3_index_res5 = h3_cell_to_parent(h3_index, 5),
+    h3_index_res7 = h3_cell_to_parent(h3_index, 7),
+    h3_index_res9 = h3_cell_to_parent(h3_index, 9)
+WHERE h3_index IS NOT NULL;
+
+ALTER TABLE fields ENABLE TRIGGER trg_fields_updated_at;
+
+DROP INDEX IF EXISTS idx_fields_h3_index;
+ALTER TABLE fields DROP COLUMN h3_index;
+
+CREATE INDEX idx_fields_h3_res3 ON fields(h3_index_res3) WHERE h3_index_res3 IS NOT NULL;
+CREATE INDEX idx_fields_h3_res5 ON fields(h3_index_res5) WHERE h3_index_res5 IS NOT NULL;
+CREATE INDEX idx_fields_h3_res7 ON fields(h3_index_res7) WHERE h3_index_res7 IS NOT NULL;
+CREATE INDEX idx_fields_h3_res9 ON fields(h3_index_res9) WHERE h3_index_res9 IS NOT NULL;
+
+COMMENT ON COLUMN fields.h3_index_res3 IS 'H3インデックス解像度3(約100km - 地方クラスタリング)';
+COMMENT ON COLUMN fields.h3_index_res5 IS 'H3インデックス解像度5(約10km - 都道府県クラスタリング)';
+COMMENT ON COLUMN fields.h3_index_res7 IS 'H3インデックス解像度7(約1km - 市区町村クラスタリング)';
+COMMENT ON COLUMN fields.h3_index_res9 IS 'H3インデックス解像度9(約100m - 詳細クラスタリング)';
+
+DROP FUNCTION IF EXISTS h3_cell_to_parent(TEXT, INT);
@@ -0,0 +1,66 @@
+-- fieldsテーブルのH3インデックスを最も詳細な解像度(res15)のセル1つに置き換える
+-- クラスタリングの各解像度のセルはh3_cell_to_parentで親セルとして求めるため、
+-- クラスター計算の解像度を変更してもfieldsテーブルの変更は不要になる
+
+-- H3インデックス(16進数文字列)から指定解像度の親セルを求める(h3-pgのh3_cell_to_parent相当)
+-- 解像度のビット(52-55)を書き換え、指定解像度より下の桁を未使用(7)で埋める
+-- 指定解像度がセルの解像度より詳細な場合はNULLを返す
+CREATE FUNCTION h3_cell_to_parent(cell TEXT, resolution INT)
+RETURNS TEXT
+LANGUAGE sql
+IMMUTABLE STRICT PARALLEL SAFE
+AS $$
+    SELECT CASE
+        WHEN resolution < 0 OR resolution > ((bits >> 52) & 15) THEN NULL
+        ELSE to_hex(
+            (bits & ~(15::BIGINT << 52))
+            | (resolution::BIGINT << 52)
+            | ((1::BIGINT << (3 * (15 - resolution))) - 1)
+        )
+    END
+    FROM (SELECT ('x' || lpad(cell, 16, '0'))::BIT(64)::BIGINT AS bits) AS parsed
+$$;
+
+COMMENT ON FUNCTION h3_cell_to_parent(TEXT, INT) IS 'H3インデックスの指定解像度の親セルを返す';
+
+-- 16進数文字列の大小がセルの数値の大小と一致するようにCの照合順序とする
+-- (子孫セルの範囲をBETWEENで検索するため)
+ALTER TABLE fields ADD COLUMN h3_index VARCHAR(15) COLLATE "C";
+
+-- 既存の圃場は重心を持たないため、res9のセルの中心の子セルで埋める
+-- res9以下の親セルは元の値と一致する。正確なres15のセルはcentroid-backfillで再計算する
+-- 内容は変わらないため、updated_atを更新しないようトリガーを止める
+ALTER TABLE fields DISABLE TRIGGER trg_fields_updated_at;
+
+UPDATE fields
+SET h3_index = to_hex(
+    ((('x' || lpad(h3_index_res9, 16, '0'))::BIT(64)::BIGINT & ~(15::BIGINT << 52)) | (15::BIGINT << 52))
+    & ~((1::BIGINT << 18) - 1)
+)
+WHERE h3_index_res9 IS NOT NULL;
+
+ALTER TABLE fields ENABLE TRIGGER trg_fields_updated_at;
+
+DROP INDEX IF EXISTS idx_fields_h3_res3;
+DROP INDEX IF EXISTS idx_fields_h3_res5;
+DROP INDEX IF EXISTS idx_fields_h3_res7;
+DROP INDEX IF EXISTS idx_fields_h3_res9;
+
+ALTER TABLE fields
+    DROP COLUMN h3_index_res3,
+    DROP COLUMN h3_index_res5,
+    DROP COLUMN h3_index_res7,
+    DROP COLUMN h3_index_res9;
+
+CREATE INDEX idx_fields_h3_index ON fields(h3_index) WHERE h3_index IS NOT NULL;
+
+COMMENT ON COLUMN fields.h3_index IS 'H3インデックス解像度15(各解像度のセルはh3_cell_to_parentで求める)';
+
+-- クラスター結果・H3被覆の解像度を設定で変更できるようにする
+ALTER TABLE cluster_results DROP CONSTRAINT cluster_results_resolution_check;
+ALTER TABLE cluster_results ADD CONSTRAINT cluster_results_resolution_check CHECK (resolution BETWEEN 0 AND 15);
+COMMENT ON COLUMN cluster_results.resolution IS 'H3解像度(0-15、CLUSTER_RESOLUTIONSで設定)';
+
+ALTER TABLE field_h3_coverages DROP CONSTRAINT field_h3_coverages_resolution_check;
+ALTER TABLE field_h3_coverages ADD CONSTRAINT field_h3_coverages_resolution_check CHECK (resolution BETWEEN 0 AND 15);
+COMMENT ON COLUMN field_h3_coverages.resolution IS 'H3解像度(0-15、CLUSTER_RESOLUTIONSで設定)';
@@ -0,0 +1,2 @@
+-- cluster_results.h3_indexの照合順序をデフォルトに戻す
+ALTER TABLE cluster_results ALTER COLUMN h3_index TYPE VARCHAR(15) COLLATE "default";
@@ -0,0 +1,4 @@
+-- 表示範囲のクラスター結果をH3インデックスの範囲検索で取得するため、
+-- 16進数文字列の大小がセルの数値の大小と一致するようにCの照合順序とする
+-- UNIQUE(resolution, h3_index)のインデックスは型の変更で再作成され、範囲検索に使われる
+ALTER TABLE cluster_results ALTER COLUMN h3_index TYPE VARCHAR(15) COLLATE "C";
@@ -0,0 +1,2 @@
+-- 保留中クラスタージョブの一意制約を削除
+DROP INDEX IF EXISTS idx_cluster_jobs_single_pending;
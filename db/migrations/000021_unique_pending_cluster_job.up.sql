@@ -0,0 +1,17 @@
+-- 保留中のクラスタージョブを1件に限定する
+-- エンキュー時はこのインデックスを使ったUPSERTで保留中ジョブへ影響セルを統合するため、
+-- 同時実行されたインポートからのエンキューも同じジョブに集約される
+
+-- 既存の保留中ジョブが複数ある場合は最古のものに集約し、全範囲再計算とする
+UPDATE cluster_jobs
+SET
+    priority = (SELECT MAX(priority) FROM cluster_jobs WHERE status = 'pending'),
+    affected_h3_cells = NULL
+WHERE id = (SELECT id FROM cluster_jobs WHERE status = 'pending' ORDER BY created_at LIMIT 1)
+  AND (SELECT COUNT(*) FROM cluster_jobs WHERE status = 'pending') > 1;
+
+DELETE FROM cluster_jobs
+WHERE status = 'pending'
+  AND id <> (SELECT id FROM cluster_jobs WHERE status = 'pending' ORDER BY created_at LIMIT 1);
+
+CREATE UNIQUE INDEX idx_cluster_jobs_single_pending ON cluster_jobs(status) WHERE status = 'pending';
@@ -0,0 +1,7 @@
+-- クラスタージョブのリース情報を削除
+DROP INDEX IF EXISTS idx_cluster_jobs_processing_heartbeat;
+
+ALTER TABLE cluster_jobs
+    DROP COLUMN attempts,
+    DROP COLUMN heartbeat_at,
+    DROP COLUMN worker_id;
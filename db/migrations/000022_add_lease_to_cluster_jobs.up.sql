@@ -0,0 +1,20 @@
+-- クラスタージョブにリース情報を追加する
+-- ワーカーはジョブ取得時に自身のIDを記録し、処理中は定期的にheartbeat_atを更新する
+-- heartbeat_atが一定時間更新されないジョブはワーカーが異常終了したものとみなし、再実行または失敗にする
+ALTER TABLE cluster_jobs
+    ADD COLUMN worker_id VARCHAR(255),
+    ADD COLUMN heartbeat_at TIMESTAMPTZ,
+    ADD COLUMN attempts INT NOT NULL DEFAULT 0;
+
+-- 既存の処理中ジョブは開始日時を最終ハートビートとみなす
+UPDATE cluster_jobs
+SET
+    heartbeat_at = COALESCE(started_at, created_at),
+    attempts = 1
+WHERE status = 'processing';
+
+CREATE INDEX idx_cluster_jobs_processing_heartbeat ON cluster_jobs(heartbeat_at) WHERE status = 'processing';
+
+COMMENT ON COLUMN cluster_jobs.worker_id IS '処理中のワーカーID';
+COMMENT ON COLUMN cluster_jobs.heartbeat_at IS '処理中のワーカーの最終ハートビート日時';
+COMMENT ON COLUMN cluster_jobs.attempts IS '実行回数(リース期限切れで再実行されるたびに増える)';
@@ -0,0 +1,3 @@
+-- 保留中クラスタージョブの通知を削除
+DROP TRIGGER IF EXISTS trg_notify_cluster_job_pending ON cluster_jobs;
+DROP FUNCTION IF EXISTS notify_cluster_job_pending();
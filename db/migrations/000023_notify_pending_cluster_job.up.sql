@@ -0,0 +1,18 @@
+-- 保留中のクラスタージョブが登録・統合・再キューされたときにワーカーへ通知する
+-- ワーカーはcluster_jobs_pendingチャネルをLISTENし、通知を受けてジョブを取得する
+-- ペイロードにはジョブIDを載せるが、ワーカーは通知をきっかけとしてのみ扱う
+
+-- 保留中ジョブ通知関数
+CREATE FUNCTION notify_cluster_job_pending() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('cluster_jobs_pending', NEW.id::TEXT);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- 保留中ジョブ通知トリガー
+CREATE TRIGGER trg_notify_cluster_job_pending
+    AFTER INSERT OR UPDATE ON cluster_jobs
+    FOR EACH ROW
+    WHEN (NEW.status = 'pending')
+    EXECUTE FUNCTION notify_cluster_job_pending();
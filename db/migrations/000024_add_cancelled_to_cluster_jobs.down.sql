@@ -0,0 +1,16 @@
+-- クラスタージョブの取り消し済みステータスを削除
+-- 取り消し済みのジョブは失敗として残す
+DROP INDEX IF EXISTS idx_cluster_jobs_created_at;
+
+UPDATE cluster_jobs
+SET
+    status = 'failed',
+    error_message = COALESCE(error_message, 'ジョブが取り消されました')
+WHERE status = 'cancelled';
+
+ALTER TABLE cluster_jobs DROP CONSTRAINT cluster_jobs_status_check;
+ALTER TABLE cluster_jobs ADD CONSTRAINT cluster_jobs_status_check
+    CHECK (status IN ('pending', 'processing', 'completed', 'failed'));
+
+COMMENT ON COLUMN cluster_jobs.status IS 'ジョブステータス(pending, processing, completed, failed)';
+COMMENT ON COLUMN cluster_jobs.completed_at IS 'ジョブ完了日時';
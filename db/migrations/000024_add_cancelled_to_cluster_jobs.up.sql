@@ -0,0 +1,11 @@
+-- クラスタージョブに取り消し済み(cancelled)ステータスを追加する
+-- 保留中のジョブはAPIから取り消すことができ、取り消したジョブは履歴として残す
+ALTER TABLE cluster_jobs DROP CONSTRAINT cluster_jobs_status_check;
+ALTER TABLE cluster_jobs ADD CONSTRAINT cluster_jobs_status_check
+    CHECK (status IN ('pending', 'processing', 'completed', 'failed', 'cancelled'));
+
+-- ジョブ履歴を作成日時の新しい順に取得するためのインデックス
+CREATE INDEX idx_cluster_jobs_created_at ON cluster_jobs(created_at DESC);
+
+COMMENT ON COLUMN cluster_jobs.status IS 'ジョブステータス(pending, processing, completed, failed, cancelled)';
+COMMENT ON COLUMN cluster_jobs.completed_at IS 'ジョブ完了日時(失敗・取り消し時はその日時)';
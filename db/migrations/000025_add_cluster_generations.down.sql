@@ -0,0 +1,11 @@
+-- 有効な世代以外のクラスター結果を削除し、世代の管理をやめる
+DELETE FROM cluster_results
+WHERE generation <> (SELECT MAX(generation) FROM cluster_generations WHERE activated_at IS NOT NULL);
+
+ALTER TABLE cluster_results DROP CONSTRAINT cluster_results_generation_resolution_h3_index_key;
+ALTER TABLE cluster_results ADD CONSTRAINT cluster_results_resolution_h3_index_key
+    UNIQUE (resolution, h3_index);
+
+ALTER TABLE cluster_results DROP COLUMN generation;
+
+DROP TABLE IF EXISTS cluster_generations;
@@ -0,0 +1,31 @@
+-- クラスター結果を世代(スナップショット)単位で管理する
+-- 全範囲再計算は新しい世代に書き込み、全解像度の計算が終わってから有効化する
+-- 有効な世代は有効化済みの世代のうち番号が最大のもので、有効化は1行の更新のため読み取り側から途中の状態は見えない
+CREATE TABLE cluster_generations (
+    generation BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    activated_at TIMESTAMPTZ
+);
+
+-- 有効な世代を取得するためのインデックス
+CREATE INDEX idx_cluster_generations_activated ON cluster_generations(generation DESC) WHERE activated_at IS NOT NULL;
+
+COMMENT ON TABLE cluster_generations IS 'クラスター結果の世代';
+COMMENT ON COLUMN cluster_generations.generation IS '世代番号(作成順に増える)';
+COMMENT ON COLUMN cluster_generations.created_at IS '作成日時(全範囲再計算の開始日時)';
+COMMENT ON COLUMN cluster_generations.activated_at IS '有効化日時(NULLの場合は計算中または破棄待ち)';
+
+-- 既存のクラスター結果は最初の世代(1)として有効化する
+INSERT INTO cluster_generations (activated_at) VALUES (NOW());
+
+ALTER TABLE cluster_results ADD COLUMN generation BIGINT NOT NULL DEFAULT 1
+    REFERENCES cluster_generations(generation) ON DELETE CASCADE;
+ALTER TABLE cluster_results ALTER COLUMN generation DROP DEFAULT;
+
+-- 解像度+H3インデックスの一意性は世代ごととする
+-- 範囲検索は世代・解像度で絞り込んだ上でh3_indexの範囲を検索する
+ALTER TABLE cluster_results DROP CONSTRAINT cluster_results_resolution_h3_index_key;
+ALTER TABLE cluster_results ADD CONSTRAINT cluster_results_generation_resolution_h3_index_key
+    UNIQUE (generation, resolution, h3_index);
+
+COMMENT ON COLUMN cluster_results.generation IS '世代番号';
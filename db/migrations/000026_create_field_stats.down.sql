@@ -0,0 +1,2 @@
+DROP MATERIALIZED VIEW IF EXISTS city_soil_stats;
+DROP MATERIALIZED VIEW IF EXISTS city_field_stats;
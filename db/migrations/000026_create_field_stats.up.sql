@@ -0,0 +1,75 @@
+-- 市区町村ごとの圃場統計(マテリアライズドビュー)
+-- 管理画面の集計表示用。集計に時間がかかるため、クラスターワーカーがジョブの処理後に再集計する
+-- 田・畑の面積は、圃場ごとに面積が最大の農地台帳の土地種別を主な土地種別とし、土地種別名で判定する
+-- 都道府県の統計は市区町村コードの上2桁で市区町村の統計を合算して求める
+CREATE MATERIALIZED VIEW city_field_stats AS
+WITH primary_categories AS (
+    SELECT DISTINCT ON (r.field_id)
+        r.field_id,
+        lc.name AS land_category_name
+    FROM field_land_registries r
+    JOIN land_categories lc ON lc.code = r.land_category_code
+    ORDER BY r.field_id, r.area_sqm DESC NULLS LAST, r.land_category_code
+),
+targets AS (
+    SELECT
+        f.city_code,
+        f.area_sqm,
+        pc.land_category_name,
+        EXISTS (
+            SELECT 1
+            FROM field_land_registries r
+            WHERE r.field_id = f.id AND r.idle_land_status_code IS NOT NULL
+        ) AS is_idle
+    FROM fields f
+    LEFT JOIN primary_categories pc ON pc.field_id = f.id
+    WHERE f.retired_at IS NULL
+)
+SELECT
+    t.city_code,
+    COUNT(*)::INT AS field_count,
+    COALESCE(SUM(t.area_sqm), 0)::DOUBLE PRECISION AS total_area_sqm,
+    COALESCE(SUM(t.area_sqm) FILTER (WHERE t.land_category_name = '田'), 0)::DOUBLE PRECISION AS paddy_area_sqm,
+    COALESCE(SUM(t.area_sqm) FILTER (WHERE t.land_category_name = '畑'), 0)::DOUBLE PRECISION AS upland_area_sqm,
+    COUNT(*) FILTER (WHERE t.is_idle)::INT AS idle_field_count,
+    COALESCE(SUM(t.area_sqm) FILTER (WHERE t.is_idle), 0)::DOUBLE PRECISION AS idle_area_sqm,
+    NOW()::TIMESTAMPTZ AS refreshed_at
+FROM targets t
+GROUP BY t.city_code;
+
+-- REFRESH MATERIALIZED VIEW CONCURRENTLYにはユニークインデックスが必要
+CREATE UNIQUE INDEX idx_city_field_stats_city_code ON city_field_stats(city_code);
+-- 都道府県単位の合算用
+CREATE INDEX idx_city_field_stats_prefecture ON city_field_stats(LEFT(city_code, 2));
+
+COMMENT ON MATERIALIZED VIEW city_field_stats IS '市区町村ごとの圃場統計';
+COMMENT ON COLUMN city_field_stats.city_code IS '市区町村コード';
+COMMENT ON COLUMN city_field_stats.field_count IS '圃場数';
+COMMENT ON COLUMN city_field_stats.total_area_sqm IS '合計面積(平方メートル)';
+COMMENT ON COLUMN city_field_stats.paddy_area_sqm IS '主な土地種別が田の圃場の合計面積(平方メートル)';
+COMMENT ON COLUMN city_field_stats.upland_area_sqm IS '主な土地種別が畑の圃場の合計面積(平方メートル)';
+COMMENT ON COLUMN city_field_stats.idle_field_count IS '遊休農地状況が登録された農地台帳を持つ圃場数';
+COMMENT ON COLUMN city_field_stats.idle_area_sqm IS '遊休農地状況が登録された農地台帳を持つ圃場の合計面積(平方メートル)';
+COMMENT ON COLUMN city_field_stats.refreshed_at IS '集計日時';
+
+-- 市区町村・土壌大分類ごとの圃場統計(マテリアライズドビュー)
+-- 土壌が未登録の圃場は含めない
+CREATE MATERIALIZED VIEW city_soil_stats AS
+SELECT
+    f.city_code,
+    s.large_code AS soil_large_code,
+    COUNT(*)::INT AS field_count,
+    COALESCE(SUM(f.area_sqm), 0)::DOUBLE PRECISION AS total_area_sqm
+FROM fields f
+JOIN soil_types s ON s.id = f.soil_type_id
+WHERE f.retired_at IS NULL
+GROUP BY f.city_code, s.large_code;
+
+CREATE UNIQUE INDEX idx_city_soil_stats_city_code_soil ON city_soil_stats(city_code, soil_large_code);
+CREATE INDEX idx_city_soil_stats_prefecture ON city_soil_stats(LEFT(city_code, 2));
+
+COMMENT ON MATERIALIZED VIEW city_soil_stats IS '市区町村・土壌大分類ごとの圃場統計';
+COMMENT ON COLUMN city_soil_stats.city_code IS '市区町村コード';
+COMMENT ON COLUMN city_soil_stats.soil_large_code IS '土壌大分類コード';
+COMMENT ON COLUMN city_soil_stats.field_count IS '圃場数';
+COMMENT ON COLUMN city_soil_stats.total_area_sqm IS '合計面積(平方メートル)';
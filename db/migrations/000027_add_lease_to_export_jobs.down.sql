@@ -0,0 +1,7 @@
+-- エクスポートジョブのリース情報を削除
+DROP INDEX IF EXISTS idx_export_jobs_processing_heartbeat;
+
+ALTER TABLE export_jobs
+    DROP COLUMN attempts,
+    DROP COLUMN heartbeat_at,
+    DROP COLUMN worker_id;
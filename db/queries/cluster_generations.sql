@@ -0,0 +1,61 @@
+-- name: GetActiveClusterGeneration :one
+-- 有効な世代(有効化済みの世代のうち番号が最大のもの)を取得
+SELECT generation
+FROM cluster_generations
+WHERE activated_at IS NOT NULL
+ORDER BY generation DESC
+LIMIT 1;
+
+-- name: ListLiveClusterGenerations :many
+-- 有効な世代と、それより新しい計算中の世代を取得(差分更新の書き込み先)
+SELECT generation
+FROM cluster_generations
+WHERE generation >= (
+    SELECT MAX(g.generation)
+    FROM cluster_generations g
+    WHERE g.activated_at IS NOT NULL
+)
+ORDER BY generation;
+
+-- name: CreateClusterGeneration :one
+-- 全範囲再計算の書き込み先となる世代を作成
+INSERT INTO cluster_generations DEFAULT VALUES
+RETURNING generation;
+
+-- name: ActivateClusterGeneration :execrows
+-- 計算が完了した世代を有効化
+-- より新しい世代が既に有効な場合は有効化しない(0件)
+UPDATE cluster_generations
+SET activated_at = NOW()
+WHERE generation = @generation
+  AND activated_at IS NULL
+  AND generation > (
+      SELECT COALESCE(MAX(g.generation), 0)
+      FROM cluster_generations g
+      WHERE g.activated_at IS NOT NULL
+  );
+
+-- name: DeleteClusterGeneration :exec
+-- 有効化していない世代を削除(世代のクラスター結果も削除される)
+DELETE FROM cluster_generations
+WHERE generation = $1 AND activated_at IS NULL;
+
+-- name: DeleteUnusedClusterGenerations :execrows
+-- 使用されなくなった世代を削除(世代のクラスター結果も削除される)
+-- 有効な世代と、切り替え直後に読み取り中のリクエストのため直前に有効だった世代は残す
+-- 有効化されていない世代は、作成からstale_seconds秒を過ぎたもの(異常終了したワーカーの計算途中の世代)のみ削除する
+WITH active AS (
+    SELECT MAX(generation) AS generation
+    FROM cluster_generations
+    WHERE activated_at IS NOT NULL
+),
+previous AS (
+    SELECT MAX(g.generation) AS generation
+    FROM cluster_generations g, active a
+    WHERE g.activated_at IS NOT NULL AND g.generation < a.generation
+)
+DELETE FROM cluster_generations g
+USING active a, previous p
+WHERE
+    (g.activated_at IS NOT NULL AND g.generation < p.generation)
+    OR (g.activated_at IS NULL AND g.created_at < NOW() - make_interval(secs => @stale_seconds::INT));
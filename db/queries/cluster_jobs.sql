@@ -1,61 +1,169 @@
--- name: CreateClusterJob :one
--- クラスタージョブを作成
-INSERT INTO cluster_jobs (
+-- name: EnqueueClusterJob :one
+-- クラスタージョブをエンキューする
+-- 保留中ジョブは部分ユニークインデックスで1件に限定されており、既に存在する場合は新規作成せずに統合する
+-- 統合時は優先度の高い方を採用し、どちらかが全範囲再計算(NULL)か統合後のセル数が上限を超える場合は全範囲再計算に昇格する
+INSERT INTO cluster_jobs AS cj (
     id,
     status,
     priority,
+    affected_h3_cells,
     created_at
 )
-VALUES ($1, 'pending', $2, NOW())
-RETURNING *;
+VALUES (@id, 'pending', @priority, @affected_h3_cells, NOW())
+ON CONFLICT (status) WHERE status = 'pending'
+DO UPDATE SET
+    priority = GREATEST(cj.priority, EXCLUDED.priority),
+    affected_h3_cells = CASE
+        WHEN cj.affected_h3_cells IS NULL OR EXCLUDED.affected_h3_cells IS NULL THEN NULL
+        WHEN (
+            SELECT COUNT(DISTINCT c)
+            FROM unnest(cj.affected_h3_cells || EXCLUDED.affected_h3_cells) AS c
+        ) > @max_affected_cells::INT THEN NULL
+        ELSE ARRAY(
+            SELECT DISTINCT c
+            FROM unnest(cj.affected_h3_cells || EXCLUDED.affected_h3_cells) AS c
+            ORDER BY c
+        )
+    END
+RETURNING
+    cj.id,
+    cj.status,
+    cj.priority,
+    cj.affected_h3_cells,
+    cj.created_at,
+    (cj.id <> @id) AS merged;
 
 -- name: GetClusterJob :one
 -- クラスタージョブをIDで取得
+SELECT * FROM cluster_jobs WHERE id = $1;
+
+-- name: ListClusterJobs :many
+-- 条件を指定してクラスタージョブの履歴を作成日時の新しい順に取得
+-- 各条件はNULLの場合に無視される。影響セルは件数のみを取得する
 SELECT
     id,
     status,
     priority,
+    (affected_h3_cells IS NULL) AS full_recalculation,
+    COALESCE(cardinality(affected_h3_cells), 0)::INT AS affected_cell_count,
+    attempts,
+    worker_id,
     created_at,
     started_at,
     completed_at,
     error_message
 FROM cluster_jobs
-WHERE id = $1;
+WHERE
+    (sqlc.narg(status)::VARCHAR IS NULL OR status = sqlc.narg(status)::VARCHAR)
+    AND (sqlc.narg(created_from)::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg(created_from)::TIMESTAMPTZ)
+    AND (sqlc.narg(created_to)::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg(created_to)::TIMESTAMPTZ)
+ORDER BY created_at DESC, id
+LIMIT sqlc.arg(row_limit)
+OFFSET sqlc.arg(row_offset);
 
--- name: GetPendingClusterJobs :many
--- 保留中のジョブを優先度順に取得(排他ロック)
-SELECT
-    id,
-    status,
-    priority,
-    created_at,
-    started_at,
-    completed_at,
-    error_message
+-- name: CountClusterJobs :one
+-- 条件に一致するクラスタージョブの総数を取得(ListClusterJobsと同一条件)
+SELECT COUNT(*)
 FROM cluster_jobs
-WHERE status = 'pending'
-ORDER BY priority DESC, created_at
-LIMIT $1
-FOR UPDATE SKIP LOCKED;
+WHERE
+    (sqlc.narg(status)::VARCHAR IS NULL OR status = sqlc.narg(status)::VARCHAR)
+    AND (sqlc.narg(created_from)::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg(created_from)::TIMESTAMPTZ)
+    AND (sqlc.narg(created_to)::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg(created_to)::TIMESTAMPTZ);
 
--- name: UpdateClusterJobToProcessing :exec
--- ジョブを処理中に更新
+-- name: CancelClusterJob :execrows
+-- 保留中のジョブを取り消し済みに更新
+-- 既にワーカーが取得したジョブは更新しない
+UPDATE cluster_jobs
+SET
+    status = 'cancelled',
+    completed_at = NOW()
+WHERE id = $1 AND status = 'pending';
+
+-- name: ClaimClusterJob :one
+-- 最も優先度の高い保留中ジョブを処理中に更新し、指定ワーカーのリースとして取得
+-- 他のワーカーやエンキューがロック中のジョブはスキップする
 UPDATE cluster_jobs
 SET
     status = 'processing',
-    started_at = NOW()
-WHERE id = $1;
+    started_at = NOW(),
+    worker_id = @worker_id,
+    heartbeat_at = NOW(),
+    attempts = attempts + 1
+WHERE id = (
+    SELECT id FROM cluster_jobs
+    WHERE status = 'pending'
+    ORDER BY priority DESC, created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
 
--- name: UpdateClusterJobToCompleted :exec
--- ジョブを完了に更新
+-- name: HeartbeatClusterJob :execrows
+-- 指定ワーカーがリースを保持している処理中ジョブのハートビートを更新
+UPDATE cluster_jobs
+SET heartbeat_at = NOW()
+WHERE id = @id AND worker_id = @worker_id AND status = 'processing';
+
+-- name: UpdateClusterJobToCompleted :execrows
+-- 指定ワーカーがリースを保持している処理中ジョブを完了に更新
 UPDATE cluster_jobs
 SET
     status = 'completed',
     completed_at = NOW()
+WHERE id = @id AND worker_id = @worker_id AND status = 'processing';
+
+-- name: UpdateClusterJobToFailed :execrows
+-- 指定ワーカーがリースを保持している処理中ジョブを失敗に更新
+UPDATE cluster_jobs
+SET
+    status = 'failed',
+    completed_at = NOW(),
+    error_message = @error_message
+WHERE id = @id AND worker_id = @worker_id AND status = 'processing';
+
+-- name: GetExpiredClusterJobs :many
+-- ハートビートがリース期間を超えて途絶えた処理中ジョブを取得(排他ロック)
+SELECT * FROM cluster_jobs
+WHERE status = 'processing'
+  AND heartbeat_at < NOW() - make_interval(secs => @lease_seconds::INT)
+ORDER BY heartbeat_at
+FOR UPDATE SKIP LOCKED;
+
+-- name: RequeueClusterJob :exec
+-- 処理中のジョブを保留中に戻し、リースを解放
+UPDATE cluster_jobs
+SET
+    status = 'pending',
+    started_at = NULL,
+    worker_id = NULL,
+    heartbeat_at = NULL
 WHERE id = $1;
 
--- name: UpdateClusterJobToFailed :exec
--- ジョブを失敗に更新
+-- name: MergeIntoPendingClusterJob :one
+-- 指定ジョブの影響セルと優先度を保留中ジョブに統合し、統合先のジョブIDを返す
+-- 統合の規則はEnqueueClusterJobと同じで、実行回数は多い方を引き継ぐ
+UPDATE cluster_jobs AS cj
+SET
+    priority = GREATEST(cj.priority, src.priority),
+    affected_h3_cells = CASE
+        WHEN cj.affected_h3_cells IS NULL OR src.affected_h3_cells IS NULL THEN NULL
+        WHEN (
+            SELECT COUNT(DISTINCT c)
+            FROM unnest(cj.affected_h3_cells || src.affected_h3_cells) AS c
+        ) > @max_affected_cells::INT THEN NULL
+        ELSE ARRAY(
+            SELECT DISTINCT c
+            FROM unnest(cj.affected_h3_cells || src.affected_h3_cells) AS c
+            ORDER BY c
+        )
+    END,
+    attempts = GREATEST(cj.attempts, src.attempts)
+FROM cluster_jobs AS src
+WHERE cj.status = 'pending' AND src.id = @id
+RETURNING cj.id;
+
+-- name: UpdateExpiredClusterJobToFailed :exec
+-- リース期限切れの処理中ジョブを失敗に更新
 UPDATE cluster_jobs
 SET
     status = 'failed',
@@ -70,41 +178,12 @@ SELECT EXISTS(
     WHERE status IN ('pending', 'processing')
 ) AS has_job;
 
--- name: DeleteOldCompletedJobs :exec
--- 7日以上前に完了したジョブを削除
+-- name: DeleteOldCompletedJobs :execrows
+-- 保持期間を過ぎた完了済みジョブを削除
 DELETE FROM cluster_jobs
-WHERE status = 'completed' AND completed_at < NOW() - INTERVAL '7 days';
+WHERE status = 'completed' AND completed_at < NOW() - make_interval(secs => @retention_seconds::INT);
 
--- name: DeleteOldFailedJobs :exec
--- 30日以上前に失敗したジョブを削除
+-- name: DeleteOldFailedJobs :execrows
+-- 保持期間を過ぎた失敗・取り消し済みジョブを削除
 DELETE FROM cluster_jobs
-WHERE status = 'failed' AND completed_at < NOW() - INTERVAL '30 days';
-
--- name: CreateClusterJobWithAffectedCells :one
--- 影響セル情報付きでクラスタージョブを作成
-INSERT INTO cluster_jobs (
-    id,
-    status,
-    priority,
-    affected_h3_cells,
-    created_at
-)
-VALUES ($1, 'pending', $2, $3, NOW())
-RETURNING *;
-
--- name: GetPendingClusterJobsWithAffectedCells :many
--- 保留中のジョブを影響セル情報付きで優先度順に取得(排他ロック)
-SELECT
-    id,
-    status,
-    priority,
-    affected_h3_cells,
-    created_at,
-    started_at,
-    completed_at,
-    error_message
-FROM cluster_jobs
-WHERE status = 'pending'
-ORDER BY priority DESC, created_at
-LIMIT $1
-FOR UPDATE SKIP LOCKED;
+WHERE status IN ('failed', 'cancelled') AND completed_at < NOW() - make_interval(secs => @retention_seconds::INT);
@@ -1,118 +1,346 @@
--- name: GetClusterResults :many
--- 指定解像度のクラスター結果を取得
+-- name: GetClusterResultsInRanges :many
+-- 指定世代・解像度のクラスター結果のうち、指定範囲のH3インデックスのものを取得
+-- lower_bounds/upper_boundsは表示範囲を覆うセルの指定解像度の子孫セルの最小値・最大値で、h3_indexの範囲検索で絞り込む
 SELECT
-    id,
-    resolution,
-    h3_index,
-    field_count,
-    center_lat,
-    center_lng,
-    calculated_at
-FROM cluster_results
-WHERE resolution = $1
-ORDER BY h3_index;
+    cr.id,
+    cr.resolution,
+    cr.h3_index,
+    cr.field_count,
+    cr.center_lat,
+    cr.center_lng,
+    cr.calculated_at,
+    cr.total_area_sqm,
+    cr.land_category_counts,
+    cr.idle_field_count,
+    cr.dominant_soil_large_code,
+    cr.generation
+FROM cluster_results cr
+JOIN unnest(@lower_bounds::TEXT[], @upper_bounds::TEXT[]) AS b(lower_bound, upper_bound)
+    ON cr.h3_index BETWEEN b.lower_bound AND b.upper_bound
+WHERE cr.generation = @generation AND cr.resolution = @resolution
+ORDER BY cr.h3_index;
 
 -- name: UpsertClusterResult :exec
--- クラスター結果をUPSERT
+-- 指定世代のクラスター結果をUPSERT
 INSERT INTO cluster_results (
     id,
+    generation,
     resolution,
     h3_index,
     field_count,
     center_lat,
     center_lng,
+    total_area_sqm,
+    land_category_counts,
+    idle_field_count,
+    dominant_soil_large_code,
     calculated_at
 )
-VALUES ($1, $2, $3, $4, $5, $6, NOW())
-ON CONFLICT (resolution, h3_index)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
+ON CONFLICT (generation, resolution, h3_index)
 DO UPDATE SET
     field_count = EXCLUDED.field_count,
     center_lat = EXCLUDED.center_lat,
     center_lng = EXCLUDED.center_lng,
+    total_area_sqm = EXCLUDED.total_area_sqm,
+    land_category_counts = EXCLUDED.land_category_counts,
+    idle_field_count = EXCLUDED.idle_field_count,
+    dominant_soil_large_code = EXCLUDED.dominant_soil_large_code,
     calculated_at = NOW();
 
--- name: DeleteClusterResultsByResolution :exec
--- 指定解像度のクラスター結果を全削除
-DELETE FROM cluster_results
-WHERE resolution = $1;
-
--- name: DeleteAllClusterResults :exec
--- 全クラスター結果を削除
-DELETE FROM cluster_results;
-
--- name: AggregateClustersByRes3 :many
--- H3解像度3でfieldsを集計
+-- name: AggregateClustersByH3 :many
+-- 指定解像度で有効なfieldsを重心のH3セルごとに集計
+-- 重心のセルはfields.h3_index(解像度15)の指定解像度の親セルとする
 SELECT
-    h3_index_res3 AS h3_index,
-    COUNT(*)::INT AS field_count
+    h3_cell_to_parent(h3_index, @resolution::INT)::TEXT AS h3_index,
+    COUNT(*)::INT AS field_count,
+    COALESCE(SUM(area_sqm), 0)::DOUBLE PRECISION AS total_area_sqm
 FROM fields
-WHERE h3_index_res3 IS NOT NULL
-GROUP BY h3_index_res3;
+WHERE h3_index IS NOT NULL AND retired_at IS NULL
+GROUP BY h3_cell_to_parent(h3_index, @resolution::INT);
 
--- name: AggregateClustersByRes5 :many
--- H3解像度5でfieldsを集計
+-- name: AggregateClustersByH3ForCells :many
+-- 指定H3セルのみ有効なfieldsを集計(差分更新用)
+-- lower_bounds/upper_boundsは各セルの解像度15の子孫セルの最小値・最大値で、h3_indexの範囲検索で絞り込む
 SELECT
-    h3_index_res5 AS h3_index,
-    COUNT(*)::INT AS field_count
-FROM fields
-WHERE h3_index_res5 IS NOT NULL
-GROUP BY h3_index_res5;
+    h3_cell_to_parent(f.h3_index, @resolution::INT)::TEXT AS h3_index,
+    COUNT(*)::INT AS field_count,
+    COALESCE(SUM(f.area_sqm), 0)::DOUBLE PRECISION AS total_area_sqm
+FROM fields f
+JOIN unnest(@lower_bounds::TEXT[], @upper_bounds::TEXT[]) AS b(lower_bound, upper_bound)
+    ON f.h3_index BETWEEN b.lower_bound AND b.upper_bound
+WHERE f.retired_at IS NULL
+GROUP BY h3_cell_to_parent(f.h3_index, @resolution::INT);
+
+-- name: DeleteClusterResultsByH3Indexes :exec
+-- 指定世代の指定H3インデックスのクラスター結果を削除(カウント0になったセル用)
+DELETE FROM cluster_results
+WHERE generation = $1 AND resolution = $2 AND h3_index = ANY(@h3_indexes::TEXT[]);
 
--- name: AggregateClustersByRes7 :many
--- H3解像度7でfieldsを集計
+-- name: AggregateClustersByCoverage :many
+-- 指定解像度で有効な圃場を被覆するH3セルごとに集計
+-- 圃場は覆っている全てのセルで1件として数え、面積はセルに含まれる部分のみ合計する
 SELECT
-    h3_index_res7 AS h3_index,
-    COUNT(*)::INT AS field_count
-FROM fields
-WHERE h3_index_res7 IS NOT NULL
-GROUP BY h3_index_res7;
+    c.h3_index,
+    COUNT(*)::INT AS field_count,
+    COALESCE(SUM(f.area_sqm * c.area_share), 0)::DOUBLE PRECISION AS total_area_sqm
+FROM field_h3_coverages c
+JOIN fields f ON f.id = c.field_id
+WHERE c.resolution = @resolution AND f.retired_at IS NULL
+GROUP BY c.h3_index;
 
--- name: AggregateClustersByRes9 :many
--- H3解像度9でfieldsを集計
+-- name: AggregateClustersByCoverageForCells :many
+-- 指定H3セルのみ被覆で集計(差分更新用)
 SELECT
-    h3_index_res9 AS h3_index,
-    COUNT(*)::INT AS field_count
-FROM fields
-WHERE h3_index_res9 IS NOT NULL
-GROUP BY h3_index_res9;
+    c.h3_index,
+    COUNT(*)::INT AS field_count,
+    COALESCE(SUM(f.area_sqm * c.area_share), 0)::DOUBLE PRECISION AS total_area_sqm
+FROM field_h3_coverages c
+JOIN fields f ON f.id = c.field_id
+WHERE c.resolution = @resolution AND c.h3_index = ANY(@h3_cells::TEXT[]) AND f.retired_at IS NULL
+GROUP BY c.h3_index;
 
--- name: AggregateClustersByRes3ForCells :many
--- 指定H3セル(res3)のみfieldsを集計(差分更新用)
+-- name: AggregateClustersByAreaShare :many
+-- 指定解像度で有効な圃場をセルに含まれる面積の割合で按分して集計
+-- 圃場数は割合の合計を四捨五入した値とし、0件になるセルは返さない
 SELECT
-    h3_index_res3 AS h3_index,
-    COUNT(*)::INT AS field_count
-FROM fields
-WHERE h3_index_res3 = ANY(@h3_cells::TEXT[])
-GROUP BY h3_index_res3;
+    c.h3_index,
+    ROUND(SUM(c.area_share))::INT AS field_count,
+    COALESCE(SUM(f.area_sqm * c.area_share), 0)::DOUBLE PRECISION AS total_area_sqm
+FROM field_h3_coverages c
+JOIN fields f ON f.id = c.field_id
+WHERE c.resolution = @resolution AND f.retired_at IS NULL
+GROUP BY c.h3_index
+HAVING SUM(c.area_share) >= 0.5;
 
--- name: AggregateClustersByRes5ForCells :many
--- 指定H3セル(res5)のみfieldsを集計(差分更新用)
+-- name: AggregateClustersByAreaShareForCells :many
+-- 指定H3セルのみ面積の割合で按分して集計(差分更新用)
 SELECT
-    h3_index_res5 AS h3_index,
-    COUNT(*)::INT AS field_count
-FROM fields
-WHERE h3_index_res5 = ANY(@h3_cells::TEXT[])
-GROUP BY h3_index_res5;
+    c.h3_index,
+    ROUND(SUM(c.area_share))::INT AS field_count,
+    COALESCE(SUM(f.area_sqm * c.area_share), 0)::DOUBLE PRECISION AS total_area_sqm
+FROM field_h3_coverages c
+JOIN fields f ON f.id = c.field_id
+WHERE c.resolution = @resolution AND c.h3_index = ANY(@h3_cells::TEXT[]) AND f.retired_at IS NULL
+GROUP BY c.h3_index
+HAVING SUM(c.area_share) >= 0.5;
 
--- name: AggregateClustersByRes7ForCells :many
--- 指定H3セル(res7)のみfieldsを集計(差分更新用)
+-- name: AggregateClusterAttributes :many
+-- 指定解像度で有効なfieldsを重心のH3セルごとに属性別に集計
+-- 土地種別コードごとの圃場数、遊休農地の圃場数、合計面積が最大の土壌大分類コードを返す
+-- lower_boundsがNULLの場合は全範囲、指定した場合は解像度15の子孫セルの範囲に重心がある圃場のみ集計する(差分更新用)
+WITH targets AS (
+    SELECT
+        h3_cell_to_parent(f.h3_index, @resolution::INT) AS h3_index,
+        f.id,
+        f.soil_type_id,
+        f.area_sqm,
+        EXISTS (
+            SELECT 1
+            FROM field_land_registries r
+            WHERE r.field_id = f.id AND r.idle_land_status_code IS NOT NULL
+        ) AS is_idle
+    FROM fields f
+    WHERE f.retired_at IS NULL
+        AND (
+            sqlc.narg(lower_bounds)::TEXT[] IS NULL
+            OR EXISTS (
+                SELECT 1
+                FROM unnest(sqlc.narg(lower_bounds)::TEXT[], sqlc.narg(upper_bounds)::TEXT[]) AS b(lower_bound, upper_bound)
+                WHERE f.h3_index BETWEEN b.lower_bound AND b.upper_bound
+            )
+        )
+),
+cells AS (
+    SELECT
+        t.h3_index,
+        COUNT(*) FILTER (WHERE t.is_idle)::INT AS idle_field_count
+    FROM targets t
+    WHERE t.h3_index IS NOT NULL
+    GROUP BY t.h3_index
+),
+categories AS (
+    SELECT
+        t.h3_index,
+        r.land_category_code,
+        COUNT(DISTINCT t.id)::INT AS field_count
+    FROM targets t
+    JOIN field_land_registries r ON r.field_id = t.id
+    WHERE r.land_category_code IS NOT NULL
+    GROUP BY t.h3_index, r.land_category_code
+),
+soils AS (
+    SELECT DISTINCT ON (t.h3_index)
+        t.h3_index,
+        s.large_code
+    FROM targets t
+    JOIN soil_types s ON s.id = t.soil_type_id
+    GROUP BY t.h3_index, s.large_code
+    ORDER BY t.h3_index, SUM(t.area_sqm) DESC NULLS LAST, s.large_code
+)
 SELECT
-    h3_index_res7 AS h3_index,
-    COUNT(*)::INT AS field_count
-FROM fields
-WHERE h3_index_res7 = ANY(@h3_cells::TEXT[])
-GROUP BY h3_index_res7;
+    cells.h3_index::TEXT AS h3_index,
+    COALESCE((
+        SELECT jsonb_object_agg(cat.land_category_code, cat.field_count)
+        FROM categories cat
+        WHERE cat.h3_index = cells.h3_index AND cat.field_count > 0
+    ), '{}')::JSONB AS land_category_counts,
+    COALESCE(cells.idle_field_count, 0)::INT AS idle_field_count,
+    soils.large_code AS dominant_soil_large_code
+FROM cells
+LEFT JOIN soils ON soils.h3_index = cells.h3_index;
 
--- name: AggregateClustersByRes9ForCells :many
--- 指定H3セル(res9)のみfieldsを集計(差分更新用)
+-- name: AggregateClusterAttributesByCoverage :many
+-- 指定解像度で有効な圃場を被覆するH3セルごとに属性別に集計
+-- area_shareがtrueの場合は圃場数をセルに含まれる面積の割合で按分し、falseの場合は覆っている圃場を1件として数える
+-- 土壌の面積はセルに含まれる部分のみ合計する。h3_cellsがNULLの場合は全範囲を集計する
+WITH targets AS (
+    SELECT
+        cv.h3_index,
+        f.id,
+        f.soil_type_id,
+        f.area_sqm * cv.area_share AS area_sqm,
+        EXISTS (
+            SELECT 1
+            FROM field_land_registries r
+            WHERE r.field_id = f.id AND r.idle_land_status_code IS NOT NULL
+        ) AS is_idle,
+        CASE WHEN @area_share::BOOLEAN THEN cv.area_share ELSE 1 END::DOUBLE PRECISION AS weight
+    FROM field_h3_coverages cv
+    JOIN fields f ON f.id = cv.field_id
+    WHERE cv.resolution = @resolution::INT
+        AND f.retired_at IS NULL
+        AND (sqlc.narg(h3_cells)::TEXT[] IS NULL OR cv.h3_index = ANY(sqlc.narg(h3_cells)::TEXT[]))
+),
+cells AS (
+    SELECT
+        t.h3_index,
+        ROUND(SUM(t.weight) FILTER (WHERE t.is_idle))::INT AS idle_field_count
+    FROM targets t
+    GROUP BY t.h3_index
+),
+categories AS (
+    SELECT
+        c.h3_index,
+        c.land_category_code,
+        ROUND(SUM(c.weight))::INT AS field_count
+    FROM (
+        SELECT DISTINCT t.h3_index, t.id, t.weight, r.land_category_code
+        FROM targets t
+        JOIN field_land_registries r ON r.field_id = t.id
+        WHERE r.land_category_code IS NOT NULL
+    ) c
+    GROUP BY c.h3_index, c.land_category_code
+),
+soils AS (
+    SELECT DISTINCT ON (t.h3_index)
+        t.h3_index,
+        s.large_code
+    FROM targets t
+    JOIN soil_types s ON s.id = t.soil_type_id
+    GROUP BY t.h3_index, s.large_code
+    ORDER BY t.h3_index, SUM(t.area_sqm) DESC NULLS LAST, s.large_code
+)
 SELECT
-    h3_index_res9 AS h3_index,
-    COUNT(*)::INT AS field_count
-FROM fields
-WHERE h3_index_res9 = ANY(@h3_cells::TEXT[])
-GROUP BY h3_index_res9;
+    cells.h3_index::TEXT AS h3_index,
+    COALESCE((
+        SELECT jsonb_object_agg(cat.land_category_code, cat.field_count)
+        FROM categories cat
+        WHERE cat.h3_index = cells.h3_index AND cat.field_count > 0
+    ), '{}')::JSONB AS land_category_counts,
+    COALESCE(cells.idle_field_count, 0)::INT AS idle_field_count,
+    soils.large_code AS dominant_soil_large_code
+FROM cells
+LEFT JOIN soils ON soils.h3_index = cells.h3_index;
 
--- name: DeleteClusterResultsByH3Indexes :exec
--- 指定H3インデックスのクラスター結果を削除(カウント0になったセル用)
-DELETE FROM cluster_results
-WHERE resolution = $1 AND h3_index = ANY(@h3_indexes::TEXT[]);
+-- name: AggregateFilteredClusters :many
+-- 絞り込み条件に一致する有効なfieldsを重心のH3セルごとにその場で集計(cluster_resultsを使用しない)
+-- 重心が指定範囲内の圃場のみ対象とし、圃場数・合計面積と属性別の集計を返す。各絞り込み条件はNULLの場合に無視される
+-- 南西端の経度が北東端の経度より大きい場合は日付変更線をまたぐ範囲として東西2つの矩形で検索する
+WITH targets AS (
+    SELECT
+        h3_cell_to_parent(f.h3_index, @resolution::INT) AS h3_index,
+        f.id,
+        f.soil_type_id,
+        f.area_sqm,
+        EXISTS (
+            SELECT 1
+            FROM field_land_registries r
+            WHERE r.field_id = f.id AND r.idle_land_status_code IS NOT NULL
+        ) AS is_idle
+    FROM fields f
+    WHERE
+        f.retired_at IS NULL
+        AND (
+            ST_Intersects(
+                f.centroid,
+                ST_MakeEnvelope(
+                    @sw_lng::FLOAT8, @sw_lat::FLOAT8,
+                    CASE WHEN @sw_lng::FLOAT8 <= @ne_lng::FLOAT8 THEN @ne_lng::FLOAT8 ELSE 180 END, @ne_lat::FLOAT8,
+                    4326
+                )
+            )
+            OR (
+                @sw_lng::FLOAT8 > @ne_lng::FLOAT8
+                AND ST_Intersects(f.centroid, ST_MakeEnvelope(-180, @sw_lat::FLOAT8, @ne_lng::FLOAT8, @ne_lat::FLOAT8, 4326))
+            )
+        )
+        AND (sqlc.narg(city_code)::VARCHAR IS NULL OR f.city_code = sqlc.narg(city_code)::VARCHAR)
+        AND (sqlc.narg(soil_small_code)::VARCHAR IS NULL OR EXISTS (
+            SELECT 1 FROM soil_types st
+            WHERE st.id = f.soil_type_id AND st.small_code = sqlc.narg(soil_small_code)::VARCHAR
+        ))
+        AND (sqlc.narg(land_category_code)::VARCHAR IS NULL OR EXISTS (
+            SELECT 1 FROM field_land_registries r
+            WHERE r.field_id = f.id AND r.land_category_code = sqlc.narg(land_category_code)::VARCHAR
+        ))
+        AND (sqlc.narg(idle_land_status_code)::VARCHAR IS NULL OR EXISTS (
+            SELECT 1 FROM field_land_registries r
+            WHERE r.field_id = f.id AND r.idle_land_status_code = sqlc.narg(idle_land_status_code)::VARCHAR
+        ))
+        AND (sqlc.narg(min_area_sqm)::FLOAT8 IS NULL OR f.area_sqm >= sqlc.narg(min_area_sqm)::FLOAT8)
+        AND (sqlc.narg(max_area_sqm)::FLOAT8 IS NULL OR f.area_sqm <= sqlc.narg(max_area_sqm)::FLOAT8)
+),
+cells AS (
+    SELECT
+        t.h3_index,
+        COUNT(*)::INT AS field_count,
+        COALESCE(SUM(t.area_sqm), 0)::DOUBLE PRECISION AS total_area_sqm,
+        COUNT(*) FILTER (WHERE t.is_idle)::INT AS idle_field_count
+    FROM targets t
+    WHERE t.h3_index IS NOT NULL
+    GROUP BY t.h3_index
+),
+categories AS (
+    SELECT
+        t.h3_index,
+        r.land_category_code,
+        COUNT(DISTINCT t.id)::INT AS field_count
+    FROM targets t
+    JOIN field_land_registries r ON r.field_id = t.id
+    WHERE r.land_category_code IS NOT NULL
+    GROUP BY t.h3_index, r.land_category_code
+),
+soils AS (
+    SELECT DISTINCT ON (t.h3_index)
+        t.h3_index,
+        s.large_code
+    FROM targets t
+    JOIN soil_types s ON s.id = t.soil_type_id
+    GROUP BY t.h3_index, s.large_code
+    ORDER BY t.h3_index, SUM(t.area_sqm) DESC NULLS LAST, s.large_code
+)
+SELECT
+    cells.h3_index::TEXT AS h3_index,
+    cells.field_count,
+    cells.total_area_sqm,
+    COALESCE((
+        SELECT jsonb_object_agg(cat.land_category_code, cat.field_count)
+        FROM categories cat
+        WHERE cat.h3_index = cells.h3_index
+    ), '{}')::JSONB AS land_category_counts,
+    cells.idle_field_count,
+    soils.large_code AS dominant_soil_large_code
+FROM cells
+LEFT JOIN soils ON soils.h3_index = cells.h3_index
+ORDER BY cells.h3_index;
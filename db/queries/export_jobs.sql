@@ -0,0 +1,105 @@
+-- name: CreateExportJob :one
+-- エクスポートジョブを作成
+INSERT INTO export_jobs (
+    format,
+    city_code,
+    soil_small_code,
+    sw_lat,
+    sw_lng,
+    ne_lat,
+    ne_lng,
+    status
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, 'pending'
+) RETURNING *;
+
+-- name: GetExportJob :one
+-- エクスポートジョブをIDで取得
+SELECT
+    id,
+    format,
+    status,
+    city_code,
+    soil_small_code,
+    sw_lat,
+    sw_lng,
+    ne_lat,
+    ne_lng,
+    total_records,
+    s3_key,
+    error_message,
+    created_at,
+    started_at,
+    completed_at,
+    worker_id,
+    heartbeat_at,
+    attempts
+FROM export_jobs
+WHERE id = $1;
+
+-- name: ClaimPendingExportJob :one
+-- 最も古い保留中のジョブを処理中に更新し、指定ワーカーのリースとして取得
+-- 他のワーカーがロック中のジョブはスキップする
+UPDATE export_jobs
+SET
+    status = 'processing',
+    started_at = NOW(),
+    worker_id = @worker_id,
+    heartbeat_at = NOW(),
+    attempts = attempts + 1
+WHERE id = (
+    SELECT id FROM export_jobs
+    WHERE status = 'pending'
+    ORDER BY created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: HeartbeatExportJob :execrows
+-- 指定ワーカーがリースを保持している処理中ジョブのハートビートを更新
+UPDATE export_jobs
+SET heartbeat_at = NOW()
+WHERE id = @id AND worker_id = @worker_id AND status = 'processing';
+
+-- name: UpdateExportJobToCompleted :execrows
+-- 指定ワーカーがリースを保持している処理中ジョブを完了に更新
+UPDATE export_jobs
+SET
+    status = 'completed',
+    s3_key = $2,
+    total_records = $3,
+    completed_at = NOW()
+WHERE id = $1 AND worker_id = $4 AND status = 'processing';
+
+-- name: UpdateExportJobToFailed :execrows
+-- 指定ワーカーがリースを保持している処理中ジョブを失敗に更新
+UPDATE export_jobs
+SET
+    status = 'failed',
+    error_message = $2,
+    completed_at = NOW()
+WHERE id = $1 AND worker_id = $3 AND status = 'processing';
+
+-- name: RequeueExpiredExportJobs :execrows
+-- ハートビートがリース期間を超えて途絶え、実行回数が上限未満の処理中ジョブを保留中に戻してリースを解放
+UPDATE export_jobs
+SET
+    status = 'pending',
+    started_at = NULL,
+    worker_id = NULL,
+    heartbeat_at = NULL
+WHERE status = 'processing'
+  AND heartbeat_at < NOW() - make_interval(secs => @lease_seconds::INT)
+  AND attempts < @max_attempts::INT;
+
+-- name: FailExpiredExportJobs :execrows
+-- ハートビートがリース期間を超えて途絶え、実行回数が上限に達した処理中ジョブを失敗に更新
+UPDATE export_jobs
+SET
+    status = 'failed',
+    error_message = @error_message,
+    completed_at = NOW()
+WHERE status = 'processing'
+  AND heartbeat_at < NOW() - make_interval(secs => @lease_seconds::INT)
+  AND attempts >= @max_attempts::INT;
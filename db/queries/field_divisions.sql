@@ -0,0 +1,11 @@
+-- name: CreateFieldDivision :one
+-- 分筆履歴(親子関係)を作成
+INSERT INTO field_divisions (
+    parent_field_id,
+    child_field_id,
+    divided_at,
+    reason,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
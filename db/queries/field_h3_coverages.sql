@@ -0,0 +1,53 @@
+-- name: ListFieldsForH3CoverageRefresh :many
+-- H3被覆の計算が必要な有効な圃場をID順に取得(キーセットページング)
+-- 被覆が未計算、または計算後に圃場が更新された圃場をafter_idより後からrow_limit件返す
+SELECT
+    f.id,
+    ST_AsBinary(f.geometry)::BYTEA AS geometry_wkb,
+    f.updated_at
+FROM fields f
+WHERE f.retired_at IS NULL
+    AND f.geometry IS NOT NULL
+    AND (sqlc.narg(after_id)::UUID IS NULL OR f.id > sqlc.narg(after_id)::UUID)
+    AND NOT EXISTS (
+        SELECT 1
+        FROM field_h3_coverages c
+        WHERE c.field_id = f.id AND c.source_updated_at = f.updated_at
+    )
+ORDER BY f.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: DeleteFieldH3Coverages :many
+-- 指定圃場のH3被覆を全て削除し、削除した被覆を返す(再計算前後の比較用)
+DELETE FROM field_h3_coverages
+WHERE field_id = $1
+RETURNING resolution, h3_index, area_share;
+
+-- name: InsertFieldH3Coverages :exec
+-- 指定圃場のH3被覆を一括登録する
+-- 解像度・H3インデックス・面積比率は同じ長さの配列で受け取る
+INSERT INTO field_h3_coverages (
+    field_id,
+    resolution,
+    h3_index,
+    area_share,
+    source_updated_at,
+    calculated_at
+)
+SELECT
+    @field_id::UUID,
+    unnest(@resolutions::INT[]),
+    unnest(@h3_indexes::TEXT[]),
+    unnest(@area_shares::DOUBLE PRECISION[]),
+    @source_updated_at::TIMESTAMPTZ,
+    NOW();
+
+-- name: DeleteOrphanFieldH3Coverages :many
+-- 削除済み・廃止済みの圃場のH3被覆を削除し、削除した被覆のセルを返す
+DELETE FROM field_h3_coverages c
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM fields f
+    WHERE f.id = c.field_id AND f.retired_at IS NULL
+)
+RETURNING c.resolution, c.h3_index;
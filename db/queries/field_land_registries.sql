@@ -56,3 +56,54 @@ DELETE FROM field_land_registries WHERE field_id = ANY($1::uuid[]);
 -- name: CountFieldLandRegistriesByFieldID :one
 -- 圃場IDで農地台帳の件数を取得
 SELECT COUNT(*) FROM field_land_registries WHERE field_id = $1;
+
+-- name: ListFieldLandRegistriesWithMastersByFieldID :many
+-- 圃場IDで農地台帳一覧を土地種別名・遊休農地状況名付きで取得
+SELECT
+    r.id,
+    r.field_id,
+    r.farmer_number,
+    r.address,
+    r.area_sqm,
+    r.land_category_code,
+    lc.name AS land_category_name,
+    r.idle_land_status_code,
+    ils.name AS idle_land_status_name,
+    r.descriptive_study_data,
+    r.created_at,
+    r.updated_at
+FROM field_land_registries r
+LEFT JOIN land_categories lc ON lc.code = r.land_category_code
+LEFT JOIN idle_land_statuses ils ON ils.code = r.idle_land_status_code
+WHERE r.field_id = $1
+ORDER BY r.created_at;
+
+-- name: ListFieldLandRegistriesForExport :many
+-- 複数の圃場IDで農地台帳一覧を土地種別名・遊休農地状況名付きで取得(エクスポート用)
+SELECT
+    r.field_id,
+    r.farmer_number,
+    r.address,
+    r.area_sqm,
+    r.land_category_code,
+    lc.name AS land_category_name,
+    r.idle_land_status_code,
+    ils.name AS idle_land_status_name,
+    r.descriptive_study_data
+FROM field_land_registries r
+LEFT JOIN land_categories lc ON lc.code = r.land_category_code
+LEFT JOIN idle_land_statuses ils ON ils.code = r.idle_land_status_code
+WHERE r.field_id = ANY(sqlc.arg(field_ids)::UUID[])
+ORDER BY r.field_id, r.created_at;
+
+-- name: UpdateFieldLandRegistryFieldID :exec
+-- 農地台帳の所属圃場を変更(分筆・合筆時の付け替え用)
+UPDATE field_land_registries
+SET field_id = @field_id
+WHERE id = @id;
+
+-- name: MoveFieldLandRegistries :exec
+-- 複数圃場の農地台帳をまとめて別の圃場に付け替える(合筆時の引き継ぎ用)
+UPDATE field_land_registries
+SET field_id = @field_id
+WHERE field_id = ANY(@source_field_ids::UUID[]);
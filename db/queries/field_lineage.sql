@@ -0,0 +1,70 @@
+-- name: ListFieldLineageEdges :many
+-- 分筆・合筆履歴を起点圃場から祖先方向・子孫方向に再帰的に辿り、系譜のエッジを取得
+-- エッジは旧圃場(from)から新圃場(to)の向きで、depthは起点からの世代数(1始まり)
+-- pathに辿った圃場を保持し、履歴に循環があっても同じ圃場を2度辿らない
+-- 同じエッジが複数の経路から到達した場合は重複して返る
+WITH RECURSIVE lineage_edges AS (
+    SELECT
+        parent_field_id AS from_field_id,
+        child_field_id AS to_field_id,
+        'division'::TEXT AS edge_type,
+        divided_at AS occurred_at,
+        reason
+    FROM field_divisions
+    UNION ALL
+    SELECT
+        source_field_id,
+        merged_field_id,
+        'merger'::TEXT,
+        merged_at,
+        reason
+    FROM field_mergers
+),
+ancestors AS (
+    SELECT
+        e.from_field_id, e.to_field_id, e.edge_type, e.occurred_at, e.reason,
+        1 AS depth,
+        ARRAY[@field_id::UUID, e.from_field_id] AS path
+    FROM lineage_edges e
+    WHERE e.to_field_id = @field_id::UUID AND e.from_field_id <> @field_id::UUID
+    UNION ALL
+    SELECT
+        e.from_field_id, e.to_field_id, e.edge_type, e.occurred_at, e.reason,
+        a.depth + 1,
+        a.path || e.from_field_id
+    FROM ancestors a
+    JOIN lineage_edges e ON e.to_field_id = a.from_field_id
+    WHERE a.depth < @max_depth::INTEGER AND NOT e.from_field_id = ANY(a.path)
+),
+descendants AS (
+    SELECT
+        e.from_field_id, e.to_field_id, e.edge_type, e.occurred_at, e.reason,
+        1 AS depth,
+        ARRAY[@field_id::UUID, e.to_field_id] AS path
+    FROM lineage_edges e
+    WHERE e.from_field_id = @field_id::UUID AND e.to_field_id <> @field_id::UUID
+    UNION ALL
+    SELECT
+        e.from_field_id, e.to_field_id, e.edge_type, e.occurred_at, e.reason,
+        d.depth + 1,
+        d.path || e.to_field_id
+    FROM descendants d
+    JOIN lineage_edges e ON e.from_field_id = d.to_field_id
+    WHERE d.depth < @max_depth::INTEGER AND NOT e.to_field_id = ANY(d.path)
+)
+SELECT
+    'ancestor'::TEXT AS direction,
+    from_field_id, to_field_id, edge_type, occurred_at, reason, depth::INTEGER AS depth
+FROM ancestors
+UNION ALL
+SELECT
+    'descendant'::TEXT AS direction,
+    from_field_id, to_field_id, edge_type, occurred_at, reason, depth::INTEGER AS depth
+FROM descendants
+ORDER BY depth, occurred_at;
+
+-- name: ListFieldLineageNodes :many
+-- 系譜グラフのノードとなる圃場を取得(廃止済みの圃場を含む)
+SELECT id, name, city_code, area_sqm, created_at, retired_at
+FROM fields
+WHERE id = ANY(@ids::UUID[]);
@@ -0,0 +1,11 @@
+-- name: CreateFieldMerger :one
+-- 合筆履歴(合筆先とソースの関係)を作成
+INSERT INTO field_mergers (
+    merged_field_id,
+    source_field_id,
+    merged_at,
+    reason,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
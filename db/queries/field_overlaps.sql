@@ -0,0 +1,117 @@
+-- name: UpsertFieldOverlaps :many
+-- 指定圃場と他の有効な圃場の重なりを検出し、圃場ペア単位で記録する
+-- ST_IntersectsでGiSTインデックスを使って候補を絞り込み、ST_Intersectionの面積が閾値未満の接触は重なりとみなさない
+-- 許容済みの重なりは面積の変化が閾値未満の間だけ許容のまま維持し、それ以外は未対応に戻す
+WITH targets AS (
+    SELECT id, geometry, area_sqm
+    FROM fields
+    WHERE id = ANY(@field_ids::UUID[]) AND retired_at IS NULL
+),
+pairs AS (
+    SELECT DISTINCT ON (LEAST(t.id, f.id), GREATEST(t.id, f.id))
+        LEAST(t.id, f.id) AS field_id_a,
+        GREATEST(t.id, f.id) AS field_id_b,
+        ST_Area(ST_Intersection(t.geometry, f.geometry)::geography) AS overlap_area_sqm,
+        LEAST(t.area_sqm, f.area_sqm) AS smaller_area_sqm
+    FROM targets t
+    JOIN fields f
+        ON f.id <> t.id
+        AND f.retired_at IS NULL
+        AND ST_Intersects(t.geometry, f.geometry)
+    ORDER BY LEAST(t.id, f.id), GREATEST(t.id, f.id)
+)
+INSERT INTO field_overlaps (
+    field_id_a,
+    field_id_b,
+    overlap_area_sqm,
+    overlap_ratio
+)
+SELECT
+    field_id_a,
+    field_id_b,
+    overlap_area_sqm,
+    COALESCE(LEAST(overlap_area_sqm / NULLIF(smaller_area_sqm, 0), 1), 1)
+FROM pairs
+WHERE overlap_area_sqm >= @min_area_sqm::FLOAT8
+ON CONFLICT (field_id_a, field_id_b) DO UPDATE SET
+    overlap_area_sqm = EXCLUDED.overlap_area_sqm,
+    overlap_ratio = EXCLUDED.overlap_ratio,
+    status = CASE
+        WHEN field_overlaps.status = 'accepted'
+            AND ABS(field_overlaps.overlap_area_sqm - EXCLUDED.overlap_area_sqm) < @min_area_sqm::FLOAT8
+        THEN 'accepted' ELSE 'open' END,
+    clipped_field_id = NULL,
+    resolved_at = CASE
+        WHEN field_overlaps.status = 'accepted'
+            AND ABS(field_overlaps.overlap_area_sqm - EXCLUDED.overlap_area_sqm) < @min_area_sqm::FLOAT8
+        THEN field_overlaps.resolved_at END,
+    resolved_by = CASE
+        WHEN field_overlaps.status = 'accepted'
+            AND ABS(field_overlaps.overlap_area_sqm - EXCLUDED.overlap_area_sqm) < @min_area_sqm::FLOAT8
+        THEN field_overlaps.resolved_by END,
+    detected_at = NOW()
+RETURNING id;
+
+-- name: DeleteStaleFieldOverlaps :exec
+-- 指定圃場が関わる未対応・許容済みの記録のうち、今回の検出で見つからなかったものを削除する
+-- クリップで解消した記録は対応履歴として残す
+DELETE FROM field_overlaps
+WHERE
+    (field_id_a = ANY(@field_ids::UUID[]) OR field_id_b = ANY(@field_ids::UUID[]))
+    AND status IN ('open', 'accepted')
+    AND NOT (id = ANY(@detected_ids::UUID[]));
+
+-- name: GetFieldOverlap :one
+-- IDでオーバーラップ検知記録を取得
+SELECT * FROM field_overlaps WHERE id = $1;
+
+-- name: LockFieldOverlapForUpdate :one
+-- オーバーラップ検知記録を行ロックして取得
+-- 同一記録への同時対応を直列化するため、トランザクション内で使用する
+SELECT * FROM field_overlaps WHERE id = $1 FOR UPDATE;
+
+-- name: ListFieldOverlaps :many
+-- 条件を指定してオーバーラップ検知記録の一覧を取得
+-- 各条件はNULLの場合に無視される。重なりの割合が大きい順に並べる
+SELECT *
+FROM field_overlaps
+WHERE
+    (sqlc.narg(status)::VARCHAR IS NULL OR status = sqlc.narg(status)::VARCHAR)
+    AND (sqlc.narg(field_id)::UUID IS NULL OR field_id_a = sqlc.narg(field_id)::UUID OR field_id_b = sqlc.narg(field_id)::UUID)
+ORDER BY overlap_ratio DESC, id
+LIMIT sqlc.arg(row_limit)
+OFFSET sqlc.arg(row_offset);
+
+-- name: CountFieldOverlaps :one
+-- 条件に一致するオーバーラップ検知記録の総数を取得(ListFieldOverlapsと同一条件)
+SELECT COUNT(*)
+FROM field_overlaps
+WHERE
+    (sqlc.narg(status)::VARCHAR IS NULL OR status = sqlc.narg(status)::VARCHAR)
+    AND (sqlc.narg(field_id)::UUID IS NULL OR field_id_a = sqlc.narg(field_id)::UUID OR field_id_b = sqlc.narg(field_id)::UUID);
+
+-- name: ResolveFieldOverlap :one
+-- オーバーラップ検知記録に対応結果(許容・クリップ)を記録
+UPDATE field_overlaps
+SET
+    status = @status,
+    clipped_field_id = @clipped_field_id,
+    note = @note,
+    resolved_at = NOW(),
+    resolved_by = @resolved_by,
+    updated_at = NOW()
+WHERE id = @id
+RETURNING *;
+
+-- name: ClipFieldGeometry :one
+-- 圃場ジオメトリから相手圃場との重なり部分を取り除いた形状をMultiPolygonのWKB形式で取得
+-- geometry_countが0の場合はクリップで圃場が消滅し、2以上の場合は複数の区画に分断される
+SELECT
+    ST_AsBinary(ST_Multi(d.geom))::BYTEA AS geometry_wkb,
+    ST_NumGeometries(d.geom)::INTEGER AS geometry_count
+FROM (
+    SELECT ST_CollectionExtract(ST_Difference(t.geometry, o.geometry), 3) AS geom
+    FROM fields t
+    CROSS JOIN fields o
+    WHERE t.id = @field_id AND o.id = @other_field_id
+) d;
@@ -0,0 +1,59 @@
+-- name: GetCityFieldStats :one
+-- 市区町村の圃場統計を取得
+SELECT
+    city_code,
+    field_count,
+    total_area_sqm,
+    paddy_area_sqm,
+    upland_area_sqm,
+    idle_field_count,
+    idle_area_sqm,
+    refreshed_at
+FROM city_field_stats
+WHERE city_code = $1;
+
+-- name: GetPrefectureFieldStats :one
+-- 都道府県内の市区町村の圃場統計を合算して取得
+-- 都道府県コードは市区町村コードの上2桁
+SELECT
+    LEFT(city_code, 2)::TEXT AS prefecture_code,
+    SUM(field_count)::INT AS field_count,
+    SUM(total_area_sqm)::DOUBLE PRECISION AS total_area_sqm,
+    SUM(paddy_area_sqm)::DOUBLE PRECISION AS paddy_area_sqm,
+    SUM(upland_area_sqm)::DOUBLE PRECISION AS upland_area_sqm,
+    SUM(idle_field_count)::INT AS idle_field_count,
+    SUM(idle_area_sqm)::DOUBLE PRECISION AS idle_area_sqm,
+    MIN(refreshed_at)::TIMESTAMPTZ AS refreshed_at
+FROM city_field_stats
+WHERE LEFT(city_code, 2) = @prefecture_code::TEXT
+GROUP BY LEFT(city_code, 2);
+
+-- name: ListCitySoilStats :many
+-- 市区町村の土壌大分類ごとの圃場統計を合計面積の大きい順に取得
+SELECT
+    soil_large_code,
+    field_count,
+    total_area_sqm
+FROM city_soil_stats
+WHERE city_code = $1
+ORDER BY total_area_sqm DESC, soil_large_code;
+
+-- name: ListPrefectureSoilStats :many
+-- 都道府県の土壌大分類ごとの圃場統計を合計面積の大きい順に取得
+SELECT
+    soil_large_code,
+    SUM(field_count)::INT AS field_count,
+    SUM(total_area_sqm)::DOUBLE PRECISION AS total_area_sqm
+FROM city_soil_stats
+WHERE LEFT(city_code, 2) = @prefecture_code::TEXT
+GROUP BY soil_large_code
+ORDER BY SUM(total_area_sqm) DESC, soil_large_code;
+
+-- name: RefreshCityFieldStats :exec
+-- 市区町村ごとの圃場統計を再集計
+-- CONCURRENTLYのため、再集計中も集計前の統計を参照できる
+REFRESH MATERIALIZED VIEW CONCURRENTLY city_field_stats;
+
+-- name: RefreshCitySoilStats :exec
+-- 市区町村・土壌大分類ごとの圃場統計を再集計
+REFRESH MATERIALIZED VIEW CONCURRENTLY city_soil_stats;
@@ -4,105 +4,104 @@ SELECT
     id,
     geometry,
     centroid,
-    area_sqm,
-    h3_index_res3,
-    h3_index_res5,
-    h3_index_res7,
-    h3_index_res9,
     city_code,
     name,
     soil_type_id,
     created_at,
     updated_at,
     created_by,
-    updated_by
+    updated_by,
+    retired_at,
+    area_sqm,
+    h3_index
 FROM fields
 WHERE id = $1;
 
 -- name: ListFields :many
--- 圃場一覧を取得
+-- 有効な圃場一覧を取得
 SELECT
     id,
     geometry,
     centroid,
-    area_sqm,
-    h3_index_res3,
-    h3_index_res5,
-    h3_index_res7,
-    h3_index_res9,
     city_code,
     name,
     soil_type_id,
     created_at,
     updated_at,
     created_by,
-    updated_by
+    updated_by,
+    retired_at,
+    area_sqm,
+    h3_index
 FROM fields
+WHERE retired_at IS NULL
 ORDER BY created_at DESC
 LIMIT $1
 OFFSET $2;
 
 -- name: CountFields :one
--- 圃場の総数を取得
-SELECT COUNT(*) FROM fields;
+-- 有効な圃場の総数を取得
+SELECT COUNT(*) FROM fields WHERE retired_at IS NULL;
 
 -- name: ListFieldsByCityCode :many
--- 市区町村コードで圃場一覧を取得
+-- 市区町村コードで有効な圃場一覧を取得
 SELECT
     id,
     geometry,
     centroid,
-    area_sqm,
-    h3_index_res3,
-    h3_index_res5,
-    h3_index_res7,
-    h3_index_res9,
     city_code,
     name,
     soil_type_id,
     created_at,
     updated_at,
     created_by,
-    updated_by
+    updated_by,
+    retired_at,
+    area_sqm,
+    h3_index
 FROM fields
-WHERE city_code = $1
+WHERE city_code = $1 AND retired_at IS NULL
 ORDER BY created_at DESC
 LIMIT $2
 OFFSET $3;
 
 -- name: CreateField :one
 -- 圃場を作成
+-- geometry, centroidはWKB形式のbytea型で受け取り、ST_GeomFromWKBで変換
+-- geometryはPolygonで渡されてもST_Multiでマルチポリゴンに揃える
 INSERT INTO fields (
+    id,
     geometry,
     centroid,
-    h3_index_res3,
-    h3_index_res5,
-    h3_index_res7,
-    h3_index_res9,
+    h3_index,
     city_code,
     name,
     soil_type_id,
-    created_by
+    created_by,
+    updated_by
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+    @id,
+    ST_Multi(ST_GeomFromWKB(@geometry_wkb::bytea, 4326)),
+    ST_GeomFromWKB(@centroid_wkb::bytea, 4326),
+    @h3_index, @city_code, @name, @soil_type_id, @created_by, @updated_by
 ) RETURNING *;
 
 -- name: UpdateField :one
 -- 圃場を更新
+-- geometry, centroidはWKB形式のbytea型で受け取り、ST_GeomFromWKBで変換
+-- geometryはPolygonで渡されてもST_Multiでマルチポリゴンに揃える
+-- 読み取り後に分筆・合筆で廃止された圃場を上書きしないよう、廃止済みの圃場は更新せず行を返さない
 UPDATE fields
 SET
-    geometry = COALESCE($2, geometry),
-    centroid = COALESCE($3, centroid),
-    h3_index_res3 = COALESCE($4, h3_index_res3),
-    h3_index_res5 = COALESCE($5, h3_index_res5),
-    h3_index_res7 = COALESCE($6, h3_index_res7),
-    h3_index_res9 = COALESCE($7, h3_index_res9),
-    city_code = COALESCE($8, city_code),
-    name = COALESCE($9, name),
-    soil_type_id = $10,
-    updated_by = $11,
+    geometry = ST_Multi(ST_GeomFromWKB(@geometry_wkb::bytea, 4326)),
+    centroid = ST_GeomFromWKB(@centroid_wkb::bytea, 4326),
+    h3_index = @h3_index,
+    city_code = @city_code,
+    name = @name,
+    soil_type_id = @soil_type_id,
+    updated_by = @updated_by,
     updated_at = NOW()
-WHERE id = $1
+WHERE id = @id AND retired_at IS NULL
 RETURNING *;
 
 -- name: DeleteField :exec
@@ -112,41 +111,305 @@ DELETE FROM fields WHERE id = $1;
 -- name: UpsertField :one
 -- 圃場をUPSERT(wagriインポート用)
 -- geometry, centroidはWKB形式のbytea型で受け取り、ST_GeomFromWKBで変換
+-- geometryはPolygonで渡されてもST_Multiでマルチポリゴンに揃える
+-- 分筆・合筆で廃止済みの圃場は更新せず、行を返さない
 INSERT INTO fields (
     id,
     geometry,
     centroid,
-    h3_index_res3,
-    h3_index_res5,
-    h3_index_res7,
-    h3_index_res9,
+    h3_index,
     city_code,
     soil_type_id
 ) VALUES (
     @id,
-    ST_GeomFromWKB(@geometry_wkb::bytea, 4326),
+    ST_Multi(ST_GeomFromWKB(@geometry_wkb::bytea, 4326)),
     ST_GeomFromWKB(@centroid_wkb::bytea, 4326),
-    @h3_index_res3, @h3_index_res5, @h3_index_res7, @h3_index_res9, @city_code, @soil_type_id
+    @h3_index, @city_code, @soil_type_id
 )
 ON CONFLICT (id) DO UPDATE SET
     geometry = EXCLUDED.geometry,
     centroid = EXCLUDED.centroid,
-    h3_index_res3 = EXCLUDED.h3_index_res3,
-    h3_index_res5 = EXCLUDED.h3_index_res5,
-    h3_index_res7 = EXCLUDED.h3_index_res7,
-    h3_index_res9 = EXCLUDED.h3_index_res9,
+    h3_index = EXCLUDED.h3_index,
     city_code = EXCLUDED.city_code,
     soil_type_id = EXCLUDED.soil_type_id,
     updated_at = NOW()
+WHERE fields.retired_at IS NULL
 RETURNING *;
 
 -- name: GetH3IndexesByFieldIDs :many
 -- 指定IDのフィールドのH3インデックスを取得(差分更新のプリフェッチ用)
 SELECT
     id,
-    h3_index_res3,
-    h3_index_res5,
-    h3_index_res7,
-    h3_index_res9
+    h3_index
 FROM fields
 WHERE id = ANY(@ids::UUID[]);
+
+-- name: SearchFields :many
+-- 検索条件を指定して有効な圃場一覧を取得
+-- 廃止済みの圃場は除外する。各条件はNULLの場合に無視される。nameを指定した場合は類似度の高い順に並べる
+-- name_patternはnameのワイルドカードをエスケープして前後に%を付けたILIKEのパターン
+-- sw_lng > ne_lngの場合は日付変更線をまたぐ範囲として東西2つに分割して判定する
+SELECT
+    f.id,
+    f.area_sqm,
+    f.h3_index,
+    f.city_code,
+    f.name,
+    f.soil_type_id,
+    f.created_at,
+    f.updated_at,
+    f.created_by,
+    f.updated_by
+FROM fields f
+WHERE
+    f.retired_at IS NULL
+    AND (sqlc.narg(city_code)::VARCHAR IS NULL OR f.city_code = sqlc.narg(city_code)::VARCHAR)
+    AND (sqlc.narg(soil_small_code)::VARCHAR IS NULL OR EXISTS (
+        SELECT 1 FROM soil_types st
+        WHERE st.id = f.soil_type_id AND st.small_code = sqlc.narg(soil_small_code)::VARCHAR
+    ))
+    AND (sqlc.narg(land_category_code)::VARCHAR IS NULL OR EXISTS (
+        SELECT 1 FROM field_land_registries r
+        WHERE r.field_id = f.id AND r.land_category_code = sqlc.narg(land_category_code)::VARCHAR
+    ))
+    AND (sqlc.narg(idle_land_status_code)::VARCHAR IS NULL OR EXISTS (
+        SELECT 1 FROM field_land_registries r
+        WHERE r.field_id = f.id AND r.idle_land_status_code = sqlc.narg(idle_land_status_code)::VARCHAR
+    ))
+    AND (sqlc.narg(min_area_sqm)::FLOAT8 IS NULL OR f.area_sqm >= sqlc.narg(min_area_sqm)::FLOAT8)
+    AND (sqlc.narg(max_area_sqm)::FLOAT8 IS NULL OR f.area_sqm <= sqlc.narg(max_area_sqm)::FLOAT8)
+    AND (sqlc.narg(sw_lng)::FLOAT8 IS NULL OR ST_Intersects(
+        f.geometry,
+        ST_MakeEnvelope(
+            sqlc.narg(sw_lng)::FLOAT8, sqlc.narg(sw_lat)::FLOAT8,
+            CASE WHEN sqlc.narg(sw_lng)::FLOAT8 <= sqlc.narg(ne_lng)::FLOAT8 THEN sqlc.narg(ne_lng)::FLOAT8 ELSE 180 END, sqlc.narg(ne_lat)::FLOAT8,
+            4326
+        )
+    ) OR (
+        sqlc.narg(sw_lng)::FLOAT8 > sqlc.narg(ne_lng)::FLOAT8
+        AND ST_Intersects(f.geometry, ST_MakeEnvelope(-180, sqlc.narg(sw_lat)::FLOAT8, sqlc.narg(ne_lng)::FLOAT8, sqlc.narg(ne_lat)::FLOAT8, 4326))
+    ))
+    AND (sqlc.narg(name)::TEXT IS NULL OR f.name ILIKE sqlc.narg(name_pattern)::TEXT ESCAPE '\' OR f.name % sqlc.narg(name)::TEXT)
+ORDER BY
+    CASE WHEN sqlc.narg(name)::TEXT IS NULL THEN 0 ELSE similarity(f.name, sqlc.narg(name)::TEXT) END DESC,
+    f.created_at DESC,
+    f.id
+LIMIT sqlc.arg(row_limit)
+OFFSET sqlc.arg(row_offset);
+
+-- name: CountSearchFields :one
+-- 検索条件に一致する圃場の総数を取得(SearchFieldsと同一条件)
+SELECT COUNT(*)
+FROM fields f
+WHERE
+    f.retired_at IS NULL
+    AND (sqlc.narg(city_code)::VARCHAR IS NULL OR f.city_code = sqlc.narg(city_code)::VARCHAR)
+    AND (sqlc.narg(soil_small_code)::VARCHAR IS NULL OR EXISTS (
+        SELECT 1 FROM soil_types st
+        WHERE st.id = f.soil_type_id AND st.small_code = sqlc.narg(soil_small_code)::VARCHAR
+    ))
+    AND (sqlc.narg(land_category_code)::VARCHAR IS NULL OR EXISTS (
+        SELECT 1 FROM field_land_registries r
+        WHERE r.field_id = f.id AND r.land_category_code = sqlc.narg(land_category_code)::VARCHAR
+    ))
+    AND (sqlc.narg(idle_land_status_code)::VARCHAR IS NULL OR EXISTS (
+        SELECT 1 FROM field_land_registries r
+        WHERE r.field_id = f.id AND r.idle_land_status_code = sqlc.narg(idle_land_status_code)::VARCHAR
+    ))
+    AND (sqlc.narg(min_area_sqm)::FLOAT8 IS NULL OR f.area_sqm >= sqlc.narg(min_area_sqm)::FLOAT8)
+    AND (sqlc.narg(max_area_sqm)::FLOAT8 IS NULL OR f.area_sqm <= sqlc.narg(max_area_sqm)::FLOAT8)
+    AND (sqlc.narg(sw_lng)::FLOAT8 IS NULL OR ST_Intersects(
+        f.geometry,
+        ST_MakeEnvelope(
+            sqlc.narg(sw_lng)::FLOAT8, sqlc.narg(sw_lat)::FLOAT8,
+            CASE WHEN sqlc.narg(sw_lng)::FLOAT8 <= sqlc.narg(ne_lng)::FLOAT8 THEN sqlc.narg(ne_lng)::FLOAT8 ELSE 180 END, sqlc.narg(ne_lat)::FLOAT8,
+            4326
+        )
+    ) OR (
+        sqlc.narg(sw_lng)::FLOAT8 > sqlc.narg(ne_lng)::FLOAT8
+        AND ST_Intersects(f.geometry, ST_MakeEnvelope(-180, sqlc.narg(sw_lat)::FLOAT8, sqlc.narg(ne_lng)::FLOAT8, sqlc.narg(ne_lat)::FLOAT8, 4326))
+    ))
+    AND (sqlc.narg(name)::TEXT IS NULL OR f.name ILIKE sqlc.narg(name_pattern)::TEXT ESCAPE '\' OR f.name % sqlc.narg(name)::TEXT);
+
+-- name: GetFieldTile :one
+-- 指定タイルの圃場をMapbox Vector Tile(MVT)形式で取得
+-- タイル範囲(EPSG:3857)をWGS84に変換してidx_fields_geometry_gistで絞り込み、ST_AsMVTGeomでタイル座標に変換する
+-- 土地種別は農地台帳のうち面積が最大のものを代表値とする。廃止済みの圃場は含めない
+WITH bounds AS (
+    SELECT
+        ST_TileEnvelope(@z::INTEGER, @x::INTEGER, @y::INTEGER) AS tile_geom,
+        ST_Transform(ST_TileEnvelope(@z::INTEGER, @x::INTEGER, @y::INTEGER, margin => 0.015625), 4326) AS filter_geom
+),
+mvt_features AS (
+    SELECT
+        f.id::TEXT AS id,
+        f.name,
+        f.area_sqm,
+        st.small_code AS soil_small_code,
+        lr.land_category_code AS land_category,
+        lc.name AS land_category_name,
+        ST_AsMVTGeom(ST_Transform(f.geometry, 3857), b.tile_geom, 4096, 64, true) AS geom
+    FROM fields f
+    CROSS JOIN bounds b
+    LEFT JOIN soil_types st ON st.id = f.soil_type_id
+    LEFT JOIN LATERAL (
+        SELECT r.land_category_code
+        FROM field_land_registries r
+        WHERE r.field_id = f.id AND r.land_category_code IS NOT NULL
+        ORDER BY r.area_sqm DESC NULLS LAST, r.id
+        LIMIT 1
+    ) lr ON true
+    LEFT JOIN land_categories lc ON lc.code = lr.land_category_code
+    WHERE f.geometry && b.filter_geom AND f.retired_at IS NULL
+)
+SELECT COALESCE(ST_AsMVT(mvt_features.*, 'fields', 4096, 'geom'), ''::BYTEA)::BYTEA AS tile
+FROM mvt_features;
+
+-- name: ListFieldsForExport :many
+-- エクスポート対象の有効な圃場をID順に取得(キーセットページング)
+-- after_idより後の圃場をrow_limit件返す。各条件はNULLの場合に無視される
+SELECT
+    f.id,
+    ST_AsBinary(f.geometry)::BYTEA AS geometry_wkb,
+    f.area_sqm,
+    f.city_code,
+    f.name,
+    st.large_code AS soil_large_code,
+    st.middle_code AS soil_middle_code,
+    st.small_code AS soil_small_code,
+    st.small_name AS soil_small_name,
+    f.created_at,
+    f.updated_at
+FROM fields f
+LEFT JOIN soil_types st ON st.id = f.soil_type_id
+WHERE
+    f.retired_at IS NULL
+    AND (sqlc.narg(after_id)::UUID IS NULL OR f.id > sqlc.narg(after_id)::UUID)
+    AND (sqlc.narg(city_code)::VARCHAR IS NULL OR f.city_code = sqlc.narg(city_code)::VARCHAR)
+    AND (sqlc.narg(soil_small_code)::VARCHAR IS NULL OR st.small_code = sqlc.narg(soil_small_code)::VARCHAR)
+    AND (sqlc.narg(sw_lng)::FLOAT8 IS NULL OR ST_Intersects(
+        f.geometry,
+        ST_MakeEnvelope(sqlc.narg(sw_lng)::FLOAT8, sqlc.narg(sw_lat)::FLOAT8, sqlc.narg(ne_lng)::FLOAT8, sqlc.narg(ne_lat)::FLOAT8, 4326)
+    ))
+ORDER BY f.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: LockFieldForUpdate :one
+-- 分筆・合筆・削除の対象圃場を行ロックして廃止状態を取得
+-- 同一圃場への同時操作を直列化するため、トランザクション内で使用する
+SELECT id, retired_at
+FROM fields
+WHERE id = $1
+FOR UPDATE;
+
+-- name: LockFieldsForUpdate :many
+-- 合筆の対象圃場をID順に行ロックして廃止状態を取得
+-- ロック順序を固定して同時操作によるデッドロックを避ける。存在しないIDは結果に含まれない
+SELECT id, retired_at
+FROM fields
+WHERE id = ANY(@ids::UUID[])
+ORDER BY id
+FOR UPDATE;
+
+-- name: RetireField :exec
+-- 圃場を廃止する(分筆・合筆で役目を終えた圃場用)
+-- 農地台帳の移動でトリガーにより書き換わった圃場名を廃止前の名称に戻す
+UPDATE fields
+SET
+    retired_at = @retired_at,
+    name = @name,
+    updated_by = @updated_by,
+    updated_at = NOW()
+WHERE id = @id;
+
+-- name: HasFieldLineage :one
+-- 圃場が分筆・合筆で作られた圃場かどうかを取得
+-- 系譜のある圃場を削除すると分筆・合筆の履歴がカスケード削除されるため、削除の代わりに廃止する判定に使う
+SELECT (
+    EXISTS (SELECT 1 FROM field_divisions WHERE child_field_id = @field_id::UUID)
+    OR EXISTS (SELECT 1 FROM field_mergers WHERE merged_field_id = @field_id::UUID)
+)::BOOLEAN AS has_lineage;
+
+-- name: RetireDeletedField :exec
+-- 削除要求された系譜のある圃場を廃止する
+-- 分筆・合筆と異なり農地台帳は移動しないため、圃場名はそのまま残す
+UPDATE fields
+SET
+    retired_at = @retired_at,
+    updated_at = NOW()
+WHERE id = @id;
+
+-- name: CheckFieldDivisionGeometries :many
+-- 分筆後の子圃場が親圃場に収まり、互いに重ならないかを検証するための面積を取得
+-- child_indexは入力配列の順序(1始まり)。outside_area_sqmは親圃場からはみ出した面積、
+-- overlap_area_sqmは自身より後ろの子圃場と重なる面積の合計
+WITH children AS (
+    SELECT
+        c.ord::INTEGER AS child_index,
+        ST_GeomFromWKB(c.wkb, 4326) AS geom
+    FROM unnest(@child_geometry_wkbs::BYTEA[]) WITH ORDINALITY AS c(wkb, ord)
+)
+SELECT
+    c.child_index,
+    ST_Area(ST_Difference(c.geom, p.geometry)::geography)::FLOAT8 AS outside_area_sqm,
+    COALESCE((
+        SELECT SUM(ST_Area(ST_Intersection(c.geom, o.geom)::geography))
+        FROM children o
+        WHERE o.child_index > c.child_index AND ST_Intersects(c.geom, o.geom)
+    ), 0)::FLOAT8 AS overlap_area_sqm
+FROM children c
+CROSS JOIN fields p
+WHERE p.id = @parent_id
+ORDER BY c.child_index;
+
+-- name: UnionFieldGeometries :one
+-- 合筆対象の圃場ジオメトリをST_Unionで結合し、マルチポリゴンのWKB形式で取得
+-- 複数区画の圃場を含む場合は結合結果も複数区画になるため、区画数ではなく圃場同士のつながりで隣接を判定する
+-- connectedは辺を共有するか重なる圃場同士をたどって全ての圃場がつながっている場合にtrue
+WITH RECURSIVE sources AS (
+    SELECT id, geometry
+    FROM fields
+    WHERE id = ANY(@ids::UUID[])
+),
+adjacent AS (
+    SELECT a.id AS from_id, b.id AS to_id
+    FROM sources a
+    JOIN sources b
+        ON a.id <> b.id
+        AND ST_Intersects(a.geometry, b.geometry)
+        AND ST_Dimension(ST_Intersection(a.geometry, b.geometry)) >= 1
+),
+reachable AS (
+    SELECT id FROM (SELECT id FROM sources ORDER BY id LIMIT 1) first_source
+    UNION
+    SELECT adj.to_id
+    FROM reachable r
+    JOIN adjacent adj ON adj.from_id = r.id
+)
+SELECT
+    ST_AsBinary(ST_Multi(ST_Union(s.geometry)))::BYTEA AS geometry_wkb,
+    ((SELECT COUNT(*) FROM reachable) = COUNT(*))::BOOLEAN AS connected
+FROM sources s;
+
+-- name: ListFieldsForCentroidBackfill :many
+-- 重心・H3インデックスの再計算対象の圃場をID順に取得(キーセットページング)
+-- 廃止済みの圃場も含め、after_idより後の圃場をrow_limit件返す
+SELECT
+    id,
+    geometry,
+    centroid,
+    h3_index,
+    retired_at
+FROM fields
+WHERE sqlc.narg(after_id)::UUID IS NULL OR id > sqlc.narg(after_id)::UUID
+ORDER BY id
+LIMIT sqlc.arg(row_limit);
+
+-- name: UpdateFieldCentroid :exec
+-- 圃場の重心とH3インデックスのみを更新(重心の算出方法変更に伴うバックフィル用)
+-- centroidはWKB形式のbytea型で受け取り、ST_GeomFromWKBで変換
+UPDATE fields
+SET
+    centroid = ST_GeomFromWKB(@centroid_wkb::bytea, 4326),
+    h3_index = @h3_index
+WHERE id = @id;
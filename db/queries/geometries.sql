@@ -0,0 +1,13 @@
+-- name: RepairPolygons :many
+-- 自己交差などで不正なポリゴンをST_MakeValidで修復し、外周を反時計回りに揃えたWKB形式で取得
+-- ordは入力配列の順序(1始まり)。穴のない単一ポリゴンに修復できなかったものは結果に含まない
+SELECT
+    g.ord::INTEGER AS ord,
+    ST_AsBinary(ST_ForcePolygonCCW(ST_GeometryN(r.geom, 1)))::BYTEA AS geometry_wkb
+FROM unnest(@geometry_wkbs::BYTEA[]) WITH ORDINALITY AS g(wkb, ord)
+CROSS JOIN LATERAL (
+    SELECT ST_CollectionExtract(ST_MakeValid(ST_GeomFromWKB(g.wkb, 4326)), 3) AS geom
+) r
+WHERE ST_NumGeometries(r.geom) = 1
+    AND ST_NumInteriorRings(ST_GeometryN(r.geom, 1)) = 0
+ORDER BY g.ord;
@@ -14,7 +14,8 @@ SELECT
     failed_record_ids,
     created_at,
     started_at,
-    completed_at
+    completed_at,
+    rejected_records
 FROM import_jobs
 WHERE id = $1;
 
@@ -34,7 +35,8 @@ SELECT
     failed_record_ids,
     created_at,
     started_at,
-    completed_at
+    completed_at,
+    rejected_records
 FROM import_jobs
 ORDER BY created_at DESC
 LIMIT $1
@@ -56,7 +58,8 @@ SELECT
     failed_record_ids,
     created_at,
     started_at,
-    completed_at
+    completed_at,
+    rejected_records
 FROM import_jobs
 WHERE city_code = $1
 ORDER BY created_at DESC
@@ -127,6 +130,14 @@ SET
 WHERE id = $1
 RETURNING *;
 
+-- name: AppendImportJobRejectedRecords :one
+-- インポートジョブにジオメトリ検証で拒否したレコードを追記
+UPDATE import_jobs
+SET
+    rejected_records = COALESCE(rejected_records, '[]'::jsonb) || sqlc.arg(rejected_records)::jsonb
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
 -- name: CountImportJobs :one
 -- インポートジョブの総数を取得
 SELECT COUNT(*) FROM import_jobs;
@@ -0,0 +1,9 @@
+package config
+
+// ClusterConfig はクラスター計算の設定を保持する
+type ClusterConfig struct {
+	// Resolutions はクラスターを計算するH3解像度を指定する
+	// "3,5,7,9"のようなカンマ区切りと"2-10"のような範囲指定が使用でき、未設定の場合は3, 5, 7, 9とする
+	// サーバーとクラスターワーカーで同じ値を設定する必要がある
+	Resolutions string `env:"CLUSTER_RESOLUTIONS"`
+}
@@ -11,6 +11,7 @@ type Config struct {
 	Database DatabaseConfig
 	Cache    CacheConfig
 	Storage  StorageConfig
+	Cluster  ClusterConfig
 }
 
 // Load は環境変数から設定を読み込む
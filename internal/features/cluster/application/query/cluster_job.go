@@ -0,0 +1,61 @@
+// Package query はクラスタリング機能の照会インターフェースを定義する
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+)
+
+// ClusterJobFilter はクラスタージョブ履歴の検索条件
+// nilの条件は絞り込みに使用しない
+type ClusterJobFilter struct {
+	Status      *entity.JobStatus // ステータス
+	CreatedFrom *time.Time        // 作成日時の下限(この日時を含む)
+	CreatedTo   *time.Time        // 作成日時の上限(この日時を含まない)
+}
+
+// ClusterJobSummary はジョブ履歴として参照するクラスタージョブ
+// 影響セルは数が多くなるため件数のみを保持する
+type ClusterJobSummary struct {
+	ID                uuid.UUID
+	Status            entity.JobStatus
+	Priority          int32
+	FullRecalculation bool // 全範囲再計算かどうか
+	AffectedCellCount int  // 影響セル数(全範囲再計算の場合は0)
+	Attempts          int32
+	WorkerID          *string
+	CreatedAt         time.Time
+	StartedAt         *time.Time
+	CompletedAt       *time.Time
+	ErrorMessage      *string
+}
+
+// Duration はジョブの処理時間を返す
+// 終了済みのジョブは開始から終了まで、処理中のジョブは開始からnowまでの時間とし、未開始の場合はnilを返す
+func (s *ClusterJobSummary) Duration(now time.Time) *time.Duration {
+	if s.StartedAt == nil {
+		return nil
+	}
+	end := now
+	if s.CompletedAt != nil {
+		end = *s.CompletedAt
+	}
+	d := end.Sub(*s.StartedAt)
+	return &d
+}
+
+// ClusterJobQuery はクラスタージョブ履歴の照会インターフェース
+type ClusterJobQuery interface {
+	// List は検索条件に一致するジョブを作成日時の新しい順に取得する
+	List(ctx context.Context, filter ClusterJobFilter, limit, offset int32) ([]*ClusterJobSummary, error)
+
+	// Count は検索条件に一致するジョブの総数を取得する
+	Count(ctx context.Context, filter ClusterJobFilter) (int64, error)
+
+	// FindByID はIDでジョブを取得する
+	// 存在しない場合はnilを返す
+	FindByID(ctx context.Context, id uuid.UUID) (*ClusterJobSummary, error)
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/internal/h3util"
@@ -16,26 +18,75 @@ type CalculateClustersInput struct {
 	AffectedH3Cells []string // 影響を受けたH3セル(nil or empty = 全範囲再計算)
 }
 
+// TileCacheInvalidator は圃場タイルキャッシュの無効化インターフェース
+// 圃場機能のタイルキャッシュリポジトリが実装する
+type TileCacheInvalidator interface {
+	// DeleteByH3Cells は指定H3セル内の圃場を含み得るタイルをキャッシュから削除する
+	DeleteByH3Cells(ctx context.Context, h3Cells []string) error
+
+	// DeleteAll は全てのタイルをキャッシュから削除する
+	DeleteAll(ctx context.Context) error
+}
+
+const (
+	// coverageRefreshBatchSize はH3被覆の再計算で1回に取得する圃場数
+	coverageRefreshBatchSize = 500
+
+	// staleGenerationAge は有効化されていない世代を異常終了したワーカーの計算途中のものとみなすまでの期間
+	// 全範囲再計算にかかる時間より十分長くする
+	staleGenerationAge = 24 * time.Hour
+)
+
 // CalculateClustersUseCase はクラスター計算ユースケース
 type CalculateClustersUseCase struct {
-	clusterRepo repository.ClusterRepository
-	cacheRepo   repository.ClusterCacheRepository
-	logger      *slog.Logger
+	clusterRepo  repository.ClusterRepository
+	cacheRepo    repository.ClusterCacheRepository
+	coverageRepo repository.H3CoverageRepository
+	tileCache    TileCacheInvalidator
+	mode         entity.AggregationMode
+	resolutions  []entity.Resolution
+	logger       *slog.Logger
 }
 
 // NewCalculateClustersUseCase はCalculateClustersUseCaseを作成する
+// tileCacheがnilの場合はタイルキャッシュの無効化を行わない
 func NewCalculateClustersUseCase(
 	clusterRepo repository.ClusterRepository,
 	cacheRepo repository.ClusterCacheRepository,
+	tileCache TileCacheInvalidator,
 	logger *slog.Logger,
 ) *CalculateClustersUseCase {
 	return &CalculateClustersUseCase{
 		clusterRepo: clusterRepo,
 		cacheRepo:   cacheRepo,
+		tileCache:   tileCache,
+		mode:        entity.AggregationModeCentroid,
+		resolutions: entity.DefaultResolutions,
 		logger:      logger,
 	}
 }
 
+// NewCalculateClustersUseCaseWithCoverage は集計方法を指定してCalculateClustersUseCaseを作成する
+// 被覆を使用する集計方法では、集計前に未計算・更新済みの圃場のH3被覆をcoverageRepoで再計算する
+// resolutionsが空の場合はデフォルトの解像度で計算する
+func NewCalculateClustersUseCaseWithCoverage(
+	clusterRepo repository.ClusterRepository,
+	cacheRepo repository.ClusterCacheRepository,
+	coverageRepo repository.H3CoverageRepository,
+	tileCache TileCacheInvalidator,
+	mode entity.AggregationMode,
+	resolutions []entity.Resolution,
+	logger *slog.Logger,
+) *CalculateClustersUseCase {
+	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, tileCache, logger)
+	uc.coverageRepo = coverageRepo
+	uc.mode = mode
+	if len(resolutions) > 0 {
+		uc.resolutions = resolutions
+	}
+	return uc
+}
+
 // Execute はクラスター計算を実行する
 // 入力のAffectedH3Cellsがnilまたは空の場合は全範囲再計算、それ以外は差分更新
 func (u *CalculateClustersUseCase) Execute(ctx context.Context, input CalculateClustersInput) error {
@@ -46,43 +97,117 @@ func (u *CalculateClustersUseCase) Execute(ctx context.Context, input CalculateC
 }
 
 // executeFullRecalculation は全範囲でクラスター計算を実行する
+//
+// 新しい世代に全解像度の結果を書き込んでから有効化するため、計算中も読み取り側は有効な世代の結果を参照する。
+// 圃場がなくなったセルは新しい世代に含まれないため、有効化により結果から消える
 func (u *CalculateClustersUseCase) executeFullRecalculation(ctx context.Context) error {
-	u.logger.Info("全範囲クラスター計算を開始します")
+	u.logger.Info("全範囲クラスター計算を開始します",
+		slog.String("mode", string(u.mode)))
+
+	// 全範囲を集計し直すため、被覆が変わったセルは使用しない
+	if u.mode.UsesCoverage() {
+		if _, err := u.refreshCoverages(ctx); err != nil {
+			return err
+		}
+	}
+
+	generation, err := u.clusterRepo.CreateGeneration(ctx)
+	if err != nil {
+		return err
+	}
 
 	// 全解像度で処理
-	for _, resolution := range entity.AllResolutions {
-		if err := u.calculateForResolution(ctx, resolution); err != nil {
+	for _, resolution := range u.resolutions {
+		if err := u.calculateForResolution(ctx, generation, resolution); err != nil {
+			u.discardGeneration(ctx, generation)
 			return fmt.Errorf("解像度%sの計算に失敗しました: %w", resolution.String(), err)
 		}
 	}
 
-	// キャッシュをクリア
-	if err := u.cacheRepo.DeleteClusters(ctx); err != nil {
-		u.logger.Warn("キャッシュのクリアに失敗しました",
+	activated, err := u.clusterRepo.ActivateGeneration(ctx, generation)
+	if err != nil {
+		u.discardGeneration(ctx, generation)
+		return err
+	}
+	if !activated {
+		// 後から開始した全範囲再計算が先に完了した場合は、そちらの方が新しい結果のため破棄する
+		u.logger.Warn("より新しい世代が有効なため、計算結果を破棄します",
+			slog.Int64("generation", generation))
+		u.discardGeneration(ctx, generation)
+		return nil
+	}
+
+	// 古い世代を削除(削除に失敗しても次回の全範囲再計算で削除される)
+	deleted, err := u.clusterRepo.DeleteUnusedGenerations(ctx, staleGenerationAge)
+	if err != nil {
+		u.logger.Warn("不要な世代の削除に失敗しました",
 			slog.String("error", err.Error()))
 	}
 
-	u.logger.Info("全範囲クラスター計算が完了しました")
+	// クラスターキャッシュは世代ごとのキーのため削除しない(古い世代のキャッシュはTTLで失効する)
+	if u.tileCache != nil {
+		if err := u.tileCache.DeleteAll(ctx); err != nil {
+			u.logger.Warn("タイルキャッシュのクリアに失敗しました",
+				slog.String("error", err.Error()))
+		}
+	}
+
+	u.logger.Info("全範囲クラスター計算が完了しました",
+		slog.Int64("generation", generation),
+		slog.Int64("deleted_generations", deleted))
 	return nil
 }
 
+// discardGeneration は有効化しない世代を削除する
+// シャットダウンで中断した場合も削除できるよう、呼び出し元のキャンセルは引き継がない
+func (u *CalculateClustersUseCase) discardGeneration(ctx context.Context, generation int64) {
+	if err := u.clusterRepo.DeleteGeneration(context.WithoutCancel(ctx), generation); err != nil {
+		u.logger.Warn("世代の破棄に失敗しました。一定期間後に削除されます",
+			slog.Int64("generation", generation),
+			slog.String("error", err.Error()))
+	}
+}
+
 // executeDifferentialRecalculation は差分でクラスター計算を実行する
 func (u *CalculateClustersUseCase) executeDifferentialRecalculation(ctx context.Context, affectedH3Cells []string) error {
 	u.logger.Info("差分クラスター計算を開始します",
-		slog.Int("affected_cells", len(affectedH3Cells)))
+		slog.Int("affected_cells", len(affectedH3Cells)),
+		slog.String("mode", string(u.mode)))
+
+	// 影響セルは圃場の重心のセルのため、被覆が変わったセルを加える
+	if u.mode.UsesCoverage() {
+		coverageCells, err := u.refreshCoverages(ctx)
+		if err != nil {
+			return err
+		}
+		affectedH3Cells = mergeCells(affectedH3Cells, coverageCells)
+	}
 
 	// 解像度ごとにH3セルを分類
-	cellsByResolution := u.classifyH3CellsByResolution(affectedH3Cells)
+	cellsByResolution, ok := u.classifyH3CellsByResolution(affectedH3Cells)
+	if !ok {
+		u.logger.Info("再計算するセル数が上限を超えたため全範囲再計算を実行します",
+			slog.Int("affected_cells", len(affectedH3Cells)))
+		return u.executeFullRecalculation(ctx)
+	}
+
+	// 有効な世代に加え、実行中の全範囲再計算の世代にも反映する
+	generations, err := u.clusterRepo.ListLiveGenerations(ctx)
+	if err != nil {
+		return err
+	}
 
 	// 各解像度で差分更新
-	for _, resolution := range entity.AllResolutions {
+	for _, resolution := range u.resolutions {
 		cells := cellsByResolution[resolution]
 		if len(cells) == 0 {
 			continue
 		}
 
-		if err := u.calculateForResolutionDifferential(ctx, resolution, cells); err != nil {
-			return fmt.Errorf("解像度%sの差分計算に失敗しました: %w", resolution.String(), err)
+		for _, generation := range generations {
+			if err := u.calculateForResolutionDifferential(ctx, generation, resolution, cells); err != nil {
+				return fmt.Errorf("解像度%sの差分計算に失敗しました: %w", resolution.String(), err)
+			}
 		}
 	}
 
@@ -91,57 +216,63 @@ func (u *CalculateClustersUseCase) executeDifferentialRecalculation(ctx context.
 		u.logger.Warn("キャッシュのクリアに失敗しました",
 			slog.String("error", err.Error()))
 	}
+	// 影響セル周辺のタイルキャッシュのみ削除
+	if u.tileCache != nil {
+		if err := u.tileCache.DeleteByH3Cells(ctx, affectedH3Cells); err != nil {
+			u.logger.Warn("タイルキャッシュの削除に失敗しました",
+				slog.String("error", err.Error()))
+		}
+	}
 
 	u.logger.Info("差分クラスター計算が完了しました")
 	return nil
 }
 
-// classifyH3CellsByResolution はH3セルを解像度ごとに分類する
-func (u *CalculateClustersUseCase) classifyH3CellsByResolution(cells []string) map[entity.Resolution][]string {
-	result := make(map[entity.Resolution][]string)
+// classifyH3CellsByResolution は影響セルから各解像度で再計算するセルを求める
+//
+// 影響セルは任意の解像度を受け付け、影響セルより粗い解像度では親セル、詳細な解像度では子セルを再計算の対象とする。
+// 1つの圃場の変更は全ての解像度の祖先セルに影響するため、解像度15のセルのみ渡せば全解像度が更新される。
+// 再計算するセル数の合計が差分更新の上限を超える場合はokがfalseになる
+func (u *CalculateClustersUseCase) classifyH3CellsByResolution(cells []string) (map[entity.Resolution][]string, bool) {
+	valid := make([]string, 0, len(cells))
 	for _, cell := range cells {
-		resolution := u.detectResolution(cell)
-		if resolution >= 0 {
-			result[resolution] = append(result[resolution], cell)
-		} else {
-			u.logger.Warn("無効または未対応のH3インデックスがスキップされました",
+		if !h3util.IsValidH3Index(cell) {
+			u.logger.Warn("無効なH3インデックスがスキップされました",
 				slog.String("h3_index", cell))
+			continue
 		}
+		valid = append(valid, cell)
 	}
-	return result
-}
 
-// detectResolution はH3インデックスから解像度を検出する
-func (u *CalculateClustersUseCase) detectResolution(h3Index string) entity.Resolution {
-	resolution := h3util.GetResolution(h3Index)
-	switch resolution {
-	case 3:
-		return entity.Res3
-	case 5:
-		return entity.Res5
-	case 7:
-		return entity.Res7
-	case 9:
-		return entity.Res9
-	default:
-		// 無効なインデックスまたは未対応の解像度
-		return -1
+	result := make(map[entity.Resolution][]string)
+	remaining := maxAffectedCells
+	for _, resolution := range u.resolutions {
+		resolved, ok := h3util.CellsAtResolution(valid, resolution, remaining)
+		if !ok {
+			return nil, false
+		}
+		if len(resolved) > 0 {
+			result[resolution] = resolved
+			remaining -= len(resolved)
+		}
 	}
+	return result, true
 }
 
-// calculateForResolutionDifferential は指定解像度で差分クラスター計算を実行する
-func (u *CalculateClustersUseCase) calculateForResolutionDifferential(ctx context.Context, resolution entity.Resolution, h3Cells []string) error {
+// calculateForResolutionDifferential は指定世代・解像度で差分クラスター計算を実行する
+func (u *CalculateClustersUseCase) calculateForResolutionDifferential(ctx context.Context, generation int64, resolution entity.Resolution, h3Cells []string) error {
 	u.logger.Info("解像度別の差分クラスター計算を開始します",
+		slog.Int64("generation", generation),
 		slog.String("resolution", resolution.String()),
 		slog.Int("cells", len(h3Cells)))
 
 	// 1. 対象セルの既存クラスター結果を削除
-	if err := u.clusterRepo.DeleteClustersByH3Indexes(ctx, resolution, h3Cells); err != nil {
+	if err := u.clusterRepo.DeleteClustersByH3Indexes(ctx, generation, resolution, h3Cells); err != nil {
 		return fmt.Errorf("既存クラスター結果の削除に失敗しました: %w", err)
 	}
 
 	// 2. 対象セルのみ再集計
-	aggregated, err := u.clusterRepo.AggregateByH3ForCells(ctx, resolution, h3Cells)
+	aggregated, err := u.aggregateForCells(ctx, resolution, h3Cells)
 	if err != nil {
 		return fmt.Errorf("差分集計に失敗しました: %w", err)
 	}
@@ -159,7 +290,7 @@ func (u *CalculateClustersUseCase) calculateForResolutionDifferential(ctx contex
 	}
 
 	// 4. cluster_resultsテーブルに保存(UPSERT)
-	if err := u.clusterRepo.SaveClusters(ctx, clusters); err != nil {
+	if err := u.clusterRepo.SaveClusters(ctx, generation, clusters); err != nil {
 		return fmt.Errorf("保存に失敗しました: %w", err)
 	}
 
@@ -170,13 +301,13 @@ func (u *CalculateClustersUseCase) calculateForResolutionDifferential(ctx contex
 	return nil
 }
 
-// calculateForResolution は指定解像度でクラスター計算を実行する
-func (u *CalculateClustersUseCase) calculateForResolution(ctx context.Context, resolution entity.Resolution) error {
+// calculateForResolution は指定解像度でクラスター計算を実行し、指定世代に保存する
+func (u *CalculateClustersUseCase) calculateForResolution(ctx context.Context, generation int64, resolution entity.Resolution) error {
 	u.logger.Info("解像度別のクラスター計算を開始します",
 		slog.String("resolution", resolution.String()))
 
-	// fieldsテーブルからH3インデックスで集計
-	aggregated, err := u.clusterRepo.AggregateByH3(ctx, resolution)
+	// fieldsテーブルのH3インデックスまたはH3被覆で集計
+	aggregated, err := u.aggregate(ctx, resolution)
 	if err != nil {
 		return fmt.Errorf("集計に失敗しました: %w", err)
 	}
@@ -194,7 +325,7 @@ func (u *CalculateClustersUseCase) calculateForResolution(ctx context.Context, r
 	}
 
 	// cluster_resultsテーブルに保存
-	if err := u.clusterRepo.SaveClusters(ctx, clusters); err != nil {
+	if err := u.clusterRepo.SaveClusters(ctx, generation, clusters); err != nil {
 		return fmt.Errorf("保存に失敗しました: %w", err)
 	}
 
@@ -204,3 +335,85 @@ func (u *CalculateClustersUseCase) calculateForResolution(ctx context.Context, r
 
 	return nil
 }
+
+// aggregate は集計方法に応じて指定解像度の全範囲を集計する
+func (u *CalculateClustersUseCase) aggregate(ctx context.Context, resolution entity.Resolution) ([]*repository.AggregatedCluster, error) {
+	if u.mode.UsesCoverage() {
+		return u.clusterRepo.AggregateByCoverage(ctx, resolution, u.mode)
+	}
+	return u.clusterRepo.AggregateByH3(ctx, resolution)
+}
+
+// aggregateForCells は集計方法に応じて指定H3セルのみ集計する
+func (u *CalculateClustersUseCase) aggregateForCells(ctx context.Context, resolution entity.Resolution, h3Cells []string) ([]*repository.AggregatedCluster, error) {
+	if u.mode.UsesCoverage() {
+		return u.clusterRepo.AggregateByCoverageForCells(ctx, resolution, u.mode, h3Cells)
+	}
+	return u.clusterRepo.AggregateByH3ForCells(ctx, resolution, h3Cells)
+}
+
+// refreshCoverages は削除済み・廃止済みの圃場の被覆を削除し、未計算・更新済みの圃場の被覆を再計算する
+// 被覆が追加・削除されたセル、または面積比率が変わったセルを返す
+func (u *CalculateClustersUseCase) refreshCoverages(ctx context.Context) ([]string, error) {
+	if u.coverageRepo == nil {
+		return nil, fmt.Errorf("集計方法%sにはH3被覆リポジトリが必要です", u.mode)
+	}
+
+	changed, err := u.coverageRepo.DeleteOrphanCoverages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("不要なH3被覆の削除に失敗しました: %w", err)
+	}
+
+	var refreshed, skipped int
+	var afterID *uuid.UUID
+	for {
+		sources, err := u.coverageRepo.ListStaleSources(ctx, afterID, coverageRefreshBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("H3被覆の計算対象の取得に失敗しました: %w", err)
+		}
+
+		for _, source := range sources {
+			if source.Geometry == nil {
+				skipped++
+				continue
+			}
+			coverages, err := h3util.CalculateCoverage(source.Geometry, u.resolutions)
+			if err != nil {
+				return nil, fmt.Errorf("圃場%sのH3被覆の計算に失敗しました: %w", source.FieldID, err)
+			}
+			cells, err := u.coverageRepo.ReplaceCoverages(ctx, source.FieldID, source.UpdatedAt, coverages)
+			if err != nil {
+				return nil, err
+			}
+			changed = mergeCells(changed, cells)
+			refreshed++
+		}
+
+		if len(sources) < coverageRefreshBatchSize {
+			break
+		}
+		afterID = &sources[len(sources)-1].FieldID
+	}
+
+	u.logger.Info("H3被覆の再計算が完了しました",
+		slog.Int("refreshed", refreshed),
+		slog.Int("skipped", skipped),
+		slog.Int("changed_cells", len(changed)))
+	return changed, nil
+}
+
+// mergeCells は重複を除いてH3セルを結合する
+func mergeCells(cells, additional []string) []string {
+	seen := make(map[string]struct{}, len(cells)+len(additional))
+	merged := make([]string, 0, len(cells)+len(additional))
+	for _, list := range [][]string{cells, additional} {
+		for _, cell := range list {
+			if _, ok := seen[cell]; ok {
+				continue
+			}
+			seen[cell] = struct{}{}
+			merged = append(merged, cell)
+		}
+	}
+	return merged
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
 	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
 )
 
 // TestNewCalculateClustersUseCase はNewCalculateClustersUseCaseが正しくUseCaseを生成することをテストする
@@ -16,7 +19,7 @@ func TestNewCalculateClustersUseCase(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 
 	require.NotNil(t, uc, "UseCaseがnilです")
 }
@@ -32,7 +35,7 @@ func TestCalculateClustersUseCase_Execute_Success(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 
 	// 全範囲再計算(空のAffectedH3Cells)
 	err := uc.Execute(context.Background(), CalculateClustersInput{})
@@ -46,7 +49,7 @@ func TestCalculateClustersUseCase_Execute_EmptyAggregation(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 
 	err := uc.Execute(context.Background(), CalculateClustersInput{})
 
@@ -59,7 +62,7 @@ func TestCalculateClustersUseCase_Execute_AggregateError(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 
 	err := uc.Execute(context.Background(), CalculateClustersInput{})
 
@@ -79,7 +82,7 @@ func TestCalculateClustersUseCase_Execute_SaveError(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 
 	err := uc.Execute(context.Background(), CalculateClustersInput{})
 
@@ -96,13 +99,91 @@ func TestCalculateClustersUseCase_Execute_CacheDeleteError(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{deleteErr: errors.New("cache delete error")}
 	logger := getTestLogger()
 
-	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 
-	err := uc.Execute(context.Background(), CalculateClustersInput{})
+	err := uc.Execute(context.Background(), CalculateClustersInput{AffectedH3Cells: []string{"871f1a4adffffff"}})
 
 	require.NoError(t, err, "キャッシュ削除エラーでも処理は完了するべき")
 }
 
+// TestCalculateClustersUseCase_Execute_FullWritesNewGeneration は全範囲再計算が新しい世代に書き込んでから有効化することをテストする
+func TestCalculateClustersUseCase_Execute_FullWritesNewGeneration(t *testing.T) {
+	aggregated := []*repository.AggregatedCluster{
+		{H3Index: "871f1a4adffffff", FieldCount: 10},
+	}
+	clusterRepo := &mockClusterRepository{aggregated: aggregated, activeGeneration: 3, nextGeneration: 4}
+	uc := NewCalculateClustersUseCase(clusterRepo, &mockClusterCacheRepository{}, nil, getTestLogger())
+
+	err := uc.Execute(context.Background(), CalculateClustersInput{})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Len(t, clusterRepo.savedGenerations, len(entity.DefaultResolutions), "全解像度の結果が保存されるべき")
+	for _, generation := range clusterRepo.savedGenerations {
+		require.Equal(t, int64(4), generation, "新しい世代に保存するべき")
+	}
+	require.Equal(t, []int64{4}, clusterRepo.activated, "計算後に新しい世代を有効化するべき")
+	require.True(t, clusterRepo.unusedDeleted, "有効化後に古い世代を削除するべき")
+	require.Equal(t, staleGenerationAge, clusterRepo.staleAfter, "計算途中の世代を削除するまでの期間が一致しない")
+	require.Empty(t, clusterRepo.discarded, "有効化した世代は破棄しないべき")
+}
+
+// TestCalculateClustersUseCase_Execute_FullDiscardsGenerationOnError は計算に失敗した世代を有効化せずに破棄することをテストする
+func TestCalculateClustersUseCase_Execute_FullDiscardsGenerationOnError(t *testing.T) {
+	aggregated := []*repository.AggregatedCluster{
+		{H3Index: "871f1a4adffffff", FieldCount: 10},
+	}
+	clusterRepo := &mockClusterRepository{aggregated: aggregated, nextGeneration: 4, saveErr: errors.New("save error")}
+	uc := NewCalculateClustersUseCase(clusterRepo, &mockClusterCacheRepository{}, nil, getTestLogger())
+
+	err := uc.Execute(context.Background(), CalculateClustersInput{})
+
+	require.Error(t, err, "保存エラー時はエラーを返すべき")
+	require.Empty(t, clusterRepo.activated, "計算に失敗した世代を有効化すべきでない")
+	require.Equal(t, []int64{4}, clusterRepo.discarded, "計算に失敗した世代を破棄するべき")
+	require.False(t, clusterRepo.unusedDeleted, "計算に失敗した場合は古い世代を削除すべきでない")
+}
+
+// TestCalculateClustersUseCase_Execute_FullSuperseded はより新しい世代が有効な場合に計算結果を破棄することをテストする
+func TestCalculateClustersUseCase_Execute_FullSuperseded(t *testing.T) {
+	tileCache := &mockTileCacheInvalidator{}
+	clusterRepo := &mockClusterRepository{nextGeneration: 4, superseded: true}
+	uc := NewCalculateClustersUseCase(clusterRepo, &mockClusterCacheRepository{}, tileCache, getTestLogger())
+
+	err := uc.Execute(context.Background(), CalculateClustersInput{})
+
+	require.NoError(t, err, "より新しい世代が有効な場合はエラーにしないべき")
+	require.Equal(t, []int64{4}, clusterRepo.discarded, "有効化できなかった世代を破棄するべき")
+	require.False(t, tileCache.deleteAllCalled, "結果が変わらないためタイルキャッシュは削除すべきでない")
+}
+
+// TestCalculateClustersUseCase_Execute_FullCreateGenerationError は世代の作成に失敗した場合にエラーを返すことをテストする
+func TestCalculateClustersUseCase_Execute_FullCreateGenerationError(t *testing.T) {
+	clusterRepo := &mockClusterRepository{generationErr: errors.New("db error")}
+	uc := NewCalculateClustersUseCase(clusterRepo, &mockClusterCacheRepository{}, nil, getTestLogger())
+
+	err := uc.Execute(context.Background(), CalculateClustersInput{})
+
+	require.Error(t, err, "世代の作成に失敗した場合はエラーを返すべき")
+	require.Empty(t, clusterRepo.savedGenerations, "世代がない場合は保存すべきでない")
+}
+
+// TestCalculateClustersUseCase_Execute_DifferentialWritesLiveGenerations は差分計算が有効な世代と計算中の世代に反映されることをテストする
+func TestCalculateClustersUseCase_Execute_DifferentialWritesLiveGenerations(t *testing.T) {
+	aggregated := []*repository.AggregatedCluster{
+		{H3Index: "871f1a4adffffff", FieldCount: 10},
+	}
+	clusterRepo := &mockClusterRepository{aggregated: aggregated, activeGeneration: 3, liveGenerations: []int64{3, 4}}
+	uc := NewCalculateClustersUseCase(clusterRepo, &mockClusterCacheRepository{}, nil, getTestLogger())
+
+	err := uc.Execute(context.Background(), CalculateClustersInput{AffectedH3Cells: []string{"891f1a4a003ffff"}})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.NotEmpty(t, clusterRepo.deletedGenerations, "差分計算が実行されるべき")
+	require.ElementsMatch(t, clusterRepo.deletedGenerations, clusterRepo.savedGenerations, "削除した世代と同じ世代に保存するべき")
+	require.Subset(t, clusterRepo.savedGenerations, []int64{3, 4}, "有効な世代と計算中の世代の両方に保存するべき")
+	require.Empty(t, clusterRepo.activated, "差分計算では世代を切り替えないべき")
+}
+
 // TestCalculateClustersUseCase_Execute_AllResolutions は全解像度で計算が実行されることをテストする
 func TestCalculateClustersUseCase_Execute_AllResolutions(t *testing.T) {
 	// 各解像度で異なる結果を返すモック
@@ -114,7 +195,7 @@ func TestCalculateClustersUseCase_Execute_AllResolutions(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 
 	err := uc.Execute(context.Background(), CalculateClustersInput{})
 
@@ -132,13 +213,56 @@ func TestCalculateClustersUseCase_Execute_InvalidH3Index(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 
 	err := uc.Execute(context.Background(), CalculateClustersInput{})
 
 	require.NoError(t, err, "無効なH3インデックスはスキップされて正常に完了するべき")
 }
 
+// TestCalculateClustersUseCase_classifyH3CellsByResolution は影響セルから設定された各解像度のセルが求められることをテストする
+func TestCalculateClustersUseCase_classifyH3CellsByResolution(t *testing.T) {
+	resolutions := []entity.Resolution{4, 6, 9, 10}
+	uc := NewCalculateClustersUseCaseWithCoverage(&mockClusterRepository{}, &mockClusterCacheRepository{}, nil, nil, entity.AggregationModeCentroid, resolutions, getTestLogger())
+
+	// 解像度9のセルの中心の子セル(解像度15)と、同じ親を持つ解像度9のセル
+	cells, ok := uc.classifyH3CellsByResolution([]string{"8f1f1a4a0000000", "891f1a4a003ffff", "invalid"})
+
+	require.True(t, ok, "上限を超えていない")
+	require.Equal(t, []string{"841f1a5ffffffff"}, cells[4], "解像度4の親セルが一致しない")
+	require.Equal(t, []string{"861f1a4a7ffffff"}, cells[6], "解像度6の親セルが一致しない")
+	require.Equal(t, []string{"891f1a4a003ffff"}, cells[9], "同じ親セルは重複なく含めるべき")
+	require.Len(t, cells[10], 7, "解像度9のセルは解像度10の全ての子セルを含めるべき")
+	require.Contains(t, cells[10], "8a1f1a4a0007fff", "解像度15のセルの親セルを含めるべき")
+	require.NotContains(t, cells, entity.Res7, "設定されていない解像度は含めない")
+}
+
+// TestCalculateClustersUseCase_classifyH3CellsByResolution_Resolution9 は解像度9の変更が祖先の全解像度に反映されることをテストする
+func TestCalculateClustersUseCase_classifyH3CellsByResolution_Resolution9(t *testing.T) {
+	uc := NewCalculateClustersUseCase(&mockClusterRepository{}, &mockClusterCacheRepository{}, nil, getTestLogger())
+
+	cells, ok := uc.classifyH3CellsByResolution([]string{"891f1a4a003ffff"})
+
+	require.True(t, ok, "上限を超えていない")
+	require.Equal(t, []string{"831f1afffffffff"}, cells[entity.Res3], "解像度3の祖先セルが一致しない")
+	require.Equal(t, []string{"851f1a4bfffffff"}, cells[entity.Res5], "解像度5の祖先セルが一致しない")
+	require.Equal(t, []string{"871f1a4a0ffffff"}, cells[entity.Res7], "解像度7の祖先セルが一致しない")
+	require.Equal(t, []string{"891f1a4a003ffff"}, cells[entity.Res9], "解像度9のセルはそのまま含めるべき")
+}
+
+// TestCalculateClustersUseCase_Execute_DifferentialTooManyCells は子セルが上限を超える場合に全範囲再計算することをテストする
+func TestCalculateClustersUseCase_Execute_DifferentialTooManyCells(t *testing.T) {
+	clusterRepo := &mockClusterRepository{activeGeneration: 1, nextGeneration: 2}
+	uc := NewCalculateClustersUseCase(clusterRepo, &mockClusterCacheRepository{}, nil, getTestLogger())
+
+	// 解像度0のセルは解像度9で約4000万の子セルになる
+	err := uc.Execute(context.Background(), CalculateClustersInput{AffectedH3Cells: []string{"8001fffffffffff"}})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Empty(t, clusterRepo.deletedGenerations, "差分計算を実行しないべき")
+	require.Equal(t, []int64{2}, clusterRepo.activated, "全範囲再計算で新しい世代を有効化するべき")
+}
+
 // TestCalculateClustersUseCase_calculateForResolution は個別解像度の計算が正しく動作することをテストする
 func TestCalculateClustersUseCase_calculateForResolution(t *testing.T) {
 	tests := []struct {
@@ -161,11 +285,187 @@ func TestCalculateClustersUseCase_calculateForResolution(t *testing.T) {
 			cacheRepo := &mockClusterCacheRepository{}
 			logger := getTestLogger()
 
-			uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+			uc := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 
-			err := uc.calculateForResolution(context.Background(), tt.resolution)
+			err := uc.calculateForResolution(context.Background(), 1, tt.resolution)
 
 			require.NoError(t, err, "解像度%sの計算でエラーが発生", tt.resolution.String())
 		})
 	}
 }
+
+// mockTileCacheInvalidator はTileCacheInvalidatorのモック実装
+type mockTileCacheInvalidator struct {
+	err error
+
+	deleteAllCalled bool
+	deletedCells    []string
+}
+
+func (m *mockTileCacheInvalidator) DeleteByH3Cells(_ context.Context, h3Cells []string) error {
+	m.deletedCells = h3Cells
+	return m.err
+}
+
+func (m *mockTileCacheInvalidator) DeleteAll(_ context.Context) error {
+	m.deleteAllCalled = true
+	return m.err
+}
+
+// TestCalculateClustersUseCase_Execute_FullInvalidatesAllTiles は全範囲再計算で全タイルキャッシュが削除されることをテストする
+func TestCalculateClustersUseCase_Execute_FullInvalidatesAllTiles(t *testing.T) {
+	tileCache := &mockTileCacheInvalidator{}
+	uc := NewCalculateClustersUseCase(&mockClusterRepository{}, &mockClusterCacheRepository{}, tileCache, getTestLogger())
+
+	err := uc.Execute(context.Background(), CalculateClustersInput{})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.True(t, tileCache.deleteAllCalled, "全タイルキャッシュが削除されるべき")
+	require.Nil(t, tileCache.deletedCells, "全範囲再計算ではセル単位の削除は不要")
+}
+
+// TestCalculateClustersUseCase_Execute_DifferentialInvalidatesAffectedTiles は差分計算で影響セルのタイルのみ削除されることをテストする
+func TestCalculateClustersUseCase_Execute_DifferentialInvalidatesAffectedTiles(t *testing.T) {
+	tileCache := &mockTileCacheInvalidator{}
+	uc := NewCalculateClustersUseCase(&mockClusterRepository{}, &mockClusterCacheRepository{}, tileCache, getTestLogger())
+	cells := []string{"871f1a4adffffff", "891f1a4a003ffff"}
+
+	err := uc.Execute(context.Background(), CalculateClustersInput{AffectedH3Cells: cells})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, cells, tileCache.deletedCells, "影響セルのタイルキャッシュが削除されるべき")
+	require.False(t, tileCache.deleteAllCalled, "差分計算では全タイルを削除すべきでない")
+}
+
+// TestCalculateClustersUseCase_Execute_TileCacheErrorIgnored はタイルキャッシュ削除エラーでも処理が完了することをテストする
+func TestCalculateClustersUseCase_Execute_TileCacheErrorIgnored(t *testing.T) {
+	tileCache := &mockTileCacheInvalidator{err: errors.New("redis error")}
+	uc := NewCalculateClustersUseCase(&mockClusterRepository{}, &mockClusterCacheRepository{}, tileCache, getTestLogger())
+
+	err := uc.Execute(context.Background(), CalculateClustersInput{AffectedH3Cells: []string{"871f1a4adffffff"}})
+
+	require.NoError(t, err, "タイルキャッシュ削除エラーでも処理は完了するべき")
+}
+
+// mockH3CoverageRepository はH3CoverageRepositoryのモック実装
+// 保持している計算対象を1回だけ返し、置き換えた被覆を圃場ごとに記録する
+type mockH3CoverageRepository struct {
+	sources      []*repository.FieldCoverageSource
+	changedCells []string // ReplaceCoveragesが返すセル
+	orphanCells  []string // DeleteOrphanCoveragesが返すセル
+	replaceErr   error
+
+	replaced map[uuid.UUID][]*entity.H3Coverage
+}
+
+func (m *mockH3CoverageRepository) ListStaleSources(_ context.Context, afterID *uuid.UUID, _ int32) ([]*repository.FieldCoverageSource, error) {
+	if afterID != nil {
+		return nil, nil
+	}
+	return m.sources, nil
+}
+
+func (m *mockH3CoverageRepository) ReplaceCoverages(_ context.Context, fieldID uuid.UUID, _ time.Time, coverages []*entity.H3Coverage) ([]string, error) {
+	if m.replaceErr != nil {
+		return nil, m.replaceErr
+	}
+	if m.replaced == nil {
+		m.replaced = make(map[uuid.UUID][]*entity.H3Coverage)
+	}
+	m.replaced[fieldID] = coverages
+	return m.changedCells, nil
+}
+
+func (m *mockH3CoverageRepository) DeleteOrphanCoverages(_ context.Context) ([]string, error) {
+	return m.orphanCells, nil
+}
+
+// newCoverageSource は(lng, lat)を南西端とする一辺sizeの正方形の圃場を計算対象として作成する
+func newCoverageSource(t *testing.T, lng, lat, size float64) *repository.FieldCoverageSource {
+	t.Helper()
+	polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{
+		{lng, lat}, {lng + size, lat}, {lng + size, lat + size}, {lng, lat + size}, {lng, lat},
+	}})
+	multiPolygon := geom.NewMultiPolygon(geom.XY)
+	require.NoError(t, multiPolygon.Push(polygon), "マルチポリゴンの作成でエラーが発生")
+	return &repository.FieldCoverageSource{FieldID: uuid.New(), Geometry: multiPolygon, UpdatedAt: time.Now()}
+}
+
+// TestCalculateClustersUseCase_Execute_CoverageFull は被覆モードの全範囲再計算で被覆を再計算してから被覆で集計することをテストする
+func TestCalculateClustersUseCase_Execute_CoverageFull(t *testing.T) {
+	source := newCoverageSource(t, 139.70, 35.60, 0.01)
+	broken := &repository.FieldCoverageSource{FieldID: uuid.New(), UpdatedAt: time.Now()}
+	coverageRepo := &mockH3CoverageRepository{sources: []*repository.FieldCoverageSource{source, broken}}
+	clusterRepo := &mockClusterRepository{aggregated: []*repository.AggregatedCluster{
+		{H3Index: "871f1a4adffffff", FieldCount: 2, TotalAreaSqm: 1500},
+	}}
+
+	uc := NewCalculateClustersUseCaseWithCoverage(clusterRepo, &mockClusterCacheRepository{}, coverageRepo, nil, entity.AggregationModeAreaShare, nil, getTestLogger())
+	err := uc.Execute(context.Background(), CalculateClustersInput{})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, entity.AggregationModeAreaShare, clusterRepo.coverageMode, "被覆で集計されていない")
+	require.Len(t, coverageRepo.replaced, 1, "ジオメトリのない圃場は被覆を計算しない")
+
+	resolutions := make(map[entity.Resolution]float64)
+	for _, coverage := range coverageRepo.replaced[source.FieldID] {
+		resolutions[coverage.Resolution] += coverage.AreaShare
+	}
+	for _, resolution := range entity.DefaultResolutions {
+		require.InDelta(t, 1.0, resolutions[resolution], 1e-9, "解像度%sの面積比率の合計が1でない", resolution.String())
+	}
+}
+
+// TestCalculateClustersUseCase_Execute_CoverageDifferential は被覆モードの差分計算で被覆が変わったセルも再集計することをテストする
+func TestCalculateClustersUseCase_Execute_CoverageDifferential(t *testing.T) {
+	coverageRepo := &mockH3CoverageRepository{
+		sources:      []*repository.FieldCoverageSource{newCoverageSource(t, 139.70, 35.60, 0.001)},
+		changedCells: []string{"891f1a4a003ffff", "891f1a4a007ffff"},
+		orphanCells:  []string{"891f1a4a00bffff"},
+	}
+	clusterRepo := &mockClusterRepository{}
+	tileCache := &mockTileCacheInvalidator{}
+
+	// 影響セルと同じ解像度のみ計算し、親セルが再集計の対象に加わらないようにする
+	resolutions := []entity.Resolution{entity.Res9}
+	uc := NewCalculateClustersUseCaseWithCoverage(clusterRepo, &mockClusterCacheRepository{}, coverageRepo, tileCache, entity.AggregationModeCoverage, resolutions, getTestLogger())
+	err := uc.Execute(context.Background(), CalculateClustersInput{AffectedH3Cells: []string{"891f1a4a003ffff"}})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	expected := []string{"891f1a4a003ffff", "891f1a4a00bffff", "891f1a4a007ffff"}
+	require.Equal(t, entity.AggregationModeCoverage, clusterRepo.coverageMode, "被覆で集計されていない")
+	require.ElementsMatch(t, expected, clusterRepo.coverageCells, "影響セルと被覆が変わったセルを重複なく再集計すべき")
+	require.ElementsMatch(t, expected, tileCache.deletedCells, "被覆が変わったセルのタイルキャッシュも削除すべき")
+}
+
+// TestCalculateClustersUseCase_Execute_CoverageErrors は被覆の再計算に失敗した場合にエラーを返すことをテストする
+func TestCalculateClustersUseCase_Execute_CoverageErrors(t *testing.T) {
+	t.Run("被覆リポジトリがない", func(t *testing.T) {
+		uc := NewCalculateClustersUseCaseWithCoverage(&mockClusterRepository{}, &mockClusterCacheRepository{}, nil, nil, entity.AggregationModeCoverage, nil, getTestLogger())
+		err := uc.Execute(context.Background(), CalculateClustersInput{})
+		require.Error(t, err, "被覆リポジトリがない場合はエラーを返すべき")
+	})
+
+	t.Run("被覆の置き換えに失敗", func(t *testing.T) {
+		coverageRepo := &mockH3CoverageRepository{
+			sources:    []*repository.FieldCoverageSource{newCoverageSource(t, 139.70, 35.60, 0.001)},
+			replaceErr: errors.New("db error"),
+		}
+		clusterRepo := &mockClusterRepository{}
+		uc := NewCalculateClustersUseCaseWithCoverage(clusterRepo, &mockClusterCacheRepository{}, coverageRepo, nil, entity.AggregationModeCoverage, nil, getTestLogger())
+
+		err := uc.Execute(context.Background(), CalculateClustersInput{AffectedH3Cells: []string{"891f1a4a003ffff"}})
+		require.Error(t, err, "被覆の置き換えに失敗した場合はエラーを返すべき")
+		require.Empty(t, clusterRepo.coverageMode, "被覆の再計算に失敗した場合は集計しない")
+	})
+
+	t.Run("重心モードでは被覆を使用しない", func(t *testing.T) {
+		coverageRepo := &mockH3CoverageRepository{replaceErr: errors.New("db error")}
+		clusterRepo := &mockClusterRepository{}
+		uc := NewCalculateClustersUseCaseWithCoverage(clusterRepo, &mockClusterCacheRepository{}, coverageRepo, nil, entity.AggregationModeCentroid, nil, getTestLogger())
+
+		err := uc.Execute(context.Background(), CalculateClustersInput{})
+		require.NoError(t, err, "重心モードでは被覆を再計算しない")
+		require.Empty(t, clusterRepo.coverageMode, "重心モードでは被覆で集計しない")
+	})
+}
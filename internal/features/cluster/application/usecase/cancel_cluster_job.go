@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
+)
+
+// CancelClusterJobUseCase は保留中ジョブの取り消しのユースケース
+type CancelClusterJobUseCase struct {
+	jobRepo  repository.ClusterJobRepository
+	jobQuery query.ClusterJobQuery
+	logger   *slog.Logger
+}
+
+// NewCancelClusterJobUseCase は新しいCancelClusterJobUseCaseを作成する
+func NewCancelClusterJobUseCase(
+	jobRepo repository.ClusterJobRepository,
+	jobQuery query.ClusterJobQuery,
+	logger *slog.Logger,
+) *CancelClusterJobUseCase {
+	return &CancelClusterJobUseCase{
+		jobRepo:  jobRepo,
+		jobQuery: jobQuery,
+		logger:   logger,
+	}
+}
+
+// Execute は保留中のジョブを取り消し、取り消し後のジョブを返す
+// ワーカーが取得済みのジョブは計算を中断できないため取り消さない
+func (u *CancelClusterJobUseCase) Execute(ctx context.Context, id uuid.UUID) (*query.ClusterJobSummary, error) {
+	cancelled, err := u.jobRepo.Cancel(ctx, id)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("ジョブの取り消しに失敗しました", err)
+	}
+
+	job, err := u.jobQuery.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("ジョブの取得に失敗しました", err)
+	}
+	if job == nil {
+		return nil, apperror.NotFoundError("ジョブが見つかりません")
+	}
+	if !cancelled {
+		return nil, apperror.ConflictError(fmt.Sprintf("保留中のジョブのみ取り消せます(現在のステータス: %s)", job.Status))
+	}
+
+	u.logger.Info("クラスタージョブを取り消しました",
+		slog.String("job_id", id.String()))
+	return job, nil
+}
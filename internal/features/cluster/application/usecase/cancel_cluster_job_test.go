@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelClusterJobUseCase_Execute(t *testing.T) {
+	id := uuid.New()
+	job := &query.ClusterJobSummary{ID: id, Status: entity.JobStatusCancelled}
+	jobQuery := &mockClusterJobQuery{job: job}
+	uc := NewCancelClusterJobUseCase(&mockClusterJobRepository{cancelled: true}, jobQuery, getTestLogger())
+
+	got, err := uc.Execute(context.Background(), id)
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, job, got, "取り消し後のジョブを返すべき")
+}
+
+func TestCancelClusterJobUseCase_Execute_Error(t *testing.T) {
+	processing := &query.ClusterJobSummary{ID: uuid.New(), Status: entity.JobStatusProcessing}
+
+	tests := []struct {
+		name       string
+		jobRepo    *mockClusterJobRepository
+		jobQuery   *mockClusterJobQuery
+		wantStatus int
+	}{
+		{
+			name:       "ジョブが存在しない",
+			jobRepo:    &mockClusterJobRepository{},
+			jobQuery:   &mockClusterJobQuery{},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "保留中でない",
+			jobRepo:    &mockClusterJobRepository{},
+			jobQuery:   &mockClusterJobQuery{job: processing},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "取り消しエラー",
+			jobRepo:    &mockClusterJobRepository{cancelErr: errors.New("db error")},
+			jobQuery:   &mockClusterJobQuery{job: processing},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "取得エラー",
+			jobRepo:    &mockClusterJobRepository{cancelled: true},
+			jobQuery:   &mockClusterJobQuery{findErr: errors.New("db error")},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewCancelClusterJobUseCase(tt.jobRepo, tt.jobQuery, getTestLogger())
+
+			_, err := uc.Execute(context.Background(), uuid.New())
+
+			require.Error(t, err, "エラーを期待")
+			require.Equal(t, tt.wantStatus, errorStatus(err), "HTTPステータスが期待値と異なります")
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
+)
+
+const (
+	// DefaultCompletedJobRetention は完了済みジョブの保持期間のデフォルト値
+	DefaultCompletedJobRetention = 7 * 24 * time.Hour
+
+	// DefaultFailedJobRetention は失敗・取り消し済みジョブの保持期間のデフォルト値
+	// 原因調査のため完了済みジョブより長く残す
+	DefaultFailedJobRetention = 30 * 24 * time.Hour
+)
+
+// CleanupJobsInput はジョブ削除ユースケースの入力
+type CleanupJobsInput struct {
+	CompletedRetention time.Duration // 完了済みジョブの保持期間(0=デフォルト値)
+	FailedRetention    time.Duration // 失敗・取り消し済みジョブの保持期間(0=デフォルト値)
+}
+
+// CleanupJobsOutput はジョブ削除ユースケースの出力
+type CleanupJobsOutput struct {
+	DeletedCompleted int64 // 削除した完了済みジョブ数
+	DeletedFailed    int64 // 削除した失敗・取り消し済みジョブ数
+}
+
+// CleanupJobsUseCase は保持期間を過ぎたジョブ履歴を削除するユースケース
+type CleanupJobsUseCase struct {
+	jobRepo repository.ClusterJobRepository
+	logger  *slog.Logger
+}
+
+// NewCleanupJobsUseCase はCleanupJobsUseCaseを作成する
+func NewCleanupJobsUseCase(
+	jobRepo repository.ClusterJobRepository,
+	logger *slog.Logger,
+) *CleanupJobsUseCase {
+	return &CleanupJobsUseCase{
+		jobRepo: jobRepo,
+		logger:  logger,
+	}
+}
+
+// Execute は保持期間を過ぎた完了済み・失敗・取り消し済みジョブを削除する
+// 保留中・処理中のジョブは削除しない
+func (u *CleanupJobsUseCase) Execute(ctx context.Context, input CleanupJobsInput) (*CleanupJobsOutput, error) {
+	if input.CompletedRetention <= 0 {
+		input.CompletedRetention = DefaultCompletedJobRetention
+	}
+	if input.FailedRetention <= 0 {
+		input.FailedRetention = DefaultFailedJobRetention
+	}
+
+	deletedCompleted, err := u.jobRepo.DeleteOldCompletedJobs(ctx, input.CompletedRetention)
+	if err != nil {
+		return nil, fmt.Errorf("完了済みジョブの削除に失敗しました: %w", err)
+	}
+
+	deletedFailed, err := u.jobRepo.DeleteOldFailedJobs(ctx, input.FailedRetention)
+	if err != nil {
+		return nil, fmt.Errorf("失敗ジョブの削除に失敗しました: %w", err)
+	}
+
+	u.logger.Info("古いジョブを削除しました",
+		slog.Duration("completed_retention", input.CompletedRetention),
+		slog.Duration("failed_retention", input.FailedRetention),
+		slog.Int64("deleted_completed", deletedCompleted),
+		slog.Int64("deleted_failed", deletedFailed))
+
+	return &CleanupJobsOutput{
+		DeletedCompleted: deletedCompleted,
+		DeletedFailed:    deletedFailed,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCleanupJobsUseCase_Execute は指定した保持期間で古いジョブを削除することをテストする
+func TestCleanupJobsUseCase_Execute(t *testing.T) {
+	jobRepo := &mockClusterJobRepository{deletedCompleted: 3, deletedFailed: 1}
+	uc := NewCleanupJobsUseCase(jobRepo, getTestLogger())
+
+	output, err := uc.Execute(context.Background(), CleanupJobsInput{
+		CompletedRetention: 24 * time.Hour,
+		FailedRetention:    48 * time.Hour,
+	})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, int64(3), output.DeletedCompleted, "完了済みジョブの削除件数が一致しない")
+	require.Equal(t, int64(1), output.DeletedFailed, "失敗ジョブの削除件数が一致しない")
+	require.Equal(t, 24*time.Hour, jobRepo.completedRetention, "完了済みジョブの保持期間が一致しない")
+	require.Equal(t, 48*time.Hour, jobRepo.failedRetention, "失敗ジョブの保持期間が一致しない")
+}
+
+// TestCleanupJobsUseCase_Execute_DefaultRetention は保持期間が未指定の場合にデフォルト値を使用することをテストする
+func TestCleanupJobsUseCase_Execute_DefaultRetention(t *testing.T) {
+	jobRepo := &mockClusterJobRepository{}
+	uc := NewCleanupJobsUseCase(jobRepo, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), CleanupJobsInput{})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, DefaultCompletedJobRetention, jobRepo.completedRetention, "完了済みジョブのデフォルト保持期間が適用されていない")
+	require.Equal(t, DefaultFailedJobRetention, jobRepo.failedRetention, "失敗ジョブのデフォルト保持期間が適用されていない")
+}
+
+// TestCleanupJobsUseCase_Execute_DeleteError は削除エラーを返すことをテストする
+func TestCleanupJobsUseCase_Execute_DeleteError(t *testing.T) {
+	jobRepo := &mockClusterJobRepository{deleteErr: errors.New("delete error")}
+	uc := NewCleanupJobsUseCase(jobRepo, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), CleanupJobsInput{})
+
+	require.Error(t, err, "削除エラーを返すべき")
+}
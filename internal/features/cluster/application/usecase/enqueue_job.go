@@ -5,6 +5,7 @@ import (
 	"context"
 	"log/slog"
 
+	"github.com/google/uuid"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
 )
@@ -15,9 +16,16 @@ type EnqueueJobInput struct {
 	AffectedH3Cells []string // 影響を受けたH3セル(nil=全範囲再計算)
 }
 
+// maxAffectedCells は差分更新ジョブが保持する影響セル数の上限
+// 保留中ジョブへの統合を繰り返して上限を超えた場合は全範囲再計算に昇格する。
+// 差分更新は影響セルごとに集計クエリを発行するため、これを超える規模では全範囲再計算の方が速い
+const maxAffectedCells = 50000
+
 // EnqueueJobOutput はジョブエンキューユースケースの出力
 type EnqueueJobOutput struct {
-	Enqueued bool // ジョブがエンキューされたかどうか
+	Enqueued bool      // ジョブがエンキューされたかどうか(既存ジョブへの統合を含む)
+	Merged   bool      // 保留中の既存ジョブに統合されたかどうか
+	JobID    uuid.UUID // エンキュー先のジョブID
 }
 
 // EnqueueJobUseCase はジョブエンキューユースケース
@@ -38,43 +46,43 @@ func NewEnqueueJobUseCase(
 }
 
 // Execute はジョブエンキューを実行する
-// 既に保留中または処理中のジョブがある場合はスキップする
+// 保留中のジョブがある場合は影響セルをそのジョブに統合し、処理中のジョブしかない場合は後続ジョブを作成する。
+// 処理中のジョブは開始時点の影響セルで計算しているため、それ以降の変更は後続ジョブで反映する
 func (u *EnqueueJobUseCase) Execute(ctx context.Context, input EnqueueJobInput) (EnqueueJobOutput, error) {
-	// 既にペンディングまたは処理中のジョブがあるか確認
-	hasJob, err := u.jobRepo.HasPendingOrProcessingJob(ctx)
-	if err != nil {
-		return EnqueueJobOutput{}, err
-	}
-
-	if hasJob {
-		u.logger.Info("既に保留中または処理中のジョブがあるためスキップします")
-		return EnqueueJobOutput{Enqueued: false}, nil
-	}
-
-	// 新しいジョブを作成
 	var job *entity.ClusterJob
-	if len(input.AffectedH3Cells) > 0 {
+	if len(input.AffectedH3Cells) > 0 && len(input.AffectedH3Cells) <= maxAffectedCells {
 		// 差分更新用ジョブ
 		job = entity.NewClusterJobWithAffectedCells(input.Priority, input.AffectedH3Cells)
-		if err := u.jobRepo.CreateWithAffectedCells(ctx, job); err != nil {
-			return EnqueueJobOutput{}, err
-		}
-		u.logger.Info("差分更新用クラスタージョブをエンキューしました",
-			slog.String("job_id", job.ID.String()),
-			slog.Int("priority", int(input.Priority)),
-			slog.Int("affected_cells", len(input.AffectedH3Cells)))
 	} else {
 		// 全範囲再計算用ジョブ
 		job = entity.NewClusterJob(input.Priority)
-		if err := u.jobRepo.Create(ctx, job); err != nil {
-			return EnqueueJobOutput{}, err
-		}
-		u.logger.Info("全範囲再計算用クラスタージョブをエンキューしました",
-			slog.String("job_id", job.ID.String()),
-			slog.Int("priority", int(input.Priority)))
 	}
 
-	return EnqueueJobOutput{Enqueued: true}, nil
+	enqueued, merged, err := u.jobRepo.Enqueue(ctx, job, maxAffectedCells)
+	if err != nil {
+		return EnqueueJobOutput{}, err
+	}
+
+	if merged {
+		u.logger.Info("保留中のクラスタージョブに統合しました",
+			slog.String("job_id", enqueued.ID.String()),
+			slog.Int("priority", int(enqueued.Priority)),
+			slog.Int("requested_cells", len(input.AffectedH3Cells)),
+			slog.Bool("full_recalculation", enqueued.IsFullRecalculation()),
+			slog.Int("affected_cells", len(enqueued.AffectedH3Cells)))
+	} else {
+		u.logger.Info("クラスタージョブをエンキューしました",
+			slog.String("job_id", enqueued.ID.String()),
+			slog.Int("priority", int(enqueued.Priority)),
+			slog.Bool("full_recalculation", enqueued.IsFullRecalculation()),
+			slog.Int("affected_cells", len(enqueued.AffectedH3Cells)))
+	}
+
+	return EnqueueJobOutput{
+		Enqueued: true,
+		Merged:   merged,
+		JobID:    enqueued.ID,
+	}, nil
 }
 
 // ClusterJobEnqueuerAdapter はimport機能から使用するアダプタ
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,7 +21,7 @@ func TestNewEnqueueJobUseCase(t *testing.T) {
 
 // TestEnqueueJobUseCase_Execute_Success は正常にジョブをエンキューすることをテストする
 func TestEnqueueJobUseCase_Execute_Success(t *testing.T) {
-	jobRepo := &mockClusterJobRepository{hasPendingJob: false}
+	jobRepo := &mockClusterJobRepository{}
 	logger := getTestLogger()
 
 	uc := NewEnqueueJobUseCase(jobRepo, logger)
@@ -31,36 +32,48 @@ func TestEnqueueJobUseCase_Execute_Success(t *testing.T) {
 	require.True(t, output.Enqueued, "ジョブがエンキューされるべき")
 }
 
-// TestEnqueueJobUseCase_Execute_SkipWhenPendingJobExists は保留中ジョブがある場合にスキップすることをテストする
-func TestEnqueueJobUseCase_Execute_SkipWhenPendingJobExists(t *testing.T) {
-	jobRepo := &mockClusterJobRepository{hasPendingJob: true}
+// TestEnqueueJobUseCase_Execute_MergeIntoPendingJob は保留中ジョブがある場合に統合されることをテストする
+func TestEnqueueJobUseCase_Execute_MergeIntoPendingJob(t *testing.T) {
+	pendingJob := entity.NewClusterJobWithAffectedCells(5, []string{"8a2f5a32d827fff", "8a2f5a32d837fff"})
+	jobRepo := &mockClusterJobRepository{pendingJob: pendingJob}
 	logger := getTestLogger()
 
 	uc := NewEnqueueJobUseCase(jobRepo, logger)
 
-	output, err := uc.Execute(context.Background(), EnqueueJobInput{Priority: 10})
+	output, err := uc.Execute(context.Background(), EnqueueJobInput{
+		Priority:        1,
+		AffectedH3Cells: []string{"8a2f5a32d847fff"},
+	})
 
-	require.NoError(t, err, "既存ジョブがある場合はスキップしてエラーなしで終了するべき")
-	require.False(t, output.Enqueued, "既存ジョブがある場合はEnqueuedがfalseになるべき")
+	require.NoError(t, err, "保留中ジョブへの統合でエラーが発生")
+	require.True(t, output.Enqueued, "統合時もEnqueuedがtrueになるべき")
+	require.True(t, output.Merged, "保留中ジョブがある場合はMergedがtrueになるべき")
+	require.Equal(t, pendingJob.ID, output.JobID, "統合先の保留中ジョブのIDを返すべき")
+	require.Equal(t, []string{"8a2f5a32d847fff"}, jobRepo.enqueuedJob.AffectedH3Cells, "影響セルがリポジトリに渡されるべき")
 }
 
-// TestEnqueueJobUseCase_Execute_HasPendingJobError は保留中ジョブ確認でエラー時にエラーを返すことをテストする
-func TestEnqueueJobUseCase_Execute_HasPendingJobError(t *testing.T) {
-	jobRepo := &mockClusterJobRepository{hasPendingErr: errors.New("db error")}
+// TestEnqueueJobUseCase_Execute_TooManyAffectedCells は影響セル数が上限を超える場合に全範囲再計算になることをテストする
+func TestEnqueueJobUseCase_Execute_TooManyAffectedCells(t *testing.T) {
+	jobRepo := &mockClusterJobRepository{}
 	logger := getTestLogger()
 
 	uc := NewEnqueueJobUseCase(jobRepo, logger)
 
-	_, err := uc.Execute(context.Background(), EnqueueJobInput{Priority: 10})
+	output, err := uc.Execute(context.Background(), EnqueueJobInput{
+		Priority:        1,
+		AffectedH3Cells: make([]string, maxAffectedCells+1),
+	})
 
-	require.Error(t, err, "DB確認エラー時はエラーを返すべき")
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.True(t, output.Enqueued, "ジョブがエンキューされるべき")
+	require.False(t, output.Merged, "保留中ジョブがない場合はMergedがfalseになるべき")
+	require.True(t, jobRepo.enqueuedJob.IsFullRecalculation(), "上限を超える場合は全範囲再計算になるべき")
 }
 
-// TestEnqueueJobUseCase_Execute_CreateError はジョブ作成エラー時にエラーを返すことをテストする
-func TestEnqueueJobUseCase_Execute_CreateError(t *testing.T) {
+// TestEnqueueJobUseCase_Execute_EnqueueError はエンキューエラー時にエラーを返すことをテストする
+func TestEnqueueJobUseCase_Execute_EnqueueError(t *testing.T) {
 	jobRepo := &mockClusterJobRepository{
-		hasPendingJob: false,
-		createErr:     errors.New("create error"),
+		enqueueErr: errors.New("enqueue error"),
 	}
 	logger := getTestLogger()
 
@@ -68,12 +81,12 @@ func TestEnqueueJobUseCase_Execute_CreateError(t *testing.T) {
 
 	_, err := uc.Execute(context.Background(), EnqueueJobInput{Priority: 10})
 
-	require.Error(t, err, "ジョブ作成エラー時はエラーを返すべき")
+	require.Error(t, err, "エンキューエラー時はエラーを返すべき")
 }
 
 // TestEnqueueJobUseCase_Execute_ZeroPriority は優先度0でもエンキューできることをテストする
 func TestEnqueueJobUseCase_Execute_ZeroPriority(t *testing.T) {
-	jobRepo := &mockClusterJobRepository{hasPendingJob: false}
+	jobRepo := &mockClusterJobRepository{}
 	logger := getTestLogger()
 
 	uc := NewEnqueueJobUseCase(jobRepo, logger)
@@ -86,7 +99,7 @@ func TestEnqueueJobUseCase_Execute_ZeroPriority(t *testing.T) {
 
 // TestEnqueueJobUseCase_Execute_NegativePriority は負の優先度でもエンキューできることをテストする
 func TestEnqueueJobUseCase_Execute_NegativePriority(t *testing.T) {
-	jobRepo := &mockClusterJobRepository{hasPendingJob: false}
+	jobRepo := &mockClusterJobRepository{}
 	logger := getTestLogger()
 
 	uc := NewEnqueueJobUseCase(jobRepo, logger)
@@ -119,7 +132,7 @@ func TestNewClusterJobEnqueuer(t *testing.T) {
 
 // TestClusterJobEnqueuer_Enqueue はEnqueueがUseCaseを正しく呼び出すことをテストする
 func TestClusterJobEnqueuer_Enqueue(t *testing.T) {
-	jobRepo := &mockClusterJobRepository{hasPendingJob: false}
+	jobRepo := &mockClusterJobRepository{}
 	logger := getTestLogger()
 
 	uc := NewEnqueueJobUseCase(jobRepo, logger)
@@ -133,8 +146,7 @@ func TestClusterJobEnqueuer_Enqueue(t *testing.T) {
 // TestClusterJobEnqueuer_Enqueue_WithError はEnqueueがエラーを正しく伝播することをテストする
 func TestClusterJobEnqueuer_Enqueue_WithError(t *testing.T) {
 	jobRepo := &mockClusterJobRepository{
-		hasPendingJob: false,
-		createErr:     errors.New("create error"),
+		enqueueErr: errors.New("enqueue error"),
 	}
 	logger := getTestLogger()
 
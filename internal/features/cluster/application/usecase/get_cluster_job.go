@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/query"
+)
+
+// GetClusterJobUseCase はジョブ詳細取得のユースケース
+type GetClusterJobUseCase struct {
+	jobQuery query.ClusterJobQuery
+}
+
+// NewGetClusterJobUseCase は新しいGetClusterJobUseCaseを作成する
+func NewGetClusterJobUseCase(jobQuery query.ClusterJobQuery) *GetClusterJobUseCase {
+	return &GetClusterJobUseCase{
+		jobQuery: jobQuery,
+	}
+}
+
+// Execute はIDでジョブを取得する
+func (u *GetClusterJobUseCase) Execute(ctx context.Context, id uuid.UUID) (*query.ClusterJobSummary, error) {
+	job, err := u.jobQuery.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("ジョブの取得に失敗しました", err)
+	}
+	if job == nil {
+		return nil, apperror.NotFoundError("ジョブが見つかりません")
+	}
+	return job, nil
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetClusterJobUseCase_Execute(t *testing.T) {
+	id := uuid.New()
+	job := &query.ClusterJobSummary{ID: id, Status: entity.JobStatusCompleted}
+	mock := &mockClusterJobQuery{job: job}
+	uc := NewGetClusterJobUseCase(mock)
+
+	got, err := uc.Execute(context.Background(), id)
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, job, got, "ジョブが一致しない")
+	require.Equal(t, id, mock.gotID, "指定したIDで取得するべき")
+}
+
+func TestGetClusterJobUseCase_Execute_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      *mockClusterJobQuery
+		wantStatus int
+	}{
+		{"ジョブが存在しない", &mockClusterJobQuery{}, http.StatusNotFound},
+		{"取得エラー", &mockClusterJobQuery{findErr: errors.New("db error")}, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewGetClusterJobUseCase(tt.query)
+
+			_, err := uc.Execute(context.Background(), uuid.New())
+
+			require.Error(t, err, "エラーを期待")
+			require.Equal(t, tt.wantStatus, errorStatus(err), "HTTPステータスが期待値と異なります")
+		})
+	}
+}
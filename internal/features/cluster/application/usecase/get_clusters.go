@@ -4,6 +4,7 @@ package usecase
 import (
 	"context"
 	"log/slog"
+	"strings"
 
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
@@ -17,6 +18,16 @@ type GetClustersInput struct {
 	SWLng float64 // 南西端の経度
 	NELat float64 // 北東端の緯度
 	NELng float64 // 北東端の経度
+
+	// 絞り込み条件(全て未指定の場合は事前計算したクラスター結果を返す)
+	CityCode           *string  // 市区町村コード
+	SoilTypeSmallCode  *string  // 土壌小分類コード
+	LandCategoryCode   *string  // 土地種別コード(農地台帳)
+	IdleLandStatusCode *string  // 遊休農地状況コード(農地台帳)
+	MinAreaSqm         *float64 // 最小面積(平方メートル)
+	MaxAreaSqm         *float64 // 最大面積(平方メートル)
+
+	WithBoundary bool // セルの境界を含めるかどうか(GeoJSON出力用)
 }
 
 // GetClustersOutput はクラスター取得ユースケースの出力
@@ -30,6 +41,7 @@ type GetClustersUseCase struct {
 	clusterRepo repository.ClusterRepository
 	cacheRepo   repository.ClusterCacheRepository
 	jobRepo     repository.ClusterJobRepository
+	resolutions []entity.Resolution
 	logger      *slog.Logger
 }
 
@@ -44,42 +56,48 @@ func NewGetClustersUseCase(
 		clusterRepo: clusterRepo,
 		cacheRepo:   cacheRepo,
 		jobRepo:     jobRepo,
+		resolutions: entity.DefaultResolutions,
 		logger:      logger,
 	}
 }
 
+// NewGetClustersUseCaseWithResolutions はクラスターワーカーが計算する解像度を指定してGetClustersUseCaseを作成する
+// resolutionsが空の場合はデフォルトの解像度を使用する
+func NewGetClustersUseCaseWithResolutions(
+	clusterRepo repository.ClusterRepository,
+	cacheRepo repository.ClusterCacheRepository,
+	jobRepo repository.ClusterJobRepository,
+	resolutions []entity.Resolution,
+	logger *slog.Logger,
+) *GetClustersUseCase {
+	uc := NewGetClustersUseCase(clusterRepo, cacheRepo, jobRepo, logger)
+	if len(resolutions) > 0 {
+		uc.resolutions = resolutions
+	}
+	return uc
+}
+
 // Execute はクラスター取得を実行する
 func (u *GetClustersUseCase) Execute(ctx context.Context, input GetClustersInput) (*GetClustersOutput, error) {
 	// ズームレベルからH3解像度を決定
-	resolution := h3util.ZoomToResolution(input.Zoom)
+	resolution := h3util.ZoomToResolution(input.Zoom, u.resolutions)
 
 	// バウンディングボックスを作成
 	bbox := h3util.NewBoundingBox(input.SWLat, input.SWLng, input.NELat, input.NELng)
 
-	// キャッシュから取得を試みる
-	clusters, err := u.cacheRepo.GetClusters(ctx, resolution)
-	if err != nil {
-		// キャッシュエラーはログに残して続行
-		u.logger.Warn("キャッシュからの取得に失敗しました",
-			slog.String("error", err.Error()),
-			slog.String("resolution", resolution.String()))
+	// 絞り込み条件がある場合は圃場をその場で集計する
+	if filter := buildClusterFilter(input); !filter.IsEmpty() {
+		return u.executeFiltered(ctx, resolution, bbox, filter, input.WithBoundary)
 	}
 
-	// キャッシュミスの場合はDBから取得
-	if clusters == nil {
-		clusters, err = u.clusterRepo.GetClusters(ctx, resolution)
-		if err != nil {
-			return nil, err
-		}
-
-		// 取得したデータをキャッシュに保存
-		if len(clusters) > 0 {
-			if cacheErr := u.cacheRepo.SetClusters(ctx, resolution, clusters); cacheErr != nil {
-				u.logger.Warn("キャッシュへの保存に失敗しました",
-					slog.String("error", cacheErr.Error()),
-					slog.String("resolution", resolution.String()))
-			}
-		}
+	// 表示範囲を覆う検索セルのクラスター結果のみ取得する
+	cells, err := h3util.ViewportCells(bbox, resolution)
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := u.getClustersInCells(ctx, resolution, cells)
+	if err != nil {
+		return nil, err
 	}
 
 	// BoundingBox内のクラスターをフィルタリング
@@ -96,10 +114,7 @@ func (u *GetClustersUseCase) Execute(ctx context.Context, input GetClustersInput
 	}
 
 	// レスポンス用に変換
-	results := make([]*entity.ClusterResult, 0, len(filteredClusters))
-	for _, cluster := range filteredClusters {
-		results = append(results, cluster.ToResult())
-	}
+	results := u.toResults(filteredClusters, input.WithBoundary)
 
 	return &GetClustersOutput{
 		Clusters: results,
@@ -107,6 +122,225 @@ func (u *GetClustersUseCase) Execute(ctx context.Context, input GetClustersInput
 	}, nil
 }
 
+// getClustersInCells は検索セルに含まれる有効な世代のクラスター結果をキャッシュまたはDBから取得する
+//
+// キャッシュは世代・検索セルごとに保持し、キャッシュにない検索セルのみDBから取得してキャッシュに保存する。
+// クラスターがない検索セルも空の結果としてキャッシュする
+func (u *GetClustersUseCase) getClustersInCells(ctx context.Context, resolution entity.Resolution, cells []string) ([]*entity.Cluster, error) {
+	if len(cells) == 0 {
+		return []*entity.Cluster{}, nil
+	}
+
+	// 1リクエスト内では同じ世代を参照し、全範囲再計算による切り替え前後の結果が混在しないようにする
+	generation, err := u.clusterRepo.GetActiveGeneration(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, err := u.cacheRepo.GetClustersByCells(ctx, generation, resolution, cells)
+	if err != nil {
+		// キャッシュエラーはログに残して続行
+		u.logger.Warn("キャッシュからの取得に失敗しました",
+			slog.String("error", err.Error()),
+			slog.String("resolution", resolution.String()))
+		cached = nil
+	}
+
+	clusters := make([]*entity.Cluster, 0)
+	missing := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		cellClusters, ok := cached[cell]
+		if !ok {
+			missing = append(missing, cell)
+			continue
+		}
+		clusters = append(clusters, cellClusters...)
+	}
+	if len(missing) == 0 {
+		return clusters, nil
+	}
+
+	// キャッシュミスの検索セルはDBから取得
+	fetched, err := u.clusterRepo.GetClustersInCells(ctx, generation, resolution, missing)
+	if err != nil {
+		return nil, err
+	}
+	clusters = append(clusters, fetched...)
+
+	// 取得したデータを検索セルごとにまとめてキャッシュに保存
+	lookupResolution := entity.Resolution(h3util.GetResolution(missing[0]))
+	clustersByCell := make(map[string][]*entity.Cluster, len(missing))
+	for _, cell := range missing {
+		clustersByCell[cell] = []*entity.Cluster{}
+	}
+	for _, cluster := range fetched {
+		cell, ok := h3util.ParentCell(cluster.H3Index, lookupResolution)
+		if !ok {
+			continue
+		}
+		if _, found := clustersByCell[cell]; !found {
+			continue
+		}
+		clustersByCell[cell] = append(clustersByCell[cell], cluster)
+	}
+	if cacheErr := u.cacheRepo.SetClustersByCells(ctx, generation, resolution, clustersByCell); cacheErr != nil {
+		u.logger.Warn("キャッシュへの保存に失敗しました",
+			slog.String("error", cacheErr.Error()),
+			slog.String("resolution", resolution.String()))
+	}
+
+	return clusters, nil
+}
+
+// executeFiltered は絞り込み条件に一致する圃場をその場で集計する
+//
+// 事前計算したクラスター結果を使用しないため、再計算ジョブの状態に関わらずIsStaleはfalseとする
+func (u *GetClustersUseCase) executeFiltered(ctx context.Context, resolution entity.Resolution, bbox *h3util.BoundingBox, filter *entity.ClusterFilter, withBoundary bool) (*GetClustersOutput, error) {
+	// 表示範囲を覆う検索セルの集計結果のみ取得する
+	cells, err := h3util.ViewportCells(bbox, resolution)
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := u.getFilteredClustersInCells(ctx, resolution, filter, cells)
+	if err != nil {
+		return nil, err
+	}
+
+	results := u.toResults(u.filterClustersByBBox(clusters, bbox), withBoundary)
+
+	return &GetClustersOutput{
+		Clusters: results,
+		IsStale:  false,
+	}, nil
+}
+
+// getFilteredClustersInCells は検索セルに含まれる絞り込み集計の結果をキャッシュまたはその場の集計から取得する
+//
+// キャッシュは絞り込み条件・検索セルごとに短いTTLで保持し、表示範囲が少しずれても重なる検索セルの結果を再利用する。
+// キャッシュにない検索セルのみ集計し、該当する圃場がない検索セルも空の結果としてキャッシュする
+func (u *GetClustersUseCase) getFilteredClustersInCells(ctx context.Context, resolution entity.Resolution, filter *entity.ClusterFilter, cells []string) ([]*entity.Cluster, error) {
+	if len(cells) == 0 {
+		return []*entity.Cluster{}, nil
+	}
+
+	filterHash := filter.Hash()
+	cached, err := u.cacheRepo.GetFilteredClustersByCells(ctx, resolution, filterHash, cells)
+	if err != nil {
+		// キャッシュエラーはログに残して続行
+		u.logger.Warn("絞り込み集計のキャッシュからの取得に失敗しました",
+			slog.String("error", err.Error()),
+			slog.String("resolution", resolution.String()))
+		cached = nil
+	}
+
+	clusters := make([]*entity.Cluster, 0)
+	missing := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		cellClusters, ok := cached[cell]
+		if !ok {
+			missing = append(missing, cell)
+			continue
+		}
+		clusters = append(clusters, cellClusters...)
+	}
+	if len(missing) == 0 {
+		return clusters, nil
+	}
+
+	// 子孫のセルは検索セルの境界から少しはみ出すため、検索セルの半径分だけ範囲を広げて集計する
+	lookupResolution := entity.Resolution(h3util.GetResolution(missing[0]))
+	missingBBox, err := h3util.CellsBoundingBox(missing)
+	if err != nil {
+		return nil, err
+	}
+	expanded, err := missingBBox.ExpandByCell(lookupResolution)
+	if err != nil {
+		return nil, err
+	}
+	aggregated, err := u.clusterRepo.AggregateFiltered(ctx, resolution, filter, repository.Bounds{
+		SWLat: expanded.SWLat,
+		SWLng: expanded.SWLng,
+		NELat: expanded.NELat,
+		NELng: expanded.NELng,
+	})
+	if err != nil {
+		return nil, err
+	}
+	converted, err := h3util.ConvertAggregatedToClusters(resolution, aggregated)
+	if err != nil {
+		return nil, err
+	}
+
+	// 集計結果を検索セルごとにまとめ、キャッシュミスの検索セルに含まれるもののみ使用する
+	clustersByCell := make(map[string][]*entity.Cluster, len(missing))
+	for _, cell := range missing {
+		clustersByCell[cell] = []*entity.Cluster{}
+	}
+	for _, cluster := range converted {
+		cell, ok := h3util.ParentCell(cluster.H3Index, lookupResolution)
+		if !ok {
+			continue
+		}
+		if _, found := clustersByCell[cell]; !found {
+			continue
+		}
+		clustersByCell[cell] = append(clustersByCell[cell], cluster)
+		clusters = append(clusters, cluster)
+	}
+	if cacheErr := u.cacheRepo.SetFilteredClustersByCells(ctx, resolution, filterHash, clustersByCell); cacheErr != nil {
+		u.logger.Warn("絞り込み集計のキャッシュへの保存に失敗しました",
+			slog.String("error", cacheErr.Error()),
+			slog.String("resolution", resolution.String()))
+	}
+
+	return clusters, nil
+}
+
+// toResults はクラスターをレスポンス用に変換する
+// withBoundaryがtrueの場合はセルの境界を設定し、境界を取得できないクラスターは除外する
+func (u *GetClustersUseCase) toResults(clusters []*entity.Cluster, withBoundary bool) []*entity.ClusterResult {
+	results := make([]*entity.ClusterResult, 0, len(clusters))
+	for _, cluster := range clusters {
+		result := cluster.ToResult()
+		if withBoundary {
+			boundary, err := h3util.CellBoundary(cluster.H3Index)
+			if err != nil {
+				u.logger.Warn("セルの境界の取得に失敗しました",
+					slog.String("error", err.Error()),
+					slog.String("h3_index", cluster.H3Index))
+				continue
+			}
+			result.Boundary = boundary
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// buildClusterFilter は入力値から絞り込み条件を構築する
+func buildClusterFilter(input GetClustersInput) *entity.ClusterFilter {
+	return &entity.ClusterFilter{
+		CityCode:           normalizeString(input.CityCode),
+		SoilTypeSmallCode:  normalizeString(input.SoilTypeSmallCode),
+		LandCategoryCode:   normalizeString(input.LandCategoryCode),
+		IdleLandStatusCode: normalizeString(input.IdleLandStatusCode),
+		MinAreaSqm:         input.MinAreaSqm,
+		MaxAreaSqm:         input.MaxAreaSqm,
+	}
+}
+
+// normalizeString は前後の空白を除去し、空文字列の場合はnilを返す
+func normalizeString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
 // filterClustersByBBox はBoundingBox内のクラスターをフィルタリングする
 func (u *GetClustersUseCase) filterClustersByBBox(clusters []*entity.Cluster, bbox *h3util.BoundingBox) []*entity.Cluster {
 	if bbox == nil || !bbox.IsValid() {
@@ -4,14 +4,18 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"maps"
 	"os"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/internal/h3util"
 	"github.com/stretchr/testify/require"
+	"github.com/uber/h3-go/v4"
 )
 
 // mockClusterRepository はClusterRepositoryのモック実装
@@ -22,64 +26,212 @@ type mockClusterRepository struct {
 	saveErr      error
 	aggregateErr error
 	deleteErr    error
+
+	coverageMode  entity.AggregationMode // AggregateByCoverage(ForCells)に渡された集計方法
+	coverageCells []string               // AggregateByCoverageForCellsに渡されたセル
+
+	filteredCalls  int                   // AggregateFilteredの呼び出し回数
+	filteredFilter *entity.ClusterFilter // AggregateFilteredに渡された絞り込み条件
+	filteredBounds repository.Bounds     // AggregateFilteredに渡された範囲
+
+	gotGeneration int64             // GetClustersInCellsに渡された世代
+	gotResolution entity.Resolution // GetClustersInCellsに渡された解像度
+	gotCells      []string          // GetClustersInCellsに渡された検索セル
+
+	activeGeneration int64   // GetActiveGenerationで返す世代
+	liveGenerations  []int64 // ListLiveGenerationsで返す世代(nilの場合は有効な世代のみ)
+	nextGeneration   int64   // CreateGenerationで返す世代
+	superseded       bool    // trueの場合はActivateGenerationで有効化しない
+	generationErr    error
+	activateErr      error
+
+	activated          []int64       // ActivateGenerationに渡された世代
+	discarded          []int64       // DeleteGenerationに渡された世代
+	unusedDeleted      bool          // DeleteUnusedGenerationsが呼ばれたかどうか
+	staleAfter         time.Duration // DeleteUnusedGenerationsに渡された期間
+	savedGenerations   []int64       // SaveClustersに渡された世代
+	deletedGenerations []int64       // DeleteClustersByH3Indexesに渡された世代
+}
+
+func (m *mockClusterRepository) GetActiveGeneration(_ context.Context) (int64, error) {
+	if m.generationErr != nil {
+		return 0, m.generationErr
+	}
+	return m.activeGeneration, nil
+}
+
+func (m *mockClusterRepository) ListLiveGenerations(_ context.Context) ([]int64, error) {
+	if m.generationErr != nil {
+		return nil, m.generationErr
+	}
+	if m.liveGenerations == nil {
+		return []int64{m.activeGeneration}, nil
+	}
+	return m.liveGenerations, nil
+}
+
+func (m *mockClusterRepository) CreateGeneration(_ context.Context) (int64, error) {
+	if m.generationErr != nil {
+		return 0, m.generationErr
+	}
+	return m.nextGeneration, nil
+}
+
+func (m *mockClusterRepository) ActivateGeneration(_ context.Context, generation int64) (bool, error) {
+	if m.activateErr != nil {
+		return false, m.activateErr
+	}
+	if m.superseded {
+		return false, nil
+	}
+	m.activated = append(m.activated, generation)
+	return true, nil
 }
 
-func (m *mockClusterRepository) GetClusters(_ context.Context, _ entity.Resolution) ([]*entity.Cluster, error) {
+func (m *mockClusterRepository) DeleteGeneration(_ context.Context, generation int64) error {
+	m.discarded = append(m.discarded, generation)
+	return nil
+}
+
+func (m *mockClusterRepository) DeleteUnusedGenerations(_ context.Context, staleAfter time.Duration) (int64, error) {
+	m.unusedDeleted = true
+	m.staleAfter = staleAfter
+	return 0, nil
+}
+
+func (m *mockClusterRepository) GetClustersInCells(_ context.Context, generation int64, resolution entity.Resolution, cells []string) ([]*entity.Cluster, error) {
+	m.gotGeneration = generation
+	m.gotResolution = resolution
+	m.gotCells = cells
 	if m.getErr != nil {
 		return nil, m.getErr
 	}
 	return m.clusters, nil
 }
 
-func (m *mockClusterRepository) SaveClusters(_ context.Context, _ []*entity.Cluster) error {
+func (m *mockClusterRepository) SaveClusters(_ context.Context, generation int64, _ []*entity.Cluster) error {
+	m.savedGenerations = append(m.savedGenerations, generation)
 	return m.saveErr
 }
 
-func (m *mockClusterRepository) DeleteClustersByResolution(_ context.Context, _ entity.Resolution) error {
-	return m.deleteErr
+func (m *mockClusterRepository) AggregateByH3(_ context.Context, _ entity.Resolution) ([]*repository.AggregatedCluster, error) {
+	if m.aggregateErr != nil {
+		return nil, m.aggregateErr
+	}
+	return m.aggregated, nil
 }
 
-func (m *mockClusterRepository) DeleteAllClusters(_ context.Context) error {
-	return m.deleteErr
+func (m *mockClusterRepository) AggregateByH3ForCells(_ context.Context, _ entity.Resolution, _ []string) ([]*repository.AggregatedCluster, error) {
+	if m.aggregateErr != nil {
+		return nil, m.aggregateErr
+	}
+	return m.aggregated, nil
 }
 
-func (m *mockClusterRepository) AggregateByH3(_ context.Context, _ entity.Resolution) ([]*repository.AggregatedCluster, error) {
+func (m *mockClusterRepository) AggregateByCoverage(_ context.Context, _ entity.Resolution, mode entity.AggregationMode) ([]*repository.AggregatedCluster, error) {
+	m.coverageMode = mode
 	if m.aggregateErr != nil {
 		return nil, m.aggregateErr
 	}
 	return m.aggregated, nil
 }
 
-func (m *mockClusterRepository) AggregateByH3ForCells(_ context.Context, _ entity.Resolution, _ []string) ([]*repository.AggregatedCluster, error) {
+func (m *mockClusterRepository) AggregateByCoverageForCells(_ context.Context, _ entity.Resolution, mode entity.AggregationMode, h3Cells []string) ([]*repository.AggregatedCluster, error) {
+	m.coverageMode = mode
+	m.coverageCells = append(m.coverageCells, h3Cells...)
 	if m.aggregateErr != nil {
 		return nil, m.aggregateErr
 	}
 	return m.aggregated, nil
 }
 
-func (m *mockClusterRepository) DeleteClustersByH3Indexes(_ context.Context, _ entity.Resolution, _ []string) error {
+func (m *mockClusterRepository) AggregateFiltered(_ context.Context, _ entity.Resolution, filter *entity.ClusterFilter, bounds repository.Bounds) ([]*repository.AggregatedCluster, error) {
+	m.filteredCalls++
+	m.filteredFilter = filter
+	m.filteredBounds = bounds
+	if m.aggregateErr != nil {
+		return nil, m.aggregateErr
+	}
+	return m.aggregated, nil
+}
+
+func (m *mockClusterRepository) DeleteClustersByH3Indexes(_ context.Context, generation int64, _ entity.Resolution, _ []string) error {
+	m.deletedGenerations = append(m.deletedGenerations, generation)
 	return m.deleteErr
 }
 
 // mockClusterCacheRepository はClusterCacheRepositoryのモック実装
 type mockClusterCacheRepository struct {
-	clusters  []*entity.Cluster
+	clusters  []*entity.Cluster            // nil以外の場合は全ての検索セルをキャッシュヒットとして返す
+	byCell    map[string][]*entity.Cluster // 検索セルごとのキャッシュ(clustersがnilの場合に使用)
 	getErr    error
 	setErr    error
 	deleteErr error
+
+	setByCell     map[string][]*entity.Cluster // SetClustersByCellsに渡された結果
+	getGeneration int64                        // GetClustersByCellsに渡された世代
+	setGeneration int64                        // SetClustersByCellsに渡された世代
+
+	filteredClusters   []*entity.Cluster            // nil以外の場合は全ての検索セルを絞り込み集計のキャッシュヒットとして返す
+	filteredByCell     map[string][]*entity.Cluster // 検索セルごとの絞り込み集計のキャッシュ(filteredClustersがnilの場合に使用)
+	filteredHashes     []string                     // SetFilteredClustersByCellsに渡された絞り込み条件のハッシュ値
+	filteredSetByCell  map[string][]*entity.Cluster // SetFilteredClustersByCellsに渡された結果
+	storeFilteredCache bool                         // trueの場合はSetFilteredClustersByCellsの結果をfilteredByCellに保存する
 }
 
-func (m *mockClusterCacheRepository) GetClusters(_ context.Context, _ entity.Resolution) ([]*entity.Cluster, error) {
+func (m *mockClusterCacheRepository) GetClustersByCells(_ context.Context, generation int64, _ entity.Resolution, cells []string) (map[string][]*entity.Cluster, error) {
+	m.getGeneration = generation
 	if m.getErr != nil {
 		return nil, m.getErr
 	}
-	return m.clusters, nil
+	return cachedByCells(cells, m.clusters, m.byCell), nil
+}
+
+func (m *mockClusterCacheRepository) SetClustersByCells(_ context.Context, generation int64, _ entity.Resolution, clustersByCell map[string][]*entity.Cluster) error {
+	m.setGeneration = generation
+	m.setByCell = clustersByCell
+	return m.setErr
 }
 
-func (m *mockClusterCacheRepository) SetClusters(_ context.Context, _ entity.Resolution, _ []*entity.Cluster) error {
+func (m *mockClusterCacheRepository) GetFilteredClustersByCells(_ context.Context, _ entity.Resolution, _ string, cells []string) (map[string][]*entity.Cluster, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	return cachedByCells(cells, m.filteredClusters, m.filteredByCell), nil
+}
+
+func (m *mockClusterCacheRepository) SetFilteredClustersByCells(_ context.Context, _ entity.Resolution, filterHash string, clustersByCell map[string][]*entity.Cluster) error {
+	m.filteredHashes = append(m.filteredHashes, filterHash)
+	m.filteredSetByCell = clustersByCell
+	if m.storeFilteredCache {
+		if m.filteredByCell == nil {
+			m.filteredByCell = make(map[string][]*entity.Cluster)
+		}
+		maps.Copy(m.filteredByCell, clustersByCell)
+	}
 	return m.setErr
 }
 
+// cachedByCells はモックのキャッシュから検索セルごとの結果を返す
+// allがnil以外の場合は全ての検索セルをキャッシュヒットとし、先頭の検索セルにallを割り当てる
+func cachedByCells(cells []string, all []*entity.Cluster, byCell map[string][]*entity.Cluster) map[string][]*entity.Cluster {
+	result := make(map[string][]*entity.Cluster)
+	for i, cell := range cells {
+		if all != nil {
+			if i == 0 {
+				result[cell] = all
+			} else {
+				result[cell] = []*entity.Cluster{}
+			}
+			continue
+		}
+		if clusters, ok := byCell[cell]; ok {
+			result[cell] = clusters
+		}
+	}
+	return result
+}
+
 func (m *mockClusterCacheRepository) DeleteClusters(_ context.Context) error {
 	return m.deleteErr
 }
@@ -88,22 +240,39 @@ func (m *mockClusterCacheRepository) DeleteClusters(_ context.Context) error {
 type mockClusterJobRepository struct {
 	jobs                 []*entity.ClusterJob
 	hasPendingJob        bool
-	createErr            error
+	pendingJob           *entity.ClusterJob // 統合先となる保留中ジョブ(nil=新規作成)
+	enqueuedJob          *entity.ClusterJob
+	claimed              int
+	claimWorkerID        string
+	completedIDs         []uuid.UUID
+	recovered            *repository.RecoveredJobs
+	cancelled            bool
+	deletedCompleted     int64
+	deletedFailed        int64
+	completedRetention   time.Duration
+	failedRetention      time.Duration
+	enqueueErr           error
 	findByIDErr          error
-	findPendingErr       error
+	claimErr             error
+	heartbeatErr         error
+	recoverErr           error
 	updateErr            error
 	updateToCompletedErr error
 	updateToFailedErr    error
 	hasPendingErr        error
+	cancelErr            error
 	deleteErr            error
 }
 
-func (m *mockClusterJobRepository) Create(_ context.Context, _ *entity.ClusterJob) error {
-	return m.createErr
-}
-
-func (m *mockClusterJobRepository) CreateWithAffectedCells(_ context.Context, _ *entity.ClusterJob) error {
-	return m.createErr
+func (m *mockClusterJobRepository) Enqueue(_ context.Context, job *entity.ClusterJob, _ int32) (*entity.ClusterJob, bool, error) {
+	m.enqueuedJob = job
+	if m.enqueueErr != nil {
+		return nil, false, m.enqueueErr
+	}
+	if m.pendingJob != nil {
+		return m.pendingJob, true, nil
+	}
+	return job, false, nil
 }
 
 func (m *mockClusterJobRepository) FindByID(_ context.Context, _ uuid.UUID) (*entity.ClusterJob, error) {
@@ -116,38 +285,51 @@ func (m *mockClusterJobRepository) FindByID(_ context.Context, _ uuid.UUID) (*en
 	return nil, nil
 }
 
-func (m *mockClusterJobRepository) FindPendingJobs(_ context.Context, _ int32) ([]*entity.ClusterJob, error) {
-	if m.findPendingErr != nil {
-		return nil, m.findPendingErr
+func (m *mockClusterJobRepository) Claim(_ context.Context, workerID string) (*entity.ClusterJob, error) {
+	if m.claimErr != nil {
+		return nil, m.claimErr
 	}
-	return m.jobs, nil
-}
-
-func (m *mockClusterJobRepository) FindPendingJobsWithAffectedCells(_ context.Context, _ int32) ([]*entity.ClusterJob, error) {
-	if m.findPendingErr != nil {
-		return nil, m.findPendingErr
+	m.claimWorkerID = workerID
+	if m.claimed >= len(m.jobs) {
+		return nil, nil
 	}
-	return m.jobs, nil
+	job := m.jobs[m.claimed]
+	m.claimed++
+	return job, nil
 }
 
-func (m *mockClusterJobRepository) UpdateToProcessing(_ context.Context, _ uuid.UUID) error {
-	return m.updateErr
+func (m *mockClusterJobRepository) Heartbeat(_ context.Context, _ uuid.UUID, _ string) error {
+	return m.heartbeatErr
 }
 
-func (m *mockClusterJobRepository) UpdateToCompleted(_ context.Context, _ uuid.UUID) error {
+func (m *mockClusterJobRepository) UpdateToCompleted(_ context.Context, id uuid.UUID, _ string) error {
 	if m.updateToCompletedErr != nil {
 		return m.updateToCompletedErr
 	}
-	return m.updateErr
+	if m.updateErr != nil {
+		return m.updateErr
+	}
+	m.completedIDs = append(m.completedIDs, id)
+	return nil
 }
 
-func (m *mockClusterJobRepository) UpdateToFailed(_ context.Context, _ uuid.UUID, _ string) error {
+func (m *mockClusterJobRepository) UpdateToFailed(_ context.Context, _ uuid.UUID, _ string, _ string) error {
 	if m.updateToFailedErr != nil {
 		return m.updateToFailedErr
 	}
 	return m.updateErr
 }
 
+func (m *mockClusterJobRepository) RecoverExpiredJobs(_ context.Context, _ time.Duration, _ int32, _ int32) (*repository.RecoveredJobs, error) {
+	if m.recoverErr != nil {
+		return nil, m.recoverErr
+	}
+	if m.recovered != nil {
+		return m.recovered, nil
+	}
+	return &repository.RecoveredJobs{}, nil
+}
+
 func (m *mockClusterJobRepository) HasPendingOrProcessingJob(_ context.Context) (bool, error) {
 	if m.hasPendingErr != nil {
 		return false, m.hasPendingErr
@@ -155,12 +337,27 @@ func (m *mockClusterJobRepository) HasPendingOrProcessingJob(_ context.Context)
 	return m.hasPendingJob, nil
 }
 
-func (m *mockClusterJobRepository) DeleteOldCompletedJobs(_ context.Context) error {
-	return m.deleteErr
+func (m *mockClusterJobRepository) Cancel(_ context.Context, _ uuid.UUID) (bool, error) {
+	if m.cancelErr != nil {
+		return false, m.cancelErr
+	}
+	return m.cancelled, nil
 }
 
-func (m *mockClusterJobRepository) DeleteOldFailedJobs(_ context.Context) error {
-	return m.deleteErr
+func (m *mockClusterJobRepository) DeleteOldCompletedJobs(_ context.Context, retention time.Duration) (int64, error) {
+	m.completedRetention = retention
+	if m.deleteErr != nil {
+		return 0, m.deleteErr
+	}
+	return m.deletedCompleted, nil
+}
+
+func (m *mockClusterJobRepository) DeleteOldFailedJobs(_ context.Context, retention time.Duration) (int64, error) {
+	m.failedRetention = retention
+	if m.deleteErr != nil {
+		return 0, m.deleteErr
+	}
+	return m.deletedFailed, nil
 }
 
 // getTestLogger はテスト用のロガーを返す
@@ -419,13 +616,14 @@ func TestGetClustersUseCase_Execute_EmptyClusters(t *testing.T) {
 // TestGetClustersUseCase_Execute_ZoomToResolution は各ズームレベルで正しい解像度が使用されることをテストする
 func TestGetClustersUseCase_Execute_ZoomToResolution(t *testing.T) {
 	tests := []struct {
-		name string
-		zoom float64
+		name       string
+		zoom       float64
+		resolution entity.Resolution
 	}{
-		{"zoom 3はRes3", 3.0},
-		{"zoom 8はRes5", 8.0},
-		{"zoom 12はRes7", 12.0},
-		{"zoom 18はRes9", 18.0},
+		{"zoom 3はRes3", 3.0, entity.Res3},
+		{"zoom 8はRes5", 8.0, entity.Res5},
+		{"zoom 12はRes7", 12.0, entity.Res7},
+		{"zoom 18はRes9", 18.0, entity.Res9},
 	}
 
 	for _, tt := range tests {
@@ -449,10 +647,23 @@ func TestGetClustersUseCase_Execute_ZoomToResolution(t *testing.T) {
 
 			require.NoError(t, err, "Executeでエラーが発生")
 			require.NotNil(t, output, "出力がnilです")
+			require.Equal(t, tt.resolution, clusterRepo.gotResolution, "解像度が一致しない")
 		})
 	}
 }
 
+// TestGetClustersUseCase_Execute_ConfiguredResolutions は設定された解像度からズームレベルに応じた解像度が使用されることをテストする
+func TestGetClustersUseCase_Execute_ConfiguredResolutions(t *testing.T) {
+	clusterRepo := &mockClusterRepository{clusters: []*entity.Cluster{}}
+	resolutions := []entity.Resolution{2, 3, 4, 5, 6, 7, 8, 9, 10}
+	uc := NewGetClustersUseCaseWithResolutions(clusterRepo, &mockClusterCacheRepository{}, &mockClusterJobRepository{}, resolutions, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), GetClustersInput{Zoom: 12, SWLat: 35.0, SWLng: 139.0, NELat: 36.0, NELng: 140.0})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, entity.Resolution(8), clusterRepo.gotResolution, "zoom 12では解像度8を使用するべき")
+}
+
 // TestGetClustersUseCase_Execute_HasPendingJobError はHasPendingOrProcessingJobエラー時に安全側に倒してtrueを返すことをテストする
 func TestGetClustersUseCase_Execute_HasPendingJobError(t *testing.T) {
 	clusters := []*entity.Cluster{
@@ -526,6 +737,100 @@ func TestGetClustersUseCase_Execute_CacheSetError(t *testing.T) {
 	require.Len(t, output.Clusters, 1, "クラスター数が期待値と異なります")
 }
 
+// TestGetClustersUseCase_Execute_PartialCacheHit はキャッシュにない検索セルのみDBから取得し、検索セルごとにキャッシュすることをテストする
+func TestGetClustersUseCase_Execute_PartialCacheHit(t *testing.T) {
+	input := GetClustersInput{Zoom: 12.0, SWLat: 35.6, SWLng: 139.6, NELat: 35.8, NELng: 139.9}
+	resolution := entity.Res7
+	cells, err := h3util.ViewportCells(h3util.NewBoundingBox(input.SWLat, input.SWLng, input.NELat, input.NELng), resolution)
+	require.NoError(t, err, "ViewportCellsでエラーが発生")
+
+	// DBから取得するクラスター(東京駅周辺)
+	dbCell, err := h3.LatLngToCell(h3.NewLatLng(35.681236, 139.767125), int(resolution))
+	require.NoError(t, err, "LatLngToCellでエラーが発生")
+	center, err := dbCell.LatLng()
+	require.NoError(t, err, "LatLngでエラーが発生")
+	dbParent, ok := h3util.ParentCell(dbCell.String(), h3util.LookupCellResolution(resolution))
+	require.True(t, ok, "親セルが取得できない")
+	require.Contains(t, cells, dbParent, "検索セルにクラスターの親セルが含まれるべき")
+
+	// 親セル以外の検索セルを1つキャッシュ済みとする
+	cachedCell := cells[0]
+	if cachedCell == dbParent {
+		cachedCell = cells[1]
+	}
+	cachedCluster := &entity.Cluster{Resolution: resolution, H3Index: "871f1a4adffffff", FieldCount: 2, CenterLat: 35.7, CenterLng: 139.7}
+	dbCluster := &entity.Cluster{Resolution: resolution, H3Index: dbCell.String(), FieldCount: 5, CenterLat: center.Lat, CenterLng: center.Lng}
+
+	clusterRepo := &mockClusterRepository{clusters: []*entity.Cluster{dbCluster}, activeGeneration: 5}
+	cacheRepo := &mockClusterCacheRepository{byCell: map[string][]*entity.Cluster{cachedCell: {cachedCluster}}}
+	uc := NewGetClustersUseCase(clusterRepo, cacheRepo, &mockClusterJobRepository{}, getTestLogger())
+
+	output, err := uc.Execute(context.Background(), input)
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Len(t, output.Clusters, 2, "キャッシュとDBのクラスターを合わせて返すべき")
+	require.Equal(t, int64(5), cacheRepo.getGeneration, "有効な世代のキャッシュを取得するべき")
+	require.Equal(t, int64(5), clusterRepo.gotGeneration, "有効な世代のクラスター結果を取得するべき")
+	require.Equal(t, int64(5), cacheRepo.setGeneration, "有効な世代のキャッシュとして保存するべき")
+	require.Len(t, clusterRepo.gotCells, len(cells)-1, "キャッシュにない検索セルのみDBから取得するべき")
+	require.NotContains(t, clusterRepo.gotCells, cachedCell, "キャッシュ済みの検索セルはDBから取得しないべき")
+	require.Len(t, cacheRepo.setByCell, len(cells)-1, "DBから取得した検索セルを全てキャッシュするべき")
+	require.Equal(t, []*entity.Cluster{dbCluster}, cacheRepo.setByCell[dbParent], "クラスターは親の検索セルにキャッシュするべき")
+	for cell, clusters := range cacheRepo.setByCell {
+		if cell != dbParent {
+			require.Empty(t, clusters, "クラスターがない検索セルは空の結果としてキャッシュするべき")
+		}
+	}
+}
+
+// TestGetClustersUseCase_Execute_ActiveGenerationError は有効な世代の取得に失敗した場合にエラーを返すことをテストする
+func TestGetClustersUseCase_Execute_ActiveGenerationError(t *testing.T) {
+	clusterRepo := &mockClusterRepository{generationErr: errors.New("db error")}
+	cacheRepo := &mockClusterCacheRepository{clusters: []*entity.Cluster{}}
+	uc := NewGetClustersUseCase(clusterRepo, cacheRepo, &mockClusterJobRepository{}, getTestLogger())
+
+	output, err := uc.Execute(context.Background(), GetClustersInput{Zoom: 12.0, SWLat: 35.0, SWLng: 139.0, NELat: 36.0, NELng: 140.0})
+
+	require.Error(t, err, "有効な世代の取得に失敗した場合はエラーを返すべき")
+	require.Nil(t, output, "エラー時は出力がnilであるべき")
+}
+
+// TestGetClustersUseCase_Execute_FullCacheHit は全ての検索セルがキャッシュにある場合はDBに問い合わせないことをテストする
+func TestGetClustersUseCase_Execute_FullCacheHit(t *testing.T) {
+	clusterRepo := &mockClusterRepository{getErr: errors.New("db should not be called")}
+	cacheRepo := &mockClusterCacheRepository{clusters: []*entity.Cluster{}}
+	uc := NewGetClustersUseCase(clusterRepo, cacheRepo, &mockClusterJobRepository{}, getTestLogger())
+
+	output, err := uc.Execute(context.Background(), GetClustersInput{Zoom: 12.0, SWLat: 35.0, SWLng: 139.0, NELat: 36.0, NELng: 140.0})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Empty(t, output.Clusters, "クラスター数は0であるべき")
+	require.Nil(t, clusterRepo.gotCells, "DBから取得しないべき")
+	require.Nil(t, cacheRepo.setByCell, "キャッシュに保存しないべき")
+}
+
+// TestGetClustersUseCase_Execute_WithBoundary はWithBoundaryを指定した場合のみセルの境界を設定することをテストする
+func TestGetClustersUseCase_Execute_WithBoundary(t *testing.T) {
+	clusters := []*entity.Cluster{
+		{Resolution: entity.Res7, H3Index: "871f1a4adffffff", FieldCount: 10, CenterLat: 35.5, CenterLng: 139.5},
+		{Resolution: entity.Res7, H3Index: "invalid", FieldCount: 1, CenterLat: 35.5, CenterLng: 139.5},
+	}
+	input := GetClustersInput{Zoom: 12.0, SWLat: 35.0, SWLng: 139.0, NELat: 36.0, NELng: 140.0}
+
+	uc := NewGetClustersUseCase(&mockClusterRepository{}, &mockClusterCacheRepository{clusters: clusters}, &mockClusterJobRepository{}, getTestLogger())
+	output, err := uc.Execute(context.Background(), input)
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Len(t, output.Clusters, 2, "クラスター数が期待値と異なります")
+	require.Nil(t, output.Clusters[0].Boundary, "WithBoundaryなしでは境界を設定しないべき")
+
+	input.WithBoundary = true
+	output, err = uc.Execute(context.Background(), input)
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Len(t, output.Clusters, 1, "境界を取得できないクラスターは除外するべき")
+	require.Len(t, output.Clusters[0].Boundary, 1, "境界は1つのポリゴンであるべき")
+	require.Len(t, output.Clusters[0].Boundary[0], 7, "六角形の閉じたリングは7点であるべき")
+}
+
 // TestGetClustersInput はGetClustersInputの構造体が正しくフィールドを持つことをテストする
 func TestGetClustersInput(t *testing.T) {
 	input := GetClustersInput{
@@ -569,3 +874,194 @@ func TestGetClustersOutput(t *testing.T) {
 		t.Error("IsStale = false, 期待値 true")
 	}
 }
+
+// res7Cell は指定座標を含む解像度7のセルを返す
+func res7Cell(t *testing.T, lat, lng float64) string {
+	t.Helper()
+	cell, err := h3.LatLngToCell(h3.NewLatLng(lat, lng), 7)
+	require.NoError(t, err, "LatLngToCellでエラーが発生")
+	return cell.String()
+}
+
+// TestGetClustersUseCase_Execute_Filtered は絞り込み条件がある場合に圃場をその場で集計することをテストする
+func TestGetClustersUseCase_Execute_Filtered(t *testing.T) {
+	cityCode := " 163210 "
+	minArea := 1000.0
+	input := GetClustersInput{
+		Zoom:       12.0,
+		SWLat:      35.0,
+		SWLng:      139.0,
+		NELat:      36.0,
+		NELng:      140.0,
+		CityCode:   &cityCode,
+		MinAreaSqm: &minArea,
+	}
+
+	t.Run("キャッシュミス時は範囲を広げて集計し、中心が範囲内のセルのみ返してキャッシュする", func(t *testing.T) {
+		inside := res7Cell(t, 35.5, 139.5)
+		outside := res7Cell(t, 36.005, 139.5)
+		clusterRepo := &mockClusterRepository{
+			getErr: errors.New("事前計算の結果は使用しない"),
+			aggregated: []*repository.AggregatedCluster{
+				{H3Index: inside, FieldCount: 3, TotalAreaSqm: 4500, IdleFieldCount: 1},
+				{H3Index: outside, FieldCount: 2},
+			},
+		}
+		cacheRepo := &mockClusterCacheRepository{}
+		jobRepo := &mockClusterJobRepository{hasPendingJob: true}
+		uc := NewGetClustersUseCase(clusterRepo, cacheRepo, jobRepo, getTestLogger())
+
+		output, err := uc.Execute(context.Background(), input)
+
+		require.NoError(t, err, "Executeでエラーが発生")
+		require.Equal(t, 1, clusterRepo.filteredCalls, "絞り込み集計が呼ばれていない")
+		require.Equal(t, "163210", *clusterRepo.filteredFilter.CityCode, "市区町村コードが正規化されていない")
+		require.Equal(t, minArea, *clusterRepo.filteredFilter.MinAreaSqm, "最小面積が渡されていない")
+		require.Nil(t, clusterRepo.filteredFilter.SoilTypeSmallCode, "未指定の条件はnilであるべき")
+		require.Less(t, clusterRepo.filteredBounds.SWLat, input.SWLat, "集計範囲が広げられていない")
+		require.Greater(t, clusterRepo.filteredBounds.NELng, input.NELng, "集計範囲が広げられていない")
+
+		require.Len(t, output.Clusters, 1, "中心が範囲外のセルを含めない")
+		require.Equal(t, inside, output.Clusters[0].H3Index, "H3インデックスが期待値と異なります")
+		require.Equal(t, int32(3), output.Clusters[0].Count, "圃場数が期待値と異なります")
+		require.Equal(t, int32(1), output.Clusters[0].IdleCount, "遊休農地の圃場数が期待値と異なります")
+		require.False(t, output.IsStale, "絞り込み集計はIsStaleがfalseであるべき")
+
+		cells, err := h3util.ViewportCells(h3util.NewBoundingBox(input.SWLat, input.SWLng, input.NELat, input.NELng), entity.Res7)
+		require.NoError(t, err, "ViewportCellsでエラーが発生")
+		require.Equal(t, []string{(&entity.ClusterFilter{CityCode: clusterRepo.filteredFilter.CityCode, MinAreaSqm: &minArea}).Hash()}, cacheRepo.filteredHashes, "絞り込み条件のハッシュ値でキャッシュするべき")
+		require.Len(t, cacheRepo.filteredSetByCell, len(cells), "表示範囲を覆う検索セルごとにキャッシュするべき")
+		insideParent, ok := h3util.ParentCell(inside, entity.Resolution(h3util.GetResolution(cells[0])))
+		require.True(t, ok, "親セルの取得に失敗しました")
+		require.Len(t, cacheRepo.filteredSetByCell[insideParent], 1, "検索セルに含まれるセルの結果をキャッシュするべき")
+	})
+
+	t.Run("キャッシュヒット時は集計しない", func(t *testing.T) {
+		clusterRepo := &mockClusterRepository{}
+		cacheRepo := &mockClusterCacheRepository{filteredClusters: []*entity.Cluster{
+			{Resolution: entity.Res7, H3Index: "871f1a4adffffff", FieldCount: 4, CenterLat: 35.5, CenterLng: 139.5},
+		}}
+		uc := NewGetClustersUseCase(clusterRepo, cacheRepo, &mockClusterJobRepository{}, getTestLogger())
+
+		output, err := uc.Execute(context.Background(), input)
+
+		require.NoError(t, err, "Executeでエラーが発生")
+		require.Zero(t, clusterRepo.filteredCalls, "キャッシュヒット時は集計しない")
+		require.Len(t, output.Clusters, 1, "クラスター数が期待値と異なります")
+	})
+
+	t.Run("表示範囲がずれても重なる検索セルのキャッシュを再利用する", func(t *testing.T) {
+		clusterRepo := &mockClusterRepository{aggregated: []*repository.AggregatedCluster{
+			{H3Index: res7Cell(t, 35.5, 139.5), FieldCount: 3},
+		}}
+		cacheRepo := &mockClusterCacheRepository{storeFilteredCache: true}
+		uc := NewGetClustersUseCase(clusterRepo, cacheRepo, &mockClusterJobRepository{}, getTestLogger())
+
+		_, err := uc.Execute(context.Background(), input)
+		require.NoError(t, err, "Executeでエラーが発生")
+		output, err := uc.Execute(context.Background(), input)
+		require.NoError(t, err, "Executeでエラーが発生")
+		require.Equal(t, 1, clusterRepo.filteredCalls, "同じ範囲はキャッシュを使用して集計しない")
+		require.Len(t, output.Clusters, 1, "キャッシュの結果を返すべき")
+
+		shifted := input
+		shifted.SWLat += 0.01
+		shifted.NELat += 0.01
+		cells, err := h3util.ViewportCells(h3util.NewBoundingBox(input.SWLat, input.SWLng, input.NELat, input.NELng), entity.Res7)
+		require.NoError(t, err, "ViewportCellsでエラーが発生")
+		shiftedCells, err := h3util.ViewportCells(h3util.NewBoundingBox(shifted.SWLat, shifted.SWLng, shifted.NELat, shifted.NELng), entity.Res7)
+		require.NoError(t, err, "ViewportCellsでエラーが発生")
+		added := make([]string, 0)
+		for _, cell := range shiftedCells {
+			if !slices.Contains(cells, cell) {
+				added = append(added, cell)
+			}
+		}
+
+		require.NotEmpty(t, added, "前提: ずらした範囲で新たな検索セルが加わるべき")
+
+		output, err = uc.Execute(context.Background(), shifted)
+		require.NoError(t, err, "Executeでエラーが発生")
+		require.Len(t, output.Clusters, 1, "キャッシュの結果を返すべき")
+		require.Equal(t, 2, clusterRepo.filteredCalls, "新たに表示範囲に入った検索セルを集計するべき")
+		require.ElementsMatch(t, added, slices.Collect(maps.Keys(cacheRepo.filteredSetByCell)), "新たに表示範囲に入った検索セルのみ保存するべき")
+	})
+
+	t.Run("キャッシュミスの検索セルのみ集計し、キャッシュヒットの検索セルの結果と重複させない", func(t *testing.T) {
+		missingCluster := res7Cell(t, 35.5, 139.5)
+		cachedCluster := res7Cell(t, 35.2, 139.2)
+		cells, err := h3util.ViewportCells(h3util.NewBoundingBox(input.SWLat, input.SWLng, input.NELat, input.NELng), entity.Res7)
+		require.NoError(t, err, "ViewportCellsでエラーが発生")
+		lookupResolution := entity.Resolution(h3util.GetResolution(cells[0]))
+		missingParent, ok := h3util.ParentCell(missingCluster, lookupResolution)
+		require.True(t, ok, "親セルの取得に失敗しました")
+		cachedParent, ok := h3util.ParentCell(cachedCluster, lookupResolution)
+		require.True(t, ok, "親セルの取得に失敗しました")
+		require.NotEqual(t, missingParent, cachedParent, "前提: 異なる検索セルに含まれるべき")
+
+		byCell := make(map[string][]*entity.Cluster, len(cells))
+		for _, cell := range cells {
+			byCell[cell] = []*entity.Cluster{}
+		}
+		delete(byCell, missingParent)
+		lat, lng, err := h3util.CellToLatLng(cachedCluster)
+		require.NoError(t, err, "セルの中心の取得に失敗しました")
+		byCell[cachedParent] = []*entity.Cluster{{Resolution: entity.Res7, H3Index: cachedCluster, FieldCount: 2, CenterLat: lat, CenterLng: lng}}
+
+		clusterRepo := &mockClusterRepository{aggregated: []*repository.AggregatedCluster{
+			{H3Index: missingCluster, FieldCount: 3},
+			{H3Index: cachedCluster, FieldCount: 2},
+		}}
+		cacheRepo := &mockClusterCacheRepository{filteredByCell: byCell}
+		uc := NewGetClustersUseCase(clusterRepo, cacheRepo, &mockClusterJobRepository{}, getTestLogger())
+
+		output, err := uc.Execute(context.Background(), input)
+
+		require.NoError(t, err, "Executeでエラーが発生")
+		require.Equal(t, 1, clusterRepo.filteredCalls, "キャッシュミスの検索セルを集計するべき")
+		require.Len(t, output.Clusters, 2, "キャッシュと集計の結果が重複しています")
+		require.Equal(t, map[string][]*entity.Cluster{missingParent: cacheRepo.filteredSetByCell[missingParent]}, cacheRepo.filteredSetByCell, "キャッシュミスの検索セルのみ保存するべき")
+		require.Len(t, cacheRepo.filteredSetByCell[missingParent], 1, "キャッシュミスの検索セルの結果を保存するべき")
+	})
+
+	t.Run("該当する圃場がない結果もキャッシュする", func(t *testing.T) {
+		cacheRepo := &mockClusterCacheRepository{}
+		uc := NewGetClustersUseCase(&mockClusterRepository{}, cacheRepo, &mockClusterJobRepository{}, getTestLogger())
+
+		output, err := uc.Execute(context.Background(), input)
+
+		require.NoError(t, err, "Executeでエラーが発生")
+		require.Empty(t, output.Clusters, "クラスターは空であるべき")
+		require.NotEmpty(t, cacheRepo.filteredSetByCell, "空の結果もキャッシュするべき")
+		for cell, clusters := range cacheRepo.filteredSetByCell {
+			require.NotNil(t, clusters, "空の結果はnilでなく空のスライスであるべき: %s", cell)
+		}
+	})
+
+	t.Run("集計失敗時はエラーを返す", func(t *testing.T) {
+		clusterRepo := &mockClusterRepository{aggregateErr: errors.New("db error")}
+		uc := NewGetClustersUseCase(clusterRepo, &mockClusterCacheRepository{}, &mockClusterJobRepository{}, getTestLogger())
+
+		output, err := uc.Execute(context.Background(), input)
+
+		require.Error(t, err, "集計失敗時はエラーを返すべき")
+		require.Nil(t, output, "エラー時は出力がnilであるべき")
+	})
+
+	t.Run("空白のみの条件は未指定として事前計算の結果を返す", func(t *testing.T) {
+		blank := "  "
+		clusterRepo := &mockClusterRepository{clusters: []*entity.Cluster{
+			{Resolution: entity.Res7, H3Index: "871f1a4adffffff", FieldCount: 4, CenterLat: 35.5, CenterLng: 139.5},
+		}}
+		uc := NewGetClustersUseCase(clusterRepo, &mockClusterCacheRepository{}, &mockClusterJobRepository{}, getTestLogger())
+
+		output, err := uc.Execute(context.Background(), GetClustersInput{
+			Zoom: 12.0, SWLat: 35.0, SWLng: 139.0, NELat: 36.0, NELng: 140.0,
+			CityCode: &blank,
+		})
+
+		require.NoError(t, err, "Executeでエラーが発生")
+		require.Zero(t, clusterRepo.filteredCalls, "絞り込み集計は呼ばない")
+		require.Len(t, output.Clusters, 1, "事前計算の結果を返すべき")
+	})
+}
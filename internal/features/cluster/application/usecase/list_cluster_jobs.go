@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/utils"
+)
+
+const (
+	// DefaultJobListLimit はジョブ履歴の取得件数のデフォルト値
+	DefaultJobListLimit = 20
+	// MaxJobListLimit はジョブ履歴の取得件数の上限
+	MaxJobListLimit = 100
+)
+
+// ListClusterJobsInput はジョブ履歴取得の入力
+type ListClusterJobsInput struct {
+	Limit       *int
+	Offset      *int
+	Status      *string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
+// ListClusterJobsOutput はジョブ履歴取得の出力
+type ListClusterJobsOutput struct {
+	Jobs  []*query.ClusterJobSummary
+	Total int64
+}
+
+// ListClusterJobsUseCase はジョブ履歴取得のユースケース
+type ListClusterJobsUseCase struct {
+	jobQuery query.ClusterJobQuery
+}
+
+// NewListClusterJobsUseCase は新しいListClusterJobsUseCaseを作成する
+func NewListClusterJobsUseCase(jobQuery query.ClusterJobQuery) *ListClusterJobsUseCase {
+	return &ListClusterJobsUseCase{
+		jobQuery: jobQuery,
+	}
+}
+
+// Execute は検索条件に一致するジョブ履歴を作成日時の新しい順に取得する
+func (u *ListClusterJobsUseCase) Execute(ctx context.Context, input ListClusterJobsInput) (*ListClusterJobsOutput, error) {
+	limit, offset, err := resolveJobListPaging(input.Limit, input.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := query.ClusterJobFilter{
+		CreatedFrom: input.CreatedFrom,
+		CreatedTo:   input.CreatedTo,
+	}
+	if input.Status != nil {
+		s := entity.JobStatus(*input.Status)
+		if !s.IsValid() {
+			return nil, apperror.BadRequestError("statusはpending, processing, completed, failed, cancelledのいずれかを指定してください")
+		}
+		filter.Status = &s
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return nil, apperror.BadRequestError("created_fromはcreated_toより前の日時を指定してください")
+	}
+
+	jobs, err := u.jobQuery.List(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("ジョブ履歴の取得に失敗しました", err)
+	}
+
+	total, err := u.jobQuery.Count(ctx, filter)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("ジョブの総数の取得に失敗しました", err)
+	}
+
+	return &ListClusterJobsOutput{
+		Jobs:  jobs,
+		Total: total,
+	}, nil
+}
+
+// resolveJobListPaging はページング指定を検証し、未指定の場合はデフォルト値を返す
+func resolveJobListPaging(limit, offset *int) (int32, int32, error) {
+	l := DefaultJobListLimit
+	if limit != nil {
+		l = *limit
+	}
+	if l < 1 || l > MaxJobListLimit {
+		return 0, 0, apperror.BadRequestError(fmt.Sprintf("limitは1から%dの範囲で指定してください", MaxJobListLimit))
+	}
+
+	o := 0
+	if offset != nil {
+		o = *offset
+	}
+	if o < 0 {
+		return 0, 0, apperror.BadRequestError("offsetは0以上で指定してください")
+	}
+
+	return utils.SafeIntToInt32(l), utils.SafeIntToInt32(o), nil
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+// mockClusterJobQuery はClusterJobQueryのモック実装
+type mockClusterJobQuery struct {
+	jobs     []*query.ClusterJobSummary
+	job      *query.ClusterJobSummary // FindByIDで返すジョブ
+	total    int64
+	listErr  error
+	countErr error
+	findErr  error
+
+	// 呼び出し時の引数を記録
+	gotFilter query.ClusterJobFilter
+	gotLimit  int32
+	gotOffset int32
+	gotID     uuid.UUID
+}
+
+func (m *mockClusterJobQuery) List(_ context.Context, filter query.ClusterJobFilter, limit, offset int32) ([]*query.ClusterJobSummary, error) {
+	m.gotFilter = filter
+	m.gotLimit = limit
+	m.gotOffset = offset
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.jobs, nil
+}
+
+func (m *mockClusterJobQuery) Count(_ context.Context, _ query.ClusterJobFilter) (int64, error) {
+	if m.countErr != nil {
+		return 0, m.countErr
+	}
+	return m.total, nil
+}
+
+func (m *mockClusterJobQuery) FindByID(_ context.Context, id uuid.UUID) (*query.ClusterJobSummary, error) {
+	m.gotID = id
+	if m.findErr != nil {
+		return nil, m.findErr
+	}
+	return m.job, nil
+}
+
+// errorStatus はアプリケーションエラーのHTTPステータスを返す
+func errorStatus(err error) int {
+	var appErr apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.HTTPStatus()
+	}
+	return 0
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestListClusterJobsUseCase_Execute_Filters(t *testing.T) {
+	jobs := []*query.ClusterJobSummary{{ID: uuid.New(), Status: entity.JobStatusFailed}}
+	mock := &mockClusterJobQuery{jobs: jobs, total: 1}
+	uc := NewListClusterJobsUseCase(mock)
+	status := "failed"
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	got, err := uc.Execute(context.Background(), ListClusterJobsInput{
+		Limit:       intPtr(50),
+		Offset:      intPtr(10),
+		Status:      &status,
+		CreatedFrom: &from,
+		CreatedTo:   &to,
+	})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, jobs, got.Jobs, "ジョブ一覧が一致しない")
+	require.Equal(t, int64(1), got.Total, "総数が一致しない")
+	require.Equal(t, int32(50), mock.gotLimit, "limitが一致しない")
+	require.Equal(t, int32(10), mock.gotOffset, "offsetが一致しない")
+	require.Equal(t, entity.JobStatusFailed, *mock.gotFilter.Status, "ステータス条件が一致しない")
+	require.Equal(t, &from, mock.gotFilter.CreatedFrom, "作成日時の下限が一致しない")
+	require.Equal(t, &to, mock.gotFilter.CreatedTo, "作成日時の上限が一致しない")
+}
+
+func TestListClusterJobsUseCase_Execute_Defaults(t *testing.T) {
+	mock := &mockClusterJobQuery{}
+	uc := NewListClusterJobsUseCase(mock)
+
+	_, err := uc.Execute(context.Background(), ListClusterJobsInput{})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Nil(t, mock.gotFilter.Status, "未指定のステータスは絞り込みに使用すべきでない")
+	require.Equal(t, int32(DefaultJobListLimit), mock.gotLimit, "デフォルトのlimitが適用されていない")
+	require.Equal(t, int32(0), mock.gotOffset, "デフォルトのoffsetが適用されていない")
+}
+
+func TestListClusterJobsUseCase_Execute_Error(t *testing.T) {
+	invalidStatus := "running"
+	from := time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		input      ListClusterJobsInput
+		query      *mockClusterJobQuery
+		wantStatus int
+	}{
+		{
+			name:       "不正なステータス",
+			input:      ListClusterJobsInput{Status: &invalidStatus},
+			query:      &mockClusterJobQuery{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "作成日時の範囲が逆転",
+			input:      ListClusterJobsInput{CreatedFrom: &from, CreatedTo: &to},
+			query:      &mockClusterJobQuery{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "limitが範囲外",
+			input:      ListClusterJobsInput{Limit: intPtr(MaxJobListLimit + 1)},
+			query:      &mockClusterJobQuery{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "offsetが負",
+			input:      ListClusterJobsInput{Offset: intPtr(-1)},
+			query:      &mockClusterJobQuery{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "一覧取得エラー",
+			query:      &mockClusterJobQuery{listErr: errors.New("db error")},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "総数取得エラー",
+			query:      &mockClusterJobQuery{countErr: errors.New("db error")},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewListClusterJobsUseCase(tt.query)
+
+			_, err := uc.Execute(context.Background(), tt.input)
+
+			require.Error(t, err, "エラーを期待")
+			require.Equal(t, tt.wantStatus, errorStatus(err), "HTTPステータスが期待値と異なります")
+		})
+	}
+}
@@ -3,22 +3,51 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
 )
 
+const (
+	// DefaultJobLeaseDuration はジョブのリース期間のデフォルト値
+	// 処理中はハートビートでリースを延長するため、計算にかかる時間より短くてよい
+	DefaultJobLeaseDuration = 2 * time.Minute
+
+	// DefaultJobMaxAttempts はリース期限切れによる再実行を含めたジョブの最大実行回数のデフォルト値
+	DefaultJobMaxAttempts int32 = 3
+
+	// heartbeatsPerLease はリース期間あたりのハートビート回数
+	// 一時的なDBエラーでハートビートが失敗しても、リース期間内に再試行できるようにする
+	heartbeatsPerLease = 3
+)
+
 // ProcessJobsInput はジョブ処理ユースケースの入力
 type ProcessJobsInput struct {
-	BatchSize int32 // 1回に処理するジョブ数
+	BatchSize     int32           // 1回に処理するジョブ数
+	WorkerID      string          // リースを保持するワーカーのID
+	LeaseDuration time.Duration   // ハートビートが途絶えてからジョブを回収するまでの期間(0=デフォルト値)
+	MaxAttempts   int32           // リース期限切れによる再実行を含めた最大実行回数(0=デフォルト値)
+	Shutdown      <-chan struct{} // 閉じられると新しいジョブを取得しない(処理中のジョブは完了させる)
+}
+
+// FieldStatsRefresher は圃場統計の再集計インターフェース(Consumer側で定義)
+// 統計機能の再集計ユースケースのアダプタが実装する
+type FieldStatsRefresher interface {
+	// Refresh は全ての市区町村の圃場統計を再集計する
+	Refresh(ctx context.Context) error
 }
 
 // ProcessJobsUseCase はジョブ処理ユースケース
 type ProcessJobsUseCase struct {
-	jobRepo     repository.ClusterJobRepository
-	calculateUC *CalculateClustersUseCase
-	logger      *slog.Logger
+	jobRepo        repository.ClusterJobRepository
+	calculateUC    *CalculateClustersUseCase
+	statsRefresher FieldStatsRefresher
+	logger         *slog.Logger
 }
 
 // NewProcessJobsUseCase はProcessJobsUseCaseを作成する
@@ -34,82 +63,189 @@ func NewProcessJobsUseCase(
 	}
 }
 
+// NewProcessJobsUseCaseWithStatsRefresher は完了したジョブごとに圃場統計を再集計するProcessJobsUseCaseを作成する
+// statsRefresherがnilの場合は再集計を行わない
+func NewProcessJobsUseCaseWithStatsRefresher(
+	jobRepo repository.ClusterJobRepository,
+	calculateUC *CalculateClustersUseCase,
+	statsRefresher FieldStatsRefresher,
+	logger *slog.Logger,
+) *ProcessJobsUseCase {
+	uc := NewProcessJobsUseCase(jobRepo, calculateUC, logger)
+	uc.statsRefresher = statsRefresher
+	return uc
+}
+
 // Execute はジョブ処理を実行する
+// 異常終了したワーカーのジョブを回収した後、保留中のジョブをリース付きで1件ずつ取得して処理する
 func (u *ProcessJobsUseCase) Execute(ctx context.Context, input ProcessJobsInput) error {
+	if input.LeaseDuration <= 0 {
+		input.LeaseDuration = DefaultJobLeaseDuration
+	}
+	if input.MaxAttempts <= 0 {
+		input.MaxAttempts = DefaultJobMaxAttempts
+	}
+
 	u.logger.Info("ジョブ処理を開始します",
-		slog.Int("batch_size", int(input.BatchSize)))
+		slog.Int("batch_size", int(input.BatchSize)),
+		slog.String("worker_id", input.WorkerID))
 
-	// 保留中のジョブを取得（排他ロック付き、影響セル情報付き）
-	jobs, err := u.jobRepo.FindPendingJobsWithAffectedCells(ctx, input.BatchSize)
+	// ハートビートが途絶えたジョブを回収(回収に失敗しても保留中ジョブの処理は継続する)
+	recovered, err := u.jobRepo.RecoverExpiredJobs(ctx, input.LeaseDuration, input.MaxAttempts, maxAffectedCells)
 	if err != nil {
-		return fmt.Errorf("ジョブの取得に失敗しました: %w", err)
+		u.logger.Warn("リース期限切れジョブの回収に失敗しました",
+			slog.String("error", err.Error()))
+	} else if recovered.Requeued > 0 || recovered.Failed > 0 {
+		u.logger.Warn("リース期限切れジョブを回収しました",
+			slog.Int("requeued", recovered.Requeued),
+			slog.Int("failed", recovered.Failed))
 	}
 
-	if len(jobs) == 0 {
+	processed := 0
+	for ; processed < int(input.BatchSize); processed++ {
+		if ctx.Err() != nil || isShuttingDown(input.Shutdown) {
+			break
+		}
+
+		job, err := u.jobRepo.Claim(ctx, input.WorkerID)
+		if err != nil {
+			return fmt.Errorf("ジョブの取得に失敗しました: %w", err)
+		}
+		if job == nil {
+			break
+		}
+
+		u.processJob(ctx, job, input)
+	}
+
+	if processed == 0 {
 		u.logger.Info("処理対象のジョブがありません")
 		return nil
 	}
 
-	u.logger.Info("処理対象のジョブを取得しました",
-		slog.Int("job_count", len(jobs)))
-
-	// 各ジョブを処理
-	for _, job := range jobs {
-		isFullRecalc := job.IsFullRecalculation()
-		u.logger.Info("ジョブの処理を開始します",
-			slog.String("job_id", job.ID.String()),
-			slog.Bool("full_recalculation", isFullRecalc),
-			slog.Int("affected_cells", len(job.AffectedH3Cells)))
+	u.logger.Info("ジョブ処理が完了しました",
+		slog.Int("job_count", processed))
+	return nil
+}
 
-		// ジョブを処理中に更新
-		if err := u.jobRepo.UpdateToProcessing(ctx, job.ID); err != nil {
-			u.logger.Error("ジョブの処理中への更新に失敗しました",
-				slog.String("job_id", job.ID.String()),
-				slog.String("error", err.Error()))
-			continue
-		}
+// processJob はリースを取得したジョブ1件を処理し、結果をジョブに記録する
+func (u *ProcessJobsUseCase) processJob(ctx context.Context, job *entity.ClusterJob, input ProcessJobsInput) {
+	u.logger.Info("ジョブの処理を開始します",
+		slog.String("job_id", job.ID.String()),
+		slog.Bool("full_recalculation", job.IsFullRecalculation()),
+		slog.Int("affected_cells", len(job.AffectedH3Cells)),
+		slog.Int("attempts", int(job.Attempts)))
+
+	// リースを失った場合は計算を中断し、回収したワーカーに処理を任せる
+	calcCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stopHeartbeat := u.keepAlive(calcCtx, cancel, job.ID, input)
+
+	// クラスター計算を実行(影響セル情報を渡す)
+	err := u.calculateUC.Execute(calcCtx, CalculateClustersInput{
+		AffectedH3Cells: job.AffectedH3Cells,
+	})
+	stopHeartbeat()
+
+	if errors.Is(context.Cause(calcCtx), entity.ErrJobLeaseLost) {
+		u.logger.Warn("ジョブのリースを失ったため処理を中断しました",
+			slog.String("job_id", job.ID.String()))
+		return
+	}
 
-		// クラスター計算を実行(影響セル情報を渡す)
-		calcInput := CalculateClustersInput{
-			AffectedH3Cells: job.AffectedH3Cells,
+	if err != nil {
+		// シャットダウンで中断した場合は処理中のまま残し、リース期限切れ後に再実行させる
+		if ctx.Err() != nil {
+			u.logger.Warn("シャットダウンのためジョブの処理を中断しました。リース期限切れ後に再実行されます",
+				slog.String("job_id", job.ID.String()))
+			return
 		}
-		if err := u.calculateUC.Execute(ctx, calcInput); err != nil {
-			u.logger.Error("クラスター計算に失敗しました",
-				slog.String("job_id", job.ID.String()),
-				slog.String("error", err.Error()))
 
-			// ジョブを失敗に更新
-			if updateErr := u.jobRepo.UpdateToFailed(ctx, job.ID, err.Error()); updateErr != nil {
-				u.logger.Error("ジョブの失敗への更新に失敗しました",
-					slog.String("job_id", job.ID.String()),
-					slog.String("error", updateErr.Error()))
-			}
-			continue
-		}
+		u.logger.Error("クラスター計算に失敗しました",
+			slog.String("job_id", job.ID.String()),
+			slog.String("error", err.Error()))
 
-		// ジョブを完了に更新
-		if err := u.jobRepo.UpdateToCompleted(ctx, job.ID); err != nil {
-			u.logger.Error("ジョブの完了への更新に失敗しました",
+		// ジョブを失敗に更新
+		if updateErr := u.jobRepo.UpdateToFailed(ctx, job.ID, input.WorkerID, err.Error()); updateErr != nil {
+			u.logger.Error("ジョブの失敗への更新に失敗しました",
 				slog.String("job_id", job.ID.String()),
-				slog.String("error", err.Error()))
-			continue
+				slog.String("error", updateErr.Error()))
 		}
-
-		u.logger.Info("ジョブの処理が完了しました",
-			slog.String("job_id", job.ID.String()))
+		return
 	}
 
-	// 古いジョブを削除
-	if err := u.jobRepo.DeleteOldCompletedJobs(ctx); err != nil {
-		u.logger.Warn("古い完了済みジョブの削除に失敗しました",
+	// ジョブを完了に更新
+	if err := u.jobRepo.UpdateToCompleted(ctx, job.ID, input.WorkerID); err != nil {
+		u.logger.Error("ジョブの完了への更新に失敗しました",
+			slog.String("job_id", job.ID.String()),
 			slog.String("error", err.Error()))
+		return
 	}
 
-	if err := u.jobRepo.DeleteOldFailedJobs(ctx); err != nil {
-		u.logger.Warn("古い失敗ジョブの削除に失敗しました",
+	u.logger.Info("ジョブの処理が完了しました",
+		slog.String("job_id", job.ID.String()))
+
+	u.refreshFieldStats(ctx, job.ID)
+}
+
+// refreshFieldStats は圃場統計を再集計する
+// 再集計に失敗してもジョブは完了済みのため、ログに残して次回のジョブで再集計させる
+func (u *ProcessJobsUseCase) refreshFieldStats(ctx context.Context, jobID uuid.UUID) {
+	if u.statsRefresher == nil {
+		return
+	}
+	if err := u.statsRefresher.Refresh(ctx); err != nil {
+		u.logger.Warn("圃場統計の再集計に失敗しました",
+			slog.String("job_id", jobID.String()),
 			slog.String("error", err.Error()))
 	}
+}
 
-	u.logger.Info("ジョブ処理が完了しました")
-	return nil
+// keepAlive は処理中のジョブのハートビートを定期的に更新する
+// リースを失った場合はentity.ErrJobLeaseLostを原因としてcancelを呼ぶ。返り値の関数で更新を停止する
+func (u *ProcessJobsUseCase) keepAlive(ctx context.Context, cancel context.CancelCauseFunc, jobID uuid.UUID, input ProcessJobsInput) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(input.LeaseDuration / heartbeatsPerLease)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := u.jobRepo.Heartbeat(ctx, jobID, input.WorkerID)
+				if errors.Is(err, entity.ErrJobLeaseLost) {
+					cancel(err)
+					return
+				}
+				if err != nil {
+					u.logger.Warn("ジョブのハートビート更新に失敗しました",
+						slog.String("job_id", jobID.String()),
+						slog.String("error", err.Error()))
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// isShuttingDown はシャットダウンが要求されているかを返す
+func isShuttingDown(shutdown <-chan struct{}) bool {
+	select {
+	case <-shutdown:
+		return true
+	default:
+		return false
+	}
 }
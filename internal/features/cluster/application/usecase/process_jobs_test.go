@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
@@ -19,7 +20,7 @@ func TestNewProcessJobsUseCase(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 	uc := NewProcessJobsUseCase(jobRepo, calculateUC, logger)
 
 	require.NotNil(t, uc, "UseCaseがnilです")
@@ -33,7 +34,7 @@ func TestProcessJobsUseCase_Execute_NoJobs(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 	uc := NewProcessJobsUseCase(jobRepo, calculateUC, logger)
 
 	err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: 10})
@@ -52,7 +53,7 @@ func TestProcessJobsUseCase_Execute_Success(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 	uc := NewProcessJobsUseCase(jobRepo, calculateUC, logger)
 
 	err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: 10})
@@ -72,7 +73,7 @@ func TestProcessJobsUseCase_Execute_MultipleJobs(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 	uc := NewProcessJobsUseCase(jobRepo, calculateUC, logger)
 
 	err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: 10})
@@ -80,15 +81,15 @@ func TestProcessJobsUseCase_Execute_MultipleJobs(t *testing.T) {
 	require.NoError(t, err, "複数ジョブが処理されるべき")
 }
 
-// TestProcessJobsUseCase_Execute_FindPendingError は保留中ジョブ取得エラー時にエラーを返すことをテストする
-func TestProcessJobsUseCase_Execute_FindPendingError(t *testing.T) {
-	jobRepo := &mockClusterJobRepository{findPendingErr: errors.New("db error")}
+// TestProcessJobsUseCase_Execute_ClaimError はジョブ取得エラー時にエラーを返すことをテストする
+func TestProcessJobsUseCase_Execute_ClaimError(t *testing.T) {
+	jobRepo := &mockClusterJobRepository{claimErr: errors.New("db error")}
 	aggregated := []*repository.AggregatedCluster{}
 	clusterRepo := &mockClusterRepository{aggregated: aggregated}
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 	uc := NewProcessJobsUseCase(jobRepo, calculateUC, logger)
 
 	err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: 10})
@@ -96,26 +97,25 @@ func TestProcessJobsUseCase_Execute_FindPendingError(t *testing.T) {
 	require.Error(t, err, "ジョブ取得エラー時はエラーを返すべき")
 }
 
-// TestProcessJobsUseCase_Execute_UpdateToProcessingError は処理中更新エラー時も次のジョブを処理することをテストする
-func TestProcessJobsUseCase_Execute_UpdateToProcessingError(t *testing.T) {
-	jobs := []*entity.ClusterJob{
-		entity.NewClusterJob(10),
-	}
+// TestProcessJobsUseCase_Execute_RecoverExpiredJobsError はリース期限切れジョブの回収エラー時も処理を継続することをテストする
+func TestProcessJobsUseCase_Execute_RecoverExpiredJobsError(t *testing.T) {
+	job := entity.NewClusterJob(10)
 	jobRepo := &mockClusterJobRepository{
-		jobs:      jobs,
-		updateErr: errors.New("update error"),
+		jobs:       []*entity.ClusterJob{job},
+		recoverErr: errors.New("recover error"),
 	}
 	aggregated := []*repository.AggregatedCluster{}
 	clusterRepo := &mockClusterRepository{aggregated: aggregated}
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 	uc := NewProcessJobsUseCase(jobRepo, calculateUC, logger)
 
 	err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: 10})
 
-	require.NoError(t, err, "更新エラーでも処理自体は継続するべき")
+	require.NoError(t, err, "回収エラーでも処理自体は継続するべき")
+	require.Equal(t, []uuid.UUID{job.ID}, jobRepo.completedIDs, "保留中ジョブは処理されるべき")
 }
 
 // TestProcessJobsUseCase_Execute_CalculateError はクラスター計算エラー時もジョブを失敗として処理することをテストする
@@ -123,14 +123,12 @@ func TestProcessJobsUseCase_Execute_CalculateError(t *testing.T) {
 	jobs := []*entity.ClusterJob{
 		entity.NewClusterJob(10),
 	}
-	// updateErrがnilでないとUpdateToProcessingでcontinueされるので、
-	// calculateのエラーをテストするにはupdateErrをnilにしておく
 	jobRepo := &mockClusterJobRepository{jobs: jobs}
 	clusterRepo := &mockClusterRepository{aggregateErr: errors.New("aggregate error")}
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 	uc := NewProcessJobsUseCase(jobRepo, calculateUC, logger)
 
 	err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: 10})
@@ -138,23 +136,91 @@ func TestProcessJobsUseCase_Execute_CalculateError(t *testing.T) {
 	require.NoError(t, err, "計算エラーでもジョブ処理自体は継続するべき")
 }
 
-// TestProcessJobsUseCase_Execute_DeleteOldJobsError は古いジョブ削除エラーでも処理が完了することをテストする
-func TestProcessJobsUseCase_Execute_DeleteOldJobsError(t *testing.T) {
-	jobRepo := &mockClusterJobRepository{
-		jobs:      []*entity.ClusterJob{},
-		deleteErr: errors.New("delete error"),
+// mockFieldStatsRefresher はFieldStatsRefresherのモック実装
+type mockFieldStatsRefresher struct {
+	err   error
+	calls int // Refreshの呼び出し回数
+}
+
+func (m *mockFieldStatsRefresher) Refresh(_ context.Context) error {
+	m.calls++
+	return m.err
+}
+
+// TestProcessJobsUseCase_Execute_RefreshesFieldStats は完了したジョブごとに圃場統計を再集計することをテストする
+func TestProcessJobsUseCase_Execute_RefreshesFieldStats(t *testing.T) {
+	jobs := []*entity.ClusterJob{
+		entity.NewClusterJob(10),
+		entity.NewClusterJob(10),
 	}
-	aggregated := []*repository.AggregatedCluster{}
-	clusterRepo := &mockClusterRepository{aggregated: aggregated}
-	cacheRepo := &mockClusterCacheRepository{}
+	jobRepo := &mockClusterJobRepository{jobs: jobs}
+	refresher := &mockFieldStatsRefresher{}
 	logger := getTestLogger()
 
-	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
-	uc := NewProcessJobsUseCase(jobRepo, calculateUC, logger)
+	calculateUC := NewCalculateClustersUseCase(&mockClusterRepository{}, &mockClusterCacheRepository{}, nil, logger)
+	uc := NewProcessJobsUseCaseWithStatsRefresher(jobRepo, calculateUC, refresher, logger)
 
 	err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: 10})
 
-	require.NoError(t, err, "古いジョブ削除エラーでも処理は完了するべき")
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, 2, refresher.calls, "完了したジョブごとに再集計するべき")
+}
+
+// TestProcessJobsUseCase_Execute_FieldStatsNotRefreshedOnFailure は失敗したジョブでは圃場統計を再集計しないことをテストする
+func TestProcessJobsUseCase_Execute_FieldStatsNotRefreshedOnFailure(t *testing.T) {
+	tests := []struct {
+		name        string
+		jobRepo     *mockClusterJobRepository
+		clusterRepo *mockClusterRepository
+	}{
+		{
+			name:        "クラスター計算エラー",
+			jobRepo:     &mockClusterJobRepository{jobs: []*entity.ClusterJob{entity.NewClusterJob(10)}},
+			clusterRepo: &mockClusterRepository{aggregateErr: errors.New("aggregate error")},
+		},
+		{
+			name: "完了更新エラー",
+			jobRepo: &mockClusterJobRepository{
+				jobs:                 []*entity.ClusterJob{entity.NewClusterJob(10)},
+				updateToCompletedErr: errors.New("complete update error"),
+			},
+			clusterRepo: &mockClusterRepository{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refresher := &mockFieldStatsRefresher{}
+			logger := getTestLogger()
+
+			calculateUC := NewCalculateClustersUseCase(tt.clusterRepo, &mockClusterCacheRepository{}, nil, logger)
+			uc := NewProcessJobsUseCaseWithStatsRefresher(tt.jobRepo, calculateUC, refresher, logger)
+
+			err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: 10})
+
+			require.NoError(t, err, "Executeでエラーが発生")
+			require.Zero(t, refresher.calls, "完了していないジョブでは再集計しないべき")
+		})
+	}
+}
+
+// TestProcessJobsUseCase_Execute_FieldStatsRefreshError は再集計エラーでもジョブ処理を継続することをテストする
+func TestProcessJobsUseCase_Execute_FieldStatsRefreshError(t *testing.T) {
+	jobs := []*entity.ClusterJob{
+		entity.NewClusterJob(10),
+		entity.NewClusterJob(10),
+	}
+	jobRepo := &mockClusterJobRepository{jobs: jobs}
+	refresher := &mockFieldStatsRefresher{err: errors.New("db error")}
+	logger := getTestLogger()
+
+	calculateUC := NewCalculateClustersUseCase(&mockClusterRepository{}, &mockClusterCacheRepository{}, nil, logger)
+	uc := NewProcessJobsUseCaseWithStatsRefresher(jobRepo, calculateUC, refresher, logger)
+
+	err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: 10})
+
+	require.NoError(t, err, "再集計エラーでもジョブ処理自体は継続するべき")
+	require.Equal(t, 2, refresher.calls, "再集計エラー後も次のジョブを処理するべき")
 }
 
 // TestProcessJobsInput はProcessJobsInputの構造体が正しくフィールドを持つことをテストする
@@ -185,7 +251,7 @@ func TestProcessJobsUseCase_Execute_BatchSize(t *testing.T) {
 			cacheRepo := &mockClusterCacheRepository{}
 			logger := getTestLogger()
 
-			calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+			calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 			uc := NewProcessJobsUseCase(jobRepo, calculateUC, logger)
 
 			err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: tt.batchSize})
@@ -206,7 +272,7 @@ func TestProcessJobsUseCase_Execute_JobWithID(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 	uc := NewProcessJobsUseCase(jobRepo, calculateUC, logger)
 
 	err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: 10})
@@ -233,7 +299,7 @@ func TestProcessJobsUseCase_Execute_UpdateToCompletedError(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 	uc := NewProcessJobsUseCase(jobRepo, calculateUC, logger)
 
 	err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: 10})
@@ -255,10 +321,87 @@ func TestProcessJobsUseCase_Execute_UpdateToFailedError(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	logger := getTestLogger()
 
-	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, logger)
+	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
 	uc := NewProcessJobsUseCase(jobRepo, calculateUC, logger)
 
 	err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: 10})
 
 	require.NoError(t, err, "失敗更新エラーでも処理自体は継続するべき")
 }
+
+// TestProcessJobsUseCase_Execute_ClaimsUpToBatchSize はバッチサイズ分のジョブをワーカーIDで取得して処理することをテストする
+func TestProcessJobsUseCase_Execute_ClaimsUpToBatchSize(t *testing.T) {
+	jobs := []*entity.ClusterJob{
+		entity.NewClusterJob(10),
+		entity.NewClusterJob(5),
+		entity.NewClusterJob(1),
+	}
+	jobRepo := &mockClusterJobRepository{jobs: jobs}
+	clusterRepo := &mockClusterRepository{aggregated: []*repository.AggregatedCluster{}}
+	cacheRepo := &mockClusterCacheRepository{}
+	logger := getTestLogger()
+
+	calculateUC := NewCalculateClustersUseCase(clusterRepo, cacheRepo, nil, logger)
+	uc := NewProcessJobsUseCase(jobRepo, calculateUC, logger)
+
+	err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: 2, WorkerID: "worker-1"})
+
+	require.NoError(t, err, "ジョブが正常に処理されるべき")
+	require.Equal(t, "worker-1", jobRepo.claimWorkerID, "ワーカーIDでリースを取得するべき")
+	require.Equal(t, []uuid.UUID{jobs[0].ID, jobs[1].ID}, jobRepo.completedIDs, "バッチサイズ分のジョブが完了するべき")
+}
+
+// TestProcessJobsUseCase_Execute_Shutdown はシャットダウン要求後は新しいジョブを取得しないことをテストする
+func TestProcessJobsUseCase_Execute_Shutdown(t *testing.T) {
+	jobRepo := &mockClusterJobRepository{jobs: []*entity.ClusterJob{entity.NewClusterJob(10)}}
+	uc := NewProcessJobsUseCase(jobRepo, nil, getTestLogger())
+
+	shutdown := make(chan struct{})
+	close(shutdown)
+
+	err := uc.Execute(context.Background(), ProcessJobsInput{BatchSize: 10, WorkerID: "worker-1", Shutdown: shutdown})
+
+	require.NoError(t, err, "シャットダウン要求時はエラーを返さないべき")
+	require.Empty(t, jobRepo.claimWorkerID, "シャットダウン要求後はジョブを取得しないべき")
+	require.Empty(t, jobRepo.completedIDs, "ジョブは処理されないべき")
+}
+
+// TestProcessJobsUseCase_KeepAlive_LeaseLost はリースを失った場合に処理中のコンテキストがキャンセルされることをテストする
+func TestProcessJobsUseCase_KeepAlive_LeaseLost(t *testing.T) {
+	jobRepo := &mockClusterJobRepository{heartbeatErr: entity.ErrJobLeaseLost}
+	uc := NewProcessJobsUseCase(jobRepo, nil, getTestLogger())
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	stop := uc.keepAlive(ctx, cancel, uuid.New(), ProcessJobsInput{
+		WorkerID:      "worker-1",
+		LeaseDuration: 30 * time.Millisecond,
+	})
+	defer stop()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("リースを失ってもコンテキストがキャンセルされませんでした")
+	}
+	require.ErrorIs(t, context.Cause(ctx), entity.ErrJobLeaseLost, "キャンセルの原因はErrJobLeaseLostであるべき")
+}
+
+// TestProcessJobsUseCase_KeepAlive_TransientError はハートビートの一時的なエラーでは処理を中断しないことをテストする
+func TestProcessJobsUseCase_KeepAlive_TransientError(t *testing.T) {
+	jobRepo := &mockClusterJobRepository{heartbeatErr: errors.New("db error")}
+	uc := NewProcessJobsUseCase(jobRepo, nil, getTestLogger())
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	stop := uc.keepAlive(ctx, cancel, uuid.New(), ProcessJobsInput{
+		WorkerID:      "worker-1",
+		LeaseDuration: 30 * time.Millisecond,
+	})
+	time.Sleep(100 * time.Millisecond)
+	stop()
+
+	require.NoError(t, ctx.Err(), "一時的なエラーではコンテキストがキャンセルされるべきではない")
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
+)
+
+// RetryClusterJobOutput はジョブ再実行の出力
+type RetryClusterJobOutput struct {
+	Job    *query.ClusterJobSummary // 再実行する保留中のジョブ
+	Merged bool                     // 既存の保留中ジョブに統合されたかどうか
+}
+
+// RetryClusterJobUseCase は失敗・取り消し済みジョブの再実行のユースケース
+type RetryClusterJobUseCase struct {
+	jobRepo      repository.ClusterJobRepository
+	enqueueJobUC *EnqueueJobUseCase
+	jobQuery     query.ClusterJobQuery
+	logger       *slog.Logger
+}
+
+// NewRetryClusterJobUseCase は新しいRetryClusterJobUseCaseを作成する
+func NewRetryClusterJobUseCase(
+	jobRepo repository.ClusterJobRepository,
+	enqueueJobUC *EnqueueJobUseCase,
+	jobQuery query.ClusterJobQuery,
+	logger *slog.Logger,
+) *RetryClusterJobUseCase {
+	return &RetryClusterJobUseCase{
+		jobRepo:      jobRepo,
+		enqueueJobUC: enqueueJobUC,
+		jobQuery:     jobQuery,
+		logger:       logger,
+	}
+}
+
+// Execute は失敗・取り消し済みジョブと同じ影響セル・優先度のジョブをエンキューする
+// 元のジョブは履歴として残し、保留中のジョブがある場合はそのジョブに統合する
+func (u *RetryClusterJobUseCase) Execute(ctx context.Context, id uuid.UUID) (*RetryClusterJobOutput, error) {
+	job, err := u.jobRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("ジョブの取得に失敗しました", err)
+	}
+	if job == nil {
+		return nil, apperror.NotFoundError("ジョブが見つかりません")
+	}
+	if !job.Status.IsRetryable() {
+		return nil, apperror.ConflictError(fmt.Sprintf("失敗・取り消し済みのジョブのみ再実行できます(現在のステータス: %s)", job.Status))
+	}
+
+	output, err := u.enqueueJobUC.Execute(ctx, EnqueueJobInput{
+		Priority:        job.Priority,
+		AffectedH3Cells: job.AffectedH3Cells,
+	})
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("再実行ジョブのエンキューに失敗しました", err)
+	}
+
+	retried, err := u.jobQuery.FindByID(ctx, output.JobID)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("再実行ジョブの取得に失敗しました", err)
+	}
+	if retried == nil {
+		return nil, apperror.InternalError("再実行ジョブが見つかりません")
+	}
+
+	u.logger.Info("クラスタージョブを再実行します",
+		slog.String("job_id", id.String()),
+		slog.String("retry_job_id", output.JobID.String()),
+		slog.Bool("merged", output.Merged))
+
+	return &RetryClusterJobOutput{
+		Job:    retried,
+		Merged: output.Merged,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryClusterJobUseCase_Execute(t *testing.T) {
+	failed := entity.NewClusterJobWithAffectedCells(5, []string{"8a2f5a32d827fff"})
+	failed.Fail("calculation error")
+	jobRepo := &mockClusterJobRepository{jobs: []*entity.ClusterJob{failed}}
+	retried := &query.ClusterJobSummary{ID: uuid.New(), Status: entity.JobStatusPending}
+	jobQuery := &mockClusterJobQuery{job: retried}
+	logger := getTestLogger()
+	uc := NewRetryClusterJobUseCase(jobRepo, NewEnqueueJobUseCase(jobRepo, logger), jobQuery, logger)
+
+	got, err := uc.Execute(context.Background(), failed.ID)
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, retried, got.Job, "再実行するジョブを返すべき")
+	require.False(t, got.Merged, "保留中ジョブがない場合は統合されないべき")
+	require.NotNil(t, jobRepo.enqueuedJob, "ジョブがエンキューされるべき")
+	require.NotEqual(t, failed.ID, jobRepo.enqueuedJob.ID, "元のジョブとは別のジョブを作成するべき")
+	require.Equal(t, failed.Priority, jobRepo.enqueuedJob.Priority, "元のジョブの優先度を引き継ぐべき")
+	require.Equal(t, failed.AffectedH3Cells, jobRepo.enqueuedJob.AffectedH3Cells, "元のジョブの影響セルを引き継ぐべき")
+	require.Equal(t, jobRepo.enqueuedJob.ID, jobQuery.gotID, "エンキューしたジョブを取得するべき")
+}
+
+func TestRetryClusterJobUseCase_Execute_MergeIntoPendingJob(t *testing.T) {
+	cancelled := entity.NewClusterJob(10)
+	cancelled.Status = entity.JobStatusCancelled
+	pendingJob := entity.NewClusterJob(0)
+	jobRepo := &mockClusterJobRepository{jobs: []*entity.ClusterJob{cancelled}, pendingJob: pendingJob}
+	jobQuery := &mockClusterJobQuery{job: &query.ClusterJobSummary{ID: pendingJob.ID, Status: entity.JobStatusPending}}
+	logger := getTestLogger()
+	uc := NewRetryClusterJobUseCase(jobRepo, NewEnqueueJobUseCase(jobRepo, logger), jobQuery, logger)
+
+	got, err := uc.Execute(context.Background(), cancelled.ID)
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.True(t, got.Merged, "保留中ジョブに統合されるべき")
+	require.Equal(t, pendingJob.ID, jobQuery.gotID, "統合先のジョブを取得するべき")
+}
+
+func TestRetryClusterJobUseCase_Execute_Error(t *testing.T) {
+	completed := entity.NewClusterJob(0)
+	completed.Complete()
+	failed := entity.NewClusterJob(0)
+	failed.Fail("calculation error")
+
+	tests := []struct {
+		name       string
+		jobRepo    *mockClusterJobRepository
+		jobQuery   *mockClusterJobQuery
+		wantStatus int
+	}{
+		{
+			name:       "ジョブが存在しない",
+			jobRepo:    &mockClusterJobRepository{},
+			jobQuery:   &mockClusterJobQuery{},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "完了済みのジョブ",
+			jobRepo:    &mockClusterJobRepository{jobs: []*entity.ClusterJob{completed}},
+			jobQuery:   &mockClusterJobQuery{},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "取得エラー",
+			jobRepo:    &mockClusterJobRepository{findByIDErr: errors.New("db error")},
+			jobQuery:   &mockClusterJobQuery{},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "エンキューエラー",
+			jobRepo:    &mockClusterJobRepository{jobs: []*entity.ClusterJob{failed}, enqueueErr: errors.New("db error")},
+			jobQuery:   &mockClusterJobQuery{},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := getTestLogger()
+			uc := NewRetryClusterJobUseCase(tt.jobRepo, NewEnqueueJobUseCase(tt.jobRepo, logger), tt.jobQuery, logger)
+
+			_, err := uc.Execute(context.Background(), uuid.New())
+
+			require.Error(t, err, "エラーを期待")
+			require.Equal(t, tt.wantStatus, errorStatus(err), "HTTPステータスが期待値と異なります")
+		})
+	}
+}
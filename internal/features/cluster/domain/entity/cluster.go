@@ -2,6 +2,10 @@
 package entity
 
 import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,35 +19,126 @@ const (
 	Res5 Resolution = 5 // 約10km - 都道府県レベル
 	Res7 Resolution = 7 // 約1km - 市区町村レベル
 	Res9 Resolution = 9 // 約100m - 詳細レベル
+
+	// MinResolution はH3の最も粗い解像度
+	MinResolution Resolution = 0
+	// MaxResolution はH3の最も詳細な解像度
+	MaxResolution Resolution = 15
 )
 
-// AllResolutions は全てのサポートされている解像度のリスト
-var AllResolutions = []Resolution{Res3, Res5, Res7, Res9}
+// DefaultResolutions はCLUSTER_RESOLUTIONSが未設定の場合にクラスターを計算する解像度のリスト
+var DefaultResolutions = []Resolution{Res3, Res5, Res7, Res9}
 
 // IsValid は解像度が有効かどうかを判定する
 func (r Resolution) IsValid() bool {
-	switch r {
-	case Res3, Res5, Res7, Res9:
-		return true
-	default:
-		return false
-	}
+	return r >= MinResolution && r <= MaxResolution
 }
 
 // String は解像度の文字列表現を返す
 func (r Resolution) String() string {
-	switch r {
-	case Res3:
-		return "res3"
-	case Res5:
-		return "res5"
-	case Res7:
-		return "res7"
-	case Res9:
-		return "res9"
-	default:
+	if !r.IsValid() {
 		return "unknown"
 	}
+	return fmt.Sprintf("res%d", int(r))
+}
+
+// ParseResolutions は文字列からクラスターを計算する解像度のリストを取得する
+// "3,5,7,9"のようなカンマ区切りと"2-10"のような範囲指定を組み合わせて指定できる
+// 空文字の場合はDefaultResolutionsを返す。結果は昇順で重複を含まない
+func ParseResolutions(s string) ([]Resolution, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultResolutions, nil
+	}
+
+	seen := make(map[Resolution]bool)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		from, to, isRange := strings.Cut(part, "-")
+		if !isRange {
+			to = from
+		}
+		lower, err := parseResolution(from)
+		if err != nil {
+			return nil, err
+		}
+		upper, err := parseResolution(to)
+		if err != nil {
+			return nil, err
+		}
+		if lower > upper {
+			return nil, fmt.Errorf("解像度の範囲が不正です: %s", part)
+		}
+		for r := lower; r <= upper; r++ {
+			seen[r] = true
+		}
+	}
+
+	resolutions := make([]Resolution, 0, len(seen))
+	for r := range seen {
+		resolutions = append(resolutions, r)
+	}
+	slices.Sort(resolutions)
+	return resolutions, nil
+}
+
+// parseResolution は文字列から単一の解像度を取得する
+func parseResolution(s string) (Resolution, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("解像度が数値ではありません: %s", s)
+	}
+	r := Resolution(n)
+	if !r.IsValid() {
+		return 0, fmt.Errorf("解像度は%dから%dの範囲で指定してください: %d", MinResolution, MaxResolution, n)
+	}
+	return r, nil
+}
+
+// AggregationMode はクラスター集計で圃場をH3セルに計上する方法を表す
+type AggregationMode string
+
+const (
+	// AggregationModeCentroid は圃場を重心のセル1つにのみ計上する(デフォルト)
+	AggregationModeCentroid AggregationMode = "centroid"
+	// AggregationModeCoverage は圃場を被覆する全てのセルにそれぞれ1件として計上する
+	AggregationModeCoverage AggregationMode = "coverage"
+	// AggregationModeAreaShare は圃場をセルに含まれる面積の割合で按分して計上する
+	AggregationModeAreaShare AggregationMode = "area_share"
+)
+
+// ParseAggregationMode は文字列から集計方法を取得する
+// 空文字の場合はAggregationModeCentroidを返す
+func ParseAggregationMode(s string) (AggregationMode, error) {
+	if s == "" {
+		return AggregationModeCentroid, nil
+	}
+	mode := AggregationMode(s)
+	if !mode.IsValid() {
+		return "", fmt.Errorf("未対応の集計方法です: %s", s)
+	}
+	return mode, nil
+}
+
+// IsValid は集計方法が有効かどうかを判定する
+func (m AggregationMode) IsValid() bool {
+	switch m {
+	case AggregationModeCentroid, AggregationModeCoverage, AggregationModeAreaShare:
+		return true
+	default:
+		return false
+	}
+}
+
+// UsesCoverage は集計にH3被覆を使用するかを判定する
+func (m AggregationMode) UsesCoverage() bool {
+	return m == AggregationModeCoverage || m == AggregationModeAreaShare
+}
+
+// H3Coverage は圃場が覆うH3セルと、圃場の面積のうちそのセルに含まれる割合
+type H3Coverage struct {
+	Resolution Resolution
+	H3Index    string
+	AreaShare  float64 // 0より大きく1以下。解像度ごとの合計は1
 }
 
 // Cluster はH3クラスタリング結果のエンティティ
@@ -54,6 +149,12 @@ type Cluster struct {
 	FieldCount   int32   // クラスターに含まれる圃場数
 	CenterLat    float64 // クラスター中心の緯度
 	CenterLng    float64 // クラスター中心の経度
+	TotalAreaSqm float64 // クラスターに含まれる圃場の合計面積(平方メートル)
+
+	LandCategoryCounts    map[string]int32 // 土地種別コード(田/畑など)ごとの圃場数
+	IdleFieldCount        int32            // 遊休農地状況が登録された圃場数
+	DominantSoilLargeCode *string          // 合計面積が最大の土壌大分類コード(土壌が未登録の場合はnil)
+
 	CalculatedAt time.Time
 }
 
@@ -72,18 +173,30 @@ func NewCluster(resolution Resolution, h3Index string, fieldCount int32, centerL
 
 // ClusterResult はAPIレスポンス用のクラスター情報
 type ClusterResult struct {
-	H3Index string
-	Lat     float64
-	Lng     float64
-	Count   int32
+	H3Index               string
+	Lat                   float64
+	Lng                   float64
+	Count                 int32
+	AreaSqm               float64
+	LandCategoryCounts    map[string]int32
+	IdleCount             int32
+	DominantSoilLargeCode *string
+
+	// Boundary はセルの境界(GeoJSONのポリゴンごとの外周リング、座標は[経度, 緯度])
+	// GetClustersInput.WithBoundaryを指定した場合のみ設定する
+	Boundary [][][]float64
 }
 
 // ToResult はClusterをClusterResultに変換する
 func (c *Cluster) ToResult() *ClusterResult {
 	return &ClusterResult{
-		H3Index: c.H3Index,
-		Lat:     c.CenterLat,
-		Lng:     c.CenterLng,
-		Count:   c.FieldCount,
+		H3Index:               c.H3Index,
+		Lat:                   c.CenterLat,
+		Lng:                   c.CenterLng,
+		Count:                 c.FieldCount,
+		AreaSqm:               c.TotalAreaSqm,
+		LandCategoryCounts:    c.LandCategoryCounts,
+		IdleCount:             c.IdleFieldCount,
+		DominantSoilLargeCode: c.DominantSoilLargeCode,
 	}
 }
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+)
+
+// ClusterFilter はクラスター集計の対象とする圃場の絞り込み条件
+// nilの条件は絞り込みに使用しない
+type ClusterFilter struct {
+	CityCode           *string  // 市区町村コード
+	SoilTypeSmallCode  *string  // 土壌小分類コード
+	LandCategoryCode   *string  // 土地種別コード(農地台帳)
+	IdleLandStatusCode *string  // 遊休農地状況コード(農地台帳)
+	MinAreaSqm         *float64 // 最小面積(平方メートル)
+	MaxAreaSqm         *float64 // 最大面積(平方メートル)
+}
+
+// IsEmpty は絞り込み条件が1つも指定されていないかどうかを判定する
+func (f *ClusterFilter) IsEmpty() bool {
+	return f == nil || len(f.values()) == 0
+}
+
+// Hash は絞り込み条件を一意に表すハッシュ値を返す
+// 条件の指定順序に依存せず、同じ条件であれば同じ値になる
+func (f *ClusterFilter) Hash() string {
+	if f == nil {
+		return hashOf("")
+	}
+	// url.Values.Encodeはキー順に並べるため、正規化された表現になる
+	return hashOf(f.values().Encode())
+}
+
+// values は指定された絞り込み条件をクエリパラメータ名をキーとして返す
+func (f *ClusterFilter) values() url.Values {
+	v := url.Values{}
+	setString := func(key string, value *string) {
+		if value != nil {
+			v.Set(key, *value)
+		}
+	}
+	setFloat := func(key string, value *float64) {
+		if value != nil {
+			v.Set(key, strconv.FormatFloat(*value, 'g', -1, 64))
+		}
+	}
+	setString("city_code", f.CityCode)
+	setString("soil_type", f.SoilTypeSmallCode)
+	setString("land_category", f.LandCategoryCode)
+	setString("idle_status", f.IdleLandStatusCode)
+	setFloat("min_area_sqm", f.MinAreaSqm)
+	setFloat("max_area_sqm", f.MaxAreaSqm)
+	return v
+}
+
+// hashOf は文字列のSHA-256ハッシュ値を16進数文字列で返す
+func hashOf(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
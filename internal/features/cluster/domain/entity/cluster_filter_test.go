@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+// TestClusterFilter_IsEmpty はIsEmptyメソッドが条件の有無を正しく判定することをテストする
+func TestClusterFilter_IsEmpty(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *ClusterFilter
+		want   bool
+	}{
+		{"nilは空", nil, true},
+		{"条件なしは空", &ClusterFilter{}, true},
+		{"市区町村コードのみ", &ClusterFilter{CityCode: strPtr("163210")}, false},
+		{"最小面積0も条件として扱う", &ClusterFilter{MinAreaSqm: floatPtr(0)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.filter.IsEmpty(), "IsEmptyの結果が期待値と異なります")
+		})
+	}
+}
+
+// TestClusterFilter_Hash は同じ条件で同じハッシュ値、異なる条件で異なるハッシュ値になることをテストする
+func TestClusterFilter_Hash(t *testing.T) {
+	base := &ClusterFilter{
+		CityCode:           strPtr("163210"),
+		IdleLandStatusCode: strPtr("1"),
+		MinAreaSqm:         floatPtr(1000),
+	}
+	same := &ClusterFilter{
+		MinAreaSqm:         floatPtr(1000),
+		IdleLandStatusCode: strPtr("1"),
+		CityCode:           strPtr("163210"),
+	}
+
+	require.Equal(t, base.Hash(), same.Hash(), "同じ条件のハッシュ値が一致しません")
+	require.Len(t, base.Hash(), 64, "SHA-256の16進数文字列であるべき")
+
+	others := []*ClusterFilter{
+		{CityCode: strPtr("163210"), IdleLandStatusCode: strPtr("1")},
+		{CityCode: strPtr("163210"), IdleLandStatusCode: strPtr("1"), MinAreaSqm: floatPtr(1001)},
+		{CityCode: strPtr("163210"), IdleLandStatusCode: strPtr("1"), MaxAreaSqm: floatPtr(1000)},
+		{CityCode: strPtr("163210"), LandCategoryCode: strPtr("1"), MinAreaSqm: floatPtr(1000)},
+	}
+	for _, other := range others {
+		require.NotEqual(t, base.Hash(), other.Hash(), "異なる条件のハッシュ値が一致しています")
+	}
+}
@@ -2,6 +2,7 @@
 package entity
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,12 +16,13 @@ const (
 	JobStatusProcessing JobStatus = "processing"
 	JobStatusCompleted  JobStatus = "completed"
 	JobStatusFailed     JobStatus = "failed"
+	JobStatusCancelled  JobStatus = "cancelled"
 )
 
 // IsValid はステータスが有効かどうかを判定する
 func (s JobStatus) IsValid() bool {
 	switch s {
-	case JobStatusPending, JobStatusProcessing, JobStatusCompleted, JobStatusFailed:
+	case JobStatusPending, JobStatusProcessing, JobStatusCompleted, JobStatusFailed, JobStatusCancelled:
 		return true
 	default:
 		return false
@@ -29,9 +31,17 @@ func (s JobStatus) IsValid() bool {
 
 // IsTerminal はステータスが終了状態かどうかを判定する
 func (s JobStatus) IsTerminal() bool {
-	return s == JobStatusCompleted || s == JobStatusFailed
+	return s == JobStatusCompleted || s == JobStatusFailed || s == JobStatusCancelled
 }
 
+// IsRetryable は再実行できるステータス(失敗・取り消し済み)かどうかを判定する
+func (s JobStatus) IsRetryable() bool {
+	return s == JobStatusFailed || s == JobStatusCancelled
+}
+
+// ErrJobLeaseLost はジョブのリースが他のワーカーに移ったか、期限切れで回収されたことを示す
+var ErrJobLeaseLost = errors.New("クラスタージョブのリースが失われました")
+
 // ClusterJob はクラスタリングジョブのエンティティ
 type ClusterJob struct {
 	ID              uuid.UUID
@@ -42,6 +52,9 @@ type ClusterJob struct {
 	StartedAt       *time.Time
 	CompletedAt     *time.Time
 	ErrorMessage    string
+	WorkerID        string     // 処理中のワーカーID
+	HeartbeatAt     *time.Time // 処理中のワーカーの最終ハートビート日時
+	Attempts        int32      // 実行回数
 }
 
 // NewClusterJob は新しいClusterJobを作成する(全範囲再計算)
@@ -113,3 +126,8 @@ func (j *ClusterJob) IsCompleted() bool {
 func (j *ClusterJob) IsFailed() bool {
 	return j.Status == JobStatusFailed
 }
+
+// HasAttemptsLeft は最大実行回数に達しておらず、再実行できるかどうかを判定する
+func (j *ClusterJob) HasAttemptsLeft(maxAttempts int32) bool {
+	return j.Attempts < maxAttempts
+}
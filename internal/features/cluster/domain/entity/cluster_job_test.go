@@ -19,6 +19,7 @@ func TestJobStatus_IsValid(t *testing.T) {
 		{"processingは有効", JobStatusProcessing, true},
 		{"completedは有効", JobStatusCompleted, true},
 		{"failedは有効", JobStatusFailed, true},
+		{"cancelledは有効", JobStatusCancelled, true},
 		// 異常系: 無効なステータス
 		{"空文字列は無効", JobStatus(""), false},
 		{"invalidは無効", JobStatus("invalid"), false},
@@ -46,6 +47,7 @@ func TestJobStatus_IsTerminal(t *testing.T) {
 		{"processingは非終端", JobStatusProcessing, false},
 		{"completedは終端", JobStatusCompleted, true},
 		{"failedは終端", JobStatusFailed, true},
+		{"cancelledは終端", JobStatusCancelled, true},
 	}
 
 	for _, tt := range tests {
@@ -58,6 +60,30 @@ func TestJobStatus_IsTerminal(t *testing.T) {
 	}
 }
 
+// TestJobStatus_IsRetryable はIsRetryableメソッドが再実行できるステータスを正しく判定することをテストする
+func TestJobStatus_IsRetryable(t *testing.T) {
+	tests := []struct {
+		name   string
+		status JobStatus
+		want   bool
+	}{
+		{"pendingは再実行不可", JobStatusPending, false},
+		{"processingは再実行不可", JobStatusProcessing, false},
+		{"completedは再実行不可", JobStatusCompleted, false},
+		{"failedは再実行可能", JobStatusFailed, true},
+		{"cancelledは再実行可能", JobStatusCancelled, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.status.IsRetryable()
+			if got != tt.want {
+				t.Errorf("IsRetryable() = %v, 期待値 %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestNewClusterJob はNewClusterJobが正しい初期値でClusterJobを生成することをテストする
 func TestNewClusterJob(t *testing.T) {
 	priority := int32(10)
@@ -230,6 +256,29 @@ func TestClusterJob_IsPending(t *testing.T) {
 	}
 }
 
+// TestClusterJob_HasAttemptsLeft はHasAttemptsLeftメソッドが最大実行回数未満の場合のみtrueを返すことをテストする
+func TestClusterJob_HasAttemptsLeft(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int32
+		want     bool
+	}{
+		{"未実行はtrue", 0, true},
+		{"最大実行回数未満はtrue", 2, true},
+		{"最大実行回数に達したらfalse", 3, false},
+		{"最大実行回数を超えたらfalse", 4, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &ClusterJob{Attempts: tt.attempts}
+			if got := job.HasAttemptsLeft(3); got != tt.want {
+				t.Errorf("HasAttemptsLeft(3) = %v, 期待値 %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestClusterJob_IsProcessing はIsProcessingメソッドがProcessingステータスのみtrueを返すことをテストする
 func TestClusterJob_IsProcessing(t *testing.T) {
 	tests := []struct {
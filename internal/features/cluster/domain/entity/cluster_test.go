@@ -19,14 +19,11 @@ func TestResolution_IsValid(t *testing.T) {
 		{"res5は有効", Res5, true},
 		{"res7は有効", Res7, true},
 		{"res9は有効", Res9, true},
+		{"res0は有効", Resolution(0), true},
+		{"res4は有効", Resolution(4), true},
+		{"res15は有効", Resolution(15), true},
 		// 異常系: 無効な解像度
-		{"res0は無効", Resolution(0), false},
-		{"res1は無効", Resolution(1), false},
-		{"res2は無効", Resolution(2), false},
-		{"res4は無効", Resolution(4), false},
-		{"res6は無効", Resolution(6), false},
-		{"res8は無効", Resolution(8), false},
-		{"res10は無効", Resolution(10), false},
+		{"res16は無効", Resolution(16), false},
 		{"負の値は無効", Resolution(-1), false},
 	}
 
@@ -51,6 +48,7 @@ func TestResolution_String(t *testing.T) {
 		{"res5の文字列表現", Res5, "res5"},
 		{"res7の文字列表現", Res7, "res7"},
 		{"res9の文字列表現", Res9, "res9"},
+		{"res12の文字列表現", Resolution(12), "res12"},
 		{"未知の解像度の文字列表現", Resolution(100), "unknown"},
 	}
 
@@ -64,19 +62,38 @@ func TestResolution_String(t *testing.T) {
 	}
 }
 
-// TestAllResolutions はAllResolutionsが全てのサポートされている解像度を含むことをテストする
-func TestAllResolutions(t *testing.T) {
-	// 期待する解像度のリスト
-	expected := []Resolution{Res3, Res5, Res7, Res9}
+// TestDefaultResolutions はDefaultResolutionsが従来の解像度を含むことをテストする
+func TestDefaultResolutions(t *testing.T) {
+	require.Equal(t, []Resolution{Res3, Res5, Res7, Res9}, DefaultResolutions, "デフォルトの解像度が一致しない")
+}
 
-	if len(AllResolutions) != len(expected) {
-		t.Errorf("AllResolutionsの長さ = %d, 期待値 %d", len(AllResolutions), len(expected))
+// TestParseResolutions はParseResolutionsがカンマ区切りと範囲指定を解釈することをテストする
+func TestParseResolutions(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Resolution
+		wantErr bool
+	}{
+		{"空文字はデフォルト", "", DefaultResolutions, false},
+		{"カンマ区切り", "3,5,7,9", []Resolution{3, 5, 7, 9}, false},
+		{"範囲指定", "2-5", []Resolution{2, 3, 4, 5}, false},
+		{"組み合わせは昇順で重複を除く", "9, 2-4,3", []Resolution{2, 3, 4, 9}, false},
+		{"範囲外", "3-16", nil, true},
+		{"逆順の範囲", "7-3", nil, true},
+		{"数値以外", "res3", nil, true},
 	}
 
-	for i, res := range expected {
-		if AllResolutions[i] != res {
-			t.Errorf("AllResolutions[%d] = %v, 期待値 %v", i, AllResolutions[i], res)
-		}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseResolutions(tt.input)
+			if tt.wantErr {
+				require.Error(t, err, "エラーが返されるべき")
+				return
+			}
+			require.NoError(t, err, "ParseResolutionsでエラーが発生")
+			require.Equal(t, tt.want, got, "解像度のリストが一致しない")
+		})
 	}
 }
 
@@ -128,10 +145,14 @@ func TestNewCluster(t *testing.T) {
 // TestCluster_ToResult はToResultメソッドがClusterResultに正しく変換することをテストする
 func TestCluster_ToResult(t *testing.T) {
 	cluster := &Cluster{
-		H3Index:    "871f1a4adffffff",
-		CenterLat:  35.681236,
-		CenterLng:  139.767125,
-		FieldCount: 42,
+		H3Index:      "871f1a4adffffff",
+		CenterLat:    35.681236,
+		CenterLng:    139.767125,
+		FieldCount:   42,
+		TotalAreaSqm: 123456.7,
+
+		LandCategoryCounts: map[string]int32{"100": 40, "200": 2},
+		IdleFieldCount:     5,
 	}
 
 	result := cluster.ToResult()
@@ -153,6 +174,20 @@ func TestCluster_ToResult(t *testing.T) {
 	if result.Count != cluster.FieldCount {
 		t.Errorf("Count = %d, 期待値 %d", result.Count, cluster.FieldCount)
 	}
+
+	if result.AreaSqm != cluster.TotalAreaSqm {
+		t.Errorf("AreaSqm = %f, 期待値 %f", result.AreaSqm, cluster.TotalAreaSqm)
+	}
+
+	require.Equal(t, cluster.LandCategoryCounts, result.LandCategoryCounts, "LandCategoryCountsが期待値と異なります")
+
+	if result.IdleCount != cluster.IdleFieldCount {
+		t.Errorf("IdleCount = %d, 期待値 %d", result.IdleCount, cluster.IdleFieldCount)
+	}
+
+	if result.DominantSoilLargeCode != nil {
+		t.Errorf("DominantSoilLargeCode = %v, 期待値 nil", *result.DominantSoilLargeCode)
+	}
 }
 
 // TestCluster_ToResult_ZeroValues はToResultメソッドがゼロ値でも正しく動作することをテストする
@@ -210,3 +245,35 @@ func TestClusterResult(t *testing.T) {
 		t.Errorf("Count = %d, 期待値 100", result.Count)
 	}
 }
+
+// TestParseAggregationMode は集計方法の文字列を正しく解釈することをテストする
+func TestParseAggregationMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    AggregationMode
+		wantErr bool
+	}{
+		{"空文字は重心", "", AggregationModeCentroid, false},
+		{"centroid", "centroid", AggregationModeCentroid, false},
+		{"coverage", "coverage", AggregationModeCoverage, false},
+		{"area_share", "area_share", AggregationModeAreaShare, false},
+		{"未対応の値", "polyfill", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAggregationMode(tt.input)
+			if tt.wantErr {
+				require.Error(t, err, "未対応の値はエラーを返すべき")
+				return
+			}
+			require.NoError(t, err, "ParseAggregationModeでエラーが発生")
+			require.Equal(t, tt.want, got, "集計方法が一致しない")
+		})
+	}
+
+	require.False(t, AggregationModeCentroid.UsesCoverage(), "重心モードは被覆を使用しない")
+	require.True(t, AggregationModeCoverage.UsesCoverage(), "被覆モードは被覆を使用する")
+	require.True(t, AggregationModeAreaShare.UsesCoverage(), "面積按分モードは被覆を使用する")
+}
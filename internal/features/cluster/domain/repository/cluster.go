@@ -3,29 +3,62 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 )
 
 // AggregatedCluster は集計されたクラスター情報
 type AggregatedCluster struct {
-	H3Index    string
-	FieldCount int32
+	H3Index               string
+	FieldCount            int32
+	TotalAreaSqm          float64
+	LandCategoryCounts    map[string]int32 // 土地種別コードごとの圃場数
+	IdleFieldCount        int32            // 遊休農地の圃場数
+	DominantSoilLargeCode *string          // 合計面積が最大の土壌大分類コード
+}
+
+// Bounds は経度・緯度で表す矩形の範囲
+// SWLngがNELngより大きい場合は日付変更線をまたぐ範囲を表す
+type Bounds struct {
+	SWLat float64 // 南西端の緯度
+	SWLng float64 // 南西端の経度
+	NELat float64 // 北東端の緯度
+	NELng float64 // 北東端の経度
 }
 
 // ClusterRepository はクラスター結果のリポジトリインターフェース
+//
+// クラスター結果は世代ごとに保持する。全範囲再計算は新しい世代に書き込み、
+// 全解像度の計算が終わってから有効化するため、読み取り側は常にいずれかの世代の完全な結果を参照する
 type ClusterRepository interface {
-	// GetClusters は指定解像度のクラスター結果を取得する
-	GetClusters(ctx context.Context, resolution entity.Resolution) ([]*entity.Cluster, error)
+	// GetActiveGeneration は有効な世代を取得する
+	GetActiveGeneration(ctx context.Context) (int64, error)
+
+	// ListLiveGenerations は有効な世代と、それより新しい計算中の世代を取得する
+	// 差分更新は計算中の世代にも書き込み、全範囲再計算の実行中の変更が有効化で失われないようにする
+	ListLiveGenerations(ctx context.Context) ([]int64, error)
+
+	// CreateGeneration は全範囲再計算の書き込み先となる世代を作成する
+	CreateGeneration(ctx context.Context) (int64, error)
+
+	// ActivateGeneration は計算が完了した世代を有効化する
+	// より新しい世代が既に有効な場合は有効化せずfalseを返す
+	ActivateGeneration(ctx context.Context, generation int64) (bool, error)
 
-	// SaveClusters は複数のクラスター結果を保存する
-	SaveClusters(ctx context.Context, clusters []*entity.Cluster) error
+	// DeleteGeneration は有効化していない世代をクラスター結果ごと削除する
+	DeleteGeneration(ctx context.Context, generation int64) error
 
-	// DeleteClustersByResolution は指定解像度のクラスター結果を削除する
-	DeleteClustersByResolution(ctx context.Context, resolution entity.Resolution) error
+	// DeleteUnusedGenerations は使用されなくなった世代をクラスター結果ごと削除し、削除した世代数を返す
+	// 有効な世代と直前に有効だった世代は残し、有効化されていない世代は作成からstaleAfterを過ぎたもののみ削除する
+	DeleteUnusedGenerations(ctx context.Context, staleAfter time.Duration) (int64, error)
 
-	// DeleteAllClusters は全てのクラスター結果を削除する
-	DeleteAllClusters(ctx context.Context) error
+	// GetClustersInCells は指定世代・解像度のクラスター結果のうち、指定セルに含まれるものを取得する
+	// cellsは指定解像度以下の解像度のセル(表示範囲を覆う検索セル)を指定する
+	GetClustersInCells(ctx context.Context, generation int64, resolution entity.Resolution, cells []string) ([]*entity.Cluster, error)
+
+	// SaveClusters は複数のクラスター結果を指定世代に保存する
+	SaveClusters(ctx context.Context, generation int64, clusters []*entity.Cluster) error
 
 	// AggregateByH3 は指定解像度でfieldsテーブルを集計する(全範囲)
 	AggregateByH3(ctx context.Context, resolution entity.Resolution) ([]*AggregatedCluster, error)
@@ -33,6 +66,17 @@ type ClusterRepository interface {
 	// AggregateByH3ForCells は指定H3セルのみfieldsテーブルを集計する(差分更新用)
 	AggregateByH3ForCells(ctx context.Context, resolution entity.Resolution, h3Cells []string) ([]*AggregatedCluster, error)
 
-	// DeleteClustersByH3Indexes は指定H3インデックスのクラスター結果を削除する
-	DeleteClustersByH3Indexes(ctx context.Context, resolution entity.Resolution, h3Indexes []string) error
+	// AggregateByCoverage は指定解像度でH3被覆を集計する(全範囲)
+	// modeはAggregationModeCoverageまたはAggregationModeAreaShareを指定する
+	AggregateByCoverage(ctx context.Context, resolution entity.Resolution, mode entity.AggregationMode) ([]*AggregatedCluster, error)
+
+	// AggregateByCoverageForCells は指定H3セルのみH3被覆を集計する(差分更新用)
+	AggregateByCoverageForCells(ctx context.Context, resolution entity.Resolution, mode entity.AggregationMode, h3Cells []string) ([]*AggregatedCluster, error)
+
+	// AggregateFiltered は絞り込み条件に一致し、重心がboundsに含まれる圃場を指定解像度でその場で集計する
+	// 事前計算したクラスター結果は使用しない
+	AggregateFiltered(ctx context.Context, resolution entity.Resolution, filter *entity.ClusterFilter, bounds Bounds) ([]*AggregatedCluster, error)
+
+	// DeleteClustersByH3Indexes は指定世代の指定H3インデックスのクラスター結果を削除する
+	DeleteClustersByH3Indexes(ctx context.Context, generation int64, resolution entity.Resolution, h3Indexes []string) error
 }
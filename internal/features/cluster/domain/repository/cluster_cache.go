@@ -9,12 +9,21 @@ import (
 
 // ClusterCacheRepository はクラスター結果のキャッシュリポジトリインターフェース
 type ClusterCacheRepository interface {
-	// GetClusters はキャッシュから指定解像度のクラスター結果を取得する
-	// キャッシュミスの場合はnilを返す
-	GetClusters(ctx context.Context, resolution entity.Resolution) ([]*entity.Cluster, error)
+	// GetClustersByCells はキャッシュから指定世代・解像度のクラスター結果を検索セルごとに取得する
+	// キャッシュにある検索セルのみ返す。クラスターがない検索セルは空のスライスになる
+	GetClustersByCells(ctx context.Context, generation int64, resolution entity.Resolution, cells []string) (map[string][]*entity.Cluster, error)
 
-	// SetClusters はクラスター結果をキャッシュに保存する
-	SetClusters(ctx context.Context, resolution entity.Resolution, clusters []*entity.Cluster) error
+	// SetClustersByCells は指定世代の検索セルごとのクラスター結果をキャッシュに保存する
+	// クラスターがない検索セルも空の結果としてキャッシュする
+	SetClustersByCells(ctx context.Context, generation int64, resolution entity.Resolution, clustersByCell map[string][]*entity.Cluster) error
+
+	// GetFilteredClustersByCells はキャッシュから絞り込み集計の結果を検索セルごとに取得する
+	// filterHashは絞り込み条件のハッシュ値。キャッシュにある検索セルのみ返す
+	GetFilteredClustersByCells(ctx context.Context, resolution entity.Resolution, filterHash string, cells []string) (map[string][]*entity.Cluster, error)
+
+	// SetFilteredClustersByCells は検索セルごとの絞り込み集計の結果を短いTTLでキャッシュに保存する
+	// 該当する圃場がない検索セルも空の結果としてキャッシュする
+	SetFilteredClustersByCells(ctx context.Context, resolution entity.Resolution, filterHash string, clustersByCell map[string][]*entity.Cluster) error
 
 	// DeleteClusters は全解像度のクラスター結果をキャッシュから削除する
 	DeleteClusters(ctx context.Context) error
@@ -3,6 +3,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
@@ -10,36 +11,51 @@ import (
 
 // ClusterJobRepository はクラスタージョブのリポジトリインターフェース
 type ClusterJobRepository interface {
-	// Create は新しいクラスタージョブを作成する(全範囲再計算)
-	Create(ctx context.Context, job *entity.ClusterJob) error
-
-	// CreateWithAffectedCells は影響セル情報付きでクラスタージョブを作成する
-	CreateWithAffectedCells(ctx context.Context, job *entity.ClusterJob) error
+	// Enqueue はクラスタージョブをエンキューする
+	// 保留中のジョブが既にある場合は新規作成せずに影響セルを統合し、統合先のジョブとtrueを返す
+	// 統合後の影響セル数がmaxAffectedCellsを超える場合は全範囲再計算に昇格する
+	Enqueue(ctx context.Context, job *entity.ClusterJob, maxAffectedCells int32) (*entity.ClusterJob, bool, error)
 
 	// FindByID はIDでクラスタージョブを取得する
+	// 存在しない場合はnilを返す
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.ClusterJob, error)
 
-	// FindPendingJobs は保留中のジョブを優先度順に取得する(排他ロック付き)
-	FindPendingJobs(ctx context.Context, limit int32) ([]*entity.ClusterJob, error)
+	// Claim は最も優先度の高い保留中ジョブを処理中に更新し、指定ワーカーのリースとして取得する
+	// 取得できるジョブがない場合はnilを返す
+	Claim(ctx context.Context, workerID string) (*entity.ClusterJob, error)
 
-	// FindPendingJobsWithAffectedCells は影響セル情報付きで保留中のジョブを取得する
-	FindPendingJobsWithAffectedCells(ctx context.Context, limit int32) ([]*entity.ClusterJob, error)
+	// Heartbeat は処理中ジョブのハートビートを更新してリースを延長する
+	// 指定ワーカーがリースを保持していない場合はentity.ErrJobLeaseLostを返す
+	Heartbeat(ctx context.Context, id uuid.UUID, workerID string) error
 
-	// UpdateToProcessing はジョブを処理中に更新する
-	UpdateToProcessing(ctx context.Context, id uuid.UUID) error
+	// UpdateToCompleted は指定ワーカーが処理中のジョブを完了に更新する
+	// 指定ワーカーがリースを保持していない場合はentity.ErrJobLeaseLostを返す
+	UpdateToCompleted(ctx context.Context, id uuid.UUID, workerID string) error
 
-	// UpdateToCompleted はジョブを完了に更新する
-	UpdateToCompleted(ctx context.Context, id uuid.UUID) error
+	// UpdateToFailed は指定ワーカーが処理中のジョブを失敗に更新する
+	// 指定ワーカーがリースを保持していない場合はentity.ErrJobLeaseLostを返す
+	UpdateToFailed(ctx context.Context, id uuid.UUID, workerID string, errorMessage string) error
 
-	// UpdateToFailed はジョブを失敗に更新する
-	UpdateToFailed(ctx context.Context, id uuid.UUID, errorMessage string) error
+	// RecoverExpiredJobs はハートビートがleaseDurationを超えて途絶えた処理中ジョブを回収する
+	// 実行回数がmaxAttempts未満のジョブは保留中に戻し(保留中ジョブがあれば統合し)、それ以外は失敗にする
+	RecoverExpiredJobs(ctx context.Context, leaseDuration time.Duration, maxAttempts int32, maxAffectedCells int32) (*RecoveredJobs, error)
 
 	// HasPendingOrProcessingJob は保留中または処理中のジョブがあるか確認する
 	HasPendingOrProcessingJob(ctx context.Context) (bool, error)
 
-	// DeleteOldCompletedJobs は古い完了済みジョブを削除する
-	DeleteOldCompletedJobs(ctx context.Context) error
+	// Cancel は保留中のジョブを取り消し済みに更新する
+	// ジョブが存在しないか保留中でない場合はfalseを返す
+	Cancel(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// DeleteOldCompletedJobs は完了からretentionを過ぎたジョブを削除し、削除件数を返す
+	DeleteOldCompletedJobs(ctx context.Context, retention time.Duration) (int64, error)
+
+	// DeleteOldFailedJobs は失敗・取り消しからretentionを過ぎたジョブを削除し、削除件数を返す
+	DeleteOldFailedJobs(ctx context.Context, retention time.Duration) (int64, error)
+}
 
-	// DeleteOldFailedJobs は古い失敗ジョブを削除する
-	DeleteOldFailedJobs(ctx context.Context) error
+// RecoveredJobs はリース期限切れジョブの回収結果
+type RecoveredJobs struct {
+	Requeued int // 保留中に戻した(または保留中ジョブに統合した)ジョブ数
+	Failed   int // 最大実行回数に達したため失敗にしたジョブ数
 }
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/twpayne/go-geom"
+)
+
+// FieldCoverageSource はH3被覆の計算対象の圃場
+type FieldCoverageSource struct {
+	FieldID   uuid.UUID
+	Geometry  *geom.MultiPolygon // デコードできなかった場合はnil
+	UpdatedAt time.Time          // 被覆の計算元として記録する圃場の更新日時
+}
+
+// H3CoverageRepository は圃場のH3被覆のリポジトリインターフェース
+type H3CoverageRepository interface {
+	// ListStaleSources は被覆が未計算、または計算後に更新された有効な圃場をafterIDより後からID順にlimit件取得する
+	ListStaleSources(ctx context.Context, afterID *uuid.UUID, limit int32) ([]*FieldCoverageSource, error)
+
+	// ReplaceCoverages は圃場の被覆を置き換え、被覆または面積比率が変わったセルを返す
+	ReplaceCoverages(ctx context.Context, fieldID uuid.UUID, sourceUpdatedAt time.Time, coverages []*entity.H3Coverage) ([]string, error)
+
+	// DeleteOrphanCoverages は削除済み・廃止済みの圃場の被覆を削除し、削除した被覆のセルを返す
+	DeleteOrphanCoverages(ctx context.Context) ([]string, error)
+}
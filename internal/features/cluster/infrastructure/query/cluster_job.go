@@ -0,0 +1,130 @@
+// Package query はクラスタリング機能の照会インターフェースの実装を提供する
+package query
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	appQuery "github.com/mktkhr/field-manager-api/internal/features/cluster/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/mktkhr/field-manager-api/internal/utils"
+)
+
+// clusterJobQuery はClusterJobQueryの実装
+type clusterJobQuery struct {
+	db      *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewClusterJobQuery は新しいClusterJobQueryを作成する
+func NewClusterJobQuery(db *pgxpool.Pool) appQuery.ClusterJobQuery {
+	return &clusterJobQuery{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+// List は検索条件に一致するジョブを作成日時の新しい順に取得する
+func (q *clusterJobQuery) List(ctx context.Context, filter appQuery.ClusterJobFilter, limit, offset int32) ([]*appQuery.ClusterJobSummary, error) {
+	status, createdFrom, createdTo := toJobFilterParams(filter)
+	rows, err := q.queries.ListClusterJobs(ctx, &sqlc.ListClusterJobsParams{
+		Status:      status,
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+		RowLimit:    limit,
+		RowOffset:   offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*appQuery.ClusterJobSummary, len(rows))
+	for i, row := range rows {
+		jobs[i] = toJobSummary(row)
+	}
+	return jobs, nil
+}
+
+// Count は検索条件に一致するジョブの総数を取得する
+func (q *clusterJobQuery) Count(ctx context.Context, filter appQuery.ClusterJobFilter) (int64, error) {
+	status, createdFrom, createdTo := toJobFilterParams(filter)
+	return q.queries.CountClusterJobs(ctx, &sqlc.CountClusterJobsParams{
+		Status:      status,
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+	})
+}
+
+// FindByID はIDでジョブを取得する
+func (q *clusterJobQuery) FindByID(ctx context.Context, id uuid.UUID) (*appQuery.ClusterJobSummary, error) {
+	job, err := q.queries.GetClusterJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// 一覧と同じく影響セルは件数のみに変換する
+	return toJobSummary(&sqlc.ListClusterJobsRow{
+		ID:                job.ID,
+		Status:            job.Status,
+		Priority:          job.Priority,
+		FullRecalculation: job.AffectedH3Cells == nil,
+		AffectedCellCount: utils.SafeIntToInt32(len(job.AffectedH3Cells)),
+		Attempts:          job.Attempts,
+		WorkerID:          job.WorkerID,
+		CreatedAt:         job.CreatedAt,
+		StartedAt:         job.StartedAt,
+		CompletedAt:       job.CompletedAt,
+		ErrorMessage:      job.ErrorMessage,
+	}), nil
+}
+
+// toJobFilterParams は検索条件をSQLCのパラメータ型に変換する
+func toJobFilterParams(filter appQuery.ClusterJobFilter) (*string, pgtype.Timestamptz, pgtype.Timestamptz) {
+	var status *string
+	if filter.Status != nil {
+		s := string(*filter.Status)
+		status = &s
+	}
+	return status, toTimestamptz(filter.CreatedFrom), toTimestamptz(filter.CreatedTo)
+}
+
+// toTimestamptz は日時をSQLCのパラメータ型に変換する(nilは無効値)
+func toTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
+// toJobSummary はジョブ履歴のSQLCの結果を照会結果に変換する
+func toJobSummary(row *sqlc.ListClusterJobsRow) *appQuery.ClusterJobSummary {
+	s := &appQuery.ClusterJobSummary{
+		ID:                row.ID,
+		Status:            entity.JobStatus(row.Status),
+		Priority:          row.Priority,
+		FullRecalculation: row.FullRecalculation,
+		AffectedCellCount: int(row.AffectedCellCount),
+		Attempts:          row.Attempts,
+		WorkerID:          row.WorkerID,
+		ErrorMessage:      row.ErrorMessage,
+	}
+	if row.CreatedAt.Valid {
+		s.CreatedAt = row.CreatedAt.Time
+	}
+	if row.StartedAt.Valid {
+		s.StartedAt = &row.StartedAt.Time
+	}
+	if row.CompletedAt.Valid {
+		s.CompletedAt = &row.CompletedAt.Time
+	}
+	return s
+}
@@ -0,0 +1,52 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	appQuery "github.com/mktkhr/field-manager-api/internal/features/cluster/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToJobFilterParams(t *testing.T) {
+	status := entity.JobStatusCancelled
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	gotStatus, gotFrom, gotTo := toJobFilterParams(appQuery.ClusterJobFilter{Status: &status, CreatedFrom: &from})
+	require.Equal(t, "cancelled", *gotStatus, "ステータスが一致しない")
+	require.Equal(t, pgtype.Timestamptz{Time: from, Valid: true}, gotFrom, "作成日時の下限が一致しない")
+	require.False(t, gotTo.Valid, "未指定の作成日時の上限は無効値にすべき")
+
+	gotStatus, gotFrom, _ = toJobFilterParams(appQuery.ClusterJobFilter{})
+	require.Nil(t, gotStatus, "未指定のステータスはnilにすべき")
+	require.False(t, gotFrom.Valid, "未指定の作成日時の下限は無効値にすべき")
+}
+
+func TestToJobSummary(t *testing.T) {
+	now := time.Now()
+	workerID := "worker-1"
+	row := &sqlc.ListClusterJobsRow{
+		ID:                uuid.New(),
+		Status:            "processing",
+		Priority:          5,
+		FullRecalculation: false,
+		AffectedCellCount: 12,
+		Attempts:          2,
+		WorkerID:          &workerID,
+		CreatedAt:         pgtype.Timestamptz{Time: now, Valid: true},
+		StartedAt:         pgtype.Timestamptz{Time: now, Valid: true},
+	}
+
+	got := toJobSummary(row)
+
+	require.Equal(t, entity.JobStatusProcessing, got.Status, "ステータスが一致しない")
+	require.Equal(t, 12, got.AffectedCellCount, "影響セル数が一致しない")
+	require.Equal(t, now, got.CreatedAt, "作成日時が一致しない")
+	require.Equal(t, &now, got.StartedAt, "開始日時が一致しない")
+	require.Nil(t, got.CompletedAt, "未完了ならnilにすべき")
+	require.Equal(t, &workerID, got.WorkerID, "ワーカーIDが一致しない")
+}
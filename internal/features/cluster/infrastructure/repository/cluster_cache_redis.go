@@ -11,7 +11,6 @@ import (
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
 	"github.com/mktkhr/field-manager-api/internal/infrastructure/cache"
-	"github.com/redis/go-redis/v9"
 )
 
 const (
@@ -20,15 +19,26 @@ const (
 
 	// clusterCacheTTL はクラスターキャッシュのTTL
 	clusterCacheTTL = 30 * time.Minute
+
+	// filteredClusterCacheKeyPrefix は絞り込み集計のキャッシュのキープレフィックス
+	filteredClusterCacheKeyPrefix = "cluster:filtered:"
+
+	// filteredClusterCacheTTL は絞り込み集計のキャッシュのTTL
+	// 圃場を直接集計した結果のため再計算ジョブでは削除されず、TTLで失効させる
+	filteredClusterCacheTTL = 1 * time.Minute
 )
 
 // clusterCacheData はキャッシュに保存するクラスターデータ
 type clusterCacheData struct {
-	H3Index      string  `json:"h3_index"`
-	FieldCount   int32   `json:"field_count"`
-	CenterLat    float64 `json:"center_lat"`
-	CenterLng    float64 `json:"center_lng"`
-	CalculatedAt int64   `json:"calculated_at"` // Unix timestamp
+	H3Index               string           `json:"h3_index"`
+	FieldCount            int32            `json:"field_count"`
+	CenterLat             float64          `json:"center_lat"`
+	CenterLng             float64          `json:"center_lng"`
+	TotalAreaSqm          float64          `json:"total_area_sqm"`
+	LandCategoryCounts    map[string]int32 `json:"land_category_counts"`
+	IdleFieldCount        int32            `json:"idle_field_count"`
+	DominantSoilLargeCode *string          `json:"dominant_soil_large_code,omitempty"`
+	CalculatedAt          int64            `json:"calculated_at"` // Unix timestamp
 }
 
 // clusterCacheRedisRepository はClusterCacheRepositoryのRedis実装
@@ -45,32 +55,105 @@ func NewClusterCacheRedisRepository(client *cache.Client, logger *slog.Logger) r
 	}
 }
 
-// buildCacheKey はキャッシュキーを構築する
-func buildCacheKey(resolution entity.Resolution) string {
-	return fmt.Sprintf("%s%s", clusterCacheKeyPrefix, resolution.String())
+// buildCacheKey は世代・検索セルごとのキャッシュキーを構築する
+// 世代をキーに含めるため、世代の切り替え後は古い世代のキャッシュが参照されずTTLで失効する
+func buildCacheKey(generation int64, resolution entity.Resolution, cell string) string {
+	return fmt.Sprintf("%s%d:%s:%s", clusterCacheKeyPrefix, generation, resolution.String(), cell)
 }
 
-// GetClusters はキャッシュから指定解像度のクラスター結果を取得する
+// buildFilteredCacheKey は絞り込み条件・検索セルごとの絞り込み集計のキャッシュキーを構築する
+func buildFilteredCacheKey(resolution entity.Resolution, filterHash, cell string) string {
+	return fmt.Sprintf("%s%s:%s:%s", filteredClusterCacheKeyPrefix, resolution.String(), filterHash, cell)
+}
+
+// GetClustersByCells はキャッシュから指定世代・解像度のクラスター結果を検索セルごとに取得する
 //
-// キャッシュは解像度ごとの全範囲データを保持する。
+// キャッシュは表示解像度より粗い検索セルごとに保持し、表示範囲を覆う検索セルのみをまとめて取得する。
 // バウンディングボックスによるフィルタリングはApplication層(UseCase)で行う。
-// この設計により、同一解像度のリクエストでキャッシュを共有でき、効率が向上する。
-func (r *clusterCacheRedisRepository) GetClusters(ctx context.Context, resolution entity.Resolution) ([]*entity.Cluster, error) {
-	key := buildCacheKey(resolution)
+// 不正なデータの検索セルはキャッシュミスとして扱う
+func (r *clusterCacheRedisRepository) GetClustersByCells(ctx context.Context, generation int64, resolution entity.Resolution, cells []string) (map[string][]*entity.Cluster, error) {
+	return r.getByCells(ctx, resolution, cells, func(cell string) string {
+		return buildCacheKey(generation, resolution, cell)
+	})
+}
+
+// SetClustersByCells は指定世代の検索セルごとのクラスター結果をキャッシュに保存する
+// クラスターがない検索セルも空の結果としてキャッシュし、DBへの問い合わせを繰り返さないようにする
+func (r *clusterCacheRedisRepository) SetClustersByCells(ctx context.Context, generation int64, resolution entity.Resolution, clustersByCell map[string][]*entity.Cluster) error {
+	return r.setByCells(ctx, clustersByCell, clusterCacheTTL, func(cell string) string {
+		return buildCacheKey(generation, resolution, cell)
+	})
+}
+
+// GetFilteredClustersByCells はキャッシュから絞り込み集計の結果を検索セルごとに取得する
+// 不正なデータの検索セルはキャッシュミスとして扱う
+func (r *clusterCacheRedisRepository) GetFilteredClustersByCells(ctx context.Context, resolution entity.Resolution, filterHash string, cells []string) (map[string][]*entity.Cluster, error) {
+	return r.getByCells(ctx, resolution, cells, func(cell string) string {
+		return buildFilteredCacheKey(resolution, filterHash, cell)
+	})
+}
+
+// SetFilteredClustersByCells は検索セルごとの絞り込み集計の結果をキャッシュに保存する
+// 該当する圃場がない検索セルも空の結果としてキャッシュする
+func (r *clusterCacheRedisRepository) SetFilteredClustersByCells(ctx context.Context, resolution entity.Resolution, filterHash string, clustersByCell map[string][]*entity.Cluster) error {
+	return r.setByCells(ctx, clustersByCell, filteredClusterCacheTTL, func(cell string) string {
+		return buildFilteredCacheKey(resolution, filterHash, cell)
+	})
+}
+
+// getByCells は検索セルごとのキャッシュをまとめて取得する
+// キャッシュにない検索セルと不正なデータの検索セルは結果に含めない
+func (r *clusterCacheRedisRepository) getByCells(ctx context.Context, resolution entity.Resolution, cells []string, keyOf func(cell string) string) (map[string][]*entity.Cluster, error) {
+	if len(cells) == 0 {
+		return map[string][]*entity.Cluster{}, nil
+	}
 
-	data, err := r.client.Get(ctx, key)
+	keys := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		keys = append(keys, keyOf(cell))
+	}
+	values, err := r.client.MGet(ctx, keys...)
 	if err != nil {
-		if err == redis.Nil {
-			// キャッシュミス
-			return nil, nil
-		}
 		return nil, fmt.Errorf("キャッシュからの取得に失敗しました: %w", err)
 	}
 
+	clustersByCell := make(map[string][]*entity.Cluster, len(cells))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		clusters, ok := decodeClusters(*value, resolution)
+		if !ok {
+			continue
+		}
+		clustersByCell[cells[i]] = clusters
+	}
+	return clustersByCell, nil
+}
+
+// setByCells は検索セルごとのクラスター結果をまとめてキャッシュに保存する
+func (r *clusterCacheRedisRepository) setByCells(ctx context.Context, clustersByCell map[string][]*entity.Cluster, ttl time.Duration, keyOf func(cell string) string) error {
+	values := make(map[string]string, len(clustersByCell))
+	for cell, clusters := range clustersByCell {
+		data, err := encodeClusters(clusters)
+		if err != nil {
+			return err
+		}
+		values[keyOf(cell)] = data
+	}
+
+	if err := r.client.SetMulti(ctx, values, ttl); err != nil {
+		return fmt.Errorf("キャッシュへの保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// decodeClusters はキャッシュデータをクラスター結果に変換する
+// 不正なデータの場合はokがfalseになる
+func decodeClusters(data string, resolution entity.Resolution) ([]*entity.Cluster, bool) {
 	var cacheItems []clusterCacheData
 	if err := json.Unmarshal([]byte(data), &cacheItems); err != nil {
-		// 不正なデータの場合はキャッシュミスとして扱う
-		return nil, nil
+		return nil, false
 	}
 
 	clusters := make([]*entity.Cluster, 0, len(cacheItems))
@@ -81,54 +164,48 @@ func (r *clusterCacheRedisRepository) GetClusters(ctx context.Context, resolutio
 			FieldCount:   item.FieldCount,
 			CenterLat:    item.CenterLat,
 			CenterLng:    item.CenterLng,
+			TotalAreaSqm: item.TotalAreaSqm,
+
+			LandCategoryCounts:    item.LandCategoryCounts,
+			IdleFieldCount:        item.IdleFieldCount,
+			DominantSoilLargeCode: item.DominantSoilLargeCode,
+
 			CalculatedAt: time.Unix(item.CalculatedAt, 0),
 		})
 	}
 
-	return clusters, nil
+	return clusters, true
 }
 
-// SetClusters はクラスター結果をキャッシュに保存する
-func (r *clusterCacheRedisRepository) SetClusters(ctx context.Context, resolution entity.Resolution, clusters []*entity.Cluster) error {
-	if len(clusters) == 0 {
-		return nil
-	}
-
+// encodeClusters はクラスター結果をキャッシュデータにシリアライズする
+func encodeClusters(clusters []*entity.Cluster) (string, error) {
 	cacheItems := make([]clusterCacheData, 0, len(clusters))
 	for _, cluster := range clusters {
 		cacheItems = append(cacheItems, clusterCacheData{
-			H3Index:      cluster.H3Index,
-			FieldCount:   cluster.FieldCount,
-			CenterLat:    cluster.CenterLat,
-			CenterLng:    cluster.CenterLng,
-			CalculatedAt: cluster.CalculatedAt.Unix(),
+			H3Index:               cluster.H3Index,
+			FieldCount:            cluster.FieldCount,
+			CenterLat:             cluster.CenterLat,
+			CenterLng:             cluster.CenterLng,
+			TotalAreaSqm:          cluster.TotalAreaSqm,
+			LandCategoryCounts:    cluster.LandCategoryCounts,
+			IdleFieldCount:        cluster.IdleFieldCount,
+			DominantSoilLargeCode: cluster.DominantSoilLargeCode,
+			CalculatedAt:          cluster.CalculatedAt.Unix(),
 		})
 	}
 
 	data, err := json.Marshal(cacheItems)
 	if err != nil {
-		return fmt.Errorf("キャッシュデータのシリアライズに失敗しました: %w", err)
-	}
-
-	key := buildCacheKey(resolution)
-	if err := r.client.Set(ctx, key, string(data), clusterCacheTTL); err != nil {
-		return fmt.Errorf("キャッシュへの保存に失敗しました: %w", err)
+		return "", fmt.Errorf("キャッシュデータのシリアライズに失敗しました: %w", err)
 	}
-
-	return nil
+	return string(data), nil
 }
 
-// DeleteClusters は全解像度のクラスター結果をキャッシュから削除する
+// DeleteClusters は全世代・全解像度のクラスター結果をキャッシュから削除する
+// 解像度は設定で変わるため、キープレフィックスに一致するキーを全て削除する
 func (r *clusterCacheRedisRepository) DeleteClusters(ctx context.Context) error {
-	var lastErr error
-	for _, resolution := range entity.AllResolutions {
-		key := buildCacheKey(resolution)
-		if err := r.client.Delete(ctx, key); err != nil {
-			r.logger.Warn("キャッシュの削除に失敗しました",
-				slog.String("key", key),
-				slog.String("error", err.Error()))
-			lastErr = err
-		}
+	if err := r.client.DeleteByPattern(ctx, clusterCacheKeyPrefix+"*"); err != nil {
+		return fmt.Errorf("キャッシュの削除に失敗しました: %w", err)
 	}
-	return lastErr
+	return nil
 }
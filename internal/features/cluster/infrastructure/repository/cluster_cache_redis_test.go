@@ -1,45 +1,55 @@
 package repository
 
 import (
+	"context"
+	"encoding/json"
+	"log/slog"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/infrastructure/cache"
+	"github.com/redis/go-redis/v9"
 )
 
-// TestBuildCacheKey はbuildCacheKeyが正しいキーを生成することをテストする
+// TestBuildCacheKey はbuildCacheKeyが世代・解像度と検索セルごとのキーを生成することをテストする
 func TestBuildCacheKey(t *testing.T) {
 	tests := []struct {
 		name       string
+		generation int64
 		resolution entity.Resolution
+		cell       string
 		wantKey    string
 	}{
 		{
 			name:       "res3のキー",
+			generation: 1,
 			resolution: entity.Res3,
-			wantKey:    "cluster:results:res3",
+			cell:       "8001fffffffffff",
+			wantKey:    "cluster:results:1:res3:8001fffffffffff",
 		},
 		{
-			name:       "res5のキー",
-			resolution: entity.Res5,
-			wantKey:    "cluster:results:res5",
-		},
-		{
-			name:       "res7のキー",
-			resolution: entity.Res7,
-			wantKey:    "cluster:results:res7",
+			name:       "res9のキー",
+			generation: 1,
+			resolution: entity.Res9,
+			cell:       "861f1a4a7ffffff",
+			wantKey:    "cluster:results:1:res9:861f1a4a7ffffff",
 		},
 		{
-			name:       "res9のキー",
+			name:       "別の世代のキー",
+			generation: 12,
 			resolution: entity.Res9,
-			wantKey:    "cluster:results:res9",
+			cell:       "861f1a4a7ffffff",
+			wantKey:    "cluster:results:12:res9:861f1a4a7ffffff",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := buildCacheKey(tt.resolution)
+			got := buildCacheKey(tt.generation, tt.resolution, tt.cell)
 			if got != tt.wantKey {
-				t.Errorf("buildCacheKey(%v) = %q, 期待値 %q", tt.resolution, got, tt.wantKey)
+				t.Errorf("buildCacheKey(%d, %v, %q) = %q, 期待値 %q", tt.generation, tt.resolution, tt.cell, got, tt.wantKey)
 			}
 		})
 	}
@@ -48,13 +58,84 @@ func TestBuildCacheKey(t *testing.T) {
 // TestBuildCacheKey_UnknownResolution は未知の解像度でもキーが生成されることをテストする
 func TestBuildCacheKey_UnknownResolution(t *testing.T) {
 	// 未知の解像度でもパニックせずにキーを生成することを確認
-	got := buildCacheKey(entity.Resolution(100))
-	expected := "cluster:results:unknown"
+	got := buildCacheKey(1, entity.Resolution(100), "861f1a4a7ffffff")
+	expected := "cluster:results:1:unknown:861f1a4a7ffffff"
 	if got != expected {
 		t.Errorf("buildCacheKey(100) = %q, 期待値 %q", got, expected)
 	}
 }
 
+// TestClusterCacheRedisRepository_ClustersByCells は検索セルごとに保存したクラスター結果を取得できることをテストする
+func TestClusterCacheRedisRepository_ClustersByCells(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis.Run()が失敗しました: %v", err)
+	}
+	defer mr.Close()
+	client := cache.NewClient(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	defer func() {
+		if err := client.Close(); err != nil {
+			t.Errorf("Close()でエラー発生 = %v", err)
+		}
+	}()
+	repo := NewClusterCacheRedisRepository(client, slog.Default())
+	ctx := context.Background()
+
+	calculatedAt := time.Unix(1700000000, 0)
+	clustersByCell := map[string][]*entity.Cluster{
+		"861f1a4a7ffffff": {
+			{H3Index: "891f1a4a003ffff", FieldCount: 3, CenterLat: 35.1, CenterLng: 139.1, CalculatedAt: calculatedAt},
+		},
+		"861f1a4afffffff": {},
+	}
+	if err := repo.SetClustersByCells(ctx, 1, entity.Res9, clustersByCell); err != nil {
+		t.Fatalf("SetClustersByCells()でエラー発生 = %v", err)
+	}
+	if err := mr.Set(buildCacheKey(1, entity.Res9, "861f1a4b7ffffff"), "invalid"); err != nil {
+		t.Fatalf("不正なデータの設定に失敗しました: %v", err)
+	}
+
+	got, err := repo.GetClustersByCells(ctx, 1, entity.Res9, []string{"861f1a4a7ffffff", "861f1a4afffffff", "861f1a4b7ffffff", "861f1a4bfffffff"})
+	if err != nil {
+		t.Fatalf("GetClustersByCells()でエラー発生 = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("キャッシュにある検索セル数 = %d, 期待値 2", len(got))
+	}
+	if clusters := got["861f1a4a7ffffff"]; len(clusters) != 1 || clusters[0].H3Index != "891f1a4a003ffff" || clusters[0].Resolution != entity.Res9 || !clusters[0].CalculatedAt.Equal(calculatedAt) {
+		t.Errorf("クラスター結果が期待値と異なります: %+v", clusters)
+	}
+	if clusters, ok := got["861f1a4afffffff"]; !ok || len(clusters) != 0 {
+		t.Errorf("クラスターがない検索セルは空の結果として取得できるべき: %v, %v", clusters, ok)
+	}
+
+	// 別の解像度のキャッシュは取得しない
+	got, err = repo.GetClustersByCells(ctx, 1, entity.Res7, []string{"861f1a4a7ffffff"})
+	if err != nil {
+		t.Fatalf("GetClustersByCells()でエラー発生 = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("別の解像度のキャッシュが取得されています: %v", got)
+	}
+
+	// 別の世代のキャッシュは取得しない
+	got, err = repo.GetClustersByCells(ctx, 2, entity.Res9, []string{"861f1a4a7ffffff"})
+	if err != nil {
+		t.Fatalf("GetClustersByCells()でエラー発生 = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("別の世代のキャッシュが取得されています: %v", got)
+	}
+
+	// 全解像度のキャッシュを削除できる
+	if err := repo.DeleteClusters(ctx); err != nil {
+		t.Fatalf("DeleteClusters()でエラー発生 = %v", err)
+	}
+	if keys := mr.Keys(); len(keys) != 0 {
+		t.Errorf("キャッシュが削除されていません: %v", keys)
+	}
+}
+
 // TestClusterCacheKeyPrefix はキープレフィックスが正しいことをテストする
 func TestClusterCacheKeyPrefix(t *testing.T) {
 	expectedPrefix := "cluster:results:"
@@ -127,3 +208,110 @@ func TestClusterCacheData_ZeroValues(t *testing.T) {
 		t.Errorf("CalculatedAt = %d, 期待値 0", data.CalculatedAt)
 	}
 }
+
+// TestClusterCacheData_Attributes は集計属性がJSONで往復できることをテストする
+func TestClusterCacheData_Attributes(t *testing.T) {
+	soil := "F"
+	data := []clusterCacheData{{
+		H3Index:               "871f1a4adffffff",
+		FieldCount:            3,
+		LandCategoryCounts:    map[string]int32{"100": 2, "200": 1},
+		IdleFieldCount:        1,
+		DominantSoilLargeCode: &soil,
+	}}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("json.Marshal でエラーが発生: %v", err)
+	}
+
+	var got []clusterCacheData
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal でエラーが発生: %v", err)
+	}
+
+	if got[0].LandCategoryCounts["100"] != 2 || got[0].LandCategoryCounts["200"] != 1 {
+		t.Errorf("LandCategoryCounts = %v, 期待値 map[100:2 200:1]", got[0].LandCategoryCounts)
+	}
+
+	if got[0].IdleFieldCount != 1 {
+		t.Errorf("IdleFieldCount = %d, 期待値 1", got[0].IdleFieldCount)
+	}
+
+	if got[0].DominantSoilLargeCode == nil || *got[0].DominantSoilLargeCode != "F" {
+		t.Errorf("DominantSoilLargeCode = %v, 期待値 F", got[0].DominantSoilLargeCode)
+	}
+}
+
+// TestBuildFilteredCacheKey は絞り込み集計のキャッシュキーが解像度・絞り込み条件・検索セルごとに分かれることをテストする
+func TestBuildFilteredCacheKey(t *testing.T) {
+	got := buildFilteredCacheKey(entity.Res7, "abc123", "841f1a5ffffffff")
+	expected := "cluster:filtered:res7:abc123:841f1a5ffffffff"
+	if got != expected {
+		t.Errorf("buildFilteredCacheKey(res7, abc123, 841f1a5ffffffff) = %q, 期待値 %q", got, expected)
+	}
+
+	if buildFilteredCacheKey(entity.Res9, "abc123", "841f1a5ffffffff") == got {
+		t.Error("解像度が異なるキーが一致しています")
+	}
+	if buildFilteredCacheKey(entity.Res7, "def456", "841f1a5ffffffff") == got {
+		t.Error("絞り込み条件が異なるキーが一致しています")
+	}
+}
+
+// TestClusterCacheRedisRepository_FilteredClustersByCells は絞り込み条件・検索セルごとに保存した集計結果を取得できることをテストする
+func TestClusterCacheRedisRepository_FilteredClustersByCells(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis.Run()が失敗しました: %v", err)
+	}
+	defer mr.Close()
+	client := cache.NewClient(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	defer func() {
+		if err := client.Close(); err != nil {
+			t.Errorf("Close()でエラー発生 = %v", err)
+		}
+	}()
+	repo := NewClusterCacheRedisRepository(client, slog.Default())
+	ctx := context.Background()
+
+	clustersByCell := map[string][]*entity.Cluster{
+		"841f1a5ffffffff": {
+			{H3Index: "871f1a4adffffff", FieldCount: 4, CenterLat: 35.5, CenterLng: 139.5},
+		},
+		"841f1a7ffffffff": {},
+	}
+	if err := repo.SetFilteredClustersByCells(ctx, entity.Res7, "abc123", clustersByCell); err != nil {
+		t.Fatalf("SetFilteredClustersByCells()でエラー発生 = %v", err)
+	}
+	if ttl := mr.TTL(buildFilteredCacheKey(entity.Res7, "abc123", "841f1a5ffffffff")); ttl != filteredClusterCacheTTL {
+		t.Errorf("TTL = %v, 期待値 %v", ttl, filteredClusterCacheTTL)
+	}
+
+	got, err := repo.GetFilteredClustersByCells(ctx, entity.Res7, "abc123", []string{"841f1a5ffffffff", "841f1a7ffffffff", "841f1a1ffffffff"})
+	if err != nil {
+		t.Fatalf("GetFilteredClustersByCells()でエラー発生 = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("キャッシュにある検索セル数 = %d, 期待値 2", len(got))
+	}
+	if clusters := got["841f1a5ffffffff"]; len(clusters) != 1 || clusters[0].H3Index != "871f1a4adffffff" || clusters[0].Resolution != entity.Res7 {
+		t.Errorf("集計結果が期待値と異なります: %+v", clusters)
+	}
+
+	// 別の絞り込み条件のキャッシュは取得しない
+	got, err = repo.GetFilteredClustersByCells(ctx, entity.Res7, "def456", []string{"841f1a5ffffffff"})
+	if err != nil {
+		t.Fatalf("GetFilteredClustersByCells()でエラー発生 = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("別の絞り込み条件のキャッシュが取得されています: %v", got)
+	}
+}
+
+// TestFilteredClusterCacheTTL は絞り込み集計のキャッシュTTLが全範囲のキャッシュより短いことをテストする
+func TestFilteredClusterCacheTTL(t *testing.T) {
+	if filteredClusterCacheTTL >= clusterCacheTTL {
+		t.Errorf("filteredClusterCacheTTL = %v, clusterCacheTTL(%v)より短くするべき", filteredClusterCacheTTL, clusterCacheTTL)
+	}
+}
@@ -3,232 +3,247 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
 	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/mktkhr/field-manager-api/internal/utils"
 )
 
+// ClusterJobPendingChannel は保留中のクラスタージョブが登録されたときに通知されるLISTEN/NOTIFYのチャネル名
+// 通知はcluster_jobsテーブルのトリガーが送る
+const ClusterJobPendingChannel = "cluster_jobs_pending"
+
 // clusterJobPostgresRepository はClusterJobRepositoryのPostgreSQL実装
 type clusterJobPostgresRepository struct {
 	pool    *pgxpool.Pool
 	queries *sqlc.Queries
+	logger  *slog.Logger
 }
 
 // NewClusterJobPostgresRepository はClusterJobRepositoryのPostgreSQL実装を作成する
-func NewClusterJobPostgresRepository(pool *pgxpool.Pool) repository.ClusterJobRepository {
+func NewClusterJobPostgresRepository(pool *pgxpool.Pool, logger *slog.Logger) repository.ClusterJobRepository {
 	return &clusterJobPostgresRepository{
 		pool:    pool,
 		queries: sqlc.New(pool),
+		logger:  logger,
 	}
 }
 
-// Create は新しいクラスタージョブを作成する(全範囲再計算)
-func (r *clusterJobPostgresRepository) Create(ctx context.Context, job *entity.ClusterJob) error {
-	_, err := r.queries.CreateClusterJob(ctx, &sqlc.CreateClusterJobParams{
-		ID:       job.ID,
-		Priority: job.Priority,
+// Enqueue はクラスタージョブをエンキューする
+// 保留中ジョブへの統合は1文のUPSERTで行うため、同時実行されたエンキュー同士でも統合先が一意に決まる
+func (r *clusterJobPostgresRepository) Enqueue(ctx context.Context, job *entity.ClusterJob, maxAffectedCells int32) (*entity.ClusterJob, bool, error) {
+	result, err := r.queries.EnqueueClusterJob(ctx, &sqlc.EnqueueClusterJobParams{
+		ID:               job.ID,
+		Priority:         job.Priority,
+		AffectedH3Cells:  job.AffectedH3Cells,
+		MaxAffectedCells: maxAffectedCells,
 	})
 	if err != nil {
-		return fmt.Errorf("クラスタージョブの作成に失敗しました: %w", err)
+		return nil, false, fmt.Errorf("クラスタージョブのエンキューに失敗しました: %w", err)
 	}
-	return nil
-}
 
-// CreateWithAffectedCells は影響セル情報付きでクラスタージョブを作成する
-func (r *clusterJobPostgresRepository) CreateWithAffectedCells(ctx context.Context, job *entity.ClusterJob) error {
-	_, err := r.queries.CreateClusterJobWithAffectedCells(ctx, &sqlc.CreateClusterJobWithAffectedCellsParams{
-		ID:              job.ID,
-		Priority:        job.Priority,
-		AffectedH3Cells: job.AffectedH3Cells,
-	})
-	if err != nil {
-		return fmt.Errorf("クラスタージョブの作成に失敗しました: %w", err)
-	}
-	return nil
+	return &entity.ClusterJob{
+		ID:              result.ID,
+		Status:          entity.JobStatus(result.Status),
+		Priority:        result.Priority,
+		AffectedH3Cells: result.AffectedH3Cells,
+		CreatedAt:       result.CreatedAt.Time,
+	}, result.Merged, nil
 }
 
 // FindByID はIDでクラスタージョブを取得する
 func (r *clusterJobPostgresRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.ClusterJob, error) {
 	result, err := r.queries.GetClusterJob(ctx, id)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
 		return nil, fmt.Errorf("クラスタージョブの取得に失敗しました: %w", err)
 	}
 
-	return convertGetClusterJobRowToEntity(result), nil
+	return convertToClusterJobEntity(result), nil
 }
 
-// FindPendingJobs は保留中のジョブを優先度順に取得する(排他ロック付き)
-func (r *clusterJobPostgresRepository) FindPendingJobs(ctx context.Context, limit int32) ([]*entity.ClusterJob, error) {
-	results, err := r.queries.GetPendingClusterJobs(ctx, limit)
+// Claim は最も優先度の高い保留中ジョブを処理中に更新し、指定ワーカーのリースとして取得する
+// 取得できるジョブがない場合はnilを返す
+func (r *clusterJobPostgresRepository) Claim(ctx context.Context, workerID string) (*entity.ClusterJob, error) {
+	result, err := r.queries.ClaimClusterJob(ctx, &workerID)
 	if err != nil {
-		return nil, fmt.Errorf("保留中ジョブの取得に失敗しました: %w", err)
-	}
-
-	jobs := make([]*entity.ClusterJob, 0, len(results))
-	for _, result := range results {
-		jobs = append(jobs, convertGetPendingClusterJobsRowToEntity(result))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("クラスタージョブの取得に失敗しました: %w", err)
 	}
-
-	return jobs, nil
+	return convertToClusterJobEntity(result), nil
 }
 
-// FindPendingJobsWithAffectedCells は影響セル情報付きで保留中のジョブを取得する
-func (r *clusterJobPostgresRepository) FindPendingJobsWithAffectedCells(ctx context.Context, limit int32) ([]*entity.ClusterJob, error) {
-	results, err := r.queries.GetPendingClusterJobsWithAffectedCells(ctx, limit)
+// Heartbeat は処理中ジョブのハートビートを更新してリースを延長する
+func (r *clusterJobPostgresRepository) Heartbeat(ctx context.Context, id uuid.UUID, workerID string) error {
+	rows, err := r.queries.HeartbeatClusterJob(ctx, &sqlc.HeartbeatClusterJobParams{
+		ID:       id,
+		WorkerID: &workerID,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("保留中ジョブの取得に失敗しました: %w", err)
+		return fmt.Errorf("ジョブのハートビート更新に失敗しました: %w", err)
 	}
-
-	jobs := make([]*entity.ClusterJob, 0, len(results))
-	for _, result := range results {
-		jobs = append(jobs, convertToClusterJobEntityWithAffectedCells(result))
-	}
-
-	return jobs, nil
-}
-
-// UpdateToProcessing はジョブを処理中に更新する
-func (r *clusterJobPostgresRepository) UpdateToProcessing(ctx context.Context, id uuid.UUID) error {
-	if err := r.queries.UpdateClusterJobToProcessing(ctx, id); err != nil {
-		return fmt.Errorf("ジョブの処理中への更新に失敗しました: %w", err)
+	if rows == 0 {
+		return entity.ErrJobLeaseLost
 	}
 	return nil
 }
 
-// UpdateToCompleted はジョブを完了に更新する
-func (r *clusterJobPostgresRepository) UpdateToCompleted(ctx context.Context, id uuid.UUID) error {
-	if err := r.queries.UpdateClusterJobToCompleted(ctx, id); err != nil {
+// UpdateToCompleted は指定ワーカーが処理中のジョブを完了に更新する
+func (r *clusterJobPostgresRepository) UpdateToCompleted(ctx context.Context, id uuid.UUID, workerID string) error {
+	rows, err := r.queries.UpdateClusterJobToCompleted(ctx, &sqlc.UpdateClusterJobToCompletedParams{
+		ID:       id,
+		WorkerID: &workerID,
+	})
+	if err != nil {
 		return fmt.Errorf("ジョブの完了への更新に失敗しました: %w", err)
 	}
-	return nil
-}
-
-// UpdateToFailed はジョブを失敗に更新する
-func (r *clusterJobPostgresRepository) UpdateToFailed(ctx context.Context, id uuid.UUID, errorMessage string) error {
-	if err := r.queries.UpdateClusterJobToFailed(ctx, &sqlc.UpdateClusterJobToFailedParams{
-		ID:           id,
-		ErrorMessage: &errorMessage,
-	}); err != nil {
-		return fmt.Errorf("ジョブの失敗への更新に失敗しました: %w", err)
+	if rows == 0 {
+		return entity.ErrJobLeaseLost
 	}
 	return nil
 }
 
-// HasPendingOrProcessingJob は保留中または処理中のジョブがあるか確認する
-func (r *clusterJobPostgresRepository) HasPendingOrProcessingJob(ctx context.Context) (bool, error) {
-	hasJob, err := r.queries.HasPendingOrProcessingJob(ctx)
+// UpdateToFailed は指定ワーカーが処理中のジョブを失敗に更新する
+func (r *clusterJobPostgresRepository) UpdateToFailed(ctx context.Context, id uuid.UUID, workerID string, errorMessage string) error {
+	rows, err := r.queries.UpdateClusterJobToFailed(ctx, &sqlc.UpdateClusterJobToFailedParams{
+		ErrorMessage: &errorMessage,
+		ID:           id,
+		WorkerID:     &workerID,
+	})
 	if err != nil {
-		return false, fmt.Errorf("ジョブ存在確認に失敗しました: %w", err)
-	}
-	return hasJob, nil
-}
-
-// DeleteOldCompletedJobs は古い完了済みジョブを削除する
-func (r *clusterJobPostgresRepository) DeleteOldCompletedJobs(ctx context.Context) error {
-	if err := r.queries.DeleteOldCompletedJobs(ctx); err != nil {
-		return fmt.Errorf("古い完了済みジョブの削除に失敗しました: %w", err)
+		return fmt.Errorf("ジョブの失敗への更新に失敗しました: %w", err)
 	}
-	return nil
-}
-
-// DeleteOldFailedJobs は古い失敗ジョブを削除する
-func (r *clusterJobPostgresRepository) DeleteOldFailedJobs(ctx context.Context) error {
-	if err := r.queries.DeleteOldFailedJobs(ctx); err != nil {
-		return fmt.Errorf("古い失敗ジョブの削除に失敗しました: %w", err)
+	if rows == 0 {
+		return entity.ErrJobLeaseLost
 	}
 	return nil
 }
 
-// convertToClusterJobEntity はSQLCの結果をエンティティに変換する
-func convertToClusterJobEntity(job *sqlc.ClusterJob) *entity.ClusterJob {
-	result := &entity.ClusterJob{
-		ID:        job.ID,
-		Status:    entity.JobStatus(job.Status),
-		Priority:  job.Priority,
-		CreatedAt: job.CreatedAt.Time,
+// RecoverExpiredJobs はハートビートが途絶えた処理中ジョブを回収する
+//
+// 期限切れジョブは行ロックを取ってから処理するため、遅れて戻ってきた元のワーカーの完了・失敗の更新は
+// 回収後にリースを失ったものとして扱われる。
+// 保留中ジョブは1件に限定されているため、既に保留中ジョブがある場合は保留中に戻さずそちらへ統合する。
+func (r *clusterJobPostgresRepository) RecoverExpiredJobs(ctx context.Context, leaseDuration time.Duration, maxAttempts int32, maxAffectedCells int32) (*repository.RecoveredJobs, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("トランザクション開始に失敗しました: %w", err)
 	}
-
-	if job.StartedAt.Valid {
-		t := job.StartedAt.Time
-		result.StartedAt = &t
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.Error("トランザクションのロールバックに失敗。データ不整合の可能性があります",
+				slog.String("error", err.Error()))
+		}
+	}()
+
+	queries := r.queries.WithTx(tx)
+	expired, err := queries.GetExpiredClusterJobs(ctx, utils.SafeIntToInt32(int(leaseDuration.Seconds())))
+	if err != nil {
+		return nil, fmt.Errorf("リース期限切れジョブの取得に失敗しました: %w", err)
 	}
 
-	if job.CompletedAt.Valid {
-		t := job.CompletedAt.Time
-		result.CompletedAt = &t
+	recovered := &repository.RecoveredJobs{}
+	for _, row := range expired {
+		job := convertToClusterJobEntity(row)
+
+		if !job.HasAttemptsLeft(maxAttempts) {
+			errorMessage := fmt.Sprintf("ワーカー(%s)のリースが期限切れになり、最大実行回数(%d)に達しました", job.WorkerID, maxAttempts)
+			if err := queries.UpdateExpiredClusterJobToFailed(ctx, &sqlc.UpdateExpiredClusterJobToFailedParams{
+				ID:           job.ID,
+				ErrorMessage: &errorMessage,
+			}); err != nil {
+				return nil, fmt.Errorf("リース期限切れジョブの失敗への更新に失敗しました (ID: %s): %w", job.ID, err)
+			}
+			recovered.Failed++
+			continue
+		}
+
+		pendingID, err := queries.MergeIntoPendingClusterJob(ctx, &sqlc.MergeIntoPendingClusterJobParams{
+			MaxAffectedCells: maxAffectedCells,
+			ID:               job.ID,
+		})
+		switch {
+		case err == nil:
+			errorMessage := fmt.Sprintf("ワーカー(%s)のリースが期限切れになったため、保留中のジョブ(%s)に統合しました", job.WorkerID, pendingID)
+			if err := queries.UpdateExpiredClusterJobToFailed(ctx, &sqlc.UpdateExpiredClusterJobToFailedParams{
+				ID:           job.ID,
+				ErrorMessage: &errorMessage,
+			}); err != nil {
+				return nil, fmt.Errorf("統合済みジョブの更新に失敗しました (ID: %s): %w", job.ID, err)
+			}
+		case errors.Is(err, pgx.ErrNoRows):
+			if err := queries.RequeueClusterJob(ctx, job.ID); err != nil {
+				return nil, fmt.Errorf("リース期限切れジョブの再エンキューに失敗しました (ID: %s): %w", job.ID, err)
+			}
+		default:
+			return nil, fmt.Errorf("保留中ジョブへの統合に失敗しました (ID: %s): %w", job.ID, err)
+		}
+		recovered.Requeued++
 	}
 
-	if job.ErrorMessage != nil {
-		result.ErrorMessage = *job.ErrorMessage
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("トランザクションコミットに失敗しました: %w", err)
 	}
-
-	return result
+	return recovered, nil
 }
 
-// convertGetClusterJobRowToEntity はGetClusterJobの結果をエンティティに変換する
-func convertGetClusterJobRowToEntity(job *sqlc.GetClusterJobRow) *entity.ClusterJob {
-	result := &entity.ClusterJob{
-		ID:        job.ID,
-		Status:    entity.JobStatus(job.Status),
-		Priority:  job.Priority,
-		CreatedAt: job.CreatedAt.Time,
-	}
-
-	if job.StartedAt.Valid {
-		t := job.StartedAt.Time
-		result.StartedAt = &t
-	}
-
-	if job.CompletedAt.Valid {
-		t := job.CompletedAt.Time
-		result.CompletedAt = &t
-	}
-
-	if job.ErrorMessage != nil {
-		result.ErrorMessage = *job.ErrorMessage
+// HasPendingOrProcessingJob は保留中または処理中のジョブがあるか確認する
+func (r *clusterJobPostgresRepository) HasPendingOrProcessingJob(ctx context.Context) (bool, error) {
+	hasJob, err := r.queries.HasPendingOrProcessingJob(ctx)
+	if err != nil {
+		return false, fmt.Errorf("ジョブ存在確認に失敗しました: %w", err)
 	}
-
-	return result
+	return hasJob, nil
 }
 
-// convertGetPendingClusterJobsRowToEntity はGetPendingClusterJobsの結果をエンティティに変換する
-func convertGetPendingClusterJobsRowToEntity(job *sqlc.GetPendingClusterJobsRow) *entity.ClusterJob {
-	result := &entity.ClusterJob{
-		ID:        job.ID,
-		Status:    entity.JobStatus(job.Status),
-		Priority:  job.Priority,
-		CreatedAt: job.CreatedAt.Time,
-	}
-
-	if job.StartedAt.Valid {
-		t := job.StartedAt.Time
-		result.StartedAt = &t
+// Cancel は保留中のジョブを取り消し済みに更新する
+func (r *clusterJobPostgresRepository) Cancel(ctx context.Context, id uuid.UUID) (bool, error) {
+	rows, err := r.queries.CancelClusterJob(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("ジョブの取り消しに失敗しました: %w", err)
 	}
+	return rows > 0, nil
+}
 
-	if job.CompletedAt.Valid {
-		t := job.CompletedAt.Time
-		result.CompletedAt = &t
+// DeleteOldCompletedJobs は完了からretentionを過ぎたジョブを削除する
+func (r *clusterJobPostgresRepository) DeleteOldCompletedJobs(ctx context.Context, retention time.Duration) (int64, error) {
+	rows, err := r.queries.DeleteOldCompletedJobs(ctx, utils.SafeIntToInt32(int(retention.Seconds())))
+	if err != nil {
+		return 0, fmt.Errorf("古い完了済みジョブの削除に失敗しました: %w", err)
 	}
+	return rows, nil
+}
 
-	if job.ErrorMessage != nil {
-		result.ErrorMessage = *job.ErrorMessage
+// DeleteOldFailedJobs は失敗・取り消しからretentionを過ぎたジョブを削除する
+func (r *clusterJobPostgresRepository) DeleteOldFailedJobs(ctx context.Context, retention time.Duration) (int64, error) {
+	rows, err := r.queries.DeleteOldFailedJobs(ctx, utils.SafeIntToInt32(int(retention.Seconds())))
+	if err != nil {
+		return 0, fmt.Errorf("古い失敗ジョブの削除に失敗しました: %w", err)
 	}
-
-	return result
+	return rows, nil
 }
 
-// convertToClusterJobEntityWithAffectedCells は影響セル情報付きでSQLCの結果をエンティティに変換する
-func convertToClusterJobEntityWithAffectedCells(job *sqlc.GetPendingClusterJobsWithAffectedCellsRow) *entity.ClusterJob {
+// convertToClusterJobEntity はSQLCの結果をエンティティに変換する
+func convertToClusterJobEntity(job *sqlc.ClusterJob) *entity.ClusterJob {
 	result := &entity.ClusterJob{
 		ID:              job.ID,
 		Status:          entity.JobStatus(job.Status),
 		Priority:        job.Priority,
 		AffectedH3Cells: job.AffectedH3Cells,
 		CreatedAt:       job.CreatedAt.Time,
+		Attempts:        job.Attempts,
 	}
 
 	if job.StartedAt.Valid {
@@ -245,5 +260,14 @@ func convertToClusterJobEntityWithAffectedCells(job *sqlc.GetPendingClusterJobsW
 		result.ErrorMessage = *job.ErrorMessage
 	}
 
+	if job.WorkerID != nil {
+		result.WorkerID = *job.WorkerID
+	}
+
+	if job.HeartbeatAt.Valid {
+		t := job.HeartbeatAt.Time
+		result.HeartbeatAt = &t
+	}
+
 	return result
 }
@@ -174,6 +174,31 @@ func TestConvertToClusterJobEntity_StartedAt(t *testing.T) {
 	}
 }
 
+// TestConvertToClusterJobEntity_Lease はリース情報が正しく変換されることをテストする
+func TestConvertToClusterJobEntity_Lease(t *testing.T) {
+	now := time.Now()
+	workerID := "worker-1"
+
+	job := &sqlc.ClusterJob{
+		ID:              uuid.New(),
+		Status:          "processing",
+		Priority:        1,
+		AffectedH3Cells: []string{"8a2f5a32d827fff"},
+		CreatedAt:       pgtype.Timestamptz{Time: now, Valid: true},
+		WorkerID:        &workerID,
+		HeartbeatAt:     pgtype.Timestamptz{Time: now, Valid: true},
+		Attempts:        2,
+	}
+
+	got := convertToClusterJobEntity(job)
+
+	require.Equal(t, "worker-1", got.WorkerID, "WorkerIDが一致しません")
+	require.NotNil(t, got.HeartbeatAt, "HeartbeatAtがnilです")
+	require.Equal(t, now.Unix(), got.HeartbeatAt.Unix(), "HeartbeatAtが一致しません")
+	require.Equal(t, int32(2), got.Attempts, "Attemptsが一致しません")
+	require.Equal(t, []string{"8a2f5a32d827fff"}, got.AffectedH3Cells, "AffectedH3Cellsが一致しません")
+}
+
 // TestConvertToClusterJobEntity_CompletedAt はCompletedAtが正しく変換されることをテストする
 func TestConvertToClusterJobEntity_CompletedAt(t *testing.T) {
 	now := time.Now()
@@ -3,13 +3,16 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/internal/h3util"
 	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
 	"github.com/mktkhr/field-manager-api/internal/utils"
 )
@@ -30,15 +33,93 @@ func NewClusterPostgresRepository(pool *pgxpool.Pool, logger *slog.Logger) repos
 	}
 }
 
-// GetClusters は指定解像度のクラスター結果を取得する
-func (r *clusterPostgresRepository) GetClusters(ctx context.Context, resolution entity.Resolution) ([]*entity.Cluster, error) {
-	results, err := r.queries.GetClusterResults(ctx, utils.SafeIntToInt32(int(resolution)))
+// GetActiveGeneration は有効な世代を取得する
+func (r *clusterPostgresRepository) GetActiveGeneration(ctx context.Context) (int64, error) {
+	generation, err := r.queries.GetActiveClusterGeneration(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("有効なクラスター世代の取得に失敗しました: %w", err)
+	}
+	return generation, nil
+}
+
+// ListLiveGenerations は有効な世代と、それより新しい計算中の世代を取得する
+func (r *clusterPostgresRepository) ListLiveGenerations(ctx context.Context) ([]int64, error) {
+	generations, err := r.queries.ListLiveClusterGenerations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("書き込み先のクラスター世代の取得に失敗しました: %w", err)
+	}
+	return generations, nil
+}
+
+// CreateGeneration は全範囲再計算の書き込み先となる世代を作成する
+func (r *clusterPostgresRepository) CreateGeneration(ctx context.Context) (int64, error) {
+	generation, err := r.queries.CreateClusterGeneration(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("クラスター世代の作成に失敗しました: %w", err)
+	}
+	return generation, nil
+}
+
+// ActivateGeneration は計算が完了した世代を有効化する
+// 有効な世代は有効化済みの世代のうち番号が最大のものとするため、1行の更新で切り替わる
+func (r *clusterPostgresRepository) ActivateGeneration(ctx context.Context, generation int64) (bool, error) {
+	rows, err := r.queries.ActivateClusterGeneration(ctx, generation)
+	if err != nil {
+		return false, fmt.Errorf("クラスター世代%dの有効化に失敗しました: %w", generation, err)
+	}
+	return rows > 0, nil
+}
+
+// DeleteGeneration は有効化していない世代をクラスター結果ごと削除する
+func (r *clusterPostgresRepository) DeleteGeneration(ctx context.Context, generation int64) error {
+	if err := r.queries.DeleteClusterGeneration(ctx, generation); err != nil {
+		return fmt.Errorf("クラスター世代%dの削除に失敗しました: %w", generation, err)
+	}
+	return nil
+}
+
+// DeleteUnusedGenerations は使用されなくなった世代をクラスター結果ごと削除する
+func (r *clusterPostgresRepository) DeleteUnusedGenerations(ctx context.Context, staleAfter time.Duration) (int64, error) {
+	deleted, err := r.queries.DeleteUnusedClusterGenerations(ctx, utils.SafeIntToInt32(int(staleAfter.Seconds())))
+	if err != nil {
+		return 0, fmt.Errorf("不要なクラスター世代の削除に失敗しました: %w", err)
+	}
+	return deleted, nil
+}
+
+// GetClustersInCells は指定世代・解像度のクラスター結果のうち、指定セルに含まれるものを取得する
+// 各セルの指定解像度の子孫セルの範囲でh3_indexを範囲検索する
+func (r *clusterPostgresRepository) GetClustersInCells(ctx context.Context, generation int64, resolution entity.Resolution, cells []string) ([]*entity.Cluster, error) {
+	lowerBounds := make([]string, 0, len(cells))
+	upperBounds := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		lower, upper, ok := h3util.DescendantRange(cell, resolution)
+		if !ok {
+			continue
+		}
+		lowerBounds = append(lowerBounds, lower)
+		upperBounds = append(upperBounds, upper)
+	}
+	if len(lowerBounds) == 0 {
+		return []*entity.Cluster{}, nil
+	}
+
+	results, err := r.queries.GetClusterResultsInRanges(ctx, &sqlc.GetClusterResultsInRangesParams{
+		LowerBounds: lowerBounds,
+		UpperBounds: upperBounds,
+		Generation:  generation,
+		Resolution:  utils.SafeIntToInt32(int(resolution)),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("クラスター結果の取得に失敗しました: %w", err)
 	}
 
 	clusters := make([]*entity.Cluster, 0, len(results))
 	for _, result := range results {
+		landCategoryCounts, err := decodeLandCategoryCounts(result.LandCategoryCounts)
+		if err != nil {
+			return nil, fmt.Errorf("土地種別ごとの圃場数の変換に失敗しました (H3Index: %s): %w", result.H3Index, err)
+		}
 		clusters = append(clusters, &entity.Cluster{
 			ID:           result.ID,
 			Resolution:   entity.Resolution(result.Resolution),
@@ -46,6 +127,12 @@ func (r *clusterPostgresRepository) GetClusters(ctx context.Context, resolution
 			FieldCount:   result.FieldCount,
 			CenterLat:    result.CenterLat,
 			CenterLng:    result.CenterLng,
+			TotalAreaSqm: result.TotalAreaSqm,
+
+			LandCategoryCounts:    landCategoryCounts,
+			IdleFieldCount:        result.IdleFieldCount,
+			DominantSoilLargeCode: result.DominantSoilLargeCode,
+
 			CalculatedAt: result.CalculatedAt.Time,
 		})
 	}
@@ -53,13 +140,13 @@ func (r *clusterPostgresRepository) GetClusters(ctx context.Context, resolution
 	return clusters, nil
 }
 
-// SaveClusters は複数のクラスター結果を保存する
+// SaveClusters は複数のクラスター結果を指定世代に保存する
 //
 // 1解像度分の全クラスター結果を1トランザクションで保存する。
 // 日本全国規模でもres3で数百件、res9でも数万件程度であり、
 // 現状の規模では1トランザクションで問題ない。
 // 将来的にデータ量が増加した場合はバッチ分割を検討すること。
-func (r *clusterPostgresRepository) SaveClusters(ctx context.Context, clusters []*entity.Cluster) error {
+func (r *clusterPostgresRepository) SaveClusters(ctx context.Context, generation int64, clusters []*entity.Cluster) error {
 	if len(clusters) == 0 {
 		return nil
 	}
@@ -77,13 +164,23 @@ func (r *clusterPostgresRepository) SaveClusters(ctx context.Context, clusters [
 
 	queries := r.queries.WithTx(tx)
 	for _, cluster := range clusters {
-		err := queries.UpsertClusterResult(ctx, &sqlc.UpsertClusterResultParams{
-			ID:         cluster.ID,
-			Resolution: utils.SafeIntToInt32(int(cluster.Resolution)),
-			H3Index:    cluster.H3Index,
-			FieldCount: cluster.FieldCount,
-			CenterLat:  cluster.CenterLat,
-			CenterLng:  cluster.CenterLng,
+		landCategoryCounts, err := encodeLandCategoryCounts(cluster.LandCategoryCounts)
+		if err != nil {
+			return fmt.Errorf("土地種別ごとの圃場数の変換に失敗しました (H3Index: %s): %w", cluster.H3Index, err)
+		}
+		err = queries.UpsertClusterResult(ctx, &sqlc.UpsertClusterResultParams{
+			ID:           cluster.ID,
+			Generation:   generation,
+			Resolution:   utils.SafeIntToInt32(int(cluster.Resolution)),
+			H3Index:      cluster.H3Index,
+			FieldCount:   cluster.FieldCount,
+			CenterLat:    cluster.CenterLat,
+			CenterLng:    cluster.CenterLng,
+			TotalAreaSqm: cluster.TotalAreaSqm,
+
+			LandCategoryCounts:    landCategoryCounts,
+			IdleFieldCount:        cluster.IdleFieldCount,
+			DominantSoilLargeCode: cluster.DominantSoilLargeCode,
 		})
 		if err != nil {
 			return fmt.Errorf("クラスター結果の保存に失敗しました (H3Index: %s): %w", cluster.H3Index, err)
@@ -96,216 +193,292 @@ func (r *clusterPostgresRepository) SaveClusters(ctx context.Context, clusters [
 	return nil
 }
 
-// DeleteClustersByResolution は指定解像度のクラスター結果を削除する
-func (r *clusterPostgresRepository) DeleteClustersByResolution(ctx context.Context, resolution entity.Resolution) error {
-	if err := r.queries.DeleteClusterResultsByResolution(ctx, utils.SafeIntToInt32(int(resolution))); err != nil {
-		return fmt.Errorf("解像度%dのクラスター結果の削除に失敗しました: %w", resolution, err)
-	}
-	return nil
-}
-
-// DeleteAllClusters は全てのクラスター結果を削除する
-func (r *clusterPostgresRepository) DeleteAllClusters(ctx context.Context) error {
-	if err := r.queries.DeleteAllClusterResults(ctx); err != nil {
-		return fmt.Errorf("クラスター結果の全削除に失敗しました: %w", err)
-	}
-	return nil
-}
-
 // AggregateByH3 は指定解像度でfieldsテーブルを集計する(全範囲)
 func (r *clusterPostgresRepository) AggregateByH3(ctx context.Context, resolution entity.Resolution) ([]*repository.AggregatedCluster, error) {
-	switch resolution {
-	case entity.Res3:
-		return r.aggregateRes3(ctx)
-	case entity.Res5:
-		return r.aggregateRes5(ctx)
-	case entity.Res7:
-		return r.aggregateRes7(ctx)
-	case entity.Res9:
-		return r.aggregateRes9(ctx)
-	default:
-		return nil, fmt.Errorf("未対応の解像度です: %d", resolution)
-	}
-}
-
-// AggregateByH3ForCells は指定H3セルのみfieldsテーブルを集計する(差分更新用)
-func (r *clusterPostgresRepository) AggregateByH3ForCells(ctx context.Context, resolution entity.Resolution, h3Cells []string) ([]*repository.AggregatedCluster, error) {
-	switch resolution {
-	case entity.Res3:
-		return r.aggregateRes3ForCells(ctx, h3Cells)
-	case entity.Res5:
-		return r.aggregateRes5ForCells(ctx, h3Cells)
-	case entity.Res7:
-		return r.aggregateRes7ForCells(ctx, h3Cells)
-	case entity.Res9:
-		return r.aggregateRes9ForCells(ctx, h3Cells)
-	default:
-		return nil, fmt.Errorf("未対応の解像度です: %d", resolution)
-	}
-}
-
-// DeleteClustersByH3Indexes は指定H3インデックスのクラスター結果を削除する
-func (r *clusterPostgresRepository) DeleteClustersByH3Indexes(ctx context.Context, resolution entity.Resolution, h3Indexes []string) error {
-	if len(h3Indexes) == 0 {
-		return nil
-	}
-	if err := r.queries.DeleteClusterResultsByH3Indexes(ctx, &sqlc.DeleteClusterResultsByH3IndexesParams{
-		Resolution: utils.SafeIntToInt32(int(resolution)),
-		H3Indexes:  h3Indexes,
-	}); err != nil {
-		return fmt.Errorf("H3インデックス指定でのクラスター結果の削除に失敗しました: %w", err)
-	}
-	return nil
-}
-
-func (r *clusterPostgresRepository) aggregateRes3(ctx context.Context) ([]*repository.AggregatedCluster, error) {
-	rows, err := r.queries.AggregateClustersByRes3(ctx)
+	rows, err := r.queries.AggregateClustersByH3(ctx, utils.SafeIntToInt32(int(resolution)))
 	if err != nil {
-		return nil, fmt.Errorf("解像度3での集計に失敗しました: %w", err)
+		return nil, fmt.Errorf("解像度%dでの集計に失敗しました: %w", resolution, err)
 	}
 
 	result := make([]*repository.AggregatedCluster, 0, len(rows))
 	for _, row := range rows {
-		if row.H3Index == nil {
-			continue
-		}
 		result = append(result, &repository.AggregatedCluster{
-			H3Index:    *row.H3Index,
-			FieldCount: row.FieldCount,
+			H3Index:      row.H3Index,
+			FieldCount:   row.FieldCount,
+			TotalAreaSqm: row.TotalAreaSqm,
 		})
 	}
+	if err := r.attachAttributes(ctx, resolution, entity.AggregationModeCentroid, nil, result); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
-func (r *clusterPostgresRepository) aggregateRes5(ctx context.Context) ([]*repository.AggregatedCluster, error) {
-	rows, err := r.queries.AggregateClustersByRes5(ctx)
+// AggregateByH3ForCells は指定H3セルのみfieldsテーブルを集計する(差分更新用)
+func (r *clusterPostgresRepository) AggregateByH3ForCells(ctx context.Context, resolution entity.Resolution, h3Cells []string) ([]*repository.AggregatedCluster, error) {
+	lowerBounds, upperBounds := descendantRanges(h3Cells)
+	rows, err := r.queries.AggregateClustersByH3ForCells(ctx, &sqlc.AggregateClustersByH3ForCellsParams{
+		Resolution:  utils.SafeIntToInt32(int(resolution)),
+		LowerBounds: lowerBounds,
+		UpperBounds: upperBounds,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("解像度5での集計に失敗しました: %w", err)
+		return nil, fmt.Errorf("解像度%dでの差分集計に失敗しました: %w", resolution, err)
 	}
 
 	result := make([]*repository.AggregatedCluster, 0, len(rows))
 	for _, row := range rows {
-		if row.H3Index == nil {
-			continue
-		}
 		result = append(result, &repository.AggregatedCluster{
-			H3Index:    *row.H3Index,
-			FieldCount: row.FieldCount,
+			H3Index:      row.H3Index,
+			FieldCount:   row.FieldCount,
+			TotalAreaSqm: row.TotalAreaSqm,
 		})
 	}
+	if err := r.attachAttributes(ctx, resolution, entity.AggregationModeCentroid, h3Cells, result); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
-func (r *clusterPostgresRepository) aggregateRes7(ctx context.Context) ([]*repository.AggregatedCluster, error) {
-	rows, err := r.queries.AggregateClustersByRes7(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("解像度7での集計に失敗しました: %w", err)
+// AggregateByCoverage は指定解像度でH3被覆を集計する(全範囲)
+func (r *clusterPostgresRepository) AggregateByCoverage(ctx context.Context, resolution entity.Resolution, mode entity.AggregationMode) ([]*repository.AggregatedCluster, error) {
+	res := utils.SafeIntToInt32(int(resolution))
+	var result []*repository.AggregatedCluster
+	switch mode {
+	case entity.AggregationModeCoverage:
+		rows, err := r.queries.AggregateClustersByCoverage(ctx, res)
+		if err != nil {
+			return nil, fmt.Errorf("解像度%dでの被覆集計に失敗しました: %w", resolution, err)
+		}
+		result = make([]*repository.AggregatedCluster, 0, len(rows))
+		for _, row := range rows {
+			result = append(result, &repository.AggregatedCluster{
+				H3Index:      row.H3Index,
+				FieldCount:   row.FieldCount,
+				TotalAreaSqm: row.TotalAreaSqm,
+			})
+		}
+	case entity.AggregationModeAreaShare:
+		rows, err := r.queries.AggregateClustersByAreaShare(ctx, res)
+		if err != nil {
+			return nil, fmt.Errorf("解像度%dでの面積按分集計に失敗しました: %w", resolution, err)
+		}
+		result = make([]*repository.AggregatedCluster, 0, len(rows))
+		for _, row := range rows {
+			result = append(result, &repository.AggregatedCluster{
+				H3Index:      row.H3Index,
+				FieldCount:   row.FieldCount,
+				TotalAreaSqm: row.TotalAreaSqm,
+			})
+		}
+	default:
+		return nil, fmt.Errorf("被覆を使用しない集計方法です: %s", mode)
 	}
+	if err := r.attachAttributes(ctx, resolution, mode, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
 
-	result := make([]*repository.AggregatedCluster, 0, len(rows))
-	for _, row := range rows {
-		if row.H3Index == nil {
-			continue
+// AggregateByCoverageForCells は指定H3セルのみH3被覆を集計する(差分更新用)
+func (r *clusterPostgresRepository) AggregateByCoverageForCells(ctx context.Context, resolution entity.Resolution, mode entity.AggregationMode, h3Cells []string) ([]*repository.AggregatedCluster, error) {
+	res := utils.SafeIntToInt32(int(resolution))
+	var result []*repository.AggregatedCluster
+	switch mode {
+	case entity.AggregationModeCoverage:
+		rows, err := r.queries.AggregateClustersByCoverageForCells(ctx, &sqlc.AggregateClustersByCoverageForCellsParams{
+			Resolution: res,
+			H3Cells:    h3Cells,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("解像度%dでの被覆差分集計に失敗しました: %w", resolution, err)
 		}
-		result = append(result, &repository.AggregatedCluster{
-			H3Index:    *row.H3Index,
-			FieldCount: row.FieldCount,
+		result = make([]*repository.AggregatedCluster, 0, len(rows))
+		for _, row := range rows {
+			result = append(result, &repository.AggregatedCluster{
+				H3Index:      row.H3Index,
+				FieldCount:   row.FieldCount,
+				TotalAreaSqm: row.TotalAreaSqm,
+			})
+		}
+	case entity.AggregationModeAreaShare:
+		rows, err := r.queries.AggregateClustersByAreaShareForCells(ctx, &sqlc.AggregateClustersByAreaShareForCellsParams{
+			Resolution: res,
+			H3Cells:    h3Cells,
 		})
+		if err != nil {
+			return nil, fmt.Errorf("解像度%dでの面積按分差分集計に失敗しました: %w", resolution, err)
+		}
+		result = make([]*repository.AggregatedCluster, 0, len(rows))
+		for _, row := range rows {
+			result = append(result, &repository.AggregatedCluster{
+				H3Index:      row.H3Index,
+				FieldCount:   row.FieldCount,
+				TotalAreaSqm: row.TotalAreaSqm,
+			})
+		}
+	default:
+		return nil, fmt.Errorf("被覆を使用しない集計方法です: %s", mode)
+	}
+	if err := r.attachAttributes(ctx, resolution, mode, h3Cells, result); err != nil {
+		return nil, err
 	}
 	return result, nil
 }
 
-func (r *clusterPostgresRepository) aggregateRes9(ctx context.Context) ([]*repository.AggregatedCluster, error) {
-	rows, err := r.queries.AggregateClustersByRes9(ctx)
+// AggregateFiltered は絞り込み条件に一致し、重心がboundsに含まれる圃場を指定解像度でその場で集計する
+func (r *clusterPostgresRepository) AggregateFiltered(ctx context.Context, resolution entity.Resolution, filter *entity.ClusterFilter, bounds repository.Bounds) ([]*repository.AggregatedCluster, error) {
+	params := &sqlc.AggregateFilteredClustersParams{
+		Resolution: utils.SafeIntToInt32(int(resolution)),
+		SwLng:      bounds.SWLng,
+		SwLat:      bounds.SWLat,
+		NeLng:      bounds.NELng,
+		NeLat:      bounds.NELat,
+	}
+	if filter != nil {
+		params.CityCode = filter.CityCode
+		params.SoilSmallCode = filter.SoilTypeSmallCode
+		params.LandCategoryCode = filter.LandCategoryCode
+		params.IdleLandStatusCode = filter.IdleLandStatusCode
+		params.MinAreaSqm = filter.MinAreaSqm
+		params.MaxAreaSqm = filter.MaxAreaSqm
+	}
+
+	rows, err := r.queries.AggregateFilteredClusters(ctx, params)
 	if err != nil {
-		return nil, fmt.Errorf("解像度9での集計に失敗しました: %w", err)
+		return nil, fmt.Errorf("解像度%dでの絞り込み集計に失敗しました: %w", resolution, err)
 	}
 
 	result := make([]*repository.AggregatedCluster, 0, len(rows))
 	for _, row := range rows {
-		if row.H3Index == nil {
-			continue
+		cluster, err := toAttributes(row.H3Index, row.LandCategoryCounts, row.IdleFieldCount, row.DominantSoilLargeCode)
+		if err != nil {
+			return nil, err
 		}
-		result = append(result, &repository.AggregatedCluster{
-			H3Index:    *row.H3Index,
-			FieldCount: row.FieldCount,
-		})
+		cluster.FieldCount = row.FieldCount
+		cluster.TotalAreaSqm = row.TotalAreaSqm
+		result = append(result, cluster)
 	}
 	return result, nil
 }
 
-func (r *clusterPostgresRepository) aggregateRes3ForCells(ctx context.Context, h3Cells []string) ([]*repository.AggregatedCluster, error) {
-	rows, err := r.queries.AggregateClustersByRes3ForCells(ctx, h3Cells)
-	if err != nil {
-		return nil, fmt.Errorf("解像度3での差分集計に失敗しました: %w", err)
+// DeleteClustersByH3Indexes は指定世代の指定H3インデックスのクラスター結果を削除する
+func (r *clusterPostgresRepository) DeleteClustersByH3Indexes(ctx context.Context, generation int64, resolution entity.Resolution, h3Indexes []string) error {
+	if len(h3Indexes) == 0 {
+		return nil
 	}
-
-	result := make([]*repository.AggregatedCluster, 0, len(rows))
-	for _, row := range rows {
-		if row.H3Index == nil {
-			continue
-		}
-		result = append(result, &repository.AggregatedCluster{
-			H3Index:    *row.H3Index,
-			FieldCount: row.FieldCount,
-		})
+	if err := r.queries.DeleteClusterResultsByH3Indexes(ctx, &sqlc.DeleteClusterResultsByH3IndexesParams{
+		Generation: generation,
+		Resolution: utils.SafeIntToInt32(int(resolution)),
+		H3Indexes:  h3Indexes,
+	}); err != nil {
+		return fmt.Errorf("H3インデックス指定でのクラスター結果の削除に失敗しました: %w", err)
 	}
-	return result, nil
+	return nil
 }
 
-func (r *clusterPostgresRepository) aggregateRes5ForCells(ctx context.Context, h3Cells []string) ([]*repository.AggregatedCluster, error) {
-	rows, err := r.queries.AggregateClustersByRes5ForCells(ctx, h3Cells)
-	if err != nil {
-		return nil, fmt.Errorf("解像度5での差分集計に失敗しました: %w", err)
+// attachAttributes は集計結果に土地種別ごとの圃場数・遊休農地の圃場数・主な土壌を設定する
+// h3CellsがnilでなければそのセルのみH3インデックスで属性を集計する
+func (r *clusterPostgresRepository) attachAttributes(ctx context.Context, resolution entity.Resolution, mode entity.AggregationMode, h3Cells []string, clusters []*repository.AggregatedCluster) error {
+	if len(clusters) == 0 {
+		return nil
 	}
 
-	result := make([]*repository.AggregatedCluster, 0, len(rows))
-	for _, row := range rows {
-		if row.H3Index == nil {
-			continue
-		}
-		result = append(result, &repository.AggregatedCluster{
-			H3Index:    *row.H3Index,
-			FieldCount: row.FieldCount,
+	res := utils.SafeIntToInt32(int(resolution))
+	attributes := make(map[string]*repository.AggregatedCluster)
+	if mode.UsesCoverage() {
+		rows, err := r.queries.AggregateClusterAttributesByCoverage(ctx, &sqlc.AggregateClusterAttributesByCoverageParams{
+			AreaShare:  mode == entity.AggregationModeAreaShare,
+			Resolution: res,
+			H3Cells:    h3Cells,
 		})
+		if err != nil {
+			return fmt.Errorf("解像度%dでの被覆の属性集計に失敗しました: %w", resolution, err)
+		}
+		for _, row := range rows {
+			attr, err := toAttributes(row.H3Index, row.LandCategoryCounts, row.IdleFieldCount, row.DominantSoilLargeCode)
+			if err != nil {
+				return err
+			}
+			attributes[row.H3Index] = attr
+		}
+	} else {
+		params := &sqlc.AggregateClusterAttributesParams{Resolution: res}
+		if h3Cells != nil {
+			params.LowerBounds, params.UpperBounds = descendantRanges(h3Cells)
+		}
+		rows, err := r.queries.AggregateClusterAttributes(ctx, params)
+		if err != nil {
+			return fmt.Errorf("解像度%dでの属性集計に失敗しました: %w", resolution, err)
+		}
+		for _, row := range rows {
+			attr, err := toAttributes(row.H3Index, row.LandCategoryCounts, row.IdleFieldCount, row.DominantSoilLargeCode)
+			if err != nil {
+				return err
+			}
+			attributes[row.H3Index] = attr
+		}
 	}
-	return result, nil
-}
 
-func (r *clusterPostgresRepository) aggregateRes7ForCells(ctx context.Context, h3Cells []string) ([]*repository.AggregatedCluster, error) {
-	rows, err := r.queries.AggregateClustersByRes7ForCells(ctx, h3Cells)
-	if err != nil {
-		return nil, fmt.Errorf("解像度7での差分集計に失敗しました: %w", err)
+	for _, cluster := range clusters {
+		attr, ok := attributes[cluster.H3Index]
+		if !ok {
+			cluster.LandCategoryCounts = map[string]int32{}
+			continue
+		}
+		cluster.LandCategoryCounts = attr.LandCategoryCounts
+		cluster.IdleFieldCount = attr.IdleFieldCount
+		cluster.DominantSoilLargeCode = attr.DominantSoilLargeCode
 	}
+	return nil
+}
 
-	result := make([]*repository.AggregatedCluster, 0, len(rows))
-	for _, row := range rows {
-		if row.H3Index == nil {
+// descendantRanges は各H3セルのres15の子孫セルの範囲(下限・上限)を返す
+// fieldsテーブルはres15のH3インデックスのみ保持するため、セルに含まれる圃場は範囲検索で求める
+func descendantRanges(h3Cells []string) (lowerBounds, upperBounds []string) {
+	lowerBounds = make([]string, 0, len(h3Cells))
+	upperBounds = make([]string, 0, len(h3Cells))
+	for _, h3Cell := range h3Cells {
+		lower, upper, ok := h3util.DescendantRange(h3Cell, entity.MaxResolution)
+		if !ok {
 			continue
 		}
-		result = append(result, &repository.AggregatedCluster{
-			H3Index:    *row.H3Index,
-			FieldCount: row.FieldCount,
-		})
+		lowerBounds = append(lowerBounds, lower)
+		upperBounds = append(upperBounds, upper)
 	}
-	return result, nil
+	return lowerBounds, upperBounds
+}
+
+// encodeLandCategoryCounts は土地種別ごとの圃場数をJSONBに変換する(nilは空のオブジェクト)
+func encodeLandCategoryCounts(counts map[string]int32) ([]byte, error) {
+	if counts == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(counts)
 }
 
-func (r *clusterPostgresRepository) aggregateRes9ForCells(ctx context.Context, h3Cells []string) ([]*repository.AggregatedCluster, error) {
-	rows, err := r.queries.AggregateClustersByRes9ForCells(ctx, h3Cells)
+// toAttributes は属性集計の結果を変換する
+func toAttributes(h3Index string, landCategoryCounts []byte, idleFieldCount int32, dominantSoilLargeCode *string) (*repository.AggregatedCluster, error) {
+	counts, err := decodeLandCategoryCounts(landCategoryCounts)
 	if err != nil {
-		return nil, fmt.Errorf("解像度9での差分集計に失敗しました: %w", err)
+		return nil, fmt.Errorf("土地種別ごとの圃場数の変換に失敗しました (H3Index: %s): %w", h3Index, err)
 	}
+	return &repository.AggregatedCluster{
+		H3Index:               h3Index,
+		LandCategoryCounts:    counts,
+		IdleFieldCount:        idleFieldCount,
+		DominantSoilLargeCode: dominantSoilLargeCode,
+	}, nil
+}
 
-	result := make([]*repository.AggregatedCluster, 0, len(rows))
-	for _, row := range rows {
-		if row.H3Index == nil {
-			continue
-		}
-		result = append(result, &repository.AggregatedCluster{
-			H3Index:    *row.H3Index,
-			FieldCount: row.FieldCount,
-		})
+// decodeLandCategoryCounts はJSONBの土地種別ごとの圃場数を変換する
+func decodeLandCategoryCounts(data []byte) (map[string]int32, error) {
+	counts := make(map[string]int32)
+	if len(data) == 0 {
+		return counts, nil
 	}
-	return result, nil
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
 }
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/mktkhr/field-manager-api/internal/utils"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/wkb"
+)
+
+// areaShareTolerance は面積比率が変わったとみなさない差の上限
+const areaShareTolerance = 1e-9
+
+// h3CoveragePostgresRepository はH3CoverageRepositoryのPostgreSQL実装
+type h3CoveragePostgresRepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+	logger  *slog.Logger
+}
+
+// NewH3CoveragePostgresRepository はH3CoverageRepositoryのPostgreSQL実装を作成する
+func NewH3CoveragePostgresRepository(pool *pgxpool.Pool, logger *slog.Logger) repository.H3CoverageRepository {
+	return &h3CoveragePostgresRepository{
+		pool:    pool,
+		queries: sqlc.New(pool),
+		logger:  logger,
+	}
+}
+
+// ListStaleSources は被覆が未計算、または計算後に更新された有効な圃場をafterIDより後からID順にlimit件取得する
+// ジオメトリをデコードできない圃場はGeometryをnilにして返す
+func (r *h3CoveragePostgresRepository) ListStaleSources(ctx context.Context, afterID *uuid.UUID, limit int32) ([]*repository.FieldCoverageSource, error) {
+	params := &sqlc.ListFieldsForH3CoverageRefreshParams{RowLimit: limit}
+	if afterID != nil {
+		params.AfterID = uuid.NullUUID{UUID: *afterID, Valid: true}
+	}
+	rows, err := r.queries.ListFieldsForH3CoverageRefresh(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("H3被覆の計算対象の圃場取得に失敗しました: %w", err)
+	}
+
+	sources := make([]*repository.FieldCoverageSource, 0, len(rows))
+	for _, row := range rows {
+		multiPolygon, err := decodeMultiPolygonWKB(row.GeometryWkb)
+		if err != nil {
+			r.logger.Warn("圃場ジオメトリのデコードに失敗しました",
+				slog.String("field_id", row.ID.String()),
+				slog.String("error", err.Error()))
+		}
+		sources = append(sources, &repository.FieldCoverageSource{
+			FieldID:   row.ID,
+			Geometry:  multiPolygon,
+			UpdatedAt: row.UpdatedAt.Time,
+		})
+	}
+	return sources, nil
+}
+
+// ReplaceCoverages は圃場の被覆を1トランザクションで置き換え、被覆または面積比率が変わったセルを返す
+func (r *h3CoveragePostgresRepository) ReplaceCoverages(ctx context.Context, fieldID uuid.UUID, sourceUpdatedAt time.Time, coverages []*entity.H3Coverage) ([]string, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("トランザクション開始に失敗しました: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.Error("トランザクションのロールバックに失敗。データ不整合の可能性があります",
+				slog.String("error", err.Error()))
+		}
+	}()
+
+	queries := r.queries.WithTx(tx)
+	deleted, err := queries.DeleteFieldH3Coverages(ctx, fieldID)
+	if err != nil {
+		return nil, fmt.Errorf("圃場%sのH3被覆の削除に失敗しました: %w", fieldID, err)
+	}
+
+	params := &sqlc.InsertFieldH3CoveragesParams{
+		FieldID:         fieldID,
+		Resolutions:     make([]int32, 0, len(coverages)),
+		H3Indexes:       make([]string, 0, len(coverages)),
+		AreaShares:      make([]float64, 0, len(coverages)),
+		SourceUpdatedAt: pgtype.Timestamptz{Time: sourceUpdatedAt, Valid: true},
+	}
+	for _, coverage := range coverages {
+		params.Resolutions = append(params.Resolutions, utils.SafeIntToInt32(int(coverage.Resolution)))
+		params.H3Indexes = append(params.H3Indexes, coverage.H3Index)
+		params.AreaShares = append(params.AreaShares, coverage.AreaShare)
+	}
+	if err := queries.InsertFieldH3Coverages(ctx, params); err != nil {
+		return nil, fmt.Errorf("圃場%sのH3被覆の登録に失敗しました: %w", fieldID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("トランザクションコミットに失敗しました: %w", err)
+	}
+
+	before := make(map[string]float64, len(deleted))
+	for _, row := range deleted {
+		before[row.H3Index] = row.AreaShare
+	}
+	after := make(map[string]float64, len(coverages))
+	for _, coverage := range coverages {
+		after[coverage.H3Index] = coverage.AreaShare
+	}
+	return changedCells(before, after), nil
+}
+
+// DeleteOrphanCoverages は削除済み・廃止済みの圃場の被覆を削除し、削除した被覆のセルを返す
+func (r *h3CoveragePostgresRepository) DeleteOrphanCoverages(ctx context.Context) ([]string, error) {
+	rows, err := r.queries.DeleteOrphanFieldH3Coverages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("不要なH3被覆の削除に失敗しました: %w", err)
+	}
+
+	seen := make(map[string]struct{}, len(rows))
+	cells := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if _, ok := seen[row.H3Index]; ok {
+			continue
+		}
+		seen[row.H3Index] = struct{}{}
+		cells = append(cells, row.H3Index)
+	}
+	sort.Strings(cells)
+	return cells, nil
+}
+
+// changedCells は置き換え前後の被覆を比較し、追加・削除されたセルと面積比率が変わったセルを返す
+func changedCells(before, after map[string]float64) []string {
+	cells := make([]string, 0)
+	for cell, share := range before {
+		if afterShare, ok := after[cell]; !ok || math.Abs(afterShare-share) > areaShareTolerance {
+			cells = append(cells, cell)
+		}
+	}
+	for cell := range after {
+		if _, ok := before[cell]; !ok {
+			cells = append(cells, cell)
+		}
+	}
+	sort.Strings(cells)
+	return cells
+}
+
+// decodeMultiPolygonWKB はWKBをMultiPolygonに変換する
+// Polygonの場合は1区画のMultiPolygonとして返す
+func decodeMultiPolygonWKB(b []byte) (*geom.MultiPolygon, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	g, err := wkb.Unmarshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("WKBのデコードに失敗しました: %w", err)
+	}
+	switch t := g.(type) {
+	case *geom.MultiPolygon:
+		return t, nil
+	case *geom.Polygon:
+		multiPolygon := geom.NewMultiPolygon(t.Layout())
+		if err := multiPolygon.Push(t); err != nil {
+			return nil, fmt.Errorf("マルチポリゴンへの変換に失敗しました: %w", err)
+		}
+		return multiPolygon, nil
+	default:
+		return nil, fmt.Errorf("ジオメトリがPolygonまたはMultiPolygonではありません: %T", g)
+	}
+}
@@ -0,0 +1,127 @@
+package h3util
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/uber/h3-go/v4"
+)
+
+// minRingArea は分割したポリゴンとして扱う最小の面積(平方度)
+const minRingArea = 1e-12
+
+// CellBoundary はH3セルの境界をGeoJSONのポリゴンの座標配列([経度, 緯度])で返す
+//
+// 戻り値はポリゴンごとの外周リング(始点と終点が同じ閉じたリング)の配列。
+// 日付変更線をまたぐセルは日付変更線で分割し、経度が-180から180の範囲に収まる複数のポリゴンにする(RFC 7946 3.1.9)。
+// 極を含むセルは極の緯度まで広げたポリゴンにする
+func CellBoundary(h3Index string) ([][][]float64, error) {
+	cell := h3.CellFromString(h3Index)
+	if !cell.IsValid() {
+		return nil, fmt.Errorf("無効なH3インデックス: %s", h3Index)
+	}
+	boundary, err := cell.Boundary()
+	if err != nil {
+		return nil, fmt.Errorf("H3セルの境界の取得に失敗しました: %w", err)
+	}
+	if len(boundary) == 0 {
+		return nil, fmt.Errorf("H3セルの境界が空です: %s", h3Index)
+	}
+
+	// 隣り合う頂点の経度の差が180度を超えないよう経度を連続させる
+	ring := make([][2]float64, 0, len(boundary)+3)
+	ring = append(ring, [2]float64{boundary[0].Lng, boundary[0].Lat})
+	for _, vertex := range boundary[1:] {
+		prev := ring[len(ring)-1][0]
+		ring = append(ring, [2]float64{prev + lngDelta(prev, vertex.Lng), vertex.Lat})
+	}
+
+	// 1周して経度が360度ずれる場合は極を含むセルのため、極を経由してリングを閉じる
+	last := ring[len(ring)-1]
+	closingLng := last[0] + lngDelta(last[0], boundary[0].Lng)
+	if math.Abs(closingLng-ring[0][0]) > 180 {
+		poleLat := 90.0
+		if boundary[0].Lat < 0 {
+			poleLat = -90
+		}
+		ring = append(ring,
+			[2]float64{closingLng, boundary[0].Lat},
+			[2]float64{closingLng, poleLat},
+			[2]float64{ring[0][0], poleLat},
+		)
+	}
+
+	// 連続させた経度が含まれる360度ごとの範囲で切り出し、-180から180の範囲に戻す
+	minLng, maxLng := ring[0][0], ring[0][0]
+	for _, point := range ring {
+		minLng = math.Min(minLng, point[0])
+		maxLng = math.Max(maxLng, point[0])
+	}
+	polygons := make([][][]float64, 0, 1)
+	for offset := math.Floor((minLng+180)/360) * 360; offset-180 < maxLng; offset += 360 {
+		clipped := clipLng(ring, offset-180, offset+180)
+		// 範囲の境界に頂点が接するだけの場合は面積のないリングになるため除く
+		if len(clipped) < 3 || math.Abs(ringArea(clipped)) < minRingArea {
+			continue
+		}
+		coords := make([][]float64, 0, len(clipped)+1)
+		for _, point := range clipped {
+			coords = append(coords, []float64{point[0] - offset, point[1]})
+		}
+		coords = append(coords, []float64{clipped[0][0] - offset, clipped[0][1]})
+		polygons = append(polygons, coords)
+	}
+	return polygons, nil
+}
+
+// ringArea はリングの符号付き面積(経度・緯度の平面上)を返す
+func ringArea(ring [][2]float64) float64 {
+	area := 0.0
+	prev := ring[len(ring)-1]
+	for _, current := range ring {
+		area += prev[0]*current[1] - current[0]*prev[1]
+		prev = current
+	}
+	return area / 2
+}
+
+// lngDelta は経度fromからtoへの差を-180から180の範囲で返す
+func lngDelta(from, to float64) float64 {
+	delta := math.Mod(to-from, 360)
+	if delta > 180 {
+		delta -= 360
+	} else if delta < -180 {
+		delta += 360
+	}
+	return delta
+}
+
+// clipLng はリングを経度west以上east以下の範囲で切り取る(Sutherland-Hodgman法)
+func clipLng(ring [][2]float64, west, east float64) [][2]float64 {
+	clipped := clipHalfPlane(ring, func(p [2]float64) float64 { return p[0] - west })
+	return clipHalfPlane(clipped, func(p [2]float64) float64 { return east - p[0] })
+}
+
+// clipHalfPlane はリングをinside(p) >= 0となる半平面で切り取る
+func clipHalfPlane(ring [][2]float64, inside func(p [2]float64) float64) [][2]float64 {
+	if len(ring) == 0 {
+		return nil
+	}
+	clipped := make([][2]float64, 0, len(ring)+2)
+	prev := ring[len(ring)-1]
+	for _, current := range ring {
+		prevIn, currentIn := inside(prev), inside(current)
+		if (prevIn >= 0) != (currentIn >= 0) {
+			t := prevIn / (prevIn - currentIn)
+			clipped = append(clipped, [2]float64{
+				prev[0] + (current[0]-prev[0])*t,
+				prev[1] + (current[1]-prev[1])*t,
+			})
+		}
+		if currentIn >= 0 {
+			clipped = append(clipped, current)
+		}
+		prev = current
+	}
+	return clipped
+}
@@ -0,0 +1,92 @@
+package h3util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber/h3-go/v4"
+)
+
+// requireValidRings はポリゴンが閉じたリングで、座標が経度・緯度の範囲内にあることを確認する
+func requireValidRings(t *testing.T, polygons [][][]float64) {
+	t.Helper()
+	for _, ring := range polygons {
+		require.GreaterOrEqual(t, len(ring), 4, "リングは3頂点以上の閉じたリングであるべき")
+		require.Equal(t, ring[0], ring[len(ring)-1], "リングの始点と終点が一致しない")
+		for _, coord := range ring {
+			require.Len(t, coord, 2, "座標は[経度, 緯度]であるべき")
+			require.GreaterOrEqual(t, coord[0], -180.0, "経度が範囲外")
+			require.LessOrEqual(t, coord[0], 180.0, "経度が範囲外")
+			require.GreaterOrEqual(t, coord[1], -90.0, "緯度が範囲外")
+			require.LessOrEqual(t, coord[1], 90.0, "緯度が範囲外")
+		}
+	}
+}
+
+// TestCellBoundary は日付変更線をまたがないセルの境界がそのまま1つのポリゴンになることをテストする
+func TestCellBoundary(t *testing.T) {
+	cell, err := h3.LatLngToCell(h3.NewLatLng(35.681236, 139.767125), 7)
+	require.NoError(t, err, "LatLngToCellでエラーが発生")
+	boundary, err := cell.Boundary()
+	require.NoError(t, err, "Boundaryでエラーが発生")
+
+	polygons, err := CellBoundary(cell.String())
+
+	require.NoError(t, err, "CellBoundaryでエラーが発生")
+	require.Len(t, polygons, 1, "ポリゴンは1つであるべき")
+	requireValidRings(t, polygons)
+	require.Len(t, polygons[0], len(boundary)+1, "頂点数が境界と一致しない")
+	for i, vertex := range boundary {
+		require.InDelta(t, vertex.Lng, polygons[0][i][0], 1e-9, "経度が境界と一致しない")
+		require.InDelta(t, vertex.Lat, polygons[0][i][1], 1e-9, "緯度が境界と一致しない")
+	}
+}
+
+// TestCellBoundary_Antimeridian は日付変更線をまたぐセルが日付変更線で2つのポリゴンに分割されることをテストする
+func TestCellBoundary_Antimeridian(t *testing.T) {
+	cell, err := h3.LatLngToCell(h3.NewLatLng(-16.5, 180), 3)
+	require.NoError(t, err, "LatLngToCellでエラーが発生")
+
+	polygons, err := CellBoundary(cell.String())
+
+	require.NoError(t, err, "CellBoundaryでエラーが発生")
+	require.Len(t, polygons, 2, "日付変更線で2つに分割されるべき")
+	requireValidRings(t, polygons)
+
+	hasEast, hasWest := false, false
+	for _, ring := range polygons {
+		for _, coord := range ring {
+			hasEast = hasEast || coord[0] == 180
+			hasWest = hasWest || coord[0] == -180
+		}
+	}
+	require.True(t, hasEast, "東側のポリゴンは経度180で切れるべき")
+	require.True(t, hasWest, "西側のポリゴンは経度-180で切れるべき")
+}
+
+// TestCellBoundary_Pole は極を含むセルが極の緯度まで広がったポリゴンになることをテストする
+func TestCellBoundary_Pole(t *testing.T) {
+	for _, lat := range []float64{90, -90} {
+		cell, err := h3.LatLngToCell(h3.NewLatLng(lat, 0), 2)
+		require.NoError(t, err, "LatLngToCellでエラーが発生")
+
+		polygons, err := CellBoundary(cell.String())
+
+		require.NoError(t, err, "CellBoundaryでエラーが発生")
+		require.NotEmpty(t, polygons, "ポリゴンが返されるべき")
+		requireValidRings(t, polygons)
+		hasPole := false
+		for _, ring := range polygons {
+			for _, coord := range ring {
+				hasPole = hasPole || coord[1] == lat
+			}
+		}
+		require.True(t, hasPole, "極の緯度を含むべき")
+	}
+}
+
+// TestCellBoundary_Invalid は無効なH3インデックスでエラーになることをテストする
+func TestCellBoundary_Invalid(t *testing.T) {
+	_, err := CellBoundary("invalid")
+	require.Error(t, err, "無効なH3インデックスはエラーになるべき")
+}
@@ -105,6 +105,28 @@ func TestConvertAggregatedToClusters_FieldCount(t *testing.T) {
 	}
 }
 
+// TestConvertAggregatedToClusters_Attributes は変換時に集計属性が引き継がれることをテストする
+func TestConvertAggregatedToClusters_Attributes(t *testing.T) {
+	soil := "F3"
+	aggregated := []*repository.AggregatedCluster{
+		{
+			H3Index:               "871f1a4adffffff",
+			FieldCount:            3,
+			LandCategoryCounts:    map[string]int32{"100": 2, "200": 1},
+			IdleFieldCount:        1,
+			DominantSoilLargeCode: &soil,
+		},
+	}
+
+	result, err := ConvertAggregatedToClusters(entity.Res7, aggregated)
+
+	require.NoError(t, err, "ConvertAggregatedToClustersでエラーが発生")
+	require.Len(t, result, 1, "結果の長さが1ではありません")
+	require.Equal(t, map[string]int32{"100": 2, "200": 1}, result[0].LandCategoryCounts, "LandCategoryCountsが期待値と異なります")
+	require.Equal(t, int32(1), result[0].IdleFieldCount, "IdleFieldCountが期待値と異なります")
+	require.Equal(t, &soil, result[0].DominantSoilLargeCode, "DominantSoilLargeCodeが期待値と異なります")
+}
+
 // TestConvertAggregatedToClusters_CenterCoordinates は変換時に中心座標が正しく計算されることをテストする
 func TestConvertAggregatedToClusters_CenterCoordinates(t *testing.T) {
 	aggregated := []*repository.AggregatedCluster{
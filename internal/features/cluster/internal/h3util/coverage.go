@@ -0,0 +1,196 @@
+package h3util
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/twpayne/go-geom"
+	"github.com/uber/h3-go/v4"
+)
+
+// CalculateCoverage はマルチポリゴンが覆うH3セルと、セルごとの面積比率を解像度ごとに計算する
+//
+// 各区画をPolygonToCells(ContainmentOverlapping)で充填し、区画とセル境界の重なり面積から比率を求める。
+// セル境界に接するだけで重なりのないセルは除外し、解像度ごとの比率の合計は1になる。
+// 面積を持たない退化したポリゴンは覆うセルに均等に按分し、セルが得られない場合は最初の頂点のセルに計上する。
+// 圃場規模では経度・緯度をそのまま平面座標とみなしても比率への影響は無視できる
+func CalculateCoverage(multiPolygon *geom.MultiPolygon, resolutions []entity.Resolution) ([]*entity.H3Coverage, error) {
+	if multiPolygon == nil || multiPolygon.NumCoords() == 0 {
+		return nil, nil
+	}
+
+	coverages := make([]*entity.H3Coverage, 0)
+	for _, resolution := range resolutions {
+		byCell, err := coverageAreas(multiPolygon, int(resolution))
+		if err != nil {
+			return nil, fmt.Errorf("解像度%sの被覆計算に失敗しました: %w", resolution.String(), err)
+		}
+		coverages = append(coverages, normalizeShares(resolution, byCell)...)
+	}
+	return coverages, nil
+}
+
+// coverageAreas は各区画を充填したセルごとに区画との重なり面積を合計する
+func coverageAreas(multiPolygon *geom.MultiPolygon, resolution int) (map[h3.Cell]float64, error) {
+	byCell := make(map[h3.Cell]float64)
+	for p := 0; p < multiPolygon.NumPolygons(); p++ {
+		polygon := multiPolygon.Polygon(p)
+		if polygon.NumLinearRings() == 0 {
+			continue
+		}
+
+		cells, err := h3.PolygonToCellsExperimental(toGeoPolygon(polygon), resolution, h3.ContainmentOverlapping)
+		if err != nil {
+			return nil, err
+		}
+		for _, cell := range cells {
+			boundary, err := cell.Boundary()
+			if err != nil {
+				return nil, err
+			}
+			byCell[cell] += intersectionArea(polygon, boundary)
+		}
+	}
+
+	if len(byCell) == 0 {
+		first := multiPolygon.Coords()[0][0][0]
+		cell, err := h3.LatLngToCell(h3.NewLatLng(first.Y(), first.X()), resolution)
+		if err != nil {
+			return nil, err
+		}
+		byCell[cell] = 0
+	}
+	return byCell, nil
+}
+
+// normalizeShares はセルごとの重なり面積を合計1の比率に変換し、H3インデックス順に返す
+func normalizeShares(resolution entity.Resolution, byCell map[h3.Cell]float64) []*entity.H3Coverage {
+	var total float64
+	for _, area := range byCell {
+		total += area
+	}
+
+	coverages := make([]*entity.H3Coverage, 0, len(byCell))
+	for cell, area := range byCell {
+		share := 1 / float64(len(byCell))
+		if total > 0 {
+			if area <= 0 {
+				continue
+			}
+			share = math.Min(area/total, 1)
+		}
+		coverages = append(coverages, &entity.H3Coverage{
+			Resolution: resolution,
+			H3Index:    cell.String(),
+			AreaShare:  share,
+		})
+	}
+	sort.Slice(coverages, func(i, j int) bool { return coverages[i].H3Index < coverages[j].H3Index })
+	return coverages
+}
+
+// toGeoPolygon はポリゴンをh3のGeoPolygonに変換する
+func toGeoPolygon(polygon *geom.Polygon) h3.GeoPolygon {
+	geoPolygon := h3.GeoPolygon{GeoLoop: toGeoLoop(polygon.LinearRing(0).Coords())}
+	for i := 1; i < polygon.NumLinearRings(); i++ {
+		geoPolygon.Holes = append(geoPolygon.Holes, toGeoLoop(polygon.LinearRing(i).Coords()))
+	}
+	return geoPolygon
+}
+
+// toGeoLoop はリングの座標をh3のGeoLoopに変換する
+func toGeoLoop(coords []geom.Coord) h3.GeoLoop {
+	loop := make(h3.GeoLoop, 0, len(coords))
+	for _, c := range coords {
+		loop = append(loop, h3.NewLatLng(c.Y(), c.X()))
+	}
+	return loop
+}
+
+// intersectionArea はポリゴンとセル境界の重なり面積を計算する
+// 外周との重なりから内周リング(穴)との重なりを差し引く。桁落ちを防ぐため外周の最初の頂点を原点として計算する
+func intersectionArea(polygon *geom.Polygon, boundary h3.CellBoundary) float64 {
+	origin := polygon.LinearRing(0).Coord(0)
+	clip := make([][2]float64, 0, len(boundary))
+	for _, ll := range boundary {
+		clip = append(clip, [2]float64{ll.Lng - origin.X(), ll.Lat - origin.Y()})
+	}
+
+	var area float64
+	for i := 0; i < polygon.NumLinearRings(); i++ {
+		coords := polygon.LinearRing(i).Coords()
+		subject := make([][2]float64, 0, len(coords))
+		for _, c := range coords {
+			subject = append(subject, [2]float64{c.X() - origin.X(), c.Y() - origin.Y()})
+		}
+
+		ringArea := math.Abs(signedArea(clipConvex(subject, clip)))
+		if i == 0 {
+			area += ringArea
+		} else {
+			area -= ringArea
+		}
+	}
+	return math.Max(area, 0)
+}
+
+// clipConvex はSutherland-Hodgman法で多角形を凸多角形clipの内側に切り取る
+// 凹多角形を切り取ると縮退した辺が残る場合があるが、面積は正しく求まる
+func clipConvex(subject, clip [][2]float64) [][2]float64 {
+	// clipの向きに関わらず内側を判定できるよう、反時計回りを正とする符号を掛ける
+	orientation := 1.0
+	if signedArea(clip) < 0 {
+		orientation = -1.0
+	}
+
+	output := subject
+	for i := range clip {
+		if len(output) == 0 {
+			break
+		}
+		a, b := clip[i], clip[(i+1)%len(clip)]
+		inside := func(p [2]float64) bool {
+			return orientation*((b[0]-a[0])*(p[1]-a[1])-(b[1]-a[1])*(p[0]-a[0])) >= 0
+		}
+
+		input := output
+		output = make([][2]float64, 0, len(input)+1)
+		for j := range input {
+			current, prev := input[j], input[(j+len(input)-1)%len(input)]
+			switch {
+			case inside(current):
+				if !inside(prev) {
+					output = append(output, lineIntersection(prev, current, a, b))
+				}
+				output = append(output, current)
+			case inside(prev):
+				output = append(output, lineIntersection(prev, current, a, b))
+			}
+		}
+	}
+	return output
+}
+
+// lineIntersection は線分p1-p2と直線a-bの交点を返す
+func lineIntersection(p1, p2, a, b [2]float64) [2]float64 {
+	dx, dy := p2[0]-p1[0], p2[1]-p1[1]
+	ex, ey := b[0]-a[0], b[1]-a[1]
+	denom := dx*ey - dy*ex
+	if denom == 0 {
+		return p2
+	}
+	t := ((a[0]-p1[0])*ey - (a[1]-p1[1])*ex) / denom
+	return [2]float64{p1[0] + t*dx, p1[1] + t*dy}
+}
+
+// signedArea は多角形の符号付き面積を返す(反時計回りが正)
+func signedArea(points [][2]float64) float64 {
+	var area float64
+	for i := range points {
+		next := points[(i+1)%len(points)]
+		area += points[i][0]*next[1] - next[0]*points[i][1]
+	}
+	return area / 2
+}
@@ -0,0 +1,117 @@
+package h3util
+
+import (
+	"testing"
+
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+	"github.com/uber/h3-go/v4"
+)
+
+// squareMultiPolygon は(lng, lat)を南西端とする一辺sizeの正方形の区画からなるマルチポリゴンを作成する
+func squareMultiPolygon(t *testing.T, squares ...[3]float64) *geom.MultiPolygon {
+	t.Helper()
+	multiPolygon := geom.NewMultiPolygon(geom.XY)
+	for _, sq := range squares {
+		lng, lat, size := sq[0], sq[1], sq[2]
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{
+			{lng, lat}, {lng + size, lat}, {lng + size, lat + size}, {lng, lat + size}, {lng, lat},
+		}})
+		require.NoError(t, multiPolygon.Push(polygon), "マルチポリゴンの作成でエラーが発生")
+	}
+	return multiPolygon
+}
+
+// sharesByResolution は解像度ごとの面積比率の合計とセル数を返す
+func sharesByResolution(coverages []*entity.H3Coverage) (map[entity.Resolution]float64, map[entity.Resolution]int) {
+	sums := make(map[entity.Resolution]float64)
+	counts := make(map[entity.Resolution]int)
+	for _, c := range coverages {
+		sums[c.Resolution] += c.AreaShare
+		counts[c.Resolution]++
+	}
+	return sums, counts
+}
+
+// TestCalculateCoverage は圃場が覆う全てのセルを面積比率付きで返すことをテストする
+func TestCalculateCoverage(t *testing.T) {
+	t.Run("res9のセルより大きい圃場は複数のセルに計上され、比率の合計は1", func(t *testing.T) {
+		// 約900m四方の圃場
+		coverages, err := CalculateCoverage(squareMultiPolygon(t, [3]float64{139.70, 35.60, 0.01}), entity.DefaultResolutions)
+		require.NoError(t, err, "CalculateCoverageでエラーが発生")
+
+		sums, counts := sharesByResolution(coverages)
+		for _, resolution := range entity.DefaultResolutions {
+			require.InDelta(t, 1.0, sums[resolution], 1e-9, "解像度%sの比率の合計が1でない", resolution.String())
+		}
+		require.Greater(t, counts[entity.Res9], 10, "res9では多数のセルを覆うべき")
+		for _, c := range coverages {
+			require.Greater(t, c.AreaShare, 0.0, "比率が0以下のセルは含めない")
+			require.LessOrEqual(t, c.AreaShare, 1.0, "比率が1を超えている")
+			require.Equal(t, int(c.Resolution), GetResolution(c.H3Index), "セルの解像度が一致しない")
+		}
+	})
+
+	t.Run("セル内に収まる小さな圃場は重心のセル1つのみ", func(t *testing.T) {
+		lng, lat := 139.70, 35.60
+		cell, err := h3.LatLngToCell(h3.NewLatLng(lat, lng), 9)
+		require.NoError(t, err, "LatLngToCellでエラーが発生")
+		center, err := cell.LatLng()
+		require.NoError(t, err, "セル中心の取得でエラーが発生")
+
+		// セル中心付近の約10m四方の圃場
+		coverages, err := CalculateCoverage(squareMultiPolygon(t, [3]float64{center.Lng, center.Lat, 0.0001}), []entity.Resolution{entity.Res9})
+		require.NoError(t, err, "CalculateCoverageでエラーが発生")
+		require.Len(t, coverages, 1, "1つのセルのみ覆うべき")
+		require.Equal(t, cell.String(), coverages[0].H3Index, "重心のセルと一致しない")
+		require.InDelta(t, 1.0, coverages[0].AreaShare, 1e-9, "比率は1であるべき")
+	})
+
+	t.Run("面積の大きい区画ほど比率が大きい", func(t *testing.T) {
+		// 大きさの異なる2区画が十分離れているため、res7では別々のセルに計上される
+		coverages, err := CalculateCoverage(squareMultiPolygon(t,
+			[3]float64{139.70, 35.60, 0.0003},
+			[3]float64{139.90, 35.60, 0.0001},
+		), []entity.Resolution{entity.Res7})
+		require.NoError(t, err, "CalculateCoverageでエラーが発生")
+		require.Len(t, coverages, 2, "区画ごとのセルを覆うべき")
+
+		large, err := h3.LatLngToCell(h3.NewLatLng(35.60015, 139.70015), 7)
+		require.NoError(t, err, "LatLngToCellでエラーが発生")
+		for _, c := range coverages {
+			if c.H3Index == large.String() {
+				require.InDelta(t, 0.9, c.AreaShare, 1e-6, "大きい区画のセルの比率が一致しない")
+			} else {
+				require.InDelta(t, 0.1, c.AreaShare, 1e-6, "小さい区画のセルの比率が一致しない")
+			}
+		}
+	})
+
+	t.Run("穴の部分は面積に含めない", func(t *testing.T) {
+		withHole := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{139.70, 35.60}, {139.71, 35.60}, {139.71, 35.61}, {139.70, 35.61}, {139.70, 35.60}},
+			{{139.701, 35.601}, {139.701, 35.609}, {139.709, 35.609}, {139.709, 35.601}, {139.701, 35.601}},
+		})
+		multiPolygon := geom.NewMultiPolygon(geom.XY)
+		require.NoError(t, multiPolygon.Push(withHole), "マルチポリゴンの作成でエラーが発生")
+
+		coverages, err := CalculateCoverage(multiPolygon, []entity.Resolution{entity.Res9})
+		require.NoError(t, err, "CalculateCoverageでエラーが発生")
+
+		sums, _ := sharesByResolution(coverages)
+		require.InDelta(t, 1.0, sums[entity.Res9], 1e-9, "比率の合計が1でない")
+
+		hole, err := h3.LatLngToCell(h3.NewLatLng(35.605, 139.705), 9)
+		require.NoError(t, err, "LatLngToCellでエラーが発生")
+		for _, c := range coverages {
+			require.NotEqual(t, hole.String(), c.H3Index, "穴の中だけにあるセルを含めない")
+		}
+	})
+
+	t.Run("nilのジオメトリ", func(t *testing.T) {
+		coverages, err := CalculateCoverage(nil, entity.DefaultResolutions)
+		require.NoError(t, err, "nilでエラーが発生")
+		require.Empty(t, coverages, "nilのジオメトリは被覆なし")
+	})
+}
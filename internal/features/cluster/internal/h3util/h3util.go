@@ -4,12 +4,16 @@ package h3util
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
 	"github.com/uber/h3-go/v4"
 )
 
+// kmPerDegree は緯度1度あたりの距離(km)
+const kmPerDegree = 111.32
+
 // BoundingBox はバウンディングボックスを表す
 type BoundingBox struct {
 	SWLat float64 // 南西端の緯度
@@ -61,25 +65,164 @@ func (bb *BoundingBox) Contains(lat, lng float64) bool {
 	return lng >= bb.SWLng || lng <= bb.NELng
 }
 
-// ZoomToResolution はズームレベルからH3解像度を決定する
+// ExpandByCell は指定解像度のセルの半径分だけ四方に広げたBoundingBoxを返す
 //
-// ズームレベル対応:
+// 中心がBoundingBox内にあるセルに属する圃場は、重心が必ず広げた範囲に含まれる。
+// 半径はセルの平均辺長の2倍とし、歪みの大きいセルや五角形セルも含める余裕を持たせる。
+// 経度が-180から180の範囲を超える場合は反対側に回し、日付変更線をまたぐBoundingBox(SWLng > NELng)とする。
+// 広げた結果が経度360度以上になる場合は経度方向を全範囲とする
+func (bb *BoundingBox) ExpandByCell(resolution entity.Resolution) (*BoundingBox, error) {
+	edgeKm, err := h3.HexagonEdgeLengthAvgKm(int(resolution))
+	if err != nil {
+		return nil, fmt.Errorf("解像度%sのセルの辺長の取得に失敗しました: %w", resolution.String(), err)
+	}
+	marginLat := 2 * edgeKm / kmPerDegree
+
+	expanded := &BoundingBox{
+		SWLat: math.Max(bb.SWLat-marginLat, -90),
+		NELat: math.Min(bb.NELat+marginLat, 90),
+		SWLng: -180,
+		NELng: 180,
+	}
+
+	// 高緯度ほど経度1度あたりの距離が短くなるため、極に近い側の緯度で経度方向の余白を求める
+	cosLat := math.Cos(math.Max(math.Abs(expanded.SWLat), math.Abs(expanded.NELat)) * math.Pi / 180)
+	if cosLat <= 0 {
+		return expanded, nil
+	}
+	marginLng := marginLat / cosLat
+	lngWidth := bb.NELng - bb.SWLng
+	if lngWidth < 0 {
+		lngWidth += 360
+	}
+	if lngWidth+2*marginLng < 360 {
+		expanded.SWLng = wrapLng(bb.SWLng - marginLng)
+		expanded.NELng = wrapLng(bb.NELng + marginLng)
+	}
+	return expanded, nil
+}
+
+// wrapLng は経度を-180から180の範囲に回す
+func wrapLng(lng float64) float64 {
+	if lng < -180 {
+		return lng + 360
+	}
+	if lng > 180 {
+		return lng - 360
+	}
+	return lng
+}
+
+// ZoomToResolution はズームレベルから設定された解像度のうち表示に使う解像度を決定する
+//
+// ズームレベル2つごとに1段階詳細な解像度を目標(zoom/2+2)とし、
+// 目標以下で最も詳細な解像度を返す。目標以下の解像度がない場合は最も粗い解像度を返す。
+// デフォルトの解像度(3, 5, 7, 9)では以下の対応になる:
 //
 //	zoom 0-5:   res3 (約100km - 地方レベル)
 //	zoom 6-9:   res5 (約10km - 都道府県レベル)
 //	zoom 10-13: res7 (約1km - 市区町村レベル)
 //	zoom 14-22: res9 (約100m - 詳細レベル)
-func ZoomToResolution(zoom float64) entity.Resolution {
-	switch {
-	case zoom < 6:
-		return entity.Res3
-	case zoom < 10:
-		return entity.Res5
-	case zoom < 14:
-		return entity.Res7
-	default:
-		return entity.Res9
+func ZoomToResolution(zoom float64, resolutions []entity.Resolution) entity.Resolution {
+	if len(resolutions) == 0 {
+		resolutions = entity.DefaultResolutions
+	}
+	target := entity.Resolution(math.Floor(math.Max(zoom, 0)/2)) + 2
+
+	selected := resolutions[0]
+	for _, resolution := range resolutions {
+		if resolution < selected {
+			selected = resolution
+		}
+	}
+	for _, resolution := range resolutions {
+		if resolution <= target && resolution > selected {
+			selected = resolution
+		}
+	}
+	return selected
+}
+
+// ParentCell はH3インデックスの指定解像度の親セルを返す
+// 無効なH3インデックスの場合と、指定解像度より粗いセルの場合はokがfalseになる
+func ParentCell(h3Index string, resolution entity.Resolution) (string, bool) {
+	cell := h3.CellFromString(h3Index)
+	if !cell.IsValid() || cell.Resolution() < int(resolution) {
+		return "", false
+	}
+	parent, err := cell.Parent(int(resolution))
+	if err != nil {
+		return "", false
+	}
+	return parent.String(), true
+}
+
+// CellsAtResolution は各H3インデックスを指定解像度のセルに変換して重複なく返す
+// 指定解像度より詳細なセルは親セル、粗いセルは指定解像度の全ての子セルに変換する。
+// 無効なH3インデックスはスキップする。
+// 子セルは解像度の差に応じて7のべき乗で増えるため、結果がlimitを超える場合は変換を中断してokがfalseになる
+func CellsAtResolution(h3Indexes []string, resolution entity.Resolution, limit int) (cells []string, ok bool) {
+	seen := make(map[h3.Cell]bool, len(h3Indexes))
+	cells = make([]string, 0, len(h3Indexes))
+	add := func(cell h3.Cell) {
+		if !seen[cell] {
+			seen[cell] = true
+			cells = append(cells, cell.String())
+		}
+	}
+
+	for _, h3Index := range h3Indexes {
+		cell := h3.CellFromString(h3Index)
+		if !cell.IsValid() {
+			continue
+		}
+
+		if cell.Resolution() >= int(resolution) {
+			parent, err := cell.Parent(int(resolution))
+			if err != nil {
+				continue
+			}
+			add(parent)
+		} else {
+			// 五角形のセルは子セルが少ないが、上限の判定には六角形の子セル数を使う
+			childCount := 1
+			for range int(resolution) - cell.Resolution() {
+				childCount *= 7
+			}
+			if len(cells)+childCount > limit {
+				return nil, false
+			}
+			children, err := cell.Children(int(resolution))
+			if err != nil {
+				continue
+			}
+			for _, child := range children {
+				add(child)
+			}
+		}
+
+		if len(cells) > limit {
+			return nil, false
+		}
+	}
+	return cells, true
+}
+
+// DescendantRange はH3インデックスの指定解像度の子孫セルの範囲を返す
+//
+// 子孫セルは解像度より下の桁だけが異なるため、下の桁を全て0にしたセルから全て7にしたセルまでの
+// 連続した範囲になる。H3インデックスは15桁の16進数文字列のため、文字列の大小でも範囲を比較できる。
+// 無効なH3インデックスの場合と、指定解像度がセルの解像度より粗い場合はokがfalseになる
+func DescendantRange(h3Index string, resolution entity.Resolution) (lower, upper string, ok bool) {
+	cell := h3.CellFromString(h3Index)
+	if !cell.IsValid() || !resolution.IsValid() || int(resolution) < cell.Resolution() {
+		return "", "", false
 	}
+	const resolutionOffset = 52
+	unused := h3.Cell(1)<<(3*(int(entity.MaxResolution)-cell.Resolution())) - 1
+	unused &^= h3.Cell(1)<<(3*(int(entity.MaxResolution)-int(resolution))) - 1
+	base := cell&^(0xF<<resolutionOffset) | h3.Cell(resolution)<<resolutionOffset
+	return (base &^ unused).String(), (base | unused).String(), true
 }
 
 // CellToLatLng はH3セルの中心座標を取得する
@@ -139,13 +282,18 @@ func ConvertAggregatedToClusters(resolution entity.Resolution, aggregated []*rep
 			continue
 		}
 
-		clusters = append(clusters, entity.NewCluster(
+		cluster := entity.NewCluster(
 			resolution,
 			agg.H3Index,
 			agg.FieldCount,
 			lat,
 			lng,
-		))
+		)
+		cluster.TotalAreaSqm = agg.TotalAreaSqm
+		cluster.LandCategoryCounts = agg.LandCategoryCounts
+		cluster.IdleFieldCount = agg.IdleFieldCount
+		cluster.DominantSoilLargeCode = agg.DominantSoilLargeCode
+		clusters = append(clusters, cluster)
 	}
 	return clusters, nil
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 	"github.com/stretchr/testify/require"
+	"github.com/uber/h3-go/v4"
 )
 
 // TestNewBoundingBox はNewBoundingBoxが正しい値でBoundingBoxを生成することをテストする
@@ -224,6 +225,62 @@ func TestBoundingBox_Contains(t *testing.T) {
 	}
 }
 
+// TestBoundingBox_ExpandByCell は中心が範囲内にあるセルの全域が広げた範囲に含まれることをテストする
+func TestBoundingBox_ExpandByCell(t *testing.T) {
+	bb := NewBoundingBox(35.60, 139.70, 35.70, 139.80)
+
+	for _, resolution := range entity.DefaultResolutions {
+		t.Run(resolution.String(), func(t *testing.T) {
+			expanded, err := bb.ExpandByCell(resolution)
+			require.NoError(t, err, "ExpandByCellでエラーが発生")
+			require.Less(t, expanded.SWLat, bb.SWLat, "南側に広がっていない")
+			require.Greater(t, expanded.NELng, bb.NELng, "東側に広がっていない")
+
+			// 範囲の角を中心付近に持つセルの頂点が全て広げた範囲に含まれる
+			for _, corner := range []h3.LatLng{h3.NewLatLng(bb.SWLat, bb.SWLng), h3.NewLatLng(bb.NELat, bb.NELng)} {
+				cell, err := h3.LatLngToCell(corner, int(resolution))
+				require.NoError(t, err, "LatLngToCellでエラーが発生")
+				boundary, err := cell.Boundary()
+				require.NoError(t, err, "Boundaryでエラーが発生")
+				for _, vertex := range boundary {
+					require.True(t, expanded.Contains(vertex.Lat, vertex.Lng), "セルの頂点が広げた範囲に含まれていない")
+				}
+			}
+		})
+	}
+
+	t.Run("日付変更線をまたぐ場合はまたいだまま広げる", func(t *testing.T) {
+		expanded, err := NewBoundingBox(35.0, 170.0, 36.0, -170.0).ExpandByCell(entity.Res7)
+		require.NoError(t, err, "ExpandByCellでエラーが発生")
+		require.Less(t, expanded.SWLng, 170.0, "西側に広がっていない")
+		require.Greater(t, expanded.SWLng, 160.0, "西側に広がりすぎている")
+		require.Greater(t, expanded.NELng, -170.0, "東側に広がっていない")
+		require.Less(t, expanded.NELng, -160.0, "東側に広がりすぎている")
+		require.True(t, expanded.Contains(35.5, 180), "日付変更線上の点が含まれていない")
+	})
+
+	t.Run("経度180を超える場合は日付変更線をまたぐ範囲にする", func(t *testing.T) {
+		expanded, err := NewBoundingBox(-17.0, 179.0, -16.0, 180.0).ExpandByCell(entity.Res3)
+		require.NoError(t, err, "ExpandByCellでエラーが発生")
+		require.Greater(t, expanded.SWLng, expanded.NELng, "日付変更線をまたぐ範囲になっていない")
+		require.True(t, expanded.Contains(-16.5, -179.5), "日付変更線の反対側に広がっていない")
+	})
+
+	t.Run("経度360度以上に広がる場合は経度方向が全範囲", func(t *testing.T) {
+		expanded, err := NewBoundingBox(35.0, -179.0, 36.0, 179.0).ExpandByCell(entity.Res3)
+		require.NoError(t, err, "ExpandByCellでエラーが発生")
+		require.Equal(t, -180.0, expanded.SWLng, "西端が-180でない")
+		require.Equal(t, 180.0, expanded.NELng, "東端が180でない")
+	})
+
+	t.Run("緯度は-90から90に収める", func(t *testing.T) {
+		expanded, err := NewBoundingBox(-89.9, 0, 89.9, 10).ExpandByCell(entity.Res3)
+		require.NoError(t, err, "ExpandByCellでエラーが発生")
+		require.Equal(t, -90.0, expanded.SWLat, "南端が-90でない")
+		require.Equal(t, 90.0, expanded.NELat, "北端が90でない")
+	})
+}
+
 // TestZoomToResolution はZoomToResolutionがズームレベルから正しい解像度を返すことをテストする
 func TestZoomToResolution(t *testing.T) {
 	tests := []struct {
@@ -256,7 +313,7 @@ func TestZoomToResolution(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := ZoomToResolution(tt.zoom)
+			got := ZoomToResolution(tt.zoom, entity.DefaultResolutions)
 			if got != tt.resolution {
 				t.Errorf("ZoomToResolution(%f) = %v, 期待値 %v", tt.zoom, got, tt.resolution)
 			}
@@ -264,6 +321,94 @@ func TestZoomToResolution(t *testing.T) {
 	}
 }
 
+// TestZoomToResolution_Continuous は連続した解像度を設定した場合にズームレベル2つごとに解像度が変わることをテストする
+func TestZoomToResolution_Continuous(t *testing.T) {
+	resolutions := []entity.Resolution{2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	require.Equal(t, entity.Resolution(2), ZoomToResolution(0, resolutions), "zoom 0は最も粗い解像度")
+	require.Equal(t, entity.Resolution(3), ZoomToResolution(2, resolutions), "zoom 2はres3")
+	require.Equal(t, entity.Resolution(3), ZoomToResolution(3.9, resolutions), "zoom 3.9はres3")
+	require.Equal(t, entity.Resolution(8), ZoomToResolution(12, resolutions), "zoom 12はres8")
+	require.Equal(t, entity.Resolution(10), ZoomToResolution(22, resolutions), "最も詳細な解像度を超えない")
+	require.Equal(t, entity.Res9, ZoomToResolution(14, nil), "未設定の場合はデフォルトの解像度を使用する")
+}
+
+// TestCellsAtResolution はCellsAtResolutionが親セル・子セルを重複なく返すことをテストする
+func TestCellsAtResolution(t *testing.T) {
+	center := h3.NewLatLng(35.681236, 139.767125)
+	child, err := h3.LatLngToCell(center, 15)
+	require.NoError(t, err, "LatLngToCellでエラーが発生")
+	sibling, err := h3.LatLngToCell(h3.NewLatLng(35.681240, 139.767130), 15)
+	require.NoError(t, err, "LatLngToCellでエラーが発生")
+	expected, err := child.Parent(7)
+	require.NoError(t, err, "Parentでエラーが発生")
+	coarse, err := child.Parent(5)
+	require.NoError(t, err, "Parentでエラーが発生")
+
+	t.Run("詳細なセルは親セルに変換する", func(t *testing.T) {
+		cells, ok := CellsAtResolution([]string{child.String(), sibling.String(), "invalid", expected.String()}, entity.Res7, 100)
+		require.True(t, ok, "上限を超えていない")
+		require.Equal(t, []string{expected.String()}, cells, "親セルが重複なく返されるべき")
+	})
+
+	t.Run("粗いセルは子セルに変換する", func(t *testing.T) {
+		children, err := coarse.Children(7)
+		require.NoError(t, err, "Childrenでエラーが発生")
+
+		cells, ok := CellsAtResolution([]string{coarse.String(), child.String()}, entity.Res7, 100)
+		require.True(t, ok, "上限を超えていない")
+		require.Len(t, cells, len(children), "子セルが重複なく返されるべき")
+		for _, c := range children {
+			require.Contains(t, cells, c.String(), "子セルが含まれていない")
+		}
+	})
+
+	t.Run("上限を超える", func(t *testing.T) {
+		_, ok := CellsAtResolution([]string{coarse.String()}, entity.Res7, 48)
+		require.False(t, ok, "子セルが上限を超えた場合はokがfalseになるべき")
+
+		_, ok = CellsAtResolution([]string{child.String(), sibling.String()}, entity.MaxResolution, 1)
+		require.False(t, ok, "変換したセルが上限を超えた場合はokがfalseになるべき")
+	})
+}
+
+// TestDescendantRange はDescendantRangeが指定解像度の子孫セルを全て含む範囲を返すことをテストする
+func TestDescendantRange(t *testing.T) {
+	cell, err := h3.LatLngToCell(h3.NewLatLng(35.681236, 139.767125), 9)
+	require.NoError(t, err, "LatLngToCellでエラーが発生")
+
+	for _, resolution := range []entity.Resolution{entity.Res9, 11, entity.MaxResolution} {
+		lower, upper, ok := DescendantRange(cell.String(), resolution)
+		require.True(t, ok, "有効なH3インデックスで範囲が取得できない")
+		require.Len(t, lower, 15, "下限は15桁であるべき")
+		require.Len(t, upper, 15, "上限は15桁であるべき")
+
+		children, err := cell.Children(int(resolution))
+		require.NoError(t, err, "Childrenでエラーが発生")
+		for _, child := range children {
+			require.GreaterOrEqual(t, child.String(), lower, "子孫セルが下限より小さい")
+			require.LessOrEqual(t, child.String(), upper, "子孫セルが上限より大きい")
+		}
+
+		neighbors, err := cell.GridDisk(1)
+		require.NoError(t, err, "GridDiskでエラーが発生")
+		for _, neighbor := range neighbors {
+			if neighbor == cell {
+				continue
+			}
+			descendant, err := neighbor.CenterChild(int(resolution))
+			require.NoError(t, err, "CenterChildでエラーが発生")
+			require.False(t, descendant.String() >= lower && descendant.String() <= upper, "隣接セルの子孫セルが範囲に含まれている")
+		}
+	}
+
+	_, _, ok := DescendantRange(cell.String(), entity.Res7)
+	require.False(t, ok, "セルより粗い解像度で範囲が取得できている")
+
+	_, _, ok = DescendantRange("invalid", entity.MaxResolution)
+	require.False(t, ok, "無効なH3インデックスで範囲が取得できている")
+}
+
 // TestCellToLatLng はCellToLatLngがH3インデックスから座標を正しく取得することをテストする
 func TestCellToLatLng(t *testing.T) {
 	tests := []struct {
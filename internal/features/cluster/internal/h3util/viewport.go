@@ -0,0 +1,176 @@
+package h3util
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/uber/h3-go/v4"
+)
+
+const (
+	// lookupCellDepth は表示する解像度に対する検索セルの解像度の差
+	// 検索セル1つに表示するセルが約7^3=343個含まれる
+	lookupCellDepth = 3
+
+	// maxLookupCells は表示範囲を覆う検索セルの最大数
+	// 超える場合は検索セルの解像度を粗くする
+	maxLookupCells = 1000
+
+	// maxPolygonLat は検索範囲のポリゴンの緯度の上限(極を頂点に含めないため)
+	maxPolygonLat = 89
+
+	// maxStripLngWidth は検索範囲のポリゴン1つあたりの経度の幅
+	// H3は経度の差が180度以上の辺を日付変更線をまたぐ辺として扱うため、範囲を分割する
+	maxStripLngWidth = 90
+
+	// earthRadiusKm は地球の平均半径(km)
+	earthRadiusKm = 6371.0088
+)
+
+// LookupCellResolution は表示する解像度のクラスター結果を検索・キャッシュする単位のセルの解像度を返す
+func LookupCellResolution(resolution entity.Resolution) entity.Resolution {
+	return max(resolution-lookupCellDepth, entity.MinResolution)
+}
+
+// ViewportCells はBoundingBoxに中心がある表示解像度のセルを全て含む検索セルを返す
+//
+// 子セルは親セルの境界から少しはみ出すことがあるため、表示解像度のセルの半径分だけ範囲を広げてから
+// 範囲に重なる検索セル(LookupCellResolution)を求める。検索セルが多すぎる場合は解像度を粗くする。
+// 結果はH3インデックスの昇順で返す
+func ViewportCells(bb *BoundingBox, resolution entity.Resolution) ([]string, error) {
+	if bb == nil || !bb.IsValid() {
+		return nil, fmt.Errorf("無効なバウンディングボックスです")
+	}
+	expanded, err := bb.ExpandByCell(resolution)
+	if err != nil {
+		return nil, err
+	}
+	expanded.SWLat = math.Max(expanded.SWLat, -maxPolygonLat)
+	expanded.NELat = math.Min(expanded.NELat, maxPolygonLat)
+
+	lookupResolution := LookupCellResolution(resolution)
+	for lookupResolution > entity.MinResolution {
+		count, err := estimateCellCount(expanded, lookupResolution)
+		if err != nil {
+			return nil, err
+		}
+		if count <= maxLookupCells {
+			break
+		}
+		lookupResolution--
+	}
+
+	seen := make(map[h3.Cell]bool)
+	for _, strip := range lngStrips(expanded) {
+		polygon := h3.GeoPolygon{GeoLoop: h3.GeoLoop{
+			{Lat: expanded.SWLat, Lng: strip[0]},
+			{Lat: expanded.SWLat, Lng: strip[1]},
+			{Lat: expanded.NELat, Lng: strip[1]},
+			{Lat: expanded.NELat, Lng: strip[0]},
+		}}
+		cells, err := h3.PolygonToCellsExperimental(polygon, int(lookupResolution), h3.ContainmentOverlapping)
+		if err != nil {
+			return nil, fmt.Errorf("表示範囲のセルの取得に失敗しました: %w", err)
+		}
+		for _, cell := range cells {
+			seen[cell] = true
+		}
+	}
+
+	h3Indexes := make([]string, 0, len(seen))
+	for cell := range seen {
+		h3Indexes = append(h3Indexes, cell.String())
+	}
+	slices.Sort(h3Indexes)
+	return h3Indexes, nil
+}
+
+// CellsBoundingBox は全てのセルの境界を含む最小のBoundingBoxを返す
+//
+// 経度は頂点の経度の間で最も広い隙間を除いた範囲とし、日付変更線をまたぐ場合はSWLng > NELngになる。
+// 極を含むセルがある場合は極の緯度まで広げ、経度方向を全範囲とする
+func CellsBoundingBox(h3Indexes []string) (*BoundingBox, error) {
+	if len(h3Indexes) == 0 {
+		return nil, fmt.Errorf("セルが指定されていません")
+	}
+
+	bb := &BoundingBox{SWLat: 90, NELat: -90, SWLng: -180, NELng: 180}
+	lngs := make([]float64, 0, len(h3Indexes)*6)
+	fullLng := false
+	for _, h3Index := range h3Indexes {
+		cell := h3.CellFromString(h3Index)
+		if !cell.IsValid() {
+			return nil, fmt.Errorf("無効なH3インデックス: %s", h3Index)
+		}
+		boundary, err := cell.Boundary()
+		if err != nil {
+			return nil, fmt.Errorf("H3セルの境界の取得に失敗しました: %w", err)
+		}
+		for _, vertex := range boundary {
+			bb.SWLat = math.Min(bb.SWLat, vertex.Lat)
+			bb.NELat = math.Max(bb.NELat, vertex.Lat)
+			lngs = append(lngs, vertex.Lng)
+		}
+		for _, pole := range []h3.LatLng{{Lat: 90}, {Lat: -90}} {
+			poleCell, err := h3.LatLngToCell(pole, cell.Resolution())
+			if err != nil {
+				return nil, fmt.Errorf("極のセルの取得に失敗しました: %w", err)
+			}
+			if poleCell == cell {
+				bb.SWLat = math.Min(bb.SWLat, pole.Lat)
+				bb.NELat = math.Max(bb.NELat, pole.Lat)
+				fullLng = true
+			}
+		}
+	}
+	if fullLng {
+		return bb, nil
+	}
+
+	// 隣り合う経度の隙間のうち最も広いものを範囲外とする(最後の隙間は日付変更線をまたいで先頭に戻る)
+	slices.Sort(lngs)
+	gapStart, gapWidth := len(lngs)-1, lngs[0]+360-lngs[len(lngs)-1]
+	for i := 0; i < len(lngs)-1; i++ {
+		if width := lngs[i+1] - lngs[i]; width > gapWidth {
+			gapStart, gapWidth = i, width
+		}
+	}
+	bb.NELng = lngs[gapStart]
+	bb.SWLng = lngs[(gapStart+1)%len(lngs)]
+	return bb, nil
+}
+
+// lngStrips はBoundingBoxの経度の範囲を幅maxStripLngWidth以下の区間に分割する
+// 日付変更線をまたぐ場合は日付変更線で分割する
+func lngStrips(bb *BoundingBox) [][2]float64 {
+	ranges := [][2]float64{{bb.SWLng, bb.NELng}}
+	if bb.SWLng > bb.NELng {
+		ranges = [][2]float64{{bb.SWLng, 180}, {-180, bb.NELng}}
+	}
+
+	strips := make([][2]float64, 0, len(ranges))
+	for _, r := range ranges {
+		for west := r[0]; west < r[1]; west += maxStripLngWidth {
+			strips = append(strips, [2]float64{west, math.Min(west+maxStripLngWidth, r[1])})
+		}
+	}
+	return strips
+}
+
+// estimateCellCount はBoundingBoxの面積とセルの平均面積から、範囲を覆うセルのおおよその数を求める
+func estimateCellCount(bb *BoundingBox, resolution entity.Resolution) (float64, error) {
+	cellAreaKm2, err := h3.HexagonAreaAvgKm2(int(resolution))
+	if err != nil {
+		return 0, fmt.Errorf("解像度%sのセルの面積の取得に失敗しました: %w", resolution.String(), err)
+	}
+	lngWidth := bb.NELng - bb.SWLng
+	if lngWidth < 0 {
+		lngWidth += 360
+	}
+	toRad := math.Pi / 180
+	areaKm2 := earthRadiusKm * earthRadiusKm * lngWidth * toRad *
+		(math.Sin(bb.NELat*toRad) - math.Sin(bb.SWLat*toRad))
+	return areaKm2 / cellAreaKm2, nil
+}
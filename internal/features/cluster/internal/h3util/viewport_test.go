@@ -0,0 +1,165 @@
+package h3util
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/h3-go/v4"
+)
+
+// TestLookupCellResolution は検索セルの解像度が表示解像度より3段階粗くなることをテストする
+func TestLookupCellResolution(t *testing.T) {
+	tests := []struct {
+		resolution entity.Resolution
+		want       entity.Resolution
+	}{
+		{entity.Res9, 6},
+		{entity.Res7, 4},
+		{entity.Res3, 0},
+		{1, 0},
+		{entity.MaxResolution, 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resolution.String(), func(t *testing.T) {
+			require.Equal(t, tt.want, LookupCellResolution(tt.resolution), "検索セルの解像度が期待値と異なります")
+		})
+	}
+}
+
+// TestViewportCells は範囲内に中心がある表示解像度のセルの親セルが全て検索セルに含まれることをテストする
+func TestViewportCells(t *testing.T) {
+	tests := []struct {
+		name       string
+		bbox       *BoundingBox
+		resolution entity.Resolution
+	}{
+		{"東京駅周辺(res9)", NewBoundingBox(35.66, 139.74, 35.70, 139.79), entity.Res9},
+		{"関東(res7)", NewBoundingBox(35.0, 139.0, 36.5, 140.5), entity.Res7},
+		{"日付変更線をまたぐ範囲(res5)", NewBoundingBox(-17.0, 179.0, -16.0, -179.5), entity.Res5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cells, err := ViewportCells(tt.bbox, tt.resolution)
+			require.NoError(t, err, "ViewportCellsでエラーが発生")
+			require.NotEmpty(t, cells, "検索セルが返されるべき")
+			require.True(t, slices.IsSorted(cells), "検索セルは昇順であるべき")
+			require.Equal(t, len(cells), len(slices.Compact(slices.Clone(cells))), "検索セルが重複しています")
+
+			lookupResolution := LookupCellResolution(tt.resolution)
+			for _, cell := range cells {
+				require.Equal(t, int(lookupResolution), GetResolution(cell), "検索セルの解像度が期待値と異なります")
+			}
+
+			// 範囲内の格子点を含むセルのうち、中心が範囲内にあるものの親セルが検索セルに含まれることを確認する
+			lngWidth := tt.bbox.NELng - tt.bbox.SWLng
+			if lngWidth < 0 {
+				lngWidth += 360
+			}
+			const steps = 20
+			for i := 0; i <= steps; i++ {
+				for j := 0; j <= steps; j++ {
+					lat := tt.bbox.SWLat + (tt.bbox.NELat-tt.bbox.SWLat)*float64(i)/steps
+					lng := tt.bbox.SWLng + lngWidth*float64(j)/steps
+					if lng > 180 {
+						lng -= 360
+					}
+					cell, err := h3.LatLngToCell(h3.NewLatLng(lat, lng), int(tt.resolution))
+					require.NoError(t, err, "LatLngToCellでエラーが発生")
+					center, err := cell.LatLng()
+					require.NoError(t, err, "LatLngでエラーが発生")
+					if !tt.bbox.Contains(center.Lat, center.Lng) {
+						continue
+					}
+					parent, err := cell.Parent(int(lookupResolution))
+					require.NoError(t, err, "Parentでエラーが発生")
+					_, found := slices.BinarySearch(cells, parent.String())
+					require.True(t, found, "範囲内のセル%sの親セルが検索セルに含まれていない", cell.String())
+				}
+			}
+		})
+	}
+}
+
+// TestViewportCells_WideBBox は広い範囲で検索セルの解像度が粗くなり、数が抑えられることをテストする
+func TestViewportCells_WideBBox(t *testing.T) {
+	cells, err := ViewportCells(NewBoundingBox(-90, -180, 90, 180), entity.Res9)
+	require.NoError(t, err, "ViewportCellsでエラーが発生")
+	require.NotEmpty(t, cells, "検索セルが返されるべき")
+	require.LessOrEqual(t, len(cells), 2*maxLookupCells, "検索セルが多すぎます")
+	require.Less(t, GetResolution(cells[0]), int(LookupCellResolution(entity.Res9)), "検索セルの解像度が粗くなるべき")
+}
+
+// TestViewportCells_InvalidBBox は無効なバウンディングボックスでエラーになることをテストする
+func TestViewportCells_InvalidBBox(t *testing.T) {
+	_, err := ViewportCells(NewBoundingBox(36, 139, 35, 140), entity.Res9)
+	require.Error(t, err, "南西端が北東端より北にある場合はエラーになるべき")
+
+	_, err = ViewportCells(nil, entity.Res9)
+	require.Error(t, err, "nilの場合はエラーになるべき")
+}
+
+// TestCellsBoundingBox はセルの境界の全ての頂点を含むBoundingBoxを返すことをテストする
+func TestCellsBoundingBox(t *testing.T) {
+	tokyo, err := h3.LatLngToCell(h3.LatLng{Lat: 35.68, Lng: 139.76}, 6)
+	require.NoError(t, err, "セルの取得に失敗しました")
+	osaka, err := h3.LatLngToCell(h3.LatLng{Lat: 34.69, Lng: 135.50}, 6)
+	require.NoError(t, err, "セルの取得に失敗しました")
+	antimeridian, err := h3.LatLngToCell(h3.LatLng{Lat: 0, Lng: 179.99}, 4)
+	require.NoError(t, err, "セルの取得に失敗しました")
+	north, err := h3.LatLngToCell(h3.LatLng{Lat: 90, Lng: 0}, 2)
+	require.NoError(t, err, "セルの取得に失敗しました")
+
+	tests := []struct {
+		name      string
+		cells     []h3.Cell
+		crossing  bool
+		fullLng   bool
+		poleLatNE bool
+	}{
+		{"複数のセル", []h3.Cell{tokyo, osaka}, false, false, false},
+		{"日付変更線をまたぐセル", []h3.Cell{antimeridian}, true, false, false},
+		{"極を含むセル", []h3.Cell{north}, false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h3Indexes := make([]string, 0, len(tt.cells))
+			for _, cell := range tt.cells {
+				h3Indexes = append(h3Indexes, cell.String())
+			}
+			bb, err := CellsBoundingBox(h3Indexes)
+			require.NoError(t, err, "CellsBoundingBoxでエラーが発生")
+			require.True(t, bb.IsValid(), "有効なBoundingBoxであるべき")
+			require.Equal(t, tt.crossing, bb.SWLng > bb.NELng, "日付変更線をまたぐかどうかが期待値と異なります")
+			if tt.fullLng {
+				require.Equal(t, -180.0, bb.SWLng, "経度方向は全範囲であるべき")
+				require.Equal(t, 180.0, bb.NELng, "経度方向は全範囲であるべき")
+			}
+			if tt.poleLatNE {
+				require.Equal(t, 90.0, bb.NELat, "極の緯度まで広げるべき")
+			}
+
+			for _, cell := range tt.cells {
+				boundary, err := cell.Boundary()
+				require.NoError(t, err, "セルの境界の取得に失敗しました")
+				for _, vertex := range boundary {
+					require.True(t, bb.Contains(vertex.Lat, vertex.Lng), "頂点(%f, %f)が範囲に含まれていません", vertex.Lat, vertex.Lng)
+				}
+			}
+		})
+	}
+
+	// 東京と大阪のセルを含む範囲は日本の外まで広がらない
+	bb, err := CellsBoundingBox([]string{tokyo.String(), osaka.String()})
+	require.NoError(t, err, "CellsBoundingBoxでエラーが発生")
+	require.Less(t, bb.NELng-bb.SWLng, 5.0, "経度の範囲が広すぎます")
+
+	_, err = CellsBoundingBox(nil)
+	require.Error(t, err, "セルがない場合はエラーになるべき")
+	_, err = CellsBoundingBox([]string{"invalid"})
+	require.Error(t, err, "無効なH3インデックスはエラーになるべき")
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
 	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
 )
 
@@ -53,6 +54,15 @@ func (h *ClusterHandler) GetClusters(ctx context.Context, request openapi.GetClu
 		SWLng: params.SwLng,
 		NELat: params.NeLat,
 		NELng: params.NeLng,
+
+		CityCode:           params.CityCode,
+		SoilTypeSmallCode:  params.SoilType,
+		LandCategoryCode:   params.LandCategory,
+		IdleLandStatusCode: params.IdleStatus,
+		MinAreaSqm:         params.MinAreaSqm,
+		MaxAreaSqm:         params.MaxAreaSqm,
+
+		WithBoundary: isGeoJSONFormat(params.Format),
 	})
 	if err != nil {
 		h.logger.Error("クラスター取得に失敗しました",
@@ -63,15 +73,14 @@ func (h *ClusterHandler) GetClusters(ctx context.Context, request openapi.GetClu
 		}, nil
 	}
 
+	if isGeoJSONFormat(params.Format) {
+		return openapi.GetClusters200ApplicationGeoPlusJSONResponse(toClusterFeatureCollection(output)), nil
+	}
+
 	// レスポンス変換
 	clusters := make([]openapi.Cluster, 0, len(output.Clusters))
 	for _, cluster := range output.Clusters {
-		clusters = append(clusters, openapi.Cluster{
-			H3Index: cluster.H3Index,
-			Lat:     cluster.Lat,
-			Lng:     cluster.Lng,
-			Count:   int(cluster.Count),
-		})
+		clusters = append(clusters, toClusterResponse(cluster))
 	}
 
 	return openapi.GetClusters200JSONResponse{
@@ -80,6 +89,65 @@ func (h *ClusterHandler) GetClusters(ctx context.Context, request openapi.GetClu
 	}, nil
 }
 
+// isGeoJSONFormat はレスポンス形式にGeoJSONが指定されているかを判定する
+func isGeoJSONFormat(format *openapi.GetClustersParamsFormat) bool {
+	return format != nil && *format == openapi.Geojson
+}
+
+// toClusterResponse はクラスターをレスポンス形式に変換する
+func toClusterResponse(cluster *entity.ClusterResult) openapi.Cluster {
+	landCategoryCounts := make(map[string]int, len(cluster.LandCategoryCounts))
+	for code, count := range cluster.LandCategoryCounts {
+		landCategoryCounts[code] = int(count)
+	}
+	return openapi.Cluster{
+		H3Index:               cluster.H3Index,
+		Lat:                   cluster.Lat,
+		Lng:                   cluster.Lng,
+		Count:                 int(cluster.Count),
+		AreaSqm:               cluster.AreaSqm,
+		LandCategoryCounts:    landCategoryCounts,
+		IdleCount:             int(cluster.IdleCount),
+		DominantSoilLargeCode: cluster.DominantSoilLargeCode,
+	}
+}
+
+// toClusterFeatureCollection はクラスターをセルの境界をジオメトリとするGeoJSON FeatureCollectionに変換する
+// 日付変更線で分割したセルはMultiPolygon、それ以外はPolygonとする
+func toClusterFeatureCollection(output *usecase.GetClustersOutput) openapi.ClusterFeatureCollection {
+	features := make([]openapi.ClusterFeature, 0, len(output.Clusters))
+	for _, cluster := range output.Clusters {
+		var geometry openapi.ClusterFeature_Geometry
+		if len(cluster.Boundary) == 1 {
+			_ = geometry.FromGeoJSONPolygon(openapi.GeoJSONPolygon{
+				Type:        openapi.Polygon,
+				Coordinates: [][][]float64{cluster.Boundary[0]},
+			})
+		} else {
+			coordinates := make([][][][]float64, 0, len(cluster.Boundary))
+			for _, ring := range cluster.Boundary {
+				coordinates = append(coordinates, [][][]float64{ring})
+			}
+			_ = geometry.FromGeoJSONMultiPolygon(openapi.GeoJSONMultiPolygon{
+				Type:        openapi.MultiPolygon,
+				Coordinates: coordinates,
+			})
+		}
+		features = append(features, openapi.ClusterFeature{
+			Type:       openapi.ClusterFeatureTypeFeature,
+			Id:         cluster.H3Index,
+			Geometry:   geometry,
+			Properties: toClusterResponse(cluster),
+		})
+	}
+
+	return openapi.ClusterFeatureCollection{
+		Type:     openapi.FeatureCollection,
+		Features: features,
+		IsStale:  output.IsStale,
+	}
+}
+
 // validateGetClustersParams はリクエストパラメータをバリデーションする
 func (h *ClusterHandler) validateGetClustersParams(params openapi.GetClustersParams) error {
 	// zoomのバリデーション
@@ -108,6 +176,17 @@ func (h *ClusterHandler) validateGetClustersParams(params openapi.GetClustersPar
 		return &ValidationError{Field: "sw_lat", Message: "南西端の緯度は北東端の緯度より小さくしてください"}
 	}
 
+	// 面積範囲のバリデーション
+	if params.MinAreaSqm != nil && *params.MinAreaSqm < 0 {
+		return &ValidationError{Field: "min_area_sqm", Message: "最小面積は0以上で指定してください"}
+	}
+	if params.MaxAreaSqm != nil && *params.MaxAreaSqm < 0 {
+		return &ValidationError{Field: "max_area_sqm", Message: "最大面積は0以上で指定してください"}
+	}
+	if params.MinAreaSqm != nil && params.MaxAreaSqm != nil && *params.MinAreaSqm > *params.MaxAreaSqm {
+		return &ValidationError{Field: "min_area_sqm", Message: "最小面積は最大面積以下で指定してください"}
+	}
+
 	return nil
 }
 
@@ -135,15 +214,14 @@ func (h *ClusterHandler) RecalculateClusters(ctx context.Context, _ openapi.Reca
 		}, nil
 	}
 
-	if !output.Enqueued {
-		return openapi.RecalculateClusters409JSONResponse{
-			Code:    "already_running",
-			Message: "既にクラスター再計算ジョブが実行中です",
-		}, nil
+	message := "クラスター再計算ジョブをエンキューしました"
+	if output.Merged {
+		message = "保留中のクラスター再計算ジョブに統合しました"
 	}
 
 	return openapi.RecalculateClusters202JSONResponse{
-		Message:  "クラスター再計算ジョブをエンキューしました",
-		Enqueued: true,
+		Message:  message,
+		Enqueued: output.Enqueued,
+		Merged:   output.Merged,
 	}, nil
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/repository"
 	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
 	"github.com/stretchr/testify/require"
+	"github.com/uber/h3-go/v4"
 )
 
 // mockClusterRepository はClusterRepositoryのモック実装
@@ -23,51 +24,87 @@ type mockClusterRepository struct {
 	getErr     error
 }
 
-func (m *mockClusterRepository) GetClusters(_ context.Context, _ entity.Resolution) ([]*entity.Cluster, error) {
+func (m *mockClusterRepository) GetActiveGeneration(_ context.Context) (int64, error) {
+	return 1, nil
+}
+
+func (m *mockClusterRepository) ListLiveGenerations(_ context.Context) ([]int64, error) {
+	return []int64{1}, nil
+}
+
+func (m *mockClusterRepository) CreateGeneration(_ context.Context) (int64, error) {
+	return 2, nil
+}
+
+func (m *mockClusterRepository) ActivateGeneration(_ context.Context, _ int64) (bool, error) {
+	return true, nil
+}
+
+func (m *mockClusterRepository) DeleteGeneration(_ context.Context, _ int64) error {
+	return nil
+}
+
+func (m *mockClusterRepository) DeleteUnusedGenerations(_ context.Context, _ time.Duration) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockClusterRepository) GetClustersInCells(_ context.Context, _ int64, _ entity.Resolution, _ []string) ([]*entity.Cluster, error) {
 	if m.getErr != nil {
 		return nil, m.getErr
 	}
 	return m.clusters, nil
 }
 
-func (m *mockClusterRepository) SaveClusters(_ context.Context, _ []*entity.Cluster) error {
+func (m *mockClusterRepository) SaveClusters(_ context.Context, _ int64, _ []*entity.Cluster) error {
 	return nil
 }
 
-func (m *mockClusterRepository) DeleteClustersByResolution(_ context.Context, _ entity.Resolution) error {
-	return nil
+func (m *mockClusterRepository) AggregateByH3(_ context.Context, _ entity.Resolution) ([]*repository.AggregatedCluster, error) {
+	return m.aggregated, nil
 }
 
-func (m *mockClusterRepository) DeleteAllClusters(_ context.Context) error {
-	return nil
+func (m *mockClusterRepository) AggregateByH3ForCells(_ context.Context, _ entity.Resolution, _ []string) ([]*repository.AggregatedCluster, error) {
+	return m.aggregated, nil
 }
 
-func (m *mockClusterRepository) AggregateByH3(_ context.Context, _ entity.Resolution) ([]*repository.AggregatedCluster, error) {
+func (m *mockClusterRepository) AggregateByCoverage(_ context.Context, _ entity.Resolution, _ entity.AggregationMode) ([]*repository.AggregatedCluster, error) {
 	return m.aggregated, nil
 }
 
-func (m *mockClusterRepository) AggregateByH3ForCells(_ context.Context, _ entity.Resolution, _ []string) ([]*repository.AggregatedCluster, error) {
+func (m *mockClusterRepository) AggregateByCoverageForCells(_ context.Context, _ entity.Resolution, _ entity.AggregationMode, _ []string) ([]*repository.AggregatedCluster, error) {
+	return m.aggregated, nil
+}
+
+func (m *mockClusterRepository) AggregateFiltered(_ context.Context, _ entity.Resolution, _ *entity.ClusterFilter, _ repository.Bounds) ([]*repository.AggregatedCluster, error) {
 	return m.aggregated, nil
 }
 
-func (m *mockClusterRepository) DeleteClustersByH3Indexes(_ context.Context, _ entity.Resolution, _ []string) error {
+func (m *mockClusterRepository) DeleteClustersByH3Indexes(_ context.Context, _ int64, _ entity.Resolution, _ []string) error {
 	return nil
 }
 
 // mockClusterCacheRepository はClusterCacheRepositoryのモック実装
 type mockClusterCacheRepository struct {
-	clusters []*entity.Cluster
-	getErr   error
+	clustersByCell map[string][]*entity.Cluster
+	getErr         error
 }
 
-func (m *mockClusterCacheRepository) GetClusters(_ context.Context, _ entity.Resolution) ([]*entity.Cluster, error) {
+func (m *mockClusterCacheRepository) GetClustersByCells(_ context.Context, _ int64, _ entity.Resolution, _ []string) (map[string][]*entity.Cluster, error) {
 	if m.getErr != nil {
 		return nil, m.getErr
 	}
-	return m.clusters, nil
+	return m.clustersByCell, nil
 }
 
-func (m *mockClusterCacheRepository) SetClusters(_ context.Context, _ entity.Resolution, _ []*entity.Cluster) error {
+func (m *mockClusterCacheRepository) SetClustersByCells(_ context.Context, _ int64, _ entity.Resolution, _ map[string][]*entity.Cluster) error {
+	return nil
+}
+
+func (m *mockClusterCacheRepository) GetFilteredClustersByCells(_ context.Context, _ entity.Resolution, _ string, _ []string) (map[string][]*entity.Cluster, error) {
+	return nil, nil
+}
+
+func (m *mockClusterCacheRepository) SetFilteredClustersByCells(_ context.Context, _ entity.Resolution, _ string, _ map[string][]*entity.Cluster) error {
 	return nil
 }
 
@@ -77,41 +114,43 @@ func (m *mockClusterCacheRepository) DeleteClusters(_ context.Context) error {
 
 // mockClusterJobRepository はClusterJobRepositoryのモック実装
 type mockClusterJobRepository struct {
+	job           *entity.ClusterJob // FindByIDで返すジョブ
+	cancelled     bool
 	hasPendingJob bool
 	hasPendingErr error
-	createErr     error
-}
-
-func (m *mockClusterJobRepository) Create(_ context.Context, _ *entity.ClusterJob) error {
-	return m.createErr
+	enqueueErr    error
 }
 
-func (m *mockClusterJobRepository) CreateWithAffectedCells(_ context.Context, _ *entity.ClusterJob) error {
-	return m.createErr
+func (m *mockClusterJobRepository) Enqueue(_ context.Context, job *entity.ClusterJob, _ int32) (*entity.ClusterJob, bool, error) {
+	if m.enqueueErr != nil {
+		return nil, false, m.enqueueErr
+	}
+	// 保留中または処理中のジョブがある場合は保留中ジョブへの統合として扱う
+	return job, m.hasPendingJob, nil
 }
 
 func (m *mockClusterJobRepository) FindByID(_ context.Context, _ uuid.UUID) (*entity.ClusterJob, error) {
-	return nil, nil
+	return m.job, nil
 }
 
-func (m *mockClusterJobRepository) FindPendingJobs(_ context.Context, _ int32) ([]*entity.ClusterJob, error) {
+func (m *mockClusterJobRepository) Claim(_ context.Context, _ string) (*entity.ClusterJob, error) {
 	return nil, nil
 }
 
-func (m *mockClusterJobRepository) FindPendingJobsWithAffectedCells(_ context.Context, _ int32) ([]*entity.ClusterJob, error) {
-	return nil, nil
+func (m *mockClusterJobRepository) Heartbeat(_ context.Context, _ uuid.UUID, _ string) error {
+	return nil
 }
 
-func (m *mockClusterJobRepository) UpdateToProcessing(_ context.Context, _ uuid.UUID) error {
+func (m *mockClusterJobRepository) UpdateToCompleted(_ context.Context, _ uuid.UUID, _ string) error {
 	return nil
 }
 
-func (m *mockClusterJobRepository) UpdateToCompleted(_ context.Context, _ uuid.UUID) error {
+func (m *mockClusterJobRepository) UpdateToFailed(_ context.Context, _ uuid.UUID, _ string, _ string) error {
 	return nil
 }
 
-func (m *mockClusterJobRepository) UpdateToFailed(_ context.Context, _ uuid.UUID, _ string) error {
-	return nil
+func (m *mockClusterJobRepository) RecoverExpiredJobs(_ context.Context, _ time.Duration, _ int32, _ int32) (*repository.RecoveredJobs, error) {
+	return &repository.RecoveredJobs{}, nil
 }
 
 func (m *mockClusterJobRepository) HasPendingOrProcessingJob(_ context.Context) (bool, error) {
@@ -121,12 +160,16 @@ func (m *mockClusterJobRepository) HasPendingOrProcessingJob(_ context.Context)
 	return m.hasPendingJob, nil
 }
 
-func (m *mockClusterJobRepository) DeleteOldCompletedJobs(_ context.Context) error {
-	return nil
+func (m *mockClusterJobRepository) Cancel(_ context.Context, _ uuid.UUID) (bool, error) {
+	return m.cancelled, nil
 }
 
-func (m *mockClusterJobRepository) DeleteOldFailedJobs(_ context.Context) error {
-	return nil
+func (m *mockClusterJobRepository) DeleteOldCompletedJobs(_ context.Context, _ time.Duration) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockClusterJobRepository) DeleteOldFailedJobs(_ context.Context, _ time.Duration) (int64, error) {
+	return 0, nil
 }
 
 // getTestLogger はテスト用のロガーを返す
@@ -150,6 +193,7 @@ func TestNewClusterHandler(t *testing.T) {
 
 // TestClusterHandler_GetClusters_Success は正常にクラスターを取得することをテストする
 func TestClusterHandler_GetClusters_Success(t *testing.T) {
+	soil := "F3"
 	clusters := []*entity.Cluster{
 		{
 			ID:           uuid.New(),
@@ -158,6 +202,12 @@ func TestClusterHandler_GetClusters_Success(t *testing.T) {
 			FieldCount:   10,
 			CenterLat:    35.681236,
 			CenterLng:    139.767125,
+			TotalAreaSqm: 12345.6,
+
+			LandCategoryCounts:    map[string]int32{"100": 7, "200": 3},
+			IdleFieldCount:        2,
+			DominantSoilLargeCode: &soil,
+
 			CalculatedAt: time.Now(),
 		},
 	}
@@ -189,6 +239,11 @@ func TestClusterHandler_GetClusters_Success(t *testing.T) {
 	resp200, ok := response.(openapi.GetClusters200JSONResponse)
 	require.True(t, ok, "200レスポンスを期待")
 	require.Len(t, resp200.Clusters, 1, "クラスター数が期待値と異なります")
+	require.Equal(t, 10, resp200.Clusters[0].Count, "圃場数が期待値と異なります")
+	require.InDelta(t, 12345.6, resp200.Clusters[0].AreaSqm, 1e-9, "合計面積が期待値と異なります")
+	require.Equal(t, map[string]int{"100": 7, "200": 3}, resp200.Clusters[0].LandCategoryCounts, "土地種別ごとの圃場数が期待値と異なります")
+	require.Equal(t, 2, resp200.Clusters[0].IdleCount, "遊休農地の圃場数が期待値と異なります")
+	require.Equal(t, &soil, resp200.Clusters[0].DominantSoilLargeCode, "主な土壌が期待値と異なります")
 	require.False(t, resp200.IsStale, "IsStaleがtrueです")
 }
 
@@ -348,6 +403,159 @@ func TestClusterHandler_GetClusters_ValidationError_SwLatGreaterThanNeLat(t *tes
 	require.True(t, ok, "400レスポンスを期待")
 }
 
+// TestClusterHandler_GetClusters_ValidationError_AreaRange は面積範囲が不正な場合にエラーを返すことをテストする
+func TestClusterHandler_GetClusters_ValidationError_AreaRange(t *testing.T) {
+	negative, small, large := -1.0, 100.0, 1000.0
+	tests := []struct {
+		name    string
+		minArea *float64
+		maxArea *float64
+	}{
+		{"min_area_sqm 負の値", &negative, nil},
+		{"max_area_sqm 負の値", nil, &negative},
+		{"min_area_sqm > max_area_sqm", &large, &small},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobRepo := &mockClusterJobRepository{}
+			logger := getTestLogger()
+			getClustersUC := usecase.NewGetClustersUseCase(&mockClusterRepository{}, &mockClusterCacheRepository{}, jobRepo, logger)
+			handler := NewClusterHandler(getClustersUC, usecase.NewEnqueueJobUseCase(jobRepo, logger), logger)
+
+			request := openapi.GetClustersRequestObject{
+				Params: openapi.GetClustersParams{
+					Zoom:       12.0,
+					SwLat:      35.0,
+					SwLng:      139.0,
+					NeLat:      36.0,
+					NeLng:      140.0,
+					MinAreaSqm: tt.minArea,
+					MaxAreaSqm: tt.maxArea,
+				},
+			}
+
+			response, err := handler.GetClusters(context.Background(), request)
+
+			require.NoError(t, err, "エラーは返さずにレスポンスで返すべき")
+			_, ok := response.(openapi.GetClusters400JSONResponse)
+			require.True(t, ok, "400レスポンスを期待")
+		})
+	}
+}
+
+// TestClusterHandler_GetClusters_Filtered は絞り込み条件がある場合に圃場をその場で集計した結果を返すことをテストする
+func TestClusterHandler_GetClusters_Filtered(t *testing.T) {
+	cell, err := h3.LatLngToCell(h3.NewLatLng(35.5, 139.5), 7)
+	require.NoError(t, err, "LatLngToCellでエラーが発生")
+
+	clusterRepo := &mockClusterRepository{
+		getErr:     errors.New("事前計算の結果は使用しない"),
+		aggregated: []*repository.AggregatedCluster{{H3Index: cell.String(), FieldCount: 2, TotalAreaSqm: 3000}},
+	}
+	jobRepo := &mockClusterJobRepository{hasPendingJob: true}
+	logger := getTestLogger()
+	getClustersUC := usecase.NewGetClustersUseCase(clusterRepo, &mockClusterCacheRepository{}, jobRepo, logger)
+	handler := NewClusterHandler(getClustersUC, usecase.NewEnqueueJobUseCase(jobRepo, logger), logger)
+
+	idleStatus := "1"
+	request := openapi.GetClustersRequestObject{
+		Params: openapi.GetClustersParams{
+			Zoom:       12.0,
+			SwLat:      35.0,
+			SwLng:      139.0,
+			NeLat:      36.0,
+			NeLng:      140.0,
+			IdleStatus: &idleStatus,
+		},
+	}
+
+	response, err := handler.GetClusters(context.Background(), request)
+
+	require.NoError(t, err, "GetClustersでエラーが発生")
+	resp200, ok := response.(openapi.GetClusters200JSONResponse)
+	require.True(t, ok, "200レスポンスを期待")
+	require.Len(t, resp200.Clusters, 1, "クラスター数が期待値と異なります")
+	require.Equal(t, 2, resp200.Clusters[0].Count, "圃場数が期待値と異なります")
+	require.InDelta(t, 3000.0, resp200.Clusters[0].AreaSqm, 1e-9, "合計面積が期待値と異なります")
+	require.False(t, resp200.IsStale, "絞り込み集計はIsStaleがfalseであるべき")
+}
+
+// TestClusterHandler_GetClusters_GeoJSON はformat=geojsonで六角形セルのFeatureCollectionを返すことをテストする
+func TestClusterHandler_GetClusters_GeoJSON(t *testing.T) {
+	cell, err := h3.LatLngToCell(h3.NewLatLng(35.681236, 139.767125), 7)
+	require.NoError(t, err, "LatLngToCellでエラーが発生")
+	center, err := cell.LatLng()
+	require.NoError(t, err, "LatLngでエラーが発生")
+
+	clusterRepo := &mockClusterRepository{clusters: []*entity.Cluster{
+		{Resolution: entity.Res7, H3Index: cell.String(), FieldCount: 10, CenterLat: center.Lat, CenterLng: center.Lng},
+	}}
+	jobRepo := &mockClusterJobRepository{hasPendingJob: true}
+	logger := getTestLogger()
+	getClustersUC := usecase.NewGetClustersUseCase(clusterRepo, &mockClusterCacheRepository{}, jobRepo, logger)
+	handler := NewClusterHandler(getClustersUC, usecase.NewEnqueueJobUseCase(jobRepo, logger), logger)
+
+	format := openapi.Geojson
+	response, err := handler.GetClusters(context.Background(), openapi.GetClustersRequestObject{
+		Params: openapi.GetClustersParams{Zoom: 12.0, SwLat: 35.0, SwLng: 139.0, NeLat: 36.0, NeLng: 140.0, Format: &format},
+	})
+
+	require.NoError(t, err, "GetClustersでエラーが発生")
+	resp200, ok := response.(openapi.GetClusters200ApplicationGeoPlusJSONResponse)
+	require.True(t, ok, "GeoJSONの200レスポンスを期待")
+	require.Equal(t, openapi.FeatureCollection, resp200.Type, "typeがFeatureCollectionではない")
+	require.True(t, resp200.IsStale, "IsStaleがtrueであるべき")
+	require.Len(t, resp200.Features, 1, "Feature数が期待値と異なります")
+
+	feature := resp200.Features[0]
+	require.Equal(t, openapi.ClusterFeatureTypeFeature, feature.Type, "typeがFeatureではない")
+	require.Equal(t, cell.String(), feature.Id, "IDはH3インデックスであるべき")
+	require.Equal(t, 10, feature.Properties.Count, "圃場数が期待値と異なります")
+	polygon, err := feature.Geometry.AsGeoJSONPolygon()
+	require.NoError(t, err, "Polygonとして取得できない")
+	require.Equal(t, openapi.Polygon, polygon.Type, "日付変更線をまたがないセルはPolygonであるべき")
+	require.Len(t, polygon.Coordinates, 1, "外周リングのみであるべき")
+	require.Len(t, polygon.Coordinates[0], 7, "六角形の閉じたリングは7点であるべき")
+}
+
+// TestClusterHandler_GetClusters_GeoJSON_Antimeridian は日付変更線をまたぐ範囲とセルをMultiPolygonで返すことをテストする
+func TestClusterHandler_GetClusters_GeoJSON_Antimeridian(t *testing.T) {
+	cell, err := h3.LatLngToCell(h3.NewLatLng(-16.5, 180), 3)
+	require.NoError(t, err, "LatLngToCellでエラーが発生")
+	center, err := cell.LatLng()
+	require.NoError(t, err, "LatLngでエラーが発生")
+
+	clusterRepo := &mockClusterRepository{clusters: []*entity.Cluster{
+		{Resolution: entity.Res3, H3Index: cell.String(), FieldCount: 1, CenterLat: center.Lat, CenterLng: center.Lng},
+	}}
+	jobRepo := &mockClusterJobRepository{}
+	logger := getTestLogger()
+	getClustersUC := usecase.NewGetClustersUseCase(clusterRepo, &mockClusterCacheRepository{}, jobRepo, logger)
+	handler := NewClusterHandler(getClustersUC, usecase.NewEnqueueJobUseCase(jobRepo, logger), logger)
+
+	format := openapi.Geojson
+	response, err := handler.GetClusters(context.Background(), openapi.GetClustersRequestObject{
+		Params: openapi.GetClustersParams{Zoom: 3.0, SwLat: -20.0, SwLng: 175.0, NeLat: -13.0, NeLng: -175.0, Format: &format},
+	})
+
+	require.NoError(t, err, "GetClustersでエラーが発生")
+	resp200, ok := response.(openapi.GetClusters200ApplicationGeoPlusJSONResponse)
+	require.True(t, ok, "GeoJSONの200レスポンスを期待")
+	require.Len(t, resp200.Features, 1, "日付変更線をまたぐ範囲のクラスターが返されるべき")
+
+	multiPolygon, err := resp200.Features[0].Geometry.AsGeoJSONMultiPolygon()
+	require.NoError(t, err, "MultiPolygonとして取得できない")
+	require.Equal(t, openapi.MultiPolygon, multiPolygon.Type, "日付変更線をまたぐセルはMultiPolygonであるべき")
+	require.Len(t, multiPolygon.Coordinates, 2, "日付変更線で2つに分割されるべき")
+	for _, polygon := range multiPolygon.Coordinates {
+		for _, coord := range polygon[0] {
+			require.GreaterOrEqual(t, coord[0], -180.0, "経度が範囲外")
+			require.LessOrEqual(t, coord[0], 180.0, "経度が範囲外")
+		}
+	}
+}
+
 // TestClusterHandler_GetClusters_UseCaseError はUseCaseエラー時に500を返すことをテストする
 func TestClusterHandler_GetClusters_UseCaseError(t *testing.T) {
 	clusterRepo := &mockClusterRepository{getErr: errors.New("db error")}
@@ -620,10 +828,11 @@ func TestClusterHandler_RecalculateClusters_Success(t *testing.T) {
 	resp202, ok := response.(openapi.RecalculateClusters202JSONResponse)
 	require.True(t, ok, "202レスポンスを期待")
 	require.True(t, resp202.Enqueued, "Enqueuedがtrueであるべき")
+	require.False(t, resp202.Merged, "Mergedがfalseであるべき")
 }
 
-// TestClusterHandler_RecalculateClusters_AlreadyRunning は既にジョブが実行中の場合に409を返すことをテストする
-func TestClusterHandler_RecalculateClusters_AlreadyRunning(t *testing.T) {
+// TestClusterHandler_RecalculateClusters_Merged は保留中ジョブがある場合に統合して202を返すことをテストする
+func TestClusterHandler_RecalculateClusters_Merged(t *testing.T) {
 	clusterRepo := &mockClusterRepository{}
 	cacheRepo := &mockClusterCacheRepository{}
 	jobRepo := &mockClusterJobRepository{hasPendingJob: true}
@@ -640,9 +849,10 @@ func TestClusterHandler_RecalculateClusters_AlreadyRunning(t *testing.T) {
 	require.NoError(t, err, "RecalculateClustersでエラーが発生")
 	require.NotNil(t, response, "レスポンスがnilです")
 
-	resp409, ok := response.(openapi.RecalculateClusters409JSONResponse)
-	require.True(t, ok, "409レスポンスを期待")
-	require.Equal(t, "already_running", resp409.Code, "エラーコードがalready_runningであるべき")
+	resp202, ok := response.(openapi.RecalculateClusters202JSONResponse)
+	require.True(t, ok, "202レスポンスを期待")
+	require.True(t, resp202.Enqueued, "Enqueuedがtrueであるべき")
+	require.True(t, resp202.Merged, "Mergedがtrueであるべき")
 }
 
 // TestClusterHandler_RecalculateClusters_Error はエンキューエラー時に500を返すことをテストする
@@ -651,7 +861,7 @@ func TestClusterHandler_RecalculateClusters_Error(t *testing.T) {
 	cacheRepo := &mockClusterCacheRepository{}
 	jobRepo := &mockClusterJobRepository{
 		hasPendingJob: false,
-		createErr:     errors.New("db error"),
+		enqueueErr:    errors.New("db error"),
 	}
 	logger := getTestLogger()
 
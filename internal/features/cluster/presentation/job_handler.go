@@ -0,0 +1,198 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
+)
+
+// ClusterJobHandler はクラスタージョブ履歴・操作APIのハンドラー
+type ClusterJobHandler struct {
+	listClusterJobsUC  *usecase.ListClusterJobsUseCase
+	getClusterJobUC    *usecase.GetClusterJobUseCase
+	cancelClusterJobUC *usecase.CancelClusterJobUseCase
+	retryClusterJobUC  *usecase.RetryClusterJobUseCase
+	logger             *slog.Logger
+}
+
+// NewClusterJobHandler はClusterJobHandlerを作成する
+func NewClusterJobHandler(
+	listClusterJobsUC *usecase.ListClusterJobsUseCase,
+	getClusterJobUC *usecase.GetClusterJobUseCase,
+	cancelClusterJobUC *usecase.CancelClusterJobUseCase,
+	retryClusterJobUC *usecase.RetryClusterJobUseCase,
+	logger *slog.Logger,
+) *ClusterJobHandler {
+	return &ClusterJobHandler{
+		listClusterJobsUC:  listClusterJobsUC,
+		getClusterJobUC:    getClusterJobUC,
+		cancelClusterJobUC: cancelClusterJobUC,
+		retryClusterJobUC:  retryClusterJobUC,
+		logger:             logger,
+	}
+}
+
+// ListClusterJobs はクラスタージョブの履歴を取得する
+func (h *ClusterJobHandler) ListClusterJobs(ctx context.Context, request openapi.ListClusterJobsRequestObject) (openapi.ListClusterJobsResponseObject, error) {
+	params := request.Params
+
+	var status *string
+	if params.Status != nil {
+		s := string(*params.Status)
+		status = &s
+	}
+
+	output, err := h.listClusterJobsUC.Execute(ctx, usecase.ListClusterJobsInput{
+		Limit:       params.Limit,
+		Offset:      params.Offset,
+		Status:      status,
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+	})
+	if err != nil {
+		if hasHTTPStatus(err, http.StatusBadRequest) {
+			return openapi.ListClusterJobs400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("クラスタージョブ履歴の取得に失敗しました",
+			slog.String("error", err.Error()))
+		return openapi.ListClusterJobs500JSONResponse{
+			Code:    "internal_error",
+			Message: "クラスタージョブ履歴の取得に失敗しました",
+		}, nil
+	}
+
+	now := time.Now()
+	jobs := make([]openapi.ClusterJob, 0, len(output.Jobs))
+	for _, job := range output.Jobs {
+		jobs = append(jobs, toClusterJobResponse(job, now))
+	}
+
+	return openapi.ListClusterJobs200JSONResponse{
+		Jobs:  jobs,
+		Total: int(output.Total),
+	}, nil
+}
+
+// GetClusterJob はクラスタージョブを取得する
+func (h *ClusterJobHandler) GetClusterJob(ctx context.Context, request openapi.GetClusterJobRequestObject) (openapi.GetClusterJobResponseObject, error) {
+	job, err := h.getClusterJobUC.Execute(ctx, request.JobId)
+	if err != nil {
+		if apperror.IsNotFoundError(err) {
+			return openapi.GetClusterJob404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("クラスタージョブの取得に失敗しました",
+			slog.String("job_id", request.JobId.String()),
+			slog.String("error", err.Error()))
+		return openapi.GetClusterJob500JSONResponse{
+			Code:    "internal_error",
+			Message: "クラスタージョブの取得に失敗しました",
+		}, nil
+	}
+
+	return openapi.GetClusterJob200JSONResponse(toClusterJobResponse(job, time.Now())), nil
+}
+
+// CancelClusterJob は保留中のクラスタージョブを取り消す
+func (h *ClusterJobHandler) CancelClusterJob(ctx context.Context, request openapi.CancelClusterJobRequestObject) (openapi.CancelClusterJobResponseObject, error) {
+	job, err := h.cancelClusterJobUC.Execute(ctx, request.JobId)
+	if err != nil {
+		if apperror.IsNotFoundError(err) {
+			return openapi.CancelClusterJob404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		if hasHTTPStatus(err, http.StatusConflict) {
+			return openapi.CancelClusterJob409JSONResponse{
+				Code:    "conflict",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("クラスタージョブの取り消しに失敗しました",
+			slog.String("job_id", request.JobId.String()),
+			slog.String("error", err.Error()))
+		return openapi.CancelClusterJob500JSONResponse{
+			Code:    "internal_error",
+			Message: "クラスタージョブの取り消しに失敗しました",
+		}, nil
+	}
+
+	return openapi.CancelClusterJob200JSONResponse(toClusterJobResponse(job, time.Now())), nil
+}
+
+// RetryClusterJob は失敗・取り消し済みのクラスタージョブを再実行する
+func (h *ClusterJobHandler) RetryClusterJob(ctx context.Context, request openapi.RetryClusterJobRequestObject) (openapi.RetryClusterJobResponseObject, error) {
+	output, err := h.retryClusterJobUC.Execute(ctx, request.JobId)
+	if err != nil {
+		if apperror.IsNotFoundError(err) {
+			return openapi.RetryClusterJob404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		if hasHTTPStatus(err, http.StatusConflict) {
+			return openapi.RetryClusterJob409JSONResponse{
+				Code:    "conflict",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("クラスタージョブの再実行に失敗しました",
+			slog.String("job_id", request.JobId.String()),
+			slog.String("error", err.Error()))
+		return openapi.RetryClusterJob500JSONResponse{
+			Code:    "internal_error",
+			Message: "クラスタージョブの再実行に失敗しました",
+		}, nil
+	}
+
+	return openapi.RetryClusterJob202JSONResponse{
+		Job:    toClusterJobResponse(output.Job, time.Now()),
+		Merged: output.Merged,
+	}, nil
+}
+
+// toClusterJobResponse はクラスタージョブをレスポンス形式に変換する
+// 処理中のジョブの処理時間はnowまでの経過時間とする
+func toClusterJobResponse(job *query.ClusterJobSummary, now time.Time) openapi.ClusterJob {
+	var durationSeconds *float64
+	if d := job.Duration(now); d != nil {
+		seconds := d.Seconds()
+		durationSeconds = &seconds
+	}
+	return openapi.ClusterJob{
+		Id:                job.ID,
+		Status:            openapi.ClusterJobStatus(job.Status),
+		Priority:          int(job.Priority),
+		FullRecalculation: job.FullRecalculation,
+		AffectedCellCount: job.AffectedCellCount,
+		Attempts:          int(job.Attempts),
+		WorkerId:          job.WorkerID,
+		CreatedAt:         job.CreatedAt,
+		StartedAt:         job.StartedAt,
+		CompletedAt:       job.CompletedAt,
+		DurationSeconds:   durationSeconds,
+		ErrorMessage:      job.ErrorMessage,
+	}
+}
+
+// hasHTTPStatus はエラーが指定したHTTPステータスのアプリケーションエラーかどうかを判定する
+func hasHTTPStatus(err error, status int) bool {
+	var appErr apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.HTTPStatus() == status
+	}
+	return false
+}
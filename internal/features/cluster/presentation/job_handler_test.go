@@ -0,0 +1,216 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+// mockClusterJobQuery はClusterJobQueryのモック実装
+type mockClusterJobQuery struct {
+	jobs    []*query.ClusterJobSummary
+	job     *query.ClusterJobSummary // FindByIDで返すジョブ
+	total   int64
+	listErr error
+}
+
+func (m *mockClusterJobQuery) List(_ context.Context, _ query.ClusterJobFilter, _, _ int32) ([]*query.ClusterJobSummary, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.jobs, nil
+}
+
+func (m *mockClusterJobQuery) Count(_ context.Context, _ query.ClusterJobFilter) (int64, error) {
+	return m.total, nil
+}
+
+func (m *mockClusterJobQuery) FindByID(_ context.Context, _ uuid.UUID) (*query.ClusterJobSummary, error) {
+	return m.job, nil
+}
+
+// newTestClusterJobHandler はモックを使用したClusterJobHandlerを作成する
+func newTestClusterJobHandler(jobRepo *mockClusterJobRepository, jobQuery *mockClusterJobQuery) *ClusterJobHandler {
+	logger := getTestLogger()
+	return NewClusterJobHandler(
+		usecase.NewListClusterJobsUseCase(jobQuery),
+		usecase.NewGetClusterJobUseCase(jobQuery),
+		usecase.NewCancelClusterJobUseCase(jobRepo, jobQuery, logger),
+		usecase.NewRetryClusterJobUseCase(jobRepo, usecase.NewEnqueueJobUseCase(jobRepo, logger), jobQuery, logger),
+		logger,
+	)
+}
+
+// TestClusterJobHandler_ListClusterJobs_Success はジョブ履歴を返すことをテストする
+func TestClusterJobHandler_ListClusterJobs_Success(t *testing.T) {
+	startedAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	completedAt := startedAt.Add(90 * time.Second)
+	jobQuery := &mockClusterJobQuery{
+		jobs: []*query.ClusterJobSummary{{
+			ID:          uuid.New(),
+			Status:      entity.JobStatusCompleted,
+			CreatedAt:   startedAt,
+			StartedAt:   &startedAt,
+			CompletedAt: &completedAt,
+		}},
+		total: 1,
+	}
+	handler := newTestClusterJobHandler(&mockClusterJobRepository{}, jobQuery)
+
+	resp, err := handler.ListClusterJobs(context.Background(), openapi.ListClusterJobsRequestObject{})
+
+	require.NoError(t, err, "ListClusterJobsでエラーが発生")
+	okResp, ok := resp.(openapi.ListClusterJobs200JSONResponse)
+	require.True(t, ok, "200レスポンスを期待")
+	require.Equal(t, 1, okResp.Total, "総数が一致しない")
+	require.Len(t, okResp.Jobs, 1, "ジョブ数が一致しない")
+	require.Equal(t, openapi.ClusterJobStatusCompleted, okResp.Jobs[0].Status, "ステータスが一致しない")
+	require.NotNil(t, okResp.Jobs[0].DurationSeconds, "処理時間が設定されていない")
+	require.InDelta(t, 90.0, *okResp.Jobs[0].DurationSeconds, 0.001, "処理時間が一致しない")
+}
+
+// TestClusterJobHandler_ListClusterJobs_Error は入力エラーと内部エラーを区別することをテストする
+func TestClusterJobHandler_ListClusterJobs_Error(t *testing.T) {
+	limit := 0
+	handler := newTestClusterJobHandler(&mockClusterJobRepository{}, &mockClusterJobQuery{})
+
+	resp, err := handler.ListClusterJobs(context.Background(), openapi.ListClusterJobsRequestObject{
+		Params: openapi.ListClusterJobsParams{Limit: &limit},
+	})
+	require.NoError(t, err, "ListClusterJobsでエラーが発生")
+	_, ok := resp.(openapi.ListClusterJobs400JSONResponse)
+	require.True(t, ok, "400レスポンスを期待")
+
+	handler = newTestClusterJobHandler(&mockClusterJobRepository{}, &mockClusterJobQuery{listErr: errors.New("db error")})
+
+	resp, err = handler.ListClusterJobs(context.Background(), openapi.ListClusterJobsRequestObject{})
+	require.NoError(t, err, "ListClusterJobsでエラーが発生")
+	_, ok = resp.(openapi.ListClusterJobs500JSONResponse)
+	require.True(t, ok, "500レスポンスを期待")
+}
+
+// TestClusterJobHandler_GetClusterJob_NotFound は存在しないジョブで404を返すことをテストする
+func TestClusterJobHandler_GetClusterJob_NotFound(t *testing.T) {
+	handler := newTestClusterJobHandler(&mockClusterJobRepository{}, &mockClusterJobQuery{})
+
+	resp, err := handler.GetClusterJob(context.Background(), openapi.GetClusterJobRequestObject{JobId: uuid.New()})
+
+	require.NoError(t, err, "GetClusterJobでエラーが発生")
+	_, ok := resp.(openapi.GetClusterJob404JSONResponse)
+	require.True(t, ok, "404レスポンスを期待")
+}
+
+// TestClusterJobHandler_CancelClusterJob は取り消しの結果に応じたレスポンスを返すことをテストする
+func TestClusterJobHandler_CancelClusterJob(t *testing.T) {
+	tests := []struct {
+		name     string
+		jobRepo  *mockClusterJobRepository
+		jobQuery *mockClusterJobQuery
+		check    func(t *testing.T, resp openapi.CancelClusterJobResponseObject)
+	}{
+		{
+			name:     "取り消し成功",
+			jobRepo:  &mockClusterJobRepository{cancelled: true},
+			jobQuery: &mockClusterJobQuery{job: &query.ClusterJobSummary{ID: uuid.New(), Status: entity.JobStatusCancelled}},
+			check: func(t *testing.T, resp openapi.CancelClusterJobResponseObject) {
+				okResp, ok := resp.(openapi.CancelClusterJob200JSONResponse)
+				require.True(t, ok, "200レスポンスを期待")
+				require.Equal(t, openapi.ClusterJobStatusCancelled, okResp.Status, "ステータスが一致しない")
+			},
+		},
+		{
+			name:     "保留中でない",
+			jobRepo:  &mockClusterJobRepository{},
+			jobQuery: &mockClusterJobQuery{job: &query.ClusterJobSummary{ID: uuid.New(), Status: entity.JobStatusProcessing}},
+			check: func(t *testing.T, resp openapi.CancelClusterJobResponseObject) {
+				_, ok := resp.(openapi.CancelClusterJob409JSONResponse)
+				require.True(t, ok, "409レスポンスを期待")
+			},
+		},
+		{
+			name:     "ジョブが存在しない",
+			jobRepo:  &mockClusterJobRepository{},
+			jobQuery: &mockClusterJobQuery{},
+			check: func(t *testing.T, resp openapi.CancelClusterJobResponseObject) {
+				_, ok := resp.(openapi.CancelClusterJob404JSONResponse)
+				require.True(t, ok, "404レスポンスを期待")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestClusterJobHandler(tt.jobRepo, tt.jobQuery)
+
+			resp, err := handler.CancelClusterJob(context.Background(), openapi.CancelClusterJobRequestObject{JobId: uuid.New()})
+
+			require.NoError(t, err, "CancelClusterJobでエラーが発生")
+			tt.check(t, resp)
+		})
+	}
+}
+
+// TestClusterJobHandler_RetryClusterJob は再実行の結果に応じたレスポンスを返すことをテストする
+func TestClusterJobHandler_RetryClusterJob(t *testing.T) {
+	failed := entity.NewClusterJob(0)
+	failed.Fail("calculation error")
+	completed := entity.NewClusterJob(0)
+	completed.Complete()
+	pending := &query.ClusterJobSummary{ID: uuid.New(), Status: entity.JobStatusPending}
+
+	tests := []struct {
+		name     string
+		jobRepo  *mockClusterJobRepository
+		jobQuery *mockClusterJobQuery
+		check    func(t *testing.T, resp openapi.RetryClusterJobResponseObject)
+	}{
+		{
+			name:     "再実行成功",
+			jobRepo:  &mockClusterJobRepository{job: failed},
+			jobQuery: &mockClusterJobQuery{job: pending},
+			check: func(t *testing.T, resp openapi.RetryClusterJobResponseObject) {
+				okResp, ok := resp.(openapi.RetryClusterJob202JSONResponse)
+				require.True(t, ok, "202レスポンスを期待")
+				require.Equal(t, pending.ID, okResp.Job.Id, "再実行するジョブIDが一致しない")
+				require.False(t, okResp.Merged, "統合されないべき")
+			},
+		},
+		{
+			name:     "完了済みのジョブ",
+			jobRepo:  &mockClusterJobRepository{job: completed},
+			jobQuery: &mockClusterJobQuery{},
+			check: func(t *testing.T, resp openapi.RetryClusterJobResponseObject) {
+				_, ok := resp.(openapi.RetryClusterJob409JSONResponse)
+				require.True(t, ok, "409レスポンスを期待")
+			},
+		},
+		{
+			name:     "ジョブが存在しない",
+			jobRepo:  &mockClusterJobRepository{},
+			jobQuery: &mockClusterJobQuery{},
+			check: func(t *testing.T, resp openapi.RetryClusterJobResponseObject) {
+				_, ok := resp.(openapi.RetryClusterJob404JSONResponse)
+				require.True(t, ok, "404レスポンスを期待")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestClusterJobHandler(tt.jobRepo, tt.jobQuery)
+
+			resp, err := handler.RetryClusterJob(context.Background(), openapi.RetryClusterJobRequestObject{JobId: uuid.New()})
+
+			require.NoError(t, err, "RetryClusterJobでエラーが発生")
+			tt.check(t, resp)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+// Package port はエクスポート機能が利用する外部サービスのインターフェースを定義する
+package port
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// StorageClient はエクスポートファイルの保存先ストレージのインターフェース
+type StorageClient interface {
+	// Upload はデータをストレージにアップロードする
+	Upload(ctx context.Context, key string, data io.Reader, contentType string) error
+
+	// PresignGetURL はオブジェクトを取得するための署名付きURLを発行する
+	PresignGetURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
@@ -0,0 +1,17 @@
+// Package query はエクスポート対象データの照会インターフェースを定義する
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+)
+
+// ExportFieldQuery はエクスポート対象圃場の照会インターフェース
+type ExportFieldQuery interface {
+	// ListPage は絞り込み条件に一致する圃場をID順に最大limit件取得する
+	// afterIDを指定した場合はそのIDより後の圃場から取得する(キーセットページング)
+	// 各圃場には紐づく農地台帳を含める
+	ListPage(ctx context.Context, filter entity.ExportFilter, afterID *uuid.UUID, limit int32) ([]*entity.ExportField, error)
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/export/application/port"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/repository"
+)
+
+// GetExportStatusOutput はエクスポートステータス取得の出力
+type GetExportStatusOutput struct {
+	ID           uuid.UUID
+	Format       entity.ExportFormat
+	Status       entity.ExportStatus
+	TotalRecords *int32
+	DownloadURL  *string // 完了時のみ設定される署名付きURL
+	ErrorMessage *string
+	CreatedAt    time.Time
+	StartedAt    *time.Time
+	CompletedAt  *time.Time
+}
+
+// GetExportStatusUseCase はエクスポートステータス取得のユースケース
+type GetExportStatusUseCase struct {
+	exportJobRepo repository.ExportJobRepository
+	storageClient port.StorageClient
+	urlExpiry     time.Duration
+	logger        *slog.Logger
+}
+
+// NewGetExportStatusUseCase は新しいGetExportStatusUseCaseを作成する
+// urlExpiryはダウンロードURLの有効期間
+func NewGetExportStatusUseCase(
+	exportJobRepo repository.ExportJobRepository,
+	storageClient port.StorageClient,
+	urlExpiry time.Duration,
+	logger *slog.Logger,
+) *GetExportStatusUseCase {
+	return &GetExportStatusUseCase{
+		exportJobRepo: exportJobRepo,
+		storageClient: storageClient,
+		urlExpiry:     urlExpiry,
+		logger:        logger,
+	}
+}
+
+// Execute はエクスポートステータスを取得する
+func (uc *GetExportStatusUseCase) Execute(ctx context.Context, id uuid.UUID) (*GetExportStatusOutput, error) {
+	job, err := uc.exportJobRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("エクスポートジョブの取得に失敗しました", err)
+	}
+
+	if job == nil {
+		return nil, apperror.NotFoundError("エクスポートジョブが見つかりません")
+	}
+
+	output := &GetExportStatusOutput{
+		ID:           job.ID,
+		Format:       job.Format,
+		Status:       job.Status,
+		TotalRecords: job.TotalRecords,
+		ErrorMessage: job.ErrorMessage,
+		CreatedAt:    job.CreatedAt,
+		StartedAt:    job.StartedAt,
+		CompletedAt:  job.CompletedAt,
+	}
+
+	if job.IsDownloadable() {
+		url, err := uc.storageClient.PresignGetURL(ctx, *job.S3Key, uc.urlExpiry)
+		if err != nil {
+			return nil, apperror.InternalErrorWithCause("ダウンロードURLの発行に失敗しました", err)
+		}
+		output.DownloadURL = &url
+	}
+
+	return output, nil
+}
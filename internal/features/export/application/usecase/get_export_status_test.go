@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func newCompletedExportJob(t *testing.T) *entity.ExportJob {
+	t.Helper()
+	job := entity.NewExportJob(entity.ExportFormatCSV, entity.ExportFilter{})
+	job.Status = entity.ExportStatusProcessing
+	require.NoError(t, job.Complete(job.ObjectKey(), 3), "テスト用ジョブの完了に失敗")
+	return job
+}
+
+func TestGetExportStatusUseCase_Execute_Completed(t *testing.T) {
+	job := newCompletedExportJob(t)
+	storage := &mockStorageClient{}
+	uc := NewGetExportStatusUseCase(&mockExportJobRepository{job: job}, storage, 15*time.Minute, getTestLogger())
+
+	output, err := uc.Execute(context.Background(), job.ID)
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, entity.ExportStatusCompleted, output.Status, "ステータスが一致しない")
+	require.NotNil(t, output.DownloadURL, "完了したジョブはダウンロードURLを返すべき")
+	require.Contains(t, *output.DownloadURL, job.ObjectKey(), "ダウンロードURLが出力ファイルを指していない")
+	require.Equal(t, 15*time.Minute, storage.expiry, "URLの有効期間が渡されていない")
+	require.NotNil(t, output.CompletedAt, "完了日時が設定されるべき")
+}
+
+func TestGetExportStatusUseCase_Execute_Pending(t *testing.T) {
+	job := entity.NewExportJob(entity.ExportFormatKML, entity.ExportFilter{})
+	uc := NewGetExportStatusUseCase(&mockExportJobRepository{job: job}, &mockStorageClient{}, time.Minute, getTestLogger())
+
+	output, err := uc.Execute(context.Background(), job.ID)
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, entity.ExportStatusPending, output.Status, "ステータスが一致しない")
+	require.Nil(t, output.DownloadURL, "未完了のジョブはダウンロードURLを返すべきでない")
+}
+
+func TestGetExportStatusUseCase_Execute_NotFound(t *testing.T) {
+	uc := NewGetExportStatusUseCase(&mockExportJobRepository{}, &mockStorageClient{}, time.Minute, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), uuid.New())
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusNotFound, errorStatus(err), "NotFoundエラーを期待")
+}
+
+func TestGetExportStatusUseCase_Execute_PresignError(t *testing.T) {
+	job := newCompletedExportJob(t)
+	storage := &mockStorageClient{presignErr: errors.New("presign error")}
+	uc := NewGetExportStatusUseCase(&mockExportJobRepository{job: job}, storage, time.Minute, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), job.ID)
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusInternalServerError, errorStatus(err), "InternalErrorを期待")
+}
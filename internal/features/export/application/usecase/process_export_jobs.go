@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/export/application/port"
+	"github.com/mktkhr/field-manager-api/internal/features/export/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/repository"
+	"github.com/mktkhr/field-manager-api/internal/features/export/internal/encoder"
+	"github.com/mktkhr/field-manager-api/internal/utils"
+)
+
+const (
+	// DefaultExportPageSize は1回の照会で取得する圃場数のデフォルト値
+	DefaultExportPageSize = 500
+
+	// DefaultExportJobLeaseDuration はジョブのリース期間のデフォルト値
+	// 処理中はハートビートでリースを延長するため、エクスポートにかかる時間より短くてよい
+	DefaultExportJobLeaseDuration = 2 * time.Minute
+
+	// DefaultExportJobMaxAttempts はリース期限切れによる再実行を含めたジョブの最大実行回数のデフォルト値
+	DefaultExportJobMaxAttempts int32 = 3
+
+	// heartbeatsPerLease はリース期間あたりのハートビート回数
+	// 一時的なDBエラーでハートビートが失敗しても、リース期間内に再試行できるようにする
+	heartbeatsPerLease = 3
+)
+
+// ProcessExportJobsInput はエクスポートジョブ処理の入力
+type ProcessExportJobsInput struct {
+	MaxJobs       int           // 1回の実行で処理する最大ジョブ数
+	PageSize      int           // 1回の照会で取得する圃場数
+	WorkerID      string        // リースを保持するワーカーのID
+	LeaseDuration time.Duration // ハートビートが途絶えてからジョブを回収するまでの期間(0=デフォルト値)
+	MaxAttempts   int32         // リース期限切れによる再実行を含めた最大実行回数(0=デフォルト値)
+}
+
+// ProcessExportJobsUseCase はエクスポートジョブ処理のユースケース
+// 圃場をページ単位で取得して一時ファイルへ逐次書き込み、完成したファイルをストレージへアップロードする
+type ProcessExportJobsUseCase struct {
+	exportJobRepo    repository.ExportJobRepository
+	exportFieldQuery query.ExportFieldQuery
+	storageClient    port.StorageClient
+	logger           *slog.Logger
+}
+
+// NewProcessExportJobsUseCase は新しいProcessExportJobsUseCaseを作成する
+func NewProcessExportJobsUseCase(
+	exportJobRepo repository.ExportJobRepository,
+	exportFieldQuery query.ExportFieldQuery,
+	storageClient port.StorageClient,
+	logger *slog.Logger,
+) *ProcessExportJobsUseCase {
+	return &ProcessExportJobsUseCase{
+		exportJobRepo:    exportJobRepo,
+		exportFieldQuery: exportFieldQuery,
+		storageClient:    storageClient,
+		logger:           logger,
+	}
+}
+
+// Execute は待機中のエクスポートジョブを順に処理する
+// 異常終了したワーカーのジョブを回収した後、待機中のジョブをリース付きで1件ずつ取得する。
+// 個々のジョブの失敗はジョブに記録し、処理を継続する
+func (uc *ProcessExportJobsUseCase) Execute(ctx context.Context, input ProcessExportJobsInput) error {
+	if input.MaxJobs <= 0 {
+		input.MaxJobs = 1
+	}
+	if input.PageSize <= 0 {
+		input.PageSize = DefaultExportPageSize
+	}
+	if input.LeaseDuration <= 0 {
+		input.LeaseDuration = DefaultExportJobLeaseDuration
+	}
+	if input.MaxAttempts <= 0 {
+		input.MaxAttempts = DefaultExportJobMaxAttempts
+	}
+
+	// ハートビートが途絶えたジョブを回収(回収に失敗しても待機中ジョブの処理は継続する)
+	recovered, err := uc.exportJobRepo.RecoverExpiredJobs(ctx, input.LeaseDuration, input.MaxAttempts)
+	if err != nil {
+		uc.logger.Warn("リース期限切れのエクスポートジョブの回収に失敗しました",
+			slog.String("error", err.Error()))
+	} else if recovered.Requeued > 0 || recovered.Failed > 0 {
+		uc.logger.Warn("リース期限切れのエクスポートジョブを回収しました",
+			slog.Int("requeued", recovered.Requeued),
+			slog.Int("failed", recovered.Failed))
+	}
+
+	for processed := 0; processed < input.MaxJobs; processed++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		job, err := uc.exportJobRepo.ClaimNextPending(ctx, input.WorkerID)
+		if err != nil {
+			return fmt.Errorf("エクスポートジョブの取得に失敗しました: %w", err)
+		}
+		if job == nil {
+			if processed == 0 {
+				uc.logger.Info("処理対象のエクスポートジョブがありません")
+			}
+			return nil
+		}
+
+		uc.processJob(ctx, job, input)
+	}
+	return nil
+}
+
+// processJob はリースを取得したジョブ1件を処理し、結果をジョブに記録する
+func (uc *ProcessExportJobsUseCase) processJob(ctx context.Context, job *entity.ExportJob, input ProcessExportJobsInput) {
+	uc.logger.Info("エクスポートジョブの処理を開始します",
+		slog.String("job_id", job.ID.String()),
+		slog.String("format", job.Format.String()),
+		slog.Int("attempts", int(job.Attempts)))
+
+	// リースを失った場合は処理を中断し、回収したワーカーに処理を任せる
+	exportCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stopHeartbeat := uc.keepAlive(exportCtx, cancel, job.ID, input)
+
+	total, err := uc.export(exportCtx, job, input.PageSize)
+	stopHeartbeat()
+
+	if errors.Is(context.Cause(exportCtx), entity.ErrJobLeaseLost) {
+		uc.logger.Warn("エクスポートジョブのリースを失ったため処理を中断しました",
+			slog.String("job_id", job.ID.String()))
+		return
+	}
+
+	if err != nil {
+		// シャットダウンで中断した場合は処理中のまま残し、リース期限切れ後に再実行させる
+		if ctx.Err() != nil {
+			uc.logger.Warn("シャットダウンのためエクスポートジョブの処理を中断しました。リース期限切れ後に再実行されます",
+				slog.String("job_id", job.ID.String()))
+			return
+		}
+
+		uc.logger.Error("エクスポートに失敗しました",
+			slog.String("job_id", job.ID.String()),
+			slog.String("error", err.Error()))
+
+		if updateErr := uc.exportJobRepo.MarkFailed(ctx, job.ID, input.WorkerID, err.Error()); updateErr != nil {
+			uc.logger.Error("ジョブの失敗への更新に失敗しました",
+				slog.String("job_id", job.ID.String()),
+				slog.String("error", updateErr.Error()))
+		}
+		return
+	}
+
+	if err := uc.exportJobRepo.MarkCompleted(ctx, job.ID, input.WorkerID, job.ObjectKey(), total); err != nil {
+		uc.logger.Error("ジョブの完了への更新に失敗しました",
+			slog.String("job_id", job.ID.String()),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	uc.logger.Info("エクスポートジョブの処理が完了しました",
+		slog.String("job_id", job.ID.String()),
+		slog.Int("total_records", int(total)))
+}
+
+// keepAlive は処理中のジョブのハートビートを定期的に更新する
+// リースを失った場合はentity.ErrJobLeaseLostを原因としてcancelを呼ぶ。返り値の関数で更新を停止する
+func (uc *ProcessExportJobsUseCase) keepAlive(ctx context.Context, cancel context.CancelCauseFunc, jobID uuid.UUID, input ProcessExportJobsInput) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(input.LeaseDuration / heartbeatsPerLease)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := uc.exportJobRepo.Heartbeat(ctx, jobID, input.WorkerID)
+				if errors.Is(err, entity.ErrJobLeaseLost) {
+					cancel(err)
+					return
+				}
+				if err != nil {
+					uc.logger.Warn("エクスポートジョブのハートビート更新に失敗しました",
+						slog.String("job_id", jobID.String()),
+						slog.String("error", err.Error()))
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// export は対象圃場をファイルに書き出してアップロードし、出力件数を返す
+func (uc *ProcessExportJobsUseCase) export(ctx context.Context, job *entity.ExportJob, pageSize int) (int32, error) {
+	file, err := os.CreateTemp("", "export-*"+job.Format.FileExtension())
+	if err != nil {
+		return 0, fmt.Errorf("一時ファイルの作成に失敗しました: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+		if err := os.Remove(file.Name()); err != nil {
+			uc.logger.Warn("一時ファイルの削除に失敗しました",
+				slog.String("path", file.Name()),
+				slog.String("error", err.Error()))
+		}
+	}()
+
+	enc, err := encoder.New(job.Format, file)
+	if err != nil {
+		return 0, fmt.Errorf("エンコーダーの作成に失敗しました: %w", err)
+	}
+	closed := false
+	defer func() {
+		// 書き込み途中で失敗した場合もエンコーダーの資源(GeoPackageのDB接続など)を解放する
+		if !closed {
+			_ = enc.Close()
+		}
+	}()
+
+	var total int32
+	var afterID *uuid.UUID
+	for {
+		fields, err := uc.exportFieldQuery.ListPage(ctx, job.Filter, afterID, utils.SafeIntToInt32(pageSize))
+		if err != nil {
+			return 0, fmt.Errorf("圃場の取得に失敗しました: %w", err)
+		}
+		for _, field := range fields {
+			if err := enc.Encode(field); err != nil {
+				return 0, fmt.Errorf("圃場%sの書き込みに失敗しました: %w", field.ID, err)
+			}
+		}
+		total += utils.SafeIntToInt32(len(fields))
+
+		if len(fields) < pageSize {
+			break
+		}
+		lastID := fields[len(fields)-1].ID
+		afterID = &lastID
+	}
+
+	closed = true
+	if err := enc.Close(); err != nil {
+		return 0, fmt.Errorf("ファイルの確定に失敗しました: %w", err)
+	}
+
+	// アップロードはファイル先頭から読み直す
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("一時ファイルのシークに失敗しました: %w", err)
+	}
+	if err := uc.storageClient.Upload(ctx, job.ObjectKey(), file, job.Format.ContentType()); err != nil {
+		return 0, fmt.Errorf("ファイルのアップロードに失敗しました: %w", err)
+	}
+	return total, nil
+}
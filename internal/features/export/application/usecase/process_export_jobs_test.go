@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/repository"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+// mockExportFieldQuery はExportFieldQueryのモック実装
+// 保持する圃場をID順とみなし、afterIDより後の圃場をlimit件ずつ返す
+type mockExportFieldQuery struct {
+	fields []*entity.ExportField
+	err    error
+
+	calls   int
+	filters []entity.ExportFilter
+}
+
+func (m *mockExportFieldQuery) ListPage(_ context.Context, filter entity.ExportFilter, afterID *uuid.UUID, limit int32) ([]*entity.ExportField, error) {
+	m.calls++
+	m.filters = append(m.filters, filter)
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	start := 0
+	if afterID != nil {
+		for i, f := range m.fields {
+			if f.ID == *afterID {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end := min(start+int(limit), len(m.fields))
+	return m.fields[start:end], nil
+}
+
+func newExportFields(t *testing.T, n int) []*entity.ExportField {
+	t.Helper()
+	fields := make([]*entity.ExportField, 0, n)
+	for i := 0; i < n; i++ {
+		polygon, err := geom.NewPolygon(geom.XY).SetCoords([][]geom.Coord{
+			{{137.0, 36.0}, {137.001, 36.0}, {137.001, 36.001}, {137.0, 36.001}, {137.0, 36.0}},
+		})
+		require.NoError(t, err, "テスト用ポリゴンの作成に失敗")
+		fields = append(fields, &entity.ExportField{ID: uuid.New(), Name: "圃場", CityCode: "163210", Geometry: polygon})
+	}
+	return fields
+}
+
+func TestProcessExportJobsUseCase_Execute_Success(t *testing.T) {
+	cityCode := "163210"
+	job := entity.NewExportJob(entity.ExportFormatCSV, entity.ExportFilter{CityCode: &cityCode})
+	repo := &mockExportJobRepository{pending: []*entity.ExportJob{job}}
+	fieldQuery := &mockExportFieldQuery{fields: newExportFields(t, 5)}
+	storage := &mockStorageClient{}
+	uc := NewProcessExportJobsUseCase(repo, fieldQuery, storage, getTestLogger())
+
+	err := uc.Execute(context.Background(), ProcessExportJobsInput{MaxJobs: 10, PageSize: 2, WorkerID: "worker-1"})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, 3, fieldQuery.calls, "ページ単位で取得されるべき")
+	require.Equal(t, []string{"worker-1", "worker-1"}, repo.claimWorkerIDs, "ワーカーIDでリースを取得すべき")
+	require.Equal(t, []string{"worker-1"}, repo.finishWorkerIDs, "リースを保持するワーカーIDで完了にすべき")
+	require.Equal(t, &cityCode, fieldQuery.filters[0].CityCode, "ジョブの絞り込み条件が渡されていない")
+
+	require.Equal(t, completedCall{s3Key: job.ObjectKey(), totalRecords: 5}, repo.completed[job.ID], "完了情報が一致しない")
+	require.Equal(t, "text/csv; charset=utf-8", storage.contentType, "Content-Typeが一致しない")
+
+	records, err := csv.NewReader(bytes.NewReader(storage.uploaded[job.ObjectKey()])).ReadAll()
+	require.NoError(t, err, "アップロードされたCSVが解析できるべき")
+	require.Len(t, records, 6, "ヘッダーと全圃場の行が出力されるべき")
+}
+
+func TestProcessExportJobsUseCase_Execute_NoJobs(t *testing.T) {
+	fieldQuery := &mockExportFieldQuery{}
+	uc := NewProcessExportJobsUseCase(&mockExportJobRepository{}, fieldQuery, &mockStorageClient{}, getTestLogger())
+
+	err := uc.Execute(context.Background(), ProcessExportJobsInput{MaxJobs: 10})
+
+	require.NoError(t, err, "ジョブがない場合もエラーなしで終了するべき")
+	require.Zero(t, fieldQuery.calls, "ジョブがない場合は圃場を取得すべきでない")
+}
+
+func TestProcessExportJobsUseCase_Execute_UploadError(t *testing.T) {
+	failing := entity.NewExportJob(entity.ExportFormatGeoJSON, entity.ExportFilter{})
+	next := entity.NewExportJob(entity.ExportFormatGeoJSON, entity.ExportFilter{})
+	repo := &mockExportJobRepository{pending: []*entity.ExportJob{failing, next}}
+	storage := &mockStorageClient{uploadErr: errors.New("s3 error")}
+	uc := NewProcessExportJobsUseCase(repo, &mockExportFieldQuery{fields: newExportFields(t, 1)}, storage, getTestLogger())
+
+	err := uc.Execute(context.Background(), ProcessExportJobsInput{MaxJobs: 10})
+
+	require.NoError(t, err, "個々のジョブの失敗はExecuteのエラーとすべきでない")
+	require.Contains(t, repo.failed[failing.ID], "s3 error", "失敗理由が記録されるべき")
+	require.Contains(t, repo.failed, next.ID, "後続のジョブも処理されるべき")
+	require.Empty(t, repo.completed, "失敗したジョブを完了にすべきでない")
+}
+
+func TestProcessExportJobsUseCase_Execute_MaxJobs(t *testing.T) {
+	jobs := []*entity.ExportJob{
+		entity.NewExportJob(entity.ExportFormatKML, entity.ExportFilter{}),
+		entity.NewExportJob(entity.ExportFormatKML, entity.ExportFilter{}),
+	}
+	repo := &mockExportJobRepository{pending: jobs}
+	uc := NewProcessExportJobsUseCase(repo, &mockExportFieldQuery{}, &mockStorageClient{}, getTestLogger())
+
+	err := uc.Execute(context.Background(), ProcessExportJobsInput{MaxJobs: 1})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Len(t, repo.completed, 1, "最大ジョブ数を超えて処理すべきでない")
+	require.Len(t, repo.pending, 1, "残りのジョブは待機中のままであるべき")
+}
+
+func TestProcessExportJobsUseCase_Execute_ClaimError(t *testing.T) {
+	uc := NewProcessExportJobsUseCase(&mockExportJobRepository{claimErr: errors.New("db error")}, &mockExportFieldQuery{}, &mockStorageClient{}, getTestLogger())
+
+	err := uc.Execute(context.Background(), ProcessExportJobsInput{MaxJobs: 1})
+
+	require.Error(t, err, "ジョブ取得の失敗はエラーを返すべき")
+}
+
+func TestProcessExportJobsUseCase_Execute_RecoversExpiredJobs(t *testing.T) {
+	repo := &mockExportJobRepository{recovered: &repository.RecoveredJobs{Requeued: 1, Failed: 1}}
+	uc := NewProcessExportJobsUseCase(repo, &mockExportFieldQuery{}, &mockStorageClient{}, getTestLogger())
+
+	err := uc.Execute(context.Background(), ProcessExportJobsInput{MaxJobs: 1})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, 1, repo.recoverCalls, "ジョブ取得前にリース期限切れジョブを回収すべき")
+	require.Equal(t, DefaultExportJobLeaseDuration, repo.leaseDuration, "リース期間のデフォルト値が使われるべき")
+	require.Equal(t, DefaultExportJobMaxAttempts, repo.maxAttempts, "最大実行回数のデフォルト値が使われるべき")
+}
+
+func TestProcessExportJobsUseCase_Execute_RecoverError(t *testing.T) {
+	job := entity.NewExportJob(entity.ExportFormatCSV, entity.ExportFilter{})
+	repo := &mockExportJobRepository{pending: []*entity.ExportJob{job}, recoverErr: errors.New("db error")}
+	uc := NewProcessExportJobsUseCase(repo, &mockExportFieldQuery{}, &mockStorageClient{}, getTestLogger())
+
+	err := uc.Execute(context.Background(), ProcessExportJobsInput{MaxJobs: 1})
+
+	require.NoError(t, err, "回収の失敗はExecuteのエラーとすべきでない")
+	require.Contains(t, repo.completed, job.ID, "回収に失敗しても待機中のジョブを処理すべき")
+}
+
+func TestProcessExportJobsUseCase_Execute_LeaseLost(t *testing.T) {
+	job := entity.NewExportJob(entity.ExportFormatCSV, entity.ExportFilter{})
+	repo := &mockExportJobRepository{pending: []*entity.ExportJob{job}, heartbeatErr: entity.ErrJobLeaseLost}
+	storage := &mockStorageClient{blockUpload: true}
+	uc := NewProcessExportJobsUseCase(repo, &mockExportFieldQuery{}, storage, getTestLogger())
+
+	err := uc.Execute(context.Background(), ProcessExportJobsInput{MaxJobs: 1, WorkerID: "worker-1", LeaseDuration: 30 * time.Millisecond})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Positive(t, repo.heartbeats, "処理中はハートビートを更新すべき")
+	require.Empty(t, repo.completed, "リースを失ったジョブを完了にすべきでない")
+	require.Empty(t, repo.failed, "リースを失ったジョブを失敗にすべきでない")
+}
+
+func TestProcessExportJobsUseCase_Execute_Shutdown(t *testing.T) {
+	job := entity.NewExportJob(entity.ExportFormatCSV, entity.ExportFilter{})
+	repo := &mockExportJobRepository{pending: []*entity.ExportJob{job}}
+	storage := &mockStorageClient{blockUpload: true}
+	uc := NewProcessExportJobsUseCase(repo, &mockExportFieldQuery{}, storage, getTestLogger())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	err := uc.Execute(ctx, ProcessExportJobsInput{MaxJobs: 1})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Empty(t, repo.failed, "シャットダウンで中断したジョブはリース期限切れ後に再実行させるため失敗にすべきでない")
+	require.Empty(t, repo.completed, "中断したジョブを完了にすべきでない")
+}
@@ -0,0 +1,107 @@
+// Package usecase はエクスポート機能のユースケースを提供する
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/repository"
+)
+
+// RequestExportInput はエクスポートリクエストの入力
+type RequestExportInput struct {
+	Format            string
+	CityCode          *string
+	SoilTypeSmallCode *string
+	SWLat             *float64
+	SWLng             *float64
+	NELat             *float64
+	NELng             *float64
+}
+
+// RequestExportOutput はエクスポートリクエストの出力
+type RequestExportOutput struct {
+	ExportJobID uuid.UUID
+}
+
+// RequestExportUseCase はエクスポートリクエストのユースケース
+// ジョブを登録するのみで、ファイルの生成はエクスポートワーカーが行う
+type RequestExportUseCase struct {
+	exportJobRepo repository.ExportJobRepository
+}
+
+// NewRequestExportUseCase は新しいRequestExportUseCaseを作成する
+func NewRequestExportUseCase(exportJobRepo repository.ExportJobRepository) *RequestExportUseCase {
+	return &RequestExportUseCase{
+		exportJobRepo: exportJobRepo,
+	}
+}
+
+// Execute はエクスポートリクエストを実行する
+func (uc *RequestExportUseCase) Execute(ctx context.Context, input RequestExportInput) (*RequestExportOutput, error) {
+	format, err := entity.ParseExportFormat(input.Format)
+	if err != nil {
+		return nil, apperror.BadRequestError("出力形式はgeojson, csv, kml, gpkgのいずれかを指定してください")
+	}
+
+	bbox, err := buildBoundingBox(input.SWLat, input.SWLng, input.NELat, input.NELng)
+	if err != nil {
+		return nil, err
+	}
+
+	job := entity.NewExportJob(format, entity.ExportFilter{
+		CityCode:          normalizeString(input.CityCode),
+		SoilTypeSmallCode: normalizeString(input.SoilTypeSmallCode),
+		BoundingBox:       bbox,
+	})
+	if err := uc.exportJobRepo.Create(ctx, job); err != nil {
+		return nil, apperror.InternalErrorWithCause("エクスポートジョブの作成に失敗しました", err)
+	}
+
+	return &RequestExportOutput{ExportJobID: job.ID}, nil
+}
+
+// buildBoundingBox はバウンディングボックスの入力を検証して変換する
+// 4項目全て未指定の場合はnilを返す
+func buildBoundingBox(swLat, swLng, neLat, neLng *float64) (*entity.BoundingBox, error) {
+	if swLat == nil && swLng == nil && neLat == nil && neLng == nil {
+		return nil, nil
+	}
+	if swLat == nil || swLng == nil || neLat == nil || neLng == nil {
+		return nil, apperror.BadRequestError("バウンディングボックスはswLat, swLng, neLat, neLngを全て指定してください")
+	}
+	if *swLat < -90 || *swLat > 90 || *neLat < -90 || *neLat > 90 {
+		return nil, apperror.BadRequestError("緯度は-90から90の範囲で指定してください")
+	}
+	if *swLng < -180 || *swLng > 180 || *neLng < -180 || *neLng > 180 {
+		return nil, apperror.BadRequestError("経度は-180から180の範囲で指定してください")
+	}
+	if *swLat > *neLat {
+		return nil, apperror.BadRequestError("南西端の緯度は北東端の緯度より小さくしてください")
+	}
+	if *swLng > *neLng {
+		return nil, apperror.BadRequestError("南西端の経度は北東端の経度より小さくしてください")
+	}
+
+	return &entity.BoundingBox{
+		SWLat: *swLat,
+		SWLng: *swLng,
+		NELat: *neLat,
+		NELng: *neLng,
+	}, nil
+}
+
+// normalizeString は前後の空白を除去し、空文字列の場合はnilを返す
+func normalizeString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/repository"
+	"github.com/stretchr/testify/require"
+)
+
+// mockExportJobRepository はExportJobRepositoryのモック実装
+type mockExportJobRepository struct {
+	job          *entity.ExportJob
+	pending      []*entity.ExportJob
+	createErr    error
+	findErr      error
+	claimErr     error
+	completeErr  error
+	heartbeatErr error
+	recoverErr   error
+	recovered    *repository.RecoveredJobs
+
+	// 呼び出し時の引数を記録
+	created         *entity.ExportJob
+	claimWorkerIDs  []string
+	completed       map[uuid.UUID]completedCall
+	failed          map[uuid.UUID]string
+	heartbeats      int
+	leaseDuration   time.Duration
+	maxAttempts     int32
+	recoverCalls    int
+	finishWorkerIDs []string
+}
+
+type completedCall struct {
+	s3Key        string
+	totalRecords int32
+}
+
+func (m *mockExportJobRepository) Create(_ context.Context, job *entity.ExportJob) error {
+	m.created = job
+	return m.createErr
+}
+
+func (m *mockExportJobRepository) FindByID(_ context.Context, _ uuid.UUID) (*entity.ExportJob, error) {
+	if m.findErr != nil {
+		return nil, m.findErr
+	}
+	return m.job, nil
+}
+
+func (m *mockExportJobRepository) ClaimNextPending(_ context.Context, workerID string) (*entity.ExportJob, error) {
+	m.claimWorkerIDs = append(m.claimWorkerIDs, workerID)
+	if m.claimErr != nil {
+		return nil, m.claimErr
+	}
+	if len(m.pending) == 0 {
+		return nil, nil
+	}
+	job := m.pending[0]
+	m.pending = m.pending[1:]
+	job.Status = entity.ExportStatusProcessing
+	job.WorkerID = workerID
+	job.Attempts++
+	return job, nil
+}
+
+func (m *mockExportJobRepository) Heartbeat(_ context.Context, _ uuid.UUID, _ string) error {
+	m.heartbeats++
+	return m.heartbeatErr
+}
+
+func (m *mockExportJobRepository) MarkCompleted(_ context.Context, id uuid.UUID, workerID string, s3Key string, totalRecords int32) error {
+	m.finishWorkerIDs = append(m.finishWorkerIDs, workerID)
+	if m.completed == nil {
+		m.completed = make(map[uuid.UUID]completedCall)
+	}
+	m.completed[id] = completedCall{s3Key: s3Key, totalRecords: totalRecords}
+	return m.completeErr
+}
+
+func (m *mockExportJobRepository) MarkFailed(_ context.Context, id uuid.UUID, workerID string, errorMessage string) error {
+	m.finishWorkerIDs = append(m.finishWorkerIDs, workerID)
+	if m.failed == nil {
+		m.failed = make(map[uuid.UUID]string)
+	}
+	m.failed[id] = errorMessage
+	return nil
+}
+
+func (m *mockExportJobRepository) RecoverExpiredJobs(_ context.Context, leaseDuration time.Duration, maxAttempts int32) (*repository.RecoveredJobs, error) {
+	m.recoverCalls++
+	m.leaseDuration = leaseDuration
+	m.maxAttempts = maxAttempts
+	if m.recoverErr != nil {
+		return nil, m.recoverErr
+	}
+	if m.recovered != nil {
+		return m.recovered, nil
+	}
+	return &repository.RecoveredJobs{}, nil
+}
+
+// mockStorageClient はStorageClientのモック実装
+type mockStorageClient struct {
+	uploadErr  error
+	presignErr error
+	// blockUpload がtrueの場合、Uploadはコンテキストがキャンセルされるまで待機する
+	blockUpload bool
+
+	uploaded    map[string][]byte
+	contentType string
+	expiry      time.Duration
+}
+
+func (m *mockStorageClient) Upload(ctx context.Context, key string, data io.Reader, contentType string) error {
+	if m.blockUpload {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	if m.uploadErr != nil {
+		return m.uploadErr
+	}
+	b, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	if m.uploaded == nil {
+		m.uploaded = make(map[string][]byte)
+	}
+	m.uploaded[key] = b
+	m.contentType = contentType
+	return nil
+}
+
+func (m *mockStorageClient) PresignGetURL(_ context.Context, key string, expiry time.Duration) (string, error) {
+	if m.presignErr != nil {
+		return "", m.presignErr
+	}
+	m.expiry = expiry
+	return "https://storage.example.com/" + key + "?X-Amz-Signature=test", nil
+}
+
+func getTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func stringPtr(s string) *string { return &s }
+
+// errorStatus はapperrorのHTTPステータスを返す
+func errorStatus(err error) int {
+	var appErr apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.HTTPStatus()
+	}
+	return 0
+}
+
+func TestRequestExportUseCase_Execute_Success(t *testing.T) {
+	repo := &mockExportJobRepository{}
+	uc := NewRequestExportUseCase(repo)
+
+	output, err := uc.Execute(context.Background(), RequestExportInput{
+		Format:            "gpkg",
+		CityCode:          stringPtr(" 163210 "),
+		SoilTypeSmallCode: stringPtr(""),
+		SWLat:             float64Ptr(36.0),
+		SWLng:             float64Ptr(137.0),
+		NELat:             float64Ptr(36.5),
+		NELng:             float64Ptr(137.5),
+	})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.NotNil(t, repo.created, "Createが呼ばれていない")
+	require.Equal(t, repo.created.ID, output.ExportJobID, "ジョブIDが一致しない")
+	require.Equal(t, entity.ExportFormatGeoPackage, repo.created.Format, "出力形式が一致しない")
+	require.Equal(t, entity.ExportStatusPending, repo.created.Status, "作成直後はpendingであるべき")
+
+	filter := repo.created.Filter
+	require.Equal(t, "163210", *filter.CityCode, "市区町村コードがトリムされていない")
+	require.Nil(t, filter.SoilTypeSmallCode, "空の土壌コードは条件に含めないべき")
+	require.Equal(t, &entity.BoundingBox{SWLat: 36.0, SWLng: 137.0, NELat: 36.5, NELng: 137.5}, filter.BoundingBox, "バウンディングボックスが一致しない")
+}
+
+func TestRequestExportUseCase_Execute_ValidationError(t *testing.T) {
+	tests := []struct {
+		name  string
+		input RequestExportInput
+	}{
+		{
+			name:  "未対応の出力形式",
+			input: RequestExportInput{Format: "shp"},
+		},
+		{
+			name:  "バウンディングボックスの一部のみ指定",
+			input: RequestExportInput{Format: "csv", SWLat: float64Ptr(36.0)},
+		},
+		{
+			name: "南西端が北東端より北",
+			input: RequestExportInput{
+				Format: "csv",
+				SWLat:  float64Ptr(37.0), SWLng: float64Ptr(137.0),
+				NELat: float64Ptr(36.0), NELng: float64Ptr(138.0),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockExportJobRepository{}
+			uc := NewRequestExportUseCase(repo)
+
+			_, err := uc.Execute(context.Background(), tt.input)
+
+			require.Error(t, err, "エラーを期待")
+			require.Equal(t, http.StatusBadRequest, errorStatus(err), "BadRequestエラーを期待")
+			require.Nil(t, repo.created, "バリデーションエラー時はCreateを呼ぶべきでない")
+		})
+	}
+}
+
+func TestRequestExportUseCase_Execute_RepositoryError(t *testing.T) {
+	uc := NewRequestExportUseCase(&mockExportJobRepository{createErr: errors.New("db error")})
+
+	_, err := uc.Execute(context.Background(), RequestExportInput{Format: "geojson"})
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusInternalServerError, errorStatus(err), "InternalErrorを期待")
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "errors"
+
+var (
+	// ErrInvalidExportFormat は未対応の出力形式エラー
+	ErrInvalidExportFormat = errors.New("invalid export format")
+
+	// ErrInvalidStatusTransition は無効なステータス遷移エラー
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+	// ErrJobLeaseLost はジョブのリースが他のワーカーに移ったか、期限切れで回収されたことを示す
+	ErrJobLeaseLost = errors.New("エクスポートジョブのリースが失われました")
+)
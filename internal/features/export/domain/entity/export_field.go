@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/twpayne/go-geom"
+)
+
+// ExportField はエクスポートする圃場1件分のデータ
+type ExportField struct {
+	ID             uuid.UUID
+	Name           string
+	CityCode       string
+	AreaSqm        *float64
+	Geometry       geom.T
+	SoilType       *ExportSoilType // 土壌タイプ(未設定の場合はnil)
+	LandRegistries []*ExportLandRegistry
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// ExportSoilType はエクスポートする土壌タイプ
+type ExportSoilType struct {
+	LargeCode  string
+	MiddleCode string
+	SmallCode  string
+	SmallName  string
+}
+
+// ExportLandRegistry はマスタ名称を解決した農地台帳
+type ExportLandRegistry struct {
+	FarmerNumber         *string    `json:"farmer_number"`
+	Address              *string    `json:"address"`
+	AreaSqm              *int32     `json:"area_sqm"`
+	LandCategoryCode     *string    `json:"land_category_code"`
+	LandCategoryName     *string    `json:"land_category_name"`
+	IdleLandStatusCode   *string    `json:"idle_land_status_code"`
+	IdleLandStatusName   *string    `json:"idle_land_status_name"`
+	DescriptiveStudyDate *time.Time `json:"descriptive_study_date"`
+}
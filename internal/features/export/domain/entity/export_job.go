@@ -0,0 +1,188 @@
+// Package entity はエクスポート機能のドメインエンティティを定義する
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportStatus はエクスポートジョブのステータスを表す
+type ExportStatus string
+
+const (
+	ExportStatusPending    ExportStatus = "pending"
+	ExportStatusProcessing ExportStatus = "processing"
+	ExportStatusCompleted  ExportStatus = "completed"
+	ExportStatusFailed     ExportStatus = "failed"
+)
+
+// IsValid はステータスが有効かどうかを判定する
+func (s ExportStatus) IsValid() bool {
+	switch s {
+	case ExportStatusPending, ExportStatusProcessing, ExportStatusCompleted, ExportStatusFailed:
+		return true
+	}
+	return false
+}
+
+// String はステータスを文字列として返す
+func (s ExportStatus) String() string {
+	return string(s)
+}
+
+// ExportFormat はエクスポートの出力形式を表す
+type ExportFormat string
+
+const (
+	ExportFormatGeoJSON    ExportFormat = "geojson"
+	ExportFormatCSV        ExportFormat = "csv"
+	ExportFormatKML        ExportFormat = "kml"
+	ExportFormatGeoPackage ExportFormat = "gpkg"
+)
+
+// ParseExportFormat は文字列から出力形式を取得する
+func ParseExportFormat(s string) (ExportFormat, error) {
+	format := ExportFormat(s)
+	if !format.IsValid() {
+		return "", fmt.Errorf("%w: %s", ErrInvalidExportFormat, s)
+	}
+	return format, nil
+}
+
+// IsValid は出力形式が有効かどうかを判定する
+func (f ExportFormat) IsValid() bool {
+	switch f {
+	case ExportFormatGeoJSON, ExportFormatCSV, ExportFormatKML, ExportFormatGeoPackage:
+		return true
+	}
+	return false
+}
+
+// String は出力形式を文字列として返す
+func (f ExportFormat) String() string {
+	return string(f)
+}
+
+// FileExtension は出力ファイルの拡張子を返す
+func (f ExportFormat) FileExtension() string {
+	switch f {
+	case ExportFormatGeoJSON:
+		return ".geojson"
+	case ExportFormatCSV:
+		return ".csv"
+	case ExportFormatKML:
+		return ".kml"
+	case ExportFormatGeoPackage:
+		return ".gpkg"
+	}
+	return ""
+}
+
+// ContentType は出力ファイルのMIMEタイプを返す
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportFormatGeoJSON:
+		return "application/geo+json"
+	case ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case ExportFormatKML:
+		return "application/vnd.google-earth.kml+xml"
+	case ExportFormatGeoPackage:
+		return "application/geopackage+sqlite3"
+	}
+	return "application/octet-stream"
+}
+
+// BoundingBox はエクスポート対象の空間範囲
+type BoundingBox struct {
+	SWLat float64 // 南西端の緯度
+	SWLng float64 // 南西端の経度
+	NELat float64 // 北東端の緯度
+	NELng float64 // 北東端の経度
+}
+
+// ExportFilter はエクスポート対象の絞り込み条件
+// nilの条件は絞り込みに使用しない
+type ExportFilter struct {
+	CityCode          *string      // 市区町村コード
+	SoilTypeSmallCode *string      // 土壌小分類コード
+	BoundingBox       *BoundingBox // 空間範囲
+}
+
+// ExportJob はエクスポートジョブエンティティ
+type ExportJob struct {
+	ID           uuid.UUID
+	Format       ExportFormat
+	Filter       ExportFilter
+	Status       ExportStatus
+	TotalRecords *int32
+	S3Key        *string
+	ErrorMessage *string
+	CreatedAt    time.Time
+	StartedAt    *time.Time
+	CompletedAt  *time.Time
+	WorkerID     string     // 処理中のワーカーID
+	HeartbeatAt  *time.Time // 処理中のワーカーの最終ハートビート日時
+	Attempts     int32      // 実行回数
+}
+
+// NewExportJob は新しいエクスポートジョブを作成する
+func NewExportJob(format ExportFormat, filter ExportFilter) *ExportJob {
+	return &ExportJob{
+		ID:        uuid.New(),
+		Format:    format,
+		Filter:    filter,
+		Status:    ExportStatusPending,
+		CreatedAt: time.Now(),
+	}
+}
+
+// ObjectKey は出力ファイルのストレージキーを返す
+func (j *ExportJob) ObjectKey() string {
+	return fmt.Sprintf("exports/%s/fields%s", j.ID.String(), j.Format.FileExtension())
+}
+
+// CanTransitionTo は指定のステータスに遷移可能かどうかを判定する
+func (j *ExportJob) CanTransitionTo(newStatus ExportStatus) bool {
+	switch j.Status {
+	case ExportStatusPending:
+		return newStatus == ExportStatusProcessing || newStatus == ExportStatusFailed
+	case ExportStatusProcessing:
+		return newStatus == ExportStatusCompleted || newStatus == ExportStatusFailed
+	case ExportStatusCompleted, ExportStatusFailed:
+		return false
+	}
+	return false
+}
+
+// Complete はジョブを完了状態にし、出力ファイルと件数を記録する
+func (j *ExportJob) Complete(s3Key string, totalRecords int32) error {
+	if !j.CanTransitionTo(ExportStatusCompleted) {
+		return ErrInvalidStatusTransition
+	}
+	now := time.Now()
+	j.Status = ExportStatusCompleted
+	j.S3Key = &s3Key
+	j.TotalRecords = &totalRecords
+	j.CompletedAt = &now
+	return nil
+}
+
+// Fail はジョブを失敗状態にする
+func (j *ExportJob) Fail(message string) error {
+	if !j.CanTransitionTo(ExportStatusFailed) {
+		return ErrInvalidStatusTransition
+	}
+	now := time.Now()
+	j.Status = ExportStatusFailed
+	j.ErrorMessage = &message
+	j.CompletedAt = &now
+	return nil
+}
+
+// IsDownloadable は出力ファイルをダウンロード可能かどうかを判定する
+func (j *ExportJob) IsDownloadable() bool {
+	return j.Status == ExportStatusCompleted && j.S3Key != nil
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestParseExportFormat は出力形式の文字列を検証できることをテストする
+func TestParseExportFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ExportFormat
+		wantErr bool
+	}{
+		{input: "geojson", want: ExportFormatGeoJSON},
+		{input: "csv", want: ExportFormatCSV},
+		{input: "kml", want: ExportFormatKML},
+		{input: "gpkg", want: ExportFormatGeoPackage},
+		{input: "shp", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseExportFormat(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidExportFormat) {
+					t.Errorf("ParseExportFormat(%q)のエラー = %v, 期待値 ErrInvalidExportFormat", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseExportFormat(%q)でエラー発生 = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseExportFormat(%q) = %s, 期待値 %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExportJobObjectKey は出力形式に応じた拡張子のキーを返すことをテストする
+func TestExportJobObjectKey(t *testing.T) {
+	job := NewExportJob(ExportFormatGeoPackage, ExportFilter{})
+	key := job.ObjectKey()
+	if !strings.HasPrefix(key, "exports/"+job.ID.String()+"/") {
+		t.Errorf("ObjectKey() = %s, ジョブIDを含むべき", key)
+	}
+	if !strings.HasSuffix(key, ".gpkg") {
+		t.Errorf("ObjectKey() = %s, 拡張子.gpkgで終わるべき", key)
+	}
+}
+
+// TestExportJobStatusTransition はステータス遷移の可否をテストする
+func TestExportJobStatusTransition(t *testing.T) {
+	job := NewExportJob(ExportFormatCSV, ExportFilter{})
+	if err := job.Complete("key", 1); !errors.Is(err, ErrInvalidStatusTransition) {
+		t.Errorf("pendingからcompletedへの遷移エラー = %v, 期待値 ErrInvalidStatusTransition", err)
+	}
+
+	job.Status = ExportStatusProcessing
+	if err := job.Complete("exports/a.csv", 10); err != nil {
+		t.Fatalf("Complete()でエラー発生 = %v", err)
+	}
+	if !job.IsDownloadable() {
+		t.Error("完了したジョブはダウンロード可能であるべき")
+	}
+	if job.TotalRecords == nil || *job.TotalRecords != 10 {
+		t.Errorf("TotalRecords = %v, 期待値 10", job.TotalRecords)
+	}
+	if err := job.Fail("error"); !errors.Is(err, ErrInvalidStatusTransition) {
+		t.Errorf("completedからfailedへの遷移エラー = %v, 期待値 ErrInvalidStatusTransition", err)
+	}
+}
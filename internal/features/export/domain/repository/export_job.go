@@ -0,0 +1,46 @@
+// Package repository はエクスポート機能のリポジトリインターフェースを定義する
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+)
+
+// ExportJobRepository はエクスポートジョブのリポジトリインターフェース
+type ExportJobRepository interface {
+	// Create はエクスポートジョブを作成する
+	Create(ctx context.Context, job *entity.ExportJob) error
+
+	// FindByID はIDでエクスポートジョブを取得する
+	// 存在しない場合はnil, nilを返す
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.ExportJob, error)
+
+	// ClaimNextPending は最も古い待機中のジョブを処理中にし、指定ワーカーのリースとして取得する
+	// 待機中のジョブがない場合はnil, nilを返す
+	ClaimNextPending(ctx context.Context, workerID string) (*entity.ExportJob, error)
+
+	// Heartbeat は処理中ジョブのハートビートを更新してリースを延長する
+	// 指定ワーカーがリースを保持していない場合はentity.ErrJobLeaseLostを返す
+	Heartbeat(ctx context.Context, id uuid.UUID, workerID string) error
+
+	// MarkCompleted は指定ワーカーが処理中のジョブを完了状態に更新する
+	// 指定ワーカーがリースを保持していない場合はentity.ErrJobLeaseLostを返す
+	MarkCompleted(ctx context.Context, id uuid.UUID, workerID string, s3Key string, totalRecords int32) error
+
+	// MarkFailed は指定ワーカーが処理中のジョブを失敗状態に更新する
+	// 指定ワーカーがリースを保持していない場合はentity.ErrJobLeaseLostを返す
+	MarkFailed(ctx context.Context, id uuid.UUID, workerID string, errorMessage string) error
+
+	// RecoverExpiredJobs はハートビートがleaseDurationを超えて途絶えた処理中ジョブを回収する
+	// 実行回数がmaxAttempts未満のジョブは待機中に戻し、それ以外は失敗にする
+	RecoverExpiredJobs(ctx context.Context, leaseDuration time.Duration, maxAttempts int32) (*RecoveredJobs, error)
+}
+
+// RecoveredJobs はリース期限切れジョブの回収結果
+type RecoveredJobs struct {
+	Requeued int // 待機中に戻したジョブ数
+	Failed   int // 最大実行回数に達したため失敗にしたジョブ数
+}
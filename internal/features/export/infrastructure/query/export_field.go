@@ -0,0 +1,129 @@
+// Package query はエクスポート対象データの照会実装を提供する
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	appQuery "github.com/mktkhr/field-manager-api/internal/features/export/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/twpayne/go-geom/encoding/wkb"
+)
+
+// exportFieldQuery はExportFieldQueryの実装
+type exportFieldQuery struct {
+	queries *sqlc.Queries
+}
+
+// NewExportFieldQuery は新しいExportFieldQueryを作成する
+func NewExportFieldQuery(db *pgxpool.Pool) appQuery.ExportFieldQuery {
+	return &exportFieldQuery{
+		queries: sqlc.New(db),
+	}
+}
+
+// ListPage は絞り込み条件に一致する圃場をID順に最大limit件取得する
+// 農地台帳はページ内の圃場分をまとめて取得する
+func (q *exportFieldQuery) ListPage(ctx context.Context, filter entity.ExportFilter, afterID *uuid.UUID, limit int32) ([]*entity.ExportField, error) {
+	params := &sqlc.ListFieldsForExportParams{
+		CityCode:      filter.CityCode,
+		SoilSmallCode: filter.SoilTypeSmallCode,
+		RowLimit:      limit,
+	}
+	if afterID != nil {
+		params.AfterID = uuid.NullUUID{UUID: *afterID, Valid: true}
+	}
+	if bbox := filter.BoundingBox; bbox != nil {
+		params.SwLat = &bbox.SWLat
+		params.SwLng = &bbox.SWLng
+		params.NeLat = &bbox.NELat
+		params.NeLng = &bbox.NELng
+	}
+
+	rows, err := q.queries.ListFieldsForExport(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("エクスポート対象圃場取得失敗: %w", err)
+	}
+	if len(rows) == 0 {
+		return []*entity.ExportField{}, nil
+	}
+
+	fields := make([]*entity.ExportField, 0, len(rows))
+	byID := make(map[uuid.UUID]*entity.ExportField, len(rows))
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		field, err := toExportField(row)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+		byID[field.ID] = field
+		ids = append(ids, field.ID)
+	}
+
+	registries, err := q.queries.ListFieldLandRegistriesForExport(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("エクスポート対象農地台帳取得失敗: %w", err)
+	}
+	for _, row := range registries {
+		if field, ok := byID[row.FieldID]; ok {
+			field.LandRegistries = append(field.LandRegistries, toExportLandRegistry(row))
+		}
+	}
+
+	return fields, nil
+}
+
+// toExportField はSQLCモデルをエクスポート用の圃場に変換する
+func toExportField(row *sqlc.ListFieldsForExportRow) (*entity.ExportField, error) {
+	field := &entity.ExportField{
+		ID:             row.ID,
+		Name:           row.Name,
+		CityCode:       row.CityCode,
+		AreaSqm:        row.AreaSqm,
+		LandRegistries: []*entity.ExportLandRegistry{},
+	}
+	if len(row.GeometryWkb) > 0 {
+		g, err := wkb.Unmarshal(row.GeometryWkb)
+		if err != nil {
+			return nil, fmt.Errorf("圃場%sのジオメトリのデコードに失敗しました: %w", row.ID, err)
+		}
+		field.Geometry = g
+	}
+	if row.SoilLargeCode != nil && row.SoilMiddleCode != nil && row.SoilSmallCode != nil && row.SoilSmallName != nil {
+		field.SoilType = &entity.ExportSoilType{
+			LargeCode:  *row.SoilLargeCode,
+			MiddleCode: *row.SoilMiddleCode,
+			SmallCode:  *row.SoilSmallCode,
+			SmallName:  *row.SoilSmallName,
+		}
+	}
+	if row.CreatedAt.Valid {
+		field.CreatedAt = row.CreatedAt.Time
+	}
+	if row.UpdatedAt.Valid {
+		field.UpdatedAt = row.UpdatedAt.Time
+	}
+	return field, nil
+}
+
+// toExportLandRegistry はSQLCモデルをエクスポート用の農地台帳に変換する
+func toExportLandRegistry(row *sqlc.ListFieldLandRegistriesForExportRow) *entity.ExportLandRegistry {
+	registry := &entity.ExportLandRegistry{
+		FarmerNumber:       row.FarmerNumber,
+		Address:            row.Address,
+		AreaSqm:            row.AreaSqm,
+		LandCategoryCode:   row.LandCategoryCode,
+		LandCategoryName:   row.LandCategoryName,
+		IdleLandStatusCode: row.IdleLandStatusCode,
+		IdleLandStatusName: row.IdleLandStatusName,
+	}
+	if row.DescriptiveStudyData.Valid {
+		t := row.DescriptiveStudyData.Time
+		registry.DescriptiveStudyDate = &t
+	}
+	return registry
+}
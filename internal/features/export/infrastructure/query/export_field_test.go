@@ -0,0 +1,66 @@
+package query
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/wkb"
+)
+
+func TestToExportField(t *testing.T) {
+	polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{137.0, 36.0}, {137.1, 36.0}, {137.1, 36.1}, {137.0, 36.1}, {137.0, 36.0}},
+	})
+	geometryWKB, err := wkb.Marshal(polygon, binary.LittleEndian)
+	require.NoError(t, err, "WKBのエンコードに失敗")
+
+	large, middle, small, name := "A", "A1", "A1a", "褐色低地土"
+	now := time.Now()
+	row := &sqlc.ListFieldsForExportRow{
+		ID:             uuid.New(),
+		GeometryWkb:    geometryWKB,
+		CityCode:       "163210",
+		Name:           "北圃場",
+		SoilLargeCode:  &large,
+		SoilMiddleCode: &middle,
+		SoilSmallCode:  &small,
+		SoilSmallName:  &name,
+		CreatedAt:      pgtype.Timestamptz{Time: now, Valid: true},
+	}
+
+	field, err := toExportField(row)
+
+	require.NoError(t, err, "toExportFieldでエラーが発生")
+	require.Equal(t, row.ID, field.ID, "IDが一致しない")
+	require.Equal(t, polygon.FlatCoords(), field.Geometry.FlatCoords(), "ジオメトリが一致しない")
+	require.NotNil(t, field.SoilType, "土壌タイプが設定されるべき")
+	require.Equal(t, "A1a", field.SoilType.SmallCode, "土壌小分類コードが一致しない")
+	require.Equal(t, now, field.CreatedAt, "作成日時が一致しない")
+	require.NotNil(t, field.LandRegistries, "農地台帳は空のスライスで初期化されるべき")
+
+	row.SoilLargeCode = nil
+	row.GeometryWkb = []byte{0x01}
+	_, err = toExportField(row)
+	require.Error(t, err, "不正なWKBはエラーになるべき")
+}
+
+func TestToExportLandRegistry(t *testing.T) {
+	date := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	farmer := "F001"
+	row := &sqlc.ListFieldLandRegistriesForExportRow{
+		FieldID:              uuid.New(),
+		FarmerNumber:         &farmer,
+		DescriptiveStudyData: pgtype.Date{Time: date, Valid: true},
+	}
+
+	registry := toExportLandRegistry(row)
+
+	require.Equal(t, &farmer, registry.FarmerNumber, "農家番号が一致しない")
+	require.Equal(t, &date, registry.DescriptiveStudyDate, "調査日が一致しない")
+}
@@ -0,0 +1,207 @@
+// Package repository はエクスポート機能のリポジトリ実装を提供する
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/repository"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/mktkhr/field-manager-api/internal/utils"
+)
+
+// exportJobRepository はExportJobRepositoryの実装
+type exportJobRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewExportJobRepository は新しいExportJobRepositoryを作成する
+func NewExportJobRepository(db *pgxpool.Pool) repository.ExportJobRepository {
+	return &exportJobRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+// Create はエクスポートジョブを作成する
+// IDと作成日時はDBで採番した値に置き換える
+func (r *exportJobRepository) Create(ctx context.Context, job *entity.ExportJob) error {
+	params := &sqlc.CreateExportJobParams{
+		Format:        job.Format.String(),
+		CityCode:      job.Filter.CityCode,
+		SoilSmallCode: job.Filter.SoilTypeSmallCode,
+	}
+	if bbox := job.Filter.BoundingBox; bbox != nil {
+		params.SwLat = &bbox.SWLat
+		params.SwLng = &bbox.SWLng
+		params.NeLat = &bbox.NELat
+		params.NeLng = &bbox.NELng
+	}
+
+	row, err := r.queries.CreateExportJob(ctx, params)
+	if err != nil {
+		return err
+	}
+	job.ID = row.ID
+	job.Status = entity.ExportStatus(row.Status)
+	if row.CreatedAt.Valid {
+		job.CreatedAt = row.CreatedAt.Time
+	}
+	return nil
+}
+
+// FindByID はIDでエクスポートジョブを取得する
+// ジョブが存在しない場合はnilを返す
+func (r *exportJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.ExportJob, error) {
+	row, err := r.queries.GetExportJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toEntity(row), nil
+}
+
+// ClaimNextPending は最も古い待機中のジョブを処理中にし、指定ワーカーのリースとして取得する
+// 待機中のジョブがない場合はnilを返す
+func (r *exportJobRepository) ClaimNextPending(ctx context.Context, workerID string) (*entity.ExportJob, error) {
+	row, err := r.queries.ClaimPendingExportJob(ctx, &workerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toEntity(row), nil
+}
+
+// Heartbeat は処理中ジョブのハートビートを更新してリースを延長する
+func (r *exportJobRepository) Heartbeat(ctx context.Context, id uuid.UUID, workerID string) error {
+	rows, err := r.queries.HeartbeatExportJob(ctx, &sqlc.HeartbeatExportJobParams{
+		ID:       id,
+		WorkerID: &workerID,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entity.ErrJobLeaseLost
+	}
+	return nil
+}
+
+// MarkCompleted は指定ワーカーが処理中のジョブを完了状態に更新する
+func (r *exportJobRepository) MarkCompleted(ctx context.Context, id uuid.UUID, workerID string, s3Key string, totalRecords int32) error {
+	rows, err := r.queries.UpdateExportJobToCompleted(ctx, &sqlc.UpdateExportJobToCompletedParams{
+		ID:           id,
+		S3Key:        &s3Key,
+		TotalRecords: &totalRecords,
+		WorkerID:     &workerID,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entity.ErrJobLeaseLost
+	}
+	return nil
+}
+
+// MarkFailed は指定ワーカーが処理中のジョブを失敗状態に更新する
+func (r *exportJobRepository) MarkFailed(ctx context.Context, id uuid.UUID, workerID string, errorMessage string) error {
+	rows, err := r.queries.UpdateExportJobToFailed(ctx, &sqlc.UpdateExportJobToFailedParams{
+		ID:           id,
+		ErrorMessage: &errorMessage,
+		WorkerID:     &workerID,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entity.ErrJobLeaseLost
+	}
+	return nil
+}
+
+// RecoverExpiredJobs はハートビートが途絶えた処理中ジョブを回収する
+// 回収後は元のワーカーのリースが解放されるため、遅れて戻ってきた元のワーカーの完了・失敗の更新はリースを失ったものとして扱われる
+func (r *exportJobRepository) RecoverExpiredJobs(ctx context.Context, leaseDuration time.Duration, maxAttempts int32) (*repository.RecoveredJobs, error) {
+	leaseSeconds := utils.SafeIntToInt32(int(leaseDuration.Seconds()))
+
+	errorMessage := fmt.Sprintf("ワーカーのリースが期限切れになり、最大実行回数(%d)に達しました", maxAttempts)
+	failed, err := r.queries.FailExpiredExportJobs(ctx, &sqlc.FailExpiredExportJobsParams{
+		ErrorMessage: &errorMessage,
+		LeaseSeconds: leaseSeconds,
+		MaxAttempts:  maxAttempts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("リース期限切れジョブの失敗への更新に失敗しました: %w", err)
+	}
+
+	requeued, err := r.queries.RequeueExpiredExportJobs(ctx, &sqlc.RequeueExpiredExportJobsParams{
+		LeaseSeconds: leaseSeconds,
+		MaxAttempts:  maxAttempts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("リース期限切れジョブの再エンキューに失敗しました: %w", err)
+	}
+
+	return &repository.RecoveredJobs{
+		Requeued: int(requeued),
+		Failed:   int(failed),
+	}, nil
+}
+
+// toEntity はSQLCモデルをエンティティに変換する
+func toEntity(row *sqlc.ExportJob) *entity.ExportJob {
+	if row == nil {
+		return nil
+	}
+
+	job := &entity.ExportJob{
+		ID:     row.ID,
+		Format: entity.ExportFormat(row.Format),
+		Filter: entity.ExportFilter{
+			CityCode:          row.CityCode,
+			SoilTypeSmallCode: row.SoilSmallCode,
+		},
+		Status:       entity.ExportStatus(row.Status),
+		TotalRecords: row.TotalRecords,
+		S3Key:        row.S3Key,
+		ErrorMessage: row.ErrorMessage,
+		Attempts:     row.Attempts,
+	}
+	if row.WorkerID != nil {
+		job.WorkerID = *row.WorkerID
+	}
+	if row.SwLat != nil && row.SwLng != nil && row.NeLat != nil && row.NeLng != nil {
+		job.Filter.BoundingBox = &entity.BoundingBox{
+			SWLat: *row.SwLat,
+			SWLng: *row.SwLng,
+			NELat: *row.NeLat,
+			NELng: *row.NeLng,
+		}
+	}
+	if row.CreatedAt.Valid {
+		job.CreatedAt = row.CreatedAt.Time
+	}
+	if row.StartedAt.Valid {
+		t := row.StartedAt.Time
+		job.StartedAt = &t
+	}
+	if row.CompletedAt.Valid {
+		t := row.CompletedAt.Time
+		job.CompletedAt = &t
+	}
+	if row.HeartbeatAt.Valid {
+		t := row.HeartbeatAt.Time
+		job.HeartbeatAt = &t
+	}
+	return job
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestToEntity はtoEntityがsqlc.ExportJobをエンティティに正しく変換することをテストする
+func TestToEntity(t *testing.T) {
+	now := time.Now()
+	cityCode := "163210"
+	swLat, swLng, neLat, neLng := 36.0, 137.0, 36.5, 137.5
+	total := int32(42)
+	s3Key := "exports/a/fields.gpkg"
+	workerID := "worker-1"
+
+	tests := []struct {
+		name string
+		row  *sqlc.ExportJob
+		want *entity.ExportJob
+	}{
+		{
+			name: "nil row",
+			row:  nil,
+			want: nil,
+		},
+		{
+			name: "pending job without filter",
+			row: &sqlc.ExportJob{
+				ID:        uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+				Format:    "csv",
+				Status:    "pending",
+				CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+			},
+			want: &entity.ExportJob{
+				ID:        uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+				Format:    entity.ExportFormatCSV,
+				Status:    entity.ExportStatusPending,
+				CreatedAt: now,
+			},
+		},
+		{
+			name: "completed job with filter",
+			row: &sqlc.ExportJob{
+				ID:           uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+				Format:       "gpkg",
+				Status:       "completed",
+				CityCode:     &cityCode,
+				SwLat:        &swLat,
+				SwLng:        &swLng,
+				NeLat:        &neLat,
+				NeLng:        &neLng,
+				TotalRecords: &total,
+				S3Key:        &s3Key,
+				CreatedAt:    pgtype.Timestamptz{Time: now, Valid: true},
+				StartedAt:    pgtype.Timestamptz{Time: now, Valid: true},
+				CompletedAt:  pgtype.Timestamptz{Time: now, Valid: true},
+			},
+			want: &entity.ExportJob{
+				ID:     uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+				Format: entity.ExportFormatGeoPackage,
+				Status: entity.ExportStatusCompleted,
+				Filter: entity.ExportFilter{
+					CityCode:    &cityCode,
+					BoundingBox: &entity.BoundingBox{SWLat: swLat, SWLng: swLng, NELat: neLat, NELng: neLng},
+				},
+				TotalRecords: &total,
+				S3Key:        &s3Key,
+				CreatedAt:    now,
+				StartedAt:    &now,
+				CompletedAt:  &now,
+			},
+		},
+		{
+			name: "processing job with lease",
+			row: &sqlc.ExportJob{
+				ID:          uuid.MustParse("33333333-3333-3333-3333-333333333333"),
+				Format:      "kml",
+				Status:      "processing",
+				CreatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+				StartedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+				WorkerID:    &workerID,
+				HeartbeatAt: pgtype.Timestamptz{Time: now, Valid: true},
+				Attempts:    2,
+			},
+			want: &entity.ExportJob{
+				ID:          uuid.MustParse("33333333-3333-3333-3333-333333333333"),
+				Format:      entity.ExportFormatKML,
+				Status:      entity.ExportStatusProcessing,
+				CreatedAt:   now,
+				StartedAt:   &now,
+				WorkerID:    workerID,
+				HeartbeatAt: &now,
+				Attempts:    2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := toEntity(tt.row)
+			require.Equal(t, tt.want, got, "変換結果が期待値と異なります")
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package encoder
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/twpayne/go-geom/encoding/wkt"
+)
+
+// csvGeometryColumn はCSVのジオメトリ列名
+const csvGeometryColumn = "geometry_wkt"
+
+// csvEncoder はジオメトリをWKT列として持つCSVを逐次書き込むエンコーダー
+type csvEncoder struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+// Encode は圃場をCSVの1行として書き込む
+func (e *csvEncoder) Encode(field *entity.ExportField) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	values, err := attributes(field)
+	if err != nil {
+		return err
+	}
+	record := make([]string, 0, len(values)+1)
+	for _, v := range values {
+		record = append(record, formatValue(v))
+	}
+
+	geometryWKT := ""
+	if field.Geometry != nil {
+		geometryWKT, err = wkt.Marshal(field.Geometry)
+		if err != nil {
+			return fmt.Errorf("WKTへの変換に失敗しました: %w", err)
+		}
+	}
+	record = append(record, geometryWKT)
+
+	if err := e.w.Write(record); err != nil {
+		return fmt.Errorf("CSVの書き込みに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Close はバッファを書き出す
+// 0件の場合もヘッダー行は出力する
+func (e *csvEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	if err := e.w.Error(); err != nil {
+		return fmt.Errorf("CSVの書き込みに失敗しました: %w", err)
+	}
+	return nil
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	header := append(append([]string{}, attributeColumns...), csvGeometryColumn)
+	if err := e.w.Write(header); err != nil {
+		return fmt.Errorf("CSVヘッダーの書き込みに失敗しました: %w", err)
+	}
+	e.headerWritten = true
+	return nil
+}
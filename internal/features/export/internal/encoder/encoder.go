@@ -0,0 +1,111 @@
+// Package encoder はエクスポート対象の圃場を各出力形式でストリーミング書き込みする
+// このパッケージはexport機能内に閉じており、他の機能からは使用しない
+package encoder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+)
+
+// timeFormat は日時属性の出力形式
+const timeFormat = "2006-01-02T15:04:05Z07:00"
+
+// Encoder は圃場を1件ずつ書き込むエンコーダー
+// 全件書き込み後に必ずCloseを呼び、フッターや索引を確定させる
+type Encoder interface {
+	// Encode は圃場1件を書き込む
+	Encode(field *entity.ExportField) error
+
+	// Close は出力を確定させる
+	// 出力先のクローズは呼び出し側の責務とする
+	Close() error
+}
+
+// New は出力形式に応じたエンコーダーを作成する
+// GeoPackageはSQLiteデータベースとしてファイルに直接書き込むため、出力先には空のファイルを渡す
+func New(format entity.ExportFormat, file *os.File) (Encoder, error) {
+	switch format {
+	case entity.ExportFormatGeoJSON:
+		return newGeoJSONEncoder(file), nil
+	case entity.ExportFormatCSV:
+		return newCSVEncoder(file), nil
+	case entity.ExportFormatKML:
+		return newKMLEncoder(file), nil
+	case entity.ExportFormatGeoPackage:
+		return newGeoPackageEncoder(file.Name())
+	}
+	return nil, fmt.Errorf("%w: %s", entity.ErrInvalidExportFormat, format)
+}
+
+// attributeColumns は全形式で共通の属性列名(出力順)
+var attributeColumns = []string{
+	"id",
+	"name",
+	"city_code",
+	"area_sqm",
+	"soil_large_code",
+	"soil_middle_code",
+	"soil_small_code",
+	"soil_small_name",
+	"land_registries",
+	"created_at",
+	"updated_at",
+}
+
+// attributes は圃場の属性値をattributeColumnsの順に返す
+// 値はnil・string・float64・json.RawMessage(農地台帳)のいずれか
+func attributes(field *entity.ExportField) ([]any, error) {
+	registries := field.LandRegistries
+	if registries == nil {
+		registries = []*entity.ExportLandRegistry{}
+	}
+	registriesJSON, err := json.Marshal(registries)
+	if err != nil {
+		return nil, fmt.Errorf("農地台帳のJSON変換に失敗しました: %w", err)
+	}
+
+	var areaSqm any
+	if field.AreaSqm != nil {
+		areaSqm = *field.AreaSqm
+	}
+	var soilLarge, soilMiddle, soilSmall, soilName any
+	if field.SoilType != nil {
+		soilLarge = field.SoilType.LargeCode
+		soilMiddle = field.SoilType.MiddleCode
+		soilSmall = field.SoilType.SmallCode
+		soilName = field.SoilType.SmallName
+	}
+
+	return []any{
+		field.ID.String(),
+		field.Name,
+		field.CityCode,
+		areaSqm,
+		soilLarge,
+		soilMiddle,
+		soilSmall,
+		soilName,
+		json.RawMessage(registriesJSON),
+		field.CreatedAt.Format(timeFormat),
+		field.UpdatedAt.Format(timeFormat),
+	}, nil
+}
+
+// formatValue は属性値をテキスト形式の出力用に文字列化する
+func formatValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case json.RawMessage:
+		return string(val)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,241 @@
+package encoder
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+// testField はテスト用の圃場を作成する
+// verticesを増やすとジオメトリのバイト数が大きくなる
+func testField(t *testing.T, vertices int) *entity.ExportField {
+	t.Helper()
+	ring := make([]geom.Coord, 0, vertices+1)
+	for i := 0; i < vertices; i++ {
+		// 反時計回りの円周上の点
+		angle := 2 * math.Pi * float64(i) / float64(vertices)
+		ring = append(ring, geom.Coord{137.0 + 0.001*math.Cos(angle), 36.0 + 0.001*math.Sin(angle)})
+	}
+	ring = append(ring, ring[0])
+	polygon, err := geom.NewPolygon(geom.XY).SetCoords([][]geom.Coord{ring})
+	require.NoError(t, err, "テスト用ポリゴンの作成に失敗")
+
+	area := 1234.5
+	farmer := "F001"
+	category := "田"
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	return &entity.ExportField{
+		ID:       uuid.New(),
+		Name:     `北圃場 <A&B> "1"`,
+		CityCode: "163210",
+		AreaSqm:  &area,
+		Geometry: polygon,
+		SoilType: &entity.ExportSoilType{LargeCode: "A", MiddleCode: "A1", SmallCode: "A1a", SmallName: "褐色低地土"},
+		LandRegistries: []*entity.ExportLandRegistry{
+			{FarmerNumber: &farmer, LandCategoryName: &category},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// tempFile はテスト用の一時ファイルを作成する
+func tempFile(t *testing.T, name string) *os.File {
+	t.Helper()
+	f, err := os.Create(filepath.Join(t.TempDir(), name))
+	require.NoError(t, err, "一時ファイルの作成に失敗")
+	t.Cleanup(func() { _ = f.Close() })
+	return f
+}
+
+// encodeAll は圃場をすべて書き込み、出力ファイルの内容を返す
+func encodeAll(t *testing.T, format entity.ExportFormat, fields ...*entity.ExportField) (string, []byte) {
+	t.Helper()
+	f := tempFile(t, "out"+format.FileExtension())
+	enc, err := New(format, f)
+	require.NoError(t, err, "エンコーダーの作成に失敗")
+	for _, field := range fields {
+		require.NoError(t, enc.Encode(field), "Encodeでエラーが発生")
+	}
+	require.NoError(t, enc.Close(), "Closeでエラーが発生")
+
+	data, err := os.ReadFile(f.Name())
+	require.NoError(t, err, "出力ファイルの読み込みに失敗")
+	return f.Name(), data
+}
+
+func TestNew_InvalidFormat(t *testing.T) {
+	_, err := New(entity.ExportFormat("shp"), tempFile(t, "out"))
+	require.ErrorIs(t, err, entity.ErrInvalidExportFormat, "未対応の形式はエラーになるべき")
+}
+
+func TestGeoJSONEncoder(t *testing.T) {
+	field := testField(t, 4)
+	_, data := encodeAll(t, entity.ExportFormatGeoJSON, field, testField(t, 5))
+
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			ID         string         `json:"id"`
+			Geometry   map[string]any `json:"geometry"`
+			Properties map[string]any `json:"properties"`
+		} `json:"features"`
+	}
+	require.NoError(t, json.Unmarshal(data, &collection), "GeoJSONとして解析できるべき")
+	require.Equal(t, "FeatureCollection", collection.Type)
+	require.Len(t, collection.Features, 2, "Feature数が一致しない")
+
+	feature := collection.Features[0]
+	require.Equal(t, field.ID.String(), feature.ID, "IDが一致しない")
+	require.Equal(t, "Polygon", feature.Geometry["type"], "ジオメトリ型が一致しない")
+	require.Equal(t, "A1a", feature.Properties["soil_small_code"], "土壌小分類コードが一致しない")
+	registries, ok := feature.Properties["land_registries"].([]any)
+	require.True(t, ok, "農地台帳は配列として出力されるべき")
+	require.Len(t, registries, 1, "農地台帳の件数が一致しない")
+}
+
+func TestGeoJSONEncoder_Empty(t *testing.T) {
+	_, data := encodeAll(t, entity.ExportFormatGeoJSON)
+
+	var collection map[string]any
+	require.NoError(t, json.Unmarshal(data, &collection), "0件でもGeoJSONとして解析できるべき")
+	require.Empty(t, collection["features"], "Featureは空であるべき")
+}
+
+func TestCSVEncoder(t *testing.T) {
+	field := testField(t, 4)
+	_, data := encodeAll(t, entity.ExportFormatCSV, field)
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(t, err, "CSVとして解析できるべき")
+	require.Len(t, records, 2, "ヘッダーと1行を期待")
+
+	header := records[0]
+	row := records[1]
+	require.Equal(t, csvGeometryColumn, header[len(header)-1], "最後の列はWKTであるべき")
+	require.True(t, strings.HasPrefix(row[len(row)-1], "POLYGON (("), "ジオメトリがWKTで出力されるべき")
+	require.Equal(t, field.Name, row[1], "圃場名がエスケープされて出力されるべき")
+	require.Equal(t, "1234.5", row[3], "面積が一致しない")
+	require.JSONEq(t, `[{"farmer_number":"F001","address":null,"area_sqm":null,"land_category_code":null,"land_category_name":"田","idle_land_status_code":null,"idle_land_status_name":null,"descriptive_study_date":null}]`, row[8], "農地台帳がJSONで出力されるべき")
+}
+
+func TestCSVEncoder_Empty(t *testing.T) {
+	_, data := encodeAll(t, entity.ExportFormatCSV)
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(t, err, "CSVとして解析できるべき")
+	require.Len(t, records, 1, "0件でもヘッダー行は出力されるべき")
+}
+
+func TestKMLEncoder(t *testing.T) {
+	field := testField(t, 4)
+	_, data := encodeAll(t, entity.ExportFormatKML, field)
+
+	var doc struct {
+		Placemarks []struct {
+			Name string `xml:"name"`
+			Data []struct {
+				Name  string `xml:"name,attr"`
+				Value string `xml:"value"`
+			} `xml:"ExtendedData>Data"`
+			Outer string `xml:"Polygon>outerBoundaryIs>LinearRing>coordinates"`
+		} `xml:"Document>Placemark"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &doc), "KMLとして解析できるべき")
+	require.Len(t, doc.Placemarks, 1, "Placemark数が一致しない")
+
+	placemark := doc.Placemarks[0]
+	require.Equal(t, field.Name, placemark.Name, "圃場名が一致しない")
+	require.Len(t, placemark.Data, len(attributeColumns), "属性数が一致しない")
+	require.Len(t, strings.Fields(placemark.Outer), 5, "外周の座標数が一致しない")
+}
+
+// openGeoPackage は出力されたGeoPackageをSQLiteデータベースとして開く
+func openGeoPackage(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err, "GeoPackageを開けない")
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// queryString はクエリ結果の1行目を列ごとに"|"で連結して返す
+func queryString(t *testing.T, db *sql.DB, query string) string {
+	t.Helper()
+	rows, err := db.Query(query)
+	require.NoError(t, err, "クエリの実行に失敗: %s", query)
+	defer func() { _ = rows.Close() }()
+
+	columns, err := rows.Columns()
+	require.NoError(t, err, "列の取得に失敗")
+	require.True(t, rows.Next(), "結果がない: %s", query)
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	require.NoError(t, rows.Scan(dest...), "結果の読み込みに失敗")
+
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, v.String)
+	}
+	return strings.Join(parts, "|")
+}
+
+func TestGeoPackageEncoder(t *testing.T) {
+	// 複数階層のB-tree・オーバーフローページ・複数ページの索引が必要になる件数・サイズで書き込む
+	fields := make([]*entity.ExportField, 0, 3000)
+	for i := 0; i < 2999; i++ {
+		fields = append(fields, testField(t, 16))
+	}
+	fields = append(fields, testField(t, 2000))
+	path, data := encodeAll(t, entity.ExportFormatGeoPackage, fields...)
+
+	require.Equal(t, "SQLite format 3\x00", string(data[:16]), "SQLiteヘッダーが一致しない")
+	require.Equal(t, []byte("GPKG"), data[68:72], "アプリケーションIDが一致しない")
+
+	db := openGeoPackage(t, path)
+	require.Equal(t, "ok", queryString(t, db, "PRAGMA integrity_check"), "整合性チェックに失敗")
+	require.Equal(t, "10200", queryString(t, db, "PRAGMA user_version"), "ユーザーバージョンが一致しない")
+	require.Equal(t, "3000", queryString(t, db, "SELECT count(*) FROM fields"), "行数が一致しない")
+	require.Equal(t, fields[2999].ID.String(), queryString(t, db, "SELECT id FROM fields WHERE fid = 3000"), "最終行のIDが一致しない")
+	require.Equal(t, "GP", queryString(t, db, "SELECT substr(CAST(geom AS TEXT), 1, 2) FROM fields WHERE fid = 1"), "ジオメトリはGeoPackageバイナリであるべき")
+	require.Equal(t, "褐色低地土|1234.5", queryString(t, db, "SELECT soil_small_name, area_sqm FROM fields WHERE fid = 1"), "属性が一致しない")
+	require.Equal(t, "fields|features|4326", queryString(t, db, "SELECT table_name, data_type, srs_id FROM gpkg_contents"), "gpkg_contentsが一致しない")
+	require.Equal(t, "1", queryString(t, db, "SELECT min_x < max_x AND min_y < max_y FROM gpkg_contents"), "全体範囲が記録されるべき")
+	require.Equal(t, "fields|geom|GEOMETRY", queryString(t, db, "SELECT table_name, column_name, geometry_type_name FROM gpkg_geometry_columns"), "gpkg_geometry_columnsが一致しない")
+	require.Equal(t, "-1,0,4326", queryString(t, db, "SELECT group_concat(srs_id) FROM (SELECT srs_id FROM gpkg_spatial_ref_sys ORDER BY srs_id)"), "空間参照系が一致しない")
+}
+
+func TestGeoPackageEncoder_Empty(t *testing.T) {
+	path, _ := encodeAll(t, entity.ExportFormatGeoPackage)
+
+	db := openGeoPackage(t, path)
+	require.Equal(t, "ok", queryString(t, db, "PRAGMA integrity_check"), "整合性チェックに失敗")
+	require.Equal(t, "0", queryString(t, db, "SELECT count(*) FROM fields"), "行数が一致しない")
+	require.Equal(t, "1", queryString(t, db, "SELECT min_x IS NULL FROM gpkg_contents"), "空のGeoPackageは全体範囲を持たないべき")
+}
+
+func TestGeoPackageEncoder_CloseTwice(t *testing.T) {
+	f := tempFile(t, "out.gpkg")
+	enc, err := New(entity.ExportFormatGeoPackage, f)
+	require.NoError(t, err, "エンコーダーの作成に失敗")
+	require.NoError(t, enc.Encode(testField(t, 16)), "Encodeでエラーが発生")
+
+	require.NoError(t, enc.Close(), "Closeでエラーが発生")
+	require.NoError(t, enc.Close(), "2回目のCloseは何もしないべき")
+}
@@ -0,0 +1,72 @@
+package encoder
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/twpayne/go-geom/encoding/geojson"
+)
+
+// geoJSONEncoder はGeoJSON FeatureCollectionを逐次書き込むエンコーダー
+// FeatureCollection全体をメモリに保持せず、Featureごとに書き出す
+type geoJSONEncoder struct {
+	w     *bufio.Writer
+	count int
+}
+
+func newGeoJSONEncoder(w io.Writer) *geoJSONEncoder {
+	return &geoJSONEncoder{w: bufio.NewWriter(w)}
+}
+
+// Encode は圃場をGeoJSON Featureとして書き込む
+func (e *geoJSONEncoder) Encode(field *entity.ExportField) error {
+	values, err := attributes(field)
+	if err != nil {
+		return err
+	}
+	properties := make(map[string]interface{}, len(attributeColumns))
+	for i, column := range attributeColumns {
+		properties[column] = values[i]
+	}
+
+	feature := &geojson.Feature{
+		ID:         field.ID.String(),
+		Geometry:   field.Geometry,
+		Properties: properties,
+	}
+	data, err := json.Marshal(feature)
+	if err != nil {
+		return fmt.Errorf("GeoJSON Featureの変換に失敗しました: %w", err)
+	}
+
+	prefix := ",\n"
+	if e.count == 0 {
+		prefix = `{"type":"FeatureCollection","features":[` + "\n"
+	}
+	if _, err := e.w.WriteString(prefix); err != nil {
+		return fmt.Errorf("GeoJSONの書き込みに失敗しました: %w", err)
+	}
+	if _, err := e.w.Write(data); err != nil {
+		return fmt.Errorf("GeoJSONの書き込みに失敗しました: %w", err)
+	}
+	e.count++
+	return nil
+}
+
+// Close はFeatureCollectionを閉じる
+func (e *geoJSONEncoder) Close() error {
+	suffix := "\n]}\n"
+	if e.count == 0 {
+		suffix = `{"type":"FeatureCollection","features":[]}` + "\n"
+	}
+	if _, err := e.w.WriteString(suffix); err != nil {
+		return fmt.Errorf("GeoJSONの書き込みに失敗しました: %w", err)
+	}
+	if err := e.w.Flush(); err != nil {
+		return fmt.Errorf("GeoJSONの書き込みに失敗しました: %w", err)
+	}
+	return nil
+}
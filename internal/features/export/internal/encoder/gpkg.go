@@ -0,0 +1,252 @@
+package encoder
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/wkb"
+
+	// GeoPackageの書き込みに使用するSQLiteドライバ(CGO不要)
+	_ "modernc.org/sqlite"
+)
+
+const (
+	// gpkgApplicationID はGeoPackageを示すアプリケーションID("GPKG")
+	gpkgApplicationID = 0x47504b47
+	// gpkgUserVersion はGeoPackage 1.2を示すユーザーバージョン
+	gpkgUserVersion = 10200
+
+	gpkgTableName      = "fields"
+	gpkgGeometryColumn = "geom"
+	gpkgSRSID          = 4326
+
+	// gpkgGeometryFlags はGeoPackageバイナリのフラグ(リトルエンディアン、XYエンベロープ付き)
+	gpkgGeometryFlags = 0x03
+)
+
+// gpkgColumnTypes は属性列のGeoPackageデータ型
+var gpkgColumnTypes = map[string]string{
+	"area_sqm": "DOUBLE",
+}
+
+const gpkgSpatialRefSysSQL = `CREATE TABLE gpkg_spatial_ref_sys (srs_name TEXT NOT NULL, srs_id INTEGER NOT NULL PRIMARY KEY, organization TEXT NOT NULL, organization_coordsys_id INTEGER NOT NULL, definition TEXT NOT NULL, description TEXT)`
+
+const gpkgContentsSQL = `CREATE TABLE gpkg_contents (table_name TEXT NOT NULL PRIMARY KEY, data_type TEXT NOT NULL, identifier TEXT UNIQUE, description TEXT DEFAULT '', last_change DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')), min_x DOUBLE, min_y DOUBLE, max_x DOUBLE, max_y DOUBLE, srs_id INTEGER, CONSTRAINT fk_gc_r_srs_id FOREIGN KEY (srs_id) REFERENCES gpkg_spatial_ref_sys(srs_id))`
+
+const gpkgGeometryColumnsSQL = `CREATE TABLE gpkg_geometry_columns (table_name TEXT NOT NULL, column_name TEXT NOT NULL, geometry_type_name TEXT NOT NULL, srs_id INTEGER NOT NULL, z TINYINT NOT NULL, m TINYINT NOT NULL, CONSTRAINT pk_geom_cols PRIMARY KEY (table_name, column_name), CONSTRAINT uk_gc_table_name UNIQUE (table_name), CONSTRAINT fk_gc_tn FOREIGN KEY (table_name) REFERENCES gpkg_contents(table_name), CONSTRAINT fk_gc_srs FOREIGN KEY (srs_id) REFERENCES gpkg_spatial_ref_sys (srs_id))`
+
+const wgs84Definition = `GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563,AUTHORITY["EPSG","7030"]],AUTHORITY["EPSG","6326"]],PRIMEM["Greenwich",0,AUTHORITY["EPSG","8901"]],UNIT["degree",0.0174532925199433,AUTHORITY["EPSG","9122"]],AUTHORITY["EPSG","4326"]]`
+
+// geoPackageEncoder は圃場テーブル1つを持つGeoPackageを書き込むエンコーダー
+// 圃場は1つのトランザクション内で行ごとに挿入し、全体範囲を含むメタデータはClose時に書き込む
+type geoPackageEncoder struct {
+	db     *sql.DB
+	tx     *sql.Tx
+	insert *sql.Stmt
+	extent *geom.Bounds
+}
+
+func newGeoPackageEncoder(path string) (*geoPackageEncoder, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("GeoPackageを開けませんでした: %w", err)
+	}
+	// PRAGMAとトランザクションを同じ接続で実行する
+	db.SetMaxOpenConns(1)
+
+	e := &geoPackageEncoder{db: db}
+	if err := e.init(); err != nil {
+		_ = e.abort()
+		return nil, fmt.Errorf("GeoPackageの初期化に失敗しました: %w", err)
+	}
+	return e, nil
+}
+
+// init はGeoPackageのヘッダーとテーブルを作成し、圃場の挿入を開始する
+func (e *geoPackageEncoder) init() error {
+	ctx := context.Background()
+	statements := []string{
+		// 出力は一時ファイルで、失敗時は破棄するためジャーナルを使用しない
+		"PRAGMA journal_mode = OFF",
+		"PRAGMA synchronous = OFF",
+		fmt.Sprintf("PRAGMA application_id = %d", gpkgApplicationID),
+		fmt.Sprintf("PRAGMA user_version = %d", gpkgUserVersion),
+		gpkgSpatialRefSysSQL,
+		gpkgContentsSQL,
+		gpkgGeometryColumnsSQL,
+		featuresTableSQL(),
+	}
+	for _, stmt := range statements {
+		if _, err := e.db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+
+	srsRows := []struct {
+		id                          int64
+		name, org, definition, desc string
+	}{
+		{-1, "Undefined cartesian SRS", "NONE", "undefined", "undefined cartesian coordinate reference system"},
+		{0, "Undefined geographic SRS", "NONE", "undefined", "undefined geographic coordinate reference system"},
+		{gpkgSRSID, "WGS 84 geodetic", "EPSG", wgs84Definition, "longitude/latitude coordinates in decimal degrees on the WGS 84 spheroid"},
+	}
+	for _, r := range srsRows {
+		if _, err := e.db.ExecContext(ctx,
+			`INSERT INTO gpkg_spatial_ref_sys (srs_name, srs_id, organization, organization_coordsys_id, definition, description) VALUES (?, ?, ?, ?, ?, ?)`,
+			r.name, r.id, r.org, r.id, r.definition, r.desc,
+		); err != nil {
+			return err
+		}
+	}
+
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	e.tx = tx
+
+	columns := append([]string{gpkgGeometryColumn}, attributeColumns...)
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	insert, err := tx.PrepareContext(ctx, fmt.Sprintf(`INSERT INTO "%s" (%s) VALUES (%s)`, gpkgTableName, strings.Join(columns, ", "), placeholders))
+	if err != nil {
+		return err
+	}
+	e.insert = insert
+	return nil
+}
+
+// Encode は圃場を圃場テーブルの1行として書き込む
+func (e *geoPackageEncoder) Encode(field *entity.ExportField) error {
+	values, err := attributes(field)
+	if err != nil {
+		return err
+	}
+
+	var geometry any
+	if field.Geometry != nil {
+		blob, err := e.geometryBlob(field.Geometry)
+		if err != nil {
+			return err
+		}
+		geometry = blob
+	}
+
+	// fidはrowidの別名のため、挿入順に採番される
+	row := make([]any, 0, len(values)+1)
+	row = append(row, geometry)
+	for _, v := range values {
+		if raw, ok := v.(json.RawMessage); ok {
+			v = string(raw)
+		}
+		row = append(row, v)
+	}
+
+	if _, err := e.insert.Exec(row...); err != nil {
+		return fmt.Errorf("GeoPackageへの書き込みに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Close は全体範囲を含むメタデータを書き込み、GeoPackageを確定させる
+// 2回目以降の呼び出しは何もしない
+func (e *geoPackageEncoder) Close() error {
+	if e.db == nil {
+		return nil
+	}
+	if err := e.writeMetadata(); err != nil {
+		_ = e.abort()
+		return fmt.Errorf("GeoPackageメタデータの書き込みに失敗しました: %w", err)
+	}
+	if err := e.insert.Close(); err != nil {
+		_ = e.abort()
+		return fmt.Errorf("GeoPackageへの書き込みに失敗しました: %w", err)
+	}
+	if err := e.tx.Commit(); err != nil {
+		_ = e.abort()
+		return fmt.Errorf("GeoPackageの確定に失敗しました: %w", err)
+	}
+	db := e.db
+	e.db = nil
+	if err := db.Close(); err != nil {
+		return fmt.Errorf("GeoPackageのクローズに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// abort は書き込み途中のトランザクションを破棄し、接続を閉じる
+func (e *geoPackageEncoder) abort() error {
+	if e.db == nil {
+		return nil
+	}
+	if e.tx != nil {
+		_ = e.tx.Rollback()
+	}
+	db := e.db
+	e.db = nil
+	return db.Close()
+}
+
+// writeMetadata は圃場テーブルをGeoPackageの内容・ジオメトリ列として登録する
+func (e *geoPackageEncoder) writeMetadata() error {
+	var minX, minY, maxX, maxY any
+	if e.extent != nil {
+		minX, minY = e.extent.Min(0), e.extent.Min(1)
+		maxX, maxY = e.extent.Max(0), e.extent.Max(1)
+	}
+	lastChange := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	if _, err := e.tx.Exec(
+		`INSERT INTO gpkg_contents (table_name, data_type, identifier, description, last_change, min_x, min_y, max_x, max_y, srs_id) VALUES (?, 'features', ?, '', ?, ?, ?, ?, ?, ?)`,
+		gpkgTableName, gpkgTableName, lastChange, minX, minY, maxX, maxY, gpkgSRSID,
+	); err != nil {
+		return err
+	}
+	_, err := e.tx.Exec(
+		`INSERT INTO gpkg_geometry_columns (table_name, column_name, geometry_type_name, srs_id, z, m) VALUES (?, ?, 'GEOMETRY', ?, 0, 0)`,
+		gpkgTableName, gpkgGeometryColumn, gpkgSRSID,
+	)
+	return err
+}
+
+// geometryBlob はジオメトリをGeoPackageバイナリ形式(ヘッダー + WKB)に変換し、全体範囲を更新する
+func (e *geoPackageEncoder) geometryBlob(g geom.T) ([]byte, error) {
+	body, err := wkb.Marshal(g, binary.LittleEndian)
+	if err != nil {
+		return nil, fmt.Errorf("WKBへの変換に失敗しました: %w", err)
+	}
+
+	bounds := g.Bounds()
+	if e.extent == nil {
+		e.extent = geom.NewBounds(geom.XY).Set(bounds.Min(0), bounds.Min(1), bounds.Max(0), bounds.Max(1))
+	} else {
+		e.extent.Extend(g)
+	}
+
+	blob := make([]byte, 0, 8+32+len(body))
+	blob = append(blob, 'G', 'P', 0, gpkgGeometryFlags)
+	blob = binary.LittleEndian.AppendUint32(blob, uint32(int32(gpkgSRSID)))
+	for _, v := range []float64{bounds.Min(0), bounds.Max(0), bounds.Min(1), bounds.Max(1)} {
+		blob = binary.LittleEndian.AppendUint64(blob, math.Float64bits(v))
+	}
+	return append(blob, body...), nil
+}
+
+// featuresTableSQL は圃場テーブルのCREATE文を返す
+func featuresTableSQL() string {
+	columns := []string{"fid INTEGER PRIMARY KEY NOT NULL", gpkgGeometryColumn + " GEOMETRY"}
+	for _, column := range attributeColumns {
+		columnType, ok := gpkgColumnTypes[column]
+		if !ok {
+			columnType = "TEXT"
+		}
+		columns = append(columns, column+" "+columnType)
+	}
+	return fmt.Sprintf(`CREATE TABLE "%s" (%s)`, gpkgTableName, strings.Join(columns, ", "))
+}
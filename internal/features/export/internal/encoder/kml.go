@@ -0,0 +1,128 @@
+package encoder
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/twpayne/go-geom"
+)
+
+const kmlHeader = `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+	`<kml xmlns="http://www.opengis.net/kml/2.2"><Document><name>fields</name>` + "\n"
+
+const kmlFooter = "</Document></kml>\n"
+
+// kmlEncoder はPlacemarkを逐次書き込むKMLエンコーダー
+// 属性はExtendedDataとして出力する
+type kmlEncoder struct {
+	w             *bufio.Writer
+	headerWritten bool
+}
+
+func newKMLEncoder(w io.Writer) *kmlEncoder {
+	return &kmlEncoder{w: bufio.NewWriter(w)}
+}
+
+// Encode は圃場をPlacemarkとして書き込む
+func (e *kmlEncoder) Encode(field *entity.ExportField) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	values, err := attributes(field)
+	if err != nil {
+		return err
+	}
+
+	e.w.WriteString("<Placemark><name>")
+	e.writeEscaped(field.Name)
+	e.w.WriteString("</name><ExtendedData>")
+	for i, column := range attributeColumns {
+		e.w.WriteString(`<Data name="` + column + `"><value>`)
+		e.writeEscaped(formatValue(values[i]))
+		e.w.WriteString("</value></Data>")
+	}
+	e.w.WriteString("</ExtendedData>")
+	if err := e.writeGeometry(field.Geometry); err != nil {
+		return err
+	}
+	if _, err := e.w.WriteString("</Placemark>\n"); err != nil {
+		return fmt.Errorf("KMLの書き込みに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Close はDocumentを閉じてバッファを書き出す
+func (e *kmlEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	if _, err := e.w.WriteString(kmlFooter); err != nil {
+		return fmt.Errorf("KMLの書き込みに失敗しました: %w", err)
+	}
+	if err := e.w.Flush(); err != nil {
+		return fmt.Errorf("KMLの書き込みに失敗しました: %w", err)
+	}
+	return nil
+}
+
+func (e *kmlEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	if _, err := e.w.WriteString(kmlHeader); err != nil {
+		return fmt.Errorf("KMLの書き込みに失敗しました: %w", err)
+	}
+	e.headerWritten = true
+	return nil
+}
+
+// writeEscaped はXMLエスケープしたテキストを書き込む
+// bufio.Writerは書き込みエラーを保持し、Flush時に返すため個別のエラーは無視する
+func (e *kmlEncoder) writeEscaped(s string) {
+	_ = xml.EscapeText(e.w, []byte(s))
+}
+
+// writeGeometry はPolygon/MultiPolygonをKMLジオメトリとして書き込む
+func (e *kmlEncoder) writeGeometry(g geom.T) error {
+	switch geometry := g.(type) {
+	case nil:
+		return nil
+	case *geom.Polygon:
+		e.writePolygon(geometry)
+	case *geom.MultiPolygon:
+		e.w.WriteString("<MultiGeometry>")
+		for i := 0; i < geometry.NumPolygons(); i++ {
+			e.writePolygon(geometry.Polygon(i))
+		}
+		e.w.WriteString("</MultiGeometry>")
+	default:
+		return fmt.Errorf("KMLに変換できないジオメトリ型です: %T", g)
+	}
+	return nil
+}
+
+func (e *kmlEncoder) writePolygon(polygon *geom.Polygon) {
+	e.w.WriteString("<Polygon>")
+	for i := 0; i < polygon.NumLinearRings(); i++ {
+		boundary := "innerBoundaryIs"
+		if i == 0 {
+			boundary = "outerBoundaryIs"
+		}
+		e.w.WriteString("<" + boundary + "><LinearRing><coordinates>")
+		for j, coord := range polygon.LinearRing(i).Coords() {
+			if j > 0 {
+				e.w.WriteByte(' ')
+			}
+			e.w.WriteString(strconv.FormatFloat(coord.X(), 'f', -1, 64))
+			e.w.WriteByte(',')
+			e.w.WriteString(strconv.FormatFloat(coord.Y(), 'f', -1, 64))
+		}
+		e.w.WriteString("</coordinates></LinearRing></" + boundary + ">")
+	}
+	e.w.WriteString("</Polygon>")
+}
@@ -0,0 +1,120 @@
+// Package presentation はエクスポート機能のHTTPハンドラーを提供する
+package presentation
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/export/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
+)
+
+// ExportHandler はエクスポートAPIのハンドラー
+type ExportHandler struct {
+	requestExportUC   *usecase.RequestExportUseCase
+	getExportStatusUC *usecase.GetExportStatusUseCase
+	logger            *slog.Logger
+}
+
+// NewExportHandler はExportHandlerを作成する
+func NewExportHandler(
+	requestExportUC *usecase.RequestExportUseCase,
+	getExportStatusUC *usecase.GetExportStatusUseCase,
+	logger *slog.Logger,
+) *ExportHandler {
+	return &ExportHandler{
+		requestExportUC:   requestExportUC,
+		getExportStatusUC: getExportStatusUC,
+		logger:            logger,
+	}
+}
+
+// RequestExport はエクスポートジョブを登録する
+func (h *ExportHandler) RequestExport(ctx context.Context, request openapi.RequestExportRequestObject) (openapi.RequestExportResponseObject, error) {
+	if request.Body == nil {
+		return openapi.RequestExport400JSONResponse{
+			Code:    "invalid_parameter",
+			Message: "リクエストボディは必須です",
+		}, nil
+	}
+
+	input := usecase.RequestExportInput{
+		Format:            string(request.Body.Format),
+		CityCode:          request.Body.CityCode,
+		SoilTypeSmallCode: request.Body.SoilTypeCode,
+	}
+	if bbox := request.Body.Bbox; bbox != nil {
+		input.SWLat = &bbox.SwLat
+		input.SWLng = &bbox.SwLng
+		input.NELat = &bbox.NeLat
+		input.NELng = &bbox.NeLng
+	}
+
+	output, err := h.requestExportUC.Execute(ctx, input)
+	if err != nil {
+		if isBadRequest(err) {
+			return openapi.RequestExport400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("エクスポートリクエストに失敗しました",
+			slog.String("error", err.Error()))
+		return openapi.RequestExport500JSONResponse{
+			Code:    "internal_error",
+			Message: "エクスポートリクエストに失敗しました",
+		}, nil
+	}
+
+	return openapi.RequestExport202JSONResponse{
+		ExportId: output.ExportJobID,
+	}, nil
+}
+
+// GetExportStatus はエクスポートジョブのステータスを取得する
+func (h *ExportHandler) GetExportStatus(ctx context.Context, request openapi.GetExportStatusRequestObject) (openapi.GetExportStatusResponseObject, error) {
+	output, err := h.getExportStatusUC.Execute(ctx, request.ExportId)
+	if err != nil {
+		if apperror.IsNotFoundError(err) {
+			return openapi.GetExportStatus404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("エクスポートステータスの取得に失敗しました",
+			slog.String("export_id", request.ExportId.String()),
+			slog.String("error", err.Error()))
+		return openapi.GetExportStatus500JSONResponse{
+			Code:    "internal_error",
+			Message: "エクスポートステータスの取得に失敗しました",
+		}, nil
+	}
+
+	resp := openapi.GetExportStatus200JSONResponse{
+		Id:           output.ID,
+		Format:       openapi.ExportStatusFormat(output.Format),
+		Status:       openapi.ExportStatusStatus(output.Status),
+		DownloadUrl:  output.DownloadURL,
+		ErrorMessage: output.ErrorMessage,
+		CreatedAt:    output.CreatedAt,
+		StartedAt:    output.StartedAt,
+		CompletedAt:  output.CompletedAt,
+	}
+	if output.TotalRecords != nil {
+		total := int(*output.TotalRecords)
+		resp.TotalRecords = &total
+	}
+	return resp, nil
+}
+
+// isBadRequest はエラーがリクエスト不正によるものかを判定する
+func isBadRequest(err error) bool {
+	var appErr apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.HTTPStatus() == http.StatusBadRequest
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/export/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/export/domain/repository"
+	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+// mockExportJobRepository はExportJobRepositoryのモック実装
+type mockExportJobRepository struct {
+	job *entity.ExportJob
+	err error
+
+	created *entity.ExportJob
+}
+
+func (m *mockExportJobRepository) Create(_ context.Context, job *entity.ExportJob) error {
+	m.created = job
+	return m.err
+}
+
+func (m *mockExportJobRepository) FindByID(_ context.Context, _ uuid.UUID) (*entity.ExportJob, error) {
+	return m.job, m.err
+}
+
+func (m *mockExportJobRepository) ClaimNextPending(_ context.Context, _ string) (*entity.ExportJob, error) {
+	return nil, nil
+}
+
+func (m *mockExportJobRepository) Heartbeat(_ context.Context, _ uuid.UUID, _ string) error {
+	return nil
+}
+
+func (m *mockExportJobRepository) MarkCompleted(_ context.Context, _ uuid.UUID, _ string, _ string, _ int32) error {
+	return nil
+}
+
+func (m *mockExportJobRepository) MarkFailed(_ context.Context, _ uuid.UUID, _ string, _ string) error {
+	return nil
+}
+
+func (m *mockExportJobRepository) RecoverExpiredJobs(_ context.Context, _ time.Duration, _ int32) (*repository.RecoveredJobs, error) {
+	return &repository.RecoveredJobs{}, nil
+}
+
+// mockStorageClient はStorageClientのモック実装
+type mockStorageClient struct{}
+
+func (m *mockStorageClient) Upload(_ context.Context, _ string, _ io.Reader, _ string) error {
+	return nil
+}
+
+func (m *mockStorageClient) PresignGetURL(_ context.Context, key string, _ time.Duration) (string, error) {
+	return "https://storage.example.com/" + key, nil
+}
+
+func getTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func newTestExportHandler(repo *mockExportJobRepository) *ExportHandler {
+	return NewExportHandler(
+		usecase.NewRequestExportUseCase(repo),
+		usecase.NewGetExportStatusUseCase(repo, &mockStorageClient{}, time.Minute, getTestLogger()),
+		getTestLogger(),
+	)
+}
+
+func TestExportHandler_RequestExport_Success(t *testing.T) {
+	repo := &mockExportJobRepository{}
+	h := newTestExportHandler(repo)
+	cityCode := "163210"
+
+	resp, err := h.RequestExport(context.Background(), openapi.RequestExportRequestObject{
+		Body: &openapi.RequestExportJSONRequestBody{
+			Format:   openapi.ExportRequestFormat("gpkg"),
+			CityCode: &cityCode,
+			Bbox:     &openapi.ExportBoundingBox{SwLat: 36.0, SwLng: 137.0, NeLat: 36.5, NeLng: 137.5},
+		},
+	})
+
+	require.NoError(t, err, "RequestExportでエラーが発生")
+	acceptedResp, ok := resp.(openapi.RequestExport202JSONResponse)
+	require.True(t, ok, "202レスポンスを期待")
+	require.Equal(t, repo.created.ID, acceptedResp.ExportId, "ジョブIDが一致しない")
+	require.NotNil(t, repo.created.Filter.BoundingBox, "バウンディングボックスが渡されていない")
+}
+
+func TestExportHandler_RequestExport_InvalidFormat(t *testing.T) {
+	h := newTestExportHandler(&mockExportJobRepository{})
+
+	resp, err := h.RequestExport(context.Background(), openapi.RequestExportRequestObject{
+		Body: &openapi.RequestExportJSONRequestBody{Format: openapi.ExportRequestFormat("shp")},
+	})
+
+	require.NoError(t, err, "RequestExportでエラーが発生")
+	badResp, ok := resp.(openapi.RequestExport400JSONResponse)
+	require.True(t, ok, "400レスポンスを期待")
+	require.Equal(t, "invalid_parameter", badResp.Code, "エラーコードが期待値と異なります")
+}
+
+func TestExportHandler_RequestExport_InternalError(t *testing.T) {
+	h := newTestExportHandler(&mockExportJobRepository{err: errors.New("db error")})
+
+	resp, err := h.RequestExport(context.Background(), openapi.RequestExportRequestObject{
+		Body: &openapi.RequestExportJSONRequestBody{Format: openapi.ExportRequestFormat("csv")},
+	})
+
+	require.NoError(t, err, "RequestExportでエラーが発生")
+	_, ok := resp.(openapi.RequestExport500JSONResponse)
+	require.True(t, ok, "500レスポンスを期待")
+}
+
+func TestExportHandler_GetExportStatus_Completed(t *testing.T) {
+	job := entity.NewExportJob(entity.ExportFormatKML, entity.ExportFilter{})
+	job.Status = entity.ExportStatusProcessing
+	require.NoError(t, job.Complete(job.ObjectKey(), 7), "テスト用ジョブの完了に失敗")
+	h := newTestExportHandler(&mockExportJobRepository{job: job})
+
+	resp, err := h.GetExportStatus(context.Background(), openapi.GetExportStatusRequestObject{ExportId: job.ID})
+
+	require.NoError(t, err, "GetExportStatusでエラーが発生")
+	okResp, ok := resp.(openapi.GetExportStatus200JSONResponse)
+	require.True(t, ok, "200レスポンスを期待")
+	require.Equal(t, openapi.ExportStatusStatus("completed"), okResp.Status, "ステータスが一致しない")
+	require.Equal(t, openapi.ExportStatusFormat("kml"), okResp.Format, "出力形式が一致しない")
+	require.NotNil(t, okResp.TotalRecords, "出力件数が設定されるべき")
+	require.Equal(t, 7, *okResp.TotalRecords, "出力件数が一致しない")
+	require.NotNil(t, okResp.DownloadUrl, "ダウンロードURLが設定されるべき")
+}
+
+func TestExportHandler_GetExportStatus_NotFound(t *testing.T) {
+	h := newTestExportHandler(&mockExportJobRepository{})
+
+	resp, err := h.GetExportStatus(context.Background(), openapi.GetExportStatusRequestObject{ExportId: uuid.New()})
+
+	require.NoError(t, err, "GetExportStatusでエラーが発生")
+	notFoundResp, ok := resp.(openapi.GetExportStatus404JSONResponse)
+	require.True(t, ok, "404レスポンスを期待")
+	require.Equal(t, "not_found", notFoundResp.Code, "エラーコードが期待値と異なります")
+}
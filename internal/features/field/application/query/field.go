@@ -0,0 +1,59 @@
+// Package query は圃場機能の照会インターフェースを定義する
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+)
+
+// BoundingBox は圃場検索用のバウンディングボックス
+// SWLng > NELngの場合は日付変更線をまたぐ範囲を表す
+type BoundingBox struct {
+	SWLat float64 // 南西端の緯度
+	SWLng float64 // 南西端の経度
+	NELat float64 // 北東端の緯度
+	NELng float64 // 北東端の経度
+}
+
+// FieldListFilter は圃場一覧の検索条件
+// nilの条件は絞り込みに使用しない
+type FieldListFilter struct {
+	CityCode           *string      // 市区町村コード
+	SoilTypeSmallCode  *string      // 土壌小分類コード
+	LandCategoryCode   *string      // 土地種別コード(農地台帳)
+	IdleLandStatusCode *string      // 遊休農地状況コード(農地台帳)
+	MinAreaSqm         *float64     // 最小面積(平方メートル)
+	MaxAreaSqm         *float64     // 最大面積(平方メートル)
+	BoundingBox        *BoundingBox // 空間範囲
+	Name               *string      // 圃場名のあいまい検索キーワード
+	NamePattern        *string      // 圃場名の部分一致検索パターン(ILIKE用にワイルドカードをエスケープ済み)
+}
+
+// FieldDetail は圃場詳細の読み取りモデル
+type FieldDetail struct {
+	Field          *entity.Field
+	SoilType       *entity.SoilType // 土壌タイプ(未設定の場合はnil)
+	LandRegistries []*LandRegistryDetail
+}
+
+// LandRegistryDetail はマスタ名称を解決した農地台帳
+type LandRegistryDetail struct {
+	Registry       *entity.FieldLandRegistry
+	LandCategory   *entity.LandCategory   // 土地種別(未設定の場合はnil)
+	IdleLandStatus *entity.IdleLandStatus // 遊休農地状況(未設定の場合はnil)
+}
+
+// FieldQuery は圃場の照会インターフェース
+type FieldQuery interface {
+	// List は検索条件に一致する圃場一覧を取得する
+	List(ctx context.Context, filter FieldListFilter, limit, offset int32) ([]*entity.Field, error)
+
+	// Count は検索条件に一致する圃場の総数を取得する
+	Count(ctx context.Context, filter FieldListFilter) (int64, error)
+
+	// FindDetailByID はIDで圃場詳細を取得する
+	// 圃場が存在しない場合はnilを返す
+	FindDetailByID(ctx context.Context, id uuid.UUID) (*FieldDetail, error)
+}
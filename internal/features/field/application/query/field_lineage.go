@@ -0,0 +1,54 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LineageEdgeType は系譜エッジの種別
+type LineageEdgeType string
+
+const (
+	// LineageEdgeDivision は分筆(親圃場→子圃場)
+	LineageEdgeDivision LineageEdgeType = "division"
+	// LineageEdgeMerger は合筆(ソース圃場→合筆先圃場)
+	LineageEdgeMerger LineageEdgeType = "merger"
+)
+
+// LineageNode は系譜グラフのノード(圃場)
+type LineageNode struct {
+	FieldID   uuid.UUID
+	Name      string
+	CityCode  string
+	AreaSqm   *float64
+	Depth     int // 起点圃場からの世代差(祖先は負、子孫は正、起点は0)
+	CreatedAt time.Time
+	RetiredAt *time.Time // 廃止日時(有効な圃場はnil)
+}
+
+// LineageEdge は系譜グラフのエッジ(旧圃場→新圃場)
+type LineageEdge struct {
+	Type        LineageEdgeType
+	FromFieldID uuid.UUID
+	ToFieldID   uuid.UUID
+	OccurredAt  time.Time
+	Reason      *string
+}
+
+// FieldLineage は圃場の系譜グラフ
+type FieldLineage struct {
+	FieldID   uuid.UUID
+	MaxDepth  int
+	Truncated bool // 最大世代数より先の履歴が残っているか
+	Nodes     []*LineageNode
+	Edges     []*LineageEdge
+}
+
+// FieldLineageQuery は圃場系譜の照会インターフェース
+type FieldLineageQuery interface {
+	// FindLineage は分筆・合筆履歴を祖先・子孫方向にmaxDepth世代まで辿った系譜グラフを取得する
+	// 起点の圃場が存在しない場合はnilを返す
+	FindLineage(ctx context.Context, fieldID uuid.UUID, maxDepth int) (*FieldLineage, error)
+}
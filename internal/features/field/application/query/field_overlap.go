@@ -0,0 +1,24 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+)
+
+// FieldOverlapFilter はオーバーラップ一覧の検索条件
+// nilの条件は絞り込みに使用しない
+type FieldOverlapFilter struct {
+	Status  *entity.OverlapStatus // 対応状況
+	FieldID *uuid.UUID            // どちらかの当事者に含まれる圃場
+}
+
+// FieldOverlapQuery はオーバーラップ検知記録の照会インターフェース
+type FieldOverlapQuery interface {
+	// List は検索条件に一致するオーバーラップを重なりの割合が大きい順に取得する
+	List(ctx context.Context, filter FieldOverlapFilter, limit, offset int32) ([]*entity.FieldOverlap, error)
+
+	// Count は検索条件に一致するオーバーラップの総数を取得する
+	Count(ctx context.Context, filter FieldOverlapFilter) (int64, error)
+}
@@ -0,0 +1,14 @@
+package query
+
+import (
+	"context"
+
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+)
+
+// FieldTileQuery は圃場ベクタータイルの照会インターフェース
+type FieldTileQuery interface {
+	// GetTile は指定タイルの圃場をMapbox Vector Tile形式で取得する
+	// タイル内に圃場が存在しない場合は空のバイト列を返す
+	GetTile(ctx context.Context, tile entity.Tile) ([]byte, error)
+}
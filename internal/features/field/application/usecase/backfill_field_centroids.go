@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/repository"
+	"github.com/twpayne/go-geom"
+)
+
+const (
+	// DefaultCentroidBackfillBatchSize は重心バックフィルで1回に取得する圃場数のデフォルト値
+	DefaultCentroidBackfillBatchSize = 500
+
+	// centroidBackfillClusterJobPriority は重心バックフィルで発行するクラスタージョブの優先度
+	// 利用者の操作を待たせないよう、圃場の手動編集より低くする
+	centroidBackfillClusterJobPriority int32 = 0
+)
+
+// BackfillFieldCentroidsInput は重心バックフィルの入力
+type BackfillFieldCentroidsInput struct {
+	BatchSize int  // 1回に取得する圃場数(0以下の場合はデフォルト値)
+	DryRun    bool // trueの場合は更新とクラスタージョブのエンキューを行わず、件数のみ集計する
+}
+
+// BackfillFieldCentroidsOutput は重心バックフィルの結果
+type BackfillFieldCentroidsOutput struct {
+	Scanned       int                           // 走査した圃場数
+	Updated       int                           // 重心またはH3インデックスが変わった圃場数(DryRunの場合は更新対象の件数)
+	Skipped       int                           // ジオメトリをデコードできず再計算できなかった圃場数
+	Methods       map[entity.CentroidMethod]int // 算出方法ごとの圃場数
+	AffectedCells []string                      // 圃場の出入りがあったH3セル(クラスタージョブの対象)
+}
+
+// BackfillFieldCentroidsUseCase は既存圃場の重心とH3インデックスを再計算するユースケース
+type BackfillFieldCentroidsUseCase struct {
+	centroidRepo       repository.FieldCentroidRepository
+	clusterJobEnqueuer ClusterJobEnqueuer
+	logger             *slog.Logger
+}
+
+// NewBackfillFieldCentroidsUseCase は新しいBackfillFieldCentroidsUseCaseを作成する
+func NewBackfillFieldCentroidsUseCase(
+	centroidRepo repository.FieldCentroidRepository,
+	clusterJobEnqueuer ClusterJobEnqueuer,
+	logger *slog.Logger,
+) *BackfillFieldCentroidsUseCase {
+	return &BackfillFieldCentroidsUseCase{
+		centroidRepo:       centroidRepo,
+		clusterJobEnqueuer: clusterJobEnqueuer,
+		logger:             logger,
+	}
+}
+
+// Execute は全圃場の重心とH3インデックスを現在の算出方法で再計算し、変わった圃場を更新する
+// 有効な圃場のH3セルが変わった場合は、移動前後のセルをまとめて差分更新用クラスタージョブをエンキューする
+// 途中で失敗した場合も、それまでに更新した圃場のセルはエンキューしてからエラーを返す
+func (uc *BackfillFieldCentroidsUseCase) Execute(ctx context.Context, input BackfillFieldCentroidsInput) (*BackfillFieldCentroidsOutput, error) {
+	batchSize := input.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultCentroidBackfillBatchSize
+	}
+
+	output := &BackfillFieldCentroidsOutput{
+		Methods:       make(map[entity.CentroidMethod]int),
+		AffectedCells: make([]string, 0),
+	}
+	backfillErr := uc.backfill(ctx, batchSize, input.DryRun, output)
+
+	if !input.DryRun && len(output.AffectedCells) > 0 && uc.clusterJobEnqueuer != nil {
+		if err := uc.clusterJobEnqueuer.EnqueueWithAffectedCells(ctx, centroidBackfillClusterJobPriority, output.AffectedCells); err != nil {
+			if backfillErr != nil {
+				uc.logger.Warn("差分更新用クラスタージョブのエンキューに失敗しました",
+					slog.Int("affected_cells", len(output.AffectedCells)),
+					slog.String("error", err.Error()))
+				return output, backfillErr
+			}
+			return output, fmt.Errorf("差分更新用クラスタージョブのエンキューに失敗: %w", err)
+		}
+	}
+	if backfillErr != nil {
+		return output, backfillErr
+	}
+
+	uc.logger.Info("圃場の重心バックフィルが完了しました",
+		slog.Int("scanned", output.Scanned),
+		slog.Int("updated", output.Updated),
+		slog.Int("skipped", output.Skipped),
+		slog.Int("point_on_surface", output.Methods[entity.CentroidMethodPointOnSurface]),
+		slog.Int("affected_cells", len(output.AffectedCells)),
+		slog.Bool("dry_run", input.DryRun))
+	return output, nil
+}
+
+// backfill は圃場をID順にbatchSize件ずつ取得して再計算し、結果をoutputに集計する
+func (uc *BackfillFieldCentroidsUseCase) backfill(ctx context.Context, batchSize int, dryRun bool, output *BackfillFieldCentroidsOutput) error {
+	seen := make(map[string]struct{})
+	var afterID *uuid.UUID
+
+	for {
+		fields, err := uc.centroidRepo.ListAfter(ctx, afterID, int32(batchSize))
+		if err != nil {
+			return err
+		}
+
+		for _, field := range fields {
+			output.Scanned++
+			if field.Geometry == nil {
+				output.Skipped++
+				continue
+			}
+
+			before := field.H3Indexes()
+			beforeCentroid := field.Centroid
+
+			method, err := field.RecalculateCentroid()
+			if err != nil {
+				return fmt.Errorf("圃場%sの重心再計算に失敗: %w", field.ID, err)
+			}
+			output.Methods[method]++
+
+			changedCells := changedH3Cells(before, field.H3Indexes())
+			if len(changedCells) == 0 && samePoint(beforeCentroid, field.Centroid) {
+				continue
+			}
+
+			if !dryRun {
+				if err := uc.centroidRepo.UpdateCentroid(ctx, field); err != nil {
+					return err
+				}
+			}
+			output.Updated++
+
+			// 廃止済みの圃場はクラスターに集計されないため、セルの移動があってもジョブの対象にしない
+			if field.IsRetired() {
+				continue
+			}
+			for _, cell := range changedCells {
+				if _, ok := seen[cell]; ok {
+					continue
+				}
+				seen[cell] = struct{}{}
+				output.AffectedCells = append(output.AffectedCells, cell)
+			}
+		}
+
+		if len(fields) < batchSize {
+			return nil
+		}
+		afterID = &fields[len(fields)-1].ID
+
+		uc.logger.Info("圃場の重心バックフィルを実行中",
+			slog.Int("scanned", output.Scanned),
+			slog.Int("updated", output.Updated),
+			slog.String("after_id", afterID.String()))
+	}
+}
+
+// changedH3Cells は再計算前後のH3インデックスを比較し、変わった場合は前後のセルを返す
+func changedH3Cells(before, after []string) []string {
+	if slices.Equal(before, after) {
+		return []string{}
+	}
+	cells := make([]string, 0, len(before)+len(after))
+	cells = append(cells, before...)
+	return append(cells, after...)
+}
+
+// samePoint は2つの重心が同じ座標かを判定する
+func samePoint(a, b *geom.Point) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.X() == b.X() && a.Y() == b.Y()
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+// mockFieldCentroidRepository はFieldCentroidRepositoryのモック実装
+// 保持している圃場をID順に返し、ListAfterの呼び出し回数と更新した圃場を記録する
+type mockFieldCentroidRepository struct {
+	fields    []*entity.Field
+	listErr   error
+	updateErr error
+
+	listCalls int
+	updated   []uuid.UUID
+}
+
+func newMockFieldCentroidRepository(fields ...*entity.Field) *mockFieldCentroidRepository {
+	sort.Slice(fields, func(i, j int) bool { return fields[i].ID.String() < fields[j].ID.String() })
+	return &mockFieldCentroidRepository{fields: fields}
+}
+
+func (m *mockFieldCentroidRepository) ListAfter(_ context.Context, afterID *uuid.UUID, limit int32) ([]*entity.Field, error) {
+	m.listCalls++
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	result := make([]*entity.Field, 0)
+	for _, f := range m.fields {
+		if afterID != nil && f.ID.String() <= afterID.String() {
+			continue
+		}
+		if len(result) == int(limit) {
+			break
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+func (m *mockFieldCentroidRepository) UpdateCentroid(_ context.Context, field *entity.Field) error {
+	if m.updateErr != nil {
+		return m.updateErr
+	}
+	m.updated = append(m.updated, field.ID)
+	return nil
+}
+
+// uShapeCoordinates は南西端を起点とした一辺sizeの正方形3つ分の幅を持つコの字型のGeoJSON座標を返す
+// 頂点の算術平均は切り欠き部分に落ちる
+func uShapeCoordinates(lng, lat, size float64) [][][]float64 {
+	return [][][]float64{
+		{
+			{lng, lat},
+			{lng + 3*size, lat},
+			{lng + 3*size, lat + 3*size},
+			{lng + 2*size, lat + 3*size},
+			{lng + 2*size, lat + size},
+			{lng + size, lat + size},
+			{lng + size, lat + 3*size},
+			{lng, lat + 3*size},
+			{lng, lat},
+		},
+	}
+}
+
+// newLegacyCentroidField は頂点の算術平均(閉じるための終点を含む)を重心とした再計算前の圃場を作成する
+func newLegacyCentroidField(t *testing.T, coordinates [][][]float64) *entity.Field {
+	t.Helper()
+	polygon, err := entity.NewPolygonFromCoordinates(coordinates)
+	require.NoError(t, err, "ポリゴンの作成でエラーが発生")
+
+	var sumX, sumY float64
+	ring := coordinates[0]
+	for _, c := range ring {
+		sumX += c[0]
+		sumY += c[1]
+	}
+	lng, lat := sumX/float64(len(ring)), sumY/float64(len(ring))
+
+	multiPolygon, err := entity.NewMultiPolygon(polygon)
+	require.NoError(t, err, "マルチポリゴンの作成でエラーが発生")
+
+	field := entity.NewField(uuid.New(), "163210")
+	field.Geometry = multiPolygon
+	field.Centroid = geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{lng, lat})
+	require.NoError(t, field.CalculateH3Index(lat, lng), "H3インデックスの計算でエラーが発生")
+	return field
+}
+
+// newCurrentCentroidField は現在の算出方法で重心を計算済みの圃場を作成する
+func newCurrentCentroidField(t *testing.T, coordinates [][][]float64) *entity.Field {
+	t.Helper()
+	polygon, err := entity.NewPolygonFromCoordinates(coordinates)
+	require.NoError(t, err, "ポリゴンの作成でエラーが発生")
+	field := entity.NewField(uuid.New(), "163210")
+	require.NoError(t, field.SetGeometry(polygon), "SetGeometryでエラーが発生")
+	return field
+}
+
+func TestBackfillFieldCentroidsUseCase_Execute(t *testing.T) {
+	t.Run("重心が変わった有効な圃場のみ更新し、移動前後のセルをエンキューする", func(t *testing.T) {
+		legacy := newLegacyCentroidField(t, uShapeCoordinates(139.70, 35.60, 0.01))
+		oldCells := legacy.H3Indexes()
+		current := newCurrentCentroidField(t, squareCoordinates(139.80, 35.60, 0.001))
+		retired := newLegacyCentroidField(t, uShapeCoordinates(139.90, 35.60, 0.01))
+		retiredAt := time.Now()
+		retired.RetiredAt = &retiredAt
+		broken := entity.NewField(uuid.New(), "163210")
+
+		repo := newMockFieldCentroidRepository(legacy, current, retired, broken)
+		enqueuer := &mockClusterJobEnqueuer{}
+		uc := NewBackfillFieldCentroidsUseCase(repo, enqueuer, getTestLogger())
+
+		output, err := uc.Execute(context.Background(), BackfillFieldCentroidsInput{BatchSize: 2})
+		require.NoError(t, err, "Executeでエラーが発生")
+
+		require.Equal(t, 4, output.Scanned, "走査件数が一致しない")
+		require.Equal(t, 2, output.Updated, "更新件数が一致しない")
+		require.Equal(t, 1, output.Skipped, "スキップ件数が一致しない")
+		require.Equal(t, 2, output.Methods[entity.CentroidMethodPointOnSurface], "内部の点を採用した件数が一致しない")
+		require.Equal(t, 1, output.Methods[entity.CentroidMethodAreaWeighted], "面積加重重心を採用した件数が一致しない")
+		require.ElementsMatch(t, []uuid.UUID{legacy.ID, retired.ID}, repo.updated, "更新した圃場が一致しない")
+		require.Equal(t, 3, repo.listCalls, "ページングの回数が一致しない")
+
+		newCells := legacy.H3Indexes()
+		require.NotEqual(t, oldCells[0], newCells[0], "セルが変わっていない")
+		require.Contains(t, enqueuer.affectedCells, oldCells[0], "移動前のセルが含まれていない")
+		require.Contains(t, enqueuer.affectedCells, newCells[0], "移動後のセルが含まれていない")
+		require.Equal(t, output.AffectedCells, enqueuer.affectedCells, "エンキューしたセルが結果と一致しない")
+		for _, cell := range retired.H3Indexes() {
+			require.NotContains(t, enqueuer.affectedCells, cell, "廃止済みの圃場のセルはエンキューしない")
+		}
+	})
+
+	t.Run("変更がなければエンキューしない", func(t *testing.T) {
+		repo := newMockFieldCentroidRepository(newCurrentCentroidField(t, squareCoordinates(139.80, 35.60, 0.001)))
+		enqueuer := &mockClusterJobEnqueuer{}
+		uc := NewBackfillFieldCentroidsUseCase(repo, enqueuer, getTestLogger())
+
+		output, err := uc.Execute(context.Background(), BackfillFieldCentroidsInput{})
+		require.NoError(t, err, "Executeでエラーが発生")
+		require.Zero(t, output.Updated, "更新件数は0であるべき")
+		require.Nil(t, enqueuer.affectedCells, "差分更新ジョブはエンキューしない")
+		require.False(t, enqueuer.enqueueCalled, "全範囲再計算ジョブはエンキューしない")
+	})
+
+	t.Run("ドライランでは更新もエンキューもしない", func(t *testing.T) {
+		repo := newMockFieldCentroidRepository(newLegacyCentroidField(t, uShapeCoordinates(139.70, 35.60, 0.01)))
+		enqueuer := &mockClusterJobEnqueuer{}
+		uc := NewBackfillFieldCentroidsUseCase(repo, enqueuer, getTestLogger())
+
+		output, err := uc.Execute(context.Background(), BackfillFieldCentroidsInput{DryRun: true})
+		require.NoError(t, err, "Executeでエラーが発生")
+		require.Equal(t, 1, output.Updated, "更新対象の件数が一致しない")
+		require.NotEmpty(t, output.AffectedCells, "影響セルが集計されていない")
+		require.Empty(t, repo.updated, "ドライランで更新している")
+		require.Nil(t, enqueuer.affectedCells, "ドライランでエンキューしている")
+	})
+
+	t.Run("更新失敗時はエラーを返し、未更新の圃場のセルはエンキューしない", func(t *testing.T) {
+		repo := newMockFieldCentroidRepository(newLegacyCentroidField(t, uShapeCoordinates(139.70, 35.60, 0.01)))
+		repo.updateErr = errors.New("db error")
+		enqueuer := &mockClusterJobEnqueuer{}
+		uc := NewBackfillFieldCentroidsUseCase(repo, enqueuer, getTestLogger())
+
+		_, err := uc.Execute(context.Background(), BackfillFieldCentroidsInput{})
+		require.Error(t, err, "更新失敗時はエラーを返すべき")
+		require.Nil(t, enqueuer.affectedCells, "更新していない圃場のセルはエンキューしない")
+	})
+
+	t.Run("取得失敗時はエラーを返す", func(t *testing.T) {
+		repo := newMockFieldCentroidRepository()
+		repo.listErr = errors.New("db error")
+		uc := NewBackfillFieldCentroidsUseCase(repo, &mockClusterJobEnqueuer{}, getTestLogger())
+
+		_, err := uc.Execute(context.Background(), BackfillFieldCentroidsInput{})
+		require.Error(t, err, "取得失敗時はエラーを返すべき")
+	})
+
+	t.Run("エンキュー失敗時はエラーを返す", func(t *testing.T) {
+		repo := newMockFieldCentroidRepository(newLegacyCentroidField(t, uShapeCoordinates(139.70, 35.60, 0.01)))
+		enqueuer := &mockClusterJobEnqueuer{err: errors.New("enqueue error")}
+		uc := NewBackfillFieldCentroidsUseCase(repo, enqueuer, getTestLogger())
+
+		_, err := uc.Execute(context.Background(), BackfillFieldCentroidsInput{})
+		require.Error(t, err, "エンキュー失敗時はエラーを返すべき")
+		require.Len(t, repo.updated, 1, "圃場の更新は完了しているべき")
+	})
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// fieldEditClusterJobPriority は圃場の手動編集で発行するクラスタージョブの優先度
+	fieldEditClusterJobPriority int32 = 1
+)
+
+// ClusterJobEnqueuer はクラスタージョブをエンキューするインターフェース(Consumer側で定義)
+type ClusterJobEnqueuer interface {
+	// Enqueue はクラスター計算ジョブをエンキューする(全範囲再計算)
+	Enqueue(ctx context.Context, priority int32) error
+	// EnqueueWithAffectedCells は影響セル情報付きでクラスター計算ジョブをエンキューする(差分更新)
+	EnqueueWithAffectedCells(ctx context.Context, priority int32, affectedCells []string) error
+}
+
+// enqueueAffectedCells は編集前後のH3セルをまとめて差分更新用クラスタージョブをエンキューする
+// エンキューの失敗は圃場の編集自体の成否に影響させず、警告ログのみ出力する
+func enqueueAffectedCells(ctx context.Context, enqueuer ClusterJobEnqueuer, logger *slog.Logger, fieldID uuid.UUID, cellGroups ...[]string) {
+	if enqueuer == nil {
+		return
+	}
+
+	seen := make(map[string]struct{})
+	affectedCells := make([]string, 0)
+	for _, cells := range cellGroups {
+		for _, cell := range cells {
+			if _, ok := seen[cell]; ok {
+				continue
+			}
+			seen[cell] = struct{}{}
+			affectedCells = append(affectedCells, cell)
+		}
+	}
+
+	if len(affectedCells) == 0 {
+		// 影響セルが得られない場合は全範囲再計算にフォールバック
+		if err := enqueuer.Enqueue(ctx, fieldEditClusterJobPriority); err != nil {
+			logger.Warn("クラスタージョブのエンキューに失敗しました",
+				slog.String("field_id", fieldID.String()),
+				slog.String("error", err.Error()))
+		}
+		return
+	}
+
+	if err := enqueuer.EnqueueWithAffectedCells(ctx, fieldEditClusterJobPriority, affectedCells); err != nil {
+		logger.Warn("差分更新用クラスタージョブのエンキューに失敗しました",
+			slog.String("field_id", fieldID.String()),
+			slog.String("error", err.Error()))
+	}
+}
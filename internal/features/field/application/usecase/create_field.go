@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/repository"
+)
+
+// CreateFieldInput は圃場作成の入力
+type CreateFieldInput struct {
+	CityCode    string
+	Name        *string
+	Coordinates [][][]float64 // GeoJSON Polygonの座標
+	UserID      *uuid.UUID    // 操作ユーザー(不明な場合はnil)
+}
+
+// CreateFieldUseCase は圃場の手動作成のユースケース
+type CreateFieldUseCase struct {
+	fieldRepo          repository.FieldRepository
+	fieldQuery         query.FieldQuery
+	overlapRepo        repository.FieldOverlapRepository
+	clusterJobEnqueuer ClusterJobEnqueuer
+	logger             *slog.Logger
+}
+
+// NewCreateFieldUseCase は新しいCreateFieldUseCaseを作成する
+func NewCreateFieldUseCase(
+	fieldRepo repository.FieldRepository,
+	fieldQuery query.FieldQuery,
+	overlapRepo repository.FieldOverlapRepository,
+	clusterJobEnqueuer ClusterJobEnqueuer,
+	logger *slog.Logger,
+) *CreateFieldUseCase {
+	return &CreateFieldUseCase{
+		fieldRepo:          fieldRepo,
+		fieldQuery:         fieldQuery,
+		overlapRepo:        overlapRepo,
+		clusterJobEnqueuer: clusterJobEnqueuer,
+		logger:             logger,
+	}
+}
+
+// Execute は圃場を作成し、作成後の圃場詳細を返す
+func (uc *CreateFieldUseCase) Execute(ctx context.Context, input CreateFieldInput) (*query.FieldDetail, error) {
+	// 1. 入力のバリデーション
+	cityCode := strings.TrimSpace(input.CityCode)
+	if cityCode == "" {
+		return nil, apperror.BadRequestError("市区町村コードは必須です")
+	}
+
+	polygon, err := entity.NewPolygonFromCoordinates(input.Coordinates)
+	if err != nil {
+		return nil, apperror.BadRequestError("ジオメトリが不正です: " + err.Error())
+	}
+
+	// 2. エンティティを組み立て(重心・H3インデックスを算出)
+	field := entity.NewField(uuid.New(), cityCode)
+	if name := normalizeString(input.Name); name != nil {
+		field.Name = *name
+	}
+	if err := field.SetGeometry(polygon); err != nil {
+		return nil, apperror.BadRequestError("H3インデックスの計算に失敗しました: " + err.Error())
+	}
+	field.CreatedBy = input.UserID
+	field.UpdatedBy = input.UserID
+
+	// 3. 永続化
+	if err := uc.fieldRepo.Create(ctx, field); err != nil {
+		return nil, apperror.InternalErrorWithCause("圃場の作成に失敗しました", err)
+	}
+
+	uc.logger.Info("圃場を作成しました",
+		slog.String("field_id", field.ID.String()),
+		slog.String("city_code", field.CityCode))
+
+	// 4. 追加された圃場のセルを差分更新
+	enqueueAffectedCells(ctx, uc.clusterJobEnqueuer, uc.logger, field.ID, field.AffectedH3Cells())
+	detectOverlaps(ctx, uc.overlapRepo, uc.logger, field.ID)
+
+	return findSavedDetail(ctx, uc.fieldQuery, field.ID)
+}
+
+// findSavedDetail は書き込み直後の圃場詳細を取得する
+func findSavedDetail(ctx context.Context, fieldQuery query.FieldQuery, id uuid.UUID) (*query.FieldDetail, error) {
+	detail, err := fieldQuery.FindDetailByID(ctx, id)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("圃場詳細の取得に失敗しました", err)
+	}
+	if detail == nil {
+		return nil, apperror.NotFoundError("圃場が見つかりません")
+	}
+	return detail, nil
+}
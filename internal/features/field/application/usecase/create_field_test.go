@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFieldRepository はFieldRepositoryのモック実装
+type mockFieldRepository struct {
+	field     *entity.Field
+	fields    map[uuid.UUID]*entity.Field // IDごとに返す圃場(該当がなければfieldを返す)
+	findErr   error
+	createErr error
+	updateErr error
+	deleteErr error
+
+	// 呼び出し時の引数を記録
+	created   *entity.Field
+	updated   *entity.Field
+	deletedID *uuid.UUID
+}
+
+func (m *mockFieldRepository) FindByID(_ context.Context, id uuid.UUID) (*entity.Field, error) {
+	if m.findErr != nil {
+		return nil, m.findErr
+	}
+	if f, ok := m.fields[id]; ok {
+		return f, nil
+	}
+	return m.field, nil
+}
+
+func (m *mockFieldRepository) Create(_ context.Context, field *entity.Field) error {
+	m.created = field
+	return m.createErr
+}
+
+func (m *mockFieldRepository) Update(_ context.Context, field *entity.Field) error {
+	m.updated = field
+	return m.updateErr
+}
+
+func (m *mockFieldRepository) Delete(_ context.Context, id uuid.UUID) error {
+	m.deletedID = &id
+	return m.deleteErr
+}
+
+func (m *mockFieldRepository) Upsert(_ context.Context, _ *entity.Field) error {
+	return nil
+}
+
+// mockClusterJobEnqueuer はClusterJobEnqueuerのモック実装
+type mockClusterJobEnqueuer struct {
+	err error
+
+	enqueueCalled bool
+	affectedCells []string
+}
+
+func (m *mockClusterJobEnqueuer) Enqueue(_ context.Context, _ int32) error {
+	m.enqueueCalled = true
+	return m.err
+}
+
+func (m *mockClusterJobEnqueuer) EnqueueWithAffectedCells(_ context.Context, _ int32, affectedCells []string) error {
+	m.affectedCells = affectedCells
+	return m.err
+}
+
+func getTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+// squareCoordinates は指定した南西端から一辺sizeの正方形のGeoJSON座標を返す
+func squareCoordinates(lng, lat, size float64) [][][]float64 {
+	return [][][]float64{
+		{
+			{lng, lat},
+			{lng + size, lat},
+			{lng + size, lat + size},
+			{lng, lat + size},
+			{lng, lat},
+		},
+	}
+}
+
+func TestCreateFieldUseCase_Execute_Success(t *testing.T) {
+	repo := &mockFieldRepository{}
+	enqueuer := &mockClusterJobEnqueuer{}
+	detail := &query.FieldDetail{}
+	uc := NewCreateFieldUseCase(repo, &mockFieldQuery{detail: detail}, &mockFieldOverlapRepository{}, enqueuer, getTestLogger())
+	userID := uuid.New()
+
+	got, err := uc.Execute(context.Background(), CreateFieldInput{
+		CityCode:    " 163210 ",
+		Name:        stringPtr("北圃場"),
+		Coordinates: squareCoordinates(137.0, 36.0, 0.001),
+		UserID:      &userID,
+	})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, detail, got, "作成後の圃場詳細が返されるべき")
+
+	created := repo.created
+	require.NotNil(t, created, "Createが呼ばれていない")
+	require.Equal(t, "163210", created.CityCode, "市区町村コードがトリムされていない")
+	require.Equal(t, "北圃場", created.Name, "圃場名が設定されていない")
+	require.NotNil(t, created.Centroid, "重心が計算されていない")
+	require.NotNil(t, created.H3Index, "H3インデックスが計算されていない")
+	require.Equal(t, &userID, created.CreatedBy, "created_byが設定されていない")
+	require.Equal(t, &userID, created.UpdatedBy, "updated_byが設定されていない")
+
+	require.ElementsMatch(t, created.AffectedH3Cells(), enqueuer.affectedCells, "新しい圃場のセルがエンキューされるべき")
+}
+
+func TestCreateFieldUseCase_Execute_DefaultName(t *testing.T) {
+	repo := &mockFieldRepository{}
+	uc := NewCreateFieldUseCase(repo, &mockFieldQuery{detail: &query.FieldDetail{}}, &mockFieldOverlapRepository{}, &mockClusterJobEnqueuer{}, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), CreateFieldInput{
+		CityCode:    "163210",
+		Name:        stringPtr("  "),
+		Coordinates: squareCoordinates(137.0, 36.0, 0.001),
+	})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, "名称不明", repo.created.Name, "空の圃場名はデフォルト名称になるべき")
+	require.Nil(t, repo.created.CreatedBy, "操作ユーザー未指定時はcreated_byがnilであるべき")
+}
+
+func TestCreateFieldUseCase_Execute_ValidationError(t *testing.T) {
+	tests := []struct {
+		name  string
+		input CreateFieldInput
+	}{
+		{
+			name:  "市区町村コードが空",
+			input: CreateFieldInput{CityCode: "", Coordinates: squareCoordinates(137.0, 36.0, 0.001)},
+		},
+		{
+			name: "リングが閉じていない",
+			input: CreateFieldInput{
+				CityCode:    "163210",
+				Coordinates: [][][]float64{{{137.0, 36.0}, {137.1, 36.0}, {137.1, 36.1}, {137.0, 36.1}}},
+			},
+		},
+		{
+			name: "自己交差",
+			input: CreateFieldInput{
+				CityCode:    "163210",
+				Coordinates: [][][]float64{{{137.0, 36.0}, {137.1, 36.1}, {137.1, 36.0}, {137.0, 36.1}, {137.0, 36.0}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockFieldRepository{}
+			enqueuer := &mockClusterJobEnqueuer{}
+			uc := NewCreateFieldUseCase(repo, &mockFieldQuery{}, &mockFieldOverlapRepository{}, enqueuer, getTestLogger())
+
+			_, err := uc.Execute(context.Background(), tt.input)
+
+			require.Error(t, err, "エラーを期待")
+			require.Equal(t, http.StatusBadRequest, errorStatus(err), "BadRequestエラーを期待")
+			require.Nil(t, repo.created, "バリデーションエラー時はCreateを呼ぶべきでない")
+			require.Nil(t, enqueuer.affectedCells, "バリデーションエラー時はエンキューすべきでない")
+		})
+	}
+}
+
+func TestCreateFieldUseCase_Execute_RepositoryError(t *testing.T) {
+	enqueuer := &mockClusterJobEnqueuer{}
+	uc := NewCreateFieldUseCase(&mockFieldRepository{createErr: errors.New("db error")}, &mockFieldQuery{}, &mockFieldOverlapRepository{}, enqueuer, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), CreateFieldInput{
+		CityCode:    "163210",
+		Coordinates: squareCoordinates(137.0, 36.0, 0.001),
+	})
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusInternalServerError, errorStatus(err), "InternalErrorを期待")
+	require.Nil(t, enqueuer.affectedCells, "作成失敗時はエンキューすべきでない")
+}
+
+func TestCreateFieldUseCase_Execute_EnqueueErrorIgnored(t *testing.T) {
+	detail := &query.FieldDetail{}
+	uc := NewCreateFieldUseCase(
+		&mockFieldRepository{},
+		&mockFieldQuery{detail: detail},
+		&mockFieldOverlapRepository{},
+		&mockClusterJobEnqueuer{err: errors.New("enqueue error")},
+		getTestLogger(),
+	)
+
+	got, err := uc.Execute(context.Background(), CreateFieldInput{
+		CityCode:    "163210",
+		Coordinates: squareCoordinates(137.0, 36.0, 0.001),
+	})
+
+	require.NoError(t, err, "エンキュー失敗は圃場作成の失敗とすべきでない")
+	require.Equal(t, detail, got, "圃場詳細が返されるべき")
+}
+
+func TestCreateFieldUseCase_Execute_DetectErrorIgnored(t *testing.T) {
+	repo := &mockFieldRepository{}
+	overlapRepo := &mockFieldOverlapRepository{detectErr: errors.New("detect error")}
+	uc := NewCreateFieldUseCase(repo, &mockFieldQuery{detail: &query.FieldDetail{}}, overlapRepo, &mockClusterJobEnqueuer{}, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), CreateFieldInput{
+		CityCode:    "163210",
+		Coordinates: squareCoordinates(137.0, 36.0, 0.001),
+	})
+
+	require.NoError(t, err, "重なり検出のエラーで作成が失敗すべきでない")
+	require.NotNil(t, repo.created, "Createが呼ばれていない")
+	require.Equal(t, []uuid.UUID{repo.created.ID}, overlapRepo.detectedFieldIDs, "作成した圃場の重なりを検出すべき")
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/repository"
+)
+
+// DeleteFieldUseCase は圃場の手動削除のユースケース
+type DeleteFieldUseCase struct {
+	fieldRepo          repository.FieldRepository
+	clusterJobEnqueuer ClusterJobEnqueuer
+	logger             *slog.Logger
+}
+
+// NewDeleteFieldUseCase は新しいDeleteFieldUseCaseを作成する
+func NewDeleteFieldUseCase(
+	fieldRepo repository.FieldRepository,
+	clusterJobEnqueuer ClusterJobEnqueuer,
+	logger *slog.Logger,
+) *DeleteFieldUseCase {
+	return &DeleteFieldUseCase{
+		fieldRepo:          fieldRepo,
+		clusterJobEnqueuer: clusterJobEnqueuer,
+		logger:             logger,
+	}
+}
+
+// Execute は圃場を農地台帳とともに削除する
+// 分筆・合筆で作られた圃場は系譜を保持するため、削除せずに廃止する
+func (uc *DeleteFieldUseCase) Execute(ctx context.Context, id uuid.UUID) error {
+	// 1. 削除前のH3セルを把握するため既存の圃場を取得
+	field, err := uc.fieldRepo.FindByID(ctx, id)
+	if err != nil {
+		return apperror.InternalErrorWithCause("圃場の取得に失敗しました", err)
+	}
+	if field == nil {
+		return apperror.NotFoundError("圃場が見つかりません")
+	}
+	// 廃止済みの圃場を削除すると分筆・合筆の履歴がカスケード削除されるため拒否する
+	// (取得後に分筆・合筆された場合はリポジトリがロックして検出する)
+	if field.IsRetired() {
+		return apperror.ConflictError(entity.ErrFieldRetired.Error())
+	}
+
+	// 2. 削除
+	if err := uc.fieldRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, entity.ErrFieldRetired) {
+			return apperror.ConflictError(entity.ErrFieldRetired.Error())
+		}
+		return apperror.InternalErrorWithCause("圃場の削除に失敗しました", err)
+	}
+
+	uc.logger.Info("圃場を削除しました",
+		slog.String("field_id", id.String()))
+
+	// 3. 削除された圃場が属していたセルを差分更新
+	enqueueAffectedCells(ctx, uc.clusterJobEnqueuer, uc.logger, id, field.AffectedH3Cells())
+
+	return nil
+}
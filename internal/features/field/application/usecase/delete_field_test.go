@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteFieldUseCase_Execute_Success(t *testing.T) {
+	field := newExistingField(t)
+	repo := &mockFieldRepository{field: field}
+	enqueuer := &mockClusterJobEnqueuer{}
+	uc := NewDeleteFieldUseCase(repo, enqueuer, getTestLogger())
+
+	err := uc.Execute(context.Background(), field.ID)
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.NotNil(t, repo.deletedID, "Deleteが呼ばれていない")
+	require.Equal(t, field.ID, *repo.deletedID, "削除対象のIDが一致しない")
+	require.ElementsMatch(t, field.AffectedH3Cells(), enqueuer.affectedCells, "削除された圃場のセルがエンキューされるべき")
+}
+
+func TestDeleteFieldUseCase_Execute_WithoutH3Indexes(t *testing.T) {
+	// H3インデックスを持たない圃場は全範囲再計算にフォールバックする
+	repo := &mockFieldRepository{field: entity.NewField(uuid.New(), "163210")}
+	enqueuer := &mockClusterJobEnqueuer{}
+	uc := NewDeleteFieldUseCase(repo, enqueuer, getTestLogger())
+
+	err := uc.Execute(context.Background(), uuid.New())
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.True(t, enqueuer.enqueueCalled, "全範囲再計算がエンキューされるべき")
+}
+
+func TestDeleteFieldUseCase_Execute_NotFound(t *testing.T) {
+	repo := &mockFieldRepository{}
+	uc := NewDeleteFieldUseCase(repo, &mockClusterJobEnqueuer{}, getTestLogger())
+
+	err := uc.Execute(context.Background(), uuid.New())
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusNotFound, errorStatus(err), "NotFoundエラーを期待")
+	require.Nil(t, repo.deletedID, "存在しない圃場は削除を呼ぶべきでない")
+}
+
+func TestDeleteFieldUseCase_Execute_RepositoryError(t *testing.T) {
+	enqueuer := &mockClusterJobEnqueuer{}
+	repo := &mockFieldRepository{field: newExistingField(t), deleteErr: errors.New("db error")}
+	uc := NewDeleteFieldUseCase(repo, enqueuer, getTestLogger())
+
+	err := uc.Execute(context.Background(), repo.field.ID)
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusInternalServerError, errorStatus(err), "InternalErrorを期待")
+	require.Nil(t, enqueuer.affectedCells, "削除失敗時はエンキューすべきでない")
+}
+
+func TestDeleteFieldUseCase_Execute_RetiredField(t *testing.T) {
+	field := newExistingField(t)
+	retiredAt := time.Now()
+	field.RetiredAt = &retiredAt
+	repo := &mockFieldRepository{field: field}
+	uc := NewDeleteFieldUseCase(repo, &mockClusterJobEnqueuer{}, getTestLogger())
+
+	err := uc.Execute(context.Background(), field.ID)
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusConflict, errorStatus(err), "Conflictエラーを期待")
+	require.Nil(t, repo.deletedID, "廃止済みの圃場はDeleteを呼ぶべきでない")
+}
+
+func TestDeleteFieldUseCase_Execute_RetiredConcurrently(t *testing.T) {
+	// 取得後に分筆・合筆で廃止された場合はリポジトリが検出する
+	enqueuer := &mockClusterJobEnqueuer{}
+	repo := &mockFieldRepository{
+		field:     newExistingField(t),
+		deleteErr: fmt.Errorf("%w: %s", entity.ErrFieldRetired, uuid.New()),
+	}
+	uc := NewDeleteFieldUseCase(repo, enqueuer, getTestLogger())
+
+	err := uc.Execute(context.Background(), repo.field.ID)
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusConflict, errorStatus(err), "Conflictエラーを期待")
+	require.Nil(t, enqueuer.affectedCells, "削除失敗時はエンキューすべきでない")
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/repository"
+)
+
+// DetectFieldOverlapsUseCase は圃場の重なり検出のユースケース
+type DetectFieldOverlapsUseCase struct {
+	overlapRepo repository.FieldOverlapRepository
+	logger      *slog.Logger
+}
+
+// NewDetectFieldOverlapsUseCase は新しいDetectFieldOverlapsUseCaseを作成する
+func NewDetectFieldOverlapsUseCase(overlapRepo repository.FieldOverlapRepository, logger *slog.Logger) *DetectFieldOverlapsUseCase {
+	return &DetectFieldOverlapsUseCase{
+		overlapRepo: overlapRepo,
+		logger:      logger,
+	}
+}
+
+// Execute は指定圃場と他の有効な圃場の重なりを検出して記録し、検出件数を返す
+func (uc *DetectFieldOverlapsUseCase) Execute(ctx context.Context, fieldIDs []uuid.UUID) (int, error) {
+	detected, err := uc.overlapRepo.DetectByFieldIDs(ctx, fieldIDs)
+	if err != nil {
+		return 0, err
+	}
+	if detected > 0 {
+		uc.logger.Info("圃場の重なりを検出しました",
+			slog.Int("fields", len(fieldIDs)),
+			slog.Int("overlaps", detected))
+	}
+	return detected, nil
+}
+
+// FieldOverlapDetectorAdapter はimport機能から使用するアダプタ
+// import機能のConsumer側で定義されるFieldOverlapDetectorインターフェースに対応
+type FieldOverlapDetectorAdapter struct {
+	usecase *DetectFieldOverlapsUseCase
+}
+
+// NewFieldOverlapDetector はFieldOverlapDetectorAdapterを作成する
+func NewFieldOverlapDetector(usecase *DetectFieldOverlapsUseCase) *FieldOverlapDetectorAdapter {
+	return &FieldOverlapDetectorAdapter{
+		usecase: usecase,
+	}
+}
+
+// DetectOverlaps は文字列形式の圃場IDを指定して重なりを検出する
+// UUIDとして解釈できないIDは無視する
+func (a *FieldOverlapDetectorAdapter) DetectOverlaps(ctx context.Context, fieldIDs []string) error {
+	ids := make([]uuid.UUID, 0, len(fieldIDs))
+	for _, id := range fieldIDs {
+		u, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, u)
+	}
+	_, err := a.usecase.Execute(ctx, ids)
+	return err
+}
+
+// detectOverlaps は手動編集後の圃場について重なりを検出する
+// 検出の失敗は圃場の編集自体の成否に影響させず、警告ログのみ出力する
+func detectOverlaps(ctx context.Context, overlapRepo repository.FieldOverlapRepository, logger *slog.Logger, fieldIDs ...uuid.UUID) {
+	if overlapRepo == nil || len(fieldIDs) == 0 {
+		return
+	}
+	if _, err := overlapRepo.DetectByFieldIDs(ctx, fieldIDs); err != nil {
+		logger.Warn("圃場の重なり検出に失敗しました",
+			slog.String("field_id", fieldIDs[0].String()),
+			slog.String("error", err.Error()))
+	}
+}
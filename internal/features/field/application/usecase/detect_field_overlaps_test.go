@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFieldOverlapRepository はFieldOverlapRepositoryのモック実装
+// Clipの成功時はクリップ対象圃場を一回り小さい矩形に置き換える
+type mockFieldOverlapRepository struct {
+	overlap   *entity.FieldOverlap
+	detected  int
+	detectErr error
+	findErr   error
+	acceptErr error
+	clipErr   error
+
+	// 呼び出し時の引数を記録
+	detectedFieldIDs []uuid.UUID
+	accepted         *entity.FieldOverlap
+	clippedField     *entity.Field
+}
+
+func (m *mockFieldOverlapRepository) DetectByFieldIDs(_ context.Context, fieldIDs []uuid.UUID) (int, error) {
+	m.detectedFieldIDs = append(m.detectedFieldIDs, fieldIDs...)
+	return m.detected, m.detectErr
+}
+
+func (m *mockFieldOverlapRepository) FindByID(_ context.Context, _ uuid.UUID) (*entity.FieldOverlap, error) {
+	return m.overlap, m.findErr
+}
+
+func (m *mockFieldOverlapRepository) Accept(_ context.Context, overlap *entity.FieldOverlap) error {
+	m.accepted = overlap
+	return m.acceptErr
+}
+
+func (m *mockFieldOverlapRepository) Clip(_ context.Context, _ *entity.FieldOverlap, field *entity.Field) error {
+	m.clippedField = field
+	if m.clipErr != nil {
+		return m.clipErr
+	}
+	polygon, err := entity.NewPolygonFromCoordinates(squareCoordinates(139.7, 35.6, 0.0005))
+	if err != nil {
+		return err
+	}
+	return field.SetGeometry(polygon)
+}
+
+func TestDetectFieldOverlapsUseCase_Execute(t *testing.T) {
+	repo := &mockFieldOverlapRepository{detected: 3}
+	uc := NewDetectFieldOverlapsUseCase(repo, getTestLogger())
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+
+	got, err := uc.Execute(context.Background(), ids)
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, 3, got, "検出件数が一致しない")
+	require.Equal(t, ids, repo.detectedFieldIDs, "指定した圃場IDで検出すべき")
+}
+
+func TestDetectFieldOverlapsUseCase_Execute_Error(t *testing.T) {
+	uc := NewDetectFieldOverlapsUseCase(&mockFieldOverlapRepository{detectErr: errors.New("db error")}, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), []uuid.UUID{uuid.New()})
+
+	require.Error(t, err, "エラーを期待")
+}
+
+func TestFieldOverlapDetectorAdapter_DetectOverlaps_SkipsInvalidIDs(t *testing.T) {
+	repo := &mockFieldOverlapRepository{}
+	adapter := NewFieldOverlapDetector(NewDetectFieldOverlapsUseCase(repo, getTestLogger()))
+	valid := uuid.New()
+
+	err := adapter.DetectOverlaps(context.Background(), []string{valid.String(), "not-a-uuid"})
+
+	require.NoError(t, err, "DetectOverlapsでエラーが発生")
+	require.Equal(t, []uuid.UUID{valid}, repo.detectedFieldIDs, "UUIDとして解釈できるIDのみ検出対象とすべき")
+}
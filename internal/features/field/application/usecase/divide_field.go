@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/repository"
+)
+
+// DivideFieldChildInput は分筆後の子圃場1件分の入力
+type DivideFieldChildInput struct {
+	Coordinates     [][][]float64 // GeoJSON Polygonの座標
+	LandRegistryIDs []uuid.UUID   // この子圃場に付け替える親圃場の農地台帳ID
+}
+
+// DivideFieldInput は分筆の入力
+type DivideFieldInput struct {
+	ParentID uuid.UUID
+	Children []DivideFieldChildInput
+	Reason   *string
+	UserID   *uuid.UUID // 操作ユーザー(不明な場合はnil)
+}
+
+// DivideFieldOutput は分筆の出力
+type DivideFieldOutput struct {
+	ParentID  uuid.UUID
+	DividedAt time.Time
+	Reason    *string
+	Children  []*query.FieldDetail // 作成された子圃場(入力の順序)
+}
+
+// DivideFieldUseCase は圃場の分筆のユースケース
+type DivideFieldUseCase struct {
+	fieldRepo          repository.FieldRepository
+	divisionRepo       repository.FieldDivisionRepository
+	fieldQuery         query.FieldQuery
+	overlapRepo        repository.FieldOverlapRepository
+	clusterJobEnqueuer ClusterJobEnqueuer
+	logger             *slog.Logger
+}
+
+// NewDivideFieldUseCase は新しいDivideFieldUseCaseを作成する
+func NewDivideFieldUseCase(
+	fieldRepo repository.FieldRepository,
+	divisionRepo repository.FieldDivisionRepository,
+	fieldQuery query.FieldQuery,
+	overlapRepo repository.FieldOverlapRepository,
+	clusterJobEnqueuer ClusterJobEnqueuer,
+	logger *slog.Logger,
+) *DivideFieldUseCase {
+	return &DivideFieldUseCase{
+		fieldRepo:          fieldRepo,
+		divisionRepo:       divisionRepo,
+		fieldQuery:         fieldQuery,
+		overlapRepo:        overlapRepo,
+		clusterJobEnqueuer: clusterJobEnqueuer,
+		logger:             logger,
+	}
+}
+
+// Execute は親圃場を複数の子圃場に分筆し、作成された子圃場の詳細を返す
+// 親圃場は削除せず廃止状態とし、分筆履歴から系譜を辿れるようにする
+func (uc *DivideFieldUseCase) Execute(ctx context.Context, input DivideFieldInput) (*DivideFieldOutput, error) {
+	// 1. 既存の親圃場を取得
+	parent, err := uc.fieldRepo.FindByID(ctx, input.ParentID)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("圃場の取得に失敗しました", err)
+	}
+	if parent == nil {
+		return nil, apperror.NotFoundError("圃場が見つかりません")
+	}
+	if parent.IsRetired() {
+		return nil, apperror.ConflictError(entity.ErrFieldRetired.Error())
+	}
+
+	// 2. 子圃場を組み立て(重心・H3インデックスを算出し、親圃場の属性を引き継ぐ)
+	children := make([]*entity.DivisionChild, 0, len(input.Children))
+	for i, c := range input.Children {
+		polygon, err := entity.NewPolygonFromCoordinates(c.Coordinates)
+		if err != nil {
+			return nil, apperror.BadRequestError(fmt.Sprintf("子圃場%dのジオメトリが不正です: %s", i+1, err.Error()))
+		}
+
+		child := entity.NewField(uuid.New(), parent.CityCode)
+		if err := child.SetGeometry(polygon); err != nil {
+			return nil, apperror.BadRequestError("H3インデックスの計算に失敗しました: " + err.Error())
+		}
+		child.SoilTypeID = parent.SoilTypeID
+		child.CreatedBy = input.UserID
+		child.UpdatedBy = input.UserID
+
+		children = append(children, &entity.DivisionChild{
+			Field:           child,
+			LandRegistryIDs: c.LandRegistryIDs,
+		})
+	}
+
+	division, err := entity.NewDivision(parent, children, normalizeString(input.Reason), input.UserID)
+	if err != nil {
+		return nil, apperror.BadRequestError(err.Error())
+	}
+
+	// 3. 永続化(子圃場の作成・履歴記録・農地台帳の付け替え・親圃場の廃止)
+	if err := uc.divisionRepo.Divide(ctx, division); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrFieldRetired):
+			return nil, apperror.ConflictError(entity.ErrFieldRetired.Error())
+		case errors.Is(err, entity.ErrDivisionChildOutsideParent),
+			errors.Is(err, entity.ErrDivisionChildrenOverlap),
+			errors.Is(err, entity.ErrLandRegistryNotInParent):
+			return nil, apperror.BadRequestError(err.Error())
+		}
+		return nil, apperror.InternalErrorWithCause("圃場の分筆に失敗しました", err)
+	}
+
+	uc.logger.Info("圃場を分筆しました",
+		slog.String("field_id", parent.ID.String()),
+		slog.Int("children", len(children)))
+
+	// 4. 親圃場と子圃場のセルをまとめて差分更新
+	cellGroups := [][]string{parent.AffectedH3Cells()}
+	for _, child := range division.ChildFields() {
+		cellGroups = append(cellGroups, child.AffectedH3Cells())
+	}
+	enqueueAffectedCells(ctx, uc.clusterJobEnqueuer, uc.logger, parent.ID, cellGroups...)
+
+	// 5. 子圃場の重なりを検出(廃止した親圃場の記録は検出対象に含めることで削除される)
+	overlapTargets := []uuid.UUID{parent.ID}
+	for _, child := range division.ChildFields() {
+		overlapTargets = append(overlapTargets, child.ID)
+	}
+	detectOverlaps(ctx, uc.overlapRepo, uc.logger, overlapTargets...)
+
+	dividedAt := division.DividedAt
+	if len(division.Records) > 0 {
+		dividedAt = division.Records[0].DividedAt
+	}
+	output := &DivideFieldOutput{
+		ParentID:  parent.ID,
+		DividedAt: dividedAt,
+		Reason:    division.Reason,
+		Children:  make([]*query.FieldDetail, 0, len(children)),
+	}
+	for _, child := range division.ChildFields() {
+		detail, err := findSavedDetail(ctx, uc.fieldQuery, child.ID)
+		if err != nil {
+			return nil, err
+		}
+		output.Children = append(output.Children, detail)
+	}
+	return output, nil
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFieldDivisionRepository はFieldDivisionRepositoryのモック実装
+type mockFieldDivisionRepository struct {
+	err error
+
+	// 呼び出し時の引数を記録
+	divided *entity.Division
+}
+
+func (m *mockFieldDivisionRepository) Divide(_ context.Context, division *entity.Division) error {
+	m.divided = division
+	if m.err != nil {
+		return m.err
+	}
+	for _, child := range division.ChildFields() {
+		division.Records = append(division.Records, &entity.FieldDivision{
+			ID:            uuid.New(),
+			ParentFieldID: division.Parent.ID,
+			ChildFieldID:  child.ID,
+			DividedAt:     division.DividedAt,
+			Reason:        division.Reason,
+		})
+	}
+	return nil
+}
+
+// divisionChildrenInput は親圃場(newExistingField)の南西・北東の1/4区画を子圃場とする入力を返す
+func divisionChildrenInput(registryIDs ...uuid.UUID) []DivideFieldChildInput {
+	return []DivideFieldChildInput{
+		{Coordinates: squareCoordinates(137.0, 36.0, 0.0005), LandRegistryIDs: registryIDs},
+		{Coordinates: squareCoordinates(137.0005, 36.0005, 0.0005)},
+	}
+}
+
+func TestDivideFieldUseCase_Execute_Success(t *testing.T) {
+	parent := newExistingField(t)
+	soilTypeID := uuid.New()
+	parent.SoilTypeID = &soilTypeID
+	divisionRepo := &mockFieldDivisionRepository{}
+	enqueuer := &mockClusterJobEnqueuer{}
+	detail := &query.FieldDetail{}
+	overlapRepo := &mockFieldOverlapRepository{}
+	uc := NewDivideFieldUseCase(&mockFieldRepository{field: parent}, divisionRepo, &mockFieldQuery{detail: detail}, overlapRepo, enqueuer, getTestLogger())
+	userID := uuid.New()
+	registryID := uuid.New()
+
+	got, err := uc.Execute(context.Background(), DivideFieldInput{
+		ParentID: parent.ID,
+		Children: divisionChildrenInput(registryID),
+		Reason:   stringPtr(" 相続による分筆 "),
+		UserID:   &userID,
+	})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, parent.ID, got.ParentID, "親圃場IDが一致しない")
+	require.Equal(t, "相続による分筆", *got.Reason, "理由がトリムされていない")
+	require.False(t, got.DividedAt.IsZero(), "分筆日時が設定されていない")
+	require.Equal(t, []*query.FieldDetail{detail, detail}, got.Children, "子圃場の詳細が返されるべき")
+
+	division := divisionRepo.divided
+	require.NotNil(t, division, "Divideが呼ばれていない")
+	require.Equal(t, parent, division.Parent, "親圃場が一致しない")
+	require.Equal(t, &userID, division.DividedBy, "操作ユーザーが設定されていない")
+	require.Len(t, division.Children, 2, "子圃場の件数が一致しない")
+	require.Equal(t, []uuid.UUID{registryID}, division.Children[0].LandRegistryIDs, "付け替え対象の農地台帳が一致しない")
+
+	expectedCells := parent.AffectedH3Cells()
+	for _, child := range division.ChildFields() {
+		require.Equal(t, parent.CityCode, child.CityCode, "市区町村コードが引き継がれていない")
+		require.Equal(t, &soilTypeID, child.SoilTypeID, "土壌タイプが引き継がれていない")
+		require.Equal(t, &userID, child.CreatedBy, "created_byが設定されていない")
+		require.NotNil(t, child.H3Index, "子圃場のH3インデックスが計算されていない")
+		expectedCells = append(expectedCells, child.AffectedH3Cells()...)
+	}
+	for _, cell := range expectedCells {
+		require.Contains(t, enqueuer.affectedCells, cell, "親圃場と子圃場のセルがエンキューされるべき")
+	}
+	expectedOverlapTargets := []uuid.UUID{parent.ID}
+	for _, child := range division.ChildFields() {
+		expectedOverlapTargets = append(expectedOverlapTargets, child.ID)
+	}
+	require.Equal(t, expectedOverlapTargets, overlapRepo.detectedFieldIDs, "子圃場と廃止した親圃場の重なりを検出すべき")
+}
+
+func TestDivideFieldUseCase_Execute_ValidationError(t *testing.T) {
+	tests := []struct {
+		name     string
+		children []DivideFieldChildInput
+	}{
+		{
+			name:     "子圃場が1件",
+			children: divisionChildrenInput()[:1],
+		},
+		{
+			name: "子圃場のジオメトリが不正",
+			children: []DivideFieldChildInput{
+				{Coordinates: squareCoordinates(137.0, 36.0, 0.0005)},
+				{Coordinates: [][][]float64{{{137.0, 36.0}, {137.1, 36.0}, {137.1, 36.1}}}},
+			},
+		},
+		{
+			name: "同じ農地台帳を複数の子圃場に指定",
+			children: func() []DivideFieldChildInput {
+				id := uuid.New()
+				children := divisionChildrenInput(id)
+				children[1].LandRegistryIDs = []uuid.UUID{id}
+				return children
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := newExistingField(t)
+			divisionRepo := &mockFieldDivisionRepository{}
+			enqueuer := &mockClusterJobEnqueuer{}
+			uc := NewDivideFieldUseCase(&mockFieldRepository{field: parent}, divisionRepo, &mockFieldQuery{}, &mockFieldOverlapRepository{}, enqueuer, getTestLogger())
+
+			_, err := uc.Execute(context.Background(), DivideFieldInput{ParentID: parent.ID, Children: tt.children})
+
+			require.Error(t, err, "エラーを期待")
+			require.Equal(t, http.StatusBadRequest, errorStatus(err), "BadRequestエラーを期待")
+			require.Nil(t, divisionRepo.divided, "バリデーションエラー時はDivideを呼ぶべきでない")
+			require.Nil(t, enqueuer.affectedCells, "バリデーションエラー時はエンキューすべきでない")
+		})
+	}
+}
+
+func TestDivideFieldUseCase_Execute_NotFound(t *testing.T) {
+	divisionRepo := &mockFieldDivisionRepository{}
+	uc := NewDivideFieldUseCase(&mockFieldRepository{}, divisionRepo, &mockFieldQuery{}, &mockFieldOverlapRepository{}, &mockClusterJobEnqueuer{}, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), DivideFieldInput{ParentID: uuid.New(), Children: divisionChildrenInput()})
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusNotFound, errorStatus(err), "NotFoundエラーを期待")
+	require.Nil(t, divisionRepo.divided, "存在しない圃場は分筆を呼ぶべきでない")
+}
+
+func TestDivideFieldUseCase_Execute_RetiredParent(t *testing.T) {
+	parent := newExistingField(t)
+	retiredAt := time.Now()
+	parent.RetiredAt = &retiredAt
+	divisionRepo := &mockFieldDivisionRepository{}
+	uc := NewDivideFieldUseCase(&mockFieldRepository{field: parent}, divisionRepo, &mockFieldQuery{}, &mockFieldOverlapRepository{}, &mockClusterJobEnqueuer{}, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), DivideFieldInput{ParentID: parent.ID, Children: divisionChildrenInput()})
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusConflict, errorStatus(err), "Conflictエラーを期待")
+	require.Nil(t, divisionRepo.divided, "廃止済みの圃場は分筆を呼ぶべきでない")
+}
+
+func TestDivideFieldUseCase_Execute_RepositoryError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "子圃場が親圃場からはみ出す",
+			err:        fmt.Errorf("%w: 子圃場1", entity.ErrDivisionChildOutsideParent),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "子圃場同士が重なる",
+			err:        fmt.Errorf("%w: 子圃場1", entity.ErrDivisionChildrenOverlap),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "親圃場に属さない農地台帳",
+			err:        fmt.Errorf("%w: %s", entity.ErrLandRegistryNotInParent, uuid.New()),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "同時に廃止された",
+			err:        entity.ErrFieldRetired,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "DBエラー",
+			err:        errors.New("db error"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := newExistingField(t)
+			enqueuer := &mockClusterJobEnqueuer{}
+			uc := NewDivideFieldUseCase(
+				&mockFieldRepository{field: parent},
+				&mockFieldDivisionRepository{err: tt.err},
+				&mockFieldQuery{},
+				&mockFieldOverlapRepository{},
+				enqueuer,
+				getTestLogger(),
+			)
+
+			_, err := uc.Execute(context.Background(), DivideFieldInput{ParentID: parent.ID, Children: divisionChildrenInput()})
+
+			require.Error(t, err, "エラーを期待")
+			require.Equal(t, tt.wantStatus, errorStatus(err), "HTTPステータスが期待値と異なります")
+			require.Nil(t, enqueuer.affectedCells, "分筆失敗時はエンキューすべきでない")
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+)
+
+// GetFieldUseCase は圃場詳細取得のユースケース
+type GetFieldUseCase struct {
+	fieldQuery query.FieldQuery
+}
+
+// NewGetFieldUseCase は新しいGetFieldUseCaseを作成する
+func NewGetFieldUseCase(fieldQuery query.FieldQuery) *GetFieldUseCase {
+	return &GetFieldUseCase{
+		fieldQuery: fieldQuery,
+	}
+}
+
+// Execute は圃場詳細を取得する
+func (uc *GetFieldUseCase) Execute(ctx context.Context, id uuid.UUID) (*query.FieldDetail, error) {
+	detail, err := uc.fieldQuery.FindDetailByID(ctx, id)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("圃場詳細の取得に失敗しました", err)
+	}
+	if detail == nil {
+		return nil, apperror.NotFoundError("圃場が見つかりません")
+	}
+	return detail, nil
+}
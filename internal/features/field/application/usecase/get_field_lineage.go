@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+)
+
+const (
+	// DefaultLineageDepth は系譜を辿るデフォルトの世代数
+	DefaultLineageDepth = 10
+	// MaxLineageDepth は系譜を辿る最大の世代数
+	// 再帰クエリのコストを抑えるため上限を設ける
+	MaxLineageDepth = 50
+)
+
+// GetFieldLineageUseCase は圃場系譜取得のユースケース
+type GetFieldLineageUseCase struct {
+	lineageQuery query.FieldLineageQuery
+}
+
+// NewGetFieldLineageUseCase は新しいGetFieldLineageUseCaseを作成する
+func NewGetFieldLineageUseCase(lineageQuery query.FieldLineageQuery) *GetFieldLineageUseCase {
+	return &GetFieldLineageUseCase{
+		lineageQuery: lineageQuery,
+	}
+}
+
+// Execute は圃場の祖先・子孫を辿った系譜グラフを取得する
+// depthがnilの場合はDefaultLineageDepth世代まで辿る
+func (uc *GetFieldLineageUseCase) Execute(ctx context.Context, id uuid.UUID, depth *int) (*query.FieldLineage, error) {
+	maxDepth := DefaultLineageDepth
+	if depth != nil {
+		maxDepth = *depth
+	}
+	if maxDepth < 1 || maxDepth > MaxLineageDepth {
+		return nil, apperror.BadRequestError(fmt.Sprintf("depthは1から%dの範囲で指定してください", MaxLineageDepth))
+	}
+
+	lineage, err := uc.lineageQuery.FindLineage(ctx, id, maxDepth)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("圃場系譜の取得に失敗しました", err)
+	}
+	if lineage == nil {
+		return nil, apperror.NotFoundError("圃場が見つかりません")
+	}
+	return lineage, nil
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFieldLineageQuery はFieldLineageQueryのモック実装
+type mockFieldLineageQuery struct {
+	lineage *query.FieldLineage
+	err     error
+
+	// 呼び出し時の引数を記録
+	maxDepth int
+}
+
+func (m *mockFieldLineageQuery) FindLineage(_ context.Context, _ uuid.UUID, maxDepth int) (*query.FieldLineage, error) {
+	m.maxDepth = maxDepth
+	return m.lineage, m.err
+}
+
+func TestGetFieldLineageUseCase_Execute_Success(t *testing.T) {
+	lineage := &query.FieldLineage{FieldID: uuid.New()}
+	depth := 3
+
+	tests := []struct {
+		name      string
+		depth     *int
+		wantDepth int
+	}{
+		{name: "depth未指定はデフォルト値", depth: nil, wantDepth: DefaultLineageDepth},
+		{name: "depth指定", depth: &depth, wantDepth: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockFieldLineageQuery{lineage: lineage}
+			uc := NewGetFieldLineageUseCase(mock)
+
+			got, err := uc.Execute(context.Background(), lineage.FieldID, tt.depth)
+
+			require.NoError(t, err, "Executeでエラーが発生")
+			require.Equal(t, lineage, got, "系譜が一致しない")
+			require.Equal(t, tt.wantDepth, mock.maxDepth, "辿る世代数が期待値と異なります")
+		})
+	}
+}
+
+func TestGetFieldLineageUseCase_Execute_InvalidDepth(t *testing.T) {
+	for _, depth := range []int{0, MaxLineageDepth + 1} {
+		uc := NewGetFieldLineageUseCase(&mockFieldLineageQuery{})
+
+		_, err := uc.Execute(context.Background(), uuid.New(), &depth)
+
+		require.Error(t, err, "depth=%dでエラーを期待", depth)
+		require.Equal(t, http.StatusBadRequest, errorStatus(err), "BadRequestエラーを期待")
+	}
+}
+
+func TestGetFieldLineageUseCase_Execute_NotFound(t *testing.T) {
+	uc := NewGetFieldLineageUseCase(&mockFieldLineageQuery{})
+
+	_, err := uc.Execute(context.Background(), uuid.New(), nil)
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusNotFound, errorStatus(err), "NotFoundエラーを期待")
+}
+
+func TestGetFieldLineageUseCase_Execute_QueryError(t *testing.T) {
+	uc := NewGetFieldLineageUseCase(&mockFieldLineageQuery{err: errors.New("db error")})
+
+	_, err := uc.Execute(context.Background(), uuid.New(), nil)
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusInternalServerError, errorStatus(err), "InternalServerErrorを期待")
+}
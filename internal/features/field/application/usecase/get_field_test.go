@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFieldUseCase_Execute_Success(t *testing.T) {
+	id := uuid.New()
+	detail := &query.FieldDetail{Field: entity.NewField(id, "163210")}
+	uc := NewGetFieldUseCase(&mockFieldQuery{detail: detail})
+
+	got, err := uc.Execute(context.Background(), id)
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, detail, got, "圃場詳細が期待値と異なります")
+}
+
+func TestGetFieldUseCase_Execute_NotFound(t *testing.T) {
+	uc := NewGetFieldUseCase(&mockFieldQuery{})
+
+	_, err := uc.Execute(context.Background(), uuid.New())
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusNotFound, errorStatus(err), "NotFoundエラーを期待")
+}
+
+func TestGetFieldUseCase_Execute_QueryError(t *testing.T) {
+	uc := NewGetFieldUseCase(&mockFieldQuery{detailErr: errors.New("db error")})
+
+	_, err := uc.Execute(context.Background(), uuid.New())
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusInternalServerError, errorStatus(err), "InternalErrorを期待")
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/repository"
+)
+
+// GetFieldTileUseCase は圃場ベクタータイル取得のユースケース
+type GetFieldTileUseCase struct {
+	tileQuery query.FieldTileQuery
+	tileCache repository.FieldTileCacheRepository
+	logger    *slog.Logger
+}
+
+// NewGetFieldTileUseCase は新しいGetFieldTileUseCaseを作成する
+// tileCacheがnilの場合はキャッシュを使用しない
+func NewGetFieldTileUseCase(
+	tileQuery query.FieldTileQuery,
+	tileCache repository.FieldTileCacheRepository,
+	logger *slog.Logger,
+) *GetFieldTileUseCase {
+	return &GetFieldTileUseCase{
+		tileQuery: tileQuery,
+		tileCache: tileCache,
+		logger:    logger,
+	}
+}
+
+// Execute は指定タイルの圃場をMapbox Vector Tile形式で取得する
+func (uc *GetFieldTileUseCase) Execute(ctx context.Context, z, x, y int) ([]byte, error) {
+	tile, err := entity.NewTile(z, x, y)
+	if err != nil {
+		return nil, apperror.BadRequestError(err.Error())
+	}
+
+	useCache := uc.tileCache != nil && tile.Cacheable()
+
+	// 1. キャッシュから取得を試みる
+	if useCache {
+		data, found, err := uc.tileCache.GetTile(ctx, tile)
+		if err != nil {
+			uc.logger.Warn("タイルキャッシュからの取得に失敗しました",
+				slog.Int("z", tile.Z), slog.Int("x", tile.X), slog.Int("y", tile.Y),
+				slog.String("error", err.Error()))
+		} else if found {
+			return data, nil
+		}
+	}
+
+	// 2. DBからタイルを生成
+	data, err := uc.tileQuery.GetTile(ctx, tile)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("タイルの生成に失敗しました", err)
+	}
+
+	// 3. キャッシュに保存(失敗してもレスポンスは返す)
+	if useCache {
+		if err := uc.tileCache.SetTile(ctx, tile, data); err != nil {
+			uc.logger.Warn("タイルキャッシュへの保存に失敗しました",
+				slog.Int("z", tile.Z), slog.Int("x", tile.X), slog.Int("y", tile.Y),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	return data, nil
+}
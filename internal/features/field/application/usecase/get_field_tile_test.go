@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFieldTileQuery はFieldTileQueryのモック実装
+type mockFieldTileQuery struct {
+	data []byte
+	err  error
+
+	calls int
+}
+
+func (m *mockFieldTileQuery) GetTile(_ context.Context, _ entity.Tile) ([]byte, error) {
+	m.calls++
+	return m.data, m.err
+}
+
+// mockFieldTileCache はFieldTileCacheRepositoryのモック実装
+type mockFieldTileCache struct {
+	tiles  map[entity.Tile][]byte
+	getErr error
+	setErr error
+}
+
+func newMockFieldTileCache() *mockFieldTileCache {
+	return &mockFieldTileCache{tiles: make(map[entity.Tile][]byte)}
+}
+
+func (m *mockFieldTileCache) GetTile(_ context.Context, tile entity.Tile) ([]byte, bool, error) {
+	if m.getErr != nil {
+		return nil, false, m.getErr
+	}
+	data, ok := m.tiles[tile]
+	return data, ok, nil
+}
+
+func (m *mockFieldTileCache) SetTile(_ context.Context, tile entity.Tile, data []byte) error {
+	if m.setErr != nil {
+		return m.setErr
+	}
+	m.tiles[tile] = data
+	return nil
+}
+
+func (m *mockFieldTileCache) DeleteByH3Cells(_ context.Context, _ []string) error {
+	return nil
+}
+
+func (m *mockFieldTileCache) DeleteAll(_ context.Context) error {
+	return nil
+}
+
+func TestGetFieldTileUseCase_Execute_CacheMissThenHit(t *testing.T) {
+	tileQuery := &mockFieldTileQuery{data: []byte("mvt")}
+	cache := newMockFieldTileCache()
+	uc := NewGetFieldTileUseCase(tileQuery, cache, getTestLogger())
+
+	got, err := uc.Execute(context.Background(), 15, 29104, 12902)
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, []byte("mvt"), got, "タイルが期待値と異なります")
+	require.Contains(t, cache.tiles, entity.Tile{Z: 15, X: 29104, Y: 12902}, "生成したタイルがキャッシュされるべき")
+
+	got, err = uc.Execute(context.Background(), 15, 29104, 12902)
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, []byte("mvt"), got, "キャッシュのタイルが返されるべき")
+	require.Equal(t, 1, tileQuery.calls, "キャッシュヒット時はDBを参照すべきでない")
+}
+
+func TestGetFieldTileUseCase_Execute_EmptyTileCached(t *testing.T) {
+	tileQuery := &mockFieldTileQuery{data: []byte{}}
+	cache := newMockFieldTileCache()
+	uc := NewGetFieldTileUseCase(tileQuery, cache, getTestLogger())
+
+	for range 2 {
+		got, err := uc.Execute(context.Background(), 14, 0, 0)
+		require.NoError(t, err, "Executeでエラーが発生")
+		require.Empty(t, got, "空のタイルが返されるべき")
+	}
+	require.Equal(t, 1, tileQuery.calls, "空のタイルもキャッシュされるべき")
+}
+
+func TestGetFieldTileUseCase_Execute_HighZoomNotCached(t *testing.T) {
+	tileQuery := &mockFieldTileQuery{data: []byte("mvt")}
+	cache := newMockFieldTileCache()
+	uc := NewGetFieldTileUseCase(tileQuery, cache, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), entity.MaxCachedTileZoom+1, 0, 0)
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Empty(t, cache.tiles, "キャッシュ対象外のズームレベルはキャッシュすべきでない")
+}
+
+func TestGetFieldTileUseCase_Execute_CacheErrorIgnored(t *testing.T) {
+	cache := newMockFieldTileCache()
+	cache.getErr = errors.New("redis error")
+	cache.setErr = errors.New("redis error")
+	uc := NewGetFieldTileUseCase(&mockFieldTileQuery{data: []byte("mvt")}, cache, getTestLogger())
+
+	got, err := uc.Execute(context.Background(), 14, 0, 0)
+
+	require.NoError(t, err, "キャッシュの失敗はタイル取得の失敗とすべきでない")
+	require.Equal(t, []byte("mvt"), got, "DBから生成したタイルが返されるべき")
+}
+
+func TestGetFieldTileUseCase_Execute_InvalidTile(t *testing.T) {
+	tileQuery := &mockFieldTileQuery{}
+	uc := NewGetFieldTileUseCase(tileQuery, nil, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), 13, 0, 0)
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusBadRequest, errorStatus(err), "BadRequestエラーを期待")
+	require.Zero(t, tileQuery.calls, "不正なタイル座標ではDBを参照すべきでない")
+}
+
+func TestGetFieldTileUseCase_Execute_QueryError(t *testing.T) {
+	uc := NewGetFieldTileUseCase(&mockFieldTileQuery{err: errors.New("db error")}, nil, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), 14, 0, 0)
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusInternalServerError, errorStatus(err), "InternalErrorを期待")
+}
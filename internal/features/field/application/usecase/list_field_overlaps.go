@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+)
+
+// ListFieldOverlapsInput はオーバーラップ一覧取得の入力
+type ListFieldOverlapsInput struct {
+	Limit   *int
+	Offset  *int
+	Status  *string
+	FieldID *uuid.UUID
+}
+
+// ListFieldOverlapsOutput はオーバーラップ一覧取得の出力
+type ListFieldOverlapsOutput struct {
+	Overlaps []*entity.FieldOverlap
+	Total    int64
+}
+
+// ListFieldOverlapsUseCase はオーバーラップ一覧取得のユースケース
+type ListFieldOverlapsUseCase struct {
+	overlapQuery query.FieldOverlapQuery
+}
+
+// NewListFieldOverlapsUseCase は新しいListFieldOverlapsUseCaseを作成する
+func NewListFieldOverlapsUseCase(overlapQuery query.FieldOverlapQuery) *ListFieldOverlapsUseCase {
+	return &ListFieldOverlapsUseCase{
+		overlapQuery: overlapQuery,
+	}
+}
+
+// Execute はオーバーラップ一覧を取得する
+func (uc *ListFieldOverlapsUseCase) Execute(ctx context.Context, input ListFieldOverlapsInput) (*ListFieldOverlapsOutput, error) {
+	limit, offset, err := resolvePaging(input.Limit, input.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := query.FieldOverlapFilter{FieldID: input.FieldID}
+	if status := normalizeString(input.Status); status != nil {
+		s := entity.OverlapStatus(strings.ToLower(*status))
+		if !s.IsValid() {
+			return nil, apperror.BadRequestError("statusはopen, accepted, clippedのいずれかを指定してください")
+		}
+		filter.Status = &s
+	}
+
+	overlaps, err := uc.overlapQuery.List(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("オーバーラップ一覧の取得に失敗しました", err)
+	}
+
+	total, err := uc.overlapQuery.Count(ctx, filter)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("オーバーラップの総数の取得に失敗しました", err)
+	}
+
+	return &ListFieldOverlapsOutput{
+		Overlaps: overlaps,
+		Total:    total,
+	}, nil
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFieldOverlapQuery はFieldOverlapQueryのモック実装
+type mockFieldOverlapQuery struct {
+	overlaps []*entity.FieldOverlap
+	total    int64
+	listErr  error
+	countErr error
+
+	// 呼び出し時の引数を記録
+	gotFilter query.FieldOverlapFilter
+	gotLimit  int32
+	gotOffset int32
+}
+
+func (m *mockFieldOverlapQuery) List(_ context.Context, filter query.FieldOverlapFilter, limit, offset int32) ([]*entity.FieldOverlap, error) {
+	m.gotFilter = filter
+	m.gotLimit = limit
+	m.gotOffset = offset
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.overlaps, nil
+}
+
+func (m *mockFieldOverlapQuery) Count(_ context.Context, _ query.FieldOverlapFilter) (int64, error) {
+	if m.countErr != nil {
+		return 0, m.countErr
+	}
+	return m.total, nil
+}
+
+func TestListFieldOverlapsUseCase_Execute_Filters(t *testing.T) {
+	overlaps := []*entity.FieldOverlap{{ID: uuid.New(), Status: entity.OverlapStatusOpen}}
+	mock := &mockFieldOverlapQuery{overlaps: overlaps, total: 1}
+	uc := NewListFieldOverlapsUseCase(mock)
+	fieldID := uuid.New()
+
+	got, err := uc.Execute(context.Background(), ListFieldOverlapsInput{
+		Limit:   intPtr(50),
+		Offset:  intPtr(10),
+		Status:  stringPtr(" Open "),
+		FieldID: &fieldID,
+	})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, overlaps, got.Overlaps, "オーバーラップ一覧が一致しない")
+	require.Equal(t, int64(1), got.Total, "総数が一致しない")
+	require.Equal(t, int32(50), mock.gotLimit, "limitが一致しない")
+	require.Equal(t, int32(10), mock.gotOffset, "offsetが一致しない")
+	require.NotNil(t, mock.gotFilter.Status, "ステータス条件が設定されていない")
+	require.Equal(t, entity.OverlapStatusOpen, *mock.gotFilter.Status, "ステータスが正規化されていない")
+	require.Equal(t, &fieldID, mock.gotFilter.FieldID, "圃場ID条件が一致しない")
+}
+
+func TestListFieldOverlapsUseCase_Execute_NoFilter(t *testing.T) {
+	mock := &mockFieldOverlapQuery{}
+	uc := NewListFieldOverlapsUseCase(mock)
+
+	_, err := uc.Execute(context.Background(), ListFieldOverlapsInput{Status: stringPtr(" ")})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Nil(t, mock.gotFilter.Status, "空のステータスは絞り込みに使用すべきでない")
+	require.Equal(t, int32(DefaultListLimit), mock.gotLimit, "デフォルトのlimitが適用されていない")
+}
+
+func TestListFieldOverlapsUseCase_Execute_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      ListFieldOverlapsInput
+		query      *mockFieldOverlapQuery
+		wantStatus int
+	}{
+		{
+			name:       "不正なステータス",
+			input:      ListFieldOverlapsInput{Status: stringPtr("resolved")},
+			query:      &mockFieldOverlapQuery{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "limitが範囲外",
+			input:      ListFieldOverlapsInput{Limit: intPtr(0)},
+			query:      &mockFieldOverlapQuery{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "一覧取得エラー",
+			query:      &mockFieldOverlapQuery{listErr: errors.New("db error")},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "総数取得エラー",
+			query:      &mockFieldOverlapQuery{countErr: errors.New("db error")},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewListFieldOverlapsUseCase(tt.query)
+
+			_, err := uc.Execute(context.Background(), tt.input)
+
+			require.Error(t, err, "エラーを期待")
+			require.Equal(t, tt.wantStatus, errorStatus(err), "HTTPステータスが期待値と異なります")
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Package usecase は圃場機能のユースケースを提供する
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/utils"
+)
+
+const (
+	// DefaultListLimit は圃場一覧のデフォルト取得件数
+	DefaultListLimit = 20
+	// MaxListLimit は圃場一覧の最大取得件数
+	MaxListLimit = 100
+)
+
+// ListFieldsInput は圃場一覧取得の入力
+type ListFieldsInput struct {
+	Limit              *int
+	Offset             *int
+	CityCode           *string
+	SoilTypeSmallCode  *string
+	LandCategoryCode   *string
+	IdleLandStatusCode *string
+	MinAreaSqm         *float64
+	MaxAreaSqm         *float64
+	SWLat              *float64
+	SWLng              *float64
+	NELat              *float64
+	NELng              *float64
+	Name               *string
+}
+
+// ListFieldsOutput は圃場一覧取得の出力
+type ListFieldsOutput struct {
+	Fields []*entity.Field
+	Total  int64
+}
+
+// ListFieldsUseCase は圃場一覧取得のユースケース
+type ListFieldsUseCase struct {
+	fieldQuery query.FieldQuery
+}
+
+// NewListFieldsUseCase は新しいListFieldsUseCaseを作成する
+func NewListFieldsUseCase(fieldQuery query.FieldQuery) *ListFieldsUseCase {
+	return &ListFieldsUseCase{
+		fieldQuery: fieldQuery,
+	}
+}
+
+// Execute は圃場一覧を取得する
+func (uc *ListFieldsUseCase) Execute(ctx context.Context, input ListFieldsInput) (*ListFieldsOutput, error) {
+	limit, offset, err := resolvePaging(input.Limit, input.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := buildFieldListFilter(input)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := uc.fieldQuery.List(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("圃場一覧の取得に失敗しました", err)
+	}
+
+	total, err := uc.fieldQuery.Count(ctx, filter)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("圃場の総数の取得に失敗しました", err)
+	}
+
+	return &ListFieldsOutput{
+		Fields: fields,
+		Total:  total,
+	}, nil
+}
+
+// resolvePaging はlimit/offsetにデフォルト値を適用してバリデーションする
+func resolvePaging(limit, offset *int) (int32, int32, error) {
+	l := DefaultListLimit
+	if limit != nil {
+		l = *limit
+	}
+	if l < 1 || l > MaxListLimit {
+		return 0, 0, apperror.BadRequestError("limitは1から100の範囲で指定してください")
+	}
+
+	o := 0
+	if offset != nil {
+		o = *offset
+	}
+	if o < 0 {
+		return 0, 0, apperror.BadRequestError("offsetは0以上で指定してください")
+	}
+
+	return utils.SafeIntToInt32(l), utils.SafeIntToInt32(o), nil
+}
+
+// buildFieldListFilter は入力値から検索条件を構築する
+func buildFieldListFilter(input ListFieldsInput) (query.FieldListFilter, error) {
+	filter := query.FieldListFilter{
+		CityCode:           normalizeString(input.CityCode),
+		SoilTypeSmallCode:  normalizeString(input.SoilTypeSmallCode),
+		LandCategoryCode:   normalizeString(input.LandCategoryCode),
+		IdleLandStatusCode: normalizeString(input.IdleLandStatusCode),
+		MinAreaSqm:         input.MinAreaSqm,
+		MaxAreaSqm:         input.MaxAreaSqm,
+		Name:               normalizeString(input.Name),
+	}
+	if filter.Name != nil {
+		pattern := "%" + escapeLikePattern(*filter.Name) + "%"
+		filter.NamePattern = &pattern
+	}
+
+	if filter.MinAreaSqm != nil && *filter.MinAreaSqm < 0 {
+		return filter, apperror.BadRequestError("最小面積は0以上で指定してください")
+	}
+	if filter.MaxAreaSqm != nil && *filter.MaxAreaSqm < 0 {
+		return filter, apperror.BadRequestError("最大面積は0以上で指定してください")
+	}
+	if filter.MinAreaSqm != nil && filter.MaxAreaSqm != nil && *filter.MinAreaSqm > *filter.MaxAreaSqm {
+		return filter, apperror.BadRequestError("最小面積は最大面積以下で指定してください")
+	}
+
+	bbox, err := buildBoundingBox(input.SWLat, input.SWLng, input.NELat, input.NELng)
+	if err != nil {
+		return filter, err
+	}
+	filter.BoundingBox = bbox
+
+	return filter, nil
+}
+
+// buildBoundingBox は4つの座標からバウンディングボックスを構築する
+// 全て未指定の場合はnilを返し、一部のみ指定された場合はエラーとする
+// 南西端の経度が北東端の経度より大きい場合は日付変更線をまたぐ範囲として扱う
+func buildBoundingBox(swLat, swLng, neLat, neLng *float64) (*query.BoundingBox, error) {
+	if swLat == nil && swLng == nil && neLat == nil && neLng == nil {
+		return nil, nil
+	}
+	if swLat == nil || swLng == nil || neLat == nil || neLng == nil {
+		return nil, apperror.BadRequestError("バウンディングボックスはsw_lat, sw_lng, ne_lat, ne_lngを全て指定してください")
+	}
+	if *swLat < -90 || *swLat > 90 || *neLat < -90 || *neLat > 90 {
+		return nil, apperror.BadRequestError("緯度は-90から90の範囲で指定してください")
+	}
+	if *swLng < -180 || *swLng > 180 || *neLng < -180 || *neLng > 180 {
+		return nil, apperror.BadRequestError("経度は-180から180の範囲で指定してください")
+	}
+	if *swLat > *neLat {
+		return nil, apperror.BadRequestError("南西端の緯度は北東端の緯度より小さくしてください")
+	}
+	return &query.BoundingBox{
+		SWLat: *swLat,
+		SWLng: *swLng,
+		NELat: *neLat,
+		NELng: *neLng,
+	}, nil
+}
+
+// likePatternEscaper はLIKEのワイルドカードとエスケープ文字をエスケープする
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLikePattern はキーワードをLIKEのパターン中で文字どおりに一致するようエスケープする
+// エスケープ文字はクエリのESCAPE句と同じバックスラッシュ
+func escapeLikePattern(s string) string {
+	return likePatternEscaper.Replace(s)
+}
+
+// normalizeString は前後の空白を除去し、空文字列の場合はnilを返す
+func normalizeString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
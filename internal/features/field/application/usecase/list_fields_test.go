@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFieldQuery はFieldQueryのモック実装
+type mockFieldQuery struct {
+	fields   []*entity.Field
+	total    int64
+	listErr  error
+	countErr error
+
+	detail    *query.FieldDetail
+	detailErr error
+
+	// 呼び出し時の引数を記録
+	gotFilter query.FieldListFilter
+	gotLimit  int32
+	gotOffset int32
+}
+
+func (m *mockFieldQuery) List(_ context.Context, filter query.FieldListFilter, limit, offset int32) ([]*entity.Field, error) {
+	m.gotFilter = filter
+	m.gotLimit = limit
+	m.gotOffset = offset
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.fields, nil
+}
+
+func (m *mockFieldQuery) Count(_ context.Context, _ query.FieldListFilter) (int64, error) {
+	if m.countErr != nil {
+		return 0, m.countErr
+	}
+	return m.total, nil
+}
+
+func (m *mockFieldQuery) FindDetailByID(_ context.Context, _ uuid.UUID) (*query.FieldDetail, error) {
+	if m.detailErr != nil {
+		return nil, m.detailErr
+	}
+	return m.detail, nil
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func stringPtr(v string) *string {
+	return &v
+}
+
+// errorStatus はエラーのHTTPステータスを返す(AppError以外は0)
+func errorStatus(err error) int {
+	var appErr apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.HTTPStatus()
+	}
+	return 0
+}
+
+func TestListFieldsUseCase_Execute_Defaults(t *testing.T) {
+	fields := []*entity.Field{entity.NewField(uuid.New(), "163210")}
+	mock := &mockFieldQuery{fields: fields, total: 1}
+	uc := NewListFieldsUseCase(mock)
+
+	output, err := uc.Execute(context.Background(), ListFieldsInput{})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Len(t, output.Fields, 1, "圃場数が期待値と異なります")
+	require.Equal(t, int64(1), output.Total, "総数が期待値と異なります")
+	require.Equal(t, int32(DefaultListLimit), mock.gotLimit, "デフォルトのlimitが適用されていない")
+	require.Equal(t, int32(0), mock.gotOffset, "デフォルトのoffsetが適用されていない")
+	require.Nil(t, mock.gotFilter.BoundingBox, "バウンディングボックスはnilであるべき")
+}
+
+func TestListFieldsUseCase_Execute_Filters(t *testing.T) {
+	mock := &mockFieldQuery{}
+	uc := NewListFieldsUseCase(mock)
+
+	_, err := uc.Execute(context.Background(), ListFieldsInput{
+		Limit:              intPtr(50),
+		Offset:             intPtr(10),
+		CityCode:           stringPtr("163210"),
+		SoilTypeSmallCode:  stringPtr("F3a7t4"),
+		LandCategoryCode:   stringPtr("01"),
+		IdleLandStatusCode: stringPtr(" "),
+		MinAreaSqm:         floatPtr(100),
+		MaxAreaSqm:         floatPtr(1000),
+		SWLat:              floatPtr(36.0),
+		SWLng:              floatPtr(137.0),
+		NELat:              floatPtr(37.0),
+		NELng:              floatPtr(138.0),
+		Name:               stringPtr("  東町  "),
+	})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, int32(50), mock.gotLimit, "limitが期待値と異なります")
+	require.Equal(t, int32(10), mock.gotOffset, "offsetが期待値と異なります")
+
+	filter := mock.gotFilter
+	require.Equal(t, "163210", *filter.CityCode, "市区町村コードが期待値と異なります")
+	require.Equal(t, "F3a7t4", *filter.SoilTypeSmallCode, "土壌小分類コードが期待値と異なります")
+	require.Equal(t, "01", *filter.LandCategoryCode, "土地種別コードが期待値と異なります")
+	require.Nil(t, filter.IdleLandStatusCode, "空白のみの条件はnilになるべき")
+	require.Equal(t, "東町", *filter.Name, "圃場名の前後空白が除去されていない")
+	require.Equal(t, "%東町%", *filter.NamePattern, "部分一致のパターンが期待値と異なります")
+	require.Equal(t, &query.BoundingBox{SWLat: 36.0, SWLng: 137.0, NELat: 37.0, NELng: 138.0}, filter.BoundingBox, "バウンディングボックスが期待値と異なります")
+}
+
+func TestListFieldsUseCase_Execute_NamePatternEscaped(t *testing.T) {
+	mock := &mockFieldQuery{}
+	uc := NewListFieldsUseCase(mock)
+
+	_, err := uc.Execute(context.Background(), ListFieldsInput{Name: stringPtr(`100%_A\B`)})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, `100%_A\B`, *mock.gotFilter.Name, "類似度検索のキーワードはエスケープしないべき")
+	require.Equal(t, `%100\%\_A\\B%`, *mock.gotFilter.NamePattern, "ワイルドカードとエスケープ文字がエスケープされていない")
+}
+
+func TestListFieldsUseCase_Execute_BoundingBoxCrossingAntimeridian(t *testing.T) {
+	mock := &mockFieldQuery{}
+	uc := NewListFieldsUseCase(mock)
+
+	_, err := uc.Execute(context.Background(), ListFieldsInput{
+		SWLat: floatPtr(-20),
+		SWLng: floatPtr(170),
+		NELat: floatPtr(-10),
+		NELng: floatPtr(-170),
+	})
+
+	require.NoError(t, err, "日付変更線をまたぐ範囲はエラーにしないべき")
+	require.Equal(t, &query.BoundingBox{SWLat: -20, SWLng: 170, NELat: -10, NELng: -170}, mock.gotFilter.BoundingBox, "バウンディングボックスが期待値と異なります")
+}
+
+func TestListFieldsUseCase_Execute_ValidationError(t *testing.T) {
+	tests := []struct {
+		name  string
+		input ListFieldsInput
+	}{
+		{"limit 0", ListFieldsInput{Limit: intPtr(0)}},
+		{"limit 101", ListFieldsInput{Limit: intPtr(101)}},
+		{"offset -1", ListFieldsInput{Offset: intPtr(-1)}},
+		{"min_area negative", ListFieldsInput{MinAreaSqm: floatPtr(-1)}},
+		{"max_area negative", ListFieldsInput{MaxAreaSqm: floatPtr(-1)}},
+		{"min_area greater than max_area", ListFieldsInput{MinAreaSqm: floatPtr(200), MaxAreaSqm: floatPtr(100)}},
+		{"bbox partial", ListFieldsInput{SWLat: floatPtr(36.0), SWLng: floatPtr(137.0)}},
+		{"bbox lat out of range", ListFieldsInput{SWLat: floatPtr(-91), SWLng: floatPtr(137), NELat: floatPtr(37), NELng: floatPtr(138)}},
+		{"bbox lng out of range", ListFieldsInput{SWLat: floatPtr(36), SWLng: floatPtr(137), NELat: floatPtr(37), NELng: floatPtr(181)}},
+		{"bbox sw_lat greater than ne_lat", ListFieldsInput{SWLat: floatPtr(38), SWLng: floatPtr(137), NELat: floatPtr(37), NELng: floatPtr(138)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewListFieldsUseCase(&mockFieldQuery{})
+
+			_, err := uc.Execute(context.Background(), tt.input)
+
+			require.Error(t, err, "バリデーションエラーを期待")
+			require.Equal(t, http.StatusBadRequest, errorStatus(err), "BadRequestエラーを期待")
+		})
+	}
+}
+
+func TestListFieldsUseCase_Execute_QueryError(t *testing.T) {
+	tests := []struct {
+		name string
+		mock *mockFieldQuery
+	}{
+		{"list error", &mockFieldQuery{listErr: errors.New("db error")}},
+		{"count error", &mockFieldQuery{countErr: errors.New("db error")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewListFieldsUseCase(tt.mock)
+
+			_, err := uc.Execute(context.Background(), ListFieldsInput{})
+
+			require.Error(t, err, "エラーを期待")
+			require.Equal(t, http.StatusInternalServerError, errorStatus(err), "InternalErrorを期待")
+		})
+	}
+}
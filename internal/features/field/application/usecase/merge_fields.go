@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/repository"
+)
+
+// MergeFieldsInput は合筆の入力
+type MergeFieldsInput struct {
+	SourceIDs []uuid.UUID
+	Reason    *string
+	UserID    *uuid.UUID // 操作ユーザー(不明な場合はnil)
+}
+
+// MergeFieldsOutput は合筆の出力
+type MergeFieldsOutput struct {
+	SourceIDs []uuid.UUID
+	MergedAt  time.Time
+	Reason    *string
+	Field     *query.FieldDetail // 作成された合筆先圃場
+}
+
+// MergeFieldsUseCase は圃場の合筆のユースケース
+type MergeFieldsUseCase struct {
+	fieldRepo          repository.FieldRepository
+	mergerRepo         repository.FieldMergerRepository
+	fieldQuery         query.FieldQuery
+	overlapRepo        repository.FieldOverlapRepository
+	clusterJobEnqueuer ClusterJobEnqueuer
+	logger             *slog.Logger
+}
+
+// NewMergeFieldsUseCase は新しいMergeFieldsUseCaseを作成する
+func NewMergeFieldsUseCase(
+	fieldRepo repository.FieldRepository,
+	mergerRepo repository.FieldMergerRepository,
+	fieldQuery query.FieldQuery,
+	overlapRepo repository.FieldOverlapRepository,
+	clusterJobEnqueuer ClusterJobEnqueuer,
+	logger *slog.Logger,
+) *MergeFieldsUseCase {
+	return &MergeFieldsUseCase{
+		fieldRepo:          fieldRepo,
+		mergerRepo:         mergerRepo,
+		fieldQuery:         fieldQuery,
+		overlapRepo:        overlapRepo,
+		clusterJobEnqueuer: clusterJobEnqueuer,
+		logger:             logger,
+	}
+}
+
+// Execute は隣接する複数の圃場を1つの圃場に合筆し、合筆先圃場の詳細を返す
+// ソース圃場は削除せず廃止状態とし、合筆履歴から系譜を辿れるようにする
+func (uc *MergeFieldsUseCase) Execute(ctx context.Context, input MergeFieldsInput) (*MergeFieldsOutput, error) {
+	// 1. ソース圃場を取得(H3セルの差分更新と土壌タイプの引き継ぎに使用)
+	sources := make([]*entity.Field, 0, len(input.SourceIDs))
+	for _, id := range input.SourceIDs {
+		source, err := uc.fieldRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, apperror.InternalErrorWithCause("圃場の取得に失敗しました", err)
+		}
+		if source == nil {
+			return nil, apperror.NotFoundError("圃場が見つかりません: " + id.String())
+		}
+		sources = append(sources, source)
+	}
+
+	merger, err := entity.NewMerger(sources, normalizeString(input.Reason), input.UserID)
+	if err != nil {
+		if errors.Is(err, entity.ErrFieldRetired) {
+			return nil, apperror.ConflictError(err.Error())
+		}
+		return nil, apperror.BadRequestError(err.Error())
+	}
+
+	// 2. 永続化(ジオメトリ結合・合筆先圃場の作成・履歴記録・農地台帳の引き継ぎ・ソース圃場の廃止)
+	if err := uc.mergerRepo.Merge(ctx, merger); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrFieldRetired):
+			return nil, apperror.ConflictError(err.Error())
+		case errors.Is(err, entity.ErrMergeSourceNotFound):
+			return nil, apperror.NotFoundError(err.Error())
+		case errors.Is(err, entity.ErrMergeSourcesNotAdjacent):
+			return nil, apperror.BadRequestError(err.Error())
+		}
+		return nil, apperror.InternalErrorWithCause("圃場の合筆に失敗しました", err)
+	}
+
+	uc.logger.Info("圃場を合筆しました",
+		slog.String("field_id", merger.Result.ID.String()),
+		slog.Int("sources", len(sources)))
+
+	// 3. ソース圃場と合筆先圃場のセルをまとめて差分更新
+	cellGroups := [][]string{merger.Result.AffectedH3Cells()}
+	for _, source := range sources {
+		cellGroups = append(cellGroups, source.AffectedH3Cells())
+	}
+	enqueueAffectedCells(ctx, uc.clusterJobEnqueuer, uc.logger, merger.Result.ID, cellGroups...)
+
+	// 4. 合筆先圃場の重なりを検出(廃止したソース圃場の記録は検出対象に含めることで削除される)
+	detectOverlaps(ctx, uc.overlapRepo, uc.logger, append([]uuid.UUID{merger.Result.ID}, merger.SourceIDs()...)...)
+
+	detail, err := findSavedDetail(ctx, uc.fieldQuery, merger.Result.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	mergedAt := merger.MergedAt
+	if len(merger.Records) > 0 {
+		mergedAt = merger.Records[0].MergedAt
+	}
+	return &MergeFieldsOutput{
+		SourceIDs: merger.SourceIDs(),
+		MergedAt:  mergedAt,
+		Reason:    merger.Reason,
+		Field:     detail,
+	}, nil
+}
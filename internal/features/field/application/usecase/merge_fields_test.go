@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFieldMergerRepository はFieldMergerRepositoryのモック実装
+// 成功時はソース圃場を覆う矩形を結合結果として設定する
+type mockFieldMergerRepository struct {
+	err error
+
+	// 呼び出し時の引数を記録
+	merged *entity.Merger
+}
+
+func (m *mockFieldMergerRepository) Merge(_ context.Context, merger *entity.Merger) error {
+	m.merged = merger
+	if m.err != nil {
+		return m.err
+	}
+	polygon, err := entity.NewPolygonFromCoordinates(squareCoordinates(137.0, 36.0, 0.002))
+	if err != nil {
+		return err
+	}
+	if err := merger.Result.SetGeometry(polygon); err != nil {
+		return err
+	}
+	for _, id := range merger.SourceIDs() {
+		merger.Records = append(merger.Records, &entity.FieldMerger{
+			ID:            uuid.New(),
+			MergedFieldID: merger.Result.ID,
+			SourceFieldID: id,
+			MergedAt:      merger.MergedAt,
+			Reason:        merger.Reason,
+		})
+	}
+	return nil
+}
+
+// newMergeSources は隣接する2つのソース圃場を作成し、IDで引けるモックリポジトリを返す
+func newMergeSources(t *testing.T) ([]*entity.Field, *mockFieldRepository) {
+	t.Helper()
+	sources := make([]*entity.Field, 0, 2)
+	fields := make(map[uuid.UUID]*entity.Field)
+	for i, lng := range []float64{137.0, 137.001} {
+		polygon, err := entity.NewPolygonFromCoordinates(squareCoordinates(lng, 36.0, 0.001))
+		require.NoError(t, err, "テスト用ポリゴンの作成に失敗")
+		field := entity.NewField(uuid.New(), "163210")
+		require.NoError(t, field.SetGeometry(polygon), "テスト用ジオメトリの設定に失敗")
+		area := float64(100 * (i + 1))
+		field.AreaSqm = &area
+		soilTypeID := uuid.New()
+		field.SoilTypeID = &soilTypeID
+		sources = append(sources, field)
+		fields[field.ID] = field
+	}
+	return sources, &mockFieldRepository{fields: fields}
+}
+
+func TestMergeFieldsUseCase_Execute_Success(t *testing.T) {
+	sources, fieldRepo := newMergeSources(t)
+	mergerRepo := &mockFieldMergerRepository{}
+	enqueuer := &mockClusterJobEnqueuer{}
+	detail := &query.FieldDetail{}
+	overlapRepo := &mockFieldOverlapRepository{}
+	uc := NewMergeFieldsUseCase(fieldRepo, mergerRepo, &mockFieldQuery{detail: detail}, overlapRepo, enqueuer, getTestLogger())
+	userID := uuid.New()
+
+	got, err := uc.Execute(context.Background(), MergeFieldsInput{
+		SourceIDs: []uuid.UUID{sources[0].ID, sources[1].ID},
+		Reason:    stringPtr("合筆"),
+		UserID:    &userID,
+	})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, []uuid.UUID{sources[0].ID, sources[1].ID}, got.SourceIDs, "ソース圃場IDが一致しない")
+	require.Equal(t, "合筆", *got.Reason, "理由が一致しない")
+	require.False(t, got.MergedAt.IsZero(), "合筆日時が設定されていない")
+	require.Equal(t, detail, got.Field, "合筆先圃場の詳細が返されるべき")
+
+	merger := mergerRepo.merged
+	require.NotNil(t, merger, "Mergeが呼ばれていない")
+	require.Equal(t, "163210", merger.Result.CityCode, "市区町村コードが引き継がれていない")
+	require.Equal(t, sources[1].SoilTypeID, merger.Result.SoilTypeID, "面積最大のソース圃場の土壌タイプを引き継ぐべき")
+	require.Equal(t, &userID, merger.Result.CreatedBy, "created_byが設定されていない")
+
+	expectedCells := merger.Result.AffectedH3Cells()
+	for _, source := range sources {
+		expectedCells = append(expectedCells, source.AffectedH3Cells()...)
+	}
+	require.False(t, enqueuer.enqueueCalled, "全範囲再計算ではなく差分更新をエンキューすべき")
+	for _, cell := range expectedCells {
+		require.Contains(t, enqueuer.affectedCells, cell, "ソース圃場と合筆先圃場のセルがエンキューされるべき")
+	}
+	require.Equal(t, []uuid.UUID{merger.Result.ID, sources[0].ID, sources[1].ID}, overlapRepo.detectedFieldIDs,
+		"合筆先圃場と廃止したソース圃場の重なりを検出すべき")
+}
+
+func TestMergeFieldsUseCase_Execute_Error(t *testing.T) {
+	retiredAt := time.Now()
+
+	tests := []struct {
+		name       string
+		setup      func(sources []*entity.Field)
+		sourceIDs  func(sources []*entity.Field) []uuid.UUID
+		mergeErr   error
+		wantStatus int
+	}{
+		{
+			name:       "ソース圃場が1件",
+			sourceIDs:  func(s []*entity.Field) []uuid.UUID { return []uuid.UUID{s[0].ID} },
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "同じ圃場を重複指定",
+			sourceIDs:  func(s []*entity.Field) []uuid.UUID { return []uuid.UUID{s[0].ID, s[0].ID} },
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "市区町村が異なる",
+			setup:      func(s []*entity.Field) { s[1].CityCode = "163220" },
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "存在しない圃場",
+			sourceIDs:  func(s []*entity.Field) []uuid.UUID { return []uuid.UUID{s[0].ID, uuid.New()} },
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "廃止済みの圃場",
+			setup:      func(s []*entity.Field) { s[1].RetiredAt = &retiredAt },
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "隣接していない",
+			mergeErr:   fmt.Errorf("%w: 結合結果が2個のポリゴンに分かれています", entity.ErrMergeSourcesNotAdjacent),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "同時に廃止された",
+			mergeErr:   fmt.Errorf("%w: %s", entity.ErrFieldRetired, uuid.New()),
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "同時に削除された",
+			mergeErr:   entity.ErrMergeSourceNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "DBエラー",
+			mergeErr:   errors.New("db error"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources, fieldRepo := newMergeSources(t)
+			if tt.setup != nil {
+				tt.setup(sources)
+			}
+			ids := []uuid.UUID{sources[0].ID, sources[1].ID}
+			if tt.sourceIDs != nil {
+				ids = tt.sourceIDs(sources)
+			}
+			enqueuer := &mockClusterJobEnqueuer{}
+			uc := NewMergeFieldsUseCase(fieldRepo, &mockFieldMergerRepository{err: tt.mergeErr}, &mockFieldQuery{}, &mockFieldOverlapRepository{}, enqueuer, getTestLogger())
+
+			_, err := uc.Execute(context.Background(), MergeFieldsInput{SourceIDs: ids})
+
+			require.Error(t, err, "エラーを期待")
+			require.Equal(t, tt.wantStatus, errorStatus(err), "HTTPステータスが期待値と異なります")
+			require.False(t, enqueuer.enqueueCalled, "合筆失敗時はエンキューすべきでない")
+			require.Nil(t, enqueuer.affectedCells, "合筆失敗時はエンキューすべきでない")
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/repository"
+)
+
+// OverlapResolveAction はオーバーラップの対応方法
+type OverlapResolveAction string
+
+const (
+	// OverlapResolveActionAccept は重なりを許容する
+	OverlapResolveActionAccept OverlapResolveAction = "accept"
+	// OverlapResolveActionClip は一方の圃場から重なり部分を取り除く
+	OverlapResolveActionClip OverlapResolveAction = "clip"
+)
+
+// ResolveFieldOverlapInput はオーバーラップ対応の入力
+type ResolveFieldOverlapInput struct {
+	ID          uuid.UUID
+	Action      OverlapResolveAction
+	ClipFieldID *uuid.UUID // クリップする圃場(Actionがclipの場合は必須)
+	Note        *string
+	UserID      *uuid.UUID // 操作ユーザー(不明な場合はnil)
+}
+
+// ResolveFieldOverlapUseCase はオーバーラップ対応のユースケース
+type ResolveFieldOverlapUseCase struct {
+	fieldRepo          repository.FieldRepository
+	overlapRepo        repository.FieldOverlapRepository
+	clusterJobEnqueuer ClusterJobEnqueuer
+	logger             *slog.Logger
+}
+
+// NewResolveFieldOverlapUseCase は新しいResolveFieldOverlapUseCaseを作成する
+func NewResolveFieldOverlapUseCase(
+	fieldRepo repository.FieldRepository,
+	overlapRepo repository.FieldOverlapRepository,
+	clusterJobEnqueuer ClusterJobEnqueuer,
+	logger *slog.Logger,
+) *ResolveFieldOverlapUseCase {
+	return &ResolveFieldOverlapUseCase{
+		fieldRepo:          fieldRepo,
+		overlapRepo:        overlapRepo,
+		clusterJobEnqueuer: clusterJobEnqueuer,
+		logger:             logger,
+	}
+}
+
+// Execute はオーバーラップを許容済みにするか、一方の圃場をクリップして解消し、対応後のオーバーラップを返す
+func (uc *ResolveFieldOverlapUseCase) Execute(ctx context.Context, input ResolveFieldOverlapInput) (*entity.FieldOverlap, error) {
+	if input.Action != OverlapResolveActionAccept && input.Action != OverlapResolveActionClip {
+		return nil, apperror.BadRequestError("actionはacceptまたはclipを指定してください")
+	}
+	if input.Action == OverlapResolveActionClip && input.ClipFieldID == nil {
+		return nil, apperror.BadRequestError("clipの場合はclipFieldIdを指定してください")
+	}
+
+	overlap, err := uc.overlapRepo.FindByID(ctx, input.ID)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("オーバーラップの取得に失敗しました", err)
+	}
+	if overlap == nil {
+		return nil, apperror.NotFoundError("オーバーラップが見つかりません")
+	}
+
+	note := normalizeString(input.Note)
+	if input.Action == OverlapResolveActionAccept {
+		return uc.accept(ctx, overlap, note, input.UserID)
+	}
+	return uc.clip(ctx, overlap, *input.ClipFieldID, note, input.UserID)
+}
+
+// accept はオーバーラップを許容済みにする
+func (uc *ResolveFieldOverlapUseCase) accept(ctx context.Context, overlap *entity.FieldOverlap, note *string, userID *uuid.UUID) (*entity.FieldOverlap, error) {
+	if err := overlap.Accept(note, userID); err != nil {
+		return nil, apperror.ConflictError(err.Error())
+	}
+	if err := uc.overlapRepo.Accept(ctx, overlap); err != nil {
+		if errors.Is(err, entity.ErrOverlapAlreadyClipped) {
+			return nil, apperror.ConflictError(err.Error())
+		}
+		return nil, apperror.InternalErrorWithCause("オーバーラップの更新に失敗しました", err)
+	}
+
+	uc.logger.Info("オーバーラップを許容しました",
+		slog.String("overlap_id", overlap.ID.String()))
+	return overlap, nil
+}
+
+// clip は指定した圃場から重なり部分を取り除いてオーバーラップを解消する
+func (uc *ResolveFieldOverlapUseCase) clip(ctx context.Context, overlap *entity.FieldOverlap, fieldID uuid.UUID, note *string, userID *uuid.UUID) (*entity.FieldOverlap, error) {
+	if err := overlap.Clip(fieldID, note, userID); err != nil {
+		if errors.Is(err, entity.ErrClipTargetNotInOverlap) {
+			return nil, apperror.BadRequestError(err.Error())
+		}
+		return nil, apperror.ConflictError(err.Error())
+	}
+
+	field, err := uc.fieldRepo.FindByID(ctx, fieldID)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("圃場の取得に失敗しました", err)
+	}
+	if field == nil {
+		return nil, apperror.NotFoundError("圃場が見つかりません")
+	}
+	if field.IsRetired() {
+		return nil, apperror.ConflictError(entity.ErrFieldRetired.Error())
+	}
+
+	oldCells := field.AffectedH3Cells()
+	field.UpdatedBy = userID
+	if err := uc.overlapRepo.Clip(ctx, overlap, field); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrFieldRetired), errors.Is(err, entity.ErrOverlapAlreadyClipped):
+			return nil, apperror.ConflictError(err.Error())
+		case errors.Is(err, entity.ErrClipResultInvalid):
+			return nil, apperror.BadRequestError(err.Error())
+		}
+		return nil, apperror.InternalErrorWithCause("圃場のクリップに失敗しました", err)
+	}
+
+	uc.logger.Info("圃場をクリップしてオーバーラップを解消しました",
+		slog.String("overlap_id", overlap.ID.String()),
+		slog.String("field_id", field.ID.String()))
+
+	// 形状が変わったため、クラスターの差分更新と他の圃場との重なりの再検出を行う
+	enqueueAffectedCells(ctx, uc.clusterJobEnqueuer, uc.logger, field.ID, oldCells, field.AffectedH3Cells())
+	detectOverlaps(ctx, uc.overlapRepo, uc.logger, field.ID)
+
+	return overlap, nil
+}
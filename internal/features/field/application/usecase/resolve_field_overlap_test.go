@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+// newOpenOverlap は指定圃場を当事者に含む未対応のオーバーラップを作成する
+func newOpenOverlap(fieldID uuid.UUID) *entity.FieldOverlap {
+	return &entity.FieldOverlap{
+		ID:             uuid.New(),
+		FieldIDA:       fieldID,
+		FieldIDB:       uuid.New(),
+		OverlapAreaSqm: 25,
+		OverlapRatio:   0.2,
+		Status:         entity.OverlapStatusOpen,
+	}
+}
+
+func TestResolveFieldOverlapUseCase_Execute_Accept(t *testing.T) {
+	overlap := newOpenOverlap(uuid.New())
+	overlapRepo := &mockFieldOverlapRepository{overlap: overlap}
+	enqueuer := &mockClusterJobEnqueuer{}
+	uc := NewResolveFieldOverlapUseCase(&mockFieldRepository{}, overlapRepo, enqueuer, getTestLogger())
+	userID := uuid.New()
+
+	got, err := uc.Execute(context.Background(), ResolveFieldOverlapInput{
+		ID:     overlap.ID,
+		Action: OverlapResolveActionAccept,
+		Note:   stringPtr(" 登記上の重複 "),
+		UserID: &userID,
+	})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, entity.OverlapStatusAccepted, got.Status, "許容済みになっていない")
+	require.Equal(t, "登記上の重複", *got.Note, "備考がトリムされていない")
+	require.Equal(t, &userID, got.ResolvedBy, "対応者が設定されていない")
+	require.Equal(t, overlap, overlapRepo.accepted, "Acceptが呼ばれていない")
+	require.Nil(t, overlapRepo.clippedField, "許容時はクリップすべきでない")
+	require.Nil(t, enqueuer.affectedCells, "許容時はエンキューすべきでない")
+}
+
+func TestResolveFieldOverlapUseCase_Execute_Clip(t *testing.T) {
+	field := newExistingField(t)
+	oldCells := field.AffectedH3Cells()
+	overlap := newOpenOverlap(field.ID)
+	overlapRepo := &mockFieldOverlapRepository{overlap: overlap}
+	enqueuer := &mockClusterJobEnqueuer{}
+	uc := NewResolveFieldOverlapUseCase(&mockFieldRepository{field: field}, overlapRepo, enqueuer, getTestLogger())
+	userID := uuid.New()
+
+	got, err := uc.Execute(context.Background(), ResolveFieldOverlapInput{
+		ID:          overlap.ID,
+		Action:      OverlapResolveActionClip,
+		ClipFieldID: &field.ID,
+		UserID:      &userID,
+	})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, entity.OverlapStatusClipped, got.Status, "クリップ済みになっていない")
+	require.Equal(t, &field.ID, got.ClippedFieldID, "クリップした圃場が設定されていない")
+	require.Equal(t, field, overlapRepo.clippedField, "クリップ対象の圃場が一致しない")
+	require.Equal(t, &userID, field.UpdatedBy, "updated_byが設定されていない")
+
+	for _, cell := range append(oldCells, field.AffectedH3Cells()...) {
+		require.Contains(t, enqueuer.affectedCells, cell, "クリップ前後のセルがエンキューされるべき")
+	}
+	require.Equal(t, []uuid.UUID{field.ID}, overlapRepo.detectedFieldIDs, "クリップした圃場の重なりを再検出すべき")
+}
+
+func TestResolveFieldOverlapUseCase_Execute_Error(t *testing.T) {
+	retiredAt := time.Now()
+
+	tests := []struct {
+		name       string
+		action     OverlapResolveAction
+		clipTarget func(field *entity.Field) *uuid.UUID
+		setup      func(field *entity.Field, overlap *entity.FieldOverlap, repo *mockFieldOverlapRepository, fieldRepo *mockFieldRepository)
+		wantStatus int
+	}{
+		{
+			name:       "不正なaction",
+			action:     "delete",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "clipでclipFieldId未指定",
+			action:     OverlapResolveActionClip,
+			clipTarget: func(_ *entity.Field) *uuid.UUID { return nil },
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "オーバーラップが存在しない",
+			action: OverlapResolveActionAccept,
+			setup: func(_ *entity.Field, _ *entity.FieldOverlap, repo *mockFieldOverlapRepository, _ *mockFieldRepository) {
+				repo.overlap = nil
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "オーバーラップ取得エラー",
+			action: OverlapResolveActionAccept,
+			setup: func(_ *entity.Field, _ *entity.FieldOverlap, repo *mockFieldOverlapRepository, _ *mockFieldRepository) {
+				repo.findErr = errors.New("db error")
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:   "許容済みを再度許容",
+			action: OverlapResolveActionAccept,
+			setup: func(_ *entity.Field, overlap *entity.FieldOverlap, _ *mockFieldOverlapRepository, _ *mockFieldRepository) {
+				overlap.Status = entity.OverlapStatusAccepted
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:   "許容中に同時にクリップされた",
+			action: OverlapResolveActionAccept,
+			setup: func(_ *entity.Field, _ *entity.FieldOverlap, repo *mockFieldOverlapRepository, _ *mockFieldRepository) {
+				repo.acceptErr = entity.ErrOverlapAlreadyClipped
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "当事者以外の圃場をクリップ",
+			action:     OverlapResolveActionClip,
+			clipTarget: func(_ *entity.Field) *uuid.UUID { id := uuid.New(); return &id },
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "クリップ済み",
+			action: OverlapResolveActionClip,
+			setup: func(_ *entity.Field, overlap *entity.FieldOverlap, _ *mockFieldOverlapRepository, _ *mockFieldRepository) {
+				overlap.Status = entity.OverlapStatusClipped
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:   "クリップ対象の圃場が存在しない",
+			action: OverlapResolveActionClip,
+			setup: func(_ *entity.Field, _ *entity.FieldOverlap, _ *mockFieldOverlapRepository, fieldRepo *mockFieldRepository) {
+				fieldRepo.field = nil
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "クリップ対象の圃場が廃止済み",
+			action: OverlapResolveActionClip,
+			setup: func(field *entity.Field, _ *entity.FieldOverlap, _ *mockFieldOverlapRepository, _ *mockFieldRepository) {
+				field.RetiredAt = &retiredAt
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:   "クリップ結果が分断される",
+			action: OverlapResolveActionClip,
+			setup: func(_ *entity.Field, _ *entity.FieldOverlap, repo *mockFieldOverlapRepository, _ *mockFieldRepository) {
+				repo.clipErr = fmt.Errorf("%w: %s", entity.ErrClipResultInvalid, uuid.New())
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "クリップ中に同時に廃止された",
+			action: OverlapResolveActionClip,
+			setup: func(_ *entity.Field, _ *entity.FieldOverlap, repo *mockFieldOverlapRepository, _ *mockFieldRepository) {
+				repo.clipErr = fmt.Errorf("%w: %s", entity.ErrFieldRetired, uuid.New())
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:   "クリップ時のDBエラー",
+			action: OverlapResolveActionClip,
+			setup: func(_ *entity.Field, _ *entity.FieldOverlap, repo *mockFieldOverlapRepository, _ *mockFieldRepository) {
+				repo.clipErr = errors.New("db error")
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := newExistingField(t)
+			overlap := newOpenOverlap(field.ID)
+			overlapRepo := &mockFieldOverlapRepository{overlap: overlap}
+			fieldRepo := &mockFieldRepository{field: field}
+			if tt.setup != nil {
+				tt.setup(field, overlap, overlapRepo, fieldRepo)
+			}
+			var clipFieldID *uuid.UUID
+			if tt.action == OverlapResolveActionClip {
+				clipFieldID = &field.ID
+				if tt.clipTarget != nil {
+					clipFieldID = tt.clipTarget(field)
+				}
+			}
+			enqueuer := &mockClusterJobEnqueuer{}
+			uc := NewResolveFieldOverlapUseCase(fieldRepo, overlapRepo, enqueuer, getTestLogger())
+
+			_, err := uc.Execute(context.Background(), ResolveFieldOverlapInput{
+				ID:          overlap.ID,
+				Action:      tt.action,
+				ClipFieldID: clipFieldID,
+			})
+
+			require.Error(t, err, "エラーを期待")
+			require.Equal(t, tt.wantStatus, errorStatus(err), "HTTPステータスが期待値と異なります")
+			require.Nil(t, enqueuer.affectedCells, "対応失敗時はエンキューすべきでない")
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/repository"
+	"github.com/twpayne/go-geom"
+)
+
+// UpdateFieldInput は圃場更新の入力
+// nilの項目は変更しない
+type UpdateFieldInput struct {
+	ID          uuid.UUID
+	CityCode    *string
+	Name        *string
+	Coordinates [][][]float64 // GeoJSON Polygonの座標
+	UserID      *uuid.UUID    // 操作ユーザー(不明な場合はnil)
+}
+
+// UpdateFieldUseCase は圃場の手動更新のユースケース
+type UpdateFieldUseCase struct {
+	fieldRepo          repository.FieldRepository
+	fieldQuery         query.FieldQuery
+	overlapRepo        repository.FieldOverlapRepository
+	clusterJobEnqueuer ClusterJobEnqueuer
+	logger             *slog.Logger
+}
+
+// NewUpdateFieldUseCase は新しいUpdateFieldUseCaseを作成する
+func NewUpdateFieldUseCase(
+	fieldRepo repository.FieldRepository,
+	fieldQuery query.FieldQuery,
+	overlapRepo repository.FieldOverlapRepository,
+	clusterJobEnqueuer ClusterJobEnqueuer,
+	logger *slog.Logger,
+) *UpdateFieldUseCase {
+	return &UpdateFieldUseCase{
+		fieldRepo:          fieldRepo,
+		fieldQuery:         fieldQuery,
+		overlapRepo:        overlapRepo,
+		clusterJobEnqueuer: clusterJobEnqueuer,
+		logger:             logger,
+	}
+}
+
+// Execute は圃場を部分更新し、更新後の圃場詳細を返す
+func (uc *UpdateFieldUseCase) Execute(ctx context.Context, input UpdateFieldInput) (*query.FieldDetail, error) {
+	// 1. 入力のバリデーション
+	if input.CityCode == nil && input.Name == nil && input.Coordinates == nil {
+		return nil, apperror.BadRequestError("更新する項目が指定されていません")
+	}
+	if input.CityCode != nil && strings.TrimSpace(*input.CityCode) == "" {
+		return nil, apperror.BadRequestError("市区町村コードは空にできません")
+	}
+	if input.Name != nil && strings.TrimSpace(*input.Name) == "" {
+		return nil, apperror.BadRequestError("圃場名は空にできません")
+	}
+
+	var polygon *geom.Polygon
+	if input.Coordinates != nil {
+		p, err := entity.NewPolygonFromCoordinates(input.Coordinates)
+		if err != nil {
+			return nil, apperror.BadRequestError("ジオメトリが不正です: " + err.Error())
+		}
+		polygon = p
+	}
+
+	// 2. 既存の圃場を取得
+	field, err := uc.fieldRepo.FindByID(ctx, input.ID)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("圃場の取得に失敗しました", err)
+	}
+	if field == nil {
+		return nil, apperror.NotFoundError("圃場が見つかりません")
+	}
+	// 廃止済みの圃場は系譜の一部として保持するため変更させない
+	// (取得後に分筆・合筆された場合はリポジトリが更新時に検出する)
+	if field.IsRetired() {
+		return nil, apperror.ConflictError(entity.ErrFieldRetired.Error())
+	}
+
+	// 3. 変更を適用
+	oldCells := field.AffectedH3Cells()
+	geometryChanged := polygon != nil
+	if geometryChanged {
+		if err := field.SetGeometry(polygon); err != nil {
+			return nil, apperror.BadRequestError("H3インデックスの計算に失敗しました: " + err.Error())
+		}
+	}
+	cityCodeChanged := false
+	if input.CityCode != nil {
+		cityCode := strings.TrimSpace(*input.CityCode)
+		cityCodeChanged = cityCode != field.CityCode
+		field.CityCode = cityCode
+	}
+	if input.Name != nil {
+		field.Name = strings.TrimSpace(*input.Name)
+	}
+	field.UpdatedBy = input.UserID
+
+	// 4. 永続化
+	if err := uc.fieldRepo.Update(ctx, field); err != nil {
+		if errors.Is(err, entity.ErrFieldRetired) {
+			return nil, apperror.ConflictError(entity.ErrFieldRetired.Error())
+		}
+		return nil, apperror.InternalErrorWithCause("圃場の更新に失敗しました", err)
+	}
+
+	uc.logger.Info("圃場を更新しました",
+		slog.String("field_id", field.ID.String()))
+
+	// 5. ジオメトリか市区町村コードが変わった場合は、移動元と移動先のセルの差分更新を行う
+	// 圃場統計はクラスタージョブの完了後に再集計されるため、市区町村コードのみの変更でもジョブをエンキューする
+	if geometryChanged || cityCodeChanged {
+		enqueueAffectedCells(ctx, uc.clusterJobEnqueuer, uc.logger, field.ID, oldCells, field.AffectedH3Cells())
+	}
+	// ジオメトリが変わった場合のみ重なりを再検出する
+	if geometryChanged {
+		detectOverlaps(ctx, uc.overlapRepo, uc.logger, field.ID)
+	}
+
+	return findSavedDetail(ctx, uc.fieldQuery, field.ID)
+}
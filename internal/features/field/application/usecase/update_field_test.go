@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+// newExistingField はジオメトリ設定済みの既存圃場を作成する
+func newExistingField(t *testing.T) *entity.Field {
+	t.Helper()
+	polygon, err := entity.NewPolygonFromCoordinates(squareCoordinates(137.0, 36.0, 0.001))
+	require.NoError(t, err, "テスト用ポリゴンの作成に失敗")
+
+	field := entity.NewField(uuid.New(), "163210")
+	require.NoError(t, field.SetGeometry(polygon), "テスト用ジオメトリの設定に失敗")
+	return field
+}
+
+func TestUpdateFieldUseCase_Execute_GeometryChanged(t *testing.T) {
+	field := newExistingField(t)
+	oldCells := field.AffectedH3Cells()
+	repo := &mockFieldRepository{field: field}
+	enqueuer := &mockClusterJobEnqueuer{}
+	overlapRepo := &mockFieldOverlapRepository{}
+	uc := NewUpdateFieldUseCase(repo, &mockFieldQuery{detail: &query.FieldDetail{}}, overlapRepo, enqueuer, getTestLogger())
+	userID := uuid.New()
+
+	// 別の地域(異なるres3セル)に移動
+	_, err := uc.Execute(context.Background(), UpdateFieldInput{
+		ID:          field.ID,
+		Coordinates: squareCoordinates(139.7, 35.6, 0.001),
+		UserID:      &userID,
+	})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.NotNil(t, repo.updated, "Updateが呼ばれていない")
+	require.Equal(t, &userID, repo.updated.UpdatedBy, "updated_byが設定されていない")
+	newCells := repo.updated.AffectedH3Cells()
+	require.NotEqual(t, oldCells, newCells, "H3インデックスが再計算されていない")
+
+	for _, cell := range append(oldCells, newCells...) {
+		require.Contains(t, enqueuer.affectedCells, cell, "変更前後のセルがエンキューされるべき")
+	}
+	require.Len(t, enqueuer.affectedCells, len(oldCells)+len(newCells), "重複のないセル数が期待値と異なります")
+	require.Equal(t, []uuid.UUID{field.ID}, overlapRepo.detectedFieldIDs, "ジオメトリ変更時は重なりを再検出すべき")
+}
+
+func TestUpdateFieldUseCase_Execute_CityCodeChanged(t *testing.T) {
+	field := newExistingField(t)
+	cells := field.AffectedH3Cells()
+	repo := &mockFieldRepository{field: field}
+	enqueuer := &mockClusterJobEnqueuer{}
+	overlapRepo := &mockFieldOverlapRepository{}
+	uc := NewUpdateFieldUseCase(repo, &mockFieldQuery{detail: &query.FieldDetail{}}, overlapRepo, enqueuer, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), UpdateFieldInput{
+		ID:       field.ID,
+		Name:     stringPtr(" 南圃場 "),
+		CityCode: stringPtr("163220"),
+	})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, "南圃場", repo.updated.Name, "圃場名が更新されていない")
+	require.Equal(t, "163220", repo.updated.CityCode, "市区町村コードが更新されていない")
+	require.False(t, enqueuer.enqueueCalled, "市区町村コードの変更で全範囲再計算すべきでない")
+	require.ElementsMatch(t, cells, enqueuer.affectedCells, "圃場統計を再集計させるため圃場のセルをエンキューすべき")
+	require.Nil(t, overlapRepo.detectedFieldIDs, "ジオメトリ未変更時は重なりを再検出すべきでない")
+}
+
+func TestUpdateFieldUseCase_Execute_AttributesOnly(t *testing.T) {
+	field := newExistingField(t)
+	repo := &mockFieldRepository{field: field}
+	enqueuer := &mockClusterJobEnqueuer{}
+	overlapRepo := &mockFieldOverlapRepository{}
+	uc := NewUpdateFieldUseCase(repo, &mockFieldQuery{detail: &query.FieldDetail{}}, overlapRepo, enqueuer, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), UpdateFieldInput{
+		ID:       field.ID,
+		Name:     stringPtr(" 南圃場 "),
+		CityCode: stringPtr(" 163210 "),
+	})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, "南圃場", repo.updated.Name, "圃場名が更新されていない")
+	require.Equal(t, "163210", repo.updated.CityCode, "市区町村コードが変わるべきでない")
+	require.False(t, enqueuer.enqueueCalled, "統計に影響しない変更で全範囲再計算すべきでない")
+	require.Nil(t, enqueuer.affectedCells, "統計に影響しない変更でエンキューすべきでない")
+	require.Nil(t, overlapRepo.detectedFieldIDs, "ジオメトリ未変更時は重なりを再検出すべきでない")
+}
+
+func TestUpdateFieldUseCase_Execute_ValidationError(t *testing.T) {
+	tests := []struct {
+		name  string
+		input UpdateFieldInput
+	}{
+		{
+			name:  "更新項目なし",
+			input: UpdateFieldInput{ID: uuid.New()},
+		},
+		{
+			name:  "空の圃場名",
+			input: UpdateFieldInput{ID: uuid.New(), Name: stringPtr(" ")},
+		},
+		{
+			name:  "空の市区町村コード",
+			input: UpdateFieldInput{ID: uuid.New(), CityCode: stringPtr("")},
+		},
+		{
+			name: "座標範囲外",
+			input: UpdateFieldInput{
+				ID:          uuid.New(),
+				Coordinates: squareCoordinates(36.0, 137.0, 0.001),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockFieldRepository{field: newExistingField(t)}
+			uc := NewUpdateFieldUseCase(repo, &mockFieldQuery{}, &mockFieldOverlapRepository{}, &mockClusterJobEnqueuer{}, getTestLogger())
+
+			_, err := uc.Execute(context.Background(), tt.input)
+
+			require.Error(t, err, "エラーを期待")
+			require.Equal(t, http.StatusBadRequest, errorStatus(err), "BadRequestエラーを期待")
+			require.Nil(t, repo.updated, "バリデーションエラー時はUpdateを呼ぶべきでない")
+		})
+	}
+}
+
+func TestUpdateFieldUseCase_Execute_NotFound(t *testing.T) {
+	uc := NewUpdateFieldUseCase(&mockFieldRepository{}, &mockFieldQuery{}, &mockFieldOverlapRepository{}, &mockClusterJobEnqueuer{}, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), UpdateFieldInput{ID: uuid.New(), Name: stringPtr("圃場")})
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusNotFound, errorStatus(err), "NotFoundエラーを期待")
+}
+
+func TestUpdateFieldUseCase_Execute_RepositoryError(t *testing.T) {
+	repo := &mockFieldRepository{field: newExistingField(t), updateErr: errors.New("db error")}
+	enqueuer := &mockClusterJobEnqueuer{}
+	uc := NewUpdateFieldUseCase(repo, &mockFieldQuery{}, &mockFieldOverlapRepository{}, enqueuer, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), UpdateFieldInput{
+		ID:          repo.field.ID,
+		Coordinates: squareCoordinates(137.0, 36.0, 0.002),
+	})
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusInternalServerError, errorStatus(err), "InternalErrorを期待")
+	require.Nil(t, enqueuer.affectedCells, "更新失敗時はエンキューすべきでない")
+}
+
+func TestUpdateFieldUseCase_Execute_RetiredField(t *testing.T) {
+	field := newExistingField(t)
+	retiredAt := time.Now()
+	field.RetiredAt = &retiredAt
+	repo := &mockFieldRepository{field: field}
+	uc := NewUpdateFieldUseCase(repo, &mockFieldQuery{}, &mockFieldOverlapRepository{}, &mockClusterJobEnqueuer{}, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), UpdateFieldInput{ID: field.ID, Name: stringPtr("圃場")})
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusConflict, errorStatus(err), "Conflictエラーを期待")
+	require.Nil(t, repo.updated, "廃止済みの圃場はUpdateを呼ぶべきでない")
+}
+
+func TestUpdateFieldUseCase_Execute_RetiredDuringUpdate(t *testing.T) {
+	// 取得後に分筆・合筆で廃止された場合はリポジトリが検出し、Conflictになる
+	field := newExistingField(t)
+	repo := &mockFieldRepository{field: field, updateErr: fmt.Errorf("%w: %s", entity.ErrFieldRetired, field.ID)}
+	enqueuer := &mockClusterJobEnqueuer{}
+	overlapRepo := &mockFieldOverlapRepository{}
+	uc := NewUpdateFieldUseCase(repo, &mockFieldQuery{}, overlapRepo, enqueuer, getTestLogger())
+
+	_, err := uc.Execute(context.Background(), UpdateFieldInput{
+		ID:          field.ID,
+		Coordinates: squareCoordinates(137.0, 36.0, 0.002),
+	})
+
+	require.Error(t, err, "エラーを期待")
+	require.Equal(t, http.StatusConflict, errorStatus(err), "Conflictエラーを期待")
+	require.Nil(t, enqueuer.affectedCells, "更新失敗時はエンキューすべきでない")
+	require.Nil(t, overlapRepo.detectedFieldIDs, "更新失敗時は重なりを再検出すべきでない")
+}
@@ -0,0 +1,210 @@
+package entity
+
+import (
+	"math"
+	"sort"
+
+	"github.com/twpayne/go-geom"
+)
+
+// CentroidMethod は圃場の代表点(重心)の算出方法を表す
+type CentroidMethod string
+
+const (
+	// CentroidMethodAreaWeighted は面積加重重心(ポリゴン内部にある場合に採用)
+	CentroidMethodAreaWeighted CentroidMethod = "area_weighted"
+	// CentroidMethodPointOnSurface はポリゴン内部の点(凹形状で面積加重重心が外側に出る場合に採用)
+	CentroidMethodPointOnSurface CentroidMethod = "point_on_surface"
+	// CentroidMethodVertexMean は頂点の算術平均(面積を持たない退化したポリゴンの場合のみ)
+	CentroidMethodVertexMean CentroidMethod = "vertex_mean"
+)
+
+// CalculateCentroid はH3セルの割り当てに使用するジオメトリの代表点を計算する
+// 算出方法の選択はCalculateRepresentativePointに従う
+func CalculateCentroid(multiPolygon *geom.MultiPolygon) *geom.Point {
+	point, _ := CalculateRepresentativePoint(multiPolygon)
+	return point
+}
+
+// CalculateRepresentativePoint はジオメトリの代表点と、圃場ごとに選択した算出方法を返す
+// 全区画の面積加重重心がいずれかの区画の内部(穴の外側)にあればそれを採用し、L字型・コの字型などの凹形状や
+// 飛び地で外側に出る場合は、面積が最大の区画の内部の点にフォールバックする
+// 圃場規模では経度・緯度をそのまま平面座標とみなしても、緯度方向の縮尺は一定倍率に過ぎず重心の位置は変わらない
+func CalculateRepresentativePoint(multiPolygon *geom.MultiPolygon) (*geom.Point, CentroidMethod) {
+	if multiPolygon == nil || multiPolygon.NumPolygons() == 0 || multiPolygon.NumCoords() == 0 {
+		return nil, ""
+	}
+
+	x, y, ok := areaWeightedCentroid(multiPolygon)
+	if !ok {
+		x, y, ok = vertexMean(multiPolygon)
+		if !ok {
+			return nil, ""
+		}
+		return newXYPoint(x, y), CentroidMethodVertexMean
+	}
+	if containsPoint(multiPolygon, x, y) {
+		return newXYPoint(x, y), CentroidMethodAreaWeighted
+	}
+	if px, py, found := pointOnSurface(largestPolygon(multiPolygon)); found {
+		return newXYPoint(px, py), CentroidMethodPointOnSurface
+	}
+	return newXYPoint(x, y), CentroidMethodAreaWeighted
+}
+
+// newXYPoint はXY座標のPointを作成する
+func newXYPoint(x, y float64) *geom.Point {
+	return geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{x, y})
+}
+
+// ringCoords はリングの座標を閉じるための終点を除いて返す
+func ringCoords(polygon *geom.Polygon, i int) []geom.Coord {
+	coords := polygon.LinearRing(i).Coords()
+	if len(coords) > 1 && coordsEqual(coords[0], coords[len(coords)-1]) {
+		coords = coords[:len(coords)-1]
+	}
+	return coords
+}
+
+// areaWeightedCentroid は全区画の面積加重重心を計算する
+// 内周リング(穴)の面積と一次モーメントは差し引く。桁落ちを防ぐため最初の頂点を原点として計算する
+// 面積が0の場合はokがfalseになる
+func areaWeightedCentroid(multiPolygon *geom.MultiPolygon) (x, y float64, ok bool) {
+	flat := multiPolygon.FlatCoords()
+	originX, originY := flat[0], flat[1]
+
+	var area, momentX, momentY float64
+	for p := 0; p < multiPolygon.NumPolygons(); p++ {
+		polygon := multiPolygon.Polygon(p)
+		for i := 0; i < polygon.NumLinearRings(); i++ {
+			ring := ringCoords(polygon, i)
+			if len(ring) < 3 {
+				continue
+			}
+
+			var a, mx, my float64
+			for j := range ring {
+				x0, y0 := ring[j].X()-originX, ring[j].Y()-originY
+				next := ring[(j+1)%len(ring)]
+				x1, y1 := next.X()-originX, next.Y()-originY
+				cross := x0*y1 - x1*y0
+				a += cross
+				mx += (x0 + x1) * cross
+				my += (y0 + y1) * cross
+			}
+			a /= 2
+			mx /= 6
+			my /= 6
+
+			// 向きに関わらず外周は加算、穴は減算する
+			sign := 1.0
+			if a < 0 {
+				sign = -1.0
+			}
+			if i > 0 {
+				sign = -sign
+			}
+			area += sign * a
+			momentX += sign * mx
+			momentY += sign * my
+		}
+	}
+
+	if area <= 0 || math.IsNaN(area) {
+		return 0, 0, false
+	}
+	return momentX/area + originX, momentY/area + originY, true
+}
+
+// vertexMean は全区画の外周リングの頂点(閉じるための終点を除く)の算術平均を計算する
+func vertexMean(multiPolygon *geom.MultiPolygon) (x, y float64, ok bool) {
+	var sumX, sumY float64
+	var n int
+	for p := 0; p < multiPolygon.NumPolygons(); p++ {
+		polygon := multiPolygon.Polygon(p)
+		if polygon.NumLinearRings() == 0 {
+			continue
+		}
+		for _, c := range ringCoords(polygon, 0) {
+			sumX += c.X()
+			sumY += c.Y()
+			n++
+		}
+	}
+	if n == 0 {
+		return 0, 0, false
+	}
+	return sumX / float64(n), sumY / float64(n), true
+}
+
+// largestPolygon は面積が最大の区画を返す
+func largestPolygon(multiPolygon *geom.MultiPolygon) *geom.Polygon {
+	var largest *geom.Polygon
+	maxArea := -1.0
+	for p := 0; p < multiPolygon.NumPolygons(); p++ {
+		polygon := multiPolygon.Polygon(p)
+		if area := polygon.Area(); area > maxArea {
+			largest, maxArea = polygon, area
+		}
+	}
+	return largest
+}
+
+// containsPoint は点がいずれかの区画の内部(穴の外側)にあるかを偶奇規則で判定する
+// 境界上の点は内部とみなさない場合がある
+func containsPoint(multiPolygon *geom.MultiPolygon, x, y float64) bool {
+	for p := 0; p < multiPolygon.NumPolygons(); p++ {
+		polygon := multiPolygon.Polygon(p)
+		inside := false
+		for i := 0; i < polygon.NumLinearRings(); i++ {
+			ring := ringCoords(polygon, i)
+			for j := range ring {
+				a, b := ring[j], ring[(j+1)%len(ring)]
+				if (a.Y() > y) == (b.Y() > y) {
+					continue
+				}
+				if x < a.X()+(y-a.Y())*(b.X()-a.X())/(b.Y()-a.Y()) {
+					inside = !inside
+				}
+			}
+		}
+		if inside {
+			return true
+		}
+	}
+	return false
+}
+
+// pointOnSurface はポリゴン内部の点を求める
+// 外接矩形の南北中央を通る水平線とリングの交点を求め、内部にある区間のうち最も長い区間の中点を返す
+func pointOnSurface(polygon *geom.Polygon) (x, y float64, ok bool) {
+	if polygon == nil || polygon.NumLinearRings() == 0 {
+		return 0, 0, false
+	}
+	bounds := polygon.Bounds()
+	y = (bounds.Min(1) + bounds.Max(1)) / 2
+
+	xs := make([]float64, 0)
+	for i := 0; i < polygon.NumLinearRings(); i++ {
+		ring := ringCoords(polygon, i)
+		for j := range ring {
+			a, b := ring[j], ring[(j+1)%len(ring)]
+			// 頂点を通る場合の二重計上を避けるため、上端を含まない半開区間で判定する
+			if (a.Y() > y) == (b.Y() > y) {
+				continue
+			}
+			xs = append(xs, a.X()+(y-a.Y())*(b.X()-a.X())/(b.Y()-a.Y()))
+		}
+	}
+	sort.Float64s(xs)
+
+	widest := 0.0
+	for i := 0; i+1 < len(xs); i += 2 {
+		if width := xs[i+1] - xs[i]; width > widest {
+			widest = width
+			x = (xs[i] + xs[i+1]) / 2
+			ok = true
+		}
+	}
+	return x, y, ok
+}
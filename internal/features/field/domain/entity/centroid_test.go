@@ -0,0 +1,157 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+// offsetPolygon は(139.0, 35.0)を原点として0.001度単位の相対座標からポリゴンを作成する
+func offsetPolygon(rings ...[][2]float64) *geom.Polygon {
+	coords := make([][]geom.Coord, len(rings))
+	for i, ring := range rings {
+		for _, p := range ring {
+			coords[i] = append(coords[i], geom.Coord{139.0 + p[0]*0.001, 35.0 + p[1]*0.001})
+		}
+		coords[i] = append(coords[i], coords[i][0])
+	}
+	return geom.NewPolygon(geom.XY).MustSetCoords(coords)
+}
+
+// offsetMultiPolygon はoffsetPolygonで作成したポリゴンを区画とするマルチポリゴンを作成する
+func offsetMultiPolygon(t *testing.T, polygons ...*geom.Polygon) *geom.MultiPolygon {
+	t.Helper()
+	multiPolygon, err := NewMultiPolygon(polygons...)
+	require.NoError(t, err, "マルチポリゴンの作成でエラーが発生")
+	return multiPolygon
+}
+
+// TestCalculateRepresentativePoint は形状に応じて代表点の算出方法を選択し、代表点がポリゴン内部に収まることをテストする
+func TestCalculateRepresentativePoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		polygons   []*geom.Polygon
+		wantMethod CentroidMethod
+		wantX      float64
+		wantY      float64
+	}{
+		{
+			name:       "正方形は中心",
+			polygons:   []*geom.Polygon{offsetPolygon([][2]float64{{0, 0}, {2, 0}, {2, 2}, {0, 2}})},
+			wantMethod: CentroidMethodAreaWeighted,
+			wantX:      1,
+			wantY:      1,
+		},
+		{
+			name:       "時計回りでも同じ重心",
+			polygons:   []*geom.Polygon{offsetPolygon([][2]float64{{0, 0}, {0, 2}, {2, 2}, {2, 0}})},
+			wantMethod: CentroidMethodAreaWeighted,
+			wantX:      1,
+			wantY:      1,
+		},
+		{
+			// 頂点の算術平均は(0.8, 1.0)になり、面積の大きい下側に寄らない
+			name:       "L字型は面積加重重心",
+			polygons:   []*geom.Polygon{offsetPolygon([][2]float64{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}})},
+			wantMethod: CentroidMethodAreaWeighted,
+			wantX:      2.5 / 3,
+			wantY:      2.5 / 3,
+		},
+		{
+			// 面積加重重心(1.5, 1.357)は切り欠き部分に落ちるため内部の点にフォールバックする
+			name:       "コの字型は内部の点",
+			polygons:   []*geom.Polygon{offsetPolygon([][2]float64{{0, 0}, {3, 0}, {3, 3}, {2, 3}, {2, 1}, {1, 1}, {1, 3}, {0, 3}})},
+			wantMethod: CentroidMethodPointOnSurface,
+			wantX:      0.5,
+			wantY:      1.5,
+		},
+		{
+			// 面積加重重心(2, 2)は穴の中にあるため内部の点にフォールバックする
+			name: "穴の中心に重心が落ちるドーナツ型",
+			polygons: []*geom.Polygon{offsetPolygon(
+				[][2]float64{{0, 0}, {4, 0}, {4, 4}, {0, 4}},
+				[][2]float64{{1, 1}, {1, 3}, {3, 3}, {3, 1}},
+			)},
+			wantMethod: CentroidMethodPointOnSurface,
+			wantX:      0.5,
+			wantY:      2,
+		},
+		{
+			name:       "一直線上の頂点は算術平均",
+			polygons:   []*geom.Polygon{offsetPolygon([][2]float64{{0, 0}, {1, 0}, {2, 0}})},
+			wantMethod: CentroidMethodVertexMean,
+			wantX:      1,
+			wantY:      0,
+		},
+		{
+			// 穴の面積を差し引くため、重心は穴と反対側に寄る
+			name: "穴が偏っている場合は穴の分を差し引いた重心",
+			polygons: []*geom.Polygon{offsetPolygon(
+				[][2]float64{{0, 0}, {4, 0}, {4, 2}, {0, 2}},
+				[][2]float64{{2.5, 0.5}, {2.5, 1.5}, {3.5, 1.5}, {3.5, 0.5}},
+			)},
+			wantMethod: CentroidMethodAreaWeighted,
+			wantX:      13.0 / 7,
+			wantY:      1,
+		},
+		{
+			// 全区画の面積加重重心(2.18, 1)は区画の間に落ちるため、面積が最大の区画の内部の点にフォールバックする
+			name: "飛び地は面積が最大の区画の内部の点",
+			polygons: []*geom.Polygon{
+				offsetPolygon([][2]float64{{3, 0}, {4.5, 0}, {4.5, 2}, {3, 2}}),
+				offsetPolygon([][2]float64{{0, 0}, {2, 0}, {2, 2}, {0, 2}}),
+			},
+			wantMethod: CentroidMethodPointOnSurface,
+			wantX:      1,
+			wantY:      1,
+		},
+		{
+			name: "区画が接していれば全体の面積加重重心",
+			polygons: []*geom.Polygon{
+				offsetPolygon([][2]float64{{0, 0}, {2, 0}, {2, 2}, {0, 2}}),
+				offsetPolygon([][2]float64{{2, 0}, {4, 0}, {4, 1}, {2, 1}}),
+			},
+			wantMethod: CentroidMethodAreaWeighted,
+			wantX:      5.0 / 3,
+			wantY:      5.0 / 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			multiPolygon := offsetMultiPolygon(t, tt.polygons...)
+			point, method := CalculateRepresentativePoint(multiPolygon)
+			require.NotNil(t, point, "代表点がnil")
+			require.Equal(t, tt.wantMethod, method, "算出方法が一致しない")
+			require.InDelta(t, 139.0+tt.wantX*0.001, point.X(), 1e-9, "経度が一致しない")
+			require.InDelta(t, 35.0+tt.wantY*0.001, point.Y(), 1e-9, "緯度が一致しない")
+			if method != CentroidMethodVertexMean {
+				require.True(t, containsPoint(multiPolygon, point.X(), point.Y()), "代表点がポリゴンの外側にある")
+			}
+		})
+	}
+
+	t.Run("nil polygon", func(t *testing.T) {
+		point, method := CalculateRepresentativePoint(nil)
+		require.Nil(t, point, "nilのポリゴンは代表点もnilにすべき")
+		require.Empty(t, method, "nilのポリゴンは算出方法も空にすべき")
+	})
+}
+
+// TestField_RecalculateCentroid は代表点の再計算でH3インデックスも代表点のセルに更新されることをテストする
+func TestField_RecalculateCentroid(t *testing.T) {
+	field := NewField(uuid.New(), "163210")
+	field.Geometry = offsetMultiPolygon(t, offsetPolygon([][2]float64{{0, 0}, {3, 0}, {3, 3}, {2, 3}, {2, 1}, {1, 1}, {1, 3}, {0, 3}}))
+
+	method, err := field.RecalculateCentroid()
+	require.NoError(t, err, "RecalculateCentroidでエラーが発生")
+	require.Equal(t, CentroidMethodPointOnSurface, method, "算出方法が一致しない")
+	require.NotNil(t, field.Centroid, "Centroidが設定されていない")
+	require.Len(t, field.H3Indexes(), 1, "H3インデックスが設定されていない")
+
+	expected := NewField(uuid.New(), "163210")
+	require.NoError(t, expected.CalculateH3Index(field.Centroid.Y(), field.Centroid.X()), "CalculateH3Indexでエラーが発生")
+	require.Equal(t, expected.H3Indexes(), field.H3Indexes(), "H3インデックスが代表点のセルと一致しない")
+}
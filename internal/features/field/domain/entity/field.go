@@ -11,23 +11,24 @@ import (
 
 // Field は圃場エンティティ
 type Field struct {
-	ID          uuid.UUID
-	Geometry    *geom.Polygon
-	Centroid    *geom.Point
-	AreaSqm     *float64
-	H3IndexRes3 *string
-	H3IndexRes5 *string
-	H3IndexRes7 *string
-	H3IndexRes9 *string
-	CityCode    string
-	Name        string
-	SoilTypeID  *uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	CreatedBy   *uuid.UUID
-	UpdatedBy   *uuid.UUID
+	ID         uuid.UUID
+	Geometry   *geom.MultiPolygon // 単一区画の圃場も1区画のマルチポリゴンとして保持する
+	Centroid   *geom.Point
+	AreaSqm    *float64
+	H3Index    *string // 代表点の解像度15のH3インデックス(各解像度のセルはH3IndexAtで求める)
+	CityCode   string
+	Name       string
+	SoilTypeID *uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	CreatedBy  *uuid.UUID
+	UpdatedBy  *uuid.UUID
+	RetiredAt  *time.Time // 分筆・合筆による廃止日時(有効な圃場はnil)
 }
 
+// FinestH3Resolution は圃場に保持するH3インデックスの解像度
+const FinestH3Resolution = 15
+
 // NewField は新しいFieldを作成する
 func NewField(id uuid.UUID, cityCode string) *Field {
 	now := time.Now()
@@ -40,60 +41,119 @@ func NewField(id uuid.UUID, cityCode string) *Field {
 	}
 }
 
-// SetGeometry はジオメトリを設定し、関連する値も計算する
+// SetGeometry は単一区画のポリゴンをジオメトリに設定し、関連する値も計算する
 func (f *Field) SetGeometry(polygon *geom.Polygon) error {
-	f.Geometry = polygon
-
-	// Centroidを計算
-	if polygon != nil {
-		centroid := CalculateCentroid(polygon)
-		f.Centroid = centroid
-
-		// H3インデックスを計算
-		if centroid != nil {
-			lat := centroid.Y()
-			lng := centroid.X()
-			if err := f.CalculateH3Indexes(lat, lng); err != nil {
-				return err
-			}
+	if polygon == nil {
+		return f.SetMultiPolygon(nil)
+	}
+	multiPolygon, err := NewMultiPolygon(polygon)
+	if err != nil {
+		return err
+	}
+	return f.SetMultiPolygon(multiPolygon)
+}
+
+// SetMultiPolygon は複数区画からなるジオメトリを設定し、関連する値も計算する
+func (f *Field) SetMultiPolygon(multiPolygon *geom.MultiPolygon) error {
+	f.Geometry = multiPolygon
+
+	if multiPolygon != nil {
+		if _, err := f.RecalculateCentroid(); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-// CalculateH3Indexes はH3インデックスを計算する
-func (f *Field) CalculateH3Indexes(lat, lng float64) error {
-	latLng := h3.NewLatLng(lat, lng)
+// RecalculateCentroid は現在のジオメトリから代表点とH3インデックスを計算し直し、採用した算出方法を返す
+func (f *Field) RecalculateCentroid() (CentroidMethod, error) {
+	centroid, method := CalculateRepresentativePoint(f.Geometry)
+	f.Centroid = centroid
 
-	cell3, err := h3.LatLngToCell(latLng, 3)
-	if err != nil {
-		return fmt.Errorf("H3インデックス(res3)の計算に失敗: %w", err)
+	// H3インデックスを計算
+	if centroid != nil {
+		if err := f.CalculateH3Index(centroid.Y(), centroid.X()); err != nil {
+			return method, err
+		}
 	}
-	s3 := cell3.String()
-	f.H3IndexRes3 = &s3
+	return method, nil
+}
 
-	cell5, err := h3.LatLngToCell(latLng, 5)
+// CalculateH3Index は代表点の座標から解像度15のH3インデックスを計算する
+func (f *Field) CalculateH3Index(lat, lng float64) error {
+	cell, err := h3.LatLngToCell(h3.NewLatLng(lat, lng), FinestH3Resolution)
 	if err != nil {
-		return fmt.Errorf("H3インデックス(res5)の計算に失敗: %w", err)
+		return fmt.Errorf("H3インデックスの計算に失敗: %w", err)
 	}
-	s5 := cell5.String()
-	f.H3IndexRes5 = &s5
+	h3Index := cell.String()
+	f.H3Index = &h3Index
+	return nil
+}
 
-	cell7, err := h3.LatLngToCell(latLng, 7)
+// H3IndexAt は指定解像度のH3インデックス(H3Indexの親セル)を返す
+// H3Indexが未設定、または解像度が範囲外の場合はnilを返す
+func (f *Field) H3IndexAt(resolution int) *string {
+	if f.H3Index == nil || *f.H3Index == "" {
+		return nil
+	}
+	parent, err := h3.CellFromString(*f.H3Index).Parent(resolution)
 	if err != nil {
-		return fmt.Errorf("H3インデックス(res7)の計算に失敗: %w", err)
+		return nil
+	}
+	h3Index := parent.String()
+	return &h3Index
+}
+
+// H3Indexes は設定済みのH3インデックスを返す
+// クラスター計算では各解像度の親セルを求めるため、解像度15のセルのみを返す
+func (f *Field) H3Indexes() []string {
+	if f.H3Index == nil || *f.H3Index == "" {
+		return []string{}
 	}
-	s7 := cell7.String()
-	f.H3IndexRes7 = &s7
+	return []string{*f.H3Index}
+}
 
-	cell9, err := h3.LatLngToCell(latLng, 9)
-	if err != nil {
-		return fmt.Errorf("H3インデックス(res9)の計算に失敗: %w", err)
+// AffectedH3Cells は圃場の追加・変更・削除でクラスターの再計算とタイルの無効化の対象になるH3セルを返す
+//
+// 代表点の解像度15のセルに加えて、各区画の外周と重なるタイル無効化用の解像度のセルを返す。
+// 代表点のセルだけでは、大きな圃場のうち代表点から離れた部分を含むタイルが無効化されないため。
+// ジオメトリが未設定の区画やセルの充填に失敗した区画は、代表点のセルのみで代替する
+func (f *Field) AffectedH3Cells() []string {
+	cells := f.H3Indexes()
+	if f.Geometry == nil {
+		return cells
+	}
+
+	seen := make(map[h3.Cell]struct{})
+	for i := 0; i < f.Geometry.NumPolygons(); i++ {
+		polygon := f.Geometry.Polygon(i)
+		if polygon.NumLinearRings() == 0 {
+			continue
+		}
+		coords := polygon.LinearRing(0).Coords()
+		loop := make(h3.GeoLoop, 0, len(coords))
+		for _, c := range coords {
+			loop = append(loop, h3.NewLatLng(c.Y(), c.X()))
+		}
+
+		covering, err := h3.PolygonToCellsExperimental(h3.GeoPolygon{GeoLoop: loop}, tileH3Resolution, h3.ContainmentOverlapping)
+		if err != nil {
+			continue
+		}
+		for _, cell := range covering {
+			if _, dup := seen[cell]; dup {
+				continue
+			}
+			seen[cell] = struct{}{}
+			cells = append(cells, cell.String())
+		}
 	}
-	s9 := cell9.String()
-	f.H3IndexRes9 = &s9
+	return cells
+}
 
-	return nil
+// IsRetired は分筆・合筆・削除により廃止済みかを判定する
+func (f *Field) IsRetired() bool {
+	return f.RetiredAt != nil
 }
 
 // SetSoilType は土壌タイプIDを設定する
@@ -101,39 +161,57 @@ func (f *Field) SetSoilType(soilTypeID uuid.UUID) {
 	f.SoilTypeID = &soilTypeID
 }
 
-// CalculateCentroid はポリゴンの重心を計算する
-func CalculateCentroid(polygon *geom.Polygon) *geom.Point {
-	if polygon == nil || polygon.NumCoords() == 0 {
-		return nil
+// ConvertLinearPolygonToPolygon はLinearPolygon(wagri形式)をPolygonに変換する
+func ConvertLinearPolygonToPolygon(coordinates [][]float64) (*geom.Polygon, error) {
+	if len(coordinates) == 0 {
+		return nil, nil
 	}
 
-	coords := polygon.FlatCoords()
-	stride := polygon.Stride()
-	numPoints := len(coords) / stride
-
-	if numPoints == 0 {
-		return nil
+	coords, err := convertLinearRing(coordinates)
+	if err != nil {
+		return nil, err
 	}
 
-	var sumX, sumY float64
-	for i := 0; i < numPoints; i++ {
-		sumX += coords[i*stride]
-		sumY += coords[i*stride+1]
+	polygon := geom.NewPolygon(geom.XY)
+	if _, err := polygon.SetCoords([][]geom.Coord{coords}); err != nil {
+		return nil, err
 	}
 
-	centroid := geom.NewPoint(geom.XY)
-	if _, err := centroid.SetCoords(geom.Coord{sumX / float64(numPoints), sumY / float64(numPoints)}); err != nil {
-		return nil
-	}
-	return centroid
+	return polygon, nil
 }
 
-// ConvertLinearPolygonToPolygon はLinearPolygon(wagri形式)をPolygonに変換する
-func ConvertLinearPolygonToPolygon(coordinates [][]float64) (*geom.Polygon, error) {
-	if len(coordinates) == 0 {
+// ConvertWagriPolygonsToMultiPolygon はwagri形式の区画ごとのリング([区画][リング][頂点][経度, 緯度])をMultiPolygonに変換する
+// 各区画の先頭リングを外周、以降を内周(穴)として扱い、閉じていないリングは始点を末尾に追加して閉じる
+func ConvertWagriPolygonsToMultiPolygon(polygons [][][][]float64) (*geom.MultiPolygon, error) {
+	if len(polygons) == 0 {
 		return nil, nil
 	}
 
+	parts := make([][][]geom.Coord, len(polygons))
+	for i, rings := range polygons {
+		if len(rings) == 0 {
+			return nil, fmt.Errorf("区画%dのリングが空です", i+1)
+		}
+		parts[i] = make([][]geom.Coord, len(rings))
+		for j, ring := range rings {
+			coords, err := convertLinearRing(ring)
+			if err != nil {
+				return nil, fmt.Errorf("区画%dのリング%d: %w", i+1, j, err)
+			}
+			parts[i][j] = coords
+		}
+	}
+
+	multiPolygon := geom.NewMultiPolygon(geom.XY)
+	if _, err := multiPolygon.SetCoords(parts); err != nil {
+		return nil, err
+	}
+
+	return multiPolygon, nil
+}
+
+// convertLinearRing はwagri形式の座標列を閉じたリングの座標に変換する
+func convertLinearRing(coordinates [][]float64) ([]geom.Coord, error) {
 	// ポリゴンには最低3点が必要
 	if len(coordinates) < 3 {
 		return nil, fmt.Errorf("ポリゴンには最低3点が必要です(現在: %d点)", len(coordinates))
@@ -141,23 +219,18 @@ func ConvertLinearPolygonToPolygon(coordinates [][]float64) (*geom.Polygon, erro
 
 	coords := make([]geom.Coord, len(coordinates))
 	for i, coord := range coordinates {
+		// 要素数が不足した座標をゼロ値で埋めると原点付近に頂点ができるためエラーにする
 		if len(coord) < 2 {
-			continue
+			return nil, fmt.Errorf("%d番目の座標の要素数が不足しています(現在: %d)", i+1, len(coord))
 		}
 		coords[i] = geom.Coord{coord[0], coord[1]}
 	}
 
 	// 座標が閉じていない場合、最初の点を末尾に追加
-	if len(coords) > 0 && !coordsEqual(coords[0], coords[len(coords)-1]) {
+	if !coordsEqual(coords[0], coords[len(coords)-1]) {
 		coords = append(coords, coords[0])
 	}
-
-	polygon := geom.NewPolygon(geom.XY)
-	if _, err := polygon.SetCoords([][]geom.Coord{coords}); err != nil {
-		return nil, err
-	}
-
-	return polygon, nil
+	return coords, nil
 }
 
 // coordsEqual は2つの座標が等しいかどうかを判定する
@@ -0,0 +1,145 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MinDivisionChildren は分筆後の子圃場の最小数
+	MinDivisionChildren = 2
+	// MaxDivisionChildren は1回の分筆で作成できる子圃場の最大数
+	MaxDivisionChildren = 50
+	// DivisionAreaToleranceSqm は分筆ジオメトリの検証で許容する誤差面積(平方メートル)
+	// 手動作図による境界のわずかなずれで分筆が失敗しないよう、これ以下のはみ出し・重なりは無視する
+	DivisionAreaToleranceSqm = 1.0
+)
+
+var (
+	// ErrFieldRetired は廃止済みの圃場を操作しようとした場合のエラー
+	ErrFieldRetired = errors.New("廃止済みの圃場です")
+	// ErrDivisionChildOutsideParent は子圃場が親圃場からはみ出している場合のエラー
+	ErrDivisionChildOutsideParent = errors.New("子圃場が親圃場からはみ出しています")
+	// ErrDivisionChildrenOverlap は子圃場同士が重なっている場合のエラー
+	ErrDivisionChildrenOverlap = errors.New("子圃場同士が重なっています")
+	// ErrLandRegistryNotInParent は付け替え対象の農地台帳が親圃場に属していない場合のエラー
+	ErrLandRegistryNotInParent = errors.New("農地台帳が親圃場に属していません")
+)
+
+// FieldDivision は分筆履歴(親圃場と子圃場の関係)
+type FieldDivision struct {
+	ID            uuid.UUID
+	ParentFieldID uuid.UUID
+	ChildFieldID  uuid.UUID
+	DividedAt     time.Time
+	Reason        *string
+	CreatedAt     time.Time
+	CreatedBy     *uuid.UUID
+}
+
+// DivisionChild は分筆で作成する子圃場
+type DivisionChild struct {
+	Field *Field
+	// LandRegistryIDs はこの子圃場に付け替える親圃場の農地台帳ID
+	// どの子圃場にも指定されなかった農地台帳は全ての子圃場に面積按分して複製する
+	LandRegistryIDs []uuid.UUID
+}
+
+// Division は1回の分筆操作
+type Division struct {
+	Parent    *Field
+	Children  []*DivisionChild
+	Reason    *string
+	DividedAt time.Time
+	DividedBy *uuid.UUID
+
+	// Records は永続化された分筆履歴(永続化後に設定される)
+	Records []*FieldDivision
+}
+
+// NewDivision は分筆操作を検証して作成する
+// 子圃場同士の重なりや親圃場からのはみ出しは空間演算が必要なため、永続化時に検証する
+func NewDivision(parent *Field, children []*DivisionChild, reason *string, dividedBy *uuid.UUID) (*Division, error) {
+	if parent.IsRetired() {
+		return nil, ErrFieldRetired
+	}
+	if len(children) < MinDivisionChildren || len(children) > MaxDivisionChildren {
+		return nil, fmt.Errorf("子圃場は%dから%d件の範囲で指定してください(現在: %d件)", MinDivisionChildren, MaxDivisionChildren, len(children))
+	}
+
+	assigned := make(map[uuid.UUID]struct{})
+	for i, child := range children {
+		if child.Field == nil || child.Field.Geometry == nil {
+			return nil, fmt.Errorf("子圃場%dのジオメトリが設定されていません", i+1)
+		}
+		for _, id := range child.LandRegistryIDs {
+			if _, dup := assigned[id]; dup {
+				return nil, fmt.Errorf("農地台帳%sが複数の子圃場に指定されています", id)
+			}
+			assigned[id] = struct{}{}
+		}
+	}
+
+	return &Division{
+		Parent:    parent,
+		Children:  children,
+		Reason:    reason,
+		DividedAt: time.Now(),
+		DividedBy: dividedBy,
+	}, nil
+}
+
+// ChildFields は子圃場の一覧を返す
+func (d *Division) ChildFields() []*Field {
+	fields := make([]*Field, 0, len(d.Children))
+	for _, child := range d.Children {
+		fields = append(fields, child.Field)
+	}
+	return fields
+}
+
+// AssignedChild は農地台帳の付け替え先の子圃場を返す
+// どの子圃場にも指定されていない場合はnilを返す
+func (d *Division) AssignedChild(registryID uuid.UUID) *Field {
+	for _, child := range d.Children {
+		for _, id := range child.LandRegistryIDs {
+			if id == registryID {
+				return child.Field
+			}
+		}
+	}
+	return nil
+}
+
+// ProrateArea は面積を重みに比例して整数で按分する
+// 累積値を丸めて差分を取るため、按分結果の合計は常に元の面積と一致する
+// 重みの合計が0以下の場合は均等に按分する
+func ProrateArea(total int32, weights []float64) []int32 {
+	normalized := make([]float64, len(weights))
+	var sum float64
+	for i, w := range weights {
+		normalized[i] = math.Max(w, 0)
+		sum += normalized[i]
+	}
+	if sum <= 0 {
+		for i := range normalized {
+			normalized[i] = 1
+		}
+		sum = float64(len(normalized))
+	}
+
+	result := make([]int32, len(weights))
+	var cumulative float64
+	var allocated int32
+	for i, w := range normalized {
+		cumulative += w
+		next := int32(math.Round(float64(total) * cumulative / sum))
+		result[i] = next - allocated
+		allocated = next
+	}
+	return result
+}
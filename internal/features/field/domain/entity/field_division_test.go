@@ -0,0 +1,160 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/twpayne/go-geom"
+)
+
+// newDivisionChildForTest はジオメトリ設定済みの子圃場を作成する
+func newDivisionChildForTest(t *testing.T, registryIDs ...uuid.UUID) *DivisionChild {
+	t.Helper()
+	polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{137.0, 36.0}, {137.001, 36.0}, {137.001, 36.001}, {137.0, 36.001}, {137.0, 36.0}},
+	})
+	field := NewField(uuid.New(), "163210")
+	if err := field.SetGeometry(polygon); err != nil {
+		t.Fatalf("SetGeometry()でエラー発生 = %v", err)
+	}
+	return &DivisionChild{Field: field, LandRegistryIDs: registryIDs}
+}
+
+// TestNewDivision は分筆操作の作成時に親圃場と子圃場を検証することをテストする
+func TestNewDivision(t *testing.T) {
+	registryID := uuid.New()
+	retiredAt := time.Now()
+
+	tests := []struct {
+		name     string
+		parent   *Field
+		children func(t *testing.T) []*DivisionChild
+		wantErr  bool
+		errIs    error
+	}{
+		{
+			name:   "正常",
+			parent: NewField(uuid.New(), "163210"),
+			children: func(t *testing.T) []*DivisionChild {
+				return []*DivisionChild{newDivisionChildForTest(t, registryID), newDivisionChildForTest(t)}
+			},
+		},
+		{
+			name:   "廃止済みの親圃場",
+			parent: &Field{ID: uuid.New(), RetiredAt: &retiredAt},
+			children: func(t *testing.T) []*DivisionChild {
+				return []*DivisionChild{newDivisionChildForTest(t), newDivisionChildForTest(t)}
+			},
+			wantErr: true,
+			errIs:   ErrFieldRetired,
+		},
+		{
+			name:   "子圃場が1件",
+			parent: NewField(uuid.New(), "163210"),
+			children: func(t *testing.T) []*DivisionChild {
+				return []*DivisionChild{newDivisionChildForTest(t)}
+			},
+			wantErr: true,
+		},
+		{
+			name:   "ジオメトリ未設定の子圃場",
+			parent: NewField(uuid.New(), "163210"),
+			children: func(t *testing.T) []*DivisionChild {
+				return []*DivisionChild{newDivisionChildForTest(t), {Field: NewField(uuid.New(), "163210")}}
+			},
+			wantErr: true,
+		},
+		{
+			name:   "同じ農地台帳を複数の子圃場に指定",
+			parent: NewField(uuid.New(), "163210"),
+			children: func(t *testing.T) []*DivisionChild {
+				return []*DivisionChild{newDivisionChildForTest(t, registryID), newDivisionChildForTest(t, registryID)}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := uuid.New()
+			division, err := NewDivision(tt.parent, tt.children(t), nil, &userID)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("NewDivision()でエラーを期待したがnilが返された")
+				}
+				if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+					t.Errorf("NewDivision()のエラー = %v, 期待値 %v", err, tt.errIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewDivision()でエラー発生 = %v", err)
+			}
+			if division.DividedAt.IsZero() {
+				t.Error("DividedAtが設定されていない")
+			}
+			if division.DividedBy == nil || *division.DividedBy != userID {
+				t.Errorf("DividedBy = %v, 期待値 %v", division.DividedBy, userID)
+			}
+		})
+	}
+}
+
+// TestDivisionAssignedChild は農地台帳の付け替え先の子圃場を返すことをテストする
+func TestDivisionAssignedChild(t *testing.T) {
+	registryID := uuid.New()
+	first := newDivisionChildForTest(t)
+	second := newDivisionChildForTest(t, registryID)
+	division, err := NewDivision(NewField(uuid.New(), "163210"), []*DivisionChild{first, second}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewDivision()でエラー発生 = %v", err)
+	}
+
+	if got := division.AssignedChild(registryID); got != second.Field {
+		t.Errorf("AssignedChild() = %v, 期待値 %v", got, second.Field)
+	}
+	if got := division.AssignedChild(uuid.New()); got != nil {
+		t.Errorf("指定のない農地台帳のAssignedChild() = %v, 期待値 nil", got)
+	}
+	if got := division.ChildFields(); len(got) != 2 || got[0] != first.Field || got[1] != second.Field {
+		t.Errorf("ChildFields()が入力の順序で子圃場を返していない")
+	}
+}
+
+// TestProrateArea は面積を重みに比例して合計を保ったまま按分することをテストする
+func TestProrateArea(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int32
+		weights []float64
+		want    []int32
+	}{
+		{name: "均等な重み", total: 1000, weights: []float64{1, 1}, want: []int32{500, 500}},
+		{name: "端数が出る重み", total: 100, weights: []float64{1, 1, 1}, want: []int32{33, 34, 33}},
+		{name: "面積比", total: 999, weights: []float64{300.5, 699.5}, want: []int32{300, 699}},
+		{name: "重みの合計が0", total: 10, weights: []float64{0, 0}, want: []int32{5, 5}},
+		{name: "重みなし", total: 10, weights: nil, want: []int32{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ProrateArea(tt.total, tt.weights)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ProrateArea()の件数 = %d, 期待値 %d", len(got), len(tt.want))
+			}
+			var sum int32
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ProrateArea()[%d] = %d, 期待値 %d", i, got[i], tt.want[i])
+				}
+				sum += got[i]
+			}
+			if len(got) > 0 && sum != tt.total {
+				t.Errorf("按分結果の合計 = %d, 期待値 %d", sum, tt.total)
+			}
+		})
+	}
+}
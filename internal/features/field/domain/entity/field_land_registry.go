@@ -72,3 +72,28 @@ func (r *FieldLandRegistry) SetIdleLandStatusCode(code string) {
 func (r *FieldLandRegistry) SetDescriptiveStudyData(date *time.Time) {
 	r.DescriptiveStudyData = date
 }
+
+// SplitInto は農地台帳を複数の圃場に複製し、面積を重みに比例して按分する
+// 面積以外の項目はそのまま引き継ぐ。面積が未設定の場合は複製先も未設定とする
+func (r *FieldLandRegistry) SplitInto(fieldIDs []uuid.UUID, weights []float64) []*FieldLandRegistry {
+	var areas []int32
+	if r.AreaSqm != nil {
+		areas = ProrateArea(*r.AreaSqm, weights)
+	}
+
+	copies := make([]*FieldLandRegistry, 0, len(fieldIDs))
+	for i, fieldID := range fieldIDs {
+		c := NewFieldLandRegistry(fieldID)
+		c.FarmerNumber = r.FarmerNumber
+		c.Address = r.Address
+		c.LandCategoryCode = r.LandCategoryCode
+		c.IdleLandStatusCode = r.IdleLandStatusCode
+		c.DescriptiveStudyData = r.DescriptiveStudyData
+		if areas != nil {
+			area := areas[i]
+			c.AreaSqm = &area
+		}
+		copies = append(copies, c)
+	}
+	return copies
+}
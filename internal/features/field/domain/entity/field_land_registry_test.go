@@ -99,3 +99,44 @@ func TestFieldLandRegistrySettersWithEmpty(t *testing.T) {
 		t.Error("DescriptiveStudyData should be nil")
 	}
 }
+
+// TestFieldLandRegistrySplitInto は農地台帳を複製し面積を按分することをテストする
+func TestFieldLandRegistrySplitInto(t *testing.T) {
+	registry := NewFieldLandRegistry(uuid.New())
+	registry.SetAddress("富山県富山市1-1")
+	registry.SetLandCategoryCode("01")
+	registry.SetAreaSqm(1000)
+
+	fieldIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	copies := registry.SplitInto(fieldIDs, []float64{300, 700})
+
+	if len(copies) != 2 {
+		t.Fatalf("複製件数 = %d, want 2", len(copies))
+	}
+	wantAreas := []int32{300, 700}
+	for i, c := range copies {
+		if c.FieldID != fieldIDs[i] {
+			t.Errorf("copies[%d].FieldID = %v, want %v", i, c.FieldID, fieldIDs[i])
+		}
+		if c.ID == registry.ID {
+			t.Errorf("copies[%d].IDが元の農地台帳と同じ", i)
+		}
+		if c.Address == nil || *c.Address != "富山県富山市1-1" {
+			t.Errorf("copies[%d].Address = %v, want 富山県富山市1-1", i, c.Address)
+		}
+		if c.LandCategoryCode == nil || *c.LandCategoryCode != "01" {
+			t.Errorf("copies[%d].LandCategoryCode = %v, want 01", i, c.LandCategoryCode)
+		}
+		if c.AreaSqm == nil || *c.AreaSqm != wantAreas[i] {
+			t.Errorf("copies[%d].AreaSqm = %v, want %d", i, c.AreaSqm, wantAreas[i])
+		}
+	}
+
+	// 面積未設定の場合は複製先も未設定
+	noArea := NewFieldLandRegistry(uuid.New())
+	for i, c := range noArea.SplitInto(fieldIDs, []float64{1, 1}) {
+		if c.AreaSqm != nil {
+			t.Errorf("copies[%d].AreaSqm = %v, want nil", i, *c.AreaSqm)
+		}
+	}
+}
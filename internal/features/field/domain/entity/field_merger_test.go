@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// newMergeSourceForTest は面積と土壌タイプを設定したソース圃場を作成する
+func newMergeSourceForTest(cityCode string, areaSqm float64) *Field {
+	field := NewField(uuid.New(), cityCode)
+	field.AreaSqm = &areaSqm
+	soilTypeID := uuid.New()
+	field.SoilTypeID = &soilTypeID
+	return field
+}
+
+// TestNewMerger は合筆操作の作成時にソース圃場を検証し、合筆先圃場の属性を決めることをテストする
+func TestNewMerger(t *testing.T) {
+	retiredAt := time.Now()
+	duplicated := newMergeSourceForTest("163210", 100)
+	retired := newMergeSourceForTest("163210", 100)
+	retired.RetiredAt = &retiredAt
+
+	tests := []struct {
+		name    string
+		sources []*Field
+		wantErr bool
+		errIs   error
+	}{
+		{
+			name:    "正常",
+			sources: []*Field{newMergeSourceForTest("163210", 100), newMergeSourceForTest("163210", 300)},
+		},
+		{
+			name:    "ソース圃場が1件",
+			sources: []*Field{newMergeSourceForTest("163210", 100)},
+			wantErr: true,
+		},
+		{
+			name:    "同じ圃場を重複指定",
+			sources: []*Field{duplicated, duplicated},
+			wantErr: true,
+		},
+		{
+			name:    "廃止済みの圃場",
+			sources: []*Field{newMergeSourceForTest("163210", 100), retired},
+			wantErr: true,
+			errIs:   ErrFieldRetired,
+		},
+		{
+			name:    "市区町村が異なる",
+			sources: []*Field{newMergeSourceForTest("163210", 100), newMergeSourceForTest("163220", 100)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := uuid.New()
+			merger, err := NewMerger(tt.sources, nil, &userID)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("NewMerger()でエラーを期待したがnilが返された")
+				}
+				if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+					t.Errorf("NewMerger()のエラー = %v, 期待値 %v", err, tt.errIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewMerger()でエラー発生 = %v", err)
+			}
+			if merger.Result == nil || merger.Result.ID == uuid.Nil {
+				t.Fatal("合筆先圃場が作成されていない")
+			}
+			if merger.Result.CityCode != "163210" {
+				t.Errorf("Result.CityCode = %s, 期待値 163210", merger.Result.CityCode)
+			}
+			if merger.Result.SoilTypeID != tt.sources[1].SoilTypeID {
+				t.Error("面積が最大のソース圃場の土壌タイプを引き継いでいない")
+			}
+			if merger.Result.CreatedBy == nil || *merger.Result.CreatedBy != userID {
+				t.Errorf("Result.CreatedBy = %v, 期待値 %v", merger.Result.CreatedBy, userID)
+			}
+			if got := merger.SourceIDs(); len(got) != 2 || got[0] != tt.sources[0].ID || got[1] != tt.sources[1].ID {
+				t.Errorf("SourceIDs() = %v", got)
+			}
+		})
+	}
+}
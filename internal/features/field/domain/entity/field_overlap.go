@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OverlapStatus はオーバーラップの対応状況
+type OverlapStatus string
+
+const (
+	// OverlapStatusOpen は未対応
+	OverlapStatusOpen OverlapStatus = "open"
+	// OverlapStatusAccepted は重なりを許容済み
+	OverlapStatusAccepted OverlapStatus = "accepted"
+	// OverlapStatusClipped は一方の圃場をクリップして解消済み
+	OverlapStatusClipped OverlapStatus = "clipped"
+)
+
+const (
+	// MinOverlapAreaSqm は重なりとして記録する最小面積(平方メートル)
+	// 境界線の座標誤差による微小な重なりや辺の共有を除外する
+	MinOverlapAreaSqm = 1.0
+)
+
+var (
+	// ErrOverlapAlreadyClipped はクリップで解消済みのオーバーラップに対応しようとした場合のエラー
+	ErrOverlapAlreadyClipped = errors.New("オーバーラップはクリップで解消済みです")
+	// ErrOverlapAlreadyAccepted は許容済みのオーバーラップを再度許容しようとした場合のエラー
+	ErrOverlapAlreadyAccepted = errors.New("オーバーラップは許容済みです")
+	// ErrClipTargetNotInOverlap はクリップ対象がオーバーラップしている圃場のどちらでもない場合のエラー
+	ErrClipTargetNotInOverlap = errors.New("クリップ対象の圃場がオーバーラップの当事者ではありません")
+	// ErrClipResultInvalid はクリップで圃場が消滅する(重なり部分が圃場全体を覆う)場合のエラー
+	ErrClipResultInvalid = errors.New("クリップすると圃場が消滅します")
+)
+
+// IsValid はステータスが定義済みの値かを判定する
+func (s OverlapStatus) IsValid() bool {
+	switch s {
+	case OverlapStatusOpen, OverlapStatusAccepted, OverlapStatusClipped:
+		return true
+	}
+	return false
+}
+
+// FieldOverlap は2つの圃場ポリゴンの重なり(オーバーラップ検知記録)
+// FieldIDAとFieldIDBは重複登録を防ぐためFieldIDA < FieldIDBに正規化される
+type FieldOverlap struct {
+	ID             uuid.UUID
+	FieldIDA       uuid.UUID
+	FieldIDB       uuid.UUID
+	OverlapAreaSqm float64
+	OverlapRatio   float64 // 面積が小さい方の圃場に対する重なり面積の割合(0-1)
+	Status         OverlapStatus
+	ClippedFieldID *uuid.UUID
+	Note           *string
+	DetectedAt     time.Time
+	ResolvedAt     *time.Time
+	ResolvedBy     *uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Involves は指定した圃場がオーバーラップの当事者かを判定する
+func (o *FieldOverlap) Involves(fieldID uuid.UUID) bool {
+	return o.FieldIDA == fieldID || o.FieldIDB == fieldID
+}
+
+// OtherFieldID はオーバーラップの相手側の圃場IDを返す
+func (o *FieldOverlap) OtherFieldID(fieldID uuid.UUID) uuid.UUID {
+	if o.FieldIDA == fieldID {
+		return o.FieldIDB
+	}
+	return o.FieldIDA
+}
+
+// Accept は重なりを許容済みにする
+// 登記上の重複など、形状を修正せずに残す重なりに使用する
+func (o *FieldOverlap) Accept(note *string, resolvedBy *uuid.UUID) error {
+	switch o.Status {
+	case OverlapStatusClipped:
+		return ErrOverlapAlreadyClipped
+	case OverlapStatusAccepted:
+		return ErrOverlapAlreadyAccepted
+	}
+	o.Status = OverlapStatusAccepted
+	o.ClippedFieldID = nil
+	o.Note = note
+	o.ResolvedBy = resolvedBy
+	return nil
+}
+
+// Clip は指定した圃場から重なり部分を取り除いて解消済みにする
+// 許容済みの重なりも後からクリップできる。形状の計算は空間演算が必要なため永続化時に行う
+func (o *FieldOverlap) Clip(fieldID uuid.UUID, note *string, resolvedBy *uuid.UUID) error {
+	if o.Status == OverlapStatusClipped {
+		return ErrOverlapAlreadyClipped
+	}
+	if !o.Involves(fieldID) {
+		return fmt.Errorf("%w: %s", ErrClipTargetNotInOverlap, fieldID)
+	}
+	o.Status = OverlapStatusClipped
+	o.ClippedFieldID = &fieldID
+	o.Note = note
+	o.ResolvedBy = resolvedBy
+	return nil
+}
@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// newOverlapForTest は指定ステータスのオーバーラップを作成する
+func newOverlapForTest(status OverlapStatus) *FieldOverlap {
+	return &FieldOverlap{
+		ID:             uuid.New(),
+		FieldIDA:       uuid.New(),
+		FieldIDB:       uuid.New(),
+		OverlapAreaSqm: 12.5,
+		OverlapRatio:   0.1,
+		Status:         status,
+	}
+}
+
+func TestOverlapStatus_IsValid(t *testing.T) {
+	tests := []struct {
+		status OverlapStatus
+		want   bool
+	}{
+		{OverlapStatusOpen, true},
+		{OverlapStatusAccepted, true},
+		{OverlapStatusClipped, true},
+		{OverlapStatus("resolved"), false},
+		{OverlapStatus(""), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := tt.status.IsValid(); got != tt.want {
+				t.Errorf("IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldOverlap_OtherFieldID(t *testing.T) {
+	overlap := newOverlapForTest(OverlapStatusOpen)
+
+	if got := overlap.OtherFieldID(overlap.FieldIDA); got != overlap.FieldIDB {
+		t.Errorf("OtherFieldID(A) = %s, want %s", got, overlap.FieldIDB)
+	}
+	if got := overlap.OtherFieldID(overlap.FieldIDB); got != overlap.FieldIDA {
+		t.Errorf("OtherFieldID(B) = %s, want %s", got, overlap.FieldIDA)
+	}
+}
+
+// TestFieldOverlap_Accept は許容時のステータス遷移をテストする
+func TestFieldOverlap_Accept(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  OverlapStatus
+		wantErr error
+	}{
+		{name: "未対応", status: OverlapStatusOpen},
+		{name: "許容済み", status: OverlapStatusAccepted, wantErr: ErrOverlapAlreadyAccepted},
+		{name: "クリップ済み", status: OverlapStatusClipped, wantErr: ErrOverlapAlreadyClipped},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlap := newOverlapForTest(tt.status)
+			note := "登記上の重複"
+			userID := uuid.New()
+
+			err := overlap.Accept(&note, &userID)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Accept() error = %v, want %v", err, tt.wantErr)
+				}
+				if overlap.Status != tt.status {
+					t.Errorf("エラー時にステータスが変更されている: %s", overlap.Status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Accept() unexpected error = %v", err)
+			}
+			if overlap.Status != OverlapStatusAccepted {
+				t.Errorf("Status = %s, want %s", overlap.Status, OverlapStatusAccepted)
+			}
+			if overlap.Note == nil || *overlap.Note != note {
+				t.Errorf("Note = %v, want %s", overlap.Note, note)
+			}
+			if overlap.ResolvedBy == nil || *overlap.ResolvedBy != userID {
+				t.Errorf("ResolvedBy = %v, want %s", overlap.ResolvedBy, userID)
+			}
+		})
+	}
+}
+
+// TestFieldOverlap_Clip はクリップ時の対象圃場の検証とステータス遷移をテストする
+func TestFieldOverlap_Clip(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  OverlapStatus
+		target  func(o *FieldOverlap) uuid.UUID
+		wantErr error
+	}{
+		{
+			name:   "未対応の圃場Aをクリップ",
+			status: OverlapStatusOpen,
+			target: func(o *FieldOverlap) uuid.UUID { return o.FieldIDA },
+		},
+		{
+			name:   "許容済みの圃場Bをクリップ",
+			status: OverlapStatusAccepted,
+			target: func(o *FieldOverlap) uuid.UUID { return o.FieldIDB },
+		},
+		{
+			name:    "当事者以外の圃場",
+			status:  OverlapStatusOpen,
+			target:  func(_ *FieldOverlap) uuid.UUID { return uuid.New() },
+			wantErr: ErrClipTargetNotInOverlap,
+		},
+		{
+			name:    "クリップ済み",
+			status:  OverlapStatusClipped,
+			target:  func(o *FieldOverlap) uuid.UUID { return o.FieldIDA },
+			wantErr: ErrOverlapAlreadyClipped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlap := newOverlapForTest(tt.status)
+			target := tt.target(overlap)
+
+			err := overlap.Clip(target, nil, nil)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Clip() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Clip() unexpected error = %v", err)
+			}
+			if overlap.Status != OverlapStatusClipped {
+				t.Errorf("Status = %s, want %s", overlap.Status, OverlapStatusClipped)
+			}
+			if overlap.ClippedFieldID == nil || *overlap.ClippedFieldID != target {
+				t.Errorf("ClippedFieldID = %v, want %s", overlap.ClippedFieldID, target)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"github.com/uber/h3-go/v4"
 )
 
 // TestNewField はNewFieldが正しいID、CityCode、デフォルト名称、タイムスタンプを持つFieldを生成することをテストする
@@ -31,45 +32,43 @@ func TestNewField(t *testing.T) {
 	}
 }
 
-// TestFieldCalculateH3Indexes はCalculateH3Indexesが各解像度(3,5,7,9)のH3インデックスを正しく計算することをテストする
-func TestFieldCalculateH3Indexes(t *testing.T) {
+// TestFieldCalculateH3Index はCalculateH3Indexが解像度15のH3インデックスを正しく計算することをテストする
+func TestFieldCalculateH3Index(t *testing.T) {
 	field := &Field{}
 
 	// 東京駅付近の座標
 	lat := 35.6812
 	lng := 139.7671
 
-	err := field.CalculateH3Indexes(lat, lng)
+	err := field.CalculateH3Index(lat, lng)
 	if err != nil {
-		t.Fatalf("CalculateH3Indexesでエラーが発生: %v", err)
+		t.Fatalf("CalculateH3Indexでエラーが発生: %v", err)
 	}
 
-	if field.H3IndexRes3 == nil {
-		t.Error("H3IndexRes3がnilです")
+	if field.H3Index == nil {
+		t.Fatal("H3Indexがnilです")
 	}
-	if field.H3IndexRes5 == nil {
-		t.Error("H3IndexRes5がnilです")
-	}
-	if field.H3IndexRes7 == nil {
-		t.Error("H3IndexRes7がnilです")
-	}
-	if field.H3IndexRes9 == nil {
-		t.Error("H3IndexRes9がnilです")
+	if got := h3.CellFromString(*field.H3Index).Resolution(); got != FinestH3Resolution {
+		t.Errorf("H3Indexの解像度 = %d, 期待値 %d", got, FinestH3Resolution)
 	}
+}
 
-	// H3インデックスが空でないことを確認
-	if *field.H3IndexRes3 == "" {
-		t.Error("H3IndexRes3が空です")
-	}
-	if *field.H3IndexRes5 == "" {
-		t.Error("H3IndexRes5が空です")
-	}
-	if *field.H3IndexRes7 == "" {
-		t.Error("H3IndexRes7が空です")
-	}
-	if *field.H3IndexRes9 == "" {
-		t.Error("H3IndexRes9が空です")
+// TestFieldH3IndexAt はH3IndexAtが指定解像度の親セルを返すことをテストする
+func TestFieldH3IndexAt(t *testing.T) {
+	field := &Field{}
+	require.Nil(t, field.H3IndexAt(9), "H3Indexが未設定の場合はnilであるべき")
+
+	require.NoError(t, field.CalculateH3Index(35.6812, 139.7671), "CalculateH3Indexでエラーが発生")
+	for _, resolution := range []int{3, 5, 7, 9} {
+		got := field.H3IndexAt(resolution)
+		require.NotNil(t, got, "解像度%dのH3インデックスがnilです", resolution)
+		require.Equal(t, resolution, h3.CellFromString(*got).Resolution(), "解像度%dのセルではない", resolution)
+
+		parent, err := h3.CellFromString(*field.H3Index).Parent(resolution)
+		require.NoError(t, err, "Parentでエラーが発生")
+		require.Equal(t, parent.String(), *got, "解像度%dのセルがH3Indexの親セルではない", resolution)
 	}
+	require.Nil(t, field.H3IndexAt(16), "範囲外の解像度はnilであるべき")
 }
 
 // TestFieldSetSoilType はSetSoilTypeがSoilTypeIDを正しく設定することをテストする
@@ -87,6 +86,58 @@ func TestFieldSetSoilType(t *testing.T) {
 	}
 }
 
+// TestFieldH3Indexes はH3Indexesが設定済みのインデックスのみを返すことをテストする
+func TestFieldH3Indexes(t *testing.T) {
+	field := &Field{}
+	if got := field.H3Indexes(); len(got) != 0 {
+		t.Errorf("H3Indexes() = %v, 期待値 空", got)
+	}
+
+	if err := field.CalculateH3Index(35.6812, 139.7671); err != nil {
+		t.Fatalf("CalculateH3Indexでエラーが発生: %v", err)
+	}
+	got := field.H3Indexes()
+	if len(got) != 1 || got[0] != *field.H3Index {
+		t.Errorf("H3Indexes() = %v, 期待値 [%s]", got, *field.H3Index)
+	}
+}
+
+// TestFieldAffectedH3Cells はAffectedH3Cellsが代表点のセルに加えて、大きな圃場の外周付近のタイルを含むセルを返すことをテストする
+func TestFieldAffectedH3Cells(t *testing.T) {
+	t.Run("geometry nil", func(t *testing.T) {
+		field := &Field{}
+		require.NoError(t, field.CalculateH3Index(35.6812, 139.7671), "CalculateH3Indexでエラーが発生")
+
+		require.Equal(t, field.H3Indexes(), field.AffectedH3Cells(), "ジオメトリがない場合は代表点のセルのみを返すべき")
+	})
+
+	t.Run("large polygon", func(t *testing.T) {
+		field := NewField(uuid.New(), "163210")
+		// 一辺約2kmの正方形(代表点のセルと隣接セルだけでは覆えない大きさ)
+		corners := [][]float64{
+			{139.70, 35.60},
+			{139.72, 35.60},
+			{139.72, 35.62},
+			{139.70, 35.62},
+		}
+		polygon, err := ConvertLinearPolygonToPolygon(append(corners, corners[0]))
+		require.NoError(t, err, "ConvertLinearPolygonToPolygonでエラーが発生")
+		require.NoError(t, field.SetGeometry(polygon), "SetGeometryでエラーが発生")
+
+		cells := field.AffectedH3Cells()
+		require.Contains(t, cells, *field.H3Index, "代表点のセルを含むべき")
+		require.Greater(t, len(cells), 7, "ジオメトリと重なる複数のセルを返すべき")
+
+		tiles := CachedTilesForH3Cells(cells)
+		for _, corner := range corners {
+			for z := MinTileZoom; z <= MaxCachedTileZoom; z++ {
+				cornerTile := TilesInBounds(z, corner[0], corner[1], corner[0], corner[1])[0]
+				require.Contains(t, tiles, cornerTile, "圃場の頂点を含むタイルが無効化対象に含まれるべき: %v", corner)
+			}
+		}
+	})
+}
+
 // TestSetGeometry はSetGeometryが有効なPolygonでGeometry、Centroid、H3インデックスを設定し、nilの場合はnilを設定することをテストする
 func TestSetGeometry(t *testing.T) {
 	t.Run("set valid polygon", func(t *testing.T) {
@@ -109,17 +160,8 @@ func TestSetGeometry(t *testing.T) {
 		if field.Centroid == nil {
 			t.Error("Centroidがnilです")
 		}
-		if field.H3IndexRes3 == nil {
-			t.Error("H3IndexRes3がnilです")
-		}
-		if field.H3IndexRes5 == nil {
-			t.Error("H3IndexRes5がnilです")
-		}
-		if field.H3IndexRes7 == nil {
-			t.Error("H3IndexRes7がnilです")
-		}
-		if field.H3IndexRes9 == nil {
-			t.Error("H3IndexRes9がnilです")
+		if field.H3Index == nil {
+			t.Error("H3Indexがnilです")
 		}
 	})
 
@@ -156,8 +198,10 @@ func TestCalculateCentroid(t *testing.T) {
 		}
 		polygon, err := ConvertLinearPolygonToPolygon(coords)
 		require.NoError(t, err, "ConvertLinearPolygonToPolygonでエラーが発生")
+		multiPolygon, err := NewMultiPolygon(polygon)
+		require.NoError(t, err, "NewMultiPolygonでエラーが発生")
 
-		result := CalculateCentroid(polygon)
+		result := CalculateCentroid(multiPolygon)
 
 		if result == nil {
 			t.Error("CalculateCentroid()がnilです、非nilを期待")
@@ -234,6 +278,17 @@ func TestConvertLinearPolygonToPolygon(t *testing.T) {
 			wantNil: false,
 			wantErr: true,
 		},
+		// 異常系: 要素数が不足した座標を含む場合はエラーを返す
+		{
+			name: "malformed coordinate",
+			coordinates: [][]float64{
+				{139.0, 35.0},
+				{139.1},
+				{139.05, 35.1},
+			},
+			wantNil: false,
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -265,6 +320,53 @@ func TestConvertLinearPolygonToPolygon(t *testing.T) {
 	}
 }
 
+// TestConvertWagriPolygonsToMultiPolygon は内周リングと複数区画を保持したままMultiPolygonに変換することをテストする
+func TestConvertWagriPolygonsToMultiPolygon(t *testing.T) {
+	t.Run("穴あきの区画と飛び地", func(t *testing.T) {
+		polygons := [][][][]float64{
+			{
+				{{139.0, 35.0}, {139.4, 35.0}, {139.4, 35.4}, {139.0, 35.4}},
+				{{139.1, 35.1}, {139.1, 35.2}, {139.2, 35.2}, {139.2, 35.1}},
+			},
+			{
+				{{139.5, 35.0}, {139.6, 35.0}, {139.6, 35.1}, {139.5, 35.0}},
+			},
+		}
+
+		multiPolygon, err := ConvertWagriPolygonsToMultiPolygon(polygons)
+		require.NoError(t, err, "ConvertWagriPolygonsToMultiPolygonでエラーが発生")
+		require.Equal(t, 2, multiPolygon.NumPolygons(), "区画数が一致しない")
+		require.Equal(t, 2, multiPolygon.Polygon(0).NumLinearRings(), "内周リングが失われている")
+		require.Equal(t, 1, multiPolygon.Polygon(1).NumLinearRings(), "2番目の区画のリング数が一致しない")
+
+		hole := multiPolygon.Polygon(0).LinearRing(1).Coords()
+		require.Len(t, hole, 5, "閉じていない内周リングは始点を末尾に追加して閉じるべき")
+		require.True(t, coordsEqual(hole[0], hole[len(hole)-1]), "内周リングが閉じていない")
+		require.Len(t, multiPolygon.Polygon(1).LinearRing(0).Coords(), 4, "閉じたリングに終点を追加している")
+	})
+
+	t.Run("空の座標はnil", func(t *testing.T) {
+		multiPolygon, err := ConvertWagriPolygonsToMultiPolygon(nil)
+		require.NoError(t, err, "空の座標でエラーが発生")
+		require.Nil(t, multiPolygon, "空の座標はnilを返すべき")
+	})
+
+	t.Run("リングのない区画はエラー", func(t *testing.T) {
+		_, err := ConvertWagriPolygonsToMultiPolygon([][][][]float64{{}})
+		require.Error(t, err, "リングのない区画はエラーを返すべき")
+	})
+
+	t.Run("頂点が不足した内周リングはエラー", func(t *testing.T) {
+		_, err := ConvertWagriPolygonsToMultiPolygon([][][][]float64{
+			{
+				{{139.0, 35.0}, {139.4, 35.0}, {139.4, 35.4}},
+				{{139.1, 35.1}, {139.2, 35.2}},
+			},
+		})
+		require.Error(t, err, "頂点が不足した内周リングはエラーを返すべき")
+	})
+}
+
 // TestCoordsEqual はcoordsEqualが同一座標、異なる座標、不完全なスライスに対して正しい判定結果を返すことをテストする
 func TestCoordsEqual(t *testing.T) {
 	tests := []struct {
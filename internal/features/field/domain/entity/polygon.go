@@ -0,0 +1,147 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/twpayne/go-geom"
+)
+
+const (
+	// minRingPoints は閉じたリングに必要な最小頂点数(始点と終点の重複を含む)
+	minRingPoints = 4
+	// maxPolygonPoints は手動登録で受け付けるポリゴンの最大頂点数
+	maxPolygonPoints = 10000
+)
+
+// NewPolygonFromCoordinates はGeoJSON Polygon形式の座標([[[経度, 緯度], ...], ...])からポリゴンを作成する
+// 先頭が外周リング、以降が内周リング(穴)として扱われ、ValidatePolygonによる検証を通過したものだけを返す
+func NewPolygonFromCoordinates(coordinates [][][]float64) (*geom.Polygon, error) {
+	if len(coordinates) == 0 {
+		return nil, fmt.Errorf("ポリゴンの座標が空です")
+	}
+
+	rings := make([][]geom.Coord, len(coordinates))
+	for i, ring := range coordinates {
+		coords := make([]geom.Coord, len(ring))
+		for j, position := range ring {
+			if len(position) < 2 {
+				return nil, fmt.Errorf("リング%dの%d番目の座標は[経度, 緯度]である必要があります", i, j)
+			}
+			coords[j] = geom.Coord{position[0], position[1]}
+		}
+		rings[i] = coords
+	}
+
+	polygon := geom.NewPolygon(geom.XY)
+	if _, err := polygon.SetCoords(rings); err != nil {
+		return nil, fmt.Errorf("ポリゴンの作成に失敗: %w", err)
+	}
+
+	if err := ValidatePolygon(polygon); err != nil {
+		return nil, err
+	}
+	return polygon, nil
+}
+
+// NewMultiPolygon は1つ以上のポリゴンを区画とするマルチポリゴンを作成する
+func NewMultiPolygon(polygons ...*geom.Polygon) (*geom.MultiPolygon, error) {
+	multiPolygon := geom.NewMultiPolygon(geom.XY)
+	for _, polygon := range polygons {
+		if err := multiPolygon.Push(polygon); err != nil {
+			return nil, fmt.Errorf("マルチポリゴンの作成に失敗: %w", err)
+		}
+	}
+	return multiPolygon, nil
+}
+
+// ValidatePolygon はポリゴンが圃場ジオメトリとして妥当かを検証する
+// リングの閉合・頂点数・座標範囲・面積・自己交差を確認する
+func ValidatePolygon(polygon *geom.Polygon) error {
+	if polygon == nil || polygon.NumLinearRings() == 0 {
+		return fmt.Errorf("ポリゴンが空です")
+	}
+	if polygon.NumCoords() > maxPolygonPoints {
+		return fmt.Errorf("頂点数が上限(%d)を超えています(現在: %d点)", maxPolygonPoints, polygon.NumCoords())
+	}
+
+	for i := 0; i < polygon.NumLinearRings(); i++ {
+		ring := polygon.LinearRing(i).Coords()
+
+		if len(ring) < minRingPoints {
+			return fmt.Errorf("リング%dには最低%d点が必要です(現在: %d点)", i, minRingPoints, len(ring))
+		}
+		if !coordsEqual(ring[0], ring[len(ring)-1]) {
+			return fmt.Errorf("リング%dが閉じていません(始点と終点が一致しません)", i)
+		}
+		for j, c := range ring {
+			if math.IsNaN(c.X()) || math.IsNaN(c.Y()) || c.X() < -180 || c.X() > 180 || c.Y() < -90 || c.Y() > 90 {
+				return fmt.Errorf("リング%dの%d番目の座標が範囲外です(経度: %v, 緯度: %v)", i, j, c.X(), c.Y())
+			}
+		}
+		if ringArea(ring) == 0 {
+			return fmt.Errorf("リング%dの面積が0です", i)
+		}
+		if ringSelfIntersects(ring) {
+			return fmt.Errorf("リング%dが自己交差しています", i)
+		}
+	}
+
+	return nil
+}
+
+// ringArea は閉じたリングの符号なし面積(座標系単位)を計算する
+func ringArea(ring []geom.Coord) float64 {
+	var sum float64
+	for i := 0; i < len(ring)-1; i++ {
+		sum += ring[i].X()*ring[i+1].Y() - ring[i+1].X()*ring[i].Y()
+	}
+	return math.Abs(sum) / 2
+}
+
+// ringSelfIntersects は閉じたリングの辺同士が交差しているかを判定する
+// 隣接する辺の共有頂点は交差とみなさない
+func ringSelfIntersects(ring []geom.Coord) bool {
+	numSegments := len(ring) - 1
+	for i := 0; i < numSegments; i++ {
+		for j := i + 1; j < numSegments; j++ {
+			// 隣接する辺(先頭と末尾の辺を含む)は頂点を共有するため除外
+			if j == i+1 || (i == 0 && j == numSegments-1) {
+				continue
+			}
+			if segmentsIntersect(ring[i], ring[i+1], ring[j], ring[j+1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// segmentsIntersect は線分p1-p2と線分p3-p4が交差(接触を含む)するかを判定する
+func segmentsIntersect(p1, p2, p3, p4 geom.Coord) bool {
+	d1 := orientation(p3, p4, p1)
+	d2 := orientation(p3, p4, p2)
+	d3 := orientation(p1, p2, p3)
+	d4 := orientation(p1, p2, p4)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+
+	// 同一直線上で重なる場合
+	return (d1 == 0 && onSegment(p3, p4, p1)) ||
+		(d2 == 0 && onSegment(p3, p4, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, p3)) ||
+		(d4 == 0 && onSegment(p1, p2, p4))
+}
+
+// orientation は点cが有向線分a-bの左側なら正、右側なら負、同一直線上なら0を返す
+func orientation(a, b, c geom.Coord) float64 {
+	return (b.X()-a.X())*(c.Y()-a.Y()) - (b.Y()-a.Y())*(c.X()-a.X())
+}
+
+// onSegment は同一直線上の点cが線分a-bの範囲内にあるかを判定する
+func onSegment(a, b, c geom.Coord) bool {
+	return math.Min(a.X(), b.X()) <= c.X() && c.X() <= math.Max(a.X(), b.X()) &&
+		math.Min(a.Y(), b.Y()) <= c.Y() && c.Y() <= math.Max(a.Y(), b.Y())
+}
@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"testing"
+)
+
+// TestNewPolygonFromCoordinates はGeoJSON座標からのポリゴン作成と各種不正ジオメトリの検出をテストする
+func TestNewPolygonFromCoordinates(t *testing.T) {
+	square := [][]float64{
+		{137.0, 36.0},
+		{137.1, 36.0},
+		{137.1, 36.1},
+		{137.0, 36.1},
+		{137.0, 36.0},
+	}
+
+	tests := []struct {
+		name        string
+		coordinates [][][]float64
+		wantErr     bool
+	}{
+		// 正常系: 閉じた四角形
+		{
+			name:        "valid square",
+			coordinates: [][][]float64{square},
+			wantErr:     false,
+		},
+		// 正常系: 穴あきポリゴン
+		{
+			name: "polygon with hole",
+			coordinates: [][][]float64{
+				square,
+				{
+					{137.02, 36.02},
+					{137.02, 36.04},
+					{137.04, 36.04},
+					{137.04, 36.02},
+					{137.02, 36.02},
+				},
+			},
+			wantErr: false,
+		},
+		// 異常系: 座標が空
+		{
+			name:        "empty coordinates",
+			coordinates: [][][]float64{},
+			wantErr:     true,
+		},
+		// 異常系: リングが閉じていない
+		{
+			name: "unclosed ring",
+			coordinates: [][][]float64{
+				{{137.0, 36.0}, {137.1, 36.0}, {137.1, 36.1}, {137.0, 36.1}},
+			},
+			wantErr: true,
+		},
+		// 異常系: 頂点数不足
+		{
+			name: "too few points",
+			coordinates: [][][]float64{
+				{{137.0, 36.0}, {137.1, 36.0}, {137.0, 36.0}},
+			},
+			wantErr: true,
+		},
+		// 異常系: 経度が範囲外
+		{
+			name: "longitude out of range",
+			coordinates: [][][]float64{
+				{{181.0, 36.0}, {181.1, 36.0}, {181.1, 36.1}, {181.0, 36.0}},
+			},
+			wantErr: true,
+		},
+		// 異常系: 緯度と経度の順序が逆(緯度が範囲外)
+		{
+			name: "swapped lat lng",
+			coordinates: [][][]float64{
+				{{36.0, 137.0}, {36.1, 137.0}, {36.1, 137.1}, {36.0, 137.0}},
+			},
+			wantErr: true,
+		},
+		// 異常系: 座標の次元不足
+		{
+			name: "position without latitude",
+			coordinates: [][][]float64{
+				{{137.0}, {137.1, 36.0}, {137.1, 36.1}, {137.0, 36.0}},
+			},
+			wantErr: true,
+		},
+		// 異常系: 一直線上の点(面積0)
+		{
+			name: "zero area",
+			coordinates: [][][]float64{
+				{{137.0, 36.0}, {137.1, 36.0}, {137.2, 36.0}, {137.0, 36.0}},
+			},
+			wantErr: true,
+		},
+		// 異常系: 蝶ネクタイ型の自己交差
+		{
+			name: "bowtie self intersection",
+			coordinates: [][][]float64{
+				{{137.0, 36.0}, {137.1, 36.1}, {137.1, 36.0}, {137.0, 36.1}, {137.0, 36.0}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polygon, err := NewPolygonFromCoordinates(tt.coordinates)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("NewPolygonFromCoordinates()でエラーを期待したがnilが返された")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewPolygonFromCoordinates()でエラー発生 = %v", err)
+			}
+			if polygon.NumLinearRings() != len(tt.coordinates) {
+				t.Errorf("NumLinearRings() = %d, 期待値 %d", polygon.NumLinearRings(), len(tt.coordinates))
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/uber/h3-go/v4"
+)
+
+const (
+	// MinTileZoom は圃場タイルを配信する最小ズームレベル
+	// デフォルトの解像度でクラスター表示が最詳細(res9)に切り替わるズームレベルに合わせる
+	MinTileZoom = 14
+	// MaxTileZoom は圃場タイルを配信する最大ズームレベル
+	MaxTileZoom = 22
+	// MaxCachedTileZoom はキャッシュ対象とする最大ズームレベル
+	// これより詳細なタイルは範囲が狭く生成コストが低いため、無効化対象を抑える目的でキャッシュしない
+	MaxCachedTileZoom = 16
+
+	// maxMercatorLat はWebメルカトルで表現できる最大緯度
+	maxMercatorLat = 85.05112878
+	// tileH3BufferRing はH3セルからタイルを求める際に加える近傍リング数
+	// インポートなど代表点のセルのみが渡される場合に、代表点のセルからはみ出した部分を含めるため隣接セルまで含める
+	tileH3BufferRing = 1
+	// tileH3Resolution はH3セルからタイルを求める際の最も詳細な解像度
+	// 圃場のH3インデックス(解像度15)は圃場より小さいため、親セルに置き換えて近傍リングの範囲を確保する。
+	// 圃場の編集ではAffectedH3Cellsがジオメトリと重なるこの解像度のセルを影響セルに含める
+	tileH3Resolution = 9
+)
+
+// Tile はWebメルカトルのXYZタイル座標
+type Tile struct {
+	Z int
+	X int
+	Y int
+}
+
+// NewTile はタイル座標を検証してTileを作成する
+func NewTile(z, x, y int) (Tile, error) {
+	if z < MinTileZoom || z > MaxTileZoom {
+		return Tile{}, fmt.Errorf("ズームレベルは%dから%dの範囲で指定してください(現在: %d)", MinTileZoom, MaxTileZoom, z)
+	}
+	n := 1 << z
+	if x < 0 || x >= n || y < 0 || y >= n {
+		return Tile{}, fmt.Errorf("ズームレベル%dのタイル座標は0から%dの範囲で指定してください(x: %d, y: %d)", z, n-1, x, y)
+	}
+	return Tile{Z: z, X: x, Y: y}, nil
+}
+
+// Cacheable はタイルがキャッシュ対象のズームレベルかを判定する
+func (t Tile) Cacheable() bool {
+	return t.Z <= MaxCachedTileZoom
+}
+
+// TilesInBounds は経緯度範囲を覆うズームレベルzのタイルを返す
+func TilesInBounds(z int, minLng, minLat, maxLng, maxLat float64) []Tile {
+	minX := lngToTileX(minLng, z)
+	maxX := lngToTileX(maxLng, z)
+	// タイルのY座標は北から南に増加する
+	minY := latToTileY(maxLat, z)
+	maxY := latToTileY(minLat, z)
+
+	tiles := make([]Tile, 0, (maxX-minX+1)*(maxY-minY+1))
+	for x := minX; x <= maxX; x++ {
+		for y := minY; y <= maxY; y++ {
+			tiles = append(tiles, Tile{Z: z, X: x, Y: y})
+		}
+	}
+	return tiles
+}
+
+// CachedTilesForH3Cells はH3セル内の圃場を含み得るキャッシュ対象タイルを返す
+// 最も詳細な解像度のセルのみを使用し、近傍リングを加えた範囲を覆うタイルを重複なく返す。
+// 大きな圃場のタイルを漏れなく返すには、代表点のセルだけでなくジオメトリと重なるセル(Field.AffectedH3Cells)を渡す
+func CachedTilesForH3Cells(h3Cells []string) []Tile {
+	finest := finestCells(h3Cells)
+
+	seen := make(map[Tile]struct{})
+	tiles := make([]Tile, 0)
+	for _, cell := range finest {
+		minLng, minLat, maxLng, maxLat, ok := cellDiskBounds(cell)
+		if !ok {
+			continue
+		}
+		for z := MinTileZoom; z <= MaxCachedTileZoom; z++ {
+			for _, tile := range TilesInBounds(z, minLng, minLat, maxLng, maxLat) {
+				if _, dup := seen[tile]; dup {
+					continue
+				}
+				seen[tile] = struct{}{}
+				tiles = append(tiles, tile)
+			}
+		}
+	}
+	return tiles
+}
+
+// finestCells は有効なH3セルのうち最も詳細な解像度のものだけを重複なく返す
+// tileH3Resolutionより詳細なセルはその解像度の親セルに置き換える
+func finestCells(h3Cells []string) []h3.Cell {
+	maxRes := -1
+	cells := make([]h3.Cell, 0, len(h3Cells))
+	for _, s := range h3Cells {
+		cell := h3.CellFromString(s)
+		if !cell.IsValid() {
+			continue
+		}
+		if cell.Resolution() > tileH3Resolution {
+			parent, err := cell.Parent(tileH3Resolution)
+			if err != nil {
+				continue
+			}
+			cell = parent
+		}
+		cells = append(cells, cell)
+		maxRes = max(maxRes, cell.Resolution())
+	}
+
+	seen := make(map[h3.Cell]struct{}, len(cells))
+	finest := make([]h3.Cell, 0, len(cells))
+	for _, cell := range cells {
+		if _, dup := seen[cell]; dup || cell.Resolution() != maxRes {
+			continue
+		}
+		seen[cell] = struct{}{}
+		finest = append(finest, cell)
+	}
+	return finest
+}
+
+// cellDiskBounds はセルと近傍リングの境界を包含する経緯度範囲を返す
+func cellDiskBounds(cell h3.Cell) (minLng, minLat, maxLng, maxLat float64, ok bool) {
+	disk, err := h3.GridDisk(cell, tileH3BufferRing)
+	if err != nil {
+		return 0, 0, 0, 0, false
+	}
+
+	minLng, minLat = math.Inf(1), math.Inf(1)
+	maxLng, maxLat = math.Inf(-1), math.Inf(-1)
+	for _, c := range disk {
+		boundary, err := c.Boundary()
+		if err != nil {
+			continue
+		}
+		for _, v := range boundary {
+			minLng = math.Min(minLng, v.Lng)
+			maxLng = math.Max(maxLng, v.Lng)
+			minLat = math.Min(minLat, v.Lat)
+			maxLat = math.Max(maxLat, v.Lat)
+		}
+	}
+	if math.IsInf(minLng, 1) {
+		return 0, 0, 0, 0, false
+	}
+	// 日付変更線をまたぐ場合は範囲が地球全体に広がるため対象外とする
+	if maxLng-minLng > 180 {
+		return 0, 0, 0, 0, false
+	}
+	return minLng, minLat, maxLng, maxLat, true
+}
+
+// lngToTileX は経度をズームレベルzのタイルX座標に変換する
+func lngToTileX(lng float64, z int) int {
+	n := float64(int(1) << z)
+	x := int(math.Floor((lng + 180) / 360 * n))
+	return clampTileIndex(x, z)
+}
+
+// latToTileY は緯度をズームレベルzのタイルY座標に変換する
+func latToTileY(lat float64, z int) int {
+	lat = math.Max(-maxMercatorLat, math.Min(maxMercatorLat, lat))
+	n := float64(int(1) << z)
+	rad := lat * math.Pi / 180
+	y := int(math.Floor((1 - math.Log(math.Tan(rad)+1/math.Cos(rad))/math.Pi) / 2 * n))
+	return clampTileIndex(y, z)
+}
+
+// clampTileIndex はタイル座標をズームレベルの有効範囲に収める
+func clampTileIndex(v, z int) int {
+	return max(0, min(v, (1<<z)-1))
+}
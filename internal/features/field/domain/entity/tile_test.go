@@ -0,0 +1,131 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/uber/h3-go/v4"
+)
+
+// TestNewTile はNewTileがズームレベルとタイル座標の範囲を検証することをテストする
+func TestNewTile(t *testing.T) {
+	tests := []struct {
+		name    string
+		z, x, y int
+		wantErr bool
+	}{
+		{name: "最小ズームレベル", z: MinTileZoom, x: 0, y: 0, wantErr: false},
+		{name: "最大ズームレベルの右下端", z: MaxTileZoom, x: (1 << MaxTileZoom) - 1, y: (1 << MaxTileZoom) - 1, wantErr: false},
+		{name: "ズームレベルが小さすぎる", z: MinTileZoom - 1, x: 0, y: 0, wantErr: true},
+		{name: "ズームレベルが大きすぎる", z: MaxTileZoom + 1, x: 0, y: 0, wantErr: true},
+		{name: "Xが範囲外", z: 14, x: 1 << 14, y: 0, wantErr: true},
+		{name: "Yが負", z: 14, x: 0, y: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tile, err := NewTile(tt.z, tt.x, tt.y)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("NewTile()でエラーを期待したがnilが返された")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewTile()でエラー発生 = %v", err)
+			}
+			if tile != (Tile{Z: tt.z, X: tt.x, Y: tt.y}) {
+				t.Errorf("NewTile() = %+v, 期待値 z=%d x=%d y=%d", tile, tt.z, tt.x, tt.y)
+			}
+		})
+	}
+}
+
+// TestTileCacheable はキャッシュ対象のズームレベルを判定できることをテストする
+func TestTileCacheable(t *testing.T) {
+	if !(Tile{Z: MaxCachedTileZoom}).Cacheable() {
+		t.Errorf("ズームレベル%dはキャッシュ対象であるべき", MaxCachedTileZoom)
+	}
+	if (Tile{Z: MaxCachedTileZoom + 1}).Cacheable() {
+		t.Errorf("ズームレベル%dはキャッシュ対象外であるべき", MaxCachedTileZoom+1)
+	}
+}
+
+// TestTilesInBounds は経緯度範囲を覆うタイルを返すことをテストする
+func TestTilesInBounds(t *testing.T) {
+	// 東京駅付近(z14では x=14552, y=6451)
+	tiles := TilesInBounds(14, 139.7671, 35.6812, 139.7671, 35.6812)
+	if len(tiles) != 1 {
+		t.Fatalf("1点を含むタイル数 = %d, 期待値 1", len(tiles))
+	}
+	if tiles[0] != (Tile{Z: 14, X: 14552, Y: 6451}) {
+		t.Errorf("タイル = %+v, 期待値 {Z:14 X:14552 Y:6451}", tiles[0])
+	}
+
+	// 範囲が2x2タイルにまたがる場合
+	tiles = TilesInBounds(1, -10, -10, 10, 10)
+	if len(tiles) != 4 {
+		t.Errorf("タイル数 = %d, 期待値 4", len(tiles))
+	}
+}
+
+// TestCachedTilesForH3Cells はH3セルから無効化対象のキャッシュタイルを求めることをテストする
+func TestCachedTilesForH3Cells(t *testing.T) {
+	latLng := h3.NewLatLng(35.6812, 139.7671)
+	res9, err := h3.LatLngToCell(latLng, 9)
+	if err != nil {
+		t.Fatalf("LatLngToCellでエラー発生: %v", err)
+	}
+	res3, err := h3.LatLngToCell(latLng, 3)
+	if err != nil {
+		t.Fatalf("LatLngToCellでエラー発生: %v", err)
+	}
+
+	tiles := CachedTilesForH3Cells([]string{res3.String(), res9.String(), "invalid"})
+	if len(tiles) == 0 {
+		t.Fatal("タイルが返されるべき")
+	}
+
+	containsCenter := map[int]bool{}
+	seen := map[Tile]bool{}
+	for _, tile := range tiles {
+		if tile.Z < MinTileZoom || tile.Z > MaxCachedTileZoom {
+			t.Errorf("キャッシュ対象外のズームレベル%dが含まれる", tile.Z)
+		}
+		if seen[tile] {
+			t.Errorf("タイル%+vが重複している", tile)
+		}
+		seen[tile] = true
+
+		center := TilesInBounds(tile.Z, 139.7671, 35.6812, 139.7671, 35.6812)[0]
+		if tile == center {
+			containsCenter[tile.Z] = true
+		}
+	}
+	for z := MinTileZoom; z <= MaxCachedTileZoom; z++ {
+		if !containsCenter[z] {
+			t.Errorf("ズームレベル%dでセル中心を含むタイルが含まれていない", z)
+		}
+	}
+
+	// 粗い解像度のセルは使用しないため、res9のみの場合と同じ結果になる
+	if got := len(CachedTilesForH3Cells([]string{res9.String()})); got != len(tiles) {
+		t.Errorf("res9のみのタイル数 = %d, 期待値 %d", got, len(tiles))
+	}
+
+	// 圃場のH3インデックス(解像度15)はres9の親セルに置き換えるため、res9の場合と同じ結果になる
+	res15, err := h3.LatLngToCell(latLng, FinestH3Resolution)
+	if err != nil {
+		t.Fatalf("LatLngToCellでエラー発生: %v", err)
+	}
+	parent, err := res15.Parent(9)
+	if err != nil {
+		t.Fatalf("Parentでエラー発生: %v", err)
+	}
+	if got, want := len(CachedTilesForH3Cells([]string{res15.String()})), len(CachedTilesForH3Cells([]string{parent.String()})); got != want {
+		t.Errorf("res15のタイル数 = %d, 期待値 %d", got, want)
+	}
+
+	if got := CachedTilesForH3Cells(nil); len(got) != 0 {
+		t.Errorf("空の入力に対するタイル数 = %d, 期待値 0", len(got))
+	}
+}
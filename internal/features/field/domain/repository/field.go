@@ -10,15 +10,19 @@ import (
 // FieldRepository は圃場のリポジトリインターフェース
 type FieldRepository interface {
 	// FindByID はIDで圃場を取得する
+	// 圃場が存在しない場合はnilを返す
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.Field, error)
 
 	// Create は圃場を作成する
 	Create(ctx context.Context, field *entity.Field) error
 
 	// Update は圃場を更新する
+	// 圃場が廃止済みの場合はentity.ErrFieldRetiredをラップして返す
 	Update(ctx context.Context, field *entity.Field) error
 
 	// Delete は圃場を削除する
+	// 分筆・合筆で作られた圃場は系譜を保持するため削除せずに廃止する
+	// 圃場が廃止済みの場合はentity.ErrFieldRetiredをラップして返す
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// Upsert は圃場をUPSERTする
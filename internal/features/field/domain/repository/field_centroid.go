@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+)
+
+// FieldCentroidRepository は圃場の重心・H3インデックス再計算(バックフィル)のリポジトリインターフェース
+type FieldCentroidRepository interface {
+	// ListAfter はafterIDより後の圃場(廃止済みを含む)をID順にlimit件取得する
+	// afterIDがnilの場合は先頭から取得する。ジオメトリ・重心・H3インデックス・廃止日時のみを設定する
+	ListAfter(ctx context.Context, afterID *uuid.UUID, limit int32) ([]*entity.Field, error)
+
+	// UpdateCentroid は圃場の重心とH3インデックスのみを更新する
+	UpdateCentroid(ctx context.Context, field *entity.Field) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+)
+
+// FieldDivisionRepository は分筆のリポジトリインターフェース
+type FieldDivisionRepository interface {
+	// Divide は分筆を1トランザクションで永続化する
+	// 子圃場の作成、分筆履歴の記録、農地台帳の付け替え・按分、親圃場の廃止をまとめて行い、
+	// 永続化した分筆履歴をdivision.Recordsに設定する
+	// 子圃場のはみ出し・重なりや親圃場の廃止済みを検出した場合はentityのエラーをラップして返す
+	Divide(ctx context.Context, division *entity.Division) error
+}
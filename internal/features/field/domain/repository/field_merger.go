@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+)
+
+// FieldMergerRepository は合筆のリポジトリインターフェース
+type FieldMergerRepository interface {
+	// Merge は合筆を1トランザクションで永続化する
+	// ソース圃場の結合、合筆先圃場の作成、合筆履歴の記録、農地台帳の引き継ぎ、ソース圃場の廃止をまとめて行う
+	// 結合したジオメトリをmerger.Resultに、永続化した合筆履歴をmerger.Recordsに設定する
+	// ソース圃場が隣接していない、存在しない、廃止済みの場合はentityのエラーをラップして返す
+	Merge(ctx context.Context, merger *entity.Merger) error
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+)
+
+// FieldOverlapRepository はオーバーラップ検知記録のリポジトリインターフェース
+type FieldOverlapRepository interface {
+	// DetectByFieldIDs は指定圃場と他の有効な圃場の重なりを検出して記録し、検出件数を返す
+	// 指定圃場が関わる既存の記録のうち重なりが無くなったもの(廃止済み圃場を含む)は削除する
+	DetectByFieldIDs(ctx context.Context, fieldIDs []uuid.UUID) (int, error)
+
+	// FindByID はIDでオーバーラップを取得する
+	// 存在しない場合はnilを返す
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.FieldOverlap, error)
+
+	// Accept は許容済みにしたオーバーラップの対応結果を永続化する
+	// 同時にクリップで解消された場合はentity.ErrOverlapAlreadyClippedを返す
+	Accept(ctx context.Context, overlap *entity.FieldOverlap) error
+
+	// Clip はクリップ対象圃場から重なり部分を取り除き、対応結果とあわせて1トランザクションで永続化する
+	// クリップ後のジオメトリをfieldに設定する。クリップで圃場が消滅する場合はentity.ErrClipResultInvalidを、
+	// 対象圃場が廃止済みの場合はentity.ErrFieldRetiredをラップして返す
+	Clip(ctx context.Context, overlap *entity.FieldOverlap, field *entity.Field) error
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+)
+
+// FieldTileCacheRepository は圃場ベクタータイルのキャッシュリポジトリインターフェース
+type FieldTileCacheRepository interface {
+	// GetTile はキャッシュからタイルを取得する
+	// キャッシュミスの場合はfalseを返す(空タイルもキャッシュ対象のため、データの有無では判定しない)
+	GetTile(ctx context.Context, tile entity.Tile) ([]byte, bool, error)
+
+	// SetTile はタイルをキャッシュに保存する
+	SetTile(ctx context.Context, tile entity.Tile, data []byte) error
+
+	// DeleteByH3Cells は指定H3セル内の圃場を含み得るタイルをキャッシュから削除する
+	DeleteByH3Cells(ctx context.Context, h3Cells []string) error
+
+	// DeleteAll は全てのタイルをキャッシュから削除する
+	DeleteAll(ctx context.Context) error
+}
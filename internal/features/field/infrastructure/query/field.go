@@ -0,0 +1,241 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	appQuery "github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/internal/geomutil"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+)
+
+// fieldQuery はFieldQueryの実装
+type fieldQuery struct {
+	db      *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewFieldQuery は新しいFieldQueryを作成する
+func NewFieldQuery(db *pgxpool.Pool) appQuery.FieldQuery {
+	return &fieldQuery{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+// List は検索条件に一致する圃場一覧を取得する
+func (q *fieldQuery) List(ctx context.Context, filter appQuery.FieldListFilter, limit, offset int32) ([]*entity.Field, error) {
+	params := &sqlc.SearchFieldsParams{
+		CityCode:           filter.CityCode,
+		SoilSmallCode:      filter.SoilTypeSmallCode,
+		LandCategoryCode:   filter.LandCategoryCode,
+		IdleLandStatusCode: filter.IdleLandStatusCode,
+		MinAreaSqm:         filter.MinAreaSqm,
+		MaxAreaSqm:         filter.MaxAreaSqm,
+		Name:               filter.Name,
+		NamePattern:        filter.NamePattern,
+		RowLimit:           limit,
+		RowOffset:          offset,
+	}
+	if filter.BoundingBox != nil {
+		params.SwLat = &filter.BoundingBox.SWLat
+		params.SwLng = &filter.BoundingBox.SWLng
+		params.NeLat = &filter.BoundingBox.NELat
+		params.NeLng = &filter.BoundingBox.NELng
+	}
+
+	rows, err := q.queries.SearchFields(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]*entity.Field, len(rows))
+	for i, row := range rows {
+		fields[i] = q.toEntity(row)
+	}
+	return fields, nil
+}
+
+// Count は検索条件に一致する圃場の総数を取得する
+func (q *fieldQuery) Count(ctx context.Context, filter appQuery.FieldListFilter) (int64, error) {
+	params := &sqlc.CountSearchFieldsParams{
+		CityCode:           filter.CityCode,
+		SoilSmallCode:      filter.SoilTypeSmallCode,
+		LandCategoryCode:   filter.LandCategoryCode,
+		IdleLandStatusCode: filter.IdleLandStatusCode,
+		MinAreaSqm:         filter.MinAreaSqm,
+		MaxAreaSqm:         filter.MaxAreaSqm,
+		Name:               filter.Name,
+		NamePattern:        filter.NamePattern,
+	}
+	if filter.BoundingBox != nil {
+		params.SwLat = &filter.BoundingBox.SWLat
+		params.SwLng = &filter.BoundingBox.SWLng
+		params.NeLat = &filter.BoundingBox.NELat
+		params.NeLng = &filter.BoundingBox.NELng
+	}
+
+	return q.queries.CountSearchFields(ctx, params)
+}
+
+// FindDetailByID はIDで圃場詳細を取得する
+func (q *fieldQuery) FindDetailByID(ctx context.Context, id uuid.UUID) (*appQuery.FieldDetail, error) {
+	row, err := q.queries.GetField(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	field, err := q.toDetailEntity(row)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &appQuery.FieldDetail{
+		Field:          field,
+		LandRegistries: []*appQuery.LandRegistryDetail{},
+	}
+
+	if row.SoilTypeID.Valid {
+		soilType, err := q.queries.GetSoilType(ctx, row.SoilTypeID.UUID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("土壌タイプの取得に失敗しました: %w", err)
+		}
+		if err == nil {
+			detail.SoilType = q.toSoilTypeEntity(soilType)
+		}
+	}
+
+	registries, err := q.queries.ListFieldLandRegistriesWithMastersByFieldID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("農地台帳の取得に失敗しました: %w", err)
+	}
+	for _, r := range registries {
+		detail.LandRegistries = append(detail.LandRegistries, q.toLandRegistryDetail(r))
+	}
+
+	return detail, nil
+}
+
+// toEntity はSQLCモデルをエンティティに変換する
+func (q *fieldQuery) toEntity(row *sqlc.SearchFieldsRow) *entity.Field {
+	if row == nil {
+		return nil
+	}
+
+	field := &entity.Field{
+		ID:       row.ID,
+		AreaSqm:  row.AreaSqm,
+		H3Index:  row.H3Index,
+		CityCode: row.CityCode,
+		Name:     row.Name,
+	}
+
+	if row.SoilTypeID.Valid {
+		field.SoilTypeID = &row.SoilTypeID.UUID
+	}
+	if row.CreatedAt.Valid {
+		field.CreatedAt = row.CreatedAt.Time
+	}
+	if row.UpdatedAt.Valid {
+		field.UpdatedAt = row.UpdatedAt.Time
+	}
+	if row.CreatedBy.Valid {
+		field.CreatedBy = &row.CreatedBy.UUID
+	}
+	if row.UpdatedBy.Valid {
+		field.UpdatedBy = &row.UpdatedBy.UUID
+	}
+
+	return field
+}
+
+// toDetailEntity はジオメトリを含むSQLCモデルをエンティティに変換する
+func (q *fieldQuery) toDetailEntity(row *sqlc.Field) (*entity.Field, error) {
+	multiPolygon, err := geomutil.DecodeMultiPolygon(row.Geometry)
+	if err != nil {
+		return nil, fmt.Errorf("圃場ジオメトリのデコードに失敗しました: %w", err)
+	}
+	centroid, err := geomutil.DecodePoint(row.Centroid)
+	if err != nil {
+		return nil, fmt.Errorf("圃場重心のデコードに失敗しました: %w", err)
+	}
+
+	field := q.toEntity(&sqlc.SearchFieldsRow{
+		ID:         row.ID,
+		AreaSqm:    row.AreaSqm,
+		H3Index:    row.H3Index,
+		CityCode:   row.CityCode,
+		Name:       row.Name,
+		SoilTypeID: row.SoilTypeID,
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+		CreatedBy:  row.CreatedBy,
+		UpdatedBy:  row.UpdatedBy,
+	})
+	field.Geometry = multiPolygon
+	field.Centroid = centroid
+	if row.RetiredAt.Valid {
+		field.RetiredAt = &row.RetiredAt.Time
+	}
+
+	return field, nil
+}
+
+// toSoilTypeEntity は土壌タイプのSQLCモデルをエンティティに変換する
+func (q *fieldQuery) toSoilTypeEntity(row *sqlc.SoilType) *entity.SoilType {
+	soilType := &entity.SoilType{
+		ID:          row.ID,
+		LargeCode:   row.LargeCode,
+		MiddleCode:  row.MiddleCode,
+		SmallCode:   row.SmallCode,
+		SmallName:   row.SmallName,
+		Description: row.Description,
+	}
+	if row.CreatedAt.Valid {
+		soilType.CreatedAt = row.CreatedAt.Time
+	}
+	if row.UpdatedAt.Valid {
+		soilType.UpdatedAt = row.UpdatedAt.Time
+	}
+	return soilType
+}
+
+// toLandRegistryDetail は農地台帳の行をマスタ名称付きの読み取りモデルに変換する
+func (q *fieldQuery) toLandRegistryDetail(row *sqlc.ListFieldLandRegistriesWithMastersByFieldIDRow) *appQuery.LandRegistryDetail {
+	registry := &entity.FieldLandRegistry{
+		ID:                 row.ID,
+		FieldID:            row.FieldID,
+		FarmerNumber:       row.FarmerNumber,
+		Address:            row.Address,
+		AreaSqm:            row.AreaSqm,
+		LandCategoryCode:   row.LandCategoryCode,
+		IdleLandStatusCode: row.IdleLandStatusCode,
+	}
+	if row.DescriptiveStudyData.Valid {
+		t := row.DescriptiveStudyData.Time
+		registry.DescriptiveStudyData = &t
+	}
+	if row.CreatedAt.Valid {
+		registry.CreatedAt = row.CreatedAt.Time
+	}
+	if row.UpdatedAt.Valid {
+		registry.UpdatedAt = row.UpdatedAt.Time
+	}
+
+	detail := &appQuery.LandRegistryDetail{Registry: registry}
+	if row.LandCategoryCode != nil && row.LandCategoryName != nil {
+		detail.LandCategory = entity.NewLandCategory(*row.LandCategoryCode, *row.LandCategoryName)
+	}
+	if row.IdleLandStatusCode != nil && row.IdleLandStatusName != nil {
+		detail.IdleLandStatus = entity.NewIdleLandStatus(*row.IdleLandStatusCode, *row.IdleLandStatusName)
+	}
+	return detail
+}
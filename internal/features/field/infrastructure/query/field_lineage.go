@@ -0,0 +1,141 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	appQuery "github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/mktkhr/field-manager-api/internal/utils"
+)
+
+// lineageDirectionAncestor は祖先方向に辿ったエッジを表すdirectionの値
+const lineageDirectionAncestor = "ancestor"
+
+// fieldLineageQuery はFieldLineageQueryの実装
+type fieldLineageQuery struct {
+	queries *sqlc.Queries
+}
+
+// NewFieldLineageQuery は新しいFieldLineageQueryを作成する
+func NewFieldLineageQuery(db *pgxpool.Pool) appQuery.FieldLineageQuery {
+	return &fieldLineageQuery{
+		queries: sqlc.New(db),
+	}
+}
+
+// FindLineage は分筆・合筆履歴を祖先・子孫方向にmaxDepth世代まで辿った系譜グラフを取得する
+func (q *fieldLineageQuery) FindLineage(ctx context.Context, fieldID uuid.UUID, maxDepth int) (*appQuery.FieldLineage, error) {
+	// 最大世代数の1つ先まで取得し、それより先の履歴が残っているかを判定する
+	edgeRows, err := q.queries.ListFieldLineageEdges(ctx, &sqlc.ListFieldLineageEdgesParams{
+		FieldID:  fieldID,
+		MaxDepth: utils.SafeIntToInt32(maxDepth + 1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("系譜エッジ取得失敗: %w", err)
+	}
+
+	lineage, depths := buildLineageEdges(fieldID, maxDepth, edgeRows)
+
+	ids := make([]uuid.UUID, 0, len(depths))
+	for id := range depths {
+		ids = append(ids, id)
+	}
+	nodeRows, err := q.queries.ListFieldLineageNodes(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("系譜ノード取得失敗: %w", err)
+	}
+
+	lineage.Nodes = toLineageNodes(nodeRows, depths)
+	for _, node := range lineage.Nodes {
+		if node.FieldID == fieldID {
+			return lineage, nil
+		}
+	}
+	// 起点の圃場が存在しない
+	return nil, nil
+}
+
+// buildLineageEdges はエッジの行から重複を除いた系譜グラフと、各圃場の起点からの世代差を組み立てる
+// 行はdepthの昇順で渡されるため、複数の経路から到達したエッジ・圃場は最も近い世代のものを採用する
+func buildLineageEdges(fieldID uuid.UUID, maxDepth int, rows []*sqlc.ListFieldLineageEdgesRow) (*appQuery.FieldLineage, map[uuid.UUID]int) {
+	type edgeKey struct {
+		edgeType string
+		from     uuid.UUID
+		to       uuid.UUID
+	}
+
+	lineage := &appQuery.FieldLineage{
+		FieldID:  fieldID,
+		MaxDepth: maxDepth,
+		Edges:    make([]*appQuery.LineageEdge, 0, len(rows)),
+	}
+	depths := map[uuid.UUID]int{fieldID: 0}
+	seen := make(map[edgeKey]struct{}, len(rows))
+
+	for _, row := range rows {
+		if int(row.Depth) > maxDepth {
+			lineage.Truncated = true
+			continue
+		}
+
+		key := edgeKey{edgeType: row.EdgeType, from: row.FromFieldID, to: row.ToFieldID}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		edge := &appQuery.LineageEdge{
+			Type:        appQuery.LineageEdgeType(row.EdgeType),
+			FromFieldID: row.FromFieldID,
+			ToFieldID:   row.ToFieldID,
+			Reason:      row.Reason,
+		}
+		if row.OccurredAt.Valid {
+			edge.OccurredAt = row.OccurredAt.Time
+		}
+		lineage.Edges = append(lineage.Edges, edge)
+
+		// 祖先方向はfrom側、子孫方向はto側が新たに到達した圃場
+		reached, depth := row.ToFieldID, int(row.Depth)
+		if row.Direction == lineageDirectionAncestor {
+			reached, depth = row.FromFieldID, -int(row.Depth)
+		}
+		if _, ok := depths[reached]; !ok {
+			depths[reached] = depth
+		}
+	}
+	return lineage, depths
+}
+
+// toLineageNodes は圃場の行を世代順の系譜ノードに変換する
+func toLineageNodes(rows []*sqlc.ListFieldLineageNodesRow, depths map[uuid.UUID]int) []*appQuery.LineageNode {
+	nodes := make([]*appQuery.LineageNode, 0, len(rows))
+	for _, row := range rows {
+		node := &appQuery.LineageNode{
+			FieldID:  row.ID,
+			Name:     row.Name,
+			CityCode: row.CityCode,
+			AreaSqm:  row.AreaSqm,
+			Depth:    depths[row.ID],
+		}
+		if row.CreatedAt.Valid {
+			node.CreatedAt = row.CreatedAt.Time
+		}
+		if row.RetiredAt.Valid {
+			node.RetiredAt = &row.RetiredAt.Time
+		}
+		nodes = append(nodes, node)
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Depth != nodes[j].Depth {
+			return nodes[i].Depth < nodes[j].Depth
+		}
+		return nodes[i].CreatedAt.Before(nodes[j].CreatedAt)
+	})
+	return nodes
+}
@@ -0,0 +1,73 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	appQuery "github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildLineageEdges(t *testing.T) {
+	// grandparent --分筆--> parent --分筆--> root, sibling
+	// root + sibling --合筆--> merged
+	grandparent, parent, root, sibling, merged := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	rows := []*sqlc.ListFieldLineageEdgesRow{
+		{Direction: "ancestor", FromFieldID: parent, ToFieldID: root, EdgeType: "division", OccurredAt: now, Depth: 1},
+		{Direction: "descendant", FromFieldID: root, ToFieldID: merged, EdgeType: "merger", OccurredAt: now, Depth: 1},
+		{Direction: "ancestor", FromFieldID: grandparent, ToFieldID: parent, EdgeType: "division", OccurredAt: now, Depth: 2},
+		// 最大世代数を超えたエッジ
+		{Direction: "ancestor", FromFieldID: uuid.New(), ToFieldID: grandparent, EdgeType: "merger", OccurredAt: now, Depth: 3},
+	}
+
+	lineage, depths := buildLineageEdges(root, 2, rows)
+
+	require.Equal(t, root, lineage.FieldID, "起点圃場IDが一致しない")
+	require.Equal(t, 2, lineage.MaxDepth, "最大世代数が一致しない")
+	require.True(t, lineage.Truncated, "最大世代数を超えた履歴があればtruncatedになるべき")
+	require.Len(t, lineage.Edges, 3, "最大世代数を超えたエッジは含めるべきでない")
+	require.Equal(t, appQuery.LineageEdgeMerger, lineage.Edges[1].Type, "エッジ種別が一致しない")
+	require.Equal(t, map[uuid.UUID]int{root: 0, parent: -1, grandparent: -2, merged: 1}, depths, "世代差が期待値と異なります")
+	require.NotContains(t, depths, sibling, "兄弟圃場は系譜に含めるべきでない")
+}
+
+func TestBuildLineageEdges_Duplicate(t *testing.T) {
+	// 同じ親から分筆された2圃場を合筆した場合、祖先への経路が2つになる
+	root, left, right, parent := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	rows := []*sqlc.ListFieldLineageEdgesRow{
+		{Direction: "ancestor", FromFieldID: left, ToFieldID: root, EdgeType: "merger", Depth: 1},
+		{Direction: "ancestor", FromFieldID: right, ToFieldID: root, EdgeType: "merger", Depth: 1},
+		{Direction: "ancestor", FromFieldID: parent, ToFieldID: left, EdgeType: "division", Depth: 2},
+		{Direction: "ancestor", FromFieldID: parent, ToFieldID: right, EdgeType: "division", Depth: 2},
+		{Direction: "ancestor", FromFieldID: parent, ToFieldID: left, EdgeType: "division", Depth: 2},
+	}
+
+	lineage, depths := buildLineageEdges(root, 10, rows)
+
+	require.False(t, lineage.Truncated, "最大世代数以内ならtruncatedになるべきでない")
+	require.Len(t, lineage.Edges, 4, "重複したエッジは1つにまとめるべき")
+	require.Equal(t, -2, depths[parent], "共通の祖先の世代差が一致しない")
+}
+
+func TestToLineageNodes(t *testing.T) {
+	now := time.Now()
+	root, parent, child := uuid.New(), uuid.New(), uuid.New()
+	area := 100.0
+	rows := []*sqlc.ListFieldLineageNodesRow{
+		{ID: child, Name: "子", CityCode: "163210", CreatedAt: pgtype.Timestamptz{Time: now, Valid: true}},
+		{ID: root, Name: "起点", CityCode: "163210", AreaSqm: &area, CreatedAt: pgtype.Timestamptz{Time: now, Valid: true}, RetiredAt: pgtype.Timestamptz{Time: now, Valid: true}},
+		{ID: parent, Name: "親", CityCode: "163210", CreatedAt: pgtype.Timestamptz{Time: now, Valid: true}},
+	}
+
+	nodes := toLineageNodes(rows, map[uuid.UUID]int{root: 0, parent: -1, child: 1})
+
+	require.Len(t, nodes, 3, "ノード数が期待値と異なります")
+	require.Equal(t, []uuid.UUID{parent, root, child}, []uuid.UUID{nodes[0].FieldID, nodes[1].FieldID, nodes[2].FieldID}, "世代順に並んでいない")
+	require.Equal(t, &area, nodes[1].AreaSqm, "面積が一致しない")
+	require.NotNil(t, nodes[1].RetiredAt, "廃止日時が設定されていない")
+	require.Nil(t, nodes[0].RetiredAt, "有効な圃場の廃止日時はnilであるべき")
+}
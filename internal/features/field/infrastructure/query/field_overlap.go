@@ -0,0 +1,100 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	appQuery "github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+)
+
+// fieldOverlapQuery はFieldOverlapQueryの実装
+type fieldOverlapQuery struct {
+	db      *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewFieldOverlapQuery は新しいFieldOverlapQueryを作成する
+func NewFieldOverlapQuery(db *pgxpool.Pool) appQuery.FieldOverlapQuery {
+	return &fieldOverlapQuery{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+// List は検索条件に一致するオーバーラップを重なりの割合が大きい順に取得する
+func (q *fieldOverlapQuery) List(ctx context.Context, filter appQuery.FieldOverlapFilter, limit, offset int32) ([]*entity.FieldOverlap, error) {
+	status, fieldID := toOverlapFilterParams(filter)
+	rows, err := q.queries.ListFieldOverlaps(ctx, &sqlc.ListFieldOverlapsParams{
+		Status:    status,
+		FieldID:   fieldID,
+		RowLimit:  limit,
+		RowOffset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	overlaps := make([]*entity.FieldOverlap, len(rows))
+	for i, row := range rows {
+		overlaps[i] = toOverlapEntity(row)
+	}
+	return overlaps, nil
+}
+
+// Count は検索条件に一致するオーバーラップの総数を取得する
+func (q *fieldOverlapQuery) Count(ctx context.Context, filter appQuery.FieldOverlapFilter) (int64, error) {
+	status, fieldID := toOverlapFilterParams(filter)
+	return q.queries.CountFieldOverlaps(ctx, &sqlc.CountFieldOverlapsParams{
+		Status:  status,
+		FieldID: fieldID,
+	})
+}
+
+// toOverlapFilterParams は検索条件をSQLCのパラメータ型に変換する
+func toOverlapFilterParams(filter appQuery.FieldOverlapFilter) (*string, uuid.NullUUID) {
+	var status *string
+	if filter.Status != nil {
+		s := string(*filter.Status)
+		status = &s
+	}
+	var fieldID uuid.NullUUID
+	if filter.FieldID != nil {
+		fieldID = uuid.NullUUID{UUID: *filter.FieldID, Valid: true}
+	}
+	return status, fieldID
+}
+
+// toOverlapEntity はオーバーラップ検知記録のSQLCモデルをエンティティに変換する
+func toOverlapEntity(row *sqlc.FieldOverlap) *entity.FieldOverlap {
+	o := &entity.FieldOverlap{
+		ID:             row.ID,
+		FieldIDA:       row.FieldIDA,
+		FieldIDB:       row.FieldIDB,
+		OverlapAreaSqm: row.OverlapAreaSqm,
+		OverlapRatio:   row.OverlapRatio,
+		Status:         entity.OverlapStatus(row.Status),
+		Note:           row.Note,
+	}
+	if row.ClippedFieldID.Valid {
+		o.ClippedFieldID = &row.ClippedFieldID.UUID
+	}
+	if row.DetectedAt.Valid {
+		o.DetectedAt = row.DetectedAt.Time
+	}
+	if row.ResolvedAt.Valid {
+		o.ResolvedAt = &row.ResolvedAt.Time
+	}
+	if row.ResolvedBy.Valid {
+		o.ResolvedBy = &row.ResolvedBy.UUID
+	}
+	if row.CreatedAt.Valid {
+		o.CreatedAt = row.CreatedAt.Time
+	}
+	if row.UpdatedAt.Valid {
+		o.UpdatedAt = row.UpdatedAt.Time
+	}
+	return o
+}
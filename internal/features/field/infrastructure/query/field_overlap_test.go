@@ -0,0 +1,53 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	appQuery "github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToOverlapFilterParams(t *testing.T) {
+	status := entity.OverlapStatusOpen
+	fieldID := uuid.New()
+
+	gotStatus, gotFieldID := toOverlapFilterParams(appQuery.FieldOverlapFilter{Status: &status, FieldID: &fieldID})
+	require.Equal(t, "open", *gotStatus, "ステータスが一致しない")
+	require.Equal(t, uuid.NullUUID{UUID: fieldID, Valid: true}, gotFieldID, "圃場IDが一致しない")
+
+	gotStatus, gotFieldID = toOverlapFilterParams(appQuery.FieldOverlapFilter{})
+	require.Nil(t, gotStatus, "未指定のステータスはnilにすべき")
+	require.False(t, gotFieldID.Valid, "未指定の圃場IDは無効値にすべき")
+}
+
+func TestToOverlapEntity(t *testing.T) {
+	now := time.Now()
+	clippedID := uuid.New()
+	note := "クリップで解消"
+	row := &sqlc.FieldOverlap{
+		ID:             uuid.New(),
+		FieldIDA:       uuid.New(),
+		FieldIDB:       clippedID,
+		OverlapAreaSqm: 42.5,
+		OverlapRatio:   0.3,
+		Status:         "clipped",
+		ClippedFieldID: uuid.NullUUID{UUID: clippedID, Valid: true},
+		Note:           &note,
+		DetectedAt:     pgtype.Timestamptz{Time: now, Valid: true},
+		ResolvedAt:     pgtype.Timestamptz{Time: now, Valid: true},
+	}
+
+	got := toOverlapEntity(row)
+
+	require.Equal(t, entity.OverlapStatusClipped, got.Status, "ステータスが一致しない")
+	require.Equal(t, &clippedID, got.ClippedFieldID, "クリップした圃場IDが一致しない")
+	require.Equal(t, now, got.DetectedAt, "検知日時が一致しない")
+	require.NotNil(t, got.ResolvedAt, "対応日時が設定されていない")
+	require.Nil(t, got.ResolvedBy, "対応者が未設定ならnilにすべき")
+	require.Equal(t, &note, got.Note, "備考が一致しない")
+}
@@ -0,0 +1,84 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldQuery_ToEntity_Nil(t *testing.T) {
+	q := &fieldQuery{}
+	require.Nil(t, q.toEntity(nil), "nilの行はnilに変換されるべき")
+}
+
+func TestFieldQuery_ToEntity_FieldMapping(t *testing.T) {
+	now := time.Now()
+	id := uuid.New()
+	soilTypeID := uuid.New()
+	userID := uuid.New()
+	area := 1234.5
+	h3Index := "8f1f8d3a4bc0000"
+
+	row := &sqlc.SearchFieldsRow{
+		ID:         id,
+		AreaSqm:    &area,
+		H3Index:    &h3Index,
+		CityCode:   "163210",
+		Name:       "テスト圃場",
+		SoilTypeID: uuid.NullUUID{UUID: soilTypeID, Valid: true},
+		CreatedAt:  pgtype.Timestamptz{Time: now, Valid: true},
+		UpdatedAt:  pgtype.Timestamptz{Time: now, Valid: true},
+		CreatedBy:  uuid.NullUUID{UUID: userID, Valid: true},
+	}
+
+	q := &fieldQuery{}
+	field := q.toEntity(row)
+
+	require.NotNil(t, field, "toEntity()がnilを返した")
+	require.Equal(t, id, field.ID, "IDが一致しない")
+	require.Equal(t, &area, field.AreaSqm, "AreaSqmが一致しない")
+	require.Equal(t, &h3Index, field.H3Index, "H3Indexが一致しない")
+	require.Equal(t, "163210", field.CityCode, "CityCodeが一致しない")
+	require.Equal(t, "テスト圃場", field.Name, "Nameが一致しない")
+	require.NotNil(t, field.SoilTypeID, "SoilTypeIDがnil")
+	require.Equal(t, soilTypeID, *field.SoilTypeID, "SoilTypeIDが一致しない")
+	require.Equal(t, now, field.CreatedAt, "CreatedAtが一致しない")
+	require.Equal(t, now, field.UpdatedAt, "UpdatedAtが一致しない")
+	require.NotNil(t, field.CreatedBy, "CreatedByがnil")
+	require.Equal(t, userID, *field.CreatedBy, "CreatedByが一致しない")
+	require.Nil(t, field.UpdatedBy, "UpdatedByはnilであるべき")
+}
+
+func TestFieldQuery_ToLandRegistryDetail(t *testing.T) {
+	code := "01"
+	name := "田"
+	idleCode := "2"
+	address := "富山県射水市1-1"
+
+	row := &sqlc.ListFieldLandRegistriesWithMastersByFieldIDRow{
+		ID:                 uuid.New(),
+		FieldID:            uuid.New(),
+		Address:            &address,
+		LandCategoryCode:   &code,
+		LandCategoryName:   &name,
+		IdleLandStatusCode: &idleCode,
+		// マスタに存在しないコードは名称がNULLになる
+		IdleLandStatusName:   nil,
+		DescriptiveStudyData: pgtype.Date{Time: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	q := &fieldQuery{}
+	detail := q.toLandRegistryDetail(row)
+
+	require.Equal(t, row.ID, detail.Registry.ID, "IDが一致しない")
+	require.Equal(t, &address, detail.Registry.Address, "所在地が一致しない")
+	require.NotNil(t, detail.Registry.DescriptiveStudyData, "実態調査日がnil")
+	require.NotNil(t, detail.LandCategory, "土地種別がnil")
+	require.Equal(t, "田", detail.LandCategory.Name, "土地種別名が一致しない")
+	require.Nil(t, detail.IdleLandStatus, "名称が解決できない遊休農地状況はnilであるべき")
+	require.Equal(t, &idleCode, detail.Registry.IdleLandStatusCode, "遊休農地状況コードは保持されるべき")
+}
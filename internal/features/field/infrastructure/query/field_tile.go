@@ -0,0 +1,37 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	appQuery "github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/mktkhr/field-manager-api/internal/utils"
+)
+
+// fieldTileQuery はFieldTileQueryの実装
+type fieldTileQuery struct {
+	queries *sqlc.Queries
+}
+
+// NewFieldTileQuery は新しいFieldTileQueryを作成する
+func NewFieldTileQuery(db *pgxpool.Pool) appQuery.FieldTileQuery {
+	return &fieldTileQuery{
+		queries: sqlc.New(db),
+	}
+}
+
+// GetTile は指定タイルの圃場をMapbox Vector Tile形式で取得する
+func (q *fieldTileQuery) GetTile(ctx context.Context, tile entity.Tile) ([]byte, error) {
+	data, err := q.queries.GetFieldTile(ctx, &sqlc.GetFieldTileParams{
+		Z: utils.SafeIntToInt32(tile.Z),
+		X: utils.SafeIntToInt32(tile.X),
+		Y: utils.SafeIntToInt32(tile.Y),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("タイル生成失敗: %w", err)
+	}
+	return data, nil
+}
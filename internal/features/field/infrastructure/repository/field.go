@@ -3,14 +3,17 @@ package repository
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/internal/geomutil"
 	importdto "github.com/mktkhr/field-manager-api/internal/features/import/domain/dto"
 	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
 	"github.com/twpayne/go-geom"
@@ -35,9 +38,13 @@ func NewFieldRepository(db *pgxpool.Pool, logger *slog.Logger) *fieldRepository
 }
 
 // FindByID はIDで圃場を取得する
+// 圃場が存在しない場合はnilを返す
 func (r *fieldRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Field, error) {
 	row, err := r.queries.GetField(ctx, id)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
 		return nil, err
 	}
 	return r.toEntity(row), nil
@@ -45,28 +52,153 @@ func (r *fieldRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.F
 
 // Create は圃場を作成する
 func (r *fieldRepository) Create(ctx context.Context, field *entity.Field) error {
-	// 実装は省略(基本的なCRUDはSQLCで対応)
+	geometryWKB, centroidWKB, err := fieldToWKB(field)
+	if err != nil {
+		return err
+	}
+
+	row, err := r.queries.CreateField(ctx, &sqlc.CreateFieldParams{
+		ID:          field.ID,
+		GeometryWkb: geometryWKB,
+		CentroidWkb: centroidWKB,
+		H3Index:     field.H3Index,
+		CityCode:    field.CityCode,
+		Name:        field.Name,
+		SoilTypeID:  uuidToNullUUID(field.SoilTypeID),
+		CreatedBy:   uuidToNullUUID(field.CreatedBy),
+		UpdatedBy:   uuidToNullUUID(field.UpdatedBy),
+	})
+	if err != nil {
+		return fmt.Errorf("圃場作成失敗: %w", err)
+	}
+
+	r.applyRow(field, row)
 	return nil
 }
 
 // Update は圃場を更新する
+// 圃場が廃止済みの場合(読み取り後に分筆・合筆された場合を含む)は更新せず、entity.ErrFieldRetiredをラップして返す
 func (r *fieldRepository) Update(ctx context.Context, field *entity.Field) error {
-	// 実装は省略(基本的なCRUDはSQLCで対応)
+	geometryWKB, centroidWKB, err := fieldToWKB(field)
+	if err != nil {
+		return err
+	}
+
+	row, err := r.queries.UpdateField(ctx, &sqlc.UpdateFieldParams{
+		GeometryWkb: geometryWKB,
+		CentroidWkb: centroidWKB,
+		H3Index:     field.H3Index,
+		CityCode:    field.CityCode,
+		Name:        field.Name,
+		SoilTypeID:  uuidToNullUUID(field.SoilTypeID),
+		UpdatedBy:   uuidToNullUUID(field.UpdatedBy),
+		ID:          field.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %s", entity.ErrFieldRetired, field.ID)
+		}
+		return fmt.Errorf("圃場更新失敗: %w", err)
+	}
+
+	r.applyRow(field, row)
 	return nil
 }
 
 // Delete は圃場を削除する
+// field_land_registriesは圃場をカスケード削除しないため、同一トランザクションで先に削除する。
+// 分筆・合筆で作られた圃場は、削除すると履歴がカスケード削除されて系譜を辿れなくなるため、
+// 農地台帳を残したまま廃止し、未対応・許容済みのオーバーラップ検知記録のみ削除する。
+// 圃場が廃止済みの場合はentity.ErrFieldRetiredをラップして返す
 func (r *fieldRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.queries.DeleteField(ctx, id)
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("トランザクション開始に失敗: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.Error("トランザクションのロールバックに失敗。データ不整合の可能性があります",
+				slog.String("error", err.Error()))
+		}
+	}()
+
+	queries := sqlc.New(tx)
+
+	// 同時に分筆・合筆されないよう圃場をロック
+	locked, err := queries.LockFieldForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("圃場ロック失敗: %w", err)
+	}
+	if locked.RetiredAt.Valid {
+		return fmt.Errorf("%w: %s", entity.ErrFieldRetired, id)
+	}
+
+	hasLineage, err := queries.HasFieldLineage(ctx, id)
+	if err != nil {
+		return fmt.Errorf("系譜確認失敗: %w", err)
+	}
+
+	if hasLineage {
+		if err := queries.RetireDeletedField(ctx, &sqlc.RetireDeletedFieldParams{
+			RetiredAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+			ID:        id,
+		}); err != nil {
+			return fmt.Errorf("圃場廃止失敗: %w", err)
+		}
+		if err := queries.DeleteStaleFieldOverlaps(ctx, &sqlc.DeleteStaleFieldOverlapsParams{
+			FieldIds:    []uuid.UUID{id},
+			DetectedIds: []uuid.UUID{},
+		}); err != nil {
+			return fmt.Errorf("オーバーラップ検知記録削除失敗: %w", err)
+		}
+	} else {
+		if err := queries.DeleteFieldLandRegistriesByFieldID(ctx, id); err != nil {
+			return fmt.Errorf("農地台帳削除失敗: %w", err)
+		}
+		if err := queries.DeleteField(ctx, id); err != nil {
+			return fmt.Errorf("圃場削除失敗: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("コミット失敗: %w", err)
+	}
+
+	return nil
 }
 
 // Upsert は圃場をUPSERTする
+// 圃場が廃止済みの場合は更新せず、entity.ErrFieldRetiredをラップして返す
 func (r *fieldRepository) Upsert(ctx context.Context, field *entity.Field) error {
-	// 実装は省略
+	geometryWKB, centroidWKB, err := fieldToWKB(field)
+	if err != nil {
+		return err
+	}
+
+	row, err := r.queries.UpsertField(ctx, &sqlc.UpsertFieldParams{
+		ID:          field.ID,
+		GeometryWkb: geometryWKB,
+		CentroidWkb: centroidWKB,
+		H3Index:     field.H3Index,
+		CityCode:    field.CityCode,
+		SoilTypeID:  uuidToNullUUID(field.SoilTypeID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %s", entity.ErrFieldRetired, field.ID)
+		}
+		return fmt.Errorf("圃場UPSERT失敗: %w", err)
+	}
+
+	r.applyRow(field, row)
 	return nil
 }
 
 // UpsertBatch は圃場をバッチでUPSERTする(wagriインポート用)
+// 分筆・合筆で廃止済みの圃場は、ジオメトリを戻したり引き継ぎ済みの農地台帳を重複させたりしないよう更新せずにスキップする
 func (r *fieldRepository) UpsertBatch(ctx context.Context, inputs []importdto.FieldBatchInput) error {
 	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
@@ -81,6 +213,7 @@ func (r *fieldRepository) UpsertBatch(ctx context.Context, inputs []importdto.Fi
 
 	queries := sqlc.New(tx)
 
+	skippedRetired := 0
 	for _, input := range inputs {
 		// 1. 土壌タイプをUPSERT(トランザクション内で直接実行)
 		var soilTypeID *uuid.UUID
@@ -123,15 +256,14 @@ func (r *fieldRepository) UpsertBatch(ctx context.Context, inputs []importdto.Fi
 			return fmt.Errorf("圃場ID変換失敗: %w", err)
 		}
 
-		// LinearPolygon -> Polygon変換
-		geometryCoords := input.GetFirstCoordinates()
-		polygon, err := entity.ConvertLinearPolygonToPolygon(geometryCoords)
+		// LinearPolygon(区画ごと) -> MultiPolygon変換
+		multiPolygon, err := entity.ConvertWagriPolygonsToMultiPolygon(input.Geometry.Polygons)
 		if err != nil {
 			return fmt.Errorf("ジオメトリ変換失敗: %w", err)
 		}
 
 		field := entity.NewField(fieldID, input.CityCode)
-		if err := field.SetGeometry(polygon); err != nil {
+		if err := field.SetMultiPolygon(multiPolygon); err != nil {
 			return fmt.Errorf("ジオメトリ設定失敗: %w", err)
 		}
 		if soilTypeID != nil {
@@ -148,19 +280,22 @@ func (r *fieldRepository) UpsertBatch(ctx context.Context, inputs []importdto.Fi
 			return fmt.Errorf("centroid WKB変換失敗: %w", err)
 		}
 
-		// UpsertFieldを実行
+		// UpsertFieldを実行(廃止済みの圃場は行が返らない)
 		_, err = queries.UpsertField(ctx, &sqlc.UpsertFieldParams{
 			ID:          field.ID,
 			GeometryWkb: geometryWKB,
 			CentroidWkb: centroidWKB,
-			H3IndexRes3: field.H3IndexRes3,
-			H3IndexRes5: field.H3IndexRes5,
-			H3IndexRes7: field.H3IndexRes7,
-			H3IndexRes9: field.H3IndexRes9,
+			H3Index:     field.H3Index,
 			CityCode:    field.CityCode,
 			SoilTypeID:  uuidToNullUUID(field.SoilTypeID),
 		})
 		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				skippedRetired++
+				r.logger.Warn("廃止済みの圃場のため更新をスキップしました",
+					slog.String("field_id", fieldID.String()))
+				continue
+			}
 			return fmt.Errorf("圃場UPSERT失敗: %w", err)
 		}
 
@@ -204,6 +339,11 @@ func (r *fieldRepository) UpsertBatch(ctx context.Context, inputs []importdto.Fi
 		return fmt.Errorf("コミット失敗: %w", err)
 	}
 
+	if skippedRetired > 0 {
+		r.logger.Info("廃止済みの圃場をスキップしました",
+			slog.Int("skipped_count", skippedRetired),
+			slog.Int("total_count", len(inputs)))
+	}
 	return nil
 }
 
@@ -214,16 +354,29 @@ func (r *fieldRepository) toEntity(row *sqlc.Field) *entity.Field {
 	}
 
 	field := &entity.Field{
-		ID:          row.ID,
-		AreaSqm:     row.AreaSqm,
-		H3IndexRes3: row.H3IndexRes3,
-		H3IndexRes5: row.H3IndexRes5,
-		H3IndexRes7: row.H3IndexRes7,
-		H3IndexRes9: row.H3IndexRes9,
-		CityCode:    row.CityCode,
-		Name:        row.Name,
+		ID:       row.ID,
+		AreaSqm:  row.AreaSqm,
+		H3Index:  row.H3Index,
+		CityCode: row.CityCode,
+		Name:     row.Name,
 	}
 
+	multiPolygon, err := geomutil.DecodeMultiPolygon(row.Geometry)
+	if err != nil {
+		r.logger.Warn("圃場ジオメトリのデコードに失敗しました",
+			slog.String("field_id", row.ID.String()),
+			slog.String("error", err.Error()))
+	}
+	field.Geometry = multiPolygon
+
+	centroid, err := geomutil.DecodePoint(row.Centroid)
+	if err != nil {
+		r.logger.Warn("圃場重心のデコードに失敗しました",
+			slog.String("field_id", row.ID.String()),
+			slog.String("error", err.Error()))
+	}
+	field.Centroid = centroid
+
 	if row.SoilTypeID.Valid {
 		field.SoilTypeID = &row.SoilTypeID.UUID
 	}
@@ -239,10 +392,38 @@ func (r *fieldRepository) toEntity(row *sqlc.Field) *entity.Field {
 	if row.UpdatedBy.Valid {
 		field.UpdatedBy = &row.UpdatedBy.UUID
 	}
+	if row.RetiredAt.Valid {
+		field.RetiredAt = &row.RetiredAt.Time
+	}
 
 	return field
 }
 
+// applyRow はDBで確定した値(面積・名称・タイムスタンプ)をエンティティに反映する
+func (r *fieldRepository) applyRow(field *entity.Field, row *sqlc.Field) {
+	field.AreaSqm = row.AreaSqm
+	field.Name = row.Name
+	if row.CreatedAt.Valid {
+		field.CreatedAt = row.CreatedAt.Time
+	}
+	if row.UpdatedAt.Valid {
+		field.UpdatedAt = row.UpdatedAt.Time
+	}
+}
+
+// fieldToWKB は圃場のジオメトリと重心をWKB形式に変換する
+func fieldToWKB(field *entity.Field) ([]byte, []byte, error) {
+	geometryWKB, err := geometryToWKB(field.Geometry)
+	if err != nil {
+		return nil, nil, fmt.Errorf("geometry WKB変換失敗: %w", err)
+	}
+	centroidWKB, err := geometryToWKB(field.Centroid)
+	if err != nil {
+		return nil, nil, fmt.Errorf("centroid WKB変換失敗: %w", err)
+	}
+	return geometryWKB, centroidWKB, nil
+}
+
 // uuidToNullUUID はuuid.UUIDポインタをuuid.NullUUIDに変換する
 func uuidToNullUUID(id *uuid.UUID) uuid.NullUUID {
 	if id == nil {
@@ -312,11 +493,8 @@ func (r *fieldRepository) GetH3IndexesByFieldIDs(ctx context.Context, fieldIDs [
 	result := make([]importdto.FieldH3Prefetch, len(rows))
 	for i, row := range rows {
 		result[i] = importdto.FieldH3Prefetch{
-			ID:          row.ID.String(),
-			H3IndexRes3: row.H3IndexRes3,
-			H3IndexRes5: row.H3IndexRes5,
-			H3IndexRes7: row.H3IndexRes7,
-			H3IndexRes9: row.H3IndexRes9,
+			ID:      row.ID.String(),
+			H3Index: row.H3Index,
 		}
 	}
 	return result, nil
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/repository"
+	"github.com/mktkhr/field-manager-api/internal/features/field/internal/geomutil"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+)
+
+// fieldCentroidRepository はFieldCentroidRepositoryの実装
+type fieldCentroidRepository struct {
+	queries *sqlc.Queries
+	logger  *slog.Logger
+}
+
+// NewFieldCentroidRepository は新しいFieldCentroidRepositoryを作成する
+func NewFieldCentroidRepository(db *pgxpool.Pool, logger *slog.Logger) repository.FieldCentroidRepository {
+	return &fieldCentroidRepository{
+		queries: sqlc.New(db),
+		logger:  logger,
+	}
+}
+
+// ListAfter はafterIDより後の圃場(廃止済みを含む)をID順にlimit件取得する
+// ジオメトリをデコードできない圃場はGeometryをnilにして返す
+func (r *fieldCentroidRepository) ListAfter(ctx context.Context, afterID *uuid.UUID, limit int32) ([]*entity.Field, error) {
+	rows, err := r.queries.ListFieldsForCentroidBackfill(ctx, &sqlc.ListFieldsForCentroidBackfillParams{
+		AfterID:  uuidToNullUUID(afterID),
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("重心再計算対象の圃場取得失敗: %w", err)
+	}
+
+	fields := make([]*entity.Field, 0, len(rows))
+	for _, row := range rows {
+		fields = append(fields, r.toCentroidEntity(row))
+	}
+	return fields, nil
+}
+
+// UpdateCentroid は圃場の重心とH3インデックスのみを更新する
+func (r *fieldCentroidRepository) UpdateCentroid(ctx context.Context, field *entity.Field) error {
+	centroidWKB, err := geometryToWKB(field.Centroid)
+	if err != nil {
+		return fmt.Errorf("centroid WKB変換失敗: %w", err)
+	}
+
+	if err := r.queries.UpdateFieldCentroid(ctx, &sqlc.UpdateFieldCentroidParams{
+		CentroidWkb: centroidWKB,
+		H3Index:     field.H3Index,
+		ID:          field.ID,
+	}); err != nil {
+		return fmt.Errorf("圃場重心更新失敗: %w", err)
+	}
+	return nil
+}
+
+// toCentroidEntity は重心再計算用の取得結果をエンティティに変換する
+func (r *fieldCentroidRepository) toCentroidEntity(row *sqlc.ListFieldsForCentroidBackfillRow) *entity.Field {
+	field := &entity.Field{
+		ID:      row.ID,
+		H3Index: row.H3Index,
+	}
+
+	multiPolygon, err := geomutil.DecodeMultiPolygon(row.Geometry)
+	if err != nil {
+		r.logger.Warn("圃場ジオメトリのデコードに失敗しました",
+			slog.String("field_id", row.ID.String()),
+			slog.String("error", err.Error()))
+	}
+	field.Geometry = multiPolygon
+
+	centroid, err := geomutil.DecodePoint(row.Centroid)
+	if err != nil {
+		r.logger.Warn("圃場重心のデコードに失敗しました",
+			slog.String("field_id", row.ID.String()),
+			slog.String("error", err.Error()))
+	}
+	field.Centroid = centroid
+
+	if row.RetiredAt.Valid {
+		field.RetiredAt = &row.RetiredAt.Time
+	}
+	return field
+}
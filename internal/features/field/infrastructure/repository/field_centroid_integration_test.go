@@ -0,0 +1,77 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/twpayne/go-geom"
+)
+
+func TestFieldCentroidRepository_ListAfterAndUpdate_Integration(t *testing.T) {
+	ctx := context.Background()
+	cleanupTestData(t, ctx)
+
+	first := createTestOverlapField(t, ctx, 139.6917, 35.6895, 139.6920, 35.6898)
+	second := createTestOverlapField(t, ctx, 139.7000, 35.6895, 139.7003, 35.6898)
+
+	repo := NewFieldCentroidRepository(testDB, slog.Default())
+
+	fields, err := repo.ListAfter(ctx, nil, 10)
+	if err != nil {
+		t.Fatalf("ListAfter() error = %v", err)
+	}
+	if len(fields) != 2 {
+		t.Fatalf("ListAfter() = %d件, want 2", len(fields))
+	}
+	if fields[0].ID.String() > fields[1].ID.String() {
+		t.Error("ID順に並んでいない")
+	}
+	for _, f := range fields {
+		if f.Geometry == nil || f.Centroid == nil {
+			t.Errorf("圃場%sのジオメトリまたは重心がデコードされていない", f.ID)
+		}
+	}
+
+	// キーセットページングで先頭の圃場より後だけを返す
+	rest, err := repo.ListAfter(ctx, &fields[0].ID, 10)
+	if err != nil {
+		t.Fatalf("ListAfter(afterID) error = %v", err)
+	}
+	if len(rest) != 1 || rest[0].ID != fields[1].ID {
+		t.Errorf("ListAfter(afterID) = %v, want [%s]", rest, fields[1].ID)
+	}
+
+	// 重心とH3インデックスのみが更新される
+	target := fields[0]
+	target.Centroid = geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{139.6918, 35.6896})
+	if err := target.CalculateH3Index(35.6896, 139.6918); err != nil {
+		t.Fatalf("CalculateH3Index() error = %v", err)
+	}
+	if err := repo.UpdateCentroid(ctx, target); err != nil {
+		t.Fatalf("UpdateCentroid() error = %v", err)
+	}
+
+	updated, err := NewFieldRepository(testDB, slog.Default()).FindByID(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if updated.Centroid.X() != 139.6918 || updated.Centroid.Y() != 35.6896 {
+		t.Errorf("Centroid = (%v, %v), want (139.6918, 35.6896)", updated.Centroid.X(), updated.Centroid.Y())
+	}
+	if *updated.H3Index != *target.H3Index {
+		t.Errorf("H3Index = %s, want %s", *updated.H3Index, *target.H3Index)
+	}
+	if updated.Geometry == nil || updated.Geometry.NumCoords() != 5 {
+		t.Error("ジオメトリが変更されている")
+	}
+
+	for _, id := range []uuid.UUID{first.ID, second.ID} {
+		if id != fields[0].ID && id != fields[1].ID {
+			t.Errorf("作成した圃場%sが取得されていない", id)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/repository"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+)
+
+// fieldDivisionRepository はFieldDivisionRepositoryの実装
+type fieldDivisionRepository struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewFieldDivisionRepository は新しいFieldDivisionRepositoryを作成する
+func NewFieldDivisionRepository(db *pgxpool.Pool, logger *slog.Logger) repository.FieldDivisionRepository {
+	return &fieldDivisionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Divide は分筆を1トランザクションで永続化する
+func (r *fieldDivisionRepository) Divide(ctx context.Context, division *entity.Division) error {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("トランザクション開始に失敗: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.Error("トランザクションのロールバックに失敗。データ不整合の可能性があります",
+				slog.String("error", err.Error()))
+		}
+	}()
+
+	queries := sqlc.New(tx)
+	parent := division.Parent
+
+	// 1. 親圃場をロックし、同時に分筆・合筆されていないことを確認
+	locked, err := queries.LockFieldForUpdate(ctx, parent.ID)
+	if err != nil {
+		return fmt.Errorf("親圃場ロック失敗: %w", err)
+	}
+	if locked.RetiredAt.Valid {
+		return entity.ErrFieldRetired
+	}
+
+	// 2. 子圃場のはみ出し・重なりを検証
+	if err := checkDivisionGeometries(ctx, queries, division); err != nil {
+		return err
+	}
+
+	// 3. 子圃場を作成
+	children := division.ChildFields()
+	childIDs := make([]uuid.UUID, 0, len(children))
+	childAreas := make([]float64, 0, len(children))
+	for _, child := range children {
+		geometryWKB, centroidWKB, err := fieldToWKB(child)
+		if err != nil {
+			return err
+		}
+		row, err := queries.CreateField(ctx, &sqlc.CreateFieldParams{
+			ID:          child.ID,
+			GeometryWkb: geometryWKB,
+			CentroidWkb: centroidWKB,
+			H3Index:     child.H3Index,
+			CityCode:    child.CityCode,
+			Name:        child.Name,
+			SoilTypeID:  uuidToNullUUID(child.SoilTypeID),
+			CreatedBy:   uuidToNullUUID(child.CreatedBy),
+			UpdatedBy:   uuidToNullUUID(child.UpdatedBy),
+		})
+		if err != nil {
+			return fmt.Errorf("子圃場作成失敗: %w", err)
+		}
+		childIDs = append(childIDs, child.ID)
+		if row.AreaSqm != nil {
+			childAreas = append(childAreas, *row.AreaSqm)
+		} else {
+			childAreas = append(childAreas, 0)
+		}
+	}
+
+	// 4. 分筆履歴を記録
+	dividedAt := pgtype.Timestamptz{Time: division.DividedAt, Valid: true}
+	records := make([]*entity.FieldDivision, 0, len(children))
+	for _, childID := range childIDs {
+		row, err := queries.CreateFieldDivision(ctx, &sqlc.CreateFieldDivisionParams{
+			ParentFieldID: parent.ID,
+			ChildFieldID:  childID,
+			DividedAt:     dividedAt,
+			Reason:        division.Reason,
+			CreatedBy:     uuidToNullUUID(division.DividedBy),
+		})
+		if err != nil {
+			return fmt.Errorf("分筆履歴作成失敗: %w", err)
+		}
+		records = append(records, toFieldDivisionEntity(row))
+	}
+
+	// 5. 農地台帳を付け替え、指定のないものは子圃場の面積で按分して複製
+	if err := distributeLandRegistries(ctx, queries, division, childIDs, childAreas); err != nil {
+		return err
+	}
+
+	// 6. 親圃場を廃止(系譜を保持するため削除しない)
+	if err := queries.RetireField(ctx, &sqlc.RetireFieldParams{
+		RetiredAt: dividedAt,
+		Name:      parent.Name,
+		UpdatedBy: uuidToNullUUID(division.DividedBy),
+		ID:        parent.ID,
+	}); err != nil {
+		return fmt.Errorf("親圃場廃止失敗: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("コミット失敗: %w", err)
+	}
+
+	division.Records = records
+	retiredAt := division.DividedAt
+	parent.RetiredAt = &retiredAt
+	return nil
+}
+
+// checkDivisionGeometries は子圃場が親圃場に収まり、互いに重ならないことを検証する
+func checkDivisionGeometries(ctx context.Context, queries *sqlc.Queries, division *entity.Division) error {
+	children := division.ChildFields()
+	wkbs := make([][]byte, 0, len(children))
+	for _, child := range children {
+		geometryWKB, err := geometryToWKB(child.Geometry)
+		if err != nil {
+			return fmt.Errorf("geometry WKB変換失敗: %w", err)
+		}
+		wkbs = append(wkbs, geometryWKB)
+	}
+
+	rows, err := queries.CheckFieldDivisionGeometries(ctx, &sqlc.CheckFieldDivisionGeometriesParams{
+		ChildGeometryWkbs: wkbs,
+		ParentID:          division.Parent.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("分筆ジオメトリ検証失敗: %w", err)
+	}
+
+	for _, row := range rows {
+		if row.OutsideAreaSqm > entity.DivisionAreaToleranceSqm {
+			return fmt.Errorf("%w: 子圃場%d(はみ出し面積 %.1f平方メートル)",
+				entity.ErrDivisionChildOutsideParent, row.ChildIndex, row.OutsideAreaSqm)
+		}
+		if row.OverlapAreaSqm > entity.DivisionAreaToleranceSqm {
+			return fmt.Errorf("%w: 子圃場%d(重なり面積 %.1f平方メートル)",
+				entity.ErrDivisionChildrenOverlap, row.ChildIndex, row.OverlapAreaSqm)
+		}
+	}
+	return nil
+}
+
+// distributeLandRegistries は親圃場の農地台帳を子圃場に付け替え、または按分して複製する
+func distributeLandRegistries(
+	ctx context.Context,
+	queries *sqlc.Queries,
+	division *entity.Division,
+	childIDs []uuid.UUID,
+	childAreas []float64,
+) error {
+	rows, err := queries.ListFieldLandRegistriesByFieldID(ctx, division.Parent.ID)
+	if err != nil {
+		return fmt.Errorf("農地台帳取得失敗: %w", err)
+	}
+
+	owned := make(map[uuid.UUID]struct{}, len(rows))
+	for _, row := range rows {
+		owned[row.ID] = struct{}{}
+	}
+	for _, child := range division.Children {
+		for _, id := range child.LandRegistryIDs {
+			if _, ok := owned[id]; !ok {
+				return fmt.Errorf("%w: %s", entity.ErrLandRegistryNotInParent, id)
+			}
+		}
+	}
+
+	for _, row := range rows {
+		if target := division.AssignedChild(row.ID); target != nil {
+			if err := queries.UpdateFieldLandRegistryFieldID(ctx, &sqlc.UpdateFieldLandRegistryFieldIDParams{
+				FieldID: target.ID,
+				ID:      row.ID,
+			}); err != nil {
+				return fmt.Errorf("農地台帳付け替え失敗: %w", err)
+			}
+			continue
+		}
+
+		for _, c := range toLandRegistryEntity(row).SplitInto(childIDs, childAreas) {
+			if _, err := queries.CreateFieldLandRegistry(ctx, toCreateLandRegistryParams(c)); err != nil {
+				return fmt.Errorf("農地台帳按分失敗: %w", err)
+			}
+		}
+	}
+
+	// 按分済みの農地台帳を親圃場から削除(付け替え済みのものは既に親圃場に存在しない)
+	if err := queries.DeleteFieldLandRegistriesByFieldID(ctx, division.Parent.ID); err != nil {
+		return fmt.Errorf("農地台帳削除失敗: %w", err)
+	}
+	return nil
+}
+
+// toFieldDivisionEntity は分筆履歴のSQLCモデルをエンティティに変換する
+func toFieldDivisionEntity(row *sqlc.FieldDivision) *entity.FieldDivision {
+	d := &entity.FieldDivision{
+		ID:            row.ID,
+		ParentFieldID: row.ParentFieldID,
+		ChildFieldID:  row.ChildFieldID,
+		Reason:        row.Reason,
+	}
+	if row.DividedAt.Valid {
+		d.DividedAt = row.DividedAt.Time
+	}
+	if row.CreatedAt.Valid {
+		d.CreatedAt = row.CreatedAt.Time
+	}
+	if row.CreatedBy.Valid {
+		d.CreatedBy = &row.CreatedBy.UUID
+	}
+	return d
+}
@@ -0,0 +1,218 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/twpayne/go-geom"
+)
+
+// newTestDivisionChild は指定範囲の矩形ジオメトリを持つ子圃場を作成する
+func newTestDivisionChild(t *testing.T, minLng, minLat, maxLng, maxLat float64, registryIDs ...uuid.UUID) *entity.DivisionChild {
+	t.Helper()
+	polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{minLng, minLat}, {maxLng, minLat}, {maxLng, maxLat}, {minLng, maxLat}, {minLng, minLat}},
+	})
+	field := entity.NewField(uuid.New(), "163210")
+	if err := field.SetGeometry(polygon); err != nil {
+		t.Fatalf("SetGeometry() error = %v", err)
+	}
+	return &entity.DivisionChild{Field: field, LandRegistryIDs: registryIDs}
+}
+
+// createTestParentWithRegistries は農地台帳を2件持つ親圃場を作成する(newTestFieldWithGeometryと同じ範囲)
+func createTestParentWithRegistries(t *testing.T, ctx context.Context) (*entity.Field, []uuid.UUID) {
+	t.Helper()
+	parent := newTestFieldWithGeometry(t)
+	if err := NewFieldRepository(testDB, slog.Default()).Create(ctx, parent); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	queries := sqlc.New(testDB)
+	ids := make([]uuid.UUID, 0, 2)
+	for _, address := range []string{"富山県射水市1-1", "富山県射水市1-2"} {
+		area := int32(1000)
+		row, err := queries.CreateFieldLandRegistry(ctx, &sqlc.CreateFieldLandRegistryParams{
+			FieldID: parent.ID,
+			Address: &address,
+			AreaSqm: &area,
+		})
+		if err != nil {
+			t.Fatalf("CreateFieldLandRegistry() error = %v", err)
+		}
+		ids = append(ids, row.ID)
+	}
+	return parent, ids
+}
+
+func TestFieldDivisionRepository_Divide_Integration(t *testing.T) {
+	ctx := context.Background()
+	cleanupTestData(t, ctx)
+
+	parent, registryIDs := createTestParentWithRegistries(t, ctx)
+	division, err := entity.NewDivision(parent, []*entity.DivisionChild{
+		newTestDivisionChild(t, 139.6917, 35.6895, 139.69185, 35.6898, registryIDs[0]),
+		newTestDivisionChild(t, 139.69185, 35.6895, 139.6920, 35.6898),
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewDivision() error = %v", err)
+	}
+
+	repo := NewFieldDivisionRepository(testDB, slog.Default())
+	if err := repo.Divide(ctx, division); err != nil {
+		t.Fatalf("Divide() error = %v", err)
+	}
+	if len(division.Records) != 2 {
+		t.Errorf("len(Records) = %d, want 2", len(division.Records))
+	}
+
+	found, err := NewFieldRepository(testDB, slog.Default()).FindByID(ctx, parent.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found == nil || !found.IsRetired() {
+		t.Fatal("親圃場が廃止されていない")
+	}
+
+	queries := sqlc.New(testDB)
+	parentRegistries, err := queries.ListFieldLandRegistriesByFieldID(ctx, parent.ID)
+	if err != nil {
+		t.Fatalf("ListFieldLandRegistriesByFieldID() error = %v", err)
+	}
+	if len(parentRegistries) != 0 {
+		t.Errorf("親圃場に農地台帳が残っている: %d件", len(parentRegistries))
+	}
+
+	// 付け替えた農地台帳は1件目の子圃場にのみ存在し、残りは両方に按分される
+	var proratedSum int32
+	for i, child := range division.ChildFields() {
+		rows, err := queries.ListFieldLandRegistriesByFieldID(ctx, child.ID)
+		if err != nil {
+			t.Fatalf("ListFieldLandRegistriesByFieldID() error = %v", err)
+		}
+		want := 1
+		if i == 0 {
+			want = 2
+		}
+		if len(rows) != want {
+			t.Errorf("子圃場%dの農地台帳 = %d件, want %d", i+1, len(rows), want)
+		}
+		for _, row := range rows {
+			if row.ID == registryIDs[0] {
+				continue
+			}
+			if row.AreaSqm != nil {
+				proratedSum += *row.AreaSqm
+			}
+		}
+	}
+	if proratedSum != 1000 {
+		t.Errorf("按分後の面積合計 = %d, want 1000", proratedSum)
+	}
+}
+
+func TestFieldDivisionRepository_Divide_InvalidGeometry_Integration(t *testing.T) {
+	tests := []struct {
+		name     string
+		children func(t *testing.T) []*entity.DivisionChild
+		errIs    error
+	}{
+		{
+			name: "子圃場が親圃場からはみ出す",
+			children: func(t *testing.T) []*entity.DivisionChild {
+				return []*entity.DivisionChild{
+					newTestDivisionChild(t, 139.6917, 35.6895, 139.69185, 35.6898),
+					newTestDivisionChild(t, 139.69185, 35.6895, 139.6925, 35.6898),
+				}
+			},
+			errIs: entity.ErrDivisionChildOutsideParent,
+		},
+		{
+			name: "子圃場同士が重なる",
+			children: func(t *testing.T) []*entity.DivisionChild {
+				return []*entity.DivisionChild{
+					newTestDivisionChild(t, 139.6917, 35.6895, 139.6919, 35.6898),
+					newTestDivisionChild(t, 139.6918, 35.6895, 139.6920, 35.6898),
+				}
+			},
+			errIs: entity.ErrDivisionChildrenOverlap,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cleanupTestData(t, ctx)
+
+			parent, _ := createTestParentWithRegistries(t, ctx)
+			division, err := entity.NewDivision(parent, tt.children(t), nil, nil)
+			if err != nil {
+				t.Fatalf("NewDivision() error = %v", err)
+			}
+
+			err = NewFieldDivisionRepository(testDB, slog.Default()).Divide(ctx, division)
+			if !errors.Is(err, tt.errIs) {
+				t.Fatalf("Divide() error = %v, want %v", err, tt.errIs)
+			}
+
+			found, err := NewFieldRepository(testDB, slog.Default()).FindByID(ctx, parent.ID)
+			if err != nil {
+				t.Fatalf("FindByID() error = %v", err)
+			}
+			if found.IsRetired() {
+				t.Error("検証エラー時に親圃場が廃止されている")
+			}
+		})
+	}
+}
+
+func TestFieldRepository_Delete_DivisionChild_Integration(t *testing.T) {
+	ctx := context.Background()
+	cleanupTestData(t, ctx)
+
+	parent, _ := createTestParentWithRegistries(t, ctx)
+	division, err := entity.NewDivision(parent, []*entity.DivisionChild{
+		newTestDivisionChild(t, 139.6917, 35.6895, 139.69185, 35.6898),
+		newTestDivisionChild(t, 139.69185, 35.6895, 139.6920, 35.6898),
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewDivision() error = %v", err)
+	}
+	if err := NewFieldDivisionRepository(testDB, slog.Default()).Divide(ctx, division); err != nil {
+		t.Fatalf("Divide() error = %v", err)
+	}
+
+	repo := NewFieldRepository(testDB, slog.Default())
+	child := division.ChildFields()[0]
+	if err := repo.Delete(ctx, child.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	// 分筆で作られた圃場は削除されずに廃止され、分筆履歴が残る
+	found, err := repo.FindByID(ctx, child.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found == nil || !found.IsRetired() {
+		t.Fatal("子圃場が廃止されていない")
+	}
+	hasLineage, err := sqlc.New(testDB).HasFieldLineage(ctx, child.ID)
+	if err != nil {
+		t.Fatalf("HasFieldLineage() error = %v", err)
+	}
+	if !hasLineage {
+		t.Error("分筆履歴が削除されている")
+	}
+
+	// 廃止済みの圃場は削除できない
+	if err := repo.Delete(ctx, child.ID); !errors.Is(err, entity.ErrFieldRetired) {
+		t.Errorf("Delete() error = %v, want ErrFieldRetired", err)
+	}
+}
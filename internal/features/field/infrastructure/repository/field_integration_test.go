@@ -4,16 +4,21 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
 	importdto "github.com/mktkhr/field-manager-api/internal/features/import/domain/dto"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/twpayne/go-geom"
 )
 
 var testDB *pgxpool.Pool
@@ -68,42 +73,143 @@ func TestNewFieldRepository_Integration(t *testing.T) {
 	}
 }
 
+// newTestFieldWithGeometry はジオメトリを設定済みのテスト用圃場を作成する
+func newTestFieldWithGeometry(t *testing.T) *entity.Field {
+	t.Helper()
+	polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{
+			{139.6917, 35.6895},
+			{139.6920, 35.6895},
+			{139.6920, 35.6898},
+			{139.6917, 35.6898},
+			{139.6917, 35.6895},
+		},
+	})
+	field := entity.NewField(uuid.New(), "163210")
+	if err := field.SetGeometry(polygon); err != nil {
+		t.Fatalf("SetGeometry() error = %v", err)
+	}
+	return field
+}
+
 func TestFieldRepository_Create_Integration(t *testing.T) {
 	ctx := context.Background()
+	cleanupTestData(t, ctx)
+
 	repo := NewFieldRepository(testDB, slog.Default())
 
-	field := entity.NewField(uuid.New(), "163210")
+	userID := uuid.New()
+	field := newTestFieldWithGeometry(t)
+	field.Name = "手動登録圃場"
+	field.CreatedBy = &userID
+	field.UpdatedBy = &userID
 
-	// Create is a stub that returns nil
 	err := repo.Create(ctx, field)
 	if err != nil {
-		t.Errorf("Create() error = %v", err)
+		t.Fatalf("Create() error = %v", err)
+	}
+	if field.AreaSqm == nil || *field.AreaSqm <= 0 {
+		t.Errorf("AreaSqm = %v, want positive value", field.AreaSqm)
+	}
+
+	found, err := repo.FindByID(ctx, field.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.Name != "手動登録圃場" {
+		t.Errorf("Name = %q, want %q", found.Name, "手動登録圃場")
+	}
+	if found.CreatedBy == nil || *found.CreatedBy != userID {
+		t.Errorf("CreatedBy = %v, want %v", found.CreatedBy, userID)
+	}
+	if found.H3Index == nil || *found.H3Index != *field.H3Index {
+		t.Errorf("H3Index = %v, want %v", found.H3Index, *field.H3Index)
 	}
 }
 
 func TestFieldRepository_Update_Integration(t *testing.T) {
 	ctx := context.Background()
+	cleanupTestData(t, ctx)
+
 	repo := NewFieldRepository(testDB, slog.Default())
 
-	field := entity.NewField(uuid.New(), "163210")
+	field := newTestFieldWithGeometry(t)
+	if err := repo.Create(ctx, field); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
 
-	// Update is a stub that returns nil
+	userID := uuid.New()
+	field.Name = "更新後圃場"
+	field.UpdatedBy = &userID
 	err := repo.Update(ctx, field)
 	if err != nil {
-		t.Errorf("Update() error = %v", err)
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, field.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.Name != "更新後圃場" {
+		t.Errorf("Name = %q, want %q", found.Name, "更新後圃場")
+	}
+	if found.UpdatedBy == nil || *found.UpdatedBy != userID {
+		t.Errorf("UpdatedBy = %v, want %v", found.UpdatedBy, userID)
+	}
+}
+
+func TestFieldRepository_Update_Retired_Integration(t *testing.T) {
+	// 読み取り後に廃止された圃場は更新せず、ErrFieldRetiredを返すことを確認する
+	ctx := context.Background()
+	cleanupTestData(t, ctx)
+
+	repo := NewFieldRepository(testDB, slog.Default())
+
+	field := newTestFieldWithGeometry(t)
+	if err := repo.Create(ctx, field); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := sqlc.New(testDB).RetireField(ctx, &sqlc.RetireFieldParams{
+		RetiredAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		Name:      field.Name,
+		ID:        field.ID,
+	}); err != nil {
+		t.Fatalf("RetireField() error = %v", err)
+	}
+
+	field.Name = "更新後圃場"
+	if err := repo.Update(ctx, field); !errors.Is(err, entity.ErrFieldRetired) {
+		t.Fatalf("Update() error = %v, want %v", err, entity.ErrFieldRetired)
+	}
+
+	found, err := repo.FindByID(ctx, field.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.Name == "更新後圃場" {
+		t.Error("廃止済みの圃場が更新されている")
 	}
 }
 
 func TestFieldRepository_Upsert_Integration(t *testing.T) {
 	ctx := context.Background()
+	cleanupTestData(t, ctx)
+
 	repo := NewFieldRepository(testDB, slog.Default())
 
-	field := entity.NewField(uuid.New(), "163210")
+	field := newTestFieldWithGeometry(t)
 
-	// Upsert is a stub that returns nil
 	err := repo.Upsert(ctx, field)
 	if err != nil {
-		t.Errorf("Upsert() error = %v", err)
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, field.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found == nil {
+		t.Fatal("FindByID() returned nil after Upsert")
 	}
 }
 
@@ -120,6 +226,52 @@ func TestFieldRepository_Delete_Integration(t *testing.T) {
 	}
 }
 
+func TestFieldRepository_Delete_WithLandRegistries_Integration(t *testing.T) {
+	// 農地台帳を持つ圃場も削除できることを確認する
+	ctx := context.Background()
+	cleanupTestData(t, ctx)
+
+	repo := NewFieldRepository(testDB, slog.Default())
+
+	fieldID := uuid.New()
+	input := importdto.FieldBatchInput{
+		ID:       fieldID.String(),
+		CityCode: "163210",
+		Geometry: importdto.FieldBatchGeometry{
+			Type: "Polygon",
+			Polygons: [][][][]float64{
+				{
+					{
+						{139.6917, 35.6895},
+						{139.6920, 35.6895},
+						{139.6920, 35.6898},
+						{139.6917, 35.6898},
+						{139.6917, 35.6895},
+					},
+				},
+			},
+		},
+		PinInfoList: []importdto.FieldBatchPinInfo{
+			{Address: "富山県射水市1-1", Area: 100},
+		},
+	}
+	if err := repo.UpsertBatch(ctx, []importdto.FieldBatchInput{input}); err != nil {
+		t.Fatalf("UpsertBatch() error = %v", err)
+	}
+
+	if err := repo.Delete(ctx, fieldID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, fieldID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found != nil {
+		t.Error("FindByID() should return nil after Delete")
+	}
+}
+
 func TestFieldRepository_FindByID_NotFound_Integration(t *testing.T) {
 	ctx := context.Background()
 	cleanupTestData(t, ctx)
@@ -127,9 +279,12 @@ func TestFieldRepository_FindByID_NotFound_Integration(t *testing.T) {
 	repo := NewFieldRepository(testDB, slog.Default())
 
 	// 存在しないIDで検索
-	_, err := repo.FindByID(ctx, uuid.New())
-	if err == nil {
-		t.Error("FindByID() expected error for non-existent ID, got nil")
+	found, err := repo.FindByID(ctx, uuid.New())
+	if err != nil {
+		t.Errorf("FindByID() error = %v", err)
+	}
+	if found != nil {
+		t.Error("FindByID() expected nil for non-existent ID")
 	}
 }
 
@@ -160,13 +315,15 @@ func TestFieldRepository_UpsertBatch_SingleFeature_Integration(t *testing.T) {
 		CityCode: "163210",
 		Geometry: importdto.FieldBatchGeometry{
 			Type: "Polygon",
-			Coordinates: [][][]float64{
+			Polygons: [][][][]float64{
 				{
-					{139.6917, 35.6895},
-					{139.6920, 35.6895},
-					{139.6920, 35.6898},
-					{139.6917, 35.6898},
-					{139.6917, 35.6895},
+					{
+						{139.6917, 35.6895},
+						{139.6920, 35.6895},
+						{139.6920, 35.6898},
+						{139.6917, 35.6898},
+						{139.6917, 35.6895},
+					},
 				},
 			},
 		},
@@ -200,13 +357,15 @@ func TestFieldRepository_UpsertBatch_WithSoilType_Integration(t *testing.T) {
 		CityCode: "163210",
 		Geometry: importdto.FieldBatchGeometry{
 			Type: "Polygon",
-			Coordinates: [][][]float64{
+			Polygons: [][][][]float64{
 				{
-					{139.6917, 35.6895},
-					{139.6920, 35.6895},
-					{139.6920, 35.6898},
-					{139.6917, 35.6898},
-					{139.6917, 35.6895},
+					{
+						{139.6917, 35.6895},
+						{139.6920, 35.6895},
+						{139.6920, 35.6898},
+						{139.6917, 35.6898},
+						{139.6917, 35.6895},
+					},
 				},
 			},
 		},
@@ -246,13 +405,15 @@ func TestFieldRepository_UpsertBatch_WithPinInfo_Integration(t *testing.T) {
 		CityCode: "163210",
 		Geometry: importdto.FieldBatchGeometry{
 			Type: "Polygon",
-			Coordinates: [][][]float64{
+			Polygons: [][][][]float64{
 				{
-					{139.6917, 35.6895},
-					{139.6920, 35.6895},
-					{139.6920, 35.6898},
-					{139.6917, 35.6898},
-					{139.6917, 35.6895},
+					{
+						{139.6917, 35.6895},
+						{139.6920, 35.6895},
+						{139.6920, 35.6898},
+						{139.6917, 35.6898},
+						{139.6917, 35.6895},
+					},
 				},
 			},
 		},
@@ -284,6 +445,99 @@ func TestFieldRepository_UpsertBatch_WithPinInfo_Integration(t *testing.T) {
 	}
 }
 
+// newTestBatchInput は指定位置の矩形と農地台帳を持つwagriインポートの入力を作成する
+func newTestBatchInput(fieldID uuid.UUID, minLng float64, addresses ...string) importdto.FieldBatchInput {
+	pinInfoList := make([]importdto.FieldBatchPinInfo, 0, len(addresses))
+	for _, address := range addresses {
+		pinInfoList = append(pinInfoList, importdto.FieldBatchPinInfo{Address: address})
+	}
+	return importdto.FieldBatchInput{
+		ID:       fieldID.String(),
+		CityCode: "163210",
+		Geometry: importdto.FieldBatchGeometry{
+			Type: "Polygon",
+			Polygons: [][][][]float64{
+				{
+					{
+						{minLng, 35.6895},
+						{minLng + 0.0003, 35.6895},
+						{minLng + 0.0003, 35.6898},
+						{minLng, 35.6898},
+						{minLng, 35.6895},
+					},
+				},
+			},
+		},
+		PinInfoList: pinInfoList,
+	}
+}
+
+func TestFieldRepository_UpsertBatch_SkipsRetiredField_Integration(t *testing.T) {
+	// 分筆・合筆で廃止済みの圃場は再インポートで更新されず、農地台帳も置き換えられないことを確認する
+	ctx := context.Background()
+	cleanupTestData(t, ctx)
+
+	repo := NewFieldRepository(testDB, slog.Default())
+	retiredID := uuid.New()
+	if err := repo.UpsertBatch(ctx, []importdto.FieldBatchInput{
+		newTestBatchInput(retiredID, 139.6917, "富山県射水市1"),
+	}); err != nil {
+		t.Fatalf("UpsertBatch() error = %v", err)
+	}
+	before, err := repo.FindByID(ctx, retiredID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if err := sqlc.New(testDB).RetireField(ctx, &sqlc.RetireFieldParams{
+		RetiredAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		Name:      before.Name,
+		ID:        retiredID,
+	}); err != nil {
+		t.Fatalf("RetireField() error = %v", err)
+	}
+
+	// 廃止済みの圃場と新しい圃場を同じバッチで再インポートする
+	newID := uuid.New()
+	if err := repo.UpsertBatch(ctx, []importdto.FieldBatchInput{
+		newTestBatchInput(retiredID, 139.7000, "富山県射水市1", "富山県射水市2"),
+		newTestBatchInput(newID, 139.6930, "富山県射水市3"),
+	}); err != nil {
+		t.Fatalf("UpsertBatch() error = %v", err)
+	}
+
+	after, err := repo.FindByID(ctx, retiredID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if !after.IsRetired() {
+		t.Error("廃止済みの圃場が有効に戻っている")
+	}
+	if *after.H3Index != *before.H3Index {
+		t.Errorf("廃止済みの圃場のH3インデックスが更新されている: %s -> %s", *before.H3Index, *after.H3Index)
+	}
+	registries, err := sqlc.New(testDB).ListFieldLandRegistriesByFieldID(ctx, retiredID)
+	if err != nil {
+		t.Fatalf("ListFieldLandRegistriesByFieldID() error = %v", err)
+	}
+	if len(registries) != 1 {
+		t.Errorf("廃止済みの圃場の農地台帳 = %d件, want 1", len(registries))
+	}
+
+	created, err := repo.FindByID(ctx, newID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if created == nil {
+		t.Error("同じバッチの有効な圃場が作成されていない")
+	}
+
+	field := newTestFieldWithGeometry(t)
+	field.ID = retiredID
+	if err := repo.Upsert(ctx, field); !errors.Is(err, entity.ErrFieldRetired) {
+		t.Errorf("Upsert() error = %v, want %v", err, entity.ErrFieldRetired)
+	}
+}
+
 func TestFieldRepository_UpsertBatch_InvalidFieldID_Integration(t *testing.T) {
 	// 不正なフィールドIDでUpsertBatchがエラーを返すことを確認する
 	ctx := context.Background()
@@ -296,13 +550,15 @@ func TestFieldRepository_UpsertBatch_InvalidFieldID_Integration(t *testing.T) {
 		CityCode: "163210",
 		Geometry: importdto.FieldBatchGeometry{
 			Type: "Polygon",
-			Coordinates: [][][]float64{
+			Polygons: [][][][]float64{
 				{
-					{139.6917, 35.6895},
-					{139.6920, 35.6895},
-					{139.6920, 35.6898},
-					{139.6917, 35.6898},
-					{139.6917, 35.6895},
+					{
+						{139.6917, 35.6895},
+						{139.6920, 35.6895},
+						{139.6920, 35.6898},
+						{139.6917, 35.6898},
+						{139.6917, 35.6895},
+					},
 				},
 			},
 		},
@@ -327,11 +583,13 @@ func TestFieldRepository_UpsertBatch_InvalidGeometry_Integration(t *testing.T) {
 		CityCode: "163210",
 		Geometry: importdto.FieldBatchGeometry{
 			Type: "Polygon",
-			Coordinates: [][][]float64{
+			Polygons: [][][][]float64{
 				{
-					// 2点だけ - 不正なポリゴン
-					{139.6917, 35.6895},
-					{139.6920, 35.6895},
+					{
+						// 2点だけ - 不正なポリゴン
+						{139.6917, 35.6895},
+						{139.6920, 35.6895},
+					},
 				},
 			},
 		},
@@ -359,13 +617,15 @@ func TestFieldRepository_UpsertBatch_MultipleFeatures_Integration(t *testing.T)
 			CityCode: "163210",
 			Geometry: importdto.FieldBatchGeometry{
 				Type: "Polygon",
-				Coordinates: [][][]float64{
+				Polygons: [][][][]float64{
 					{
-						{139.6917, 35.6895},
-						{139.6920, 35.6895},
-						{139.6920, 35.6898},
-						{139.6917, 35.6898},
-						{139.6917, 35.6895},
+						{
+							{139.6917, 35.6895},
+							{139.6920, 35.6895},
+							{139.6920, 35.6898},
+							{139.6917, 35.6898},
+							{139.6917, 35.6895},
+						},
 					},
 				},
 			},
@@ -375,13 +635,15 @@ func TestFieldRepository_UpsertBatch_MultipleFeatures_Integration(t *testing.T)
 			CityCode: "131016",
 			Geometry: importdto.FieldBatchGeometry{
 				Type: "Polygon",
-				Coordinates: [][][]float64{
+				Polygons: [][][][]float64{
 					{
-						{139.7000, 35.7000},
-						{139.7010, 35.7000},
-						{139.7010, 35.7010},
-						{139.7000, 35.7010},
-						{139.7000, 35.7000},
+						{
+							{139.7000, 35.7000},
+							{139.7010, 35.7000},
+							{139.7010, 35.7010},
+							{139.7000, 35.7010},
+							{139.7000, 35.7000},
+						},
 					},
 				},
 			},
@@ -424,13 +686,15 @@ func TestFieldRepository_UpsertBatch_UpdateExisting_Integration(t *testing.T) {
 		CityCode: "163210",
 		Geometry: importdto.FieldBatchGeometry{
 			Type: "Polygon",
-			Coordinates: [][][]float64{
+			Polygons: [][][][]float64{
 				{
-					{139.6917, 35.6895},
-					{139.6920, 35.6895},
-					{139.6920, 35.6898},
-					{139.6917, 35.6898},
-					{139.6917, 35.6895},
+					{
+						{139.6917, 35.6895},
+						{139.6920, 35.6895},
+						{139.6920, 35.6898},
+						{139.6917, 35.6898},
+						{139.6917, 35.6895},
+					},
 				},
 			},
 		},
@@ -492,8 +756,10 @@ func TestFieldRepository_UpsertBatch_TransactionError_Integration(t *testing.T)
 		ID:       uuid.New().String(),
 		CityCode: "163210",
 		Geometry: importdto.FieldBatchGeometry{
-			Type:        "Polygon",
-			Coordinates: [][][]float64{{{139.6917, 35.6895}, {139.6920, 35.6895}, {139.6920, 35.6898}, {139.6917, 35.6898}, {139.6917, 35.6895}}},
+			Type: "Polygon",
+			Polygons: [][][][]float64{
+				{},
+			},
 		},
 	}
 
@@ -515,8 +781,10 @@ func TestFieldRepository_UpsertBatch_EmptyGeometry_Integration(t *testing.T) {
 		ID:       fieldID.String(),
 		CityCode: "163210",
 		Geometry: importdto.FieldBatchGeometry{
-			Type:        "Polygon",
-			Coordinates: [][][]float64{}, // 空のコーディネート
+			Type: "Polygon",
+			Polygons: [][][][]float64{
+				{},
+			}, // 空のコーディネート
 		},
 	}
 
@@ -540,10 +808,12 @@ func TestFieldRepository_UpsertBatch_TwoPointsGeometry_Integration(t *testing.T)
 		CityCode: "163210",
 		Geometry: importdto.FieldBatchGeometry{
 			Type: "Polygon",
-			Coordinates: [][][]float64{
+			Polygons: [][][][]float64{
 				{
-					{139.6917, 35.6895},
-					{139.6920, 35.6895},
+					{
+						{139.6917, 35.6895},
+						{139.6920, 35.6895},
+					},
 				},
 			},
 		},
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/repository"
+	"github.com/mktkhr/field-manager-api/internal/features/field/internal/geomutil"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+)
+
+// fieldMergerRepository はFieldMergerRepositoryの実装
+type fieldMergerRepository struct {
+	db     *pgxpool.Pool
+	logger *slog.Logger
+}
+
+// NewFieldMergerRepository は新しいFieldMergerRepositoryを作成する
+func NewFieldMergerRepository(db *pgxpool.Pool, logger *slog.Logger) repository.FieldMergerRepository {
+	return &fieldMergerRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Merge は合筆を1トランザクションで永続化する
+func (r *fieldMergerRepository) Merge(ctx context.Context, merger *entity.Merger) error {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("トランザクション開始に失敗: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.Error("トランザクションのロールバックに失敗。データ不整合の可能性があります",
+				slog.String("error", err.Error()))
+		}
+	}()
+
+	queries := sqlc.New(tx)
+	sourceIDs := merger.SourceIDs()
+
+	// 1. ソース圃場をロックし、同時に分筆・合筆されていないことを確認
+	locked, err := queries.LockFieldsForUpdate(ctx, sourceIDs)
+	if err != nil {
+		return fmt.Errorf("ソース圃場ロック失敗: %w", err)
+	}
+	if len(locked) != len(sourceIDs) {
+		return entity.ErrMergeSourceNotFound
+	}
+	for _, row := range locked {
+		if row.RetiredAt.Valid {
+			return fmt.Errorf("%w: %s", entity.ErrFieldRetired, row.ID)
+		}
+	}
+
+	// 2. ソース圃場を結合し、全ての圃場が辺の共有でつながっていること(隣接していること)を確認
+	// 複数区画の圃場を含む場合は結合結果も複数区画のまま合筆先圃場のジオメトリにする
+	union, err := queries.UnionFieldGeometries(ctx, sourceIDs)
+	if err != nil {
+		return fmt.Errorf("ジオメトリ結合失敗: %w", err)
+	}
+	if !union.Connected {
+		return fmt.Errorf("%w: 他の圃場と辺を共有していない圃場があります", entity.ErrMergeSourcesNotAdjacent)
+	}
+	multiPolygon, err := geomutil.DecodeMultiPolygon(union.GeometryWkb)
+	if err != nil {
+		return fmt.Errorf("結合ジオメトリのデコード失敗: %w", err)
+	}
+
+	// 3. 合筆先圃場を作成
+	result := merger.Result
+	if err := result.SetMultiPolygon(multiPolygon); err != nil {
+		return fmt.Errorf("合筆先圃場のH3インデックス計算失敗: %w", err)
+	}
+	geometryWKB, centroidWKB, err := fieldToWKB(result)
+	if err != nil {
+		return err
+	}
+	row, err := queries.CreateField(ctx, &sqlc.CreateFieldParams{
+		ID:          result.ID,
+		GeometryWkb: geometryWKB,
+		CentroidWkb: centroidWKB,
+		H3Index:     result.H3Index,
+		CityCode:    result.CityCode,
+		Name:        result.Name,
+		SoilTypeID:  uuidToNullUUID(result.SoilTypeID),
+		CreatedBy:   uuidToNullUUID(result.CreatedBy),
+		UpdatedBy:   uuidToNullUUID(result.UpdatedBy),
+	})
+	if err != nil {
+		return fmt.Errorf("合筆先圃場作成失敗: %w", err)
+	}
+	result.AreaSqm = row.AreaSqm
+
+	// 4. 合筆履歴を記録
+	mergedAt := pgtype.Timestamptz{Time: merger.MergedAt, Valid: true}
+	records := make([]*entity.FieldMerger, 0, len(sourceIDs))
+	for _, sourceID := range sourceIDs {
+		row, err := queries.CreateFieldMerger(ctx, &sqlc.CreateFieldMergerParams{
+			MergedFieldID: result.ID,
+			SourceFieldID: sourceID,
+			MergedAt:      mergedAt,
+			Reason:        merger.Reason,
+			CreatedBy:     uuidToNullUUID(merger.MergedBy),
+		})
+		if err != nil {
+			return fmt.Errorf("合筆履歴作成失敗: %w", err)
+		}
+		records = append(records, toFieldMergerEntity(row))
+	}
+
+	// 5. ソース圃場の農地台帳を合筆先圃場に引き継ぐ
+	if err := queries.MoveFieldLandRegistries(ctx, &sqlc.MoveFieldLandRegistriesParams{
+		FieldID:        result.ID,
+		SourceFieldIds: sourceIDs,
+	}); err != nil {
+		return fmt.Errorf("農地台帳引き継ぎ失敗: %w", err)
+	}
+
+	// 6. ソース圃場を廃止(系譜を保持するため削除しない)
+	for _, source := range merger.Sources {
+		if err := queries.RetireField(ctx, &sqlc.RetireFieldParams{
+			RetiredAt: mergedAt,
+			Name:      source.Name,
+			UpdatedBy: uuidToNullUUID(merger.MergedBy),
+			ID:        source.ID,
+		}); err != nil {
+			return fmt.Errorf("ソース圃場廃止失敗: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("コミット失敗: %w", err)
+	}
+
+	merger.Records = records
+	for _, source := range merger.Sources {
+		retiredAt := merger.MergedAt
+		source.RetiredAt = &retiredAt
+	}
+	return nil
+}
+
+// toFieldMergerEntity は合筆履歴のSQLCモデルをエンティティに変換する
+func toFieldMergerEntity(row *sqlc.FieldMerger) *entity.FieldMerger {
+	m := &entity.FieldMerger{
+		ID:            row.ID,
+		MergedFieldID: row.MergedFieldID,
+		SourceFieldID: row.SourceFieldID,
+		Reason:        row.Reason,
+	}
+	if row.MergedAt.Valid {
+		m.MergedAt = row.MergedAt.Time
+	}
+	if row.CreatedAt.Valid {
+		m.CreatedAt = row.CreatedAt.Time
+	}
+	if row.CreatedBy.Valid {
+		m.CreatedBy = &row.CreatedBy.UUID
+	}
+	return m
+}
@@ -0,0 +1,166 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/twpayne/go-geom"
+)
+
+// createTestMergeSource は指定範囲の矩形ジオメトリと農地台帳1件を持つ圃場を作成する
+func createTestMergeSource(t *testing.T, ctx context.Context, minLng, minLat, maxLng, maxLat float64) *entity.Field {
+	t.Helper()
+	polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{minLng, minLat}, {maxLng, minLat}, {maxLng, maxLat}, {minLng, maxLat}, {minLng, minLat}},
+	})
+	field := entity.NewField(uuid.New(), "163210")
+	if err := field.SetGeometry(polygon); err != nil {
+		t.Fatalf("SetGeometry() error = %v", err)
+	}
+	if err := NewFieldRepository(testDB, slog.Default()).Create(ctx, field); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	address := "富山県射水市" + field.ID.String()[:8]
+	if _, err := sqlc.New(testDB).CreateFieldLandRegistry(ctx, &sqlc.CreateFieldLandRegistryParams{
+		FieldID: field.ID,
+		Address: &address,
+	}); err != nil {
+		t.Fatalf("CreateFieldLandRegistry() error = %v", err)
+	}
+	return field
+}
+
+// createTestMultiPartMergeSource は指定範囲の矩形を区画とする複数区画の圃場を作成する
+// rectsは区画ごとの[最小経度, 最小緯度, 最大経度, 最大緯度]
+func createTestMultiPartMergeSource(t *testing.T, ctx context.Context, rects ...[4]float64) *entity.Field {
+	t.Helper()
+	multiPolygon := geom.NewMultiPolygon(geom.XY)
+	for _, r := range rects {
+		polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+			{{r[0], r[1]}, {r[2], r[1]}, {r[2], r[3]}, {r[0], r[3]}, {r[0], r[1]}},
+		})
+		if err := multiPolygon.Push(polygon); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+	field := entity.NewField(uuid.New(), "163210")
+	if err := field.SetMultiPolygon(multiPolygon); err != nil {
+		t.Fatalf("SetMultiPolygon() error = %v", err)
+	}
+	if err := NewFieldRepository(testDB, slog.Default()).Create(ctx, field); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return field
+}
+
+func TestFieldMergerRepository_Merge_Integration(t *testing.T) {
+	ctx := context.Background()
+	cleanupTestData(t, ctx)
+
+	sources := []*entity.Field{
+		createTestMergeSource(t, ctx, 139.6917, 35.6895, 139.69185, 35.6898),
+		createTestMergeSource(t, ctx, 139.69185, 35.6895, 139.6920, 35.6898),
+	}
+	merger, err := entity.NewMerger(sources, nil, nil)
+	if err != nil {
+		t.Fatalf("NewMerger() error = %v", err)
+	}
+
+	if err := NewFieldMergerRepository(testDB, slog.Default()).Merge(ctx, merger); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if len(merger.Records) != 2 {
+		t.Errorf("len(Records) = %d, want 2", len(merger.Records))
+	}
+	if merger.Result.Geometry == nil || merger.Result.H3Index == nil {
+		t.Fatal("合筆先圃場のジオメトリ・H3インデックスが設定されていない")
+	}
+
+	fieldRepo := NewFieldRepository(testDB, slog.Default())
+	for _, source := range sources {
+		found, err := fieldRepo.FindByID(ctx, source.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if found == nil || !found.IsRetired() {
+			t.Errorf("ソース圃場%sが廃止されていない", source.ID)
+		}
+	}
+
+	registries, err := sqlc.New(testDB).ListFieldLandRegistriesByFieldID(ctx, merger.Result.ID)
+	if err != nil {
+		t.Fatalf("ListFieldLandRegistriesByFieldID() error = %v", err)
+	}
+	if len(registries) != 2 {
+		t.Errorf("合筆先圃場の農地台帳 = %d件, want 2", len(registries))
+	}
+}
+
+func TestFieldMergerRepository_Merge_NotAdjacent_Integration(t *testing.T) {
+	ctx := context.Background()
+	cleanupTestData(t, ctx)
+
+	sources := []*entity.Field{
+		createTestMergeSource(t, ctx, 139.6917, 35.6895, 139.6918, 35.6898),
+		createTestMergeSource(t, ctx, 139.6919, 35.6895, 139.6920, 35.6898),
+	}
+	merger, err := entity.NewMerger(sources, nil, nil)
+	if err != nil {
+		t.Fatalf("NewMerger() error = %v", err)
+	}
+
+	err = NewFieldMergerRepository(testDB, slog.Default()).Merge(ctx, merger)
+	if !errors.Is(err, entity.ErrMergeSourcesNotAdjacent) {
+		t.Fatalf("Merge() error = %v, want %v", err, entity.ErrMergeSourcesNotAdjacent)
+	}
+
+	found, err := NewFieldRepository(testDB, slog.Default()).FindByID(ctx, sources[0].ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.IsRetired() {
+		t.Error("検証エラー時にソース圃場が廃止されている")
+	}
+}
+
+func TestFieldMergerRepository_Merge_MultiPartSource_Integration(t *testing.T) {
+	ctx := context.Background()
+	cleanupTestData(t, ctx)
+
+	// 2区画の圃場と、その1区画目に隣接する圃場を合筆する
+	multiPart := createTestMultiPartMergeSource(t, ctx,
+		[4]float64{139.6917, 35.6895, 139.69185, 35.6898},
+		[4]float64{139.6930, 35.6895, 139.6932, 35.6898},
+	)
+	neighbour := createTestMergeSource(t, ctx, 139.69185, 35.6895, 139.6920, 35.6898)
+	merger, err := entity.NewMerger([]*entity.Field{multiPart, neighbour}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewMerger() error = %v", err)
+	}
+
+	if err := NewFieldMergerRepository(testDB, slog.Default()).Merge(ctx, merger); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if merger.Result.Geometry == nil || merger.Result.H3Index == nil {
+		t.Fatal("合筆先圃場のジオメトリ・H3インデックスが設定されていない")
+	}
+	if got := merger.Result.Geometry.NumPolygons(); got != 2 {
+		t.Errorf("合筆先圃場の区画数 = %d, want 2", got)
+	}
+
+	found, err := NewFieldRepository(testDB, slog.Default()).FindByID(ctx, merger.Result.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found == nil || found.Geometry == nil || found.Geometry.NumPolygons() != 2 {
+		t.Error("合筆先圃場が複数区画のまま保存されていない")
+	}
+}
@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/repository"
+	"github.com/mktkhr/field-manager-api/internal/features/field/internal/geomutil"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+)
+
+// fieldOverlapRepository はFieldOverlapRepositoryの実装
+type fieldOverlapRepository struct {
+	db      *pgxpool.Pool
+	queries *sqlc.Queries
+	logger  *slog.Logger
+}
+
+// NewFieldOverlapRepository は新しいFieldOverlapRepositoryを作成する
+func NewFieldOverlapRepository(db *pgxpool.Pool, logger *slog.Logger) repository.FieldOverlapRepository {
+	return &fieldOverlapRepository{
+		db:      db,
+		queries: sqlc.New(db),
+		logger:  logger,
+	}
+}
+
+// DetectByFieldIDs は指定圃場と他の有効な圃場の重なりを検出して記録し、検出件数を返す
+// 検出結果の記録と重なりが無くなった記録の削除を1トランザクションで行う
+func (r *fieldOverlapRepository) DetectByFieldIDs(ctx context.Context, fieldIDs []uuid.UUID) (int, error) {
+	if len(fieldIDs) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("トランザクション開始に失敗: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.Error("トランザクションのロールバックに失敗。データ不整合の可能性があります",
+				slog.String("error", err.Error()))
+		}
+	}()
+
+	queries := sqlc.New(tx)
+
+	detectedIDs, err := queries.UpsertFieldOverlaps(ctx, &sqlc.UpsertFieldOverlapsParams{
+		FieldIds:   fieldIDs,
+		MinAreaSqm: entity.MinOverlapAreaSqm,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("オーバーラップ検出失敗: %w", err)
+	}
+
+	if err := queries.DeleteStaleFieldOverlaps(ctx, &sqlc.DeleteStaleFieldOverlapsParams{
+		FieldIds:    fieldIDs,
+		DetectedIds: detectedIDs,
+	}); err != nil {
+		return 0, fmt.Errorf("解消済みオーバーラップ削除失敗: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("コミット失敗: %w", err)
+	}
+	return len(detectedIDs), nil
+}
+
+// FindByID はIDでオーバーラップを取得する
+// 存在しない場合はnilを返す
+func (r *fieldOverlapRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.FieldOverlap, error) {
+	row, err := r.queries.GetFieldOverlap(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toFieldOverlapEntity(row), nil
+}
+
+// Accept は許容済みにしたオーバーラップの対応結果を永続化する
+func (r *fieldOverlapRepository) Accept(ctx context.Context, overlap *entity.FieldOverlap) error {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("トランザクション開始に失敗: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.Error("トランザクションのロールバックに失敗。データ不整合の可能性があります",
+				slog.String("error", err.Error()))
+		}
+	}()
+
+	queries := sqlc.New(tx)
+	if err := lockUnclippedOverlap(ctx, queries, overlap.ID); err != nil {
+		return err
+	}
+
+	row, err := queries.ResolveFieldOverlap(ctx, toResolveFieldOverlapParams(overlap))
+	if err != nil {
+		return fmt.Errorf("オーバーラップ対応結果更新失敗: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("コミット失敗: %w", err)
+	}
+
+	*overlap = *toFieldOverlapEntity(row)
+	return nil
+}
+
+// Clip はクリップ対象圃場から重なり部分を取り除き、対応結果とあわせて1トランザクションで永続化する
+func (r *fieldOverlapRepository) Clip(ctx context.Context, overlap *entity.FieldOverlap, field *entity.Field) error {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("トランザクション開始に失敗: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.Error("トランザクションのロールバックに失敗。データ不整合の可能性があります",
+				slog.String("error", err.Error()))
+		}
+	}()
+
+	queries := sqlc.New(tx)
+
+	// 1. オーバーラップとクリップ対象圃場をロックし、同時に対応・廃止されていないことを確認
+	if err := lockUnclippedOverlap(ctx, queries, overlap.ID); err != nil {
+		return err
+	}
+	locked, err := queries.LockFieldForUpdate(ctx, field.ID)
+	if err != nil {
+		return fmt.Errorf("クリップ対象圃場ロック失敗: %w", err)
+	}
+	if locked.RetiredAt.Valid {
+		return fmt.Errorf("%w: %s", entity.ErrFieldRetired, field.ID)
+	}
+
+	// 2. 相手圃場との重なりを取り除いた形状を計算
+	clipped, err := queries.ClipFieldGeometry(ctx, &sqlc.ClipFieldGeometryParams{
+		FieldID:      field.ID,
+		OtherFieldID: overlap.OtherFieldID(field.ID),
+	})
+	if err != nil {
+		return fmt.Errorf("クリップ形状計算失敗: %w", err)
+	}
+	if clipped.GeometryCount == 0 {
+		return fmt.Errorf("%w: %s", entity.ErrClipResultInvalid, field.ID)
+	}
+	multiPolygon, err := geomutil.DecodeMultiPolygon(clipped.GeometryWkb)
+	if err != nil {
+		return fmt.Errorf("クリップ形状のデコード失敗: %w", err)
+	}
+
+	// 3. クリップ対象圃場を更新(重心・H3インデックスを再計算)。分断された場合は複数区画の圃場になる
+	if err := field.SetMultiPolygon(multiPolygon); err != nil {
+		return fmt.Errorf("クリップ後のH3インデックス計算失敗: %w", err)
+	}
+	geometryWKB, centroidWKB, err := fieldToWKB(field)
+	if err != nil {
+		return err
+	}
+	row, err := queries.UpdateField(ctx, &sqlc.UpdateFieldParams{
+		GeometryWkb: geometryWKB,
+		CentroidWkb: centroidWKB,
+		H3Index:     field.H3Index,
+		CityCode:    field.CityCode,
+		Name:        field.Name,
+		SoilTypeID:  uuidToNullUUID(field.SoilTypeID),
+		UpdatedBy:   uuidToNullUUID(field.UpdatedBy),
+		ID:          field.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("クリップ対象圃場更新失敗: %w", err)
+	}
+
+	// 4. 対応結果を記録
+	resolved, err := queries.ResolveFieldOverlap(ctx, toResolveFieldOverlapParams(overlap))
+	if err != nil {
+		return fmt.Errorf("オーバーラップ対応結果更新失敗: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("コミット失敗: %w", err)
+	}
+
+	field.AreaSqm = row.AreaSqm
+	if row.UpdatedAt.Valid {
+		field.UpdatedAt = row.UpdatedAt.Time
+	}
+	*overlap = *toFieldOverlapEntity(resolved)
+	return nil
+}
+
+// lockUnclippedOverlap はオーバーラップを行ロックし、クリップで解消済みでないことを確認する
+func lockUnclippedOverlap(ctx context.Context, queries *sqlc.Queries, id uuid.UUID) error {
+	row, err := queries.LockFieldOverlapForUpdate(ctx, id)
+	if err != nil {
+		return fmt.Errorf("オーバーラップロック失敗: %w", err)
+	}
+	if entity.OverlapStatus(row.Status) == entity.OverlapStatusClipped {
+		return entity.ErrOverlapAlreadyClipped
+	}
+	return nil
+}
+
+// toResolveFieldOverlapParams はオーバーラップの対応結果を更新パラメータに変換する
+func toResolveFieldOverlapParams(overlap *entity.FieldOverlap) *sqlc.ResolveFieldOverlapParams {
+	return &sqlc.ResolveFieldOverlapParams{
+		Status:         string(overlap.Status),
+		ClippedFieldID: uuidToNullUUID(overlap.ClippedFieldID),
+		Note:           overlap.Note,
+		ResolvedBy:     uuidToNullUUID(overlap.ResolvedBy),
+		ID:             overlap.ID,
+	}
+}
+
+// toFieldOverlapEntity はオーバーラップ検知記録のSQLCモデルをエンティティに変換する
+func toFieldOverlapEntity(row *sqlc.FieldOverlap) *entity.FieldOverlap {
+	o := &entity.FieldOverlap{
+		ID:             row.ID,
+		FieldIDA:       row.FieldIDA,
+		FieldIDB:       row.FieldIDB,
+		OverlapAreaSqm: row.OverlapAreaSqm,
+		OverlapRatio:   row.OverlapRatio,
+		Status:         entity.OverlapStatus(row.Status),
+		Note:           row.Note,
+	}
+	if row.ClippedFieldID.Valid {
+		o.ClippedFieldID = &row.ClippedFieldID.UUID
+	}
+	if row.DetectedAt.Valid {
+		o.DetectedAt = row.DetectedAt.Time
+	}
+	if row.ResolvedAt.Valid {
+		o.ResolvedAt = &row.ResolvedAt.Time
+	}
+	if row.ResolvedBy.Valid {
+		o.ResolvedBy = &row.ResolvedBy.UUID
+	}
+	if row.CreatedAt.Valid {
+		o.CreatedAt = row.CreatedAt.Time
+	}
+	if row.UpdatedAt.Valid {
+		o.UpdatedAt = row.UpdatedAt.Time
+	}
+	return o
+}
@@ -0,0 +1,131 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/twpayne/go-geom"
+)
+
+// createTestOverlapField は指定範囲の矩形ジオメトリを持つ圃場を作成する
+func createTestOverlapField(t *testing.T, ctx context.Context, minLng, minLat, maxLng, maxLat float64) *entity.Field {
+	t.Helper()
+	polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{minLng, minLat}, {maxLng, minLat}, {maxLng, maxLat}, {minLng, maxLat}, {minLng, minLat}},
+	})
+	field := entity.NewField(uuid.New(), "163210")
+	if err := field.SetGeometry(polygon); err != nil {
+		t.Fatalf("SetGeometry() error = %v", err)
+	}
+	if err := NewFieldRepository(testDB, slog.Default()).Create(ctx, field); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return field
+}
+
+// detectSingleOverlap は圃場の重なりを検出し、1件だけ記録されたオーバーラップを返す
+func detectSingleOverlap(t *testing.T, ctx context.Context, repo *fieldOverlapRepository, field *entity.Field) *entity.FieldOverlap {
+	t.Helper()
+	detected, err := repo.DetectByFieldIDs(ctx, []uuid.UUID{field.ID})
+	if err != nil {
+		t.Fatalf("DetectByFieldIDs() error = %v", err)
+	}
+	if detected != 1 {
+		t.Fatalf("DetectByFieldIDs() = %d, want 1", detected)
+	}
+	rows, err := repo.queries.ListFieldOverlaps(ctx, &sqlc.ListFieldOverlapsParams{
+		FieldID:  uuid.NullUUID{UUID: field.ID, Valid: true},
+		RowLimit: 10,
+	})
+	if err != nil {
+		t.Fatalf("ListFieldOverlaps() error = %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("ListFieldOverlaps() = %d件, want 1", len(rows))
+	}
+	return toFieldOverlapEntity(rows[0])
+}
+
+func TestFieldOverlapRepository_DetectAndClip_Integration(t *testing.T) {
+	ctx := context.Background()
+	cleanupTestData(t, ctx)
+
+	left := createTestOverlapField(t, ctx, 139.6917, 35.6895, 139.6920, 35.6898)
+	right := createTestOverlapField(t, ctx, 139.6919, 35.6895, 139.6922, 35.6898)
+	// 離れた圃場は検出対象にならない
+	createTestOverlapField(t, ctx, 139.7000, 35.6895, 139.7003, 35.6898)
+
+	repo := NewFieldOverlapRepository(testDB, slog.Default()).(*fieldOverlapRepository)
+	overlap := detectSingleOverlap(t, ctx, repo, left)
+	if !overlap.Involves(right.ID) {
+		t.Errorf("オーバーラップの当事者に%sが含まれていない", right.ID)
+	}
+	if overlap.OverlapRatio <= 0 || overlap.OverlapRatio >= 1 {
+		t.Errorf("OverlapRatio = %v, want (0, 1)", overlap.OverlapRatio)
+	}
+
+	field, err := NewFieldRepository(testDB, slog.Default()).FindByID(ctx, right.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	areaBefore := *field.AreaSqm
+	if err := overlap.Clip(field.ID, nil, nil); err != nil {
+		t.Fatalf("Clip() error = %v", err)
+	}
+	if err := repo.Clip(ctx, overlap, field); err != nil {
+		t.Fatalf("repo.Clip() error = %v", err)
+	}
+	if overlap.Status != entity.OverlapStatusClipped || overlap.ResolvedAt == nil {
+		t.Errorf("クリップ結果が記録されていない: status=%s", overlap.Status)
+	}
+	if field.AreaSqm == nil || *field.AreaSqm >= areaBefore {
+		t.Errorf("クリップ後の面積が減っていない: before=%v after=%v", areaBefore, field.AreaSqm)
+	}
+
+	// クリップ後は重なりが無くなるため再検出しても0件になり、クリップ済みの記録は残る
+	detected, err := repo.DetectByFieldIDs(ctx, []uuid.UUID{right.ID})
+	if err != nil {
+		t.Fatalf("DetectByFieldIDs() error = %v", err)
+	}
+	if detected != 0 {
+		t.Errorf("クリップ後のDetectByFieldIDs() = %d, want 0", detected)
+	}
+	found, err := repo.FindByID(ctx, overlap.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found == nil || found.Status != entity.OverlapStatusClipped {
+		t.Error("クリップ済みの記録が削除されている")
+	}
+}
+
+func TestFieldOverlapRepository_Accept_Integration(t *testing.T) {
+	ctx := context.Background()
+	cleanupTestData(t, ctx)
+
+	left := createTestOverlapField(t, ctx, 139.6917, 35.6895, 139.6920, 35.6898)
+	createTestOverlapField(t, ctx, 139.6919, 35.6895, 139.6922, 35.6898)
+
+	repo := NewFieldOverlapRepository(testDB, slog.Default()).(*fieldOverlapRepository)
+	overlap := detectSingleOverlap(t, ctx, repo, left)
+
+	note := "登記上の重複"
+	if err := overlap.Accept(&note, nil); err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+	if err := repo.Accept(ctx, overlap); err != nil {
+		t.Fatalf("repo.Accept() error = %v", err)
+	}
+
+	// 形状が変わらない限り再検出しても許容済みのまま
+	again := detectSingleOverlap(t, ctx, repo, left)
+	if again.Status != entity.OverlapStatusAccepted {
+		t.Errorf("再検出後のStatus = %s, want %s", again.Status, entity.OverlapStatusAccepted)
+	}
+}
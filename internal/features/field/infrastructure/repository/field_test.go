@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"encoding/binary"
+	"io"
+	"log/slog"
 	"testing"
 	"time"
 
@@ -9,15 +12,13 @@ import (
 	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
 	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
 	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/ewkbhex"
 )
 
 // TestFieldRepository_ToEntity はtoEntityメソッドがsqlc.FieldをEntity.Fieldに正しく変換することをテストする
 func TestFieldRepository_ToEntity(t *testing.T) {
 	now := time.Now()
-	h3Res3 := "831f8dfffffffff"
-	h3Res5 := "851f8d3ffffffff"
-	h3Res7 := "871f8d3a7ffffff"
-	h3Res9 := "891f8d3a4bfffff"
+	h3Index := "8f1f8d3a4bc0000"
 	areaSqm := 1234.56
 	soilTypeID := uuid.New()
 	createdBy := uuid.New()
@@ -50,23 +51,17 @@ func TestFieldRepository_ToEntity(t *testing.T) {
 		{
 			name: "field with all H3 indexes",
 			row: &sqlc.Field{
-				ID:          uuid.New(),
-				CityCode:    "163210",
-				Name:        "テスト圃場",
-				H3IndexRes3: &h3Res3,
-				H3IndexRes5: &h3Res5,
-				H3IndexRes7: &h3Res7,
-				H3IndexRes9: &h3Res9,
-				CreatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
-				UpdatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+				ID:        uuid.New(),
+				CityCode:  "163210",
+				Name:      "テスト圃場",
+				H3Index:   &h3Index,
+				CreatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+				UpdatedAt: pgtype.Timestamptz{Time: now, Valid: true},
 			},
 			want: &entity.Field{
-				CityCode:    "163210",
-				Name:        "テスト圃場",
-				H3IndexRes3: &h3Res3,
-				H3IndexRes5: &h3Res5,
-				H3IndexRes7: &h3Res7,
-				H3IndexRes9: &h3Res9,
+				CityCode: "163210",
+				Name:     "テスト圃場",
+				H3Index:  &h3Index,
 			},
 		},
 		{
@@ -108,31 +103,25 @@ func TestFieldRepository_ToEntity(t *testing.T) {
 		{
 			name: "full field with all fields",
 			row: &sqlc.Field{
-				ID:          uuid.New(),
-				CityCode:    "163210",
-				Name:        "テスト圃場",
-				AreaSqm:     &areaSqm,
-				H3IndexRes3: &h3Res3,
-				H3IndexRes5: &h3Res5,
-				H3IndexRes7: &h3Res7,
-				H3IndexRes9: &h3Res9,
-				SoilTypeID:  uuid.NullUUID{UUID: soilTypeID, Valid: true},
-				CreatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
-				UpdatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
-				CreatedBy:   uuid.NullUUID{UUID: createdBy, Valid: true},
-				UpdatedBy:   uuid.NullUUID{UUID: updatedBy, Valid: true},
+				ID:         uuid.New(),
+				CityCode:   "163210",
+				Name:       "テスト圃場",
+				AreaSqm:    &areaSqm,
+				H3Index:    &h3Index,
+				SoilTypeID: uuid.NullUUID{UUID: soilTypeID, Valid: true},
+				CreatedAt:  pgtype.Timestamptz{Time: now, Valid: true},
+				UpdatedAt:  pgtype.Timestamptz{Time: now, Valid: true},
+				CreatedBy:  uuid.NullUUID{UUID: createdBy, Valid: true},
+				UpdatedBy:  uuid.NullUUID{UUID: updatedBy, Valid: true},
 			},
 			want: &entity.Field{
-				CityCode:    "163210",
-				Name:        "テスト圃場",
-				AreaSqm:     &areaSqm,
-				H3IndexRes3: &h3Res3,
-				H3IndexRes5: &h3Res5,
-				H3IndexRes7: &h3Res7,
-				H3IndexRes9: &h3Res9,
-				SoilTypeID:  &soilTypeID,
-				CreatedBy:   &createdBy,
-				UpdatedBy:   &updatedBy,
+				CityCode:   "163210",
+				Name:       "テスト圃場",
+				AreaSqm:    &areaSqm,
+				H3Index:    &h3Index,
+				SoilTypeID: &soilTypeID,
+				CreatedBy:  &createdBy,
+				UpdatedBy:  &updatedBy,
 			},
 		},
 	}
@@ -167,10 +156,7 @@ func TestFieldRepository_ToEntity(t *testing.T) {
 // TestFieldRepository_ToEntity_FieldMapping はtoEntityメソッドが全フィールドを正しくマッピングすることをテストする
 func TestFieldRepository_ToEntity_FieldMapping(t *testing.T) {
 	now := time.Now()
-	h3Res3 := "831f8dfffffffff"
-	h3Res5 := "851f8d3ffffffff"
-	h3Res7 := "871f8d3a7ffffff"
-	h3Res9 := "891f8d3a4bfffff"
+	h3Index := "8f1f8d3a4bc0000"
 	areaSqm := 1234.56
 	soilTypeID := uuid.New()
 	createdBy := uuid.New()
@@ -178,19 +164,16 @@ func TestFieldRepository_ToEntity_FieldMapping(t *testing.T) {
 	id := uuid.New()
 
 	row := &sqlc.Field{
-		ID:          id,
-		CityCode:    "163210",
-		Name:        "テスト圃場",
-		AreaSqm:     &areaSqm,
-		H3IndexRes3: &h3Res3,
-		H3IndexRes5: &h3Res5,
-		H3IndexRes7: &h3Res7,
-		H3IndexRes9: &h3Res9,
-		SoilTypeID:  uuid.NullUUID{UUID: soilTypeID, Valid: true},
-		CreatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
-		UpdatedAt:   pgtype.Timestamptz{Time: now.Add(time.Hour), Valid: true},
-		CreatedBy:   uuid.NullUUID{UUID: createdBy, Valid: true},
-		UpdatedBy:   uuid.NullUUID{UUID: updatedBy, Valid: true},
+		ID:         id,
+		CityCode:   "163210",
+		Name:       "テスト圃場",
+		AreaSqm:    &areaSqm,
+		H3Index:    &h3Index,
+		SoilTypeID: uuid.NullUUID{UUID: soilTypeID, Valid: true},
+		CreatedAt:  pgtype.Timestamptz{Time: now, Valid: true},
+		UpdatedAt:  pgtype.Timestamptz{Time: now.Add(time.Hour), Valid: true},
+		CreatedBy:  uuid.NullUUID{UUID: createdBy, Valid: true},
+		UpdatedBy:  uuid.NullUUID{UUID: updatedBy, Valid: true},
 	}
 
 	r := &fieldRepository{}
@@ -216,24 +199,9 @@ func TestFieldRepository_ToEntity_FieldMapping(t *testing.T) {
 		t.Errorf("AreaSqm = %v, want %v", result.AreaSqm, &areaSqm)
 	}
 
-	// H3IndexRes3
-	if result.H3IndexRes3 == nil || *result.H3IndexRes3 != h3Res3 {
-		t.Errorf("H3IndexRes3 = %v, want %v", result.H3IndexRes3, &h3Res3)
-	}
-
-	// H3IndexRes5
-	if result.H3IndexRes5 == nil || *result.H3IndexRes5 != h3Res5 {
-		t.Errorf("H3IndexRes5 = %v, want %v", result.H3IndexRes5, &h3Res5)
-	}
-
-	// H3IndexRes7
-	if result.H3IndexRes7 == nil || *result.H3IndexRes7 != h3Res7 {
-		t.Errorf("H3IndexRes7 = %v, want %v", result.H3IndexRes7, &h3Res7)
-	}
-
-	// H3IndexRes9
-	if result.H3IndexRes9 == nil || *result.H3IndexRes9 != h3Res9 {
-		t.Errorf("H3IndexRes9 = %v, want %v", result.H3IndexRes9, &h3Res9)
+	// H3Index
+	if result.H3Index == nil || *result.H3Index != h3Index {
+		t.Errorf("H3Index = %v, want %v", result.H3Index, &h3Index)
 	}
 
 	// SoilTypeID
@@ -267,19 +235,16 @@ func TestFieldRepository_ToEntity_NullableFields(t *testing.T) {
 	now := time.Now()
 
 	row := &sqlc.Field{
-		ID:          uuid.New(),
-		CityCode:    "163210",
-		Name:        "テスト圃場",
-		AreaSqm:     nil,
-		H3IndexRes3: nil,
-		H3IndexRes5: nil,
-		H3IndexRes7: nil,
-		H3IndexRes9: nil,
-		SoilTypeID:  uuid.NullUUID{Valid: false},
-		CreatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
-		UpdatedAt:   pgtype.Timestamptz{Time: now, Valid: true},
-		CreatedBy:   uuid.NullUUID{Valid: false},
-		UpdatedBy:   uuid.NullUUID{Valid: false},
+		ID:         uuid.New(),
+		CityCode:   "163210",
+		Name:       "テスト圃場",
+		AreaSqm:    nil,
+		H3Index:    nil,
+		SoilTypeID: uuid.NullUUID{Valid: false},
+		CreatedAt:  pgtype.Timestamptz{Time: now, Valid: true},
+		UpdatedAt:  pgtype.Timestamptz{Time: now, Valid: true},
+		CreatedBy:  uuid.NullUUID{Valid: false},
+		UpdatedBy:  uuid.NullUUID{Valid: false},
 	}
 
 	r := &fieldRepository{}
@@ -288,17 +253,8 @@ func TestFieldRepository_ToEntity_NullableFields(t *testing.T) {
 	if result.AreaSqm != nil {
 		t.Errorf("AreaSqm = %v, want nil", result.AreaSqm)
 	}
-	if result.H3IndexRes3 != nil {
-		t.Errorf("H3IndexRes3 = %v, want nil", result.H3IndexRes3)
-	}
-	if result.H3IndexRes5 != nil {
-		t.Errorf("H3IndexRes5 = %v, want nil", result.H3IndexRes5)
-	}
-	if result.H3IndexRes7 != nil {
-		t.Errorf("H3IndexRes7 = %v, want nil", result.H3IndexRes7)
-	}
-	if result.H3IndexRes9 != nil {
-		t.Errorf("H3IndexRes9 = %v, want nil", result.H3IndexRes9)
+	if result.H3Index != nil {
+		t.Errorf("H3Index = %v, want nil", result.H3Index)
 	}
 	if result.SoilTypeID != nil {
 		t.Errorf("SoilTypeID = %v, want nil", result.SoilTypeID)
@@ -311,6 +267,54 @@ func TestFieldRepository_ToEntity_NullableFields(t *testing.T) {
 	}
 }
 
+// TestFieldRepository_ToEntity_Geometry はtoEntityメソッドがジオメトリと重心をデコードすることをテストする
+func TestFieldRepository_ToEntity_Geometry(t *testing.T) {
+	polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{137.0, 36.0}, {137.1, 36.0}, {137.1, 36.1}, {137.0, 36.1}, {137.0, 36.0}},
+	}).SetSRID(4326)
+	centroid := geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{137.05, 36.05}).SetSRID(4326)
+
+	polygonHex, err := ewkbhex.Encode(polygon, binary.LittleEndian)
+	if err != nil {
+		t.Fatalf("ewkbhex.Encode() error = %v", err)
+	}
+	centroidHex, err := ewkbhex.Encode(centroid, binary.LittleEndian)
+	if err != nil {
+		t.Fatalf("ewkbhex.Encode() error = %v", err)
+	}
+
+	row := &sqlc.Field{
+		ID:       uuid.New(),
+		Geometry: polygonHex,
+		Centroid: centroidHex,
+		CityCode: "163210",
+		Name:     "テスト圃場",
+	}
+
+	r := &fieldRepository{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	result := r.toEntity(row)
+
+	if result.Geometry == nil {
+		t.Fatal("Geometry = nil, want non-nil")
+	}
+	if got := result.Geometry.FlatCoords(); len(got) != len(polygon.FlatCoords()) || got[0] != 137.0 || got[1] != 36.0 {
+		t.Errorf("Geometry.FlatCoords() = %v, want %v", got, polygon.FlatCoords())
+	}
+	if result.Centroid == nil {
+		t.Fatal("Centroid = nil, want non-nil")
+	}
+	if result.Centroid.X() != 137.05 || result.Centroid.Y() != 36.05 {
+		t.Errorf("Centroid = (%v, %v), want (137.05, 36.05)", result.Centroid.X(), result.Centroid.Y())
+	}
+
+	// 不正なジオメトリはnilとして扱う
+	row.Geometry = "invalid"
+	result = r.toEntity(row)
+	if result.Geometry != nil {
+		t.Errorf("Geometry = %v, want nil", result.Geometry)
+	}
+}
+
 // TestUuidToNullUUID はuuidToNullUUIDがnilと有効なUUIDを正しく変換することをテストする
 func TestUuidToNullUUID(t *testing.T) {
 	tests := []struct {
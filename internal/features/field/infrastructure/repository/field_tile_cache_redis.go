@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/repository"
+	"github.com/mktkhr/field-manager-api/internal/infrastructure/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// fieldTileCacheKeyPrefix は圃場タイルキャッシュのキープレフィックス
+	fieldTileCacheKeyPrefix = "tile:fields:"
+
+	// fieldTileCacheTTL は圃場タイルキャッシュのTTL
+	fieldTileCacheTTL = 1 * time.Hour
+
+	// maxInvalidationTiles は個別削除するタイル数の上限
+	// 広範囲の更新で上限を超える場合は全タイルを削除する
+	maxInvalidationTiles = 10000
+)
+
+// fieldTileCacheRedisRepository はFieldTileCacheRepositoryのRedis実装
+type fieldTileCacheRedisRepository struct {
+	client *cache.Client
+	logger *slog.Logger
+}
+
+// NewFieldTileCacheRedisRepository はFieldTileCacheRepositoryのRedis実装を作成する
+func NewFieldTileCacheRedisRepository(client *cache.Client, logger *slog.Logger) repository.FieldTileCacheRepository {
+	return &fieldTileCacheRedisRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// buildTileCacheKey はタイルのキャッシュキーを構築する
+func buildTileCacheKey(tile entity.Tile) string {
+	return fmt.Sprintf("%s%d:%d:%d", fieldTileCacheKeyPrefix, tile.Z, tile.X, tile.Y)
+}
+
+// GetTile はキャッシュからタイルを取得する
+func (r *fieldTileCacheRedisRepository) GetTile(ctx context.Context, tile entity.Tile) ([]byte, bool, error) {
+	data, err := r.client.Get(ctx, buildTileCacheKey(tile))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			// キャッシュミス
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("タイルキャッシュからの取得に失敗しました: %w", err)
+	}
+	return []byte(data), true, nil
+}
+
+// SetTile はタイルをキャッシュに保存する
+func (r *fieldTileCacheRedisRepository) SetTile(ctx context.Context, tile entity.Tile, data []byte) error {
+	if err := r.client.Set(ctx, buildTileCacheKey(tile), string(data), fieldTileCacheTTL); err != nil {
+		return fmt.Errorf("タイルキャッシュへの保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// DeleteByH3Cells は指定H3セル内の圃場を含み得るタイルをキャッシュから削除する
+func (r *fieldTileCacheRedisRepository) DeleteByH3Cells(ctx context.Context, h3Cells []string) error {
+	tiles := entity.CachedTilesForH3Cells(h3Cells)
+	if len(tiles) == 0 {
+		return nil
+	}
+	if len(tiles) > maxInvalidationTiles {
+		r.logger.Info("無効化対象のタイル数が上限を超えたため全タイルを削除します",
+			slog.Int("tile_count", len(tiles)))
+		return r.DeleteAll(ctx)
+	}
+
+	keys := make([]string, 0, len(tiles))
+	for _, tile := range tiles {
+		keys = append(keys, buildTileCacheKey(tile))
+	}
+	if err := r.client.DeleteKeys(ctx, keys...); err != nil {
+		return fmt.Errorf("タイルキャッシュの削除に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// DeleteAll は全てのタイルをキャッシュから削除する
+func (r *fieldTileCacheRedisRepository) DeleteAll(ctx context.Context) error {
+	if err := r.client.DeleteByPattern(ctx, fieldTileCacheKeyPrefix+"*"); err != nil {
+		return fmt.Errorf("タイルキャッシュの全削除に失敗しました: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/infrastructure/cache"
+	"github.com/redis/go-redis/v9"
+	"github.com/uber/h3-go/v4"
+)
+
+// setupTileCacheRepository はminiredisを使用したタイルキャッシュリポジトリを作成する
+func setupTileCacheRepository(t *testing.T) (*miniredis.Miniredis, *fieldTileCacheRedisRepository) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = redisClient.Close() })
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := NewFieldTileCacheRedisRepository(cache.NewClient(redisClient), logger).(*fieldTileCacheRedisRepository)
+	return mr, repo
+}
+
+// TestBuildTileCacheKey はbuildTileCacheKeyが正しいキーを生成することをテストする
+func TestBuildTileCacheKey(t *testing.T) {
+	got := buildTileCacheKey(entity.Tile{Z: 15, X: 29104, Y: 12902})
+	want := "tile:fields:15:29104:12902"
+	if got != want {
+		t.Errorf("buildTileCacheKey() = %q, 期待値 %q", got, want)
+	}
+}
+
+// TestFieldTileCacheRedisRepository_GetSetTile はタイルの保存と取得をテストする
+func TestFieldTileCacheRedisRepository_GetSetTile(t *testing.T) {
+	_, repo := setupTileCacheRepository(t)
+	ctx := context.Background()
+	tile := entity.Tile{Z: 14, X: 14552, Y: 6451}
+
+	_, found, err := repo.GetTile(ctx, tile)
+	if err != nil {
+		t.Fatalf("GetTile()でエラー発生 = %v", err)
+	}
+	if found {
+		t.Error("未保存のタイルはキャッシュミスになるべき")
+	}
+
+	if err := repo.SetTile(ctx, tile, []byte{0x1a, 0x02}); err != nil {
+		t.Fatalf("SetTile()でエラー発生 = %v", err)
+	}
+	data, found, err := repo.GetTile(ctx, tile)
+	if err != nil {
+		t.Fatalf("GetTile()でエラー発生 = %v", err)
+	}
+	if !found || string(data) != string([]byte{0x1a, 0x02}) {
+		t.Errorf("GetTile() = %v, %v, 期待値 [26 2], true", data, found)
+	}
+
+	// 空タイルもキャッシュヒットとして扱う
+	empty := entity.Tile{Z: 14, X: 0, Y: 0}
+	if err := repo.SetTile(ctx, empty, []byte{}); err != nil {
+		t.Fatalf("SetTile()でエラー発生 = %v", err)
+	}
+	if _, found, _ := repo.GetTile(ctx, empty); !found {
+		t.Error("空タイルはキャッシュヒットになるべき")
+	}
+}
+
+// TestFieldTileCacheRedisRepository_DeleteByH3Cells は変更セル周辺のタイルのみ削除されることをテストする
+func TestFieldTileCacheRedisRepository_DeleteByH3Cells(t *testing.T) {
+	mr, repo := setupTileCacheRepository(t)
+	ctx := context.Background()
+
+	cell, err := h3.LatLngToCell(h3.NewLatLng(35.6812, 139.7671), 9)
+	if err != nil {
+		t.Fatalf("LatLngToCellでエラー発生: %v", err)
+	}
+	affected := entity.TilesInBounds(15, 139.7671, 35.6812, 139.7671, 35.6812)[0]
+	// 遠方(富山)のタイル
+	unaffected := entity.TilesInBounds(15, 137.2113, 36.6953, 137.2113, 36.6953)[0]
+
+	for _, tile := range []entity.Tile{affected, unaffected} {
+		if err := repo.SetTile(ctx, tile, []byte("data")); err != nil {
+			t.Fatalf("SetTile()でエラー発生 = %v", err)
+		}
+	}
+
+	if err := repo.DeleteByH3Cells(ctx, []string{cell.String()}); err != nil {
+		t.Fatalf("DeleteByH3Cells()でエラー発生 = %v", err)
+	}
+
+	if mr.Exists(buildTileCacheKey(affected)) {
+		t.Error("変更セルを含むタイルは削除されるべき")
+	}
+	if !mr.Exists(buildTileCacheKey(unaffected)) {
+		t.Error("変更セルと無関係のタイルは削除されるべきでない")
+	}
+}
+
+// TestFieldTileCacheRedisRepository_DeleteAll はタイルキャッシュのみが全削除されることをテストする
+func TestFieldTileCacheRedisRepository_DeleteAll(t *testing.T) {
+	mr, repo := setupTileCacheRepository(t)
+	ctx := context.Background()
+
+	for x := range 3 {
+		if err := repo.SetTile(ctx, entity.Tile{Z: 14, X: x, Y: 0}, []byte("data")); err != nil {
+			t.Fatalf("SetTile()でエラー発生 = %v", err)
+		}
+	}
+	if err := mr.Set("cluster:results:res9", "[]"); err != nil {
+		t.Fatalf("miniredisへの保存に失敗: %v", err)
+	}
+
+	if err := repo.DeleteAll(ctx); err != nil {
+		t.Fatalf("DeleteAll()でエラー発生 = %v", err)
+	}
+
+	if keys := mr.Keys(); len(keys) != 1 || keys[0] != "cluster:results:res9" {
+		t.Errorf("残存キー = %v, 期待値 [cluster:results:res9]", keys)
+	}
+}
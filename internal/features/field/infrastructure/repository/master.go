@@ -128,23 +128,7 @@ func (r *fieldLandRegistryRepository) FindByFieldID(ctx context.Context, fieldID
 
 // Create は農地台帳を作成する
 func (r *fieldLandRegistryRepository) Create(ctx context.Context, registry *entity.FieldLandRegistry) error {
-	var descriptiveStudyData pgtype.Date
-	if registry.DescriptiveStudyData != nil {
-		descriptiveStudyData = pgtype.Date{
-			Time:  *registry.DescriptiveStudyData,
-			Valid: true,
-		}
-	}
-
-	_, err := r.queries.CreateFieldLandRegistry(ctx, &sqlc.CreateFieldLandRegistryParams{
-		FieldID:              registry.FieldID,
-		FarmerNumber:         registry.FarmerNumber,
-		Address:              registry.Address,
-		AreaSqm:              registry.AreaSqm,
-		LandCategoryCode:     registry.LandCategoryCode,
-		IdleLandStatusCode:   registry.IdleLandStatusCode,
-		DescriptiveStudyData: descriptiveStudyData,
-	})
+	_, err := r.queries.CreateFieldLandRegistry(ctx, toCreateLandRegistryParams(registry))
 	return err
 }
 
@@ -170,6 +154,11 @@ func (r *fieldLandRegistryRepository) CreateBatch(ctx context.Context, registrie
 
 // toEntity はSQLCモデルをエンティティに変換する
 func (r *fieldLandRegistryRepository) toEntity(row *sqlc.FieldLandRegistry) *entity.FieldLandRegistry {
+	return toLandRegistryEntity(row)
+}
+
+// toLandRegistryEntity は農地台帳のSQLCモデルをエンティティに変換する
+func toLandRegistryEntity(row *sqlc.FieldLandRegistry) *entity.FieldLandRegistry {
 	if row == nil {
 		return nil
 	}
@@ -197,3 +186,24 @@ func (r *fieldLandRegistryRepository) toEntity(row *sqlc.FieldLandRegistry) *ent
 
 	return registry
 }
+
+// toCreateLandRegistryParams は農地台帳エンティティを作成用パラメータに変換する
+func toCreateLandRegistryParams(registry *entity.FieldLandRegistry) *sqlc.CreateFieldLandRegistryParams {
+	var descriptiveStudyData pgtype.Date
+	if registry.DescriptiveStudyData != nil {
+		descriptiveStudyData = pgtype.Date{
+			Time:  *registry.DescriptiveStudyData,
+			Valid: true,
+		}
+	}
+
+	return &sqlc.CreateFieldLandRegistryParams{
+		FieldID:              registry.FieldID,
+		FarmerNumber:         registry.FarmerNumber,
+		Address:              registry.Address,
+		AreaSqm:              registry.AreaSqm,
+		LandCategoryCode:     registry.LandCategoryCode,
+		IdleLandStatusCode:   registry.IdleLandStatusCode,
+		DescriptiveStudyData: descriptiveStudyData,
+	}
+}
@@ -0,0 +1,93 @@
+// Package geomutil はPostGISジオメトリの変換ユーティリティを提供する
+// このパッケージはfield機能内に閉じており、他の機能からは使用しない
+package geomutil
+
+import (
+	"fmt"
+
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/ewkb"
+	"github.com/twpayne/go-geom/encoding/ewkbhex"
+)
+
+// Decode はPostGISから取得したジオメトリ値をgeom.Tに変換する
+// テキスト形式(16進EWKB文字列)とバイナリ形式(EWKBバイト列)の両方に対応する
+func Decode(v interface{}) (geom.T, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case geom.T:
+		return val, nil
+	case string:
+		if val == "" {
+			return nil, nil
+		}
+		g, err := ewkbhex.Decode(val)
+		if err != nil {
+			return nil, fmt.Errorf("16進EWKBのデコードに失敗しました: %w", err)
+		}
+		return g, nil
+	case []byte:
+		if len(val) == 0 {
+			return nil, nil
+		}
+		g, err := ewkb.Unmarshal(val)
+		if err != nil {
+			// テキスト形式がバイト列で渡された場合を考慮
+			if hg, hexErr := ewkbhex.Decode(string(val)); hexErr == nil {
+				return hg, nil
+			}
+			return nil, fmt.Errorf("EWKBのデコードに失敗しました: %w", err)
+		}
+		return g, nil
+	default:
+		return nil, fmt.Errorf("未対応のジオメトリ型です: %T", v)
+	}
+}
+
+// DecodePolygon はジオメトリ値をPolygonに変換する
+func DecodePolygon(v interface{}) (*geom.Polygon, error) {
+	g, err := Decode(v)
+	if err != nil || g == nil {
+		return nil, err
+	}
+	polygon, ok := g.(*geom.Polygon)
+	if !ok {
+		return nil, fmt.Errorf("ジオメトリがPolygonではありません: %T", g)
+	}
+	return polygon, nil
+}
+
+// DecodePoint はジオメトリ値をPointに変換する
+func DecodePoint(v interface{}) (*geom.Point, error) {
+	g, err := Decode(v)
+	if err != nil || g == nil {
+		return nil, err
+	}
+	point, ok := g.(*geom.Point)
+	if !ok {
+		return nil, fmt.Errorf("ジオメトリがPointではありません: %T", g)
+	}
+	return point, nil
+}
+
+// DecodeMultiPolygon はジオメトリ値をMultiPolygonに変換する
+// Polygonの場合は1区画のMultiPolygonとして返す
+func DecodeMultiPolygon(v interface{}) (*geom.MultiPolygon, error) {
+	g, err := Decode(v)
+	if err != nil || g == nil {
+		return nil, err
+	}
+	switch val := g.(type) {
+	case *geom.MultiPolygon:
+		return val, nil
+	case *geom.Polygon:
+		multiPolygon := geom.NewMultiPolygon(val.Layout()).SetSRID(val.SRID())
+		if err := multiPolygon.Push(val); err != nil {
+			return nil, fmt.Errorf("PolygonからMultiPolygonへの変換に失敗しました: %w", err)
+		}
+		return multiPolygon, nil
+	default:
+		return nil, fmt.Errorf("ジオメトリがMultiPolygonではありません: %T", g)
+	}
+}
@@ -0,0 +1,102 @@
+package geomutil
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/ewkb"
+	"github.com/twpayne/go-geom/encoding/ewkbhex"
+)
+
+func testPolygon() *geom.Polygon {
+	return geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{137.0, 36.0}, {137.1, 36.0}, {137.1, 36.1}, {137.0, 36.1}, {137.0, 36.0}},
+	}).SetSRID(4326)
+}
+
+func TestDecode(t *testing.T) {
+	polygon := testPolygon()
+	hexStr, err := ewkbhex.Encode(polygon, binary.LittleEndian)
+	require.NoError(t, err, "16進EWKBのエンコードに失敗")
+	bin, err := ewkb.Marshal(polygon, binary.LittleEndian)
+	require.NoError(t, err, "EWKBのエンコードに失敗")
+
+	tests := []struct {
+		name    string
+		input   interface{}
+		wantNil bool
+		wantErr bool
+	}{
+		{name: "nil", input: nil, wantNil: true},
+		{name: "空文字列", input: "", wantNil: true},
+		{name: "16進EWKB文字列", input: hexStr},
+		{name: "EWKBバイト列", input: bin},
+		{name: "16進EWKBのバイト列", input: []byte(hexStr)},
+		{name: "geom.T", input: polygon},
+		{name: "不正な文字列", input: "zzzz", wantErr: true},
+		{name: "未対応の型", input: 123, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Decode(tt.input)
+			if tt.wantErr {
+				require.Error(t, err, "エラーを期待")
+				return
+			}
+			require.NoError(t, err, "Decodeでエラーが発生")
+			if tt.wantNil {
+				require.Nil(t, g, "nilを期待")
+				return
+			}
+			require.Equal(t, polygon.FlatCoords(), g.FlatCoords(), "座標が一致しない")
+			require.Equal(t, 4326, g.SRID(), "SRIDが一致しない")
+		})
+	}
+}
+
+func TestDecodePolygon_TypeMismatch(t *testing.T) {
+	point := geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{137.0, 36.0})
+	hexStr, err := ewkbhex.Encode(point, binary.LittleEndian)
+	require.NoError(t, err, "16進EWKBのエンコードに失敗")
+
+	_, err = DecodePolygon(hexStr)
+	require.Error(t, err, "Polygon以外はエラーになるべき")
+
+	p, err := DecodePoint(hexStr)
+	require.NoError(t, err, "DecodePointでエラーが発生")
+	require.Equal(t, []float64{137.0, 36.0}, p.FlatCoords(), "座標が一致しない")
+}
+
+func TestDecodeMultiPolygon(t *testing.T) {
+	polygon := testPolygon()
+	polygonHex, err := ewkbhex.Encode(polygon, binary.LittleEndian)
+	require.NoError(t, err, "16進EWKBのエンコードに失敗")
+
+	multiPolygon := geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+		polygon.Coords(),
+		{{{137.2, 36.0}, {137.3, 36.0}, {137.3, 36.1}, {137.2, 36.1}, {137.2, 36.0}}},
+	}).SetSRID(4326)
+	multiHex, err := ewkbhex.Encode(multiPolygon, binary.LittleEndian)
+	require.NoError(t, err, "16進EWKBのエンコードに失敗")
+
+	got, err := DecodeMultiPolygon(polygonHex)
+	require.NoError(t, err, "Polygonのデコードでエラーが発生")
+	require.Equal(t, 1, got.NumPolygons(), "Polygonは1区画のMultiPolygonになるべき")
+	require.Equal(t, polygon.FlatCoords(), got.FlatCoords(), "座標が一致しない")
+	require.Equal(t, 4326, got.SRID(), "SRIDが一致しない")
+
+	got, err = DecodeMultiPolygon(multiHex)
+	require.NoError(t, err, "MultiPolygonのデコードでエラーが発生")
+	require.Equal(t, 2, got.NumPolygons(), "区画数が一致しない")
+
+	point := geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{137.0, 36.0})
+	_, err = DecodeMultiPolygon(point)
+	require.Error(t, err, "Polygon・MultiPolygon以外はエラーになるべき")
+
+	got, err = DecodeMultiPolygon(nil)
+	require.NoError(t, err, "nilでエラーが発生")
+	require.Nil(t, got, "nilを期待")
+}
@@ -0,0 +1,522 @@
+// Package presentation は圃場機能のHTTPハンドラーを提供する
+package presentation
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/twpayne/go-geom"
+)
+
+const (
+	// sqmPerHa は1ヘクタールあたりの平方メートル
+	sqmPerHa = 10000.0
+)
+
+// FieldHandler は圃場APIのハンドラー
+type FieldHandler struct {
+	listFieldsUC  *usecase.ListFieldsUseCase
+	getFieldUC    *usecase.GetFieldUseCase
+	createFieldUC *usecase.CreateFieldUseCase
+	updateFieldUC *usecase.UpdateFieldUseCase
+	deleteFieldUC *usecase.DeleteFieldUseCase
+	divideFieldUC *usecase.DivideFieldUseCase
+	mergeFieldsUC *usecase.MergeFieldsUseCase
+	logger        *slog.Logger
+}
+
+// NewFieldHandler はFieldHandlerを作成する
+func NewFieldHandler(
+	listFieldsUC *usecase.ListFieldsUseCase,
+	getFieldUC *usecase.GetFieldUseCase,
+	createFieldUC *usecase.CreateFieldUseCase,
+	updateFieldUC *usecase.UpdateFieldUseCase,
+	deleteFieldUC *usecase.DeleteFieldUseCase,
+	divideFieldUC *usecase.DivideFieldUseCase,
+	mergeFieldsUC *usecase.MergeFieldsUseCase,
+	logger *slog.Logger,
+) *FieldHandler {
+	return &FieldHandler{
+		listFieldsUC:  listFieldsUC,
+		getFieldUC:    getFieldUC,
+		createFieldUC: createFieldUC,
+		updateFieldUC: updateFieldUC,
+		deleteFieldUC: deleteFieldUC,
+		divideFieldUC: divideFieldUC,
+		mergeFieldsUC: mergeFieldsUC,
+		logger:        logger,
+	}
+}
+
+// ListFields は圃場一覧を取得する
+func (h *FieldHandler) ListFields(ctx context.Context, request openapi.ListFieldsRequestObject) (openapi.ListFieldsResponseObject, error) {
+	params := request.Params
+
+	output, err := h.listFieldsUC.Execute(ctx, usecase.ListFieldsInput{
+		Limit:              params.Limit,
+		Offset:             params.Offset,
+		CityCode:           params.CityCode,
+		SoilTypeSmallCode:  params.SoilType,
+		LandCategoryCode:   params.LandCategory,
+		IdleLandStatusCode: params.IdleStatus,
+		MinAreaSqm:         params.MinAreaSqm,
+		MaxAreaSqm:         params.MaxAreaSqm,
+		SWLat:              params.SwLat,
+		SWLng:              params.SwLng,
+		NELat:              params.NeLat,
+		NELng:              params.NeLng,
+		Name:               params.Q,
+	})
+	if err != nil {
+		if isBadRequest(err) {
+			return openapi.ListFields400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("圃場一覧の取得に失敗しました",
+			slog.String("error", err.Error()))
+		return openapi.ListFields500JSONResponse{
+			Code:    "internal_error",
+			Message: "圃場一覧の取得に失敗しました",
+		}, nil
+	}
+
+	// レスポンス変換
+	fields := make([]openapi.Field, 0, len(output.Fields))
+	for _, field := range output.Fields {
+		fields = append(fields, toFieldResponse(field))
+	}
+
+	return openapi.ListFields200JSONResponse{
+		Fields: fields,
+		Total:  int(output.Total),
+	}, nil
+}
+
+// GetField は圃場詳細をGeoJSON Featureとして取得する
+func (h *FieldHandler) GetField(ctx context.Context, request openapi.GetFieldRequestObject) (openapi.GetFieldResponseObject, error) {
+	detail, err := h.getFieldUC.Execute(ctx, request.FieldId)
+	if err != nil {
+		if apperror.IsNotFoundError(err) {
+			return openapi.GetField404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("圃場詳細の取得に失敗しました",
+			slog.String("field_id", request.FieldId.String()),
+			slog.String("error", err.Error()))
+		return openapi.GetField500JSONResponse{
+			Code:    "internal_error",
+			Message: "圃場詳細の取得に失敗しました",
+		}, nil
+	}
+
+	return openapi.GetField200JSONResponse(toFieldFeature(detail)), nil
+}
+
+// CreateField はGeoJSON Polygonから圃場を作成する
+func (h *FieldHandler) CreateField(ctx context.Context, request openapi.CreateFieldRequestObject) (openapi.CreateFieldResponseObject, error) {
+	if request.Body == nil {
+		return openapi.CreateField400JSONResponse{
+			Code:    "invalid_parameter",
+			Message: "リクエストボディは必須です",
+		}, nil
+	}
+	if request.Body.Geometry.Type != openapi.Polygon {
+		return openapi.CreateField400JSONResponse{
+			Code:    "invalid_parameter",
+			Message: "geometry.typeはPolygonである必要があります",
+		}, nil
+	}
+
+	detail, err := h.createFieldUC.Execute(ctx, usecase.CreateFieldInput{
+		CityCode:    request.Body.CityCode,
+		Name:        request.Body.Name,
+		Coordinates: request.Body.Geometry.Coordinates,
+		UserID:      request.Params.XUserID,
+	})
+	if err != nil {
+		if isBadRequest(err) {
+			return openapi.CreateField400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("圃場の作成に失敗しました",
+			slog.String("error", err.Error()))
+		return openapi.CreateField500JSONResponse{
+			Code:    "internal_error",
+			Message: "圃場の作成に失敗しました",
+		}, nil
+	}
+
+	return openapi.CreateField201JSONResponse(toFieldFeature(detail)), nil
+}
+
+// UpdateField は圃場を部分更新する
+func (h *FieldHandler) UpdateField(ctx context.Context, request openapi.UpdateFieldRequestObject) (openapi.UpdateFieldResponseObject, error) {
+	if request.Body == nil {
+		return openapi.UpdateField400JSONResponse{
+			Code:    "invalid_parameter",
+			Message: "リクエストボディは必須です",
+		}, nil
+	}
+
+	input := usecase.UpdateFieldInput{
+		ID:       request.FieldId,
+		CityCode: request.Body.CityCode,
+		Name:     request.Body.Name,
+		UserID:   request.Params.XUserID,
+	}
+	if g := request.Body.Geometry; g != nil {
+		if g.Type != openapi.Polygon {
+			return openapi.UpdateField400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: "geometry.typeはPolygonである必要があります",
+			}, nil
+		}
+		input.Coordinates = g.Coordinates
+	}
+
+	detail, err := h.updateFieldUC.Execute(ctx, input)
+	if err != nil {
+		if isBadRequest(err) {
+			return openapi.UpdateField400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: err.Error(),
+			}, nil
+		}
+		if apperror.IsNotFoundError(err) {
+			return openapi.UpdateField404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		if isConflict(err) {
+			return openapi.UpdateField409JSONResponse{
+				Code:    "conflict",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("圃場の更新に失敗しました",
+			slog.String("field_id", request.FieldId.String()),
+			slog.String("error", err.Error()))
+		return openapi.UpdateField500JSONResponse{
+			Code:    "internal_error",
+			Message: "圃場の更新に失敗しました",
+		}, nil
+	}
+
+	return openapi.UpdateField200JSONResponse(toFieldFeature(detail)), nil
+}
+
+// DeleteField は圃場を削除する
+func (h *FieldHandler) DeleteField(ctx context.Context, request openapi.DeleteFieldRequestObject) (openapi.DeleteFieldResponseObject, error) {
+	if err := h.deleteFieldUC.Execute(ctx, request.FieldId); err != nil {
+		if apperror.IsNotFoundError(err) {
+			return openapi.DeleteField404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		if isConflict(err) {
+			return openapi.DeleteField409JSONResponse{
+				Code:    "conflict",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("圃場の削除に失敗しました",
+			slog.String("field_id", request.FieldId.String()),
+			slog.String("error", err.Error()))
+		return openapi.DeleteField500JSONResponse{
+			Code:    "internal_error",
+			Message: "圃場の削除に失敗しました",
+		}, nil
+	}
+
+	return openapi.DeleteField204Response{}, nil
+}
+
+// DivideField は圃場を複数の子圃場に分筆する
+func (h *FieldHandler) DivideField(ctx context.Context, request openapi.DivideFieldRequestObject) (openapi.DivideFieldResponseObject, error) {
+	if request.Body == nil {
+		return openapi.DivideField400JSONResponse{
+			Code:    "invalid_parameter",
+			Message: "リクエストボディは必須です",
+		}, nil
+	}
+
+	input := usecase.DivideFieldInput{
+		ParentID: request.FieldId,
+		Children: make([]usecase.DivideFieldChildInput, 0, len(request.Body.Children)),
+		Reason:   request.Body.Reason,
+		UserID:   request.Params.XUserID,
+	}
+	for _, child := range request.Body.Children {
+		if child.Geometry.Type != openapi.Polygon {
+			return openapi.DivideField400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: "geometry.typeはPolygonである必要があります",
+			}, nil
+		}
+		c := usecase.DivideFieldChildInput{Coordinates: child.Geometry.Coordinates}
+		if child.LandRegistryIds != nil {
+			c.LandRegistryIDs = *child.LandRegistryIds
+		}
+		input.Children = append(input.Children, c)
+	}
+
+	output, err := h.divideFieldUC.Execute(ctx, input)
+	if err != nil {
+		if isBadRequest(err) {
+			return openapi.DivideField400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: err.Error(),
+			}, nil
+		}
+		if apperror.IsNotFoundError(err) {
+			return openapi.DivideField404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		if isConflict(err) {
+			return openapi.DivideField409JSONResponse{
+				Code:    "conflict",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("圃場の分筆に失敗しました",
+			slog.String("field_id", request.FieldId.String()),
+			slog.String("error", err.Error()))
+		return openapi.DivideField500JSONResponse{
+			Code:    "internal_error",
+			Message: "圃場の分筆に失敗しました",
+		}, nil
+	}
+
+	children := make([]openapi.FieldFeature, 0, len(output.Children))
+	for _, detail := range output.Children {
+		children = append(children, toFieldFeature(detail))
+	}
+
+	return openapi.DivideField201JSONResponse{
+		ParentFieldId: output.ParentID,
+		DividedAt:     output.DividedAt,
+		Reason:        output.Reason,
+		Children:      children,
+	}, nil
+}
+
+// MergeFields は隣接する複数の圃場を1つの圃場に合筆する
+func (h *FieldHandler) MergeFields(ctx context.Context, request openapi.MergeFieldsRequestObject) (openapi.MergeFieldsResponseObject, error) {
+	if request.Body == nil {
+		return openapi.MergeFields400JSONResponse{
+			Code:    "invalid_parameter",
+			Message: "リクエストボディは必須です",
+		}, nil
+	}
+
+	output, err := h.mergeFieldsUC.Execute(ctx, usecase.MergeFieldsInput{
+		SourceIDs: request.Body.SourceFieldIds,
+		Reason:    request.Body.Reason,
+		UserID:    request.Params.XUserID,
+	})
+	if err != nil {
+		if isBadRequest(err) {
+			return openapi.MergeFields400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: err.Error(),
+			}, nil
+		}
+		if apperror.IsNotFoundError(err) {
+			return openapi.MergeFields404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		if isConflict(err) {
+			return openapi.MergeFields409JSONResponse{
+				Code:    "conflict",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("圃場の合筆に失敗しました",
+			slog.Int("sources", len(request.Body.SourceFieldIds)),
+			slog.String("error", err.Error()))
+		return openapi.MergeFields500JSONResponse{
+			Code:    "internal_error",
+			Message: "圃場の合筆に失敗しました",
+		}, nil
+	}
+
+	return openapi.MergeFields201JSONResponse{
+		SourceFieldIds: output.SourceIDs,
+		MergedAt:       output.MergedAt,
+		Reason:         output.Reason,
+		Field:          toFieldFeature(output.Field),
+	}, nil
+}
+
+// toFieldResponse は圃場エンティティをレスポンスに変換する
+func toFieldResponse(field *entity.Field) openapi.Field {
+	res := openapi.Field{
+		Id:        field.ID,
+		Name:      field.Name,
+		CityCode:  field.CityCode,
+		CreatedAt: field.CreatedAt,
+		UpdatedAt: field.UpdatedAt,
+	}
+	if field.AreaSqm != nil {
+		areaHa := *field.AreaSqm / sqmPerHa
+		res.AreaHa = &areaHa
+	}
+	return res
+}
+
+// toFieldFeature は圃場詳細をGeoJSON Featureに変換する
+func toFieldFeature(detail *query.FieldDetail) openapi.FieldFeature {
+	field := detail.Field
+
+	props := openapi.FieldProperties{
+		Name:     field.Name,
+		CityCode: field.CityCode,
+		AreaSqm:  field.AreaSqm,
+		H3Indexes: openapi.FieldH3Indexes{
+			Res3: field.H3IndexAt(3),
+			Res5: field.H3IndexAt(5),
+			Res7: field.H3IndexAt(7),
+			Res9: field.H3IndexAt(9),
+		},
+		LandRegistries: make([]openapi.LandRegistry, 0, len(detail.LandRegistries)),
+		CreatedAt:      field.CreatedAt,
+		UpdatedAt:      field.UpdatedAt,
+		RetiredAt:      field.RetiredAt,
+	}
+	if field.AreaSqm != nil {
+		areaHa := *field.AreaSqm / sqmPerHa
+		props.AreaHa = &areaHa
+	}
+	if field.Centroid != nil && !field.Centroid.Empty() {
+		props.Centroid = &openapi.GeoJSONPoint{
+			Type:        openapi.Point,
+			Coordinates: []float64{field.Centroid.X(), field.Centroid.Y()},
+		}
+	}
+	if st := detail.SoilType; st != nil {
+		props.SoilType = &openapi.SoilType{
+			Id:          st.ID,
+			LargeCode:   st.LargeCode,
+			MiddleCode:  st.MiddleCode,
+			SmallCode:   st.SmallCode,
+			SmallName:   st.SmallName,
+			Description: st.Description,
+		}
+	}
+	for _, lr := range detail.LandRegistries {
+		props.LandRegistries = append(props.LandRegistries, toLandRegistryResponse(lr))
+	}
+
+	return openapi.FieldFeature{
+		Type:       openapi.FieldFeatureTypeFeature,
+		Id:         field.ID,
+		Geometry:   toGeometryResponse(field.Geometry),
+		Properties: props,
+	}
+}
+
+// toLandRegistryResponse は農地台帳をレスポンスに変換する
+func toLandRegistryResponse(lr *query.LandRegistryDetail) openapi.LandRegistry {
+	r := lr.Registry
+	res := openapi.LandRegistry{
+		Id:           r.ID,
+		FarmerNumber: r.FarmerNumber,
+		Address:      r.Address,
+	}
+	if r.AreaSqm != nil {
+		area := int(*r.AreaSqm)
+		res.AreaSqm = &area
+	}
+	if r.DescriptiveStudyData != nil {
+		res.DescriptiveStudyDate = &openapi_types.Date{Time: *r.DescriptiveStudyData}
+	}
+	if lr.LandCategory != nil {
+		res.LandCategory = &openapi.CodeName{Code: lr.LandCategory.Code, Name: lr.LandCategory.Name}
+	}
+	if lr.IdleLandStatus != nil {
+		res.IdleLandStatus = &openapi.CodeName{Code: lr.IdleLandStatus.Code, Name: lr.IdleLandStatus.Name}
+	}
+	return res
+}
+
+// toGeometryResponse は圃場ジオメトリをGeoJSONに変換する
+// 単一区画の場合はPolygon、複数区画の場合はMultiPolygonとして出力する
+// 座標は登録時に検証済みで有限の数値のみのため、union型への変換は失敗しない
+func toGeometryResponse(multiPolygon *geom.MultiPolygon) openapi.FieldFeature_Geometry {
+	var geometry openapi.FieldFeature_Geometry
+	if multiPolygon != nil && multiPolygon.NumPolygons() > 1 {
+		coordinates := make([][][][]float64, 0, multiPolygon.NumPolygons())
+		for i := 0; i < multiPolygon.NumPolygons(); i++ {
+			coordinates = append(coordinates, polygonCoordinates(multiPolygon.Polygon(i)))
+		}
+		_ = geometry.FromGeoJSONMultiPolygon(openapi.GeoJSONMultiPolygon{
+			Type:        openapi.MultiPolygon,
+			Coordinates: coordinates,
+		})
+		return geometry
+	}
+
+	var polygon *geom.Polygon
+	if multiPolygon != nil && multiPolygon.NumPolygons() == 1 {
+		polygon = multiPolygon.Polygon(0)
+	}
+	_ = geometry.FromGeoJSONPolygon(openapi.GeoJSONPolygon{
+		Type:        openapi.Polygon,
+		Coordinates: polygonCoordinates(polygon),
+	})
+	return geometry
+}
+
+// polygonCoordinates はポリゴンをGeoJSONの座標配列([経度, 緯度])に変換する
+func polygonCoordinates(polygon *geom.Polygon) [][][]float64 {
+	if polygon == nil {
+		return [][][]float64{}
+	}
+	rings := make([][][]float64, 0, polygon.NumLinearRings())
+	for _, ring := range polygon.Coords() {
+		coords := make([][]float64, 0, len(ring))
+		for _, c := range ring {
+			coords = append(coords, []float64{c.X(), c.Y()})
+		}
+		rings = append(rings, coords)
+	}
+	return rings
+}
+
+// isBadRequest はエラーがリクエスト不正によるものかを判定する
+func isBadRequest(err error) bool {
+	var appErr apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.HTTPStatus() == http.StatusBadRequest
+	}
+	return false
+}
+
+// isConflict はエラーがリソースの状態との競合によるものかを判定する
+func isConflict(err error) bool {
+	var appErr apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.HTTPStatus() == http.StatusConflict
+	}
+	return false
+}
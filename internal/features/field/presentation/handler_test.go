@@ -0,0 +1,612 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+// mockFieldQuery はFieldQueryのモック実装
+type mockFieldQuery struct {
+	fields    []*entity.Field
+	total     int64
+	listErr   error
+	detail    *query.FieldDetail
+	detailErr error
+}
+
+func (m *mockFieldQuery) List(_ context.Context, _ query.FieldListFilter, _, _ int32) ([]*entity.Field, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.fields, nil
+}
+
+func (m *mockFieldQuery) Count(_ context.Context, _ query.FieldListFilter) (int64, error) {
+	return m.total, nil
+}
+
+func (m *mockFieldQuery) FindDetailByID(_ context.Context, _ uuid.UUID) (*query.FieldDetail, error) {
+	if m.detailErr != nil {
+		return nil, m.detailErr
+	}
+	return m.detail, nil
+}
+
+// mockFieldRepository はFieldRepositoryのモック実装
+type mockFieldRepository struct {
+	field  *entity.Field
+	fields map[uuid.UUID]*entity.Field // IDごとに返す圃場(該当がなければfieldを返す)
+}
+
+func (m *mockFieldRepository) FindByID(_ context.Context, id uuid.UUID) (*entity.Field, error) {
+	if f, ok := m.fields[id]; ok {
+		return f, nil
+	}
+	return m.field, nil
+}
+
+func (m *mockFieldRepository) Create(_ context.Context, _ *entity.Field) error {
+	return nil
+}
+
+func (m *mockFieldRepository) Update(_ context.Context, _ *entity.Field) error {
+	return nil
+}
+
+func (m *mockFieldRepository) Delete(_ context.Context, _ uuid.UUID) error {
+	return nil
+}
+
+func (m *mockFieldRepository) Upsert(_ context.Context, _ *entity.Field) error {
+	return nil
+}
+
+// mockFieldDivisionRepository はFieldDivisionRepositoryのモック実装
+type mockFieldDivisionRepository struct {
+	err error
+}
+
+func (m *mockFieldDivisionRepository) Divide(_ context.Context, _ *entity.Division) error {
+	return m.err
+}
+
+// mockFieldMergerRepository はFieldMergerRepositoryのモック実装
+type mockFieldMergerRepository struct {
+	err error
+}
+
+func (m *mockFieldMergerRepository) Merge(_ context.Context, _ *entity.Merger) error {
+	return m.err
+}
+
+// getTestLogger はテスト用のロガーを返す
+func getTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+// newTestFieldHandler はモックを注入したFieldHandlerを作成する
+func newTestFieldHandler(q *mockFieldQuery) *FieldHandler {
+	return newTestFieldHandlerWithRepository(q, &mockFieldRepository{})
+}
+
+// newTestFieldHandlerWithRepository はリポジトリのモックも指定してFieldHandlerを作成する
+// クラスタージョブのエンキューとオーバーラップ検出は行わない
+func newTestFieldHandlerWithRepository(q *mockFieldQuery, repo *mockFieldRepository) *FieldHandler {
+	logger := getTestLogger()
+	return NewFieldHandler(
+		usecase.NewListFieldsUseCase(q),
+		usecase.NewGetFieldUseCase(q),
+		usecase.NewCreateFieldUseCase(repo, q, nil, nil, logger),
+		usecase.NewUpdateFieldUseCase(repo, q, nil, nil, logger),
+		usecase.NewDeleteFieldUseCase(repo, nil, logger),
+		usecase.NewDivideFieldUseCase(repo, &mockFieldDivisionRepository{}, q, nil, nil, logger),
+		usecase.NewMergeFieldsUseCase(repo, &mockFieldMergerRepository{}, q, nil, nil, logger),
+		logger,
+	)
+}
+
+// testPolygon はテスト用のGeoJSON Polygonを返す
+func testPolygon() openapi.GeoJSONPolygon {
+	return openapi.GeoJSONPolygon{
+		Type: openapi.Polygon,
+		Coordinates: [][][]float64{
+			{{137.0, 36.0}, {137.001, 36.0}, {137.001, 36.001}, {137.0, 36.001}, {137.0, 36.0}},
+		},
+	}
+}
+
+// TestFieldHandler_ListFields_Success は正常に圃場一覧を取得することをテストする
+func TestFieldHandler_ListFields_Success(t *testing.T) {
+	area := 12345.0
+	now := time.Now()
+	field := &entity.Field{
+		ID:        uuid.New(),
+		Name:      "テスト圃場",
+		CityCode:  "163210",
+		AreaSqm:   &area,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	handler := newTestFieldHandler(&mockFieldQuery{fields: []*entity.Field{field}, total: 42})
+
+	response, err := handler.ListFields(context.Background(), openapi.ListFieldsRequestObject{})
+
+	require.NoError(t, err, "ListFieldsでエラーが発生")
+	resp200, ok := response.(openapi.ListFields200JSONResponse)
+	require.True(t, ok, "200レスポンスを期待")
+	require.Equal(t, 42, resp200.Total, "総数が期待値と異なります")
+	require.Len(t, resp200.Fields, 1, "圃場数が期待値と異なります")
+
+	got := resp200.Fields[0]
+	require.Equal(t, field.ID, got.Id, "IDが一致しない")
+	require.Equal(t, "テスト圃場", got.Name, "Nameが一致しない")
+	require.Equal(t, "163210", got.CityCode, "CityCodeが一致しない")
+	require.NotNil(t, got.AreaHa, "AreaHaがnil")
+	require.InDelta(t, 1.2345, *got.AreaHa, 1e-9, "AreaHaがヘクタールに変換されていない")
+}
+
+// TestFieldHandler_ListFields_ValidationError は不正なパラメータで400を返すことをテストする
+func TestFieldHandler_ListFields_ValidationError(t *testing.T) {
+	swLat := 36.0
+	handler := newTestFieldHandler(&mockFieldQuery{})
+
+	response, err := handler.ListFields(context.Background(), openapi.ListFieldsRequestObject{
+		Params: openapi.ListFieldsParams{SwLat: &swLat},
+	})
+
+	require.NoError(t, err, "エラーは返さずにレスポンスで返すべき")
+	resp400, ok := response.(openapi.ListFields400JSONResponse)
+	require.True(t, ok, "400レスポンスを期待")
+	require.Equal(t, "invalid_parameter", resp400.Code, "エラーコードが期待値と異なります")
+}
+
+// TestFieldHandler_ListFields_InternalError はクエリ失敗時に500を返すことをテストする
+func TestFieldHandler_ListFields_InternalError(t *testing.T) {
+	handler := newTestFieldHandler(&mockFieldQuery{listErr: errors.New("db error")})
+
+	response, err := handler.ListFields(context.Background(), openapi.ListFieldsRequestObject{})
+
+	require.NoError(t, err, "エラーは返さずにレスポンスで返すべき")
+	resp500, ok := response.(openapi.ListFields500JSONResponse)
+	require.True(t, ok, "500レスポンスを期待")
+	require.Equal(t, "internal_error", resp500.Code, "エラーコードが期待値と異なります")
+}
+
+// TestFieldHandler_GetField_Success は圃場詳細をGeoJSON Featureとして返すことをテストする
+func TestFieldHandler_GetField_Success(t *testing.T) {
+	id := uuid.New()
+	area := 5000.0
+	h3Index := "8f1f8d3a4bc0000"
+	res3 := "831f8dfffffffff"
+	res9 := "891f8d3a4bfffff"
+	registryArea := int32(4800)
+	studyDate := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	address := "富山県射水市1-1"
+
+	field := entity.NewField(id, "163210")
+	field.AreaSqm = &area
+	field.H3Index = &h3Index
+	field.Geometry = geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+		{{{137.0, 36.0}, {137.1, 36.0}, {137.1, 36.1}, {137.0, 36.1}, {137.0, 36.0}}},
+	})
+	field.Centroid = geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{137.05, 36.05})
+
+	registry := entity.NewFieldLandRegistry(id)
+	registry.Address = &address
+	registry.AreaSqm = &registryArea
+	registry.DescriptiveStudyData = &studyDate
+
+	detail := &query.FieldDetail{
+		Field:    field,
+		SoilType: entity.NewSoilType("F3", "F3a7", "F3a7t4", "粗粒グライ灰色低地土"),
+		LandRegistries: []*query.LandRegistryDetail{
+			{
+				Registry:       registry,
+				LandCategory:   entity.NewLandCategory("01", "田"),
+				IdleLandStatus: nil,
+			},
+		},
+	}
+	handler := newTestFieldHandler(&mockFieldQuery{detail: detail})
+
+	response, err := handler.GetField(context.Background(), openapi.GetFieldRequestObject{FieldId: id})
+
+	require.NoError(t, err, "GetFieldでエラーが発生")
+	resp200, ok := response.(openapi.GetField200JSONResponse)
+	require.True(t, ok, "200レスポンスを期待")
+
+	require.Equal(t, openapi.FieldFeatureTypeFeature, resp200.Type, "typeがFeatureではない")
+	require.Equal(t, id, resp200.Id, "IDが一致しない")
+	geometryType, err := resp200.Geometry.Discriminator()
+	require.NoError(t, err, "geometry.typeの取得でエラーが発生")
+	require.Equal(t, string(openapi.Polygon), geometryType, "単一区画のgeometry.typeがPolygonではない")
+	polygon, err := resp200.Geometry.AsGeoJSONPolygon()
+	require.NoError(t, err, "Polygonへの変換でエラーが発生")
+	require.Len(t, polygon.Coordinates, 1, "リング数が期待値と異なります")
+	require.Len(t, polygon.Coordinates[0], 5, "頂点数が期待値と異なります")
+	require.Equal(t, []float64{137.0, 36.0}, polygon.Coordinates[0][0], "座標が[経度, 緯度]になっていない")
+
+	props := resp200.Properties
+	require.NotNil(t, props.Centroid, "centroidがnil")
+	require.Equal(t, []float64{137.05, 36.05}, props.Centroid.Coordinates, "重心座標が一致しない")
+	require.Equal(t, &res9, props.H3Indexes.Res9, "H3インデックスが一致しない")
+	require.Equal(t, &res3, props.H3Indexes.Res3, "親セルのH3インデックスが一致しない")
+	require.InDelta(t, 0.5, *props.AreaHa, 1e-9, "AreaHaが一致しない")
+	require.NotNil(t, props.SoilType, "soilTypeがnil")
+	require.Equal(t, "F3", props.SoilType.LargeCode, "大分類コードが一致しない")
+	require.Equal(t, "F3a7t4", props.SoilType.SmallCode, "小分類コードが一致しない")
+
+	require.Len(t, props.LandRegistries, 1, "農地台帳数が期待値と異なります")
+	lr := props.LandRegistries[0]
+	require.Equal(t, &address, lr.Address, "所在地が一致しない")
+	require.Equal(t, 4800, *lr.AreaSqm, "台帳面積が一致しない")
+	require.Equal(t, &openapi.CodeName{Code: "01", Name: "田"}, lr.LandCategory, "土地種別が一致しない")
+	require.Nil(t, lr.IdleLandStatus, "遊休農地状況はnilであるべき")
+	require.Equal(t, studyDate, lr.DescriptiveStudyDate.Time, "実態調査日が一致しない")
+}
+
+// TestFieldHandler_GetField_MultiPolygon は複数区画の圃場をMultiPolygonとして、内周リングを保持したまま返すことをテストする
+func TestFieldHandler_GetField_MultiPolygon(t *testing.T) {
+	id := uuid.New()
+	field := entity.NewField(id, "163210")
+	field.Geometry = geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+		{
+			{{137.0, 36.0}, {137.1, 36.0}, {137.1, 36.1}, {137.0, 36.1}, {137.0, 36.0}},
+			{{137.02, 36.02}, {137.02, 36.04}, {137.04, 36.04}, {137.04, 36.02}, {137.02, 36.02}},
+		},
+		{
+			{{137.2, 36.0}, {137.3, 36.0}, {137.3, 36.1}, {137.2, 36.0}},
+		},
+	})
+	handler := newTestFieldHandler(&mockFieldQuery{detail: &query.FieldDetail{Field: field}})
+
+	response, err := handler.GetField(context.Background(), openapi.GetFieldRequestObject{FieldId: id})
+
+	require.NoError(t, err, "GetFieldでエラーが発生")
+	resp200, ok := response.(openapi.GetField200JSONResponse)
+	require.True(t, ok, "200レスポンスを期待")
+
+	geometryType, err := resp200.Geometry.Discriminator()
+	require.NoError(t, err, "geometry.typeの取得でエラーが発生")
+	require.Equal(t, string(openapi.MultiPolygon), geometryType, "複数区画のgeometry.typeがMultiPolygonではない")
+	multiPolygon, err := resp200.Geometry.AsGeoJSONMultiPolygon()
+	require.NoError(t, err, "MultiPolygonへの変換でエラーが発生")
+	require.Len(t, multiPolygon.Coordinates, 2, "区画数が期待値と異なります")
+	require.Len(t, multiPolygon.Coordinates[0], 2, "内周リングが失われている")
+	require.Equal(t, []float64{137.02, 36.02}, multiPolygon.Coordinates[0][1][0], "内周リングの座標が一致しない")
+	require.Len(t, multiPolygon.Coordinates[1][0], 4, "2番目の区画の頂点数が期待値と異なります")
+}
+
+// TestFieldHandler_GetField_NotFound は圃場が存在しない場合に404を返すことをテストする
+func TestFieldHandler_GetField_NotFound(t *testing.T) {
+	handler := newTestFieldHandler(&mockFieldQuery{})
+
+	response, err := handler.GetField(context.Background(), openapi.GetFieldRequestObject{FieldId: uuid.New()})
+
+	require.NoError(t, err, "エラーは返さずにレスポンスで返すべき")
+	resp404, ok := response.(openapi.GetField404JSONResponse)
+	require.True(t, ok, "404レスポンスを期待")
+	require.Equal(t, "not_found", resp404.Code, "エラーコードが期待値と異なります")
+}
+
+// TestFieldHandler_GetField_InternalError はクエリ失敗時に500を返すことをテストする
+func TestFieldHandler_GetField_InternalError(t *testing.T) {
+	handler := newTestFieldHandler(&mockFieldQuery{detailErr: errors.New("db error")})
+
+	response, err := handler.GetField(context.Background(), openapi.GetFieldRequestObject{FieldId: uuid.New()})
+
+	require.NoError(t, err, "エラーは返さずにレスポンスで返すべき")
+	_, ok := response.(openapi.GetField500JSONResponse)
+	require.True(t, ok, "500レスポンスを期待")
+}
+
+// TestFieldHandler_CreateField_Success は圃場作成時に201と作成後のFeatureを返すことをテストする
+func TestFieldHandler_CreateField_Success(t *testing.T) {
+	id := uuid.New()
+	detail := &query.FieldDetail{Field: entity.NewField(id, "163210")}
+	handler := newTestFieldHandler(&mockFieldQuery{detail: detail})
+
+	response, err := handler.CreateField(context.Background(), openapi.CreateFieldRequestObject{
+		Body: &openapi.CreateFieldJSONRequestBody{
+			CityCode: "163210",
+			Geometry: testPolygon(),
+		},
+	})
+
+	require.NoError(t, err, "CreateFieldでエラーが発生")
+	resp201, ok := response.(openapi.CreateField201JSONResponse)
+	require.True(t, ok, "201レスポンスを期待")
+	require.Equal(t, id, resp201.Id, "IDが一致しない")
+}
+
+// TestFieldHandler_CreateField_InvalidGeometry は不正なジオメトリで400を返すことをテストする
+func TestFieldHandler_CreateField_InvalidGeometry(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry openapi.GeoJSONPolygon
+	}{
+		{
+			name:     "typeがPolygonでない",
+			geometry: openapi.GeoJSONPolygon{Type: "Point", Coordinates: testPolygon().Coordinates},
+		},
+		{
+			name: "リングが閉じていない",
+			geometry: openapi.GeoJSONPolygon{
+				Type:        openapi.Polygon,
+				Coordinates: [][][]float64{{{137.0, 36.0}, {137.001, 36.0}, {137.001, 36.001}, {137.0, 36.001}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestFieldHandler(&mockFieldQuery{})
+
+			response, err := handler.CreateField(context.Background(), openapi.CreateFieldRequestObject{
+				Body: &openapi.CreateFieldJSONRequestBody{CityCode: "163210", Geometry: tt.geometry},
+			})
+
+			require.NoError(t, err, "エラーは返さずにレスポンスで返すべき")
+			resp400, ok := response.(openapi.CreateField400JSONResponse)
+			require.True(t, ok, "400レスポンスを期待")
+			require.Equal(t, "invalid_parameter", resp400.Code, "エラーコードが期待値と異なります")
+		})
+	}
+}
+
+// TestFieldHandler_UpdateField_NotFound は存在しない圃場の更新で404を返すことをテストする
+func TestFieldHandler_UpdateField_NotFound(t *testing.T) {
+	handler := newTestFieldHandler(&mockFieldQuery{})
+	name := "圃場"
+
+	response, err := handler.UpdateField(context.Background(), openapi.UpdateFieldRequestObject{
+		FieldId: uuid.New(),
+		Body:    &openapi.UpdateFieldJSONRequestBody{Name: &name},
+	})
+
+	require.NoError(t, err, "エラーは返さずにレスポンスで返すべき")
+	resp404, ok := response.(openapi.UpdateField404JSONResponse)
+	require.True(t, ok, "404レスポンスを期待")
+	require.Equal(t, "not_found", resp404.Code, "エラーコードが期待値と異なります")
+}
+
+// TestFieldHandler_UpdateField_Success は圃場更新時に200を返すことをテストする
+func TestFieldHandler_UpdateField_Success(t *testing.T) {
+	field := entity.NewField(uuid.New(), "163210")
+	detail := &query.FieldDetail{Field: field}
+	handler := newTestFieldHandlerWithRepository(&mockFieldQuery{detail: detail}, &mockFieldRepository{field: field})
+	geometry := testPolygon()
+
+	response, err := handler.UpdateField(context.Background(), openapi.UpdateFieldRequestObject{
+		FieldId: field.ID,
+		Body:    &openapi.UpdateFieldJSONRequestBody{Geometry: &geometry},
+	})
+
+	require.NoError(t, err, "UpdateFieldでエラーが発生")
+	_, ok := response.(openapi.UpdateField200JSONResponse)
+	require.True(t, ok, "200レスポンスを期待")
+}
+
+// TestFieldHandler_DeleteField は圃場削除の成功時に204、存在しない場合に404を返すことをテストする
+func TestFieldHandler_DeleteField(t *testing.T) {
+	field := entity.NewField(uuid.New(), "163210")
+
+	handler := newTestFieldHandlerWithRepository(&mockFieldQuery{}, &mockFieldRepository{field: field})
+	response, err := handler.DeleteField(context.Background(), openapi.DeleteFieldRequestObject{FieldId: field.ID})
+	require.NoError(t, err, "DeleteFieldでエラーが発生")
+	_, ok := response.(openapi.DeleteField204Response)
+	require.True(t, ok, "204レスポンスを期待")
+
+	handler = newTestFieldHandler(&mockFieldQuery{})
+	response, err = handler.DeleteField(context.Background(), openapi.DeleteFieldRequestObject{FieldId: uuid.New()})
+	require.NoError(t, err, "エラーは返さずにレスポンスで返すべき")
+	_, ok = response.(openapi.DeleteField404JSONResponse)
+	require.True(t, ok, "404レスポンスを期待")
+}
+
+// TestFieldHandler_UpdateField_Retired は廃止済みの圃場の更新で409を返すことをテストする
+func TestFieldHandler_UpdateField_Retired(t *testing.T) {
+	field := entity.NewField(uuid.New(), "163210")
+	retiredAt := time.Now()
+	field.RetiredAt = &retiredAt
+	handler := newTestFieldHandlerWithRepository(&mockFieldQuery{}, &mockFieldRepository{field: field})
+	name := "圃場"
+
+	response, err := handler.UpdateField(context.Background(), openapi.UpdateFieldRequestObject{
+		FieldId: field.ID,
+		Body:    &openapi.UpdateFieldJSONRequestBody{Name: &name},
+	})
+
+	require.NoError(t, err, "エラーは返さずにレスポンスで返すべき")
+	resp409, ok := response.(openapi.UpdateField409JSONResponse)
+	require.True(t, ok, "409レスポンスを期待")
+	require.Equal(t, "conflict", resp409.Code, "エラーコードが期待値と異なります")
+}
+
+// testDivisionChildren は testPolygon を左右に2分割した子圃場を返す
+func testDivisionChildren() []openapi.FieldDivisionChild {
+	return []openapi.FieldDivisionChild{
+		{Geometry: openapi.GeoJSONPolygon{
+			Type:        openapi.Polygon,
+			Coordinates: [][][]float64{{{137.0, 36.0}, {137.0005, 36.0}, {137.0005, 36.001}, {137.0, 36.001}, {137.0, 36.0}}},
+		}},
+		{Geometry: openapi.GeoJSONPolygon{
+			Type:        openapi.Polygon,
+			Coordinates: [][][]float64{{{137.0005, 36.0}, {137.001, 36.0}, {137.001, 36.001}, {137.0005, 36.001}, {137.0005, 36.0}}},
+		}},
+	}
+}
+
+// TestFieldHandler_DivideField_Success は分筆時に201と子圃場のFeatureを返すことをテストする
+func TestFieldHandler_DivideField_Success(t *testing.T) {
+	parent := entity.NewField(uuid.New(), "163210")
+	detail := &query.FieldDetail{Field: entity.NewField(uuid.New(), "163210")}
+	handler := newTestFieldHandlerWithRepository(&mockFieldQuery{detail: detail}, &mockFieldRepository{field: parent})
+	reason := "分筆"
+
+	response, err := handler.DivideField(context.Background(), openapi.DivideFieldRequestObject{
+		FieldId: parent.ID,
+		Body:    &openapi.DivideFieldJSONRequestBody{Children: testDivisionChildren(), Reason: &reason},
+	})
+
+	require.NoError(t, err, "DivideFieldでエラーが発生")
+	resp201, ok := response.(openapi.DivideField201JSONResponse)
+	require.True(t, ok, "201レスポンスを期待")
+	require.Equal(t, parent.ID, resp201.ParentFieldId, "親圃場IDが一致しない")
+	require.Equal(t, &reason, resp201.Reason, "理由が一致しない")
+	require.Len(t, resp201.Children, 2, "子圃場数が期待値と異なります")
+}
+
+// TestFieldHandler_DivideField_Error は分筆の失敗内容に応じたレスポンスを返すことをテストする
+func TestFieldHandler_DivideField_Error(t *testing.T) {
+	retiredAt := time.Now()
+	retired := entity.NewField(uuid.New(), "163210")
+	retired.RetiredAt = &retiredAt
+
+	tests := []struct {
+		name     string
+		repo     *mockFieldRepository
+		body     *openapi.DivideFieldJSONRequestBody
+		wantCode string
+	}{
+		{
+			name:     "ボディなし",
+			repo:     &mockFieldRepository{field: entity.NewField(uuid.New(), "163210")},
+			wantCode: "invalid_parameter",
+		},
+		{
+			name: "typeがPolygonでない",
+			repo: &mockFieldRepository{field: entity.NewField(uuid.New(), "163210")},
+			body: &openapi.DivideFieldJSONRequestBody{Children: []openapi.FieldDivisionChild{
+				testDivisionChildren()[0],
+				{Geometry: openapi.GeoJSONPolygon{Type: "Point", Coordinates: testPolygon().Coordinates}},
+			}},
+			wantCode: "invalid_parameter",
+		},
+		{
+			name:     "存在しない圃場",
+			repo:     &mockFieldRepository{},
+			body:     &openapi.DivideFieldJSONRequestBody{Children: testDivisionChildren()},
+			wantCode: "not_found",
+		},
+		{
+			name:     "廃止済みの圃場",
+			repo:     &mockFieldRepository{field: retired},
+			body:     &openapi.DivideFieldJSONRequestBody{Children: testDivisionChildren()},
+			wantCode: "conflict",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestFieldHandlerWithRepository(&mockFieldQuery{}, tt.repo)
+
+			response, err := handler.DivideField(context.Background(), openapi.DivideFieldRequestObject{
+				FieldId: uuid.New(),
+				Body:    tt.body,
+			})
+
+			require.NoError(t, err, "エラーは返さずにレスポンスで返すべき")
+			var code string
+			switch resp := response.(type) {
+			case openapi.DivideField400JSONResponse:
+				code = resp.Code
+			case openapi.DivideField404JSONResponse:
+				code = resp.Code
+			case openapi.DivideField409JSONResponse:
+				code = resp.Code
+			default:
+				t.Fatalf("想定外のレスポンス型: %T", response)
+			}
+			require.Equal(t, tt.wantCode, code, "エラーコードが期待値と異なります")
+		})
+	}
+}
+
+// TestFieldHandler_MergeFields_Success は合筆時に201と合筆先圃場のFeatureを返すことをテストする
+func TestFieldHandler_MergeFields_Success(t *testing.T) {
+	first := entity.NewField(uuid.New(), "163210")
+	second := entity.NewField(uuid.New(), "163210")
+	repo := &mockFieldRepository{fields: map[uuid.UUID]*entity.Field{first.ID: first, second.ID: second}}
+	detail := &query.FieldDetail{Field: entity.NewField(uuid.New(), "163210")}
+	handler := newTestFieldHandlerWithRepository(&mockFieldQuery{detail: detail}, repo)
+
+	response, err := handler.MergeFields(context.Background(), openapi.MergeFieldsRequestObject{
+		Body: &openapi.MergeFieldsJSONRequestBody{SourceFieldIds: []uuid.UUID{first.ID, second.ID}},
+	})
+
+	require.NoError(t, err, "MergeFieldsでエラーが発生")
+	resp201, ok := response.(openapi.MergeFields201JSONResponse)
+	require.True(t, ok, "201レスポンスを期待")
+	require.Equal(t, []uuid.UUID{first.ID, second.ID}, resp201.SourceFieldIds, "ソース圃場IDが一致しない")
+	require.Equal(t, detail.Field.ID, resp201.Field.Id, "合筆先圃場のIDが一致しない")
+}
+
+// TestFieldHandler_MergeFields_Error は合筆の失敗内容に応じたレスポンスを返すことをテストする
+func TestFieldHandler_MergeFields_Error(t *testing.T) {
+	retiredAt := time.Now()
+	active := entity.NewField(uuid.New(), "163210")
+	retired := entity.NewField(uuid.New(), "163210")
+	retired.RetiredAt = &retiredAt
+	repo := &mockFieldRepository{fields: map[uuid.UUID]*entity.Field{active.ID: active, retired.ID: retired}}
+
+	tests := []struct {
+		name     string
+		body     *openapi.MergeFieldsJSONRequestBody
+		wantCode string
+	}{
+		{
+			name:     "ボディなし",
+			wantCode: "invalid_parameter",
+		},
+		{
+			name:     "同じ圃場を重複指定",
+			body:     &openapi.MergeFieldsJSONRequestBody{SourceFieldIds: []uuid.UUID{active.ID, active.ID}},
+			wantCode: "invalid_parameter",
+		},
+		{
+			name:     "存在しない圃場",
+			body:     &openapi.MergeFieldsJSONRequestBody{SourceFieldIds: []uuid.UUID{active.ID, uuid.New()}},
+			wantCode: "not_found",
+		},
+		{
+			name:     "廃止済みの圃場",
+			body:     &openapi.MergeFieldsJSONRequestBody{SourceFieldIds: []uuid.UUID{active.ID, retired.ID}},
+			wantCode: "conflict",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestFieldHandlerWithRepository(&mockFieldQuery{}, repo)
+
+			response, err := handler.MergeFields(context.Background(), openapi.MergeFieldsRequestObject{Body: tt.body})
+
+			require.NoError(t, err, "エラーは返さずにレスポンスで返すべき")
+			var code string
+			switch resp := response.(type) {
+			case openapi.MergeFields400JSONResponse:
+				code = resp.Code
+			case openapi.MergeFields404JSONResponse:
+				code = resp.Code
+			case openapi.MergeFields409JSONResponse:
+				code = resp.Code
+			default:
+				t.Fatalf("想定外のレスポンス型: %T", response)
+			}
+			require.Equal(t, tt.wantCode, code, "エラーコードが期待値と異なります")
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package presentation
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
+)
+
+// FieldLineageHandler は圃場系譜APIのハンドラー
+type FieldLineageHandler struct {
+	getFieldLineageUC *usecase.GetFieldLineageUseCase
+	logger            *slog.Logger
+}
+
+// NewFieldLineageHandler はFieldLineageHandlerを作成する
+func NewFieldLineageHandler(getFieldLineageUC *usecase.GetFieldLineageUseCase, logger *slog.Logger) *FieldLineageHandler {
+	return &FieldLineageHandler{
+		getFieldLineageUC: getFieldLineageUC,
+		logger:            logger,
+	}
+}
+
+// GetFieldLineage は分筆・合筆履歴から圃場の系譜グラフを取得する
+func (h *FieldLineageHandler) GetFieldLineage(ctx context.Context, request openapi.GetFieldLineageRequestObject) (openapi.GetFieldLineageResponseObject, error) {
+	lineage, err := h.getFieldLineageUC.Execute(ctx, request.FieldId, request.Params.Depth)
+	if err != nil {
+		if isBadRequest(err) {
+			return openapi.GetFieldLineage400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: err.Error(),
+			}, nil
+		}
+		if apperror.IsNotFoundError(err) {
+			return openapi.GetFieldLineage404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("圃場系譜の取得に失敗しました",
+			slog.String("field_id", request.FieldId.String()),
+			slog.String("error", err.Error()))
+		return openapi.GetFieldLineage500JSONResponse{
+			Code:    "internal_error",
+			Message: "圃場系譜の取得に失敗しました",
+		}, nil
+	}
+
+	return openapi.GetFieldLineage200JSONResponse(toFieldLineageResponse(lineage)), nil
+}
+
+// toFieldLineageResponse は系譜グラフをレスポンスに変換する
+func toFieldLineageResponse(lineage *query.FieldLineage) openapi.FieldLineageResponse {
+	res := openapi.FieldLineageResponse{
+		FieldId:   lineage.FieldID,
+		Depth:     lineage.MaxDepth,
+		Truncated: lineage.Truncated,
+		Nodes:     make([]openapi.FieldLineageNode, 0, len(lineage.Nodes)),
+		Edges:     make([]openapi.FieldLineageEdge, 0, len(lineage.Edges)),
+	}
+
+	for _, node := range lineage.Nodes {
+		n := openapi.FieldLineageNode{
+			Id:        node.FieldID,
+			Name:      node.Name,
+			CityCode:  node.CityCode,
+			Depth:     node.Depth,
+			CreatedAt: node.CreatedAt,
+			RetiredAt: node.RetiredAt,
+		}
+		if node.AreaSqm != nil {
+			areaHa := *node.AreaSqm / sqmPerHa
+			n.AreaHa = &areaHa
+		}
+		res.Nodes = append(res.Nodes, n)
+	}
+
+	for _, edge := range lineage.Edges {
+		res.Edges = append(res.Edges, openapi.FieldLineageEdge{
+			Type:        openapi.FieldLineageEdgeType(edge.Type),
+			FromFieldId: edge.FromFieldID,
+			ToFieldId:   edge.ToFieldID,
+			OccurredAt:  edge.OccurredAt,
+			Reason:      edge.Reason,
+		})
+	}
+	return res
+}
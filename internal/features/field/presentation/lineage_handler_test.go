@@ -0,0 +1,95 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFieldLineageQuery はFieldLineageQueryのモック実装
+type mockFieldLineageQuery struct {
+	lineage *query.FieldLineage
+	err     error
+}
+
+func (m *mockFieldLineageQuery) FindLineage(_ context.Context, _ uuid.UUID, _ int) (*query.FieldLineage, error) {
+	return m.lineage, m.err
+}
+
+func newTestFieldLineageHandler(lineageQuery *mockFieldLineageQuery) *FieldLineageHandler {
+	return NewFieldLineageHandler(usecase.NewGetFieldLineageUseCase(lineageQuery), getTestLogger())
+}
+
+func TestFieldLineageHandler_GetFieldLineage_Success(t *testing.T) {
+	parentID := uuid.New()
+	childID := uuid.New()
+	area := 5000.0
+	now := time.Now()
+	reason := "分筆"
+	lineage := &query.FieldLineage{
+		FieldID:   childID,
+		MaxDepth:  10,
+		Truncated: true,
+		Nodes: []*query.LineageNode{
+			{FieldID: parentID, Name: "親圃場", CityCode: "163210", Depth: -1, CreatedAt: now, RetiredAt: &now},
+			{FieldID: childID, Name: "子圃場", CityCode: "163210", AreaSqm: &area, Depth: 0, CreatedAt: now},
+		},
+		Edges: []*query.LineageEdge{
+			{Type: query.LineageEdgeDivision, FromFieldID: parentID, ToFieldID: childID, OccurredAt: now, Reason: &reason},
+		},
+	}
+	h := newTestFieldLineageHandler(&mockFieldLineageQuery{lineage: lineage})
+
+	resp, err := h.GetFieldLineage(context.Background(), openapi.GetFieldLineageRequestObject{FieldId: childID})
+
+	require.NoError(t, err, "GetFieldLineageでエラーが発生")
+	okResp, ok := resp.(openapi.GetFieldLineage200JSONResponse)
+	require.True(t, ok, "200レスポンスを期待")
+	require.Equal(t, childID, okResp.FieldId, "起点圃場IDが一致しない")
+	require.True(t, okResp.Truncated, "truncatedが反映されていない")
+	require.Len(t, okResp.Nodes, 2, "ノード数が期待値と異なります")
+	require.Equal(t, -1, okResp.Nodes[0].Depth, "祖先のdepthが負になっていない")
+	require.NotNil(t, okResp.Nodes[0].RetiredAt, "廃止日時が設定されていない")
+	require.Nil(t, okResp.Nodes[0].AreaHa, "面積未設定のノードはareaHaを省略すべき")
+	require.InDelta(t, 0.5, *okResp.Nodes[1].AreaHa, 1e-9, "面積がヘクタールに変換されていない")
+	require.Len(t, okResp.Edges, 1, "エッジ数が期待値と異なります")
+	require.Equal(t, openapi.Division, okResp.Edges[0].Type, "エッジ種別が一致しない")
+	require.Equal(t, parentID, okResp.Edges[0].FromFieldId, "エッジの始点が一致しない")
+	require.Equal(t, &reason, okResp.Edges[0].Reason, "理由が一致しない")
+}
+
+func TestFieldLineageHandler_GetFieldLineage_Error(t *testing.T) {
+	invalidDepth := 0
+
+	tests := []struct {
+		name     string
+		query    *mockFieldLineageQuery
+		depth    *int
+		wantType any
+	}{
+		{name: "depthが範囲外", query: &mockFieldLineageQuery{}, depth: &invalidDepth, wantType: openapi.GetFieldLineage400JSONResponse{}},
+		{name: "圃場が存在しない", query: &mockFieldLineageQuery{}, wantType: openapi.GetFieldLineage404JSONResponse{}},
+		{name: "DBエラー", query: &mockFieldLineageQuery{err: errors.New("db error")}, wantType: openapi.GetFieldLineage500JSONResponse{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestFieldLineageHandler(tt.query)
+
+			resp, err := h.GetFieldLineage(context.Background(), openapi.GetFieldLineageRequestObject{
+				FieldId: uuid.New(),
+				Params:  openapi.GetFieldLineageParams{Depth: tt.depth},
+			})
+
+			require.NoError(t, err, "エラーは返さずにレスポンスで返すべき")
+			require.IsType(t, tt.wantType, resp, "レスポンス型が期待値と異なります")
+		})
+	}
+}
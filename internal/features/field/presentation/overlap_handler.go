@@ -0,0 +1,138 @@
+package presentation
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
+)
+
+// FieldOverlapHandler は圃場オーバーラップAPIのハンドラー
+type FieldOverlapHandler struct {
+	listFieldOverlapsUC   *usecase.ListFieldOverlapsUseCase
+	resolveFieldOverlapUC *usecase.ResolveFieldOverlapUseCase
+	logger                *slog.Logger
+}
+
+// NewFieldOverlapHandler はFieldOverlapHandlerを作成する
+func NewFieldOverlapHandler(
+	listFieldOverlapsUC *usecase.ListFieldOverlapsUseCase,
+	resolveFieldOverlapUC *usecase.ResolveFieldOverlapUseCase,
+	logger *slog.Logger,
+) *FieldOverlapHandler {
+	return &FieldOverlapHandler{
+		listFieldOverlapsUC:   listFieldOverlapsUC,
+		resolveFieldOverlapUC: resolveFieldOverlapUC,
+		logger:                logger,
+	}
+}
+
+// ListFieldOverlaps は検出済みの圃場オーバーラップ一覧を取得する
+func (h *FieldOverlapHandler) ListFieldOverlaps(ctx context.Context, request openapi.ListFieldOverlapsRequestObject) (openapi.ListFieldOverlapsResponseObject, error) {
+	params := request.Params
+
+	var status *string
+	if params.Status != nil {
+		s := string(*params.Status)
+		status = &s
+	}
+
+	output, err := h.listFieldOverlapsUC.Execute(ctx, usecase.ListFieldOverlapsInput{
+		Limit:   params.Limit,
+		Offset:  params.Offset,
+		Status:  status,
+		FieldID: params.FieldId,
+	})
+	if err != nil {
+		if isBadRequest(err) {
+			return openapi.ListFieldOverlaps400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("オーバーラップ一覧の取得に失敗しました",
+			slog.String("error", err.Error()))
+		return openapi.ListFieldOverlaps500JSONResponse{
+			Code:    "internal_error",
+			Message: "オーバーラップ一覧の取得に失敗しました",
+		}, nil
+	}
+
+	overlaps := make([]openapi.FieldOverlap, 0, len(output.Overlaps))
+	for _, o := range output.Overlaps {
+		overlaps = append(overlaps, toFieldOverlapResponse(o))
+	}
+
+	return openapi.ListFieldOverlaps200JSONResponse{
+		Overlaps: overlaps,
+		Total:    int(output.Total),
+	}, nil
+}
+
+// ResolveFieldOverlap はオーバーラップを許容するか、一方の圃場をクリップして解消する
+func (h *FieldOverlapHandler) ResolveFieldOverlap(ctx context.Context, request openapi.ResolveFieldOverlapRequestObject) (openapi.ResolveFieldOverlapResponseObject, error) {
+	if request.Body == nil {
+		return openapi.ResolveFieldOverlap400JSONResponse{
+			Code:    "invalid_parameter",
+			Message: "リクエストボディは必須です",
+		}, nil
+	}
+
+	overlap, err := h.resolveFieldOverlapUC.Execute(ctx, usecase.ResolveFieldOverlapInput{
+		ID:          request.OverlapId,
+		Action:      usecase.OverlapResolveAction(request.Body.Action),
+		ClipFieldID: request.Body.ClipFieldId,
+		Note:        request.Body.Note,
+		UserID:      request.Params.XUserID,
+	})
+	if err != nil {
+		if isBadRequest(err) {
+			return openapi.ResolveFieldOverlap400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: err.Error(),
+			}, nil
+		}
+		if apperror.IsNotFoundError(err) {
+			return openapi.ResolveFieldOverlap404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		if isConflict(err) {
+			return openapi.ResolveFieldOverlap409JSONResponse{
+				Code:    "conflict",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("オーバーラップの対応に失敗しました",
+			slog.String("overlap_id", request.OverlapId.String()),
+			slog.String("action", string(request.Body.Action)),
+			slog.String("error", err.Error()))
+		return openapi.ResolveFieldOverlap500JSONResponse{
+			Code:    "internal_error",
+			Message: "オーバーラップの対応に失敗しました",
+		}, nil
+	}
+
+	return openapi.ResolveFieldOverlap200JSONResponse(toFieldOverlapResponse(overlap)), nil
+}
+
+// toFieldOverlapResponse はオーバーラップエンティティをレスポンスに変換する
+func toFieldOverlapResponse(overlap *entity.FieldOverlap) openapi.FieldOverlap {
+	return openapi.FieldOverlap{
+		Id:             overlap.ID,
+		FieldIdA:       overlap.FieldIDA,
+		FieldIdB:       overlap.FieldIDB,
+		OverlapAreaSqm: overlap.OverlapAreaSqm,
+		OverlapRatio:   overlap.OverlapRatio,
+		Status:         openapi.FieldOverlapStatus(overlap.Status),
+		ClippedFieldId: overlap.ClippedFieldID,
+		Note:           overlap.Note,
+		DetectedAt:     overlap.DetectedAt,
+		ResolvedAt:     overlap.ResolvedAt,
+		ResolvedBy:     overlap.ResolvedBy,
+	}
+}
@@ -0,0 +1,176 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/query"
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFieldOverlapQuery はFieldOverlapQueryのモック実装
+type mockFieldOverlapQuery struct {
+	overlaps []*entity.FieldOverlap
+	total    int64
+	err      error
+}
+
+func (m *mockFieldOverlapQuery) List(_ context.Context, _ query.FieldOverlapFilter, _, _ int32) ([]*entity.FieldOverlap, error) {
+	return m.overlaps, m.err
+}
+
+func (m *mockFieldOverlapQuery) Count(_ context.Context, _ query.FieldOverlapFilter) (int64, error) {
+	return m.total, m.err
+}
+
+// mockFieldOverlapRepository はFieldOverlapRepositoryのモック実装
+type mockFieldOverlapRepository struct {
+	overlap   *entity.FieldOverlap
+	acceptErr error
+}
+
+func (m *mockFieldOverlapRepository) DetectByFieldIDs(_ context.Context, _ []uuid.UUID) (int, error) {
+	return 0, nil
+}
+
+func (m *mockFieldOverlapRepository) FindByID(_ context.Context, _ uuid.UUID) (*entity.FieldOverlap, error) {
+	return m.overlap, nil
+}
+
+func (m *mockFieldOverlapRepository) Accept(_ context.Context, _ *entity.FieldOverlap) error {
+	return m.acceptErr
+}
+
+func (m *mockFieldOverlapRepository) Clip(_ context.Context, _ *entity.FieldOverlap, _ *entity.Field) error {
+	return nil
+}
+
+// newTestFieldOverlapHandler はモックを注入したFieldOverlapHandlerを作成する
+func newTestFieldOverlapHandler(overlapQuery *mockFieldOverlapQuery, overlapRepo *mockFieldOverlapRepository) *FieldOverlapHandler {
+	logger := getTestLogger()
+	return NewFieldOverlapHandler(
+		usecase.NewListFieldOverlapsUseCase(overlapQuery),
+		usecase.NewResolveFieldOverlapUseCase(&mockFieldRepository{}, overlapRepo, nil, logger),
+		logger,
+	)
+}
+
+// newTestOverlap は未対応のオーバーラップを作成する
+func newTestOverlap() *entity.FieldOverlap {
+	return &entity.FieldOverlap{
+		ID:             uuid.New(),
+		FieldIDA:       uuid.New(),
+		FieldIDB:       uuid.New(),
+		OverlapAreaSqm: 40,
+		OverlapRatio:   0.25,
+		Status:         entity.OverlapStatusOpen,
+	}
+}
+
+func TestFieldOverlapHandler_ListFieldOverlaps_Success(t *testing.T) {
+	overlap := newTestOverlap()
+	h := newTestFieldOverlapHandler(&mockFieldOverlapQuery{overlaps: []*entity.FieldOverlap{overlap}, total: 1}, &mockFieldOverlapRepository{})
+	status := openapi.ListFieldOverlapsParamsStatusOpen
+
+	resp, err := h.ListFieldOverlaps(context.Background(), openapi.ListFieldOverlapsRequestObject{
+		Params: openapi.ListFieldOverlapsParams{Status: &status},
+	})
+
+	require.NoError(t, err, "ListFieldOverlapsでエラーが発生")
+	okResp, ok := resp.(openapi.ListFieldOverlaps200JSONResponse)
+	require.True(t, ok, "200レスポンスを期待")
+	require.Equal(t, 1, okResp.Total, "総数が一致しない")
+	require.Len(t, okResp.Overlaps, 1, "件数が期待値と異なります")
+	require.Equal(t, overlap.ID, okResp.Overlaps[0].Id, "IDが一致しない")
+	require.Equal(t, openapi.FieldOverlapStatusOpen, okResp.Overlaps[0].Status, "ステータスが一致しない")
+	require.InDelta(t, 0.25, okResp.Overlaps[0].OverlapRatio, 1e-9, "重なりの割合が一致しない")
+}
+
+func TestFieldOverlapHandler_ListFieldOverlaps_Error(t *testing.T) {
+	h := newTestFieldOverlapHandler(&mockFieldOverlapQuery{err: errors.New("db error")}, &mockFieldOverlapRepository{})
+
+	resp, err := h.ListFieldOverlaps(context.Background(), openapi.ListFieldOverlapsRequestObject{})
+
+	require.NoError(t, err, "エラーは返さずにレスポンスで返すべき")
+	require.IsType(t, openapi.ListFieldOverlaps500JSONResponse{}, resp, "500レスポンスを期待")
+}
+
+func TestFieldOverlapHandler_ResolveFieldOverlap_Accept(t *testing.T) {
+	overlap := newTestOverlap()
+	h := newTestFieldOverlapHandler(&mockFieldOverlapQuery{}, &mockFieldOverlapRepository{overlap: overlap})
+	userID := uuid.New()
+	note := "登記上の重複"
+
+	resp, err := h.ResolveFieldOverlap(context.Background(), openapi.ResolveFieldOverlapRequestObject{
+		OverlapId: overlap.ID,
+		Params:    openapi.ResolveFieldOverlapParams{XUserID: &userID},
+		Body:      &openapi.FieldOverlapResolveRequest{Action: openapi.Accept, Note: &note},
+	})
+
+	require.NoError(t, err, "ResolveFieldOverlapでエラーが発生")
+	okResp, ok := resp.(openapi.ResolveFieldOverlap200JSONResponse)
+	require.True(t, ok, "200レスポンスを期待")
+	require.Equal(t, openapi.FieldOverlapStatusAccepted, okResp.Status, "許容済みになっていない")
+	require.Equal(t, &userID, okResp.ResolvedBy, "対応者が一致しない")
+	require.Equal(t, &note, okResp.Note, "備考が一致しない")
+}
+
+func TestFieldOverlapHandler_ResolveFieldOverlap_Error(t *testing.T) {
+	otherID := uuid.New()
+
+	tests := []struct {
+		name     string
+		repo     *mockFieldOverlapRepository
+		body     *openapi.FieldOverlapResolveRequest
+		wantType any
+	}{
+		{
+			name:     "ボディなし",
+			repo:     &mockFieldOverlapRepository{overlap: newTestOverlap()},
+			wantType: openapi.ResolveFieldOverlap400JSONResponse{},
+		},
+		{
+			name:     "当事者以外の圃場をクリップ",
+			repo:     &mockFieldOverlapRepository{overlap: newTestOverlap()},
+			body:     &openapi.FieldOverlapResolveRequest{Action: openapi.Clip, ClipFieldId: &otherID},
+			wantType: openapi.ResolveFieldOverlap400JSONResponse{},
+		},
+		{
+			name:     "オーバーラップが存在しない",
+			repo:     &mockFieldOverlapRepository{},
+			body:     &openapi.FieldOverlapResolveRequest{Action: openapi.Accept},
+			wantType: openapi.ResolveFieldOverlap404JSONResponse{},
+		},
+		{
+			name:     "クリップ済み",
+			repo:     &mockFieldOverlapRepository{overlap: newTestOverlap(), acceptErr: entity.ErrOverlapAlreadyClipped},
+			body:     &openapi.FieldOverlapResolveRequest{Action: openapi.Accept},
+			wantType: openapi.ResolveFieldOverlap409JSONResponse{},
+		},
+		{
+			name:     "DBエラー",
+			repo:     &mockFieldOverlapRepository{overlap: newTestOverlap(), acceptErr: errors.New("db error")},
+			body:     &openapi.FieldOverlapResolveRequest{Action: openapi.Accept},
+			wantType: openapi.ResolveFieldOverlap500JSONResponse{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestFieldOverlapHandler(&mockFieldOverlapQuery{}, tt.repo)
+
+			resp, err := h.ResolveFieldOverlap(context.Background(), openapi.ResolveFieldOverlapRequestObject{
+				OverlapId: uuid.New(),
+				Body:      tt.body,
+			})
+
+			require.NoError(t, err, "エラーは返さずにレスポンスで返すべき")
+			require.IsType(t, tt.wantType, resp, "レスポンス型が期待値と異なります")
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package presentation
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
+)
+
+// FieldTileHandler は圃場ベクタータイルAPIのハンドラー
+type FieldTileHandler struct {
+	getFieldTileUC *usecase.GetFieldTileUseCase
+	logger         *slog.Logger
+}
+
+// NewFieldTileHandler はFieldTileHandlerを作成する
+func NewFieldTileHandler(getFieldTileUC *usecase.GetFieldTileUseCase, logger *slog.Logger) *FieldTileHandler {
+	return &FieldTileHandler{
+		getFieldTileUC: getFieldTileUC,
+		logger:         logger,
+	}
+}
+
+// GetFieldTile は圃場のベクタータイル(MVT)を取得する
+func (h *FieldTileHandler) GetFieldTile(ctx context.Context, request openapi.GetFieldTileRequestObject) (openapi.GetFieldTileResponseObject, error) {
+	data, err := h.getFieldTileUC.Execute(ctx, request.Z, request.X, request.Y)
+	if err != nil {
+		if isBadRequest(err) {
+			return openapi.GetFieldTile400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("圃場タイルの取得に失敗しました",
+			slog.Int("z", request.Z),
+			slog.Int("x", request.X),
+			slog.Int("y", request.Y),
+			slog.String("error", err.Error()))
+		return openapi.GetFieldTile500JSONResponse{
+			Code:    "internal_error",
+			Message: "圃場タイルの取得に失敗しました",
+		}, nil
+	}
+
+	return openapi.GetFieldTile200ApplicationvndMapboxVectorTileResponse{
+		Body:          bytes.NewReader(data),
+		ContentLength: int64(len(data)),
+	}, nil
+}
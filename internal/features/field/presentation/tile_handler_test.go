@@ -0,0 +1,64 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/mktkhr/field-manager-api/internal/features/field/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/features/field/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFieldTileQuery はFieldTileQueryのモック実装
+type mockFieldTileQuery struct {
+	data []byte
+	err  error
+}
+
+func (m *mockFieldTileQuery) GetTile(_ context.Context, _ entity.Tile) ([]byte, error) {
+	return m.data, m.err
+}
+
+func newTestFieldTileHandler(tileQuery *mockFieldTileQuery) *FieldTileHandler {
+	uc := usecase.NewGetFieldTileUseCase(tileQuery, nil, getTestLogger())
+	return NewFieldTileHandler(uc, getTestLogger())
+}
+
+func TestFieldTileHandler_GetFieldTile_Success(t *testing.T) {
+	h := newTestFieldTileHandler(&mockFieldTileQuery{data: []byte("mvt")})
+
+	resp, err := h.GetFieldTile(context.Background(), openapi.GetFieldTileRequestObject{Z: 15, X: 29104, Y: 12902})
+
+	require.NoError(t, err, "GetFieldTileでエラーが発生")
+	okResp, ok := resp.(openapi.GetFieldTile200ApplicationvndMapboxVectorTileResponse)
+	require.True(t, ok, "200レスポンスを期待")
+	require.Equal(t, int64(3), okResp.ContentLength, "Content-Lengthが期待値と異なります")
+	body, err := io.ReadAll(okResp.Body)
+	require.NoError(t, err, "レスポンスボディの読み込みに失敗")
+	require.Equal(t, []byte("mvt"), body, "タイルが期待値と異なります")
+}
+
+func TestFieldTileHandler_GetFieldTile_InvalidZoom(t *testing.T) {
+	h := newTestFieldTileHandler(&mockFieldTileQuery{})
+
+	resp, err := h.GetFieldTile(context.Background(), openapi.GetFieldTileRequestObject{Z: 10, X: 0, Y: 0})
+
+	require.NoError(t, err, "GetFieldTileでエラーが発生")
+	badResp, ok := resp.(openapi.GetFieldTile400JSONResponse)
+	require.True(t, ok, "400レスポンスを期待")
+	require.Equal(t, "invalid_parameter", badResp.Code, "エラーコードが期待値と異なります")
+}
+
+func TestFieldTileHandler_GetFieldTile_InternalError(t *testing.T) {
+	h := newTestFieldTileHandler(&mockFieldTileQuery{err: errors.New("db error")})
+
+	resp, err := h.GetFieldTile(context.Background(), openapi.GetFieldTileRequestObject{Z: 14, X: 0, Y: 0})
+
+	require.NoError(t, err, "GetFieldTileでエラーが発生")
+	errResp, ok := resp.(openapi.GetFieldTile500JSONResponse)
+	require.True(t, ok, "500レスポンスを期待")
+	require.Equal(t, "internal_error", errResp.Code, "エラーコードが期待値と異なります")
+}
@@ -0,0 +1,10 @@
+package port
+
+import "context"
+
+// GeometryRepairer は自己交差などで不正なポリゴンを修復するインターフェース
+type GeometryRepairer interface {
+	// RepairRings は外周リングをST_MakeValid相当の処理で修復し、外周が反時計回りの閉じたリングを返す
+	// 戻り値は入力と同じ順序で、穴のない単一ポリゴンに修復できなかったリングはnilになる
+	RepairRings(ctx context.Context, rings [][][]float64) ([][][]float64, error)
+}
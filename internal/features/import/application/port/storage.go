@@ -3,6 +3,7 @@ package port
 import (
 	"context"
 	"io"
+	"time"
 )
 
 // StorageClient はオブジェクトストレージ(S3/RustFS)操作のインターフェース
@@ -21,4 +22,7 @@ type StorageClient interface {
 
 	// Exists はオブジェクトが存在するかどうかを確認する
 	Exists(ctx context.Context, key string) (bool, error)
+
+	// PresignGetURL はオブジェクトを取得するための署名付きURLを発行する
+	PresignGetURL(ctx context.Context, key string, expiry time.Duration) (string, error)
 }
@@ -19,6 +19,7 @@ type GetImportStatusOutput struct {
 	FailedRecords    int32
 	Progress         float64
 	ErrorMessage     *string
+	RejectedRecords  []entity.RejectedRecord // ジオメトリ検証で拒否したレコード
 	CreatedAt        string
 	StartedAt        *string
 	CompletedAt      *string
@@ -56,6 +57,7 @@ func (uc *GetImportStatusUseCase) Execute(ctx context.Context, id uuid.UUID) (*G
 		FailedRecords:    job.FailedRecords,
 		Progress:         job.Progress(),
 		ErrorMessage:     job.ErrorMessage,
+		RejectedRecords:  job.RejectedRecords,
 		CreatedAt:        job.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 
@@ -20,6 +22,9 @@ const (
 	DefaultBatchSize = 1000
 )
 
+// errGeometryRepairerUnavailable は自己交差の修復処理が設定されていないことを示す
+var errGeometryRepairerUnavailable = errors.New("ジオメトリの修復処理が設定されていません")
+
 // FieldRepository はField操作用のリポジトリインターフェース(Consumer側で定義)
 type FieldRepository interface {
 	// UpsertBatch は圃場をバッチでUPSERTする
@@ -36,6 +41,12 @@ type ClusterJobEnqueuer interface {
 	EnqueueWithAffectedCells(ctx context.Context, priority int32, affectedCells []string) error
 }
 
+// FieldOverlapDetector は圃場の重なりを検出するインターフェース(Consumer側で定義)
+type FieldOverlapDetector interface {
+	// DetectOverlaps は指定圃場と他の圃場の重なりを検出して記録する
+	DetectOverlaps(ctx context.Context, fieldIDs []string) error
+}
+
 // ProcessImportInput はインポート処理の入力
 type ProcessImportInput struct {
 	ImportJobID uuid.UUID
@@ -49,6 +60,8 @@ type ProcessImportUseCase struct {
 	storageClient      port.StorageClient
 	fieldRepo          FieldRepository
 	clusterJobEnqueuer ClusterJobEnqueuer
+	overlapDetector    FieldOverlapDetector
+	geometryRepairer   port.GeometryRepairer
 	logger             *slog.Logger
 }
 
@@ -58,6 +71,8 @@ func NewProcessImportUseCase(
 	storageClient port.StorageClient,
 	fieldRepo FieldRepository,
 	clusterJobEnqueuer ClusterJobEnqueuer,
+	overlapDetector FieldOverlapDetector,
+	geometryRepairer port.GeometryRepairer,
 	logger *slog.Logger,
 ) *ProcessImportUseCase {
 	return &ProcessImportUseCase{
@@ -65,6 +80,8 @@ func NewProcessImportUseCase(
 		storageClient:      storageClient,
 		fieldRepo:          fieldRepo,
 		clusterJobEnqueuer: clusterJobEnqueuer,
+		overlapDetector:    overlapDetector,
+		geometryRepairer:   geometryRepairer,
 		logger:             logger,
 	}
 }
@@ -125,14 +142,16 @@ func (uc *ProcessImportUseCase) Execute(ctx context.Context, input ProcessImport
 
 		if len(batch) >= input.BatchSize {
 			batchNumber++
-			if err := uc.processBatchWithH3Collection(ctx, batch, affectedH3Cells); err != nil {
+			valid := uc.validateBatch(ctx, input.ImportJobID, batch)
+			failedCount += utils.SafeIntToInt32(len(batch) - len(valid))
+			if err := uc.processBatchWithH3Collection(ctx, valid, affectedH3Cells); err != nil {
 				uc.logger.Error("バッチ処理に失敗", "batch", batchNumber, "error", err)
-				for _, f := range batch {
+				for _, f := range valid {
 					failedIDs = append(failedIDs, f.Properties.ID)
 				}
-				failedCount += utils.SafeIntToInt32(len(batch))
+				failedCount += utils.SafeIntToInt32(len(valid))
 			} else {
-				processedCount += utils.SafeIntToInt32(len(batch))
+				processedCount += utils.SafeIntToInt32(len(valid))
 			}
 
 			// 進捗を更新
@@ -147,14 +166,16 @@ func (uc *ProcessImportUseCase) Execute(ctx context.Context, input ProcessImport
 	// 残りのバッチを処理
 	if len(batch) > 0 {
 		batchNumber++
-		if err := uc.processBatchWithH3Collection(ctx, batch, affectedH3Cells); err != nil {
+		valid := uc.validateBatch(ctx, input.ImportJobID, batch)
+		failedCount += utils.SafeIntToInt32(len(batch) - len(valid))
+		if err := uc.processBatchWithH3Collection(ctx, valid, affectedH3Cells); err != nil {
 			uc.logger.Error("最終バッチ処理に失敗", "batch", batchNumber, "error", err)
-			for _, f := range batch {
+			for _, f := range valid {
 				failedIDs = append(failedIDs, f.Properties.ID)
 			}
-			failedCount += utils.SafeIntToInt32(len(batch))
+			failedCount += utils.SafeIntToInt32(len(valid))
 		} else {
-			processedCount += utils.SafeIntToInt32(len(batch))
+			processedCount += utils.SafeIntToInt32(len(valid))
 		}
 	}
 
@@ -252,8 +273,106 @@ func (uc *ProcessImportUseCase) seekToTargetFeatures(decoder *json.Decoder) erro
 	return apperror.InternalError("targetFeaturesが見つかりません")
 }
 
+// validateBatch はバッチ内の各レコードのジオメトリを検証・修復し、取り込み可能なレコードを返す
+// 修復できないレコードはバッチ全体を失敗させずに除外し、理由をインポートジョブに記録する
+func (uc *ProcessImportUseCase) validateBatch(ctx context.Context, jobID uuid.UUID, batch []entity.WagriFeature) []entity.WagriFeature {
+	valid := make([]entity.WagriFeature, 0, len(batch))
+	var (
+		rejected           []entity.RejectedRecord
+		repairedCount      int
+		pending            []entity.WagriFeature
+		pendingValidations []*entity.GeometryValidation
+		pendingRings       [][][]float64
+	)
+
+	for _, feature := range batch {
+		v := entity.ValidatePolygons(feature.Geometry.Polygons)
+		switch {
+		case v.IsRejected():
+			rejected = append(rejected, entity.NewRejectedRecord(feature.Properties.ID, v))
+		case v.NeedsRepair():
+			feature.Geometry.Polygons = v.Polygons
+			pending = append(pending, feature)
+			pendingValidations = append(pendingValidations, v)
+			for _, i := range v.RepairPolygons {
+				pendingRings = append(pendingRings, v.Polygons[i][0])
+			}
+		default:
+			if v.IsRepaired() {
+				repairedCount++
+			}
+			feature.Geometry.Polygons = v.Polygons
+			valid = append(valid, feature)
+		}
+	}
+
+	// 外周の自己交差はST_MakeValid相当の修復をまとめて行う
+	// 修復結果は区画の外周と同じ順序で返るため、レコードごとに修復対象の区画数だけ読み進める
+	if len(pending) > 0 {
+		repaired, err := uc.repairRings(ctx, pendingRings)
+		if err != nil {
+			// 修復処理を実行できなかったレコードは、修復不能な自己交差と区別して記録する
+			uc.logger.Warn("自己交差したジオメトリの修復処理を実行できません",
+				"import_job_id", jobID,
+				"count", len(pending),
+				"error", err.Error())
+			for _, feature := range pending {
+				v := &entity.GeometryValidation{}
+				v.Reject(entity.GeometryDefectRepairUnavailable, fmt.Sprintf("自己交差の修復処理を実行できませんでした: %s", err.Error()))
+				rejected = append(rejected, entity.NewRejectedRecord(feature.Properties.ID, v))
+			}
+			pending = nil
+		}
+		next := 0
+		for k, feature := range pending {
+			ok := true
+			for _, i := range pendingValidations[k].RepairPolygons {
+				if next >= len(repaired) || repaired[next] == nil {
+					ok = false
+				} else {
+					feature.Geometry.Polygons[i][0] = repaired[next]
+				}
+				next++
+			}
+			if !ok {
+				v := &entity.GeometryValidation{}
+				v.Reject(entity.GeometryDefectSelfIntersection, "自己交差を穴のない単一ポリゴンに修復できません")
+				rejected = append(rejected, entity.NewRejectedRecord(feature.Properties.ID, v))
+				continue
+			}
+			repairedCount++
+			valid = append(valid, feature)
+		}
+	}
+
+	if repairedCount > 0 {
+		uc.logger.Info("ジオメトリの不備を修復しました", "import_job_id", jobID, "repaired", repairedCount)
+	}
+	if len(rejected) > 0 {
+		uc.logger.Warn("ジオメトリの不備によりレコードを拒否しました", "import_job_id", jobID, "rejected", len(rejected))
+		if err := uc.importJobRepo.AppendRejectedRecords(ctx, jobID, rejected); err != nil {
+			uc.logger.Warn("拒否レコードの記録に失敗", "error", err)
+		}
+	}
+
+	return valid
+}
+
+// repairRings は自己交差した外周リングを修復する
+// 修復できないリングはnilになる。修復処理が設定されていない場合や修復処理に失敗した場合はエラーを返す
+func (uc *ProcessImportUseCase) repairRings(ctx context.Context, rings [][][]float64) ([][][]float64, error) {
+	if uc.geometryRepairer == nil {
+		return nil, errGeometryRepairerUnavailable
+	}
+	return uc.geometryRepairer.RepairRings(ctx, rings)
+}
+
 // processBatchWithH3Collection はバッチを処理し、影響を受けたH3セルを収集する
 func (uc *ProcessImportUseCase) processBatchWithH3Collection(ctx context.Context, batch []entity.WagriFeature, affectedH3Cells *dto.H3IndexSet) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
 	// バッチ内のフィールドIDを収集
 	fieldIDs := make([]string, len(batch))
 	for i, feature := range batch {
@@ -293,6 +412,15 @@ func (uc *ProcessImportUseCase) processBatchWithH3Collection(ctx context.Context
 		}
 	}
 
+	// 4. 既存圃場との重なりを検出
+	if uc.overlapDetector != nil {
+		if err := uc.overlapDetector.DetectOverlaps(ctx, fieldIDs); err != nil {
+			uc.logger.Warn("圃場の重なり検出に失敗しました",
+				"error", err.Error())
+			// 重なり検出はインポート結果に影響しないため処理を続行
+		}
+	}
+
 	return nil
 }
 
@@ -311,8 +439,8 @@ func convertWagriFeatureToFieldBatchInput(feature entity.WagriFeature) dto.Field
 		ID:       feature.Properties.ID,
 		CityCode: feature.Properties.CityCode,
 		Geometry: dto.FieldBatchGeometry{
-			Coordinates: feature.Geometry.Coordinates,
-			Type:        feature.Geometry.Type,
+			Polygons: feature.Geometry.Polygons,
+			Type:     feature.Geometry.Type,
 		},
 	}
 
@@ -8,8 +8,10 @@ import (
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/mktkhr/field-manager-api/internal/features/import/application/port"
 	"github.com/mktkhr/field-manager-api/internal/features/import/domain/dto"
 	"github.com/mktkhr/field-manager-api/internal/features/import/domain/entity"
 )
@@ -43,13 +45,19 @@ func (m *mockStorageClient) Exists(ctx context.Context, key string) (bool, error
 	return true, nil
 }
 
+func (m *mockStorageClient) PresignGetURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return "", nil
+}
+
 // mockFieldRepository はFieldRepositoryのモック実装
 type mockFieldRepository struct {
 	err        error
 	h3Prefetch []dto.FieldH3Prefetch
+	upserted   []dto.FieldBatchInput
 }
 
 func (m *mockFieldRepository) UpsertBatch(ctx context.Context, inputs []dto.FieldBatchInput) error {
+	m.upserted = append(m.upserted, inputs...)
 	return m.err
 }
 
@@ -57,6 +65,38 @@ func (m *mockFieldRepository) GetH3IndexesByFieldIDs(ctx context.Context, fieldI
 	return m.h3Prefetch, nil
 }
 
+// mockFieldOverlapDetector はFieldOverlapDetectorのモック実装
+type mockFieldOverlapDetector struct {
+	err         error
+	calledWith  []string
+	calledCount int
+}
+
+func (m *mockFieldOverlapDetector) DetectOverlaps(ctx context.Context, fieldIDs []string) error {
+	m.calledWith = append(m.calledWith, fieldIDs...)
+	m.calledCount++
+	return m.err
+}
+
+// mockGeometryRepairer はGeometryRepairerのモック実装
+// 頂点数が4のリングは矩形に修復し、それ以外は修復できなかったものとして扱う
+type mockGeometryRepairer struct {
+	err error
+}
+
+func (m *mockGeometryRepairer) RepairRings(ctx context.Context, rings [][][]float64) ([][][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	repaired := make([][][]float64, len(rings))
+	for i, ring := range rings {
+		if len(ring) == 5 {
+			repaired[i] = [][]float64{{139.0, 35.0}, {139.1, 35.0}, {139.1, 35.1}, {139.0, 35.1}, {139.0, 35.0}}
+		}
+	}
+	return repaired, nil
+}
+
 // testImportJobRepository はテスト用のImportJobRepositoryモック
 type testImportJobRepository struct {
 	job *entity.ImportJob
@@ -101,6 +141,13 @@ func (r *testImportJobRepository) UpdateTotalRecords(ctx context.Context, id uui
 	return nil
 }
 
+func (r *testImportJobRepository) AppendRejectedRecords(ctx context.Context, id uuid.UUID, records []entity.RejectedRecord) error {
+	if r.job != nil {
+		r.job.RejectedRecords = append(r.job.RejectedRecords, records...)
+	}
+	return nil
+}
+
 func (r *testImportJobRepository) UpdateError(ctx context.Context, id uuid.UUID, message string, failedIDs []string) error {
 	if r.job != nil {
 		r.job.ErrorMessage = &message
@@ -223,6 +270,8 @@ func TestProcessImportUseCase_Execute(t *testing.T) {
 				tt.mockStorage,
 				tt.mockFieldRepo,
 				nil, // clusterJobEnqueuer
+				nil, // overlapDetector
+				nil, // geometryRepairer
 				logger,
 			)
 
@@ -260,6 +309,8 @@ func TestProcessImportUseCase_DefaultBatchSize(t *testing.T) {
 		mockStorage,
 		mockFieldRepo,
 		nil, // clusterJobEnqueuer
+		nil, // overlapDetector
+		nil, // geometryRepairer
 		logger,
 	)
 
@@ -275,3 +326,173 @@ func TestProcessImportUseCase_DefaultBatchSize(t *testing.T) {
 		t.Errorf("Execute() error = %v", err)
 	}
 }
+
+// TestProcessImportUseCase_DetectOverlaps はバッチのUPSERT後に重なり検出が呼ばれ、検出の失敗がインポートを失敗させないことをテストする
+func TestProcessImportUseCase_DetectOverlaps(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	data := `{
+		"targetFeatures": [
+			{
+				"type": "Feature",
+				"geometry": {"type": "LinearPolygon", "coordinates": [[[139.0, 35.0], [139.1, 35.0], [139.05, 35.1]]]},
+				"properties": {"ID": "test-id-001", "CityCode": "163210"}
+			},
+			{
+				"type": "Feature",
+				"geometry": {"type": "LinearPolygon", "coordinates": [[[139.0, 35.0], [139.1, 35.0], [139.05, 35.1]]]},
+				"properties": {"ID": "test-id-002", "CityCode": "163210"}
+			}
+		]
+	}`
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "検出成功", err: nil},
+		{name: "検出失敗でもインポートは成功", err: errors.New("db error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := &mockFieldOverlapDetector{err: tt.err}
+			uc := NewProcessImportUseCase(
+				&testImportJobRepository{job: entity.NewImportJob("163210")},
+				&mockStorageClient{data: []byte(data)},
+				&mockFieldRepository{},
+				nil, // clusterJobEnqueuer
+				detector,
+				nil, // geometryRepairer
+				logger,
+			)
+
+			err := uc.Execute(context.Background(), ProcessImportInput{
+				ImportJobID: uuid.New(),
+				S3Key:       "imports/163210/test.json",
+				BatchSize:   1,
+			})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			// バッチサイズ1のため、バッチごとに1回ずつ呼ばれる
+			if detector.calledCount != 2 {
+				t.Errorf("DetectOverlaps called %d times, want 2", detector.calledCount)
+			}
+			want := []string{"test-id-001", "test-id-002"}
+			if len(detector.calledWith) != len(want) {
+				t.Fatalf("DetectOverlaps calledWith = %v, want %v", detector.calledWith, want)
+			}
+			for i := range want {
+				if detector.calledWith[i] != want[i] {
+					t.Errorf("calledWith[%d] = %s, want %s", i, detector.calledWith[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestProcessImportUseCase_GeometryValidation はジオメトリの不備を修復・拒否し、拒否理由をジョブに記録することをテストする
+func TestProcessImportUseCase_GeometryValidation(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	data := `{
+		"targetFeatures": [
+			{"geometry": {"coordinates": [[[139.0, 35.0], [139.1, 35.0], [139.05, 35.1]]]}, "properties": {"ID": "valid"}},
+			{"geometry": {"coordinates": [[[139.0, 35.0], [139.05, 35.1], [139.1, 35.0]]]}, "properties": {"ID": "clockwise"}},
+			{"geometry": {"coordinates": [[[139.0, 35.0], [139.1, 95.0], [139.05, 35.1]]]}, "properties": {"ID": "out-of-range"}},
+			{"geometry": {"coordinates": [[[139.0, 35.0], [139.1, 35.1], [139.1, 35.0], [139.0, 35.1]]]}, "properties": {"ID": "bowtie"}},
+			{"geometry": {"coordinates": [[[139.0, 35.0], [139.1, 35.0], [139.2, 35.0], [139.1, 35.0], [139.1, 35.1]]]}, "properties": {"ID": "spike"}}
+		]
+	}`
+
+	tests := []struct {
+		name         string
+		repairer     port.GeometryRepairer
+		wantUpserted []string
+		wantRejected map[string]string
+	}{
+		{
+			name:         "自己交差を修復できる",
+			repairer:     &mockGeometryRepairer{},
+			wantUpserted: []string{"valid", "clockwise", "bowtie"},
+			wantRejected: map[string]string{"out-of-range": "out_of_range", "spike": "self_intersection"},
+		},
+		{
+			name:         "修復処理に失敗",
+			repairer:     &mockGeometryRepairer{err: errors.New("db error")},
+			wantUpserted: []string{"valid", "clockwise"},
+			wantRejected: map[string]string{"out-of-range": "out_of_range", "bowtie": "repair_unavailable", "spike": "repair_unavailable"},
+		},
+		{
+			name:         "修復処理が未設定",
+			repairer:     nil,
+			wantUpserted: []string{"valid", "clockwise"},
+			wantRejected: map[string]string{"out-of-range": "out_of_range", "bowtie": "repair_unavailable", "spike": "repair_unavailable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldRepo := &mockFieldRepository{}
+			importRepo := &testImportJobRepository{job: entity.NewImportJob("163210")}
+			uc := NewProcessImportUseCase(
+				importRepo,
+				&mockStorageClient{data: []byte(data)},
+				fieldRepo,
+				nil, // clusterJobEnqueuer
+				nil, // overlapDetector
+				tt.repairer,
+				logger,
+			)
+
+			err := uc.Execute(context.Background(), ProcessImportInput{
+				ImportJobID: uuid.New(),
+				S3Key:       "imports/163210/test.json",
+				BatchSize:   1000,
+			})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			// 拒否したレコードがあってもバッチ全体は失敗しない
+			if len(fieldRepo.upserted) != len(tt.wantUpserted) {
+				t.Fatalf("upserted = %d件, want %d件", len(fieldRepo.upserted), len(tt.wantUpserted))
+			}
+			for i, id := range tt.wantUpserted {
+				if fieldRepo.upserted[i].ID != id {
+					t.Errorf("upserted[%d].ID = %s, want %s", i, fieldRepo.upserted[i].ID, id)
+				}
+			}
+			if importRepo.job.ProcessedRecords != int32(len(tt.wantUpserted)) {
+				t.Errorf("ProcessedRecords = %d, want %d", importRepo.job.ProcessedRecords, len(tt.wantUpserted))
+			}
+			if importRepo.job.FailedRecords != int32(len(tt.wantRejected)) {
+				t.Errorf("FailedRecords = %d, want %d", importRepo.job.FailedRecords, len(tt.wantRejected))
+			}
+
+			if len(importRepo.job.RejectedRecords) != len(tt.wantRejected) {
+				t.Fatalf("RejectedRecords = %v, want %v", importRepo.job.RejectedRecords, tt.wantRejected)
+			}
+			for _, r := range importRepo.job.RejectedRecords {
+				if want := tt.wantRejected[r.ID]; string(r.Defect) != want {
+					t.Errorf("RejectedRecords[%s].Defect = %s, want %s", r.ID, r.Defect, want)
+				}
+				if r.Reason == "" {
+					t.Errorf("RejectedRecords[%s]の理由が空", r.ID)
+				}
+			}
+
+			// 時計回りの外周は反時計回りに修正されて閉じている
+			ring := fieldRepo.upserted[1].Geometry.Polygons[0][0]
+			var area float64
+			for i := 0; i < len(ring)-1; i++ {
+				area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+			}
+			if len(ring) != 4 || area <= 0 {
+				t.Errorf("時計回りの外周が修正されていない: %v", ring)
+			}
+		})
+	}
+}
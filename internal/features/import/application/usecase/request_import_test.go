@@ -63,6 +63,10 @@ func (m *mockImportJobRepository) UpdateError(ctx context.Context, id uuid.UUID,
 	return nil
 }
 
+func (m *mockImportJobRepository) AppendRejectedRecords(ctx context.Context, id uuid.UUID, records []entity.RejectedRecord) error {
+	return nil
+}
+
 // mockStepFunctionsClient はStepFunctionsClientのモック実装
 type mockStepFunctionsClient struct {
 	executionArn string
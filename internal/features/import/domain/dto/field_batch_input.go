@@ -14,10 +14,10 @@ type FieldBatchInput struct {
 
 // FieldBatchGeometry はバッチUPSERT用のジオメトリデータ
 type FieldBatchGeometry struct {
-	// Coordinates は [[[lng, lat], [lng, lat], ...]] 形式の座標
-	// LinearPolygon形式(wagri)またはPolygon形式
-	Coordinates [][][]float64
-	Type        string
+	// Polygons は区画ごとのリング([区画][リング][[lng, lat], ...]])
+	// 各区画の先頭リングが外周、以降が内周(穴)。LinearPolygon形式(wagri)・Polygon形式は1区画になる
+	Polygons [][][][]float64
+	Type     string
 }
 
 // FieldBatchSoilType はバッチUPSERT用の土壌タイプ情報
@@ -51,14 +51,6 @@ func (f *FieldBatchInput) HasPinInfo() bool {
 	return len(f.PinInfoList) > 0
 }
 
-// GetFirstCoordinates はジオメトリの最初の座標配列を取得する
-func (f *FieldBatchInput) GetFirstCoordinates() [][]float64 {
-	if len(f.Geometry.Coordinates) > 0 {
-		return f.Geometry.Coordinates[0]
-	}
-	return nil
-}
-
 // ParseDescriptiveStudyData は DescriptiveStudyDataRaw をパースして time.Time を返す
 func (p *FieldBatchPinInfo) ParseDescriptiveStudyData() *time.Time {
 	if p.DescriptiveStudyData != nil {
@@ -0,0 +1,302 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+)
+
+// GeometryDefect はジオメトリの不備の種類を表す
+type GeometryDefect string
+
+const (
+	// GeometryDefectEmpty は座標が存在しない
+	GeometryDefectEmpty GeometryDefect = "empty"
+	// GeometryDefectMalformedCoordinate は要素数が不足している、または数値でない座標を含む
+	GeometryDefectMalformedCoordinate GeometryDefect = "malformed_coordinate"
+	// GeometryDefectOutOfRange は経度・緯度が範囲外の座標を含む
+	GeometryDefectOutOfRange GeometryDefect = "out_of_range"
+	// GeometryDefectTooFewPoints は重複を除いた頂点が3点未満
+	GeometryDefectTooFewPoints GeometryDefect = "too_few_points"
+	// GeometryDefectZeroArea は全頂点が一直線上にあり面積を持たない
+	GeometryDefectZeroArea GeometryDefect = "zero_area"
+	// GeometryDefectDuplicateVertex は連続する重複頂点を含む(重複を除いて修復)
+	GeometryDefectDuplicateVertex GeometryDefect = "duplicate_vertex"
+	// GeometryDefectWrongWinding はリングの向きが逆(外周は反時計回り、内周は時計回りに並べ替えて修復)
+	GeometryDefectWrongWinding GeometryDefect = "wrong_winding"
+	// GeometryDefectSelfIntersection は辺同士が交差・接触している(外周はST_MakeValid相当の処理で修復)
+	GeometryDefectSelfIntersection GeometryDefect = "self_intersection"
+	// GeometryDefectRepairUnavailable は自己交差の修復処理を実行できなかった
+	// ジオメトリが修復不能とは限らないため、修復処理が復旧した後の再インポートで取り込める可能性がある
+	GeometryDefectRepairUnavailable GeometryDefect = "repair_unavailable"
+)
+
+// collinearTolerance は3点が一直線上にあるとみなす外積の絶対値の上限(平方度)
+// 1e-12平方度は日本付近で約0.01平方メートルに相当する
+const collinearTolerance = 1e-12
+
+// GeometryValidation はリングまたは区画の集合の検証結果
+type GeometryValidation struct {
+	// Ring は修復後の閉じたリング(拒否時・ST_MakeValid相当の修復が必要な場合は入力のまま)
+	Ring [][]float64
+	// Polygons はValidatePolygonsで検証した修復後の区画ごとのリング
+	Polygons [][][][]float64
+	// RepairPolygons は外周の自己交差の修復(ST_MakeValid相当)が必要な区画のインデックス
+	RepairPolygons []int
+	// Defects は検出した不備(修復済みのものを含む)
+	Defects []GeometryDefect
+	// RejectDefect は取り込みを拒否する原因となった不備(空の場合は取り込み可能)
+	RejectDefect GeometryDefect
+	// RejectReason は取り込みを拒否する理由
+	RejectReason string
+}
+
+// IsRejected は取り込みを拒否するかどうかを判定する
+func (v *GeometryValidation) IsRejected() bool {
+	return v.RejectDefect != ""
+}
+
+// NeedsRepair は自己交差の修復(ST_MakeValid相当)が必要かどうかを判定する
+func (v *GeometryValidation) NeedsRepair() bool {
+	return !v.IsRejected() && v.HasDefect(GeometryDefectSelfIntersection)
+}
+
+// HasDefect は指定の不備を検出したかどうかを判定する
+func (v *GeometryValidation) HasDefect(defect GeometryDefect) bool {
+	for _, d := range v.Defects {
+		if d == defect {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRepaired は修復済みの不備があるかどうかを判定する
+func (v *GeometryValidation) IsRepaired() bool {
+	return !v.IsRejected() && len(v.Defects) > 0
+}
+
+// Reject は指定の不備を理由に取り込みを拒否する
+func (v *GeometryValidation) Reject(defect GeometryDefect, reason string) {
+	if !v.HasDefect(defect) {
+		v.Defects = append(v.Defects, defect)
+	}
+	v.RejectDefect = defect
+	v.RejectReason = reason
+}
+
+// ValidatePolygons は全区画の外周・内周リングを検証し、重複頂点の除去と向きの修正を行う
+// いずれかのリングを拒否した場合は区画全体を拒否する
+// 外周の自己交差はRepairPolygonsで呼び出し側に修復を委ね、内周の自己交差は修復できないため拒否する
+func ValidatePolygons(polygons [][][][]float64) *GeometryValidation {
+	v := &GeometryValidation{Polygons: polygons}
+
+	if len(polygons) == 0 {
+		v.Reject(GeometryDefectEmpty, "座標が存在しません")
+		return v
+	}
+
+	validated := make([][][][]float64, len(polygons))
+	for i, rings := range polygons {
+		if len(rings) == 0 {
+			v.Reject(GeometryDefectEmpty, fmt.Sprintf("区画%dの座標が存在しません", i+1))
+			return v
+		}
+
+		validated[i] = make([][][]float64, len(rings))
+		for j, ring := range rings {
+			hole := j > 0
+			rv := validateRing(ring, hole)
+			label := fmt.Sprintf("区画%dの外周", i+1)
+			if hole {
+				label = fmt.Sprintf("区画%dの内周%d", i+1, j)
+			}
+
+			if rv.IsRejected() {
+				v.Reject(rv.RejectDefect, fmt.Sprintf("%s: %s", label, rv.RejectReason))
+				return v
+			}
+			for _, d := range rv.Defects {
+				if !v.HasDefect(d) {
+					v.Defects = append(v.Defects, d)
+				}
+			}
+			if rv.HasDefect(GeometryDefectSelfIntersection) {
+				if hole {
+					v.Reject(GeometryDefectSelfIntersection, fmt.Sprintf("%s: 内周の自己交差は修復できません", label))
+					return v
+				}
+				v.RepairPolygons = append(v.RepairPolygons, i)
+			}
+			validated[i][j] = rv.Ring
+		}
+	}
+
+	v.Polygons = validated
+	return v
+}
+
+// ValidateRing は外周リングの座標を検証し、重複頂点の除去と向きの修正を行う
+// 自己交差はこの関数では修復せず、NeedsRepairで呼び出し側に修復を委ねる
+func ValidateRing(coordinates [][]float64) *GeometryValidation {
+	return validateRing(coordinates, false)
+}
+
+// validateRing はリングの座標を検証し、外周は反時計回り、内周(hole)は時計回りに向きを揃える
+func validateRing(coordinates [][]float64, hole bool) *GeometryValidation {
+	v := &GeometryValidation{Ring: coordinates}
+
+	if len(coordinates) == 0 {
+		v.Reject(GeometryDefectEmpty, "座標が存在しません")
+		return v
+	}
+
+	for i, coord := range coordinates {
+		if len(coord) < 2 || !isFinite(coord[0]) || !isFinite(coord[1]) {
+			v.Reject(GeometryDefectMalformedCoordinate, fmt.Sprintf("%d番目の座標が不正です: %v", i+1, coord))
+			return v
+		}
+		if coord[0] < -180 || coord[0] > 180 || coord[1] < -90 || coord[1] > 90 {
+			v.Reject(GeometryDefectOutOfRange, fmt.Sprintf("%d番目の座標が経度・緯度の範囲外です: [%v, %v]", i+1, coord[0], coord[1]))
+			return v
+		}
+	}
+
+	// 連続する重複頂点と閉じるための終点を除いた頂点列を作成
+	ring := make([][]float64, 0, len(coordinates))
+	duplicated := false
+	for _, coord := range coordinates {
+		point := []float64{coord[0], coord[1]}
+		if len(ring) > 0 && pointsEqual(ring[len(ring)-1], point) {
+			duplicated = true
+			continue
+		}
+		ring = append(ring, point)
+	}
+	if len(ring) > 1 && pointsEqual(ring[0], ring[len(ring)-1]) {
+		ring = ring[:len(ring)-1]
+	}
+	if duplicated {
+		v.Defects = append(v.Defects, GeometryDefectDuplicateVertex)
+	}
+
+	if len(ring) < 3 {
+		v.Reject(GeometryDefectTooFewPoints, fmt.Sprintf("ポリゴンには重複を除いて最低3点が必要です(現在: %d点)", len(ring)))
+		return v
+	}
+
+	if isCollinear(ring) {
+		v.Reject(GeometryDefectZeroArea, "全ての頂点が一直線上にあり面積がありません")
+		return v
+	}
+
+	if hasSelfIntersection(ring) {
+		v.Defects = append(v.Defects, GeometryDefectSelfIntersection)
+		v.Ring = closeRing(ring)
+		return v
+	}
+
+	if (signedArea(ring) < 0) != hole {
+		for i, j := 0, len(ring)-1; i < j; i, j = i+1, j-1 {
+			ring[i], ring[j] = ring[j], ring[i]
+		}
+		v.Defects = append(v.Defects, GeometryDefectWrongWinding)
+	}
+
+	v.Ring = closeRing(ring)
+	return v
+}
+
+// isFinite は値が有限の数値かどうかを判定する
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// pointsEqual は2つの頂点が等しいかどうかを判定する
+func pointsEqual(a, b []float64) bool {
+	return a[0] == b[0] && a[1] == b[1]
+}
+
+// closeRing は始点を末尾に追加して閉じたリングを返す
+func closeRing(ring [][]float64) [][]float64 {
+	return append(ring, []float64{ring[0][0], ring[0][1]})
+}
+
+// isCollinear は全ての頂点が始点と2番目の頂点を通る直線上にあるかを判定する
+func isCollinear(ring [][]float64) bool {
+	for i := 2; i < len(ring); i++ {
+		if math.Abs(orientation(ring[0], ring[1], ring[i])) >= collinearTolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// signedArea は閉じていない頂点列の符号付き面積(平方度)を計算する
+// 反時計回りの場合に正、時計回りの場合に負になる
+func signedArea(ring [][]float64) float64 {
+	var sum float64
+	for i := range ring {
+		j := (i + 1) % len(ring)
+		sum += ring[i][0]*ring[j][1] - ring[j][0]*ring[i][1]
+	}
+	return sum / 2
+}
+
+// hasSelfIntersection は閉じていない頂点列の辺同士が交差・接触しているかを判定する
+// 隣接する辺は共有する頂点以外で重なる場合(折り返し)のみ交差とみなす
+func hasSelfIntersection(ring [][]float64) bool {
+	n := len(ring)
+	for i := 0; i < n; i++ {
+		a1, a2 := ring[i], ring[(i+1)%n]
+		for j := i + 1; j < n; j++ {
+			b1, b2 := ring[j], ring[(j+1)%n]
+			if j == i+1 || (i == 0 && j == n-1) {
+				// 隣接する辺: 共有頂点で折り返していないかを確認
+				shared, other, prev := a2, b2, a1
+				if i == 0 && j == n-1 {
+					shared, other, prev = a1, b1, a2
+				}
+				if orientation(prev, shared, other) == 0 && dot(prev, shared, other) > 0 {
+					return true
+				}
+				continue
+			}
+			if segmentsIntersect(a1, a2, b1, b2) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// orientation は3点の並びの向きを返す(反時計回りで正、時計回りで負、一直線上で0)
+func orientation(p, q, r []float64) float64 {
+	return (q[0]-p[0])*(r[1]-p[1]) - (q[1]-p[1])*(r[0]-p[0])
+}
+
+// dot は頂点qから見たpとrの方向ベクトルの内積を返す(正の場合は同じ向きに折り返している)
+func dot(p, q, r []float64) float64 {
+	return (p[0]-q[0])*(r[0]-q[0]) + (p[1]-q[1])*(r[1]-q[1])
+}
+
+// onSegment は一直線上にある点rが線分pqの範囲内にあるかを判定する
+func onSegment(p, q, r []float64) bool {
+	return math.Min(p[0], q[0]) <= r[0] && r[0] <= math.Max(p[0], q[0]) &&
+		math.Min(p[1], q[1]) <= r[1] && r[1] <= math.Max(p[1], q[1])
+}
+
+// segmentsIntersect は線分p1p2と線分q1q2が交差・接触しているかを判定する
+func segmentsIntersect(p1, p2, q1, q2 []float64) bool {
+	d1 := orientation(q1, q2, p1)
+	d2 := orientation(q1, q2, p2)
+	d3 := orientation(p1, p2, q1)
+	d4 := orientation(p1, p2, q2)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(q1, q2, p1)) ||
+		(d2 == 0 && onSegment(q1, q2, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, q1)) ||
+		(d4 == 0 && onSegment(p1, p2, q2))
+}
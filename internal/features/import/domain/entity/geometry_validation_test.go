@@ -0,0 +1,195 @@
+package entity
+
+import (
+	"math"
+	"testing"
+)
+
+// TestValidateRing は外周リングの不備の分類と修復結果をテストする
+func TestValidateRing(t *testing.T) {
+	tests := []struct {
+		name         string
+		coordinates  [][]float64
+		wantReject   GeometryDefect
+		wantDefects  []GeometryDefect
+		wantRepair   bool
+		wantRingSize int
+	}{
+		{
+			name:         "正常な反時計回りの三角形",
+			coordinates:  [][]float64{{139.0, 35.0}, {139.1, 35.0}, {139.05, 35.1}},
+			wantRingSize: 4,
+		},
+		{
+			name:         "閉じた正常な四角形",
+			coordinates:  [][]float64{{139.0, 35.0}, {139.1, 35.0}, {139.1, 35.1}, {139.0, 35.1}, {139.0, 35.0}},
+			wantRingSize: 5,
+		},
+		{
+			name:        "座標なし",
+			coordinates: nil,
+			wantReject:  GeometryDefectEmpty,
+		},
+		{
+			name:        "要素数が不足した座標",
+			coordinates: [][]float64{{139.0, 35.0}, {139.1}, {139.05, 35.1}},
+			wantReject:  GeometryDefectMalformedCoordinate,
+		},
+		{
+			name:        "NaNを含む座標",
+			coordinates: [][]float64{{139.0, 35.0}, {math.NaN(), 35.0}, {139.05, 35.1}},
+			wantReject:  GeometryDefectMalformedCoordinate,
+		},
+		{
+			name:        "緯度が範囲外",
+			coordinates: [][]float64{{139.0, 35.0}, {139.1, 95.0}, {139.05, 35.1}},
+			wantReject:  GeometryDefectOutOfRange,
+		},
+		{
+			name:        "緯度経度が逆転",
+			coordinates: [][]float64{{35.0, 139.0}, {35.0, 139.1}, {35.1, 139.05}},
+			wantReject:  GeometryDefectOutOfRange,
+		},
+		{
+			name:        "重複を除くと2点",
+			coordinates: [][]float64{{139.0, 35.0}, {139.1, 35.0}, {139.1, 35.0}, {139.0, 35.0}},
+			wantReject:  GeometryDefectTooFewPoints,
+		},
+		{
+			name:        "一直線上の頂点",
+			coordinates: [][]float64{{139.0, 35.0}, {139.1, 35.0}, {139.2, 35.0}},
+			wantReject:  GeometryDefectZeroArea,
+		},
+		{
+			name:         "連続する重複頂点",
+			coordinates:  [][]float64{{139.0, 35.0}, {139.1, 35.0}, {139.1, 35.0}, {139.05, 35.1}},
+			wantDefects:  []GeometryDefect{GeometryDefectDuplicateVertex},
+			wantRingSize: 4,
+		},
+		{
+			name:         "時計回り",
+			coordinates:  [][]float64{{139.0, 35.0}, {139.05, 35.1}, {139.1, 35.0}},
+			wantDefects:  []GeometryDefect{GeometryDefectWrongWinding},
+			wantRingSize: 4,
+		},
+		{
+			name:         "蝶ネクタイ型の自己交差",
+			coordinates:  [][]float64{{139.0, 35.0}, {139.1, 35.1}, {139.1, 35.0}, {139.0, 35.1}},
+			wantDefects:  []GeometryDefect{GeometryDefectSelfIntersection},
+			wantRepair:   true,
+			wantRingSize: 5,
+		},
+		{
+			name:         "折り返し(スパイク)",
+			coordinates:  [][]float64{{139.0, 35.0}, {139.1, 35.0}, {139.2, 35.0}, {139.1, 35.0}, {139.1, 35.1}},
+			wantDefects:  []GeometryDefect{GeometryDefectSelfIntersection},
+			wantRepair:   true,
+			wantRingSize: 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := ValidateRing(tt.coordinates)
+
+			if tt.wantReject != "" {
+				if !v.IsRejected() || v.RejectDefect != tt.wantReject {
+					t.Fatalf("RejectDefect = %q, want %q", v.RejectDefect, tt.wantReject)
+				}
+				if v.RejectReason == "" {
+					t.Error("拒否理由が設定されていない")
+				}
+				return
+			}
+			if v.IsRejected() {
+				t.Fatalf("予期しない拒否: %s (%s)", v.RejectDefect, v.RejectReason)
+			}
+			if v.NeedsRepair() != tt.wantRepair {
+				t.Errorf("NeedsRepair() = %v, want %v", v.NeedsRepair(), tt.wantRepair)
+			}
+			for _, d := range tt.wantDefects {
+				if !v.HasDefect(d) {
+					t.Errorf("不備%qが検出されていない: %v", d, v.Defects)
+				}
+			}
+			if len(tt.wantDefects) == 0 && len(v.Defects) > 0 {
+				t.Errorf("予期しない不備: %v", v.Defects)
+			}
+			if len(v.Ring) != tt.wantRingSize {
+				t.Errorf("len(Ring) = %d, want %d", len(v.Ring), tt.wantRingSize)
+			}
+			first, last := v.Ring[0], v.Ring[len(v.Ring)-1]
+			if first[0] != last[0] || first[1] != last[1] {
+				t.Errorf("リングが閉じていない: %v", v.Ring)
+			}
+			if !tt.wantRepair && signedArea(v.Ring[:len(v.Ring)-1]) <= 0 {
+				t.Errorf("外周が反時計回りになっていない: %v", v.Ring)
+			}
+		})
+	}
+}
+
+// TestValidatePolygons は全区画の外周・内周リングの検証と向きの修正、修復対象の区画の記録をテストする
+func TestValidatePolygons(t *testing.T) {
+	outer := [][]float64{{139.0, 35.0}, {139.4, 35.0}, {139.4, 35.4}, {139.0, 35.4}}
+	bowtie := [][]float64{{139.5, 35.0}, {139.6, 35.1}, {139.6, 35.0}, {139.5, 35.1}}
+
+	t.Run("反時計回りの内周は時計回りに修正する", func(t *testing.T) {
+		hole := [][]float64{{139.1, 35.1}, {139.2, 35.1}, {139.2, 35.2}, {139.1, 35.2}}
+		v := ValidatePolygons([][][][]float64{{outer, hole}})
+
+		if v.IsRejected() {
+			t.Fatalf("予期しない拒否: %s (%s)", v.RejectDefect, v.RejectReason)
+		}
+		if !v.HasDefect(GeometryDefectWrongWinding) {
+			t.Errorf("内周の向きの不備が検出されていない: %v", v.Defects)
+		}
+		if signedArea(v.Polygons[0][0][:4]) <= 0 {
+			t.Errorf("外周が反時計回りになっていない: %v", v.Polygons[0][0])
+		}
+		if signedArea(v.Polygons[0][1][:4]) >= 0 {
+			t.Errorf("内周が時計回りになっていない: %v", v.Polygons[0][1])
+		}
+	})
+
+	t.Run("外周が自己交差した区画のみ修復対象にする", func(t *testing.T) {
+		v := ValidatePolygons([][][][]float64{{outer}, {bowtie}})
+
+		if !v.NeedsRepair() {
+			t.Fatalf("NeedsRepair() = false, want true")
+		}
+		if len(v.RepairPolygons) != 1 || v.RepairPolygons[0] != 1 {
+			t.Errorf("RepairPolygons = %v, want [1]", v.RepairPolygons)
+		}
+		if len(v.Polygons) != 2 {
+			t.Errorf("len(Polygons) = %d, want 2", len(v.Polygons))
+		}
+	})
+
+	t.Run("内周の自己交差は拒否する", func(t *testing.T) {
+		v := ValidatePolygons([][][][]float64{{outer, bowtie}})
+
+		if v.RejectDefect != GeometryDefectSelfIntersection {
+			t.Fatalf("RejectDefect = %q, want %q", v.RejectDefect, GeometryDefectSelfIntersection)
+		}
+	})
+
+	t.Run("いずれかの区画が不正なら全体を拒否する", func(t *testing.T) {
+		v := ValidatePolygons([][][][]float64{{outer}, {{{139.5, 35.0}, {139.6, 35.0}}}})
+
+		if v.RejectDefect != GeometryDefectTooFewPoints {
+			t.Fatalf("RejectDefect = %q, want %q", v.RejectDefect, GeometryDefectTooFewPoints)
+		}
+		if v.RejectReason == "" {
+			t.Error("拒否理由が設定されていない")
+		}
+	})
+
+	t.Run("座標なし", func(t *testing.T) {
+		v := ValidatePolygons(nil)
+
+		if v.RejectDefect != GeometryDefectEmpty {
+			t.Fatalf("RejectDefect = %q, want %q", v.RejectDefect, GeometryDefectEmpty)
+		}
+	})
+}
@@ -32,6 +32,22 @@ func (s ImportStatus) String() string {
 	return string(s)
 }
 
+// RejectedRecord はジオメトリ検証で取り込みを拒否したレコード
+type RejectedRecord struct {
+	ID     string         `json:"id"`
+	Defect GeometryDefect `json:"defect"`
+	Reason string         `json:"reason"`
+}
+
+// NewRejectedRecord は検証結果から拒否レコードを作成する
+func NewRejectedRecord(id string, v *GeometryValidation) RejectedRecord {
+	return RejectedRecord{
+		ID:     id,
+		Defect: v.RejectDefect,
+		Reason: v.RejectReason,
+	}
+}
+
 // ImportJob はインポートジョブエンティティ
 type ImportJob struct {
 	ID                 uuid.UUID
@@ -45,6 +61,7 @@ type ImportJob struct {
 	ExecutionArn       *string
 	ErrorMessage       *string
 	FailedRecordIDs    []string
+	RejectedRecords    []RejectedRecord
 	CreatedAt          time.Time
 	StartedAt          *time.Time
 	CompletedAt        *time.Time
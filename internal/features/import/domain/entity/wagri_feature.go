@@ -1,7 +1,9 @@
 package entity
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -19,8 +21,65 @@ type WagriFeature struct {
 
 // WagriGeometry はwagri APIのジオメトリを表す
 type WagriGeometry struct {
-	Coordinates [][][]float64 `json:"coordinates"`
-	Type        string        `json:"type"` // LinearPolygon
+	// Polygons は区画ごとのリング([区画][リング][頂点][経度, 緯度])
+	// 各区画の先頭リングが外周、以降が内周(池や建物などの除外部分)
+	// LinearPolygon・Polygonは1区画、MultiPolygonは複数区画として保持する
+	Polygons [][][][]float64 `json:"-"`
+	Type     string          `json:"type"` // LinearPolygon, Polygon, MultiPolygon
+}
+
+// UnmarshalJSON は座標の階層の深さから単一ポリゴンとマルチポリゴンを判別してパースする
+// typeの値は提供元によって揺れがあるため、判別には使用しない
+func (g *WagriGeometry) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Coordinates json.RawMessage `json:"coordinates"`
+		Type        string          `json:"type"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	g.Type = raw.Type
+	g.Polygons = nil
+
+	depth, empty := coordinatesDepth(raw.Coordinates)
+	switch {
+	case empty:
+		return nil
+	case depth == 3:
+		var rings [][][]float64
+		if err := json.Unmarshal(raw.Coordinates, &rings); err != nil {
+			return err
+		}
+		g.Polygons = [][][][]float64{rings}
+	case depth == 4:
+		if err := json.Unmarshal(raw.Coordinates, &g.Polygons); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("ジオメトリの座標の階層が不正です(type: %s, 階層: %d)", raw.Type, depth)
+	}
+	return nil
+}
+
+// coordinatesDepth は座標の配列の入れ子の深さを返す
+// 先頭の要素が空配列またはnullの場合はemptyがtrueになる
+func coordinatesDepth(data json.RawMessage) (depth int, empty bool) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return 0, true
+	}
+	for _, b := range trimmed {
+		switch b {
+		case '[':
+			depth++
+		case ' ', '\t', '\n', '\r':
+		case ']':
+			return depth, true
+		default:
+			return depth, false
+		}
+	}
+	return depth, false
 }
 
 // WagriProperties はwagri APIのプロパティを表す
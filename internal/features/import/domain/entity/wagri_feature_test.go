@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -80,6 +81,78 @@ func TestParseWagriResponse(t *testing.T) {
 	}
 }
 
+// TestWagriGeometryUnmarshalJSON は座標の階層から単一ポリゴンとマルチポリゴンを判別し、内周リングを保持することをテストする
+func TestWagriGeometryUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		wantPolygons int
+		wantRings    []int
+		wantErr      bool
+	}{
+		{
+			name:         "LinearPolygon",
+			data:         `{"type": "LinearPolygon", "coordinates": [[[139.0, 35.0], [139.1, 35.0], [139.05, 35.1]]]}`,
+			wantPolygons: 1,
+			wantRings:    []int{1},
+		},
+		{
+			name: "穴あきのPolygon",
+			data: `{"type": "Polygon", "coordinates": [
+				[[139.0, 35.0], [139.4, 35.0], [139.4, 35.4], [139.0, 35.4]],
+				[[139.1, 35.1], [139.1, 35.2], [139.2, 35.2], [139.2, 35.1]]
+			]}`,
+			wantPolygons: 1,
+			wantRings:    []int{2},
+		},
+		{
+			name: "MultiPolygon",
+			data: `{"type": "MultiPolygon", "coordinates": [
+				[[[139.0, 35.0], [139.4, 35.0], [139.4, 35.4]], [[139.1, 35.1], [139.2, 35.1], [139.2, 35.2]]],
+				[[[139.5, 35.0], [139.6, 35.0], [139.6, 35.1]]]
+			]}`,
+			wantPolygons: 2,
+			wantRings:    []int{2, 1},
+		},
+		{
+			name:         "空の座標",
+			data:         `{"type": "LinearPolygon", "coordinates": []}`,
+			wantPolygons: 0,
+		},
+		{
+			name:    "階層が不正な座標",
+			data:    `{"type": "LinearPolygon", "coordinates": [[139.0, 35.0], [139.1, 35.0]]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var g WagriGeometry
+			err := json.Unmarshal([]byte(tt.data), &g)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("UnmarshalJSON()でエラーを期待したがnilが返された")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnmarshalJSON()でエラー発生 = %v", err)
+			}
+
+			if len(g.Polygons) != tt.wantPolygons {
+				t.Fatalf("len(Polygons) = %d, 期待値 %d", len(g.Polygons), tt.wantPolygons)
+			}
+			for i, want := range tt.wantRings {
+				if len(g.Polygons[i]) != want {
+					t.Errorf("区画%dのリング数 = %d, 期待値 %d", i+1, len(g.Polygons[i]), want)
+				}
+			}
+		})
+	}
+}
+
 // TestWagriPropertiesHasSoilType はHasSoilTypeメソッドがSoilSmallCodeの有無を正しく判定することをテストする
 func TestWagriPropertiesHasSoilType(t *testing.T) {
 	tests := []struct {
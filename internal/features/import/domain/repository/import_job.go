@@ -32,4 +32,7 @@ type ImportJobRepository interface {
 
 	// UpdateError はエラー情報を更新する
 	UpdateError(ctx context.Context, id uuid.UUID, message string, failedIDs []string) error
+
+	// AppendRejectedRecords はジオメトリ検証で拒否したレコードを追記する
+	AppendRejectedRecords(ctx context.Context, id uuid.UUID, records []entity.RejectedRecord) error
 }
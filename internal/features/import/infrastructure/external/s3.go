@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -22,10 +24,16 @@ type s3API interface {
 	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
 }
 
+// s3PresignAPI は署名付きURL発行のインターフェース
+type s3PresignAPI interface {
+	PresignGetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+}
+
 // s3Client はStorageClientの実装
 type s3Client struct {
-	api    s3API
-	bucket string
+	api       s3API
+	presigner s3PresignAPI
+	bucket    string
 }
 
 // NewS3Client は新しいS3Clientを作成する(AWSConfig使用)
@@ -62,8 +70,9 @@ func NewS3Client(ctx context.Context, cfg *appConfig.AWSConfig) (port.StorageCli
 	})
 
 	return &s3Client{
-		api:    client,
-		bucket: cfg.S3Bucket,
+		api:       client,
+		presigner: s3.NewPresignClient(client),
+		bucket:    cfg.S3Bucket,
 	}, nil
 }
 
@@ -71,6 +80,31 @@ func NewS3Client(ctx context.Context, cfg *appConfig.AWSConfig) (port.StorageCli
 // S3Enabled=true: AWS S3に接続
 // S3Enabled=false: RustFS/MinIOに接続
 func NewS3ClientFromStorageConfig(ctx context.Context, cfg *appConfig.StorageConfig) (port.StorageClient, error) {
+	client, err := newS3FromStorageConfig(ctx, cfg, cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// 署名付きURLはホスト名を含めて署名されるため、RustFS/MinIOの場合は
+	// クライアントから到達可能な公開エンドポイントで署名する
+	presignClient := client
+	if !cfg.S3Enabled && cfg.GetPublicEndpoint() != cfg.Endpoint {
+		presignClient, err = newS3FromStorageConfig(ctx, cfg, cfg.GetPublicEndpoint())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &s3Client{
+		api:       client,
+		presigner: s3.NewPresignClient(presignClient),
+		bucket:    cfg.Bucket,
+	}, nil
+}
+
+// newS3FromStorageConfig はStorageConfigからS3 APIクライアントを作成する
+// endpointはS3Enabled=falseの場合のみ使用する
+func newS3FromStorageConfig(ctx context.Context, cfg *appConfig.StorageConfig, endpoint string) (*s3.Client, error) {
 	opts := []func(*config.LoadOptions) error{
 		config.WithRegion(cfg.Region),
 	}
@@ -89,7 +123,7 @@ func NewS3ClientFromStorageConfig(ctx context.Context, cfg *appConfig.StorageCon
 			aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
 				//nolint:staticcheck
 				return aws.Endpoint{
-					URL:               cfg.Endpoint,
+					URL:               endpoint,
 					HostnameImmutable: true,
 				}, nil
 			}),
@@ -101,17 +135,12 @@ func NewS3ClientFromStorageConfig(ctx context.Context, cfg *appConfig.StorageCon
 		return nil, err
 	}
 
-	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+	return s3.NewFromConfig(awsCfg, func(o *s3.Options) {
 		// RustFS/MinIOの場合はパス形式URLを使用
 		if !cfg.S3Enabled {
 			o.UsePathStyle = cfg.UsePathStyle
 		}
-	})
-
-	return &s3Client{
-		api:    client,
-		bucket: cfg.Bucket,
-	}, nil
+	}), nil
 }
 
 // Upload はデータをS3にアップロードする
@@ -142,6 +171,18 @@ func (c *s3Client) GetObjectStream(ctx context.Context, key string) (io.ReadClos
 	return c.Download(ctx, key)
 }
 
+// PresignGetURL はオブジェクトを取得するための署名付きURLを発行する
+func (c *s3Client) PresignGetURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	req, err := c.presigner.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", err
+	}
+	return req.URL, nil
+}
+
 // Delete はS3からオブジェクトを削除する
 func (c *s3Client) Delete(ctx context.Context, key string) error {
 	_, err := c.api.DeleteObject(ctx, &s3.DeleteObjectInput{
@@ -5,8 +5,12 @@ import (
 	"context"
 	"errors"
 	"io"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go/middleware"
@@ -476,3 +480,33 @@ func TestS3Client_ExistsValidatesParams(t *testing.T) {
 		t.Errorf("Key = %q, want %q", *capturedParams.Key, "path/to/file.txt")
 	}
 }
+
+// TestS3Client_PresignGetURL はPresignGetURLメソッドが有効期間付きの署名付きURLを返すことをテストする
+func TestS3Client_PresignGetURL(t *testing.T) {
+	api := s3.New(s3.Options{
+		Region:       "ap-northeast-1",
+		Credentials:  credentials.NewStaticCredentialsProvider("access", "secret", ""),
+		BaseEndpoint: aws.String("https://storage.example.com"),
+		UsePathStyle: true,
+	})
+	client := &s3Client{
+		api:       api,
+		presigner: s3.NewPresignClient(api),
+		bucket:    "my-bucket",
+	}
+
+	url, err := client.PresignGetURL(context.Background(), "exports/file.csv", 15*time.Minute)
+	if err != nil {
+		t.Fatalf("PresignGetURL() error = %v", err)
+	}
+
+	if !strings.HasPrefix(url, "https://storage.example.com/my-bucket/exports/file.csv?") {
+		t.Errorf("URL = %q, want prefix %q", url, "https://storage.example.com/my-bucket/exports/file.csv?")
+	}
+	if !strings.Contains(url, "X-Amz-Expires=900") {
+		t.Errorf("URL = %q, want X-Amz-Expires=900", url)
+	}
+	if !strings.Contains(url, "X-Amz-Signature=") {
+		t.Errorf("URL = %q, want X-Amz-Signature", url)
+	}
+}
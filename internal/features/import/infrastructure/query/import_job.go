@@ -115,5 +115,12 @@ func (q *importJobQuery) toEntity(row *sqlc.ImportJob) *entity.ImportJob {
 		}
 	}
 
+	if len(row.RejectedRecords) > 0 {
+		var records []entity.RejectedRecord
+		if err := json.Unmarshal(row.RejectedRecords, &records); err == nil {
+			job.RejectedRecords = records
+		}
+	}
+
 	return job
 }
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mktkhr/field-manager-api/internal/features/import/application/port"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/wkb"
+)
+
+// geometryRepairer はPostGISのST_MakeValidを使用したGeometryRepairerの実装
+type geometryRepairer struct {
+	queries *sqlc.Queries
+}
+
+// NewGeometryRepairer は新しいGeometryRepairerを作成する
+func NewGeometryRepairer(db *pgxpool.Pool) port.GeometryRepairer {
+	return &geometryRepairer{
+		queries: sqlc.New(db),
+	}
+}
+
+// RepairRings は外周リングをST_MakeValidで修復し、外周が反時計回りの閉じたリングを返す
+// 穴のない単一ポリゴンに修復できなかったリングはnilになる
+func (r *geometryRepairer) RepairRings(ctx context.Context, rings [][][]float64) ([][][]float64, error) {
+	if len(rings) == 0 {
+		return nil, nil
+	}
+
+	wkbs, err := ringsToWKB(rings)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.queries.RepairPolygons(ctx, wkbs)
+	if err != nil {
+		return nil, fmt.Errorf("ジオメトリ修復失敗: %w", err)
+	}
+
+	repaired := make([][][]float64, len(rings))
+	for _, row := range rows {
+		idx := int(row.Ord) - 1
+		if idx < 0 || idx >= len(rings) {
+			continue
+		}
+		ring, err := wkbToRing(row.GeometryWkb)
+		if err != nil {
+			return nil, err
+		}
+		repaired[idx] = ring
+	}
+	return repaired, nil
+}
+
+// ringsToWKB は外周リングを1つずつPolygonのWKB形式に変換する
+// 閉じていないリングは始点を末尾に追加して閉じる
+func ringsToWKB(rings [][][]float64) ([][]byte, error) {
+	wkbs := make([][]byte, len(rings))
+	for i, ring := range rings {
+		coords := make([]geom.Coord, 0, len(ring)+1)
+		for _, c := range ring {
+			coords = append(coords, geom.Coord{c[0], c[1]})
+		}
+		if len(coords) > 0 && !coords[0].Equal(geom.XY, coords[len(coords)-1]) {
+			coords = append(coords, coords[0])
+		}
+
+		polygon, err := geom.NewPolygon(geom.XY).SetCoords([][]geom.Coord{coords})
+		if err != nil {
+			return nil, fmt.Errorf("%d番目のリングのポリゴン変換失敗: %w", i+1, err)
+		}
+		data, err := wkb.Marshal(polygon, binary.LittleEndian)
+		if err != nil {
+			return nil, fmt.Errorf("%d番目のリングのWKB変換失敗: %w", i+1, err)
+		}
+		wkbs[i] = data
+	}
+	return wkbs, nil
+}
+
+// wkbToRing はPolygonのWKBから外周リングの座標を取り出す
+func wkbToRing(data []byte) ([][]float64, error) {
+	g, err := wkb.Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("修復結果のWKBデコード失敗: %w", err)
+	}
+	polygon, ok := g.(*geom.Polygon)
+	if !ok || polygon.NumLinearRings() == 0 {
+		return nil, fmt.Errorf("修復結果がPolygonではありません: %T", g)
+	}
+
+	coords := polygon.LinearRing(0).Coords()
+	ring := make([][]float64, len(coords))
+	for i, c := range coords {
+		ring[i] = []float64{c.X(), c.Y()}
+	}
+	return ring, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"testing"
+)
+
+// TestRingsToWKB_RoundTrip は閉じていないリングを閉じてWKBに変換し、元の座標に戻せることをテストする
+func TestRingsToWKB_RoundTrip(t *testing.T) {
+	rings := [][][]float64{
+		{{139.0, 35.0}, {139.1, 35.0}, {139.1, 35.1}, {139.0, 35.1}},
+		{{139.0, 35.0}, {139.1, 35.0}, {139.05, 35.1}, {139.0, 35.0}},
+	}
+
+	wkbs, err := ringsToWKB(rings)
+	if err != nil {
+		t.Fatalf("ringsToWKB() error = %v", err)
+	}
+	if len(wkbs) != len(rings) {
+		t.Fatalf("len(wkbs) = %d, want %d", len(wkbs), len(rings))
+	}
+
+	ring, err := wkbToRing(wkbs[0])
+	if err != nil {
+		t.Fatalf("wkbToRing() error = %v", err)
+	}
+	if len(ring) != 5 {
+		t.Fatalf("len(ring) = %d, want 5 (閉じたリング)", len(ring))
+	}
+	if ring[0][0] != ring[4][0] || ring[0][1] != ring[4][1] {
+		t.Errorf("リングが閉じていない: %v", ring)
+	}
+
+	ring, err = wkbToRing(wkbs[1])
+	if err != nil {
+		t.Fatalf("wkbToRing() error = %v", err)
+	}
+	if len(ring) != 4 {
+		t.Errorf("閉じたリングに終点が重複して追加されている: %v", ring)
+	}
+}
+
+// TestWkbToRing_Invalid は不正なWKBでエラーを返すことをテストする
+func TestWkbToRing_Invalid(t *testing.T) {
+	if _, err := wkbToRing([]byte{0x01, 0x02}); err == nil {
+		t.Error("wkbToRing() expected error, got nil")
+	}
+}
@@ -120,6 +120,24 @@ func (r *importJobRepository) UpdateError(ctx context.Context, id uuid.UUID, mes
 	return err
 }
 
+// AppendRejectedRecords はジオメトリ検証で拒否したレコードを追記する
+func (r *importJobRepository) AppendRejectedRecords(ctx context.Context, id uuid.UUID, records []entity.RejectedRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	data, err := jsonMarshal(records)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.queries.AppendImportJobRejectedRecords(ctx, &sqlc.AppendImportJobRejectedRecordsParams{
+		RejectedRecords: data,
+		ID:              id,
+	})
+	return err
+}
+
 // toEntity はSQLCモデルをエンティティに変換する
 func (r *importJobRepository) toEntity(row *sqlc.ImportJob) *entity.ImportJob {
 	if row == nil {
@@ -161,5 +179,16 @@ func (r *importJobRepository) toEntity(row *sqlc.ImportJob) *entity.ImportJob {
 		}
 	}
 
+	if len(row.RejectedRecords) > 0 {
+		var records []entity.RejectedRecord
+		if err := json.Unmarshal(row.RejectedRecords, &records); err != nil {
+			r.logger.Warn("拒否レコードのパースに失敗",
+				slog.String("job_id", row.ID.String()),
+				slog.String("error", err.Error()))
+		} else {
+			job.RejectedRecords = records
+		}
+	}
+
 	return job
 }
@@ -584,3 +584,51 @@ func TestImportJobRepository_UpdateError_MarshalError(t *testing.T) {
 		t.Errorf("UpdateError() error = %v, want %v", err, marshalError)
 	}
 }
+
+// TestImportJobRepository_ToEntity_RejectedRecords はtoEntityメソッドが拒否レコードのJSONを変換することをテストする
+func TestImportJobRepository_ToEntity_RejectedRecords(t *testing.T) {
+	row := &sqlc.ImportJob{
+		ID:              uuid.New(),
+		CityCode:        "163210",
+		Status:          "partially_completed",
+		RejectedRecords: []byte(`[{"id":"id1","defect":"out_of_range","reason":"1番目の座標が経度・緯度の範囲外です"}]`),
+	}
+
+	r := &importJobRepository{logger: slog.Default()}
+	result := r.toEntity(row)
+
+	if len(result.RejectedRecords) != 1 {
+		t.Fatalf("len(RejectedRecords) = %d, want 1", len(result.RejectedRecords))
+	}
+	got := result.RejectedRecords[0]
+	if got.ID != "id1" || got.Defect != entity.GeometryDefectOutOfRange {
+		t.Errorf("RejectedRecords[0] = %+v, want id1/out_of_range", got)
+	}
+}
+
+// TestImportJobRepository_AppendRejectedRecords_Empty は拒否レコードが無い場合にDBへアクセスしないことをテストする
+func TestImportJobRepository_AppendRejectedRecords_Empty(t *testing.T) {
+	repo := &importJobRepository{logger: slog.Default()}
+
+	if err := repo.AppendRejectedRecords(context.Background(), uuid.New(), nil); err != nil {
+		t.Errorf("AppendRejectedRecords() error = %v, want nil", err)
+	}
+}
+
+// TestImportJobRepository_AppendRejectedRecords_MarshalError はAppendRejectedRecordsメソッドがJSONマーシャルエラーを返すことをテストする
+func TestImportJobRepository_AppendRejectedRecords_MarshalError(t *testing.T) {
+	originalMarshal := jsonMarshal
+	defer func() { jsonMarshal = originalMarshal }()
+
+	marshalError := errors.New("mock marshal error")
+	jsonMarshal = func(v interface{}) ([]byte, error) {
+		return nil, marshalError
+	}
+
+	repo := &importJobRepository{logger: slog.Default()}
+
+	err := repo.AppendRejectedRecords(context.Background(), uuid.New(), []entity.RejectedRecord{{ID: "id1"}})
+	if !errors.Is(err, marshalError) {
+		t.Errorf("AppendRejectedRecords() error = %v, want %v", err, marshalError)
+	}
+}
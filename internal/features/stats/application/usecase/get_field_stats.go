@@ -0,0 +1,84 @@
+// Package usecase は統計機能のユースケースを提供する
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/stats/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/stats/domain/repository"
+)
+
+// GetFieldStatsInput は圃場統計取得ユースケースの入力
+type GetFieldStatsInput struct {
+	Scope entity.Scope // 集計単位(市区町村/都道府県)
+	Code  string       // 市区町村コードまたは都道府県コード
+}
+
+// GetFieldStatsUseCase は圃場統計取得ユースケース
+type GetFieldStatsUseCase struct {
+	statsRepo repository.FieldStatsRepository
+	cacheRepo repository.FieldStatsCacheRepository
+	logger    *slog.Logger
+}
+
+// NewGetFieldStatsUseCase はGetFieldStatsUseCaseを作成する
+func NewGetFieldStatsUseCase(
+	statsRepo repository.FieldStatsRepository,
+	cacheRepo repository.FieldStatsCacheRepository,
+	logger *slog.Logger,
+) *GetFieldStatsUseCase {
+	return &GetFieldStatsUseCase{
+		statsRepo: statsRepo,
+		cacheRepo: cacheRepo,
+		logger:    logger,
+	}
+}
+
+// Execute は市区町村または都道府県の圃場統計を取得する
+// キャッシュにない場合は集計済みの統計を取得してキャッシュに保存する
+func (u *GetFieldStatsUseCase) Execute(ctx context.Context, input GetFieldStatsInput) (*entity.FieldStats, error) {
+	if !input.Scope.IsValidCode(input.Code) {
+		if input.Scope == entity.ScopePrefecture {
+			return nil, apperror.BadRequestError("都道府県コードは01から47の2桁の数字で指定してください")
+		}
+		return nil, apperror.BadRequestError("市区町村コードは6桁の数字で指定してください")
+	}
+
+	cached, err := u.cacheRepo.Get(ctx, input.Scope, input.Code)
+	if err != nil {
+		// キャッシュエラーはログに残して続行
+		u.logger.Warn("圃場統計のキャッシュからの取得に失敗しました",
+			slog.String("scope", string(input.Scope)),
+			slog.String("code", input.Code),
+			slog.String("error", err.Error()))
+	}
+	if cached != nil {
+		return cached, nil
+	}
+
+	stats, err := u.find(ctx, input)
+	if err != nil {
+		return nil, apperror.InternalErrorWithCause("圃場統計の取得に失敗しました", err)
+	}
+	if stats == nil {
+		return nil, apperror.NotFoundError("圃場統計が見つかりません")
+	}
+
+	if err := u.cacheRepo.Set(ctx, stats); err != nil {
+		u.logger.Warn("圃場統計のキャッシュへの保存に失敗しました",
+			slog.String("scope", string(input.Scope)),
+			slog.String("code", input.Code),
+			slog.String("error", err.Error()))
+	}
+	return stats, nil
+}
+
+// find は集計単位に応じて圃場統計を取得する
+func (u *GetFieldStatsUseCase) find(ctx context.Context, input GetFieldStatsInput) (*entity.FieldStats, error) {
+	if input.Scope == entity.ScopePrefecture {
+		return u.statsRepo.FindByPrefectureCode(ctx, input.Code)
+	}
+	return u.statsRepo.FindByCityCode(ctx, input.Code)
+}
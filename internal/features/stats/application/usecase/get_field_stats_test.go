@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/stats/domain/entity"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFieldStatsRepository はFieldStatsRepositoryのモック実装
+type mockFieldStatsRepository struct {
+	stats      *entity.FieldStats
+	findErr    error
+	refreshErr error
+
+	gotCityCode       string // FindByCityCodeに渡された市区町村コード
+	gotPrefectureCode string // FindByPrefectureCodeに渡された都道府県コード
+	refreshCalls      int    // Refreshの呼び出し回数
+}
+
+func (m *mockFieldStatsRepository) FindByCityCode(_ context.Context, cityCode string) (*entity.FieldStats, error) {
+	m.gotCityCode = cityCode
+	return m.stats, m.findErr
+}
+
+func (m *mockFieldStatsRepository) FindByPrefectureCode(_ context.Context, prefectureCode string) (*entity.FieldStats, error) {
+	m.gotPrefectureCode = prefectureCode
+	return m.stats, m.findErr
+}
+
+func (m *mockFieldStatsRepository) Refresh(_ context.Context) error {
+	m.refreshCalls++
+	return m.refreshErr
+}
+
+// mockFieldStatsCacheRepository はFieldStatsCacheRepositoryのモック実装
+type mockFieldStatsCacheRepository struct {
+	cached    *entity.FieldStats
+	getErr    error
+	setErr    error
+	deleteErr error
+
+	saved       *entity.FieldStats // Setに渡された統計
+	deleteCalls int                // DeleteAllの呼び出し回数
+}
+
+func (m *mockFieldStatsCacheRepository) Get(_ context.Context, _ entity.Scope, _ string) (*entity.FieldStats, error) {
+	return m.cached, m.getErr
+}
+
+func (m *mockFieldStatsCacheRepository) Set(_ context.Context, stats *entity.FieldStats) error {
+	m.saved = stats
+	return m.setErr
+}
+
+func (m *mockFieldStatsCacheRepository) DeleteAll(_ context.Context) error {
+	m.deleteCalls++
+	return m.deleteErr
+}
+
+func getTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func errorStatus(err error) int {
+	var appErr apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.HTTPStatus()
+	}
+	return 0
+}
+
+func TestGetFieldStatsUseCase_Execute_City(t *testing.T) {
+	stats := &entity.FieldStats{Scope: entity.ScopeCity, Code: "163210", FieldCount: 3}
+	repo := &mockFieldStatsRepository{stats: stats}
+	cacheRepo := &mockFieldStatsCacheRepository{}
+	uc := NewGetFieldStatsUseCase(repo, cacheRepo, getTestLogger())
+
+	got, err := uc.Execute(context.Background(), GetFieldStatsInput{Scope: entity.ScopeCity, Code: "163210"})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, stats, got, "統計が一致しない")
+	require.Equal(t, "163210", repo.gotCityCode, "市区町村コードで取得するべき")
+	require.Empty(t, repo.gotPrefectureCode, "都道府県の統計は取得しないべき")
+	require.Equal(t, stats, cacheRepo.saved, "取得した統計をキャッシュに保存するべき")
+}
+
+func TestGetFieldStatsUseCase_Execute_Prefecture(t *testing.T) {
+	stats := &entity.FieldStats{Scope: entity.ScopePrefecture, Code: "16", FieldCount: 10}
+	repo := &mockFieldStatsRepository{stats: stats}
+	uc := NewGetFieldStatsUseCase(repo, &mockFieldStatsCacheRepository{}, getTestLogger())
+
+	got, err := uc.Execute(context.Background(), GetFieldStatsInput{Scope: entity.ScopePrefecture, Code: "16"})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, stats, got, "統計が一致しない")
+	require.Equal(t, "16", repo.gotPrefectureCode, "都道府県コードで取得するべき")
+	require.Empty(t, repo.gotCityCode, "市区町村の統計は取得しないべき")
+}
+
+func TestGetFieldStatsUseCase_Execute_CacheHit(t *testing.T) {
+	cached := &entity.FieldStats{Scope: entity.ScopeCity, Code: "163210", FieldCount: 5}
+	repo := &mockFieldStatsRepository{}
+	cacheRepo := &mockFieldStatsCacheRepository{cached: cached}
+	uc := NewGetFieldStatsUseCase(repo, cacheRepo, getTestLogger())
+
+	got, err := uc.Execute(context.Background(), GetFieldStatsInput{Scope: entity.ScopeCity, Code: "163210"})
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, cached, got, "キャッシュの統計を返すべき")
+	require.Empty(t, repo.gotCityCode, "キャッシュヒット時はリポジトリから取得しないべき")
+}
+
+func TestGetFieldStatsUseCase_Execute_CacheError(t *testing.T) {
+	stats := &entity.FieldStats{Scope: entity.ScopeCity, Code: "163210"}
+	repo := &mockFieldStatsRepository{stats: stats}
+	cacheRepo := &mockFieldStatsCacheRepository{
+		getErr: errors.New("redis error"),
+		setErr: errors.New("redis error"),
+	}
+	uc := NewGetFieldStatsUseCase(repo, cacheRepo, getTestLogger())
+
+	got, err := uc.Execute(context.Background(), GetFieldStatsInput{Scope: entity.ScopeCity, Code: "163210"})
+
+	require.NoError(t, err, "キャッシュエラーは無視して続行するべき")
+	require.Equal(t, stats, got, "リポジトリの統計を返すべき")
+}
+
+func TestGetFieldStatsUseCase_Execute_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      GetFieldStatsInput
+		repo       *mockFieldStatsRepository
+		wantStatus int
+	}{
+		{
+			name:       "市区町村コードが6桁でない",
+			input:      GetFieldStatsInput{Scope: entity.ScopeCity, Code: "16321"},
+			repo:       &mockFieldStatsRepository{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "都道府県コードが範囲外",
+			input:      GetFieldStatsInput{Scope: entity.ScopePrefecture, Code: "48"},
+			repo:       &mockFieldStatsRepository{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "統計が存在しない",
+			input:      GetFieldStatsInput{Scope: entity.ScopeCity, Code: "163210"},
+			repo:       &mockFieldStatsRepository{},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "取得エラー",
+			input:      GetFieldStatsInput{Scope: entity.ScopePrefecture, Code: "16"},
+			repo:       &mockFieldStatsRepository{findErr: errors.New("db error")},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacheRepo := &mockFieldStatsCacheRepository{}
+			uc := NewGetFieldStatsUseCase(tt.repo, cacheRepo, getTestLogger())
+
+			_, err := uc.Execute(context.Background(), tt.input)
+
+			require.Error(t, err, "エラーを期待")
+			require.Equal(t, tt.wantStatus, errorStatus(err), "HTTPステータスが期待値と異なります")
+			require.Nil(t, cacheRepo.saved, "エラー時はキャッシュに保存しないべき")
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mktkhr/field-manager-api/internal/features/stats/domain/repository"
+)
+
+// RefreshFieldStatsUseCase は圃場統計の再集計ユースケース
+type RefreshFieldStatsUseCase struct {
+	statsRepo repository.FieldStatsRepository
+	cacheRepo repository.FieldStatsCacheRepository
+	logger    *slog.Logger
+}
+
+// NewRefreshFieldStatsUseCase はRefreshFieldStatsUseCaseを作成する
+func NewRefreshFieldStatsUseCase(
+	statsRepo repository.FieldStatsRepository,
+	cacheRepo repository.FieldStatsCacheRepository,
+	logger *slog.Logger,
+) *RefreshFieldStatsUseCase {
+	return &RefreshFieldStatsUseCase{
+		statsRepo: statsRepo,
+		cacheRepo: cacheRepo,
+		logger:    logger,
+	}
+}
+
+// Execute は全ての市区町村の圃場統計を再集計し、キャッシュを削除する
+func (u *RefreshFieldStatsUseCase) Execute(ctx context.Context) error {
+	startedAt := time.Now()
+	if err := u.statsRepo.Refresh(ctx); err != nil {
+		return fmt.Errorf("圃場統計の再集計に失敗しました: %w", err)
+	}
+
+	// 削除に失敗したキャッシュはTTLで失効する
+	if err := u.cacheRepo.DeleteAll(ctx); err != nil {
+		u.logger.Warn("圃場統計のキャッシュの削除に失敗しました",
+			slog.String("error", err.Error()))
+	}
+
+	u.logger.Info("圃場統計を再集計しました",
+		slog.Duration("duration", time.Since(startedAt)))
+	return nil
+}
+
+// FieldStatsRefresherAdapter はcluster機能から使用するアダプタ
+// cluster機能のConsumer側で定義されるFieldStatsRefresherインターフェースに対応
+type FieldStatsRefresherAdapter struct {
+	usecase *RefreshFieldStatsUseCase
+}
+
+// NewFieldStatsRefresher はFieldStatsRefresherAdapterを作成する
+func NewFieldStatsRefresher(usecase *RefreshFieldStatsUseCase) *FieldStatsRefresherAdapter {
+	return &FieldStatsRefresherAdapter{
+		usecase: usecase,
+	}
+}
+
+// Refresh は圃場統計を再集計する
+func (a *FieldStatsRefresherAdapter) Refresh(ctx context.Context) error {
+	return a.usecase.Execute(ctx)
+}
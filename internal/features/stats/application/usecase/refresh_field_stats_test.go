@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshFieldStatsUseCase_Execute(t *testing.T) {
+	repo := &mockFieldStatsRepository{}
+	cacheRepo := &mockFieldStatsCacheRepository{}
+	uc := NewRefreshFieldStatsUseCase(repo, cacheRepo, getTestLogger())
+
+	err := uc.Execute(context.Background())
+
+	require.NoError(t, err, "Executeでエラーが発生")
+	require.Equal(t, 1, repo.refreshCalls, "統計を再集計するべき")
+	require.Equal(t, 1, cacheRepo.deleteCalls, "再集計後にキャッシュを削除するべき")
+}
+
+func TestRefreshFieldStatsUseCase_Execute_RefreshError(t *testing.T) {
+	repo := &mockFieldStatsRepository{refreshErr: errors.New("db error")}
+	cacheRepo := &mockFieldStatsCacheRepository{}
+	uc := NewRefreshFieldStatsUseCase(repo, cacheRepo, getTestLogger())
+
+	err := uc.Execute(context.Background())
+
+	require.Error(t, err, "再集計エラーを返すべき")
+	require.Zero(t, cacheRepo.deleteCalls, "再集計に失敗した場合はキャッシュを削除しないべき")
+}
+
+func TestRefreshFieldStatsUseCase_Execute_CacheError(t *testing.T) {
+	repo := &mockFieldStatsRepository{}
+	cacheRepo := &mockFieldStatsCacheRepository{deleteErr: errors.New("redis error")}
+	uc := NewRefreshFieldStatsUseCase(repo, cacheRepo, getTestLogger())
+
+	err := uc.Execute(context.Background())
+
+	require.NoError(t, err, "キャッシュの削除エラーは無視するべき")
+}
+
+func TestFieldStatsRefresherAdapter_Refresh(t *testing.T) {
+	repo := &mockFieldStatsRepository{}
+	adapter := NewFieldStatsRefresher(NewRefreshFieldStatsUseCase(repo, &mockFieldStatsCacheRepository{}, getTestLogger()))
+
+	err := adapter.Refresh(context.Background())
+
+	require.NoError(t, err, "Refreshでエラーが発生")
+	require.Equal(t, 1, repo.refreshCalls, "ユースケースを実行するべき")
+}
@@ -0,0 +1,76 @@
+// Package entity は統計機能のドメインエンティティを定義する
+package entity
+
+import (
+	"regexp"
+	"time"
+)
+
+// Scope は統計の集計単位
+type Scope string
+
+const (
+	// ScopeCity は市区町村単位の統計
+	ScopeCity Scope = "city"
+
+	// ScopePrefecture は都道府県単位の統計
+	ScopePrefecture Scope = "prefecture"
+)
+
+var (
+	// cityCodePattern は市区町村コード(検査数字付きの全国地方公共団体コード)の形式
+	cityCodePattern = regexp.MustCompile(`^[0-9]{6}$`)
+
+	// prefectureCodePattern は都道府県コード(01-47)の形式
+	prefectureCodePattern = regexp.MustCompile(`^(0[1-9]|[1-3][0-9]|4[0-7])$`)
+)
+
+// IsValidCode は集計単位に応じたコードの形式かどうかを判定する
+func (s Scope) IsValidCode(code string) bool {
+	switch s {
+	case ScopeCity:
+		return cityCodePattern.MatchString(code)
+	case ScopePrefecture:
+		return prefectureCodePattern.MatchString(code)
+	default:
+		return false
+	}
+}
+
+// PrefectureCode は市区町村コードから都道府県コード(上2桁)を返す
+func PrefectureCode(cityCode string) string {
+	if len(cityCode) < 2 {
+		return ""
+	}
+	return cityCode[:2]
+}
+
+// FieldStats は市区町村または都道府県ごとの圃場統計
+type FieldStats struct {
+	Scope          Scope
+	Code           string  // 市区町村コードまたは都道府県コード
+	FieldCount     int32   // 圃場数
+	TotalAreaSqm   float64 // 合計面積(平方メートル)
+	PaddyAreaSqm   float64 // 主な土地種別が田の圃場の合計面積
+	UplandAreaSqm  float64 // 主な土地種別が畑の圃場の合計面積
+	IdleFieldCount int32   // 遊休農地状況が登録された圃場数
+	IdleAreaSqm    float64 // 遊休農地状況が登録された圃場の合計面積
+	SoilTypes      []*SoilTypeStats
+	RefreshedAt    time.Time // 集計日時(都道府県の場合は市区町村のうち最も古い集計日時)
+}
+
+// SoilTypeStats は土壌大分類ごとの圃場統計
+type SoilTypeStats struct {
+	LargeCode    string  // 土壌大分類コード
+	FieldCount   int32   // 圃場数
+	TotalAreaSqm float64 // 合計面積(平方メートル)
+}
+
+// IdleLandRatio は合計面積に占める遊休農地の面積の割合(0-1)を返す
+// 合計面積が0の場合は0を返す
+func (s *FieldStats) IdleLandRatio() float64 {
+	if s.TotalAreaSqm <= 0 {
+		return 0
+	}
+	return s.IdleAreaSqm / s.TotalAreaSqm
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestScope_IsValidCode は集計単位ごとにコードの形式が判定されることをテストする
+func TestScope_IsValidCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope Scope
+		code  string
+		want  bool
+	}{
+		{"市区町村コード", ScopeCity, "163210", true},
+		{"検査数字のない市区町村コード", ScopeCity, "16321", false},
+		{"数字以外を含む市区町村コード", ScopeCity, "16321a", false},
+		{"都道府県コード", ScopePrefecture, "16", true},
+		{"北海道", ScopePrefecture, "01", true},
+		{"沖縄県", ScopePrefecture, "47", true},
+		{"存在しない都道府県コード", ScopePrefecture, "48", false},
+		{"00は都道府県コードではない", ScopePrefecture, "00", false},
+		{"1桁の都道府県コード", ScopePrefecture, "1", false},
+		{"未知の集計単位", Scope("region"), "16", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.scope.IsValidCode(tt.code), "コード%sの判定が一致しない", tt.code)
+		})
+	}
+}
+
+// TestPrefectureCode は市区町村コードの上2桁が都道府県コードになることをテストする
+func TestPrefectureCode(t *testing.T) {
+	require.Equal(t, "16", PrefectureCode("163210"), "上2桁が都道府県コードになるべき")
+	require.Empty(t, PrefectureCode("1"), "2桁未満の場合は空文字列を返すべき")
+}
+
+// TestFieldStats_IdleLandRatio は遊休農地の面積の割合をテストする
+func TestFieldStats_IdleLandRatio(t *testing.T) {
+	stats := &FieldStats{TotalAreaSqm: 2000, IdleAreaSqm: 500}
+	require.InDelta(t, 0.25, stats.IdleLandRatio(), 1e-9, "遊休農地の割合が一致しない")
+
+	empty := &FieldStats{}
+	require.Zero(t, empty.IdleLandRatio(), "合計面積が0の場合は0を返すべき")
+}
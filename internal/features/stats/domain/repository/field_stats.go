@@ -0,0 +1,24 @@
+// Package repository は統計機能のリポジトリインターフェースを定義する
+package repository
+
+import (
+	"context"
+
+	"github.com/mktkhr/field-manager-api/internal/features/stats/domain/entity"
+)
+
+// FieldStatsRepository は圃場統計のリポジトリインターフェース
+//
+// 統計は集計済みの要約テーブルから取得し、Refreshで圃場の現在の状態から再集計する
+type FieldStatsRepository interface {
+	// FindByCityCode は市区町村の圃場統計を取得する
+	// 圃場がない市区町村の場合はnilを返す
+	FindByCityCode(ctx context.Context, cityCode string) (*entity.FieldStats, error)
+
+	// FindByPrefectureCode は都道府県内の市区町村の圃場統計を合算して取得する
+	// 圃場がない都道府県の場合はnilを返す
+	FindByPrefectureCode(ctx context.Context, prefectureCode string) (*entity.FieldStats, error)
+
+	// Refresh は全ての市区町村の圃場統計を再集計する
+	Refresh(ctx context.Context) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mktkhr/field-manager-api/internal/features/stats/domain/entity"
+)
+
+// FieldStatsCacheRepository は圃場統計のキャッシュリポジトリインターフェース
+type FieldStatsCacheRepository interface {
+	// Get はキャッシュから集計単位・コードの圃場統計を取得する
+	// キャッシュミスの場合はnilを返す
+	Get(ctx context.Context, scope entity.Scope, code string) (*entity.FieldStats, error)
+
+	// Set は圃場統計をキャッシュに保存する
+	Set(ctx context.Context, stats *entity.FieldStats) error
+
+	// DeleteAll は全ての圃場統計をキャッシュから削除する
+	DeleteAll(ctx context.Context) error
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mktkhr/field-manager-api/internal/features/stats/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/stats/domain/repository"
+	"github.com/mktkhr/field-manager-api/internal/infrastructure/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// fieldStatsCacheKeyPrefix は圃場統計のキャッシュのキープレフィックス
+	fieldStatsCacheKeyPrefix = "stats:fields:"
+
+	// fieldStatsCacheTTL は圃場統計のキャッシュのTTL
+	// 統計の再集計時に削除するため、再集計されない間は長く保持する
+	fieldStatsCacheTTL = 6 * time.Hour
+)
+
+// fieldStatsCacheData はキャッシュに保存する圃場統計
+type fieldStatsCacheData struct {
+	FieldCount     int32                    `json:"field_count"`
+	TotalAreaSqm   float64                  `json:"total_area_sqm"`
+	PaddyAreaSqm   float64                  `json:"paddy_area_sqm"`
+	UplandAreaSqm  float64                  `json:"upland_area_sqm"`
+	IdleFieldCount int32                    `json:"idle_field_count"`
+	IdleAreaSqm    float64                  `json:"idle_area_sqm"`
+	SoilTypes      []soilTypeStatsCacheData `json:"soil_types"`
+	RefreshedAt    int64                    `json:"refreshed_at"` // Unix timestamp
+}
+
+// soilTypeStatsCacheData はキャッシュに保存する土壌大分類ごとの圃場統計
+type soilTypeStatsCacheData struct {
+	LargeCode    string  `json:"large_code"`
+	FieldCount   int32   `json:"field_count"`
+	TotalAreaSqm float64 `json:"total_area_sqm"`
+}
+
+// fieldStatsCacheRedisRepository はFieldStatsCacheRepositoryのRedis実装
+type fieldStatsCacheRedisRepository struct {
+	client *cache.Client
+	logger *slog.Logger
+}
+
+// NewFieldStatsCacheRedisRepository はFieldStatsCacheRepositoryのRedis実装を作成する
+func NewFieldStatsCacheRedisRepository(client *cache.Client, logger *slog.Logger) repository.FieldStatsCacheRepository {
+	return &fieldStatsCacheRedisRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// buildFieldStatsCacheKey は集計単位・コードごとのキャッシュキーを構築する
+func buildFieldStatsCacheKey(scope entity.Scope, code string) string {
+	return fmt.Sprintf("%s%s:%s", fieldStatsCacheKeyPrefix, scope, code)
+}
+
+// Get はキャッシュから集計単位・コードの圃場統計を取得する
+// 不正なデータの場合はキャッシュミスとして扱う
+func (r *fieldStatsCacheRedisRepository) Get(ctx context.Context, scope entity.Scope, code string) (*entity.FieldStats, error) {
+	data, err := r.client.Get(ctx, buildFieldStatsCacheKey(scope, code))
+	if err != nil {
+		if err == redis.Nil {
+			// キャッシュミス
+			return nil, nil
+		}
+		return nil, fmt.Errorf("キャッシュからの取得に失敗しました: %w", err)
+	}
+
+	var item fieldStatsCacheData
+	if err := json.Unmarshal([]byte(data), &item); err != nil {
+		return nil, nil
+	}
+
+	soilTypes := make([]*entity.SoilTypeStats, 0, len(item.SoilTypes))
+	for _, soil := range item.SoilTypes {
+		soilTypes = append(soilTypes, &entity.SoilTypeStats{
+			LargeCode:    soil.LargeCode,
+			FieldCount:   soil.FieldCount,
+			TotalAreaSqm: soil.TotalAreaSqm,
+		})
+	}
+
+	return &entity.FieldStats{
+		Scope:          scope,
+		Code:           code,
+		FieldCount:     item.FieldCount,
+		TotalAreaSqm:   item.TotalAreaSqm,
+		PaddyAreaSqm:   item.PaddyAreaSqm,
+		UplandAreaSqm:  item.UplandAreaSqm,
+		IdleFieldCount: item.IdleFieldCount,
+		IdleAreaSqm:    item.IdleAreaSqm,
+		SoilTypes:      soilTypes,
+		RefreshedAt:    time.Unix(item.RefreshedAt, 0),
+	}, nil
+}
+
+// Set は圃場統計をキャッシュに保存する
+func (r *fieldStatsCacheRedisRepository) Set(ctx context.Context, stats *entity.FieldStats) error {
+	soilTypes := make([]soilTypeStatsCacheData, 0, len(stats.SoilTypes))
+	for _, soil := range stats.SoilTypes {
+		soilTypes = append(soilTypes, soilTypeStatsCacheData{
+			LargeCode:    soil.LargeCode,
+			FieldCount:   soil.FieldCount,
+			TotalAreaSqm: soil.TotalAreaSqm,
+		})
+	}
+
+	data, err := json.Marshal(fieldStatsCacheData{
+		FieldCount:     stats.FieldCount,
+		TotalAreaSqm:   stats.TotalAreaSqm,
+		PaddyAreaSqm:   stats.PaddyAreaSqm,
+		UplandAreaSqm:  stats.UplandAreaSqm,
+		IdleFieldCount: stats.IdleFieldCount,
+		IdleAreaSqm:    stats.IdleAreaSqm,
+		SoilTypes:      soilTypes,
+		RefreshedAt:    stats.RefreshedAt.Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("キャッシュデータのシリアライズに失敗しました: %w", err)
+	}
+
+	if err := r.client.Set(ctx, buildFieldStatsCacheKey(stats.Scope, stats.Code), string(data), fieldStatsCacheTTL); err != nil {
+		return fmt.Errorf("キャッシュへの保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// DeleteAll は全ての圃場統計をキャッシュから削除する
+func (r *fieldStatsCacheRedisRepository) DeleteAll(ctx context.Context) error {
+	if err := r.client.DeleteByPattern(ctx, fieldStatsCacheKeyPrefix+"*"); err != nil {
+		return fmt.Errorf("キャッシュの削除に失敗しました: %w", err)
+	}
+	return nil
+}
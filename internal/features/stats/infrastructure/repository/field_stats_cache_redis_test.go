@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/mktkhr/field-manager-api/internal/features/stats/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/infrastructure/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+// TestBuildFieldStatsCacheKey はbuildFieldStatsCacheKeyが集計単位・コードごとのキーを生成することをテストする
+func TestBuildFieldStatsCacheKey(t *testing.T) {
+	if got := buildFieldStatsCacheKey(entity.ScopeCity, "163210"); got != "stats:fields:city:163210" {
+		t.Errorf("市区町村のキー = %q, 期待値 %q", got, "stats:fields:city:163210")
+	}
+	if got := buildFieldStatsCacheKey(entity.ScopePrefecture, "16"); got != "stats:fields:prefecture:16" {
+		t.Errorf("都道府県のキー = %q, 期待値 %q", got, "stats:fields:prefecture:16")
+	}
+}
+
+// TestFieldStatsCacheRedisRepository は保存した圃場統計を取得・削除できることをテストする
+func TestFieldStatsCacheRedisRepository(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis.Run()が失敗しました: %v", err)
+	}
+	defer mr.Close()
+	client := cache.NewClient(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	defer func() {
+		if err := client.Close(); err != nil {
+			t.Errorf("Close()でエラー発生 = %v", err)
+		}
+	}()
+	repo := NewFieldStatsCacheRedisRepository(client, slog.Default())
+	ctx := context.Background()
+
+	// キャッシュミス
+	got, err := repo.Get(ctx, entity.ScopeCity, "163210")
+	if err != nil {
+		t.Fatalf("Get()でエラー発生 = %v", err)
+	}
+	if got != nil {
+		t.Errorf("キャッシュミスの場合はnilを返すべき: %+v", got)
+	}
+
+	refreshedAt := time.Unix(1700000000, 0)
+	stats := &entity.FieldStats{
+		Scope:          entity.ScopeCity,
+		Code:           "163210",
+		FieldCount:     10,
+		TotalAreaSqm:   5000,
+		PaddyAreaSqm:   3000,
+		UplandAreaSqm:  1500,
+		IdleFieldCount: 2,
+		IdleAreaSqm:    800,
+		SoilTypes: []*entity.SoilTypeStats{
+			{LargeCode: "F3", FieldCount: 6, TotalAreaSqm: 3500},
+			{LargeCode: "B1", FieldCount: 4, TotalAreaSqm: 1500},
+		},
+		RefreshedAt: refreshedAt,
+	}
+	if err := repo.Set(ctx, stats); err != nil {
+		t.Fatalf("Set()でエラー発生 = %v", err)
+	}
+
+	got, err = repo.Get(ctx, entity.ScopeCity, "163210")
+	if err != nil {
+		t.Fatalf("Get()でエラー発生 = %v", err)
+	}
+	if got == nil || got.FieldCount != 10 || got.PaddyAreaSqm != 3000 || got.IdleAreaSqm != 800 || !got.RefreshedAt.Equal(refreshedAt) {
+		t.Fatalf("圃場統計が期待値と異なります: %+v", got)
+	}
+	if len(got.SoilTypes) != 2 || got.SoilTypes[0].LargeCode != "F3" || got.SoilTypes[1].TotalAreaSqm != 1500 {
+		t.Errorf("土壌別の圃場統計が期待値と異なります: %+v", got.SoilTypes)
+	}
+
+	// 別の集計単位のキャッシュは取得しない
+	got, err = repo.Get(ctx, entity.ScopePrefecture, "163210")
+	if err != nil {
+		t.Fatalf("Get()でエラー発生 = %v", err)
+	}
+	if got != nil {
+		t.Errorf("別の集計単位のキャッシュが取得されています: %+v", got)
+	}
+
+	// 不正なデータはキャッシュミスとして扱う
+	if err := mr.Set(buildFieldStatsCacheKey(entity.ScopePrefecture, "16"), "invalid"); err != nil {
+		t.Fatalf("不正なデータの設定に失敗しました: %v", err)
+	}
+	got, err = repo.Get(ctx, entity.ScopePrefecture, "16")
+	if err != nil {
+		t.Fatalf("Get()でエラー発生 = %v", err)
+	}
+	if got != nil {
+		t.Errorf("不正なデータはキャッシュミスとして扱うべき: %+v", got)
+	}
+
+	if err := repo.DeleteAll(ctx); err != nil {
+		t.Fatalf("DeleteAll()でエラー発生 = %v", err)
+	}
+	if keys := mr.Keys(); len(keys) != 0 {
+		t.Errorf("キャッシュが削除されていません: %v", keys)
+	}
+}
@@ -0,0 +1,139 @@
+// Package repository は統計機能のリポジトリ実装を提供する
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mktkhr/field-manager-api/internal/features/stats/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/features/stats/domain/repository"
+	"github.com/mktkhr/field-manager-api/internal/generated/sqlc"
+)
+
+// fieldStatsPostgresRepository はFieldStatsRepositoryのPostgreSQL実装
+type fieldStatsPostgresRepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+	logger  *slog.Logger
+}
+
+// NewFieldStatsPostgresRepository はFieldStatsRepositoryのPostgreSQL実装を作成する
+func NewFieldStatsPostgresRepository(pool *pgxpool.Pool, logger *slog.Logger) repository.FieldStatsRepository {
+	return &fieldStatsPostgresRepository{
+		pool:    pool,
+		queries: sqlc.New(pool),
+		logger:  logger,
+	}
+}
+
+// FindByCityCode は市区町村の圃場統計を取得する
+func (r *fieldStatsPostgresRepository) FindByCityCode(ctx context.Context, cityCode string) (*entity.FieldStats, error) {
+	row, err := r.queries.GetCityFieldStats(ctx, cityCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("市区町村の圃場統計の取得に失敗しました: %w", err)
+	}
+
+	soils, err := r.queries.ListCitySoilStats(ctx, cityCode)
+	if err != nil {
+		return nil, fmt.Errorf("市区町村の土壌別の圃場統計の取得に失敗しました: %w", err)
+	}
+	soilTypes := make([]*entity.SoilTypeStats, 0, len(soils))
+	for _, soil := range soils {
+		soilTypes = append(soilTypes, &entity.SoilTypeStats{
+			LargeCode:    soil.SoilLargeCode,
+			FieldCount:   soil.FieldCount,
+			TotalAreaSqm: soil.TotalAreaSqm,
+		})
+	}
+
+	stats := &entity.FieldStats{
+		Scope:          entity.ScopeCity,
+		Code:           row.CityCode,
+		FieldCount:     row.FieldCount,
+		TotalAreaSqm:   row.TotalAreaSqm,
+		PaddyAreaSqm:   row.PaddyAreaSqm,
+		UplandAreaSqm:  row.UplandAreaSqm,
+		IdleFieldCount: row.IdleFieldCount,
+		IdleAreaSqm:    row.IdleAreaSqm,
+		SoilTypes:      soilTypes,
+	}
+	if row.RefreshedAt.Valid {
+		stats.RefreshedAt = row.RefreshedAt.Time
+	}
+	return stats, nil
+}
+
+// FindByPrefectureCode は都道府県内の市区町村の圃場統計を合算して取得する
+func (r *fieldStatsPostgresRepository) FindByPrefectureCode(ctx context.Context, prefectureCode string) (*entity.FieldStats, error) {
+	row, err := r.queries.GetPrefectureFieldStats(ctx, prefectureCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("都道府県の圃場統計の取得に失敗しました: %w", err)
+	}
+
+	soils, err := r.queries.ListPrefectureSoilStats(ctx, prefectureCode)
+	if err != nil {
+		return nil, fmt.Errorf("都道府県の土壌別の圃場統計の取得に失敗しました: %w", err)
+	}
+	soilTypes := make([]*entity.SoilTypeStats, 0, len(soils))
+	for _, soil := range soils {
+		soilTypes = append(soilTypes, &entity.SoilTypeStats{
+			LargeCode:    soil.SoilLargeCode,
+			FieldCount:   soil.FieldCount,
+			TotalAreaSqm: soil.TotalAreaSqm,
+		})
+	}
+
+	stats := &entity.FieldStats{
+		Scope:          entity.ScopePrefecture,
+		Code:           row.PrefectureCode,
+		FieldCount:     row.FieldCount,
+		TotalAreaSqm:   row.TotalAreaSqm,
+		PaddyAreaSqm:   row.PaddyAreaSqm,
+		UplandAreaSqm:  row.UplandAreaSqm,
+		IdleFieldCount: row.IdleFieldCount,
+		IdleAreaSqm:    row.IdleAreaSqm,
+		SoilTypes:      soilTypes,
+	}
+	if row.RefreshedAt.Valid {
+		stats.RefreshedAt = row.RefreshedAt.Time
+	}
+	return stats, nil
+}
+
+// Refresh は全ての市区町村の圃場統計を再集計する
+// 圃場統計と土壌別の統計が食い違わないよう、1トランザクションで再集計する
+func (r *fieldStatsPostgresRepository) Refresh(ctx context.Context) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("トランザクション開始に失敗しました: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && err != pgx.ErrTxClosed {
+			r.logger.Error("トランザクションのロールバックに失敗。データ不整合の可能性があります",
+				slog.String("error", err.Error()))
+		}
+	}()
+
+	queries := r.queries.WithTx(tx)
+	if err := queries.RefreshCityFieldStats(ctx); err != nil {
+		return fmt.Errorf("市区町村の圃場統計の再集計に失敗しました: %w", err)
+	}
+	if err := queries.RefreshCitySoilStats(ctx); err != nil {
+		return fmt.Errorf("市区町村の土壌別の圃場統計の再集計に失敗しました: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("トランザクションコミットに失敗しました: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Package presentation は統計機能のHTTPハンドラーを提供する
+package presentation
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/mktkhr/field-manager-api/internal/apperror"
+	"github.com/mktkhr/field-manager-api/internal/features/stats/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/features/stats/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
+)
+
+// FieldStatsHandler は圃場統計APIのハンドラー
+type FieldStatsHandler struct {
+	getFieldStatsUC *usecase.GetFieldStatsUseCase
+	logger          *slog.Logger
+}
+
+// NewFieldStatsHandler はFieldStatsHandlerを作成する
+func NewFieldStatsHandler(getFieldStatsUC *usecase.GetFieldStatsUseCase, logger *slog.Logger) *FieldStatsHandler {
+	return &FieldStatsHandler{
+		getFieldStatsUC: getFieldStatsUC,
+		logger:          logger,
+	}
+}
+
+// GetCityStats は市区町村の圃場統計を取得する
+func (h *FieldStatsHandler) GetCityStats(ctx context.Context, request openapi.GetCityStatsRequestObject) (openapi.GetCityStatsResponseObject, error) {
+	stats, err := h.getFieldStatsUC.Execute(ctx, usecase.GetFieldStatsInput{
+		Scope: entity.ScopeCity,
+		Code:  request.CityCode,
+	})
+	if err != nil {
+		if hasHTTPStatus(err, http.StatusBadRequest) {
+			return openapi.GetCityStats400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: err.Error(),
+			}, nil
+		}
+		if apperror.IsNotFoundError(err) {
+			return openapi.GetCityStats404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("市区町村の圃場統計の取得に失敗しました",
+			slog.String("city_code", request.CityCode),
+			slog.String("error", err.Error()))
+		return openapi.GetCityStats500JSONResponse{
+			Code:    "internal_error",
+			Message: "圃場統計の取得に失敗しました",
+		}, nil
+	}
+
+	return openapi.GetCityStats200JSONResponse(toFieldStatsResponse(stats)), nil
+}
+
+// GetPrefectureStats は都道府県の圃場統計を取得する
+func (h *FieldStatsHandler) GetPrefectureStats(ctx context.Context, request openapi.GetPrefectureStatsRequestObject) (openapi.GetPrefectureStatsResponseObject, error) {
+	stats, err := h.getFieldStatsUC.Execute(ctx, usecase.GetFieldStatsInput{
+		Scope: entity.ScopePrefecture,
+		Code:  request.Code,
+	})
+	if err != nil {
+		if hasHTTPStatus(err, http.StatusBadRequest) {
+			return openapi.GetPrefectureStats400JSONResponse{
+				Code:    "invalid_parameter",
+				Message: err.Error(),
+			}, nil
+		}
+		if apperror.IsNotFoundError(err) {
+			return openapi.GetPrefectureStats404JSONResponse{
+				Code:    "not_found",
+				Message: err.Error(),
+			}, nil
+		}
+		h.logger.Error("都道府県の圃場統計の取得に失敗しました",
+			slog.String("prefecture_code", request.Code),
+			slog.String("error", err.Error()))
+		return openapi.GetPrefectureStats500JSONResponse{
+			Code:    "internal_error",
+			Message: "圃場統計の取得に失敗しました",
+		}, nil
+	}
+
+	return openapi.GetPrefectureStats200JSONResponse(toFieldStatsResponse(stats)), nil
+}
+
+// toFieldStatsResponse は圃場統計エンティティをAPIレスポンスに変換する
+func toFieldStatsResponse(stats *entity.FieldStats) openapi.FieldStats {
+	soilTypes := make([]openapi.SoilTypeStats, 0, len(stats.SoilTypes))
+	for _, soil := range stats.SoilTypes {
+		soilTypes = append(soilTypes, openapi.SoilTypeStats{
+			LargeCode:  soil.LargeCode,
+			FieldCount: int(soil.FieldCount),
+			AreaSqm:    soil.TotalAreaSqm,
+		})
+	}
+
+	return openapi.FieldStats{
+		Scope:          openapi.FieldStatsScope(stats.Scope),
+		Code:           stats.Code,
+		FieldCount:     int(stats.FieldCount),
+		TotalAreaSqm:   stats.TotalAreaSqm,
+		PaddyAreaSqm:   stats.PaddyAreaSqm,
+		UplandAreaSqm:  stats.UplandAreaSqm,
+		IdleFieldCount: int(stats.IdleFieldCount),
+		IdleAreaSqm:    stats.IdleAreaSqm,
+		IdleLandRatio:  stats.IdleLandRatio(),
+		SoilTypes:      soilTypes,
+		RefreshedAt:    stats.RefreshedAt,
+	}
+}
+
+// hasHTTPStatus はエラーが指定したHTTPステータスのアプリケーションエラーかどうかを判定する
+func hasHTTPStatus(err error, status int) bool {
+	var appErr apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.HTTPStatus() == status
+	}
+	return false
+}
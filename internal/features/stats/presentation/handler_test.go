@@ -0,0 +1,165 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mktkhr/field-manager-api/internal/features/stats/application/usecase"
+	"github.com/mktkhr/field-manager-api/internal/features/stats/domain/entity"
+	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+// mockFieldStatsRepository はFieldStatsRepositoryのモック実装
+type mockFieldStatsRepository struct {
+	stats   *entity.FieldStats
+	findErr error
+}
+
+func (m *mockFieldStatsRepository) FindByCityCode(_ context.Context, _ string) (*entity.FieldStats, error) {
+	return m.stats, m.findErr
+}
+
+func (m *mockFieldStatsRepository) FindByPrefectureCode(_ context.Context, _ string) (*entity.FieldStats, error) {
+	return m.stats, m.findErr
+}
+
+func (m *mockFieldStatsRepository) Refresh(_ context.Context) error {
+	return nil
+}
+
+// mockFieldStatsCacheRepository は常にキャッシュミスになるFieldStatsCacheRepositoryのモック実装
+type mockFieldStatsCacheRepository struct{}
+
+func (m *mockFieldStatsCacheRepository) Get(_ context.Context, _ entity.Scope, _ string) (*entity.FieldStats, error) {
+	return nil, nil
+}
+
+func (m *mockFieldStatsCacheRepository) Set(_ context.Context, _ *entity.FieldStats) error {
+	return nil
+}
+
+func (m *mockFieldStatsCacheRepository) DeleteAll(_ context.Context) error {
+	return nil
+}
+
+func getTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+// newTestFieldStatsHandler はモックを使用したFieldStatsHandlerを作成する
+func newTestFieldStatsHandler(repo *mockFieldStatsRepository) *FieldStatsHandler {
+	logger := getTestLogger()
+	return NewFieldStatsHandler(
+		usecase.NewGetFieldStatsUseCase(repo, &mockFieldStatsCacheRepository{}, logger),
+		logger,
+	)
+}
+
+// TestFieldStatsHandler_GetCityStats_Success は市区町村の統計を返すことをテストする
+func TestFieldStatsHandler_GetCityStats_Success(t *testing.T) {
+	refreshedAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	repo := &mockFieldStatsRepository{stats: &entity.FieldStats{
+		Scope:          entity.ScopeCity,
+		Code:           "163210",
+		FieldCount:     4,
+		TotalAreaSqm:   10000,
+		PaddyAreaSqm:   6000,
+		UplandAreaSqm:  3000,
+		IdleFieldCount: 1,
+		IdleAreaSqm:    2500,
+		SoilTypes: []*entity.SoilTypeStats{
+			{LargeCode: "F3", FieldCount: 3, TotalAreaSqm: 8000},
+		},
+		RefreshedAt: refreshedAt,
+	}}
+	handler := newTestFieldStatsHandler(repo)
+
+	resp, err := handler.GetCityStats(context.Background(), openapi.GetCityStatsRequestObject{CityCode: "163210"})
+
+	require.NoError(t, err, "GetCityStatsでエラーが発生")
+	okResp, ok := resp.(openapi.GetCityStats200JSONResponse)
+	require.True(t, ok, "200レスポンスを期待")
+	require.Equal(t, openapi.FieldStatsScope("city"), okResp.Scope, "集計単位が一致しない")
+	require.Equal(t, "163210", okResp.Code, "市区町村コードが一致しない")
+	require.Equal(t, 4, okResp.FieldCount, "圃場数が一致しない")
+	require.InDelta(t, 6000.0, okResp.PaddyAreaSqm, 0.001, "田の面積が一致しない")
+	require.InDelta(t, 3000.0, okResp.UplandAreaSqm, 0.001, "畑の面積が一致しない")
+	require.InDelta(t, 0.25, okResp.IdleLandRatio, 0.001, "遊休農地率が一致しない")
+	require.Equal(t, []openapi.SoilTypeStats{{LargeCode: "F3", FieldCount: 3, AreaSqm: 8000}}, okResp.SoilTypes, "土壌の内訳が一致しない")
+	require.Equal(t, refreshedAt, okResp.RefreshedAt, "集計日時が一致しない")
+}
+
+// TestFieldStatsHandler_GetCityStats_Error は入力エラー・未検出・内部エラーを区別することをテストする
+func TestFieldStatsHandler_GetCityStats_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		cityCode string
+		repo     *mockFieldStatsRepository
+		wantType any
+	}{
+		{"市区町村コードが不正", "abc", &mockFieldStatsRepository{}, openapi.GetCityStats400JSONResponse{}},
+		{"統計が存在しない", "163210", &mockFieldStatsRepository{}, openapi.GetCityStats404JSONResponse{}},
+		{"取得エラー", "163210", &mockFieldStatsRepository{findErr: errors.New("db error")}, openapi.GetCityStats500JSONResponse{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestFieldStatsHandler(tt.repo)
+
+			resp, err := handler.GetCityStats(context.Background(), openapi.GetCityStatsRequestObject{CityCode: tt.cityCode})
+
+			require.NoError(t, err, "GetCityStatsでエラーが発生")
+			require.IsType(t, tt.wantType, resp, "レスポンスの種類が期待値と異なります")
+		})
+	}
+}
+
+// TestFieldStatsHandler_GetPrefectureStats_Success は都道府県の統計を返すことをテストする
+func TestFieldStatsHandler_GetPrefectureStats_Success(t *testing.T) {
+	repo := &mockFieldStatsRepository{stats: &entity.FieldStats{
+		Scope:      entity.ScopePrefecture,
+		Code:       "16",
+		FieldCount: 12,
+	}}
+	handler := newTestFieldStatsHandler(repo)
+
+	resp, err := handler.GetPrefectureStats(context.Background(), openapi.GetPrefectureStatsRequestObject{Code: "16"})
+
+	require.NoError(t, err, "GetPrefectureStatsでエラーが発生")
+	okResp, ok := resp.(openapi.GetPrefectureStats200JSONResponse)
+	require.True(t, ok, "200レスポンスを期待")
+	require.Equal(t, openapi.FieldStatsScope("prefecture"), okResp.Scope, "集計単位が一致しない")
+	require.Equal(t, 12, okResp.FieldCount, "圃場数が一致しない")
+	require.Zero(t, okResp.IdleLandRatio, "合計面積が0の場合は遊休農地率を0にするべき")
+	require.NotNil(t, okResp.SoilTypes, "土壌の内訳は空配列にするべき")
+}
+
+// TestFieldStatsHandler_GetPrefectureStats_Error は入力エラー・未検出・内部エラーを区別することをテストする
+func TestFieldStatsHandler_GetPrefectureStats_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		repo     *mockFieldStatsRepository
+		wantType any
+	}{
+		{"都道府県コードが不正", "00", &mockFieldStatsRepository{}, openapi.GetPrefectureStats400JSONResponse{}},
+		{"統計が存在しない", "16", &mockFieldStatsRepository{}, openapi.GetPrefectureStats404JSONResponse{}},
+		{"取得エラー", "16", &mockFieldStatsRepository{findErr: errors.New("db error")}, openapi.GetPrefectureStats500JSONResponse{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestFieldStatsHandler(tt.repo)
+
+			resp, err := handler.GetPrefectureStats(context.Background(), openapi.GetPrefectureStatsRequestObject{Code: tt.code})
+
+			require.NoError(t, err, "GetPrefectureStatsでエラーが発生")
+			require.IsType(t, tt.wantType, resp, "レスポンスの種類が期待値と異なります")
+		})
+	}
+}
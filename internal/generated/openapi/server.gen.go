@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -20,21 +21,72 @@ type ServerInterface interface {
 	// クラスター一覧取得
 	// (GET /api/v1/clusters)
 	GetClusters(c *gin.Context, params GetClustersParams)
+	// クラスタージョブ履歴取得
+	// (GET /api/v1/clusters/jobs)
+	ListClusterJobs(c *gin.Context, params ListClusterJobsParams)
+	// クラスタージョブ取得
+	// (GET /api/v1/clusters/jobs/{jobId})
+	GetClusterJob(c *gin.Context, jobId openapi_types.UUID)
+	// クラスタージョブ取り消し
+	// (POST /api/v1/clusters/jobs/{jobId}/cancel)
+	CancelClusterJob(c *gin.Context, jobId openapi_types.UUID)
+	// クラスタージョブ再実行
+	// (POST /api/v1/clusters/jobs/{jobId}/retry)
+	RetryClusterJob(c *gin.Context, jobId openapi_types.UUID)
 	// クラスター再計算リクエスト
 	// (POST /api/v1/clusters/recalculate)
 	RecalculateClusters(c *gin.Context)
+	// エクスポートリクエスト
+	// (POST /api/v1/exports)
+	RequestExport(c *gin.Context)
+	// エクスポートステータス取得
+	// (GET /api/v1/exports/{exportId})
+	GetExportStatus(c *gin.Context, exportId openapi_types.UUID)
 	// 圃場一覧取得
 	// (GET /api/v1/fields)
 	ListFields(c *gin.Context, params ListFieldsParams)
+	// 圃場作成
+	// (POST /api/v1/fields)
+	CreateField(c *gin.Context, params CreateFieldParams)
+	// 圃場合筆
+	// (POST /api/v1/fields/mergers)
+	MergeFields(c *gin.Context, params MergeFieldsParams)
+	// 圃場オーバーラップ一覧取得
+	// (GET /api/v1/fields/overlaps)
+	ListFieldOverlaps(c *gin.Context, params ListFieldOverlapsParams)
+	// 圃場オーバーラップ対応
+	// (POST /api/v1/fields/overlaps/{overlapId}/resolve)
+	ResolveFieldOverlap(c *gin.Context, overlapId openapi_types.UUID, params ResolveFieldOverlapParams)
+	// 圃場削除
+	// (DELETE /api/v1/fields/{fieldId})
+	DeleteField(c *gin.Context, fieldId openapi_types.UUID)
 	// 圃場詳細取得
 	// (GET /api/v1/fields/{fieldId})
 	GetField(c *gin.Context, fieldId openapi_types.UUID)
+	// 圃場更新
+	// (PATCH /api/v1/fields/{fieldId})
+	UpdateField(c *gin.Context, fieldId openapi_types.UUID, params UpdateFieldParams)
+	// 圃場分筆
+	// (POST /api/v1/fields/{fieldId}/divisions)
+	DivideField(c *gin.Context, fieldId openapi_types.UUID, params DivideFieldParams)
+	// 圃場系譜取得
+	// (GET /api/v1/fields/{fieldId}/lineage)
+	GetFieldLineage(c *gin.Context, fieldId openapi_types.UUID, params GetFieldLineageParams)
 	// インポートリクエスト
 	// (POST /api/v1/imports)
 	RequestImport(c *gin.Context)
 	// インポートステータス取得
 	// (GET /api/v1/imports/{importId})
 	GetImportStatus(c *gin.Context, importId openapi_types.UUID)
+	// 市区町村の圃場統計取得
+	// (GET /api/v1/stats/cities/{cityCode})
+	GetCityStats(c *gin.Context, cityCode string)
+	// 都道府県の圃場統計取得
+	// (GET /api/v1/stats/prefectures/{code})
+	GetPrefectureStats(c *gin.Context, code string)
+	// 圃場ベクタータイル取得
+	// (GET /api/v1/tiles/fields/{z}/{x}/{y}.mvt)
+	GetFieldTile(c *gin.Context, z int, x int, y int)
 	// ヘルスチェック
 	// (GET /health)
 	HealthCheck(c *gin.Context)
@@ -132,18 +184,61 @@ func (siw *ServerInterfaceWrapper) GetClusters(c *gin.Context) {
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
+	// ------------- Optional query parameter "city_code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "city_code", c.Request.URL.Query(), &params.CityCode)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter city_code: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	siw.Handler.GetClusters(c, params)
-}
+	// ------------- Optional query parameter "soil_type" -------------
 
-// RecalculateClusters operation middleware
-func (siw *ServerInterfaceWrapper) RecalculateClusters(c *gin.Context) {
+	err = runtime.BindQueryParameter("form", true, false, "soil_type", c.Request.URL.Query(), &params.SoilType)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter soil_type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "land_category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "land_category", c.Request.URL.Query(), &params.LandCategory)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter land_category: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "idle_status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "idle_status", c.Request.URL.Query(), &params.IdleStatus)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter idle_status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "min_area_sqm" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_area_sqm", c.Request.URL.Query(), &params.MinAreaSqm)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter min_area_sqm: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "max_area_sqm" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_area_sqm", c.Request.URL.Query(), &params.MaxAreaSqm)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter max_area_sqm: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -152,16 +247,16 @@ func (siw *ServerInterfaceWrapper) RecalculateClusters(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.RecalculateClusters(c)
+	siw.Handler.GetClusters(c, params)
 }
 
-// ListFields operation middleware
-func (siw *ServerInterfaceWrapper) ListFields(c *gin.Context) {
+// ListClusterJobs operation middleware
+func (siw *ServerInterfaceWrapper) ListClusterJobs(c *gin.Context) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListFieldsParams
+	var params ListClusterJobsParams
 
 	// ------------- Optional query parameter "limit" -------------
 
@@ -179,6 +274,30 @@ func (siw *ServerInterfaceWrapper) ListFields(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", c.Request.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter created_from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", c.Request.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter created_to: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -186,20 +305,20 @@ func (siw *ServerInterfaceWrapper) ListFields(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ListFields(c, params)
+	siw.Handler.ListClusterJobs(c, params)
 }
 
-// GetField operation middleware
-func (siw *ServerInterfaceWrapper) GetField(c *gin.Context) {
+// GetClusterJob operation middleware
+func (siw *ServerInterfaceWrapper) GetClusterJob(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "fieldId" -------------
-	var fieldId openapi_types.UUID
+	// ------------- Path parameter "jobId" -------------
+	var jobId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "fieldId", c.Param("fieldId"), &fieldId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", c.Param("jobId"), &jobId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter fieldId: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter jobId: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -210,11 +329,22 @@ func (siw *ServerInterfaceWrapper) GetField(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetField(c, fieldId)
+	siw.Handler.GetClusterJob(c, jobId)
 }
 
-// RequestImport operation middleware
-func (siw *ServerInterfaceWrapper) RequestImport(c *gin.Context) {
+// CancelClusterJob operation middleware
+func (siw *ServerInterfaceWrapper) CancelClusterJob(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", c.Param("jobId"), &jobId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter jobId: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -223,20 +353,20 @@ func (siw *ServerInterfaceWrapper) RequestImport(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.RequestImport(c)
+	siw.Handler.CancelClusterJob(c, jobId)
 }
 
-// GetImportStatus operation middleware
-func (siw *ServerInterfaceWrapper) GetImportStatus(c *gin.Context) {
+// RetryClusterJob operation middleware
+func (siw *ServerInterfaceWrapper) RetryClusterJob(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "importId" -------------
-	var importId openapi_types.UUID
+	// ------------- Path parameter "jobId" -------------
+	var jobId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "importId", c.Param("importId"), &importId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", c.Param("jobId"), &jobId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter importId: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter jobId: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -247,11 +377,11 @@ func (siw *ServerInterfaceWrapper) GetImportStatus(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetImportStatus(c, importId)
+	siw.Handler.RetryClusterJob(c, jobId)
 }
 
-// HealthCheck operation middleware
-func (siw *ServerInterfaceWrapper) HealthCheck(c *gin.Context) {
+// RecalculateClusters operation middleware
+func (siw *ServerInterfaceWrapper) RecalculateClusters(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -260,336 +390,2206 @@ func (siw *ServerInterfaceWrapper) HealthCheck(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.HealthCheck(c)
-}
-
-// GinServerOptions provides options for the Gin server.
-type GinServerOptions struct {
-	BaseURL      string
-	Middlewares  []MiddlewareFunc
-	ErrorHandler func(*gin.Context, error, int)
+	siw.Handler.RecalculateClusters(c)
 }
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router gin.IRouter, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, GinServerOptions{})
-}
+// RequestExport operation middleware
+func (siw *ServerInterfaceWrapper) RequestExport(c *gin.Context) {
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
-	errorHandler := options.ErrorHandler
-	if errorHandler == nil {
-		errorHandler = func(c *gin.Context, err error, statusCode int) {
-			c.JSON(statusCode, gin.H{"msg": err.Error()})
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
 		}
 	}
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandler:       errorHandler,
-	}
-
-	router.GET(options.BaseURL+"/api/v1/clusters", wrapper.GetClusters)
-	router.POST(options.BaseURL+"/api/v1/clusters/recalculate", wrapper.RecalculateClusters)
-	router.GET(options.BaseURL+"/api/v1/fields", wrapper.ListFields)
-	router.GET(options.BaseURL+"/api/v1/fields/:fieldId", wrapper.GetField)
-	router.POST(options.BaseURL+"/api/v1/imports", wrapper.RequestImport)
-	router.GET(options.BaseURL+"/api/v1/imports/:importId", wrapper.GetImportStatus)
-	router.GET(options.BaseURL+"/health", wrapper.HealthCheck)
+	siw.Handler.RequestExport(c)
 }
 
-type GetClustersRequestObject struct {
-	Params GetClustersParams
-}
+// GetExportStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetExportStatus(c *gin.Context) {
 
-type GetClustersResponseObject interface {
-	VisitGetClustersResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type GetClusters200JSONResponse ClusterListResponse
+	// ------------- Path parameter "exportId" -------------
+	var exportId openapi_types.UUID
 
-func (response GetClusters200JSONResponse) VisitGetClustersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "exportId", c.Param("exportId"), &exportId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter exportId: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetExportStatus(c, exportId)
 }
 
-type GetClusters400JSONResponse ErrorResponse
+// ListFields operation middleware
+func (siw *ServerInterfaceWrapper) ListFields(c *gin.Context) {
 
-func (response GetClusters400JSONResponse) VisitGetClustersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListFieldsParams
 
-type GetClusters500JSONResponse ErrorResponse
+	// ------------- Optional query parameter "limit" -------------
 
-func (response GetClusters500JSONResponse) VisitGetClustersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "offset" -------------
 
-type RecalculateClustersRequestObject struct {
-}
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type RecalculateClustersResponseObject interface {
-	VisitRecalculateClustersResponse(w http.ResponseWriter) error
-}
+	// ------------- Optional query parameter "city_code" -------------
 
-type RecalculateClusters202JSONResponse RecalculateResponse
+	err = runtime.BindQueryParameter("form", true, false, "city_code", c.Request.URL.Query(), &params.CityCode)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter city_code: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response RecalculateClusters202JSONResponse) VisitRecalculateClustersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(202)
+	// ------------- Optional query parameter "soil_type" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "soil_type", c.Request.URL.Query(), &params.SoilType)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter soil_type: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type RecalculateClusters409JSONResponse ErrorResponse
+	// ------------- Optional query parameter "land_category" -------------
 
-func (response RecalculateClusters409JSONResponse) VisitRecalculateClustersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	err = runtime.BindQueryParameter("form", true, false, "land_category", c.Request.URL.Query(), &params.LandCategory)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter land_category: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "idle_status" -------------
 
-type RecalculateClusters500JSONResponse ErrorResponse
+	err = runtime.BindQueryParameter("form", true, false, "idle_status", c.Request.URL.Query(), &params.IdleStatus)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter idle_status: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response RecalculateClusters500JSONResponse) VisitRecalculateClustersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	// ------------- Optional query parameter "min_area_sqm" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "min_area_sqm", c.Request.URL.Query(), &params.MinAreaSqm)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter min_area_sqm: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type ListFieldsRequestObject struct {
-	Params ListFieldsParams
-}
+	// ------------- Optional query parameter "max_area_sqm" -------------
 
-type ListFieldsResponseObject interface {
-	VisitListFieldsResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindQueryParameter("form", true, false, "max_area_sqm", c.Request.URL.Query(), &params.MaxAreaSqm)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter max_area_sqm: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type ListFields200JSONResponse FieldListResponse
+	// ------------- Optional query parameter "sw_lat" -------------
 
-func (response ListFields200JSONResponse) VisitListFieldsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindQueryParameter("form", true, false, "sw_lat", c.Request.URL.Query(), &params.SwLat)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sw_lat: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "sw_lng" -------------
 
-type ListFields400JSONResponse ErrorResponse
+	err = runtime.BindQueryParameter("form", true, false, "sw_lng", c.Request.URL.Query(), &params.SwLng)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sw_lng: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response ListFields400JSONResponse) VisitListFieldsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// ------------- Optional query parameter "ne_lat" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "ne_lat", c.Request.URL.Query(), &params.NeLat)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter ne_lat: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type ListFields500JSONResponse ErrorResponse
+	// ------------- Optional query parameter "ne_lng" -------------
 
-func (response ListFields500JSONResponse) VisitListFieldsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	err = runtime.BindQueryParameter("form", true, false, "ne_lng", c.Request.URL.Query(), &params.NeLng)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter ne_lng: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Optional query parameter "q" -------------
 
-type GetFieldRequestObject struct {
+	err = runtime.BindQueryParameter("form", true, false, "q", c.Request.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter q: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListFields(c, params)
+}
+
+// CreateField operation middleware
+func (siw *ServerInterfaceWrapper) CreateField(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateFieldParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "X-User-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-User-ID")]; found {
+		var XUserID openapi_types.UUID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for X-User-ID, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-User-ID", valueList[0], &XUserID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter X-User-ID: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.XUserID = &XUserID
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateField(c, params)
+}
+
+// MergeFields operation middleware
+func (siw *ServerInterfaceWrapper) MergeFields(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params MergeFieldsParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "X-User-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-User-ID")]; found {
+		var XUserID openapi_types.UUID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for X-User-ID, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-User-ID", valueList[0], &XUserID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter X-User-ID: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.XUserID = &XUserID
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.MergeFields(c, params)
+}
+
+// ListFieldOverlaps operation middleware
+func (siw *ServerInterfaceWrapper) ListFieldOverlaps(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListFieldOverlapsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "field_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "field_id", c.Request.URL.Query(), &params.FieldId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter field_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListFieldOverlaps(c, params)
+}
+
+// ResolveFieldOverlap operation middleware
+func (siw *ServerInterfaceWrapper) ResolveFieldOverlap(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "overlapId" -------------
+	var overlapId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "overlapId", c.Param("overlapId"), &overlapId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter overlapId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ResolveFieldOverlapParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "X-User-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-User-ID")]; found {
+		var XUserID openapi_types.UUID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for X-User-ID, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-User-ID", valueList[0], &XUserID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter X-User-ID: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.XUserID = &XUserID
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ResolveFieldOverlap(c, overlapId, params)
+}
+
+// DeleteField operation middleware
+func (siw *ServerInterfaceWrapper) DeleteField(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "fieldId" -------------
+	var fieldId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "fieldId", c.Param("fieldId"), &fieldId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter fieldId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteField(c, fieldId)
+}
+
+// GetField operation middleware
+func (siw *ServerInterfaceWrapper) GetField(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "fieldId" -------------
+	var fieldId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "fieldId", c.Param("fieldId"), &fieldId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter fieldId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetField(c, fieldId)
+}
+
+// UpdateField operation middleware
+func (siw *ServerInterfaceWrapper) UpdateField(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "fieldId" -------------
+	var fieldId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "fieldId", c.Param("fieldId"), &fieldId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter fieldId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateFieldParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "X-User-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-User-ID")]; found {
+		var XUserID openapi_types.UUID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for X-User-ID, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-User-ID", valueList[0], &XUserID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter X-User-ID: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.XUserID = &XUserID
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateField(c, fieldId, params)
+}
+
+// DivideField operation middleware
+func (siw *ServerInterfaceWrapper) DivideField(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "fieldId" -------------
+	var fieldId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "fieldId", c.Param("fieldId"), &fieldId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter fieldId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DivideFieldParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "X-User-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-User-ID")]; found {
+		var XUserID openapi_types.UUID
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for X-User-ID, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-User-ID", valueList[0], &XUserID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter X-User-ID: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.XUserID = &XUserID
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DivideField(c, fieldId, params)
+}
+
+// GetFieldLineage operation middleware
+func (siw *ServerInterfaceWrapper) GetFieldLineage(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "fieldId" -------------
+	var fieldId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "fieldId", c.Param("fieldId"), &fieldId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter fieldId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFieldLineageParams
+
+	// ------------- Optional query parameter "depth" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "depth", c.Request.URL.Query(), &params.Depth)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter depth: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetFieldLineage(c, fieldId, params)
+}
+
+// RequestImport operation middleware
+func (siw *ServerInterfaceWrapper) RequestImport(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RequestImport(c)
+}
+
+// GetImportStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetImportStatus(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "importId" -------------
+	var importId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "importId", c.Param("importId"), &importId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter importId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetImportStatus(c, importId)
+}
+
+// GetCityStats operation middleware
+func (siw *ServerInterfaceWrapper) GetCityStats(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "cityCode" -------------
+	var cityCode string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cityCode", c.Param("cityCode"), &cityCode, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cityCode: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCityStats(c, cityCode)
+}
+
+// GetPrefectureStats operation middleware
+func (siw *ServerInterfaceWrapper) GetPrefectureStats(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "code", c.Param("code"), &code, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetPrefectureStats(c, code)
+}
+
+// GetFieldTile operation middleware
+func (siw *ServerInterfaceWrapper) GetFieldTile(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "z" -------------
+	var z int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "z", c.Param("z"), &z, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter z: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "x" -------------
+	var x int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "x", c.Param("x"), &x, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter x: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "y" -------------
+	var y int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "y", c.Param("y"), &y, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter y: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetFieldTile(c, z, x, y)
+}
+
+// HealthCheck operation middleware
+func (siw *ServerInterfaceWrapper) HealthCheck(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.HealthCheck(c)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/api/v1/clusters", wrapper.GetClusters)
+	router.GET(options.BaseURL+"/api/v1/clusters/jobs", wrapper.ListClusterJobs)
+	router.GET(options.BaseURL+"/api/v1/clusters/jobs/:jobId", wrapper.GetClusterJob)
+	router.POST(options.BaseURL+"/api/v1/clusters/jobs/:jobId/cancel", wrapper.CancelClusterJob)
+	router.POST(options.BaseURL+"/api/v1/clusters/jobs/:jobId/retry", wrapper.RetryClusterJob)
+	router.POST(options.BaseURL+"/api/v1/clusters/recalculate", wrapper.RecalculateClusters)
+	router.POST(options.BaseURL+"/api/v1/exports", wrapper.RequestExport)
+	router.GET(options.BaseURL+"/api/v1/exports/:exportId", wrapper.GetExportStatus)
+	router.GET(options.BaseURL+"/api/v1/fields", wrapper.ListFields)
+	router.POST(options.BaseURL+"/api/v1/fields", wrapper.CreateField)
+	router.POST(options.BaseURL+"/api/v1/fields/mergers", wrapper.MergeFields)
+	router.GET(options.BaseURL+"/api/v1/fields/overlaps", wrapper.ListFieldOverlaps)
+	router.POST(options.BaseURL+"/api/v1/fields/overlaps/:overlapId/resolve", wrapper.ResolveFieldOverlap)
+	router.DELETE(options.BaseURL+"/api/v1/fields/:fieldId", wrapper.DeleteField)
+	router.GET(options.BaseURL+"/api/v1/fields/:fieldId", wrapper.GetField)
+	router.PATCH(options.BaseURL+"/api/v1/fields/:fieldId", wrapper.UpdateField)
+	router.POST(options.BaseURL+"/api/v1/fields/:fieldId/divisions", wrapper.DivideField)
+	router.GET(options.BaseURL+"/api/v1/fields/:fieldId/lineage", wrapper.GetFieldLineage)
+	router.POST(options.BaseURL+"/api/v1/imports", wrapper.RequestImport)
+	router.GET(options.BaseURL+"/api/v1/imports/:importId", wrapper.GetImportStatus)
+	router.GET(options.BaseURL+"/api/v1/stats/cities/:cityCode", wrapper.GetCityStats)
+	router.GET(options.BaseURL+"/api/v1/stats/prefectures/:code", wrapper.GetPrefectureStats)
+	router.GET(options.BaseURL+"/api/v1/tiles/fields/:z/:x/:y.mvt", wrapper.GetFieldTile)
+	router.GET(options.BaseURL+"/health", wrapper.HealthCheck)
+}
+
+type GetClustersRequestObject struct {
+	Params GetClustersParams
+}
+
+type GetClustersResponseObject interface {
+	VisitGetClustersResponse(w http.ResponseWriter) error
+}
+
+type GetClusters200ApplicationGeoPlusJSONResponse ClusterFeatureCollection
+
+func (response GetClusters200ApplicationGeoPlusJSONResponse) VisitGetClustersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/geo+json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClusters200JSONResponse ClusterListResponse
+
+func (response GetClusters200JSONResponse) VisitGetClustersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClusters400JSONResponse ErrorResponse
+
+func (response GetClusters400JSONResponse) VisitGetClustersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClusters500JSONResponse ErrorResponse
+
+func (response GetClusters500JSONResponse) VisitGetClustersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListClusterJobsRequestObject struct {
+	Params ListClusterJobsParams
+}
+
+type ListClusterJobsResponseObject interface {
+	VisitListClusterJobsResponse(w http.ResponseWriter) error
+}
+
+type ListClusterJobs200JSONResponse ClusterJobListResponse
+
+func (response ListClusterJobs200JSONResponse) VisitListClusterJobsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListClusterJobs400JSONResponse ErrorResponse
+
+func (response ListClusterJobs400JSONResponse) VisitListClusterJobsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListClusterJobs500JSONResponse ErrorResponse
+
+func (response ListClusterJobs500JSONResponse) VisitListClusterJobsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClusterJobRequestObject struct {
+	JobId openapi_types.UUID `json:"jobId"`
+}
+
+type GetClusterJobResponseObject interface {
+	VisitGetClusterJobResponse(w http.ResponseWriter) error
+}
+
+type GetClusterJob200JSONResponse ClusterJob
+
+func (response GetClusterJob200JSONResponse) VisitGetClusterJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClusterJob404JSONResponse ErrorResponse
+
+func (response GetClusterJob404JSONResponse) VisitGetClusterJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClusterJob500JSONResponse ErrorResponse
+
+func (response GetClusterJob500JSONResponse) VisitGetClusterJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelClusterJobRequestObject struct {
+	JobId openapi_types.UUID `json:"jobId"`
+}
+
+type CancelClusterJobResponseObject interface {
+	VisitCancelClusterJobResponse(w http.ResponseWriter) error
+}
+
+type CancelClusterJob200JSONResponse ClusterJob
+
+func (response CancelClusterJob200JSONResponse) VisitCancelClusterJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelClusterJob404JSONResponse ErrorResponse
+
+func (response CancelClusterJob404JSONResponse) VisitCancelClusterJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelClusterJob409JSONResponse ErrorResponse
+
+func (response CancelClusterJob409JSONResponse) VisitCancelClusterJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelClusterJob500JSONResponse ErrorResponse
+
+func (response CancelClusterJob500JSONResponse) VisitCancelClusterJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryClusterJobRequestObject struct {
+	JobId openapi_types.UUID `json:"jobId"`
+}
+
+type RetryClusterJobResponseObject interface {
+	VisitRetryClusterJobResponse(w http.ResponseWriter) error
+}
+
+type RetryClusterJob202JSONResponse ClusterJobRetryResponse
+
+func (response RetryClusterJob202JSONResponse) VisitRetryClusterJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryClusterJob404JSONResponse ErrorResponse
+
+func (response RetryClusterJob404JSONResponse) VisitRetryClusterJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryClusterJob409JSONResponse ErrorResponse
+
+func (response RetryClusterJob409JSONResponse) VisitRetryClusterJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryClusterJob500JSONResponse ErrorResponse
+
+func (response RetryClusterJob500JSONResponse) VisitRetryClusterJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RecalculateClustersRequestObject struct {
+}
+
+type RecalculateClustersResponseObject interface {
+	VisitRecalculateClustersResponse(w http.ResponseWriter) error
+}
+
+type RecalculateClusters202JSONResponse RecalculateResponse
+
+func (response RecalculateClusters202JSONResponse) VisitRecalculateClustersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RecalculateClusters500JSONResponse ErrorResponse
+
+func (response RecalculateClusters500JSONResponse) VisitRecalculateClustersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestExportRequestObject struct {
+	Body *RequestExportJSONRequestBody
+}
+
+type RequestExportResponseObject interface {
+	VisitRequestExportResponse(w http.ResponseWriter) error
+}
+
+type RequestExport202JSONResponse ExportResponse
+
+func (response RequestExport202JSONResponse) VisitRequestExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestExport400JSONResponse ErrorResponse
+
+func (response RequestExport400JSONResponse) VisitRequestExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestExport500JSONResponse ErrorResponse
+
+func (response RequestExport500JSONResponse) VisitRequestExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetExportStatusRequestObject struct {
+	ExportId openapi_types.UUID `json:"exportId"`
+}
+
+type GetExportStatusResponseObject interface {
+	VisitGetExportStatusResponse(w http.ResponseWriter) error
+}
+
+type GetExportStatus200JSONResponse ExportStatus
+
+func (response GetExportStatus200JSONResponse) VisitGetExportStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetExportStatus404JSONResponse ErrorResponse
+
+func (response GetExportStatus404JSONResponse) VisitGetExportStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetExportStatus500JSONResponse ErrorResponse
+
+func (response GetExportStatus500JSONResponse) VisitGetExportStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFieldsRequestObject struct {
+	Params ListFieldsParams
+}
+
+type ListFieldsResponseObject interface {
+	VisitListFieldsResponse(w http.ResponseWriter) error
+}
+
+type ListFields200JSONResponse FieldListResponse
+
+func (response ListFields200JSONResponse) VisitListFieldsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFields400JSONResponse ErrorResponse
+
+func (response ListFields400JSONResponse) VisitListFieldsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFields500JSONResponse ErrorResponse
+
+func (response ListFields500JSONResponse) VisitListFieldsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFieldRequestObject struct {
+	Params CreateFieldParams
+	Body   *CreateFieldJSONRequestBody
+}
+
+type CreateFieldResponseObject interface {
+	VisitCreateFieldResponse(w http.ResponseWriter) error
+}
+
+type CreateField201JSONResponse FieldFeature
+
+func (response CreateField201JSONResponse) VisitCreateFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateField400JSONResponse ErrorResponse
+
+func (response CreateField400JSONResponse) VisitCreateFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateField500JSONResponse ErrorResponse
+
+func (response CreateField500JSONResponse) VisitCreateFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeFieldsRequestObject struct {
+	Params MergeFieldsParams
+	Body   *MergeFieldsJSONRequestBody
+}
+
+type MergeFieldsResponseObject interface {
+	VisitMergeFieldsResponse(w http.ResponseWriter) error
+}
+
+type MergeFields201JSONResponse FieldMergerResponse
+
+func (response MergeFields201JSONResponse) VisitMergeFieldsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeFields400JSONResponse ErrorResponse
+
+func (response MergeFields400JSONResponse) VisitMergeFieldsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeFields404JSONResponse ErrorResponse
+
+func (response MergeFields404JSONResponse) VisitMergeFieldsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeFields409JSONResponse ErrorResponse
+
+func (response MergeFields409JSONResponse) VisitMergeFieldsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergeFields500JSONResponse ErrorResponse
+
+func (response MergeFields500JSONResponse) VisitMergeFieldsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFieldOverlapsRequestObject struct {
+	Params ListFieldOverlapsParams
+}
+
+type ListFieldOverlapsResponseObject interface {
+	VisitListFieldOverlapsResponse(w http.ResponseWriter) error
+}
+
+type ListFieldOverlaps200JSONResponse FieldOverlapListResponse
+
+func (response ListFieldOverlaps200JSONResponse) VisitListFieldOverlapsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFieldOverlaps400JSONResponse ErrorResponse
+
+func (response ListFieldOverlaps400JSONResponse) VisitListFieldOverlapsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFieldOverlaps500JSONResponse ErrorResponse
+
+func (response ListFieldOverlaps500JSONResponse) VisitListFieldOverlapsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResolveFieldOverlapRequestObject struct {
+	OverlapId openapi_types.UUID `json:"overlapId"`
+	Params    ResolveFieldOverlapParams
+	Body      *ResolveFieldOverlapJSONRequestBody
+}
+
+type ResolveFieldOverlapResponseObject interface {
+	VisitResolveFieldOverlapResponse(w http.ResponseWriter) error
+}
+
+type ResolveFieldOverlap200JSONResponse FieldOverlap
+
+func (response ResolveFieldOverlap200JSONResponse) VisitResolveFieldOverlapResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResolveFieldOverlap400JSONResponse ErrorResponse
+
+func (response ResolveFieldOverlap400JSONResponse) VisitResolveFieldOverlapResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResolveFieldOverlap404JSONResponse ErrorResponse
+
+func (response ResolveFieldOverlap404JSONResponse) VisitResolveFieldOverlapResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResolveFieldOverlap409JSONResponse ErrorResponse
+
+func (response ResolveFieldOverlap409JSONResponse) VisitResolveFieldOverlapResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResolveFieldOverlap500JSONResponse ErrorResponse
+
+func (response ResolveFieldOverlap500JSONResponse) VisitResolveFieldOverlapResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFieldRequestObject struct {
+	FieldId openapi_types.UUID `json:"fieldId"`
+}
+
+type DeleteFieldResponseObject interface {
+	VisitDeleteFieldResponse(w http.ResponseWriter) error
+}
+
+type DeleteField204Response struct {
+}
+
+func (response DeleteField204Response) VisitDeleteFieldResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteField404JSONResponse ErrorResponse
+
+func (response DeleteField404JSONResponse) VisitDeleteFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteField409JSONResponse ErrorResponse
+
+func (response DeleteField409JSONResponse) VisitDeleteFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteField500JSONResponse ErrorResponse
+
+func (response DeleteField500JSONResponse) VisitDeleteFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFieldRequestObject struct {
+	FieldId openapi_types.UUID `json:"fieldId"`
+}
+
+type GetFieldResponseObject interface {
+	VisitGetFieldResponse(w http.ResponseWriter) error
+}
+
+type GetField200JSONResponse FieldFeature
+
+func (response GetField200JSONResponse) VisitGetFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetField404JSONResponse ErrorResponse
+
+func (response GetField404JSONResponse) VisitGetFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetField500JSONResponse ErrorResponse
+
+func (response GetField500JSONResponse) VisitGetFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateFieldRequestObject struct {
+	FieldId openapi_types.UUID `json:"fieldId"`
+	Params  UpdateFieldParams
+	Body    *UpdateFieldJSONRequestBody
+}
+
+type UpdateFieldResponseObject interface {
+	VisitUpdateFieldResponse(w http.ResponseWriter) error
+}
+
+type UpdateField200JSONResponse FieldFeature
+
+func (response UpdateField200JSONResponse) VisitUpdateFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateField400JSONResponse ErrorResponse
+
+func (response UpdateField400JSONResponse) VisitUpdateFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateField404JSONResponse ErrorResponse
+
+func (response UpdateField404JSONResponse) VisitUpdateFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateField409JSONResponse ErrorResponse
+
+func (response UpdateField409JSONResponse) VisitUpdateFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateField500JSONResponse ErrorResponse
+
+func (response UpdateField500JSONResponse) VisitUpdateFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DivideFieldRequestObject struct {
+	FieldId openapi_types.UUID `json:"fieldId"`
+	Params  DivideFieldParams
+	Body    *DivideFieldJSONRequestBody
+}
+
+type DivideFieldResponseObject interface {
+	VisitDivideFieldResponse(w http.ResponseWriter) error
+}
+
+type DivideField201JSONResponse FieldDivisionResponse
+
+func (response DivideField201JSONResponse) VisitDivideFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DivideField400JSONResponse ErrorResponse
+
+func (response DivideField400JSONResponse) VisitDivideFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DivideField404JSONResponse ErrorResponse
+
+func (response DivideField404JSONResponse) VisitDivideFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DivideField409JSONResponse ErrorResponse
+
+func (response DivideField409JSONResponse) VisitDivideFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DivideField500JSONResponse ErrorResponse
+
+func (response DivideField500JSONResponse) VisitDivideFieldResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFieldLineageRequestObject struct {
 	FieldId openapi_types.UUID `json:"fieldId"`
+	Params  GetFieldLineageParams
+}
+
+type GetFieldLineageResponseObject interface {
+	VisitGetFieldLineageResponse(w http.ResponseWriter) error
+}
+
+type GetFieldLineage200JSONResponse FieldLineageResponse
+
+func (response GetFieldLineage200JSONResponse) VisitGetFieldLineageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFieldLineage400JSONResponse ErrorResponse
+
+func (response GetFieldLineage400JSONResponse) VisitGetFieldLineageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFieldLineage404JSONResponse ErrorResponse
+
+func (response GetFieldLineage404JSONResponse) VisitGetFieldLineageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFieldLineage500JSONResponse ErrorResponse
+
+func (response GetFieldLineage500JSONResponse) VisitGetFieldLineageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestImportRequestObject struct {
+	Body *RequestImportJSONRequestBody
+}
+
+type RequestImportResponseObject interface {
+	VisitRequestImportResponse(w http.ResponseWriter) error
+}
+
+type RequestImport202JSONResponse ImportResponse
+
+func (response RequestImport202JSONResponse) VisitRequestImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestImport400JSONResponse ErrorResponse
+
+func (response RequestImport400JSONResponse) VisitRequestImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestImport500JSONResponse ErrorResponse
+
+func (response RequestImport500JSONResponse) VisitRequestImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetImportStatusRequestObject struct {
+	ImportId openapi_types.UUID `json:"importId"`
+}
+
+type GetImportStatusResponseObject interface {
+	VisitGetImportStatusResponse(w http.ResponseWriter) error
+}
+
+type GetImportStatus200JSONResponse ImportStatus
+
+func (response GetImportStatus200JSONResponse) VisitGetImportStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetImportStatus404JSONResponse ErrorResponse
+
+func (response GetImportStatus404JSONResponse) VisitGetImportStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetImportStatus500JSONResponse ErrorResponse
+
+func (response GetImportStatus500JSONResponse) VisitGetImportStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCityStatsRequestObject struct {
+	CityCode string `json:"cityCode"`
+}
+
+type GetCityStatsResponseObject interface {
+	VisitGetCityStatsResponse(w http.ResponseWriter) error
+}
+
+type GetCityStats200JSONResponse FieldStats
+
+func (response GetCityStats200JSONResponse) VisitGetCityStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCityStats400JSONResponse ErrorResponse
+
+func (response GetCityStats400JSONResponse) VisitGetCityStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCityStats404JSONResponse ErrorResponse
+
+func (response GetCityStats404JSONResponse) VisitGetCityStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCityStats500JSONResponse ErrorResponse
+
+func (response GetCityStats500JSONResponse) VisitGetCityStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPrefectureStatsRequestObject struct {
+	Code string `json:"code"`
+}
+
+type GetPrefectureStatsResponseObject interface {
+	VisitGetPrefectureStatsResponse(w http.ResponseWriter) error
+}
+
+type GetPrefectureStats200JSONResponse FieldStats
+
+func (response GetPrefectureStats200JSONResponse) VisitGetPrefectureStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPrefectureStats400JSONResponse ErrorResponse
+
+func (response GetPrefectureStats400JSONResponse) VisitGetPrefectureStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPrefectureStats404JSONResponse ErrorResponse
+
+func (response GetPrefectureStats404JSONResponse) VisitGetPrefectureStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPrefectureStats500JSONResponse ErrorResponse
+
+func (response GetPrefectureStats500JSONResponse) VisitGetPrefectureStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFieldTileRequestObject struct {
+	Z int `json:"z"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type GetFieldTileResponseObject interface {
+	VisitGetFieldTileResponse(w http.ResponseWriter) error
+}
+
+type GetFieldTile200ApplicationvndMapboxVectorTileResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetFieldTile200ApplicationvndMapboxVectorTileResponse) VisitGetFieldTileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/vnd.mapbox-vector-tile")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetFieldTile400JSONResponse ErrorResponse
+
+func (response GetFieldTile400JSONResponse) VisitGetFieldTileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetFieldTile500JSONResponse ErrorResponse
+
+func (response GetFieldTile500JSONResponse) VisitGetFieldTileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HealthCheckRequestObject struct {
+}
+
+type HealthCheckResponseObject interface {
+	VisitHealthCheckResponse(w http.ResponseWriter) error
+}
+
+type HealthCheck200JSONResponse HealthResponse
+
+func (response HealthCheck200JSONResponse) VisitHealthCheckResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HealthCheck429JSONResponse ErrorResponse
+
+func (response HealthCheck429JSONResponse) VisitHealthCheckResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HealthCheck503JSONResponse ErrorResponse
+
+func (response HealthCheck503JSONResponse) VisitHealthCheckResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// クラスター一覧取得
+	// (GET /api/v1/clusters)
+	GetClusters(ctx context.Context, request GetClustersRequestObject) (GetClustersResponseObject, error)
+	// クラスタージョブ履歴取得
+	// (GET /api/v1/clusters/jobs)
+	ListClusterJobs(ctx context.Context, request ListClusterJobsRequestObject) (ListClusterJobsResponseObject, error)
+	// クラスタージョブ取得
+	// (GET /api/v1/clusters/jobs/{jobId})
+	GetClusterJob(ctx context.Context, request GetClusterJobRequestObject) (GetClusterJobResponseObject, error)
+	// クラスタージョブ取り消し
+	// (POST /api/v1/clusters/jobs/{jobId}/cancel)
+	CancelClusterJob(ctx context.Context, request CancelClusterJobRequestObject) (CancelClusterJobResponseObject, error)
+	// クラスタージョブ再実行
+	// (POST /api/v1/clusters/jobs/{jobId}/retry)
+	RetryClusterJob(ctx context.Context, request RetryClusterJobRequestObject) (RetryClusterJobResponseObject, error)
+	// クラスター再計算リクエスト
+	// (POST /api/v1/clusters/recalculate)
+	RecalculateClusters(ctx context.Context, request RecalculateClustersRequestObject) (RecalculateClustersResponseObject, error)
+	// エクスポートリクエスト
+	// (POST /api/v1/exports)
+	RequestExport(ctx context.Context, request RequestExportRequestObject) (RequestExportResponseObject, error)
+	// エクスポートステータス取得
+	// (GET /api/v1/exports/{exportId})
+	GetExportStatus(ctx context.Context, request GetExportStatusRequestObject) (GetExportStatusResponseObject, error)
+	// 圃場一覧取得
+	// (GET /api/v1/fields)
+	ListFields(ctx context.Context, request ListFieldsRequestObject) (ListFieldsResponseObject, error)
+	// 圃場作成
+	// (POST /api/v1/fields)
+	CreateField(ctx context.Context, request CreateFieldRequestObject) (CreateFieldResponseObject, error)
+	// 圃場合筆
+	// (POST /api/v1/fields/mergers)
+	MergeFields(ctx context.Context, request MergeFieldsRequestObject) (MergeFieldsResponseObject, error)
+	// 圃場オーバーラップ一覧取得
+	// (GET /api/v1/fields/overlaps)
+	ListFieldOverlaps(ctx context.Context, request ListFieldOverlapsRequestObject) (ListFieldOverlapsResponseObject, error)
+	// 圃場オーバーラップ対応
+	// (POST /api/v1/fields/overlaps/{overlapId}/resolve)
+	ResolveFieldOverlap(ctx context.Context, request ResolveFieldOverlapRequestObject) (ResolveFieldOverlapResponseObject, error)
+	// 圃場削除
+	// (DELETE /api/v1/fields/{fieldId})
+	DeleteField(ctx context.Context, request DeleteFieldRequestObject) (DeleteFieldResponseObject, error)
+	// 圃場詳細取得
+	// (GET /api/v1/fields/{fieldId})
+	GetField(ctx context.Context, request GetFieldRequestObject) (GetFieldResponseObject, error)
+	// 圃場更新
+	// (PATCH /api/v1/fields/{fieldId})
+	UpdateField(ctx context.Context, request UpdateFieldRequestObject) (UpdateFieldResponseObject, error)
+	// 圃場分筆
+	// (POST /api/v1/fields/{fieldId}/divisions)
+	DivideField(ctx context.Context, request DivideFieldRequestObject) (DivideFieldResponseObject, error)
+	// 圃場系譜取得
+	// (GET /api/v1/fields/{fieldId}/lineage)
+	GetFieldLineage(ctx context.Context, request GetFieldLineageRequestObject) (GetFieldLineageResponseObject, error)
+	// インポートリクエスト
+	// (POST /api/v1/imports)
+	RequestImport(ctx context.Context, request RequestImportRequestObject) (RequestImportResponseObject, error)
+	// インポートステータス取得
+	// (GET /api/v1/imports/{importId})
+	GetImportStatus(ctx context.Context, request GetImportStatusRequestObject) (GetImportStatusResponseObject, error)
+	// 市区町村の圃場統計取得
+	// (GET /api/v1/stats/cities/{cityCode})
+	GetCityStats(ctx context.Context, request GetCityStatsRequestObject) (GetCityStatsResponseObject, error)
+	// 都道府県の圃場統計取得
+	// (GET /api/v1/stats/prefectures/{code})
+	GetPrefectureStats(ctx context.Context, request GetPrefectureStatsRequestObject) (GetPrefectureStatsResponseObject, error)
+	// 圃場ベクタータイル取得
+	// (GET /api/v1/tiles/fields/{z}/{x}/{y}.mvt)
+	GetFieldTile(ctx context.Context, request GetFieldTileRequestObject) (GetFieldTileResponseObject, error)
+	// ヘルスチェック
+	// (GET /health)
+	HealthCheck(ctx context.Context, request HealthCheckRequestObject) (HealthCheckResponseObject, error)
 }
 
-type GetFieldResponseObject interface {
-	VisitGetFieldResponse(w http.ResponseWriter) error
+type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
+type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
 }
 
-type GetField200JSONResponse Field
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
 
-func (response GetField200JSONResponse) VisitGetFieldResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// GetClusters operation middleware
+func (sh *strictHandler) GetClusters(ctx *gin.Context, params GetClustersParams) {
+	var request GetClustersRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Params = params
 
-type GetField404JSONResponse ErrorResponse
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetClusters(ctx, request.(GetClustersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetClusters")
+	}
 
-func (response GetField404JSONResponse) VisitGetFieldResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	response, err := handler(ctx, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetClustersResponseObject); ok {
+		if err := validResponse.VisitGetClustersResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetField500JSONResponse ErrorResponse
+// ListClusterJobs operation middleware
+func (sh *strictHandler) ListClusterJobs(ctx *gin.Context, params ListClusterJobsParams) {
+	var request ListClusterJobsRequestObject
 
-func (response GetField500JSONResponse) VisitGetFieldResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListClusterJobs(ctx, request.(ListClusterJobsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListClusterJobs")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListClusterJobsResponseObject); ok {
+		if err := validResponse.VisitListClusterJobsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type RequestImportRequestObject struct {
-	Body *RequestImportJSONRequestBody
+// GetClusterJob operation middleware
+func (sh *strictHandler) GetClusterJob(ctx *gin.Context, jobId openapi_types.UUID) {
+	var request GetClusterJobRequestObject
+
+	request.JobId = jobId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetClusterJob(ctx, request.(GetClusterJobRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetClusterJob")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetClusterJobResponseObject); ok {
+		if err := validResponse.VisitGetClusterJobResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type RequestImportResponseObject interface {
-	VisitRequestImportResponse(w http.ResponseWriter) error
+// CancelClusterJob operation middleware
+func (sh *strictHandler) CancelClusterJob(ctx *gin.Context, jobId openapi_types.UUID) {
+	var request CancelClusterJobRequestObject
+
+	request.JobId = jobId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelClusterJob(ctx, request.(CancelClusterJobRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelClusterJob")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CancelClusterJobResponseObject); ok {
+		if err := validResponse.VisitCancelClusterJobResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type RequestImport202JSONResponse ImportResponse
+// RetryClusterJob operation middleware
+func (sh *strictHandler) RetryClusterJob(ctx *gin.Context, jobId openapi_types.UUID) {
+	var request RetryClusterJobRequestObject
 
-func (response RequestImport202JSONResponse) VisitRequestImportResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(202)
+	request.JobId = jobId
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RetryClusterJob(ctx, request.(RetryClusterJobRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RetryClusterJob")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RetryClusterJobResponseObject); ok {
+		if err := validResponse.VisitRetryClusterJobResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type RequestImport400JSONResponse ErrorResponse
+// RecalculateClusters operation middleware
+func (sh *strictHandler) RecalculateClusters(ctx *gin.Context) {
+	var request RecalculateClustersRequestObject
 
-func (response RequestImport400JSONResponse) VisitRequestImportResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RecalculateClusters(ctx, request.(RecalculateClustersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RecalculateClusters")
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RecalculateClustersResponseObject); ok {
+		if err := validResponse.VisitRecalculateClustersResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type RequestImport500JSONResponse ErrorResponse
+// RequestExport operation middleware
+func (sh *strictHandler) RequestExport(ctx *gin.Context) {
+	var request RequestExportRequestObject
 
-func (response RequestImport500JSONResponse) VisitRequestImportResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var body RequestExportJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RequestExport(ctx, request.(RequestExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RequestExport")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(RequestExportResponseObject); ok {
+		if err := validResponse.VisitRequestExportResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetImportStatusRequestObject struct {
-	ImportId openapi_types.UUID `json:"importId"`
+// GetExportStatus operation middleware
+func (sh *strictHandler) GetExportStatus(ctx *gin.Context, exportId openapi_types.UUID) {
+	var request GetExportStatusRequestObject
+
+	request.ExportId = exportId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetExportStatus(ctx, request.(GetExportStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetExportStatus")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetExportStatusResponseObject); ok {
+		if err := validResponse.VisitGetExportStatusResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetImportStatusResponseObject interface {
-	VisitGetImportStatusResponse(w http.ResponseWriter) error
+// ListFields operation middleware
+func (sh *strictHandler) ListFields(ctx *gin.Context, params ListFieldsParams) {
+	var request ListFieldsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFields(ctx, request.(ListFieldsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFields")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListFieldsResponseObject); ok {
+		if err := validResponse.VisitListFieldsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetImportStatus200JSONResponse ImportStatus
+// CreateField operation middleware
+func (sh *strictHandler) CreateField(ctx *gin.Context, params CreateFieldParams) {
+	var request CreateFieldRequestObject
 
-func (response GetImportStatus200JSONResponse) VisitGetImportStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.Params = params
+
+	var body CreateFieldJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateField(ctx, request.(CreateFieldRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateField")
+	}
+
+	response, err := handler(ctx, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(CreateFieldResponseObject); ok {
+		if err := validResponse.VisitCreateFieldResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetImportStatus404JSONResponse ErrorResponse
+// MergeFields operation middleware
+func (sh *strictHandler) MergeFields(ctx *gin.Context, params MergeFieldsParams) {
+	var request MergeFieldsRequestObject
 
-func (response GetImportStatus404JSONResponse) VisitGetImportStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body MergeFieldsJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
 
-type GetImportStatus500JSONResponse ErrorResponse
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.MergeFields(ctx, request.(MergeFieldsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "MergeFields")
+	}
 
-func (response GetImportStatus500JSONResponse) VisitGetImportStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(ctx, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(MergeFieldsResponseObject); ok {
+		if err := validResponse.VisitMergeFieldsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type HealthCheckRequestObject struct {
-}
+// ListFieldOverlaps operation middleware
+func (sh *strictHandler) ListFieldOverlaps(ctx *gin.Context, params ListFieldOverlapsParams) {
+	var request ListFieldOverlapsRequestObject
 
-type HealthCheckResponseObject interface {
-	VisitHealthCheckResponse(w http.ResponseWriter) error
-}
+	request.Params = params
 
-type HealthCheck200JSONResponse HealthResponse
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFieldOverlaps(ctx, request.(ListFieldOverlapsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFieldOverlaps")
+	}
 
-func (response HealthCheck200JSONResponse) VisitHealthCheckResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(ctx, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ListFieldOverlapsResponseObject); ok {
+		if err := validResponse.VisitListFieldOverlapsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type HealthCheck429JSONResponse ErrorResponse
+// ResolveFieldOverlap operation middleware
+func (sh *strictHandler) ResolveFieldOverlap(ctx *gin.Context, overlapId openapi_types.UUID, params ResolveFieldOverlapParams) {
+	var request ResolveFieldOverlapRequestObject
 
-func (response HealthCheck429JSONResponse) VisitHealthCheckResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(429)
+	request.OverlapId = overlapId
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body ResolveFieldOverlapJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
 
-type HealthCheck503JSONResponse ErrorResponse
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ResolveFieldOverlap(ctx, request.(ResolveFieldOverlapRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResolveFieldOverlap")
+	}
 
-func (response HealthCheck503JSONResponse) VisitHealthCheckResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(503)
+	response, err := handler(ctx, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(ResolveFieldOverlapResponseObject); ok {
+		if err := validResponse.VisitResolveFieldOverlapResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// クラスター一覧取得
-	// (GET /api/v1/clusters)
-	GetClusters(ctx context.Context, request GetClustersRequestObject) (GetClustersResponseObject, error)
-	// クラスター再計算リクエスト
-	// (POST /api/v1/clusters/recalculate)
-	RecalculateClusters(ctx context.Context, request RecalculateClustersRequestObject) (RecalculateClustersResponseObject, error)
-	// 圃場一覧取得
-	// (GET /api/v1/fields)
-	ListFields(ctx context.Context, request ListFieldsRequestObject) (ListFieldsResponseObject, error)
-	// 圃場詳細取得
-	// (GET /api/v1/fields/{fieldId})
-	GetField(ctx context.Context, request GetFieldRequestObject) (GetFieldResponseObject, error)
-	// インポートリクエスト
-	// (POST /api/v1/imports)
-	RequestImport(ctx context.Context, request RequestImportRequestObject) (RequestImportResponseObject, error)
-	// インポートステータス取得
-	// (GET /api/v1/imports/{importId})
-	GetImportStatus(ctx context.Context, request GetImportStatusRequestObject) (GetImportStatusResponseObject, error)
-	// ヘルスチェック
-	// (GET /health)
-	HealthCheck(ctx context.Context, request HealthCheckRequestObject) (HealthCheckResponseObject, error)
-}
+// DeleteField operation middleware
+func (sh *strictHandler) DeleteField(ctx *gin.Context, fieldId openapi_types.UUID) {
+	var request DeleteFieldRequestObject
 
-type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
-type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+	request.FieldId = fieldId
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
-}
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteField(ctx, request.(DeleteFieldRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteField")
+	}
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteFieldResponseObject); ok {
+		if err := validResponse.VisitDeleteFieldResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// GetClusters operation middleware
-func (sh *strictHandler) GetClusters(ctx *gin.Context, params GetClustersParams) {
-	var request GetClustersRequestObject
+// GetField operation middleware
+func (sh *strictHandler) GetField(ctx *gin.Context, fieldId openapi_types.UUID) {
+	var request GetFieldRequestObject
 
-	request.Params = params
+	request.FieldId = fieldId
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetClusters(ctx, request.(GetClustersRequestObject))
+		return sh.ssi.GetField(ctx, request.(GetFieldRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetClusters")
+		handler = middleware(handler, "GetField")
 	}
 
 	response, err := handler(ctx, request)
@@ -597,8 +2597,8 @@ func (sh *strictHandler) GetClusters(ctx *gin.Context, params GetClustersParams)
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetClustersResponseObject); ok {
-		if err := validResponse.VisitGetClustersResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetFieldResponseObject); ok {
+		if err := validResponse.VisitGetFieldResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -606,15 +2606,26 @@ func (sh *strictHandler) GetClusters(ctx *gin.Context, params GetClustersParams)
 	}
 }
 
-// RecalculateClusters operation middleware
-func (sh *strictHandler) RecalculateClusters(ctx *gin.Context) {
-	var request RecalculateClustersRequestObject
+// UpdateField operation middleware
+func (sh *strictHandler) UpdateField(ctx *gin.Context, fieldId openapi_types.UUID, params UpdateFieldParams) {
+	var request UpdateFieldRequestObject
+
+	request.FieldId = fieldId
+	request.Params = params
+
+	var body UpdateFieldJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.RecalculateClusters(ctx, request.(RecalculateClustersRequestObject))
+		return sh.ssi.UpdateField(ctx, request.(UpdateFieldRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RecalculateClusters")
+		handler = middleware(handler, "UpdateField")
 	}
 
 	response, err := handler(ctx, request)
@@ -622,8 +2633,8 @@ func (sh *strictHandler) RecalculateClusters(ctx *gin.Context) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(RecalculateClustersResponseObject); ok {
-		if err := validResponse.VisitRecalculateClustersResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(UpdateFieldResponseObject); ok {
+		if err := validResponse.VisitUpdateFieldResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -631,17 +2642,26 @@ func (sh *strictHandler) RecalculateClusters(ctx *gin.Context) {
 	}
 }
 
-// ListFields operation middleware
-func (sh *strictHandler) ListFields(ctx *gin.Context, params ListFieldsParams) {
-	var request ListFieldsRequestObject
+// DivideField operation middleware
+func (sh *strictHandler) DivideField(ctx *gin.Context, fieldId openapi_types.UUID, params DivideFieldParams) {
+	var request DivideFieldRequestObject
 
+	request.FieldId = fieldId
 	request.Params = params
 
+	var body DivideFieldJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.ListFields(ctx, request.(ListFieldsRequestObject))
+		return sh.ssi.DivideField(ctx, request.(DivideFieldRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListFields")
+		handler = middleware(handler, "DivideField")
 	}
 
 	response, err := handler(ctx, request)
@@ -649,8 +2669,8 @@ func (sh *strictHandler) ListFields(ctx *gin.Context, params ListFieldsParams) {
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(ListFieldsResponseObject); ok {
-		if err := validResponse.VisitListFieldsResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(DivideFieldResponseObject); ok {
+		if err := validResponse.VisitDivideFieldResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -658,17 +2678,18 @@ func (sh *strictHandler) ListFields(ctx *gin.Context, params ListFieldsParams) {
 	}
 }
 
-// GetField operation middleware
-func (sh *strictHandler) GetField(ctx *gin.Context, fieldId openapi_types.UUID) {
-	var request GetFieldRequestObject
+// GetFieldLineage operation middleware
+func (sh *strictHandler) GetFieldLineage(ctx *gin.Context, fieldId openapi_types.UUID, params GetFieldLineageParams) {
+	var request GetFieldLineageRequestObject
 
 	request.FieldId = fieldId
+	request.Params = params
 
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetField(ctx, request.(GetFieldRequestObject))
+		return sh.ssi.GetFieldLineage(ctx, request.(GetFieldLineageRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetField")
+		handler = middleware(handler, "GetFieldLineage")
 	}
 
 	response, err := handler(ctx, request)
@@ -676,8 +2697,8 @@ func (sh *strictHandler) GetField(ctx *gin.Context, fieldId openapi_types.UUID)
 	if err != nil {
 		ctx.Error(err)
 		ctx.Status(http.StatusInternalServerError)
-	} else if validResponse, ok := response.(GetFieldResponseObject); ok {
-		if err := validResponse.VisitGetFieldResponse(ctx.Writer); err != nil {
+	} else if validResponse, ok := response.(GetFieldLineageResponseObject); ok {
+		if err := validResponse.VisitGetFieldLineageResponse(ctx.Writer); err != nil {
 			ctx.Error(err)
 		}
 	} else if response != nil {
@@ -745,6 +2766,89 @@ func (sh *strictHandler) GetImportStatus(ctx *gin.Context, importId openapi_type
 	}
 }
 
+// GetCityStats operation middleware
+func (sh *strictHandler) GetCityStats(ctx *gin.Context, cityCode string) {
+	var request GetCityStatsRequestObject
+
+	request.CityCode = cityCode
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCityStats(ctx, request.(GetCityStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCityStats")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetCityStatsResponseObject); ok {
+		if err := validResponse.VisitGetCityStatsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPrefectureStats operation middleware
+func (sh *strictHandler) GetPrefectureStats(ctx *gin.Context, code string) {
+	var request GetPrefectureStatsRequestObject
+
+	request.Code = code
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPrefectureStats(ctx, request.(GetPrefectureStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPrefectureStats")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetPrefectureStatsResponseObject); ok {
+		if err := validResponse.VisitGetPrefectureStatsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetFieldTile operation middleware
+func (sh *strictHandler) GetFieldTile(ctx *gin.Context, z int, x int, y int) {
+	var request GetFieldTileRequestObject
+
+	request.Z = z
+	request.X = x
+	request.Y = y
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetFieldTile(ctx, request.(GetFieldTileRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetFieldTile")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetFieldTileResponseObject); ok {
+		if err := validResponse.VisitGetFieldTileResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // HealthCheck operation middleware
 func (sh *strictHandler) HealthCheck(ctx *gin.Context) {
 	var request HealthCheckRequestObject
@@ -18,42 +18,162 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xZW3MUxxX+K1QnD7hKaC+yXbBvhARQlUmlIG9E5Wp2e1djz42eHoxCbZVmxpHkaGUE",
-	"sVhwJAMGC12ilWKgvMhE+jGt2ZX+Raq7Z3cu26NdMFJClf1gZrQz55z++jvfOX3mFigammnoSCcWKNwC",
-	"VnEcaZBfnlNtiyDMLk1smAgTBfEfioatE3ZRQlYRKyZRDB0UAHU3qbdK3VfU3aXea+qs+/Pr1Nmhbo26",
-	"s/6i5z960VrYAkOATJgIFICiE1RBGFSHwPjIqF5CN3uNXhyh7lPqPafeNPU85sJ9dTL38cHkj62Frda9",
-	"aX+j7s/UPwBDAN2Emqkyu6dHcuXTpXLnv9ChRbCiV5g/FfZfwF5zw9/1qNNo/7Tpby+DIVA2sMZeBCXD",
-	"vqai0LBua9fEQlS98gaGX9YGNFwdAhhdtxWMSqBwtQuXWIjwOhTsy1j3ZePaZ6hIWFTBVn6iWOQyskxD",
-	"t5BkW8VD/FohSOMXv8WoDArgN5mQJpmAI5kOQapdjxBjOMHuFesKgSoagCQ1f2puf2Wm3ajvNTeoM0ud",
-	"VepMUWc2BOGaYagI6j0odAMO/ckW/weMDXzIso0S/2sPRzRkWbAi+y0ZB7MQPi+L4byC1FKvb4gRvAh7",
-	"UTpY+r69OneSevc54zlS3voHg1GwiBEkqHSWMzx8HhJ0iigakqVDzLsECqUUs2XbSklmRocah0uDNz9B",
-	"eoWMg0L+o48kD9pm6c1CTCDO3XNv0eVG7aZuwuE5UGaPDJ4BYlsl/CcGgWoEyq7UJRYS+Ou8IIv6IoIq",
-	"GU8P2SKQ2PwqVEDj874QBq/JPI5qpoHJZXTdRhaRJIxCJs4FSROnrd90/dp2+5vt1tId6j7nrP0qJs25",
-	"j0fyuWzf4LouDgsvDRB0ExVtFtFZrPfGeIUg88R5Wy+ye8tvPNx/XDt7+Y8yOivc0WhJpmKiJi3xJc5Q",
-	"t0m9Z9S7N/r7aIrK0yRJ5Y6T9KVe6e5w+kb0BM/YqqLDk0y3VRUyFSkQbCMJAm8hJYip7aVQOvv6KENF",
-	"RaXLqGhgkXm93cGA8mNio4gsq48xExsVjCxLorqTP7bm6u2vp09mT+Wy2QHl1iIQ/0KYIyms2xpjhYn0",
-	"ksKLerAoJajwwaaCDm7sCYiJAlV14tPw5zGJFy4xEWhSwkqTKo55l3LdoCWwJ/c0gnmUUjLCX0ZFqBZt",
-	"FRJ0SILr121kI2leBolInRp1V1iOuhvU+4F3GgusEXUe9ukxYnU/IW/Ty+35qfbL+dZ3i9R7zPvRn5lp",
-	"txkTuUSP021wwujcuz3R1VmnzP7/sK9kdAIcCpHoxZK9pOhlQ7IM3om3G4/b81PU/YnF6U1R79HZP42y",
-	"VlIpogB1Uc7BpdE/s9KKVVAA44SYViGTMUykW4aNi2jYwJVM8JKVYc8yqimEI8Hr44lLUIcVhE8IBzcQ",
-	"tkQg2eHccJY9zqxBUwEFMDKcHR7hnCbjfK8z0FQyN3KZaHNaQZLmvVWb9hvfhrvsbnNtfkS9f1HvAfXW",
-	"qbNCvXnqLgenCfcJ34At6i12Txb+1N+o02BnjniX6t71b9/zd+rUeUDdWTrp/kWXOVj3dxepc586y63F",
-	"yQNGsrWLI/vPnvjebX97mbp396fX/NmFA6fZ+vt3EVuAQ4AhWworN+ACIufC5taEGGpIrP5qct0XDKOi",
-	"ohOXoGlRp9Eb1cnccPZUPj+cpZOOv3WntbDlb+74u4tM3RRm4LqN8ESnnSqAvxqGBqJ8E+IgOp64xHUk",
-	"UYM3FY3pVj4/BDRFFzc5ySGmh4pz9f0fdtvrm9FTliwq64tPxUnnreI6k43EdYrdvVlknWNaWmRcm98q",
-	"stzpWGj8tn9stXpr6d8DoKaj40YtGtlhqLHIjha1MWZbVBAuGvlsVpz2dILE9AKapqoUedJlPrPEySd0",
-	"P8DBN3aU4ILb59g/ub/8jOndh+8wlvjpVhaFt8bFbYUL/Qz17rCgWPl6zeJyanvNudbGExbXR8cal/uS",
-	"S9U8D2SFB/Wa1zoLFW2skAlQuDo2BCxb0yCeSMNTCDOrmbBixaYCY8xWsn5kcNhh8M7CsAYZZTUGK+Jd",
-	"RW/Vv6fO+t7uUnvhAZ9r7PDOY1O0EPwvjViz4rjUnfUfvfDnZ6izyT1vsLLk1YOSllYqIh1TpGQkqJ9/",
-	"Z9sqa9Akm9sLl3+7vvfzfcH+M8fHMrERkt1zauLst9fceP+oH64nntx90yCcbwRNVJxMTNHOd0YSibZD",
-	"JuOqoimsvoS4lFAZ2ioBhXw2KtjZrOx8ITdqlMsWSrEqM3OUSt87MpKRnTfUv8r7L+R4FMYeVQ+IKyFz",
-	"5hb/d7RUTaX1BSRYnUJqdtgI6RdYG6w3SRnxHDkl02m4v/q8/WJL0PDD49tu4Zw6tf3lWeo8ZYds9yvq",
-	"rFHny/eGegK6gagnpnZWegfxBaxghZ14O0g0+BcsnpPJ4eHdZA7zPkJS6flAVswEA3oii/zOKE28M2zj",
-	"o99qfPLAsqB6hL1FYrAr3dk4dHHcoi3Grwr8Vl3GYfBGUqJDf1lOZG51RtrV1GFN2vycd8ViHCUy5VVi",
-	"9CKblMSG5IMofHfk/v8q8bEVDZAGcciOXfvTd/P9rAaHw9tTH+LJMM4/16W2IuJr3rlxVPwcHCGDEh8N",
-	"+8Hi1FobT/xmkx2VZhf2/rPIJ9HL1PmS5RzjU/7M/0xQD5yv/affCt6MHD9v/sH2fGa1/c3KXnPOv715",
-	"OHW8+9Rb53E71H0mBssRrgTsGKsKI/iGfKjb/mfT39xhlcR91Z29ZwATncBSj6KmOA4kL/ArmdhFPgYk",
-	"emBL8jhva9I6maTCygwkZ+trYggfvto9t1bHqv8NAAD//+grNoSdJAAA",
+	"H4sIAAAAAAAC/+x9/XPT1rbov5Lxu2/GmWdIAvT0lJn+0NLbU86jPR1o77tvaB4jbCWotS1Xlik53Mx4",
+	"yyQ4xIGQkoRAIEC+THJjBwIlJED+mB3J9n/xZn9I2pK2ZDmEfPTA8INjS3uvvfb6XmuvfTUSl1MZOS2m",
+	"1Wzk5NVINn5JTAn446lkLquKCvqYUeSMqKiSiH8QFFE492sKfUyI2bgiZVRJTkdORqBWhYWnUHsNtS1Y",
+	"eAPBsj66DME7qJWgNqxPF/RHLyCo6KPFernYePCk9nQkqr9eMyZew8Jj9EKhCAvLMA/qT5brC4Ow8AR/",
+	"OQTBIgRVqG2iX52DNgplvTgIQYUM1x6JRXpkJSWokZORhJy7mBQjsYjalxEjJyPpXOqiqET6Y5G4nEur",
+	"O4PfGF+1R5TSqthLhkzIKSktpNVzspQ8Iyi94ik5IXqnYNcOQcmYzutziwgn0zP6bEmfW9SLg43HD6G2",
+	"RpYeJT/gR5dqU5uN0nP09KMX+mgRgmo6l0yiNYtXhFQmiUD6+ngkFkFfC2jtJ1UlJ1rgZlVFSvciaC8d",
+	"P51OiFe88H1zHGpzsLAGC9dhoYAQor2Odv2lkX9ujK8aE9f1lUm9OOmc8q/Hu3r+mugx/0U480mJpHiK",
+	"j/QGuLH95nb93XN9ehWCSu3GH8YagKBkLnYcYR8sQHCt6R4khXTilKCKvbLSh2cj5JpISGguIfm9g4w5",
+	"e+jcqukZfXq1Vq7oxXl7P2p3Vjtq47chWILgaTsEdyAo4/2zAbMwczXS1dkZOdl1LBY5hj4c77egli/+",
+	"LMZVAnRzStxeX9G3Cgg7r6r6xkI4Ik+me1sY+GUp5MD9sYgi/pqTFDEROXneoiSyEDKryWAxS1Rw94Yl",
+	"i24OZqgA+loU1JwihmDXyt9E+e/n/vFdG30levorCKo8kkYE7BRqvaKcElWljzcNkTsV/UmhNl6Cec2Y",
+	"nN/evKvPDRn3X9Re3YPaGBISYAaCUfPhqvsZgDhbH3oOwSQEM9/mkqr0vZzs65XTkVgkIaEJkQBRZSxw",
+	"U0ImI5H9czx6MvI/OmyB3UGldQddt2vUcC+ZT/VbGOn7TkihncAb0h+LyGnxHz2Rk+evRv5NEXtCDxfq",
+	"cQfI/d1YVOyZVHJSQBC0pi60GPhqREznUogBTPLs9kzg4hT8K15fzKY2BxDNmeCUnEyKcYKV5uygD6zU",
+	"F8f0t08sEnYxCDOcGxs95An8WVLFVFgUmeiwRZ2gKEIf+lvKnlOFZBhGLumDI/VysVaZ3F5fgWAYgqcQ",
+	"DEIwbO/iRVlOikI6YEeYxYXdG2vRNrABe/J3+SLHOOrpEeOqmDglJpM+Gk9/VUGywFwi2qjqu/qzx2SX",
+	"jPHVqD5QrlWv6fefOx4ylX5nO1f7CaoqpjJqljNhZab+uKTff4jGhoUlhGPttTE905ga1YvXsYJdhloR",
+	"KdjBEfI01MaQ8aPl+ZMhAkiKqpj4grfASml7Y9CYnDemtKg+98wYn4SFTf3WBNRuGH8UIZg0pjRk0YEH",
+	"EFTIc07LTVDFI6qUEsPYMnFFFCxAuEN4XknkFAHBek6My+kED2PXF2qjg8aU1pj4PVpbHGuHeY18hwnS",
+	"3ovGxLC+OIxIVBsiarQBbpL3YB4Y00vmA06bjatofVZqa3RRUWTlWzGbFXoxwTdFTU8umTwrxoVkPJcU",
+	"+FKDR2pN+I1IaAv+XE5K2M+x0lWSFUnlaFT92pI+UNQ3FqKN5bvIsAObEDzVB4rI4sZY5hNdVhWU4J1u",
+	"ipGsKqi5LE8ErcPCIixMIClUGMQcsgW115GYJVYyYjohYesmo8hxMZuVqKlDOQHtqSAl8Ye4kI6LSfS5",
+	"mwPEb7Lyi6icTgSCMabfmtDfTRJ7ARaqGKRlIthPf9V8rS4Jh/eIrp7ZGx6NxDhCjBEvLMcFS8czUlY9",
+	"K2YzcjoreiXlz/LFlrULErkczaLKqpDk2fQuJOApzceDYT+L1HMg8K2BnBKVXpGz49tbD2rjU0SqGJNP",
+	"9JW7Ng2A5drLZ1hoECdopglrelcbsSYOWG3wNsXJQy1v1d5ZAK5lWwA3UeJyQiSGrgeYwkMKCahYjh8E",
+	"ZX10pLa46rGU4tTR97B5WkjxfnCDi16nD/Pg/Hck9AO2x2/2lK0nQgFgPs+F4UpGVtQv5RyWgF/KnMAB",
+	"je5o61BbwtGcIjI0QHl7Y05/VYFgCmrDRNFAsFh7+RBqN+rv3kAt78FnWjzDc4n10qTx4FltudqiG5wW",
+	"z6R7mwwX2vmNRbK/8aEbmazPb7UOXfY3PnTscDt1zQmo5hwxilgTI/77fFb8NSdmVS+tXbwoX2nG/V5S",
+	"QSaapPb5xMPWNb20UbuzYTy4zfCam0Jst67rL8ePdXXy7A0TPV5bbkO/cV9/+0R/cysaz17GoUQnmWpj",
+	"/+d//6AXJ3EsZxKCBfJOO6P9e0X55yxWjvHs5Ugs8ksqify4zC+9XBWflaXkD30Zvyggifat3nJF+7wL",
+	"D2ZduuSgrfSTGyL+nW+DlImHDQsPaFDW1EjY7mhi+LlAtObxB/KcZZO5RZvDx9g770D+LZ2UhcSPStKL",
+	"ndrb5/royPbmXQhGYCEPtQUcl1ghG/jj2TNR6v0gD6cCwRZ2A4b0G9TfgkCD2o32MKC3bu5bHLBDqg1p",
+	"2e+qHd66ec2DHJt0Z8W4rPDdOcTOxJRmI7U+YPqZjxgbdL2MMR1sDn8tickEP4nyjcAJh5O0CCzcxUyI",
+	"raHCcti8RmtyNrJL/MLOdHXHVGWaSynhyhkx3ateipw89sknnAdzmURrIPK2Ec/GYIxdOTuF75aewo/7",
+	"6srWtyKMjmOj1C0FYy3s8qw2fXQkWpsGtfF5GpfJl4itu70+Yty9CfM4r9ZkX9xWpY1YC2hfXH4lXZYS",
+	"Abi8JCUTipgO7YJYg2YlOX0KvY0tYuHKafL2J52xSEpK07+OeR0VRRSy3GhJcbC2gtKNtdHB2p1nHbo2",
+	"Vc8XmlKctYBADNjAejCw831HeZezYq+UVZW+0zzZCMHvKDq1MmrmZ5excrtt3N+CAIUE6wtL5k8VkqbT",
+	"b63q62vYGLC2oyl7O1HsQlA4EkEICvCEGCpx+dhvp43iqOVFW2vFsVAkZcvY2imiNPKjQX3jVju7sqaE",
+	"FhDyTmDCbkmaZgRFTKt44NPhBKdNrMFU6ByZBS4WgkJ903AEl/Wna7UXq94UQwuJNn3k7vZ6HsvGTSul",
+	"CkGVUjPMg8bsfQjWcKIYpV/rc9eN8VXzBRKCXWIqDaof02xN0mwhgrhhU2SYSJgM+96nyjAE35B8tJj1",
+	"I9TG9RGS8uYlFT3UqojZ48RdaiGvqIjZT7iWkCJmP/X74TM+A/NXeUZKi0Kv+O+JXo4g7FHkFCM/nEgw",
+	"Jhep8LN0mS3gC5v6aJF8CbW3JE1Efmpv7vjFInI8nlMUn5wQmc2cgSR9/HI+4UVcLKLK/mudWHWv1dZz",
+	"JiQoExF+iSZJOydKUOX0OZ0nD3DUVfmcTMGEEcwnzcCsEj43yewqu2oH2rubEMx3coJDMB/cF9kdLyOj",
+	"XvLCWH/5qmYSqZWG216f2N6c1V9VorX5CZxXqtafP4J5gLZ/BZdnrMyiOjP8sn9KtUXPhUO2qhSOH+wE",
+	"7GbBWHlCc6ckcACBZYChOjhiqbeH5J1wvg9BbihXllKSvx3mt1HvtiCYhWCG1LyRLfKr5BITvS2UHnhE",
+	"IscS6/ETEiYNlNnwgJlja771cmKHgGJW5ACqKrl0HG0CR545MYcJ5gYm74r+bN5YeYHKBCvDGM20YC5U",
+	"xqTHNgkpHdhAmEs09ySALoKySHiKFhH1Ppk+Ol9Qrg/P8S0Swr6Op68bOFoM5QbGIlk5p8RFKquz/iOh",
+	"9IhL5Zo0GN7FCu3guhMFTiCDkaU02eRWXSaSn2xFEwQYA15075KD6hqYAZuKFn+s/eOyqCSFDC+zKmUy",
+	"YsLXfMFJ0SVkmxYmUf3g2ye1G39AbWx7q4LU107EVUJUcWKfp5GM6XztpWbMTddm5ls0z6gA+YJjQlwf",
+	"wb7arKuEF8fGByF4jEo0USYEeefgmjHxOswy6Hxf7ng+VHM9En6+sHaArPL84+o7fWua5AP8BYVMqOQL",
+	"v9p6ujDthqvinVNAH9JAozOeRUUffrafa28Yr3xZr74jYsuGjL5S0Yee66PFaOeRrpCgKGJWTl5uVQqQ",
+	"d77sC5uz4Bb+kN0hVedROSOmPzeml8iXsTYhHhczqpj4vF5+pldeG+tFCLZibZRxP3cxaH1x1vijSB5i",
+	"U4do1EgsYg4WiZmc39z2JxkHk7sYwvcQjGs/mfQEw/PNZFSwDqcTtKjF6dDvo8ytiZuqczrZWUIbvnpd",
+	"8Cmhpaw68dpYG4+S/frcIm+ojREyIATw+fZ63skSyPlwsyku40J/Ts1BcIulCjI8JQZuTgv9EFI5TLGC",
+	"7vRXUbJACEpoDLb4UN8aaDwqtkdiH1KaubZPcBfhujbNeSxjD1xT3wNM7ytS42JaVWQpETpML6XV/cvb",
+	"XWJDZU352A6sObMKUgv+zxn7tT6eQNgNR7qwqQ8RdlumDhL2qK3o/+651naVR7N1nzOf24Xspdd5t/fR",
+	"szEtZzVRLUbWv8osVPEOOQpTbRTeNsDv+sZ0bbrUap4T6zm/8vnAM2DoSJG/EdX6WbPm5xVDygYE2NcB",
+	"y9rFc3BoKsxqfNPOeQZxWR95BDWADDknBD7mnLV9nUe7joVaeUZIJPp892R7fRNzoX3YLuo9HsnmHelp",
+	"SfPpdoSjO6uMHt6lDVPEHkXMXuJLncb9wXq5SGWJg9JZZcvyh+mAGNN5qGn6rTkIrrGjtCB14jIvCE0G",
+	"Q0m0tyOMnREnZd4ZRUQ13fz0iy3Jsr7FatbRVOvI4+BAvbwWdVJTpTE10niEIuD8g6tmZo4erEWu2rXQ",
+	"2VZTjBIh5WdQ+lLa7pBFLoNkbAvkjOhz/Daz+F2hT3d8AtNEzCzmZeSnCysudnSvxyOnnBLVLVtYwnFy",
+	"jK+G+RErIcY0d0UhStf1yj0S3Gg8Gqjdr5AyOuP+C2NilVi63hLs97efPlhlTajKGQ+qeHlbjlaWlQTK",
+	"ZnPZlqbFKbdiZ2ENaliyD4zoxcmoPnrNfKiqz03ot8v2Q3mgDw44vsG1Efgc1MaiUZ6CoHqeVCbH2kjB",
+	"czfLxgEfQvCYHcg81qRQZ2d/2ylpB4LDpuJYvHf7bx4x71vcNYzcqBu17ZHYB0WgjRAC9K5jYicUzCPZ",
+	"D06m+0CdLPJ3lxC/EYWkesk/osNU4VpGufxLUxeEvsab8XQq8ATBh6iK9Cs8DALPvypejOcQRF8onPDQ",
+	"OVXMtH2dS+N4Rpack/3i7HfciHHKv7yeFJ3sQm29NYn/Un1r6wOz8/tUeN96wTsuCGfKvnecwqc1500G",
+	"yyhyryJmObIKtSIYmazdvI7cpM7OkLbkPhfS42JDVRKSyb4L9s9hyut3UjjPRCyYc6gutLv3lMF5s+oE",
+	"R3CJU35ge5Ae+1FIJPjbagzl9emyPr3Ko5mdhRED2s5cFs+puUTfVwI38FqZMQaG60tbxsymMTnv9ha5",
+	"URRBSYnKd4TcOOr1Fq57ewUL83ppwgqQ1fPj22+n6/mB+spdvThfG1/Sb716j6yY6THYoijw9Kh5LNPV",
+	"zyf8e17a41GLfeQ5oJRFTP+aE3Ni4EFtUEL1w8j4WIGFeXx4lXNaN2qf87XfXMen+/AJX063BebE/V4c",
+	"HnacGOV1Q6i9HDUeTmNyLuBWFW+gtu7Q0K5TvHZHAeZUuwdZkzgOgNy9pvrOBDBmb0zg+eZzTHiWF9NA",
+	"YCJtPBm1ohttR37KdXYeF9tQZyTnN9ZxPW/zoF06AZMM6Bvm6Qzm7frlGS8lJRJJnwGt9fkNKHzKGzKb",
+	"EpJJ/oje44yeEdUTvmPyj2JbYxJHOkSy1MahY/0s5OyMQWTjEwsXPmx8aeeh7yDy8Wkv15SIXChmseuI",
+	"MZlI8eITDSGle2S/1dQqj2ujg0gN0c4bj774/jTaSCkuUqFMIiyRb0//EIlFckoycjJySVUz2ZMdHSi3",
+	"TmpzjspKbwd9KduBnkV2i6SSpSFY274V0kKvqLSRCS6LSpYA0nm062gnehyNJmSkyMnI8aOdR49jA0m9",
+	"hHe9Q8hIHZe7Oth2CL1iQADLlLraBkb1I1j4b1iYwm2YyrAwah4cvQ61Weq6FqatOnR9cIBWqDskKtMV",
+	"BBeM5bWf0rwJlvWtaQjuQrBgTOcbSOgvfXO8vjirF27pGwsokX19SR8eb4B148ZDZiz78DHYMh483t5E",
+	"5UZsRM4KbcM82N4Y1oesnjGTeK1OaEnbRhTjvUVHA8vb6/n69ReOjLU2RhoBkTQcCWOTB35KR8mfVPeA",
+	"qjlO2ewlQMJby7WZFdp1B2mWWcuwofuQB7QVBI7Kr0Ow3CMksyJ+F50YacfLJ9z5OT00G7B0ffRa825b",
+	"2ph5cAWUyXJ8u2+hHdm6A8HUT+lomOZybNQK7TbtLDdlrSSCaZk0OUJOaORvonrK7ouRERQhJeI/Tp53",
+	"E/DfZLk3KbZ9K2Sy+ByAm7yiXUc7jxw7drQTIWL1NjqBg2sCcIwKDfBrTsTHNSjb/lOWUxFWjBCXgRhu",
+	"/JBLSrgipZA3c4xEWMgfXZwgeKg+DDyosr9dIA0LdwTXZ50MXEc+62wVspelYMjSvTuFrOuvDtC6/hoK",
+	"Nl5vDR5saXGvscZr0+EH2V5jzSdkxQMPOb8XaHLGhihMcCts7wg/apKl5AUaLuRN7GeW8Sf29kNl/Wo/",
+	"CYD8uAtx05Fj4Wg6KZuTpinxlqZGzucFK9zQwsQo9bx6K9CY482XktIXkC10IftryjEhh/hMcgtDayQT",
+	"3jo4wpUPAg5SBrRByBoyV0hzFaw1QdWlGq0eszQinwemggVVb4dKj270W5rdh8FaVELsEXJJtCra9MIM",
+	"idE/6by88Ho3LijFYQBs2uF+vSg/kVZFYokLmUxSimON2tEryv/rZ1qDbk8fvjumvTqEXMfQOxrWUbeJ",
+	"Le4mXX/z9YXFSH8sciJwma3B4mxUxYPCddq6cBsBRagYNdsqoXYDK7MIrk/2FC7tJWakUQxIGQP1Bm9M",
+	"VoznSAvD892xSDaXSglKnx8+iWUeiUVUoTfraETWjcZyOxAdZv87rhfRPJRinfnRxsj5dlJGgiJBKE8+",
+	"icpLHqFzZRyPwdHiEDXpcg5g2tb0SBrrFZDCb+xeWOPRhje47pmBr/qpMTmPbERuA1DP08c7jcn5KIpi",
+	"Ve7p+bl2qI01wE0IbqJpQNks6hsnzdj5Vi7iA7vtHsfS5WonKSX5yJFjnayN0NnJNUaZoDd/ArmnJyv6",
+	"zNDJFbyOIUM3quSqf6/q2+1+ll4YXeS0vT7cmBqNkkYT9Fs23sm1l0io/wI69eqjuIIqJptCdMMHIkdN",
+	"UgBcqtw6VK3pmB0pAnfTzea6wKInIk0+KoXdUgouxO5EO3Rc/Vm+eDrR/35agmlljITw22eNmS2r1TQs",
+	"bFqrQ6UUldeuGFNAJOHv8kUfCYuiZjbP4EWE88x80t57wzmtcAvhkxN7SY92wqm+MAzBnN3xBFw7zOzx",
+	"HozRQfQSWk1GzqrBCbIw2SnGRJkitpKj8XKJAEuMl6jVELwdj26Ncq32UrMNIvYnUGUmuE+2jphQ9tck",
+	"kMrwL8OwaAALbBphxmOgVwCytbYG6guADQBbDd2/+P40OkQ6OIIjwK4k3JSvSXUKY/hfiOPZrdDflbyE",
+	"c5BlwInOz/YHHIbRFv8MIskigR0KJsWsJebLpSCfKJykwlmIEgR3nfKhbPXWD8Hs+kDBLZ5oGwnaBhc1",
+	"k9BuoA6mpkvJgmC2VbPGMyafoAyPQ+baJII7nw7baRTwwPmAVa8wCfPAFWCCoELy/BCUkChBvilYMuf1",
+	"CC3cvP0AyKxjH0BmORvT8wSYfYWHRc+T25t3P4orG5xA/vsTCDCTBMJLL8Wuh/IXW7ykp+f6kKBin52J",
+	"CmNitb5wyxQ39yG4hySES35oYxxYwLJx97rxiJQZLZgCxhaAzJUuzPzkChREA46kL36YBLuwYbBM2cyM",
+	"SenvSrU/7gVKSI6kstDO5Gc/mBjhVb3xRYhrM20RcrhYwl6JM87QlDFI3/JsgAqnp7nKrrOJvPbpY55G",
+	"o3YwtjAOtce4DA2Z9y5/o/HgoT5aMqZnkHq8M4OJCalHlgpdgUDiohBrn9ug3Co28KNIXLtPWrNTfShm",
+	"1S/lRN/u7bvjnoH+/n632u3/gDzg6ozPpTrPFjr3j1WoH2NlO+LSJhhm+NPkRB57dlw17xcICpL53meA",
+	"2dWZDPFUWDmyG8zhXsdFAKDix2okzM2LojkuPwhjoZpLPbCOtWNF4fjKif19MFEDaOOwxtmaINkTcOMy",
+	"mN2wr1f01X4k8Yn12z3T/iSn8PAlKp7EY7OyR2SQP3to5BfRB/MAbeg8ZPPh7Yq+Kda+PIF32axPwIYk",
+	"cn6ZR3GmqIxnIeVZEJRIxRHtMWI2FLNtVv8yPjO9ajrZQ89QUb5vQvNrs5Xhny6X+bGA6mMB1b9oAVXr",
+	"daoHpy71INWhHpy60w+IFVsTVlBkBOV73qGGjHPTtRdPcHTlDfUbC0NR0njOLLMvY/J+ivxLpAufwsKj",
+	"xuOH22/eoMBwXvufEJQvULeTuKCFIep84tvI7Pp9fMm2S2f5Mc2vDnQ0637xIS1Sb2diXrSDsWU++nQ7",
+	"NjxZNHpsTGpOopsn+OEU82yEeaoB+WGTENwmAVrrpIgxNKwPj5v9hCzTz7zUocy71AGXpNqr0QE6ZlKr",
+	"TOrXN0g0xdlia8aajPhu3xw3kysVznEc913eAZlUXDqEKbLZEQzj95Htt9OwsIAn+cO89BfmNbP+6GJf",
+	"W0cb7eeG/oBguV6+a69CGzZ585IoJETFZs7/PPJjVlSO4HYDLbqNux8I4tykFSoa1LW7EFjtqL184L47",
+	"iNwn4TrJ037gxAbtwee5j/XQiROMfp4g8fiqHeRCjYCQbePerHFznvAouTvIe9EJ1MbO/XDhxzRun7rY",
+	"RT1D500hTK6SKap1DKOPlvRZFNCtv9vAHuUzY3qI6M5Gfrb2cpR8pt38aNUGyXugfOv25gQHNFD2jIaT",
+	"FPRXd9rkRGenM9brvC+Js3Ii8OgAeeBetqa5Ryg8xDYDwLGGJai9QKLXkZv1oK5COcoanqacK1R+5YG7",
+	"2d+bcQhGan9MoVrgPPB2yTcbfFa7qLGDHP8/MHu8wrGcNdSc+XHJ9LK9ILmOg6PmlZ4WhJ55UVSIgW2U",
+	"RwYopU4Ll1HaikCK3LCBYfPsId5uEkcpbJogLOPPTmVDKvVcoR1Q0avv6s8e63MT1mFGPiBl77J3S7Ph",
+	"ewH8whRhNZsXPFvX2dCbxML+dng1n+P2if1QfK4bHXjGMcY7OX97UI1jnA22NJ6L7kum1DflLVN9sKeR",
+	"ZjdcB6Akgl42ZOU2TNBoJTzhpsNiKlh3bYUwFdhW9vxT/M720KY6rzgVXcVq9x7FZpYJdeEpacxOLtMg",
+	"4gkfaDEft7rqspdR+BzXYV6qupuHIYBG8VxmeQJKCZdx+1UqRYnHRJVuYdNlEdK2moVNbxttZ4f5Ckk2",
+	"G3PT2G1ij+F0ecNpxvSSsZFnEYQ04ZNCbbyEguGgUl+a019VsMrawsSPuNOU45NMZSx7qwGrl3d8LUWT",
+	"QPs/7LsG/nzxduaui9bPC7V2jYVn7re/b28M1/MDaI8Y8eK664h30BNtywUp4QBqP9OcvjdmcIUZRyx8",
+	"DDXtisAPwK1//MlfF3RcpZ9I/S6+QSSoFI4zOS8rSfgFiS7PRSKm4l22HiffE1HLKgtb7M0N4V5tjo4k",
+	"+uAIBGvWvTVITBc3zfnJDSBV5jIRCBYdjU0Yf6Z2fx3pC9NlYAU470KTa0ho41tvHJ6wrTfMvi0ldDHO",
+	"5oBlpdnet9Nx1IuDxsSKXVHn68o6lZPjJqzWvJpJy9vmrhq9Mjhiar2gOj5ML6xoCFXTYZHcexV1xMK7",
+	"WT50WyGkg+VzOQC5dsjxMPtd/GuCQjlgH0aNcI1zvCPmERTOrh0Od4wjDGhUjRc0Gto394zLFpxVkHgL",
+	"6y0dBEeO3MpE9EkehAScdf8Ot9Yn6w+n76/SS9T6iVJPiqpv+36kcxzByDL6r2lIU9Gwnl1L7r7QdxFJ",
+	"YG3IkVMC1draZn1lGt/i+MAoATqAdX6PCRaiSWhkEz0TdUJSxWdzaLsv+lph03XXkb3Tzx4yAZCZHQb+",
+	"PEtkWlBwV8MPL1ghUeL3Wq6eR6l+hTfHJ2PGUab2Pba7WR95gkMdeAGkMnTPRdWBDyAdnogR3kZ+ljqg",
+	"6hGFL56u1V6sQm3MlQi0CiQ8URzzfhHE1WQUVvvlgd1jlJj+KJ1d2OSmswub7nxFYdMhHMxEjm8rwP1m",
+	"qc49S98SXJPt8snaHhzWPRRMQ3AZWOAhqPFLAcyjj47UFnHMkXt/nCdGiQKn2Plk7wCiXqD7QVJw6/aP",
+	"8Zf60Agxpneq/Xy0mQlVM21GLj7aW9aL/WtXljjvmtoP/y5ANBG6IST5Jyor+WgLHUpbiFBjix5UR0K6",
+	"LGUlOR1QdFNfWLKdKTPsp6+McqwgHP/DjoUtdK0HQdUeCWXJbuIUAq6e2BjDbo0VMaUU4CyrGY5y8lTb",
+	"m/Pb68NMPqpKIrDt7smZqhUMYtOqFXww7LZxfwsCVErDwN5yvQpzp2vf6UTWFb51uYW0lbYN+bILEvPd",
+	"CkGS63Vy9MU1Aok+G6UhfPE8ztTNXa/PvmX2iVnfflW8MCCU2Z3brSqXr6TLUiJc/SZLxuzO2zmufVX1",
+	"LHacRTZO2v5zFNmQfdvPKpuvqJQM1B4Y9Qe0zqaJ2o+i38AWracubDqyJ7TN40fL4NBGSTBltmoZJKW0",
+	"SC8T4odSGFETJSl+y5hod9X70d9pfW87ycnp66u1e0jr199tEZ1iybTa/IQ+UKRqYAXdxGBGXckRmHEr",
+	"TOPIKJZxiGXduiLCmFykdrkpzyv1hSU2/GkVdbUT+kL2vOsNfWXUekMfKLZj//c2dhUX2dkLN81DN5WE",
+	"mFEvIVPk5auas9wUty6d2N6cxbbKIjq6QJdarT9/hFBAFgyqKA0DKnp+zqkj6eOFTfPBBzhQ/BBqJTqr",
+	"w7Ygk2H+X7TQTF8i514HinYUGJRQTBrM2nXNpgOuKrk0Or5ot29igTJfX9bfLdXGVqnBRsbRNNrlyjQh",
+	"j+kbCxiUIbZ9Htc11zSCQbSVdFHYR9eGgwNjZyjpNlHy5uBlx2Ume6zkAzbUZI1hUsJM9pLcHcQrs8H7",
+	"zy806mJLmT5pVsm0B+e58P4EyToXvx/kUpuPAcgW9RHZ21AVPuSC1ADn9DehV5HacLMcKl9xpP2N6ZWs",
+	"NW3i49c/h1y/+oH65zhv2d3j/jmuO3S5O+tE3cfmObvc4SMIvQxLmOTP44mOq+btwUGdc/hXFTdtm8NT",
+	"rY77iMPEv034DmzuybGiEGyw771u/HbzsDa6CUKvRz9wmSGrCmq2Iy6pkpjtuGreUOzPEY6MlWlokjbq",
+	"7O2PsLBZu7NaG79tl24WNh2dOG5et9K49q2M9DK7ij44UC+v8S77q718hi/Hq5Jr8WyLNz9H/AFXhMuv",
+	"HTztXzg4Yt62F3itBmr5Lql95D7MZqEvXk4v+hfjMWjnm8XMtdD+bM7p85IRVFVU0Hj/73znkc+6r/6l",
+	"/9/2I/lMsOJra5Ed23NtF7ANB8D6dB64t09lsVAfBnuUJw3IhnvED5Y0POGTUcQeMY7SfUgCBUqfRuFt",
+	"A/yub0zXpkvkhtAACHDszbqbc+HgiJLvrQWHEijsom1K7uw6cuJTP4nSijRxSpJo5/muI591/9f5riPH",
+	"u7FY+a8T5zuPfNrd/lG2hNqRAyxcWLAPgXBxYLl14aJKSTFrhWX/2d9x9Up/x9W+/qOpy2qTrn6OnKw2",
+	"9q2QuShfafsPMa7KStsPUlKMfvsfP7STO/9Ql3lvm7//xrbZHGq2groWVQkYRKygu3NRz9tZjA+AL+t9",
+	"A0FVSsTaEAPH2sx+W7E23HsNX9aNW7/F2hyN0Fx/XkBvI6lHOgiioB9AVi0J7m7ZLXZ9bghuJu9KJO9o",
+	"pRO1MSIASajUmNJwNHCx9uiFMXutuRjEDI6w2UwA8m7gPXHk2DEf6ffPQNHnc7PuiXBXj1Ek/ifplMif",
+	"/0rw/C1edkZn/L9BM/btfMbWJPjldOJoCnPDkcuYG44gLnNKCcshvSilBRxfdWsNTmRiCjvwW0zgaXnv",
+	"4yPmzATVh/A4IA+NHlGJ5SIVlZdEIaleYqShk0m/wT+fuiTGf4l8QE1PpgmNEVAyVmZJgoqc62Y7yWCq",
+	"OfbZvkXVGuCmPnePkMzxvSeZ35HjX3xau1PeXh/Rb1WD4weFu1iMv0ZaSFsk9dUMpVDq6O4ngyiX+eK5",
+	"dn8dnTkv3CaXMOaUZORkpCPS322N5L08lz8xFWd0Xr8+gLXK49rooCutlOU8jmPbfuFsd5gt6zsfM4K7",
+	"Fsfdmpo3yDfHnXp1ibTltV+12u973+VydGNgZHvrsf0+YWhfbBET2XG+PRvp7+7//wMAeaWqq/nSAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
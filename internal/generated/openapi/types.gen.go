@@ -4,28 +4,153 @@
 package openapi
 
 import (
+	"encoding/json"
+	"errors"
 	"time"
 
+	"github.com/oapi-codegen/runtime"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for ClusterFeatureType.
+const (
+	ClusterFeatureTypeFeature ClusterFeatureType = "Feature"
+)
+
+// Defines values for ClusterFeatureCollectionType.
+const (
+	FeatureCollection ClusterFeatureCollectionType = "FeatureCollection"
+)
+
+// Defines values for ClusterJobStatus.
+const (
+	ClusterJobStatusCancelled  ClusterJobStatus = "cancelled"
+	ClusterJobStatusCompleted  ClusterJobStatus = "completed"
+	ClusterJobStatusFailed     ClusterJobStatus = "failed"
+	ClusterJobStatusPending    ClusterJobStatus = "pending"
+	ClusterJobStatusProcessing ClusterJobStatus = "processing"
+)
+
+// Defines values for ExportRequestFormat.
+const (
+	ExportRequestFormatCsv     ExportRequestFormat = "csv"
+	ExportRequestFormatGeojson ExportRequestFormat = "geojson"
+	ExportRequestFormatGpkg    ExportRequestFormat = "gpkg"
+	ExportRequestFormatKml     ExportRequestFormat = "kml"
+)
+
+// Defines values for ExportStatusFormat.
+const (
+	ExportStatusFormatCsv     ExportStatusFormat = "csv"
+	ExportStatusFormatGeojson ExportStatusFormat = "geojson"
+	ExportStatusFormatGpkg    ExportStatusFormat = "gpkg"
+	ExportStatusFormatKml     ExportStatusFormat = "kml"
+)
+
+// Defines values for ExportStatusStatus.
+const (
+	ExportStatusStatusCompleted  ExportStatusStatus = "completed"
+	ExportStatusStatusFailed     ExportStatusStatus = "failed"
+	ExportStatusStatusPending    ExportStatusStatus = "pending"
+	ExportStatusStatusProcessing ExportStatusStatus = "processing"
+)
+
+// Defines values for FieldFeatureType.
+const (
+	FieldFeatureTypeFeature FieldFeatureType = "Feature"
+)
+
+// Defines values for FieldLineageEdgeType.
+const (
+	Division FieldLineageEdgeType = "division"
+	Merger   FieldLineageEdgeType = "merger"
+)
+
+// Defines values for FieldOverlapStatus.
+const (
+	FieldOverlapStatusAccepted FieldOverlapStatus = "accepted"
+	FieldOverlapStatusClipped  FieldOverlapStatus = "clipped"
+	FieldOverlapStatusOpen     FieldOverlapStatus = "open"
+)
+
+// Defines values for FieldOverlapResolveRequestAction.
+const (
+	Accept FieldOverlapResolveRequestAction = "accept"
+	Clip   FieldOverlapResolveRequestAction = "clip"
+)
+
+// Defines values for FieldStatsScope.
+const (
+	City       FieldStatsScope = "city"
+	Prefecture FieldStatsScope = "prefecture"
+)
+
+// Defines values for GeoJSONMultiPolygonType.
+const (
+	MultiPolygon GeoJSONMultiPolygonType = "MultiPolygon"
+)
+
+// Defines values for GeoJSONPointType.
+const (
+	Point GeoJSONPointType = "Point"
+)
+
+// Defines values for GeoJSONPolygonType.
+const (
+	Polygon GeoJSONPolygonType = "Polygon"
+)
+
 // Defines values for ImportStatusStatus.
 const (
-	Completed          ImportStatusStatus = "completed"
-	Failed             ImportStatusStatus = "failed"
-	PartiallyCompleted ImportStatusStatus = "partially_completed"
-	Pending            ImportStatusStatus = "pending"
-	Processing         ImportStatusStatus = "processing"
+	ImportStatusStatusCompleted          ImportStatusStatus = "completed"
+	ImportStatusStatusFailed             ImportStatusStatus = "failed"
+	ImportStatusStatusPartiallyCompleted ImportStatusStatus = "partially_completed"
+	ImportStatusStatusPending            ImportStatusStatus = "pending"
+	ImportStatusStatusProcessing         ImportStatusStatus = "processing"
+)
+
+// Defines values for GetClustersParamsFormat.
+const (
+	Geojson GetClustersParamsFormat = "geojson"
+	Json    GetClustersParamsFormat = "json"
+)
+
+// Defines values for ListClusterJobsParamsStatus.
+const (
+	ListClusterJobsParamsStatusCancelled  ListClusterJobsParamsStatus = "cancelled"
+	ListClusterJobsParamsStatusCompleted  ListClusterJobsParamsStatus = "completed"
+	ListClusterJobsParamsStatusFailed     ListClusterJobsParamsStatus = "failed"
+	ListClusterJobsParamsStatusPending    ListClusterJobsParamsStatus = "pending"
+	ListClusterJobsParamsStatusProcessing ListClusterJobsParamsStatus = "processing"
+)
+
+// Defines values for ListFieldOverlapsParamsStatus.
+const (
+	ListFieldOverlapsParamsStatusAccepted ListFieldOverlapsParamsStatus = "accepted"
+	ListFieldOverlapsParamsStatusClipped  ListFieldOverlapsParamsStatus = "clipped"
+	ListFieldOverlapsParamsStatusOpen     ListFieldOverlapsParamsStatus = "open"
 )
 
 // Cluster defines model for Cluster.
 type Cluster struct {
+	// AreaSqm クラスターに含まれる圃場の合計面積(平方メートル、被覆モードではセルに含まれる部分の面積)
+	AreaSqm float64 `json:"areaSqm"`
+
 	// Count クラスターに含まれる圃場数
 	Count int `json:"count"`
 
+	// DominantSoilLargeCode 合計面積が最大の土壌大分類コード(土壌が未登録の場合はnull)
+	DominantSoilLargeCode *string `json:"dominantSoilLargeCode"`
+
 	// H3Index H3インデックス(16進数文字列)
 	H3Index string `json:"h3Index"`
 
+	// IdleCount 遊休農地の状況が登録されている圃場数
+	IdleCount int `json:"idleCount"`
+
+	// LandCategoryCounts 土地種別コード(田/畑など)ごとの圃場数
+	LandCategoryCounts map[string]int `json:"landCategoryCounts"`
+
 	// Lat クラスター中心の緯度
 	Lat float64 `json:"lat"`
 
@@ -33,6 +158,85 @@ type Cluster struct {
 	Lng float64 `json:"lng"`
 }
 
+// ClusterFeature クラスターのGeoJSON Feature(IDはH3インデックス)
+type ClusterFeature struct {
+	// Geometry セルの境界。日付変更線をまたぐセルは日付変更線で分割したMultiPolygon
+	Geometry ClusterFeature_Geometry `json:"geometry"`
+
+	// Id H3インデックス(16進数文字列)
+	Id         string             `json:"id"`
+	Properties Cluster            `json:"properties"`
+	Type       ClusterFeatureType `json:"type"`
+}
+
+// ClusterFeature_Geometry セルの境界。日付変更線をまたぐセルは日付変更線で分割したMultiPolygon
+type ClusterFeature_Geometry struct {
+	union json.RawMessage
+}
+
+// ClusterFeatureType defines model for ClusterFeature.Type.
+type ClusterFeatureType string
+
+// ClusterFeatureCollection クラスターの六角形セルのGeoJSON FeatureCollection
+type ClusterFeatureCollection struct {
+	Features []ClusterFeature `json:"features"`
+
+	// IsStale クラスターが再計算中かどうか
+	IsStale bool                         `json:"isStale"`
+	Type    ClusterFeatureCollectionType `json:"type"`
+}
+
+// ClusterFeatureCollectionType defines model for ClusterFeatureCollection.Type.
+type ClusterFeatureCollectionType string
+
+// ClusterJob defines model for ClusterJob.
+type ClusterJob struct {
+	// AffectedCellCount 差分再計算の対象セル数(全範囲再計算の場合は0)
+	AffectedCellCount int `json:"affectedCellCount"`
+
+	// Attempts 実行回数(リース期限切れによる再実行を含む)
+	Attempts int `json:"attempts"`
+
+	// CompletedAt 完了日時(失敗・取り消し時はその日時)
+	CompletedAt *time.Time `json:"completedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+
+	// DurationSeconds 処理時間(秒)。処理中の場合は開始からの経過時間、未開始の場合はnull
+	DurationSeconds *float64 `json:"durationSeconds"`
+	ErrorMessage    *string  `json:"errorMessage"`
+
+	// FullRecalculation 全範囲再計算かどうか
+	FullRecalculation bool               `json:"fullRecalculation"`
+	Id                openapi_types.UUID `json:"id"`
+
+	// Priority 優先度(高いほど先に処理)
+	Priority  int        `json:"priority"`
+	StartedAt *time.Time `json:"startedAt"`
+
+	// Status ジョブステータス
+	Status ClusterJobStatus `json:"status"`
+
+	// WorkerId ジョブを取得したワーカーのID
+	WorkerId *string `json:"workerId"`
+}
+
+// ClusterJobStatus ジョブステータス
+type ClusterJobStatus string
+
+// ClusterJobListResponse defines model for ClusterJobListResponse.
+type ClusterJobListResponse struct {
+	Jobs  []ClusterJob `json:"jobs"`
+	Total int          `json:"total"`
+}
+
+// ClusterJobRetryResponse defines model for ClusterJobRetryResponse.
+type ClusterJobRetryResponse struct {
+	Job ClusterJob `json:"job"`
+
+	// Merged 保留中の既存ジョブに統合されたかどうか
+	Merged bool `json:"merged"`
+}
+
 // ClusterListResponse defines model for ClusterListResponse.
 type ClusterListResponse struct {
 	Clusters []Cluster `json:"clusters"`
@@ -41,16 +245,87 @@ type ClusterListResponse struct {
 	IsStale bool `json:"isStale"`
 }
 
+// CodeName マスタのコードと名称
+type CodeName struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 }
 
+// ExportBoundingBox 圃場ジオメトリと交差する範囲で絞り込む
+type ExportBoundingBox struct {
+	// NeLat 北東端の緯度
+	NeLat float64 `json:"neLat"`
+
+	// NeLng 北東端の経度
+	NeLng float64 `json:"neLng"`
+
+	// SwLat 南西端の緯度
+	SwLat float64 `json:"swLat"`
+
+	// SwLng 南西端の経度
+	SwLng float64 `json:"swLng"`
+}
+
+// ExportRequest defines model for ExportRequest.
+type ExportRequest struct {
+	// Bbox 圃場ジオメトリと交差する範囲で絞り込む
+	Bbox *ExportBoundingBox `json:"bbox,omitempty"`
+
+	// CityCode 市区町村コードで絞り込む
+	CityCode *string `json:"cityCode,omitempty"`
+
+	// Format 出力形式(csvはジオメトリをWKT列として出力)
+	Format ExportRequestFormat `json:"format"`
+
+	// SoilTypeCode 土壌小分類コードで絞り込む
+	SoilTypeCode *string `json:"soilTypeCode,omitempty"`
+}
+
+// ExportRequestFormat 出力形式(csvはジオメトリをWKT列として出力)
+type ExportRequestFormat string
+
+// ExportResponse defines model for ExportResponse.
+type ExportResponse struct {
+	// ExportId エクスポートジョブID
+	ExportId openapi_types.UUID `json:"exportId"`
+}
+
+// ExportStatus defines model for ExportStatus.
+type ExportStatus struct {
+	CompletedAt *time.Time `json:"completedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+
+	// DownloadUrl 署名付きダウンロードURL(完了時のみ、有効期限あり)
+	DownloadUrl  *string            `json:"downloadUrl"`
+	ErrorMessage *string            `json:"errorMessage"`
+	Format       ExportStatusFormat `json:"format"`
+	Id           openapi_types.UUID `json:"id"`
+	StartedAt    *time.Time         `json:"startedAt"`
+	Status       ExportStatusStatus `json:"status"`
+
+	// TotalRecords 出力した圃場数
+	TotalRecords *int `json:"totalRecords"`
+}
+
+// ExportStatusFormat defines model for ExportStatus.Format.
+type ExportStatusFormat string
+
+// ExportStatusStatus defines model for ExportStatus.Status.
+type ExportStatusStatus string
+
 // Field defines model for Field.
 type Field struct {
 	// AreaHa 面積(ヘクタール)
-	AreaHa      *float64           `json:"areaHa,omitempty"`
+	AreaHa *float64 `json:"areaHa,omitempty"`
+
+	// CityCode 市区町村コード
+	CityCode    string             `json:"cityCode"`
 	CreatedAt   time.Time          `json:"createdAt"`
 	Description *string            `json:"description,omitempty"`
 	Id          openapi_types.UUID `json:"id"`
@@ -58,12 +333,298 @@ type Field struct {
 	UpdatedAt   time.Time          `json:"updatedAt"`
 }
 
+// FieldCreateRequest defines model for FieldCreateRequest.
+type FieldCreateRequest struct {
+	// CityCode 市区町村コード
+	CityCode string         `json:"cityCode"`
+	Geometry GeoJSONPolygon `json:"geometry"`
+
+	// Name 圃場名(省略時は「名称不明」)
+	Name *string `json:"name,omitempty"`
+}
+
+// FieldDivideRequest defines model for FieldDivideRequest.
+type FieldDivideRequest struct {
+	Children []FieldDivisionChild `json:"children"`
+
+	// Reason 分筆の理由/備考
+	Reason *string `json:"reason,omitempty"`
+}
+
+// FieldDivisionChild defines model for FieldDivisionChild.
+type FieldDivisionChild struct {
+	Geometry GeoJSONPolygon `json:"geometry"`
+
+	// LandRegistryIds この子圃場に付け替える親圃場の農地台帳ID
+	LandRegistryIds *[]openapi_types.UUID `json:"landRegistryIds,omitempty"`
+}
+
+// FieldDivisionResponse defines model for FieldDivisionResponse.
+type FieldDivisionResponse struct {
+	// Children 作成された子圃場(リクエストの順序)
+	Children      []FieldFeature     `json:"children"`
+	DividedAt     time.Time          `json:"dividedAt"`
+	ParentFieldId openapi_types.UUID `json:"parentFieldId"`
+	Reason        *string            `json:"reason,omitempty"`
+}
+
+// FieldFeature 圃場詳細のGeoJSON Feature
+type FieldFeature struct {
+	// Geometry 単一区画の圃場はPolygon、飛び地など複数区画からなる圃場はMultiPolygon
+	Geometry   FieldFeature_Geometry `json:"geometry"`
+	Id         openapi_types.UUID    `json:"id"`
+	Properties FieldProperties       `json:"properties"`
+	Type       FieldFeatureType      `json:"type"`
+}
+
+// FieldFeature_Geometry 単一区画の圃場はPolygon、飛び地など複数区画からなる圃場はMultiPolygon
+type FieldFeature_Geometry struct {
+	union json.RawMessage
+}
+
+// FieldFeatureType defines model for FieldFeature.Type.
+type FieldFeatureType string
+
+// FieldH3Indexes 圃場重心のH3インデックス
+type FieldH3Indexes struct {
+	Res3 *string `json:"res3,omitempty"`
+	Res5 *string `json:"res5,omitempty"`
+	Res7 *string `json:"res7,omitempty"`
+	Res9 *string `json:"res9,omitempty"`
+}
+
+// FieldLineageEdge defines model for FieldLineageEdge.
+type FieldLineageEdge struct {
+	// FromFieldId 旧圃場(分筆の親圃場・合筆のソース圃場)
+	FromFieldId openapi_types.UUID `json:"fromFieldId"`
+
+	// OccurredAt 分筆・合筆日時
+	OccurredAt time.Time `json:"occurredAt"`
+	Reason     *string   `json:"reason,omitempty"`
+
+	// ToFieldId 新圃場(分筆の子圃場・合筆先圃場)
+	ToFieldId openapi_types.UUID `json:"toFieldId"`
+
+	// Type division=分筆、merger=合筆
+	Type FieldLineageEdgeType `json:"type"`
+}
+
+// FieldLineageEdgeType division=分筆、merger=合筆
+type FieldLineageEdgeType string
+
+// FieldLineageNode defines model for FieldLineageNode.
+type FieldLineageNode struct {
+	// AreaHa 面積(ヘクタール)
+	AreaHa    *float64  `json:"areaHa,omitempty"`
+	CityCode  string    `json:"cityCode"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Depth 起点圃場からの世代差(祖先は負、子孫は正、起点は0)
+	Depth int                `json:"depth"`
+	Id    openapi_types.UUID `json:"id"`
+	Name  string             `json:"name"`
+
+	// RetiredAt 分筆・合筆による廃止日時(有効な圃場では省略)
+	RetiredAt *time.Time `json:"retiredAt,omitempty"`
+}
+
+// FieldLineageResponse defines model for FieldLineageResponse.
+type FieldLineageResponse struct {
+	// Depth 辿った最大世代数
+	Depth int                `json:"depth"`
+	Edges []FieldLineageEdge `json:"edges"`
+
+	// FieldId 起点とした圃場のID
+	FieldId openapi_types.UUID `json:"fieldId"`
+	Nodes   []FieldLineageNode `json:"nodes"`
+
+	// Truncated 最大世代数より先の履歴が残っているか
+	Truncated bool `json:"truncated"`
+}
+
 // FieldListResponse defines model for FieldListResponse.
 type FieldListResponse struct {
 	Fields []Field `json:"fields"`
 	Total  int     `json:"total"`
 }
 
+// FieldMergeRequest defines model for FieldMergeRequest.
+type FieldMergeRequest struct {
+	// Reason 合筆の理由/備考
+	Reason *string `json:"reason,omitempty"`
+
+	// SourceFieldIds 合筆するソース圃場のID
+	SourceFieldIds []openapi_types.UUID `json:"sourceFieldIds"`
+}
+
+// FieldMergerResponse defines model for FieldMergerResponse.
+type FieldMergerResponse struct {
+	// Field 圃場詳細のGeoJSON Feature
+	Field          FieldFeature         `json:"field"`
+	MergedAt       time.Time            `json:"mergedAt"`
+	Reason         *string              `json:"reason,omitempty"`
+	SourceFieldIds []openapi_types.UUID `json:"sourceFieldIds"`
+}
+
+// FieldOverlap defines model for FieldOverlap.
+type FieldOverlap struct {
+	// ClippedFieldId クリップで形状を修正した圃場のID
+	ClippedFieldId *openapi_types.UUID `json:"clippedFieldId,omitempty"`
+
+	// DetectedAt 最終検知日時
+	DetectedAt time.Time `json:"detectedAt"`
+
+	// FieldIdA 重なっている圃場のうちIDが小さい方
+	FieldIdA openapi_types.UUID `json:"fieldIdA"`
+
+	// FieldIdB 重なっている圃場のうちIDが大きい方
+	FieldIdB openapi_types.UUID `json:"fieldIdB"`
+	Id       openapi_types.UUID `json:"id"`
+
+	// Note 対応時の備考
+	Note *string `json:"note,omitempty"`
+
+	// OverlapAreaSqm 重なり部分の面積(平方メートル)
+	OverlapAreaSqm float64 `json:"overlapAreaSqm"`
+
+	// OverlapRatio 面積が小さい方の圃場に対する重なり面積の割合(0-1)
+	OverlapRatio float64             `json:"overlapRatio"`
+	ResolvedAt   *time.Time          `json:"resolvedAt,omitempty"`
+	ResolvedBy   *openapi_types.UUID `json:"resolvedBy,omitempty"`
+
+	// Status 対応状況(open=未対応, accepted=許容済み, clipped=クリップで解消済み)
+	Status FieldOverlapStatus `json:"status"`
+}
+
+// FieldOverlapStatus 対応状況(open=未対応, accepted=許容済み, clipped=クリップで解消済み)
+type FieldOverlapStatus string
+
+// FieldOverlapListResponse defines model for FieldOverlapListResponse.
+type FieldOverlapListResponse struct {
+	Overlaps []FieldOverlap `json:"overlaps"`
+	Total    int            `json:"total"`
+}
+
+// FieldOverlapResolveRequest defines model for FieldOverlapResolveRequest.
+type FieldOverlapResolveRequest struct {
+	// Action 対応方法(accept=重なりを許容, clip=一方の圃場から重なり部分を取り除く)
+	Action FieldOverlapResolveRequestAction `json:"action"`
+
+	// ClipFieldId クリップする圃場のID(actionがclipの場合は必須)
+	ClipFieldId *openapi_types.UUID `json:"clipFieldId,omitempty"`
+
+	// Note 対応時の備考
+	Note *string `json:"note,omitempty"`
+}
+
+// FieldOverlapResolveRequestAction 対応方法(accept=重なりを許容, clip=一方の圃場から重なり部分を取り除く)
+type FieldOverlapResolveRequestAction string
+
+// FieldProperties defines model for FieldProperties.
+type FieldProperties struct {
+	// AreaHa 面積(ヘクタール)
+	AreaHa *float64 `json:"areaHa,omitempty"`
+
+	// AreaSqm 面積(平方メートル)
+	AreaSqm  *float64      `json:"areaSqm,omitempty"`
+	Centroid *GeoJSONPoint `json:"centroid,omitempty"`
+
+	// CityCode 市区町村コード
+	CityCode  string    `json:"cityCode"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// H3Indexes 圃場重心のH3インデックス
+	H3Indexes      FieldH3Indexes `json:"h3Indexes"`
+	LandRegistries []LandRegistry `json:"landRegistries"`
+	Name           string         `json:"name"`
+
+	// RetiredAt 分筆・合筆・削除により廃止された日時(有効な圃場では省略)
+	RetiredAt *time.Time `json:"retiredAt,omitempty"`
+
+	// SoilType 土壌タイプ(大分類 -> 中分類 -> 小分類)
+	SoilType  *SoilType `json:"soilType,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// FieldStats defines model for FieldStats.
+type FieldStats struct {
+	// Code 市区町村コードまたは都道府県コード
+	Code string `json:"code"`
+
+	// FieldCount 圃場数
+	FieldCount int `json:"fieldCount"`
+
+	// IdleAreaSqm 遊休農地の状況が登録されている圃場の合計面積(平方メートル)
+	IdleAreaSqm float64 `json:"idleAreaSqm"`
+
+	// IdleFieldCount 遊休農地の状況が登録されている圃場数
+	IdleFieldCount int `json:"idleFieldCount"`
+
+	// IdleLandRatio 合計面積に占める遊休農地の面積の割合(0-1)
+	IdleLandRatio float64 `json:"idleLandRatio"`
+
+	// PaddyAreaSqm 主な土地種別(面積が最大の農地台帳の土地種別)が田の圃場の合計面積(平方メートル)
+	PaddyAreaSqm float64 `json:"paddyAreaSqm"`
+
+	// RefreshedAt 集計日時(都道府県の場合は市区町村のうち最も古い集計日時)
+	RefreshedAt time.Time `json:"refreshedAt"`
+
+	// Scope 集計単位
+	Scope FieldStatsScope `json:"scope"`
+
+	// SoilTypes 土壌大分類ごとの内訳(合計面積の降順、土壌が未登録の圃場は含まない)
+	SoilTypes []SoilTypeStats `json:"soilTypes"`
+
+	// TotalAreaSqm 合計面積(平方メートル)
+	TotalAreaSqm float64 `json:"totalAreaSqm"`
+
+	// UplandAreaSqm 主な土地種別が畑の圃場の合計面積(平方メートル)
+	UplandAreaSqm float64 `json:"uplandAreaSqm"`
+}
+
+// FieldStatsScope 集計単位
+type FieldStatsScope string
+
+// FieldUpdateRequest 指定した項目のみ更新する
+type FieldUpdateRequest struct {
+	// CityCode 市区町村コード
+	CityCode *string         `json:"cityCode,omitempty"`
+	Geometry *GeoJSONPolygon `json:"geometry,omitempty"`
+
+	// Name 圃場名
+	Name *string `json:"name,omitempty"`
+}
+
+// GeoJSONMultiPolygon defines model for GeoJSONMultiPolygon.
+type GeoJSONMultiPolygon struct {
+	// Coordinates 区画ごとのリングの配列(各区画は外周リング、内周リングの順)。座標は[経度, 緯度]
+	Coordinates [][][][]float64         `json:"coordinates"`
+	Type        GeoJSONMultiPolygonType `json:"type"`
+}
+
+// GeoJSONMultiPolygonType defines model for GeoJSONMultiPolygon.Type.
+type GeoJSONMultiPolygonType string
+
+// GeoJSONPoint defines model for GeoJSONPoint.
+type GeoJSONPoint struct {
+	// Coordinates 座標([経度, 緯度])
+	Coordinates []float64        `json:"coordinates"`
+	Type        GeoJSONPointType `json:"type"`
+}
+
+// GeoJSONPointType defines model for GeoJSONPoint.Type.
+type GeoJSONPointType string
+
+// GeoJSONPolygon defines model for GeoJSONPolygon.
+type GeoJSONPolygon struct {
+	// Coordinates リングの配列(外周リング、内周リングの順)。座標は[経度, 緯度]
+	Coordinates [][][]float64      `json:"coordinates"`
+	Type        GeoJSONPolygonType `json:"type"`
+}
+
+// GeoJSONPolygonType defines model for GeoJSONPolygon.Type.
+type GeoJSONPolygonType string
+
 // HealthResponse defines model for HealthResponse.
 type HealthResponse struct {
 	Status string `json:"status"`
@@ -104,15 +665,70 @@ type ImportStatus struct {
 // ImportStatusStatus defines model for ImportStatus.Status.
 type ImportStatusStatus string
 
+// LandRegistry 農地台帳
+type LandRegistry struct {
+	// Address 所在地
+	Address *string `json:"address,omitempty"`
+
+	// AreaSqm 面積(平方メートル)
+	AreaSqm *int `json:"areaSqm,omitempty"`
+
+	// DescriptiveStudyDate 実態調査日
+	DescriptiveStudyDate *openapi_types.Date `json:"descriptiveStudyDate,omitempty"`
+
+	// FarmerNumber ハッシュ化された耕作者識別番号
+	FarmerNumber *string            `json:"farmerNumber,omitempty"`
+	Id           openapi_types.UUID `json:"id"`
+
+	// IdleLandStatus マスタのコードと名称
+	IdleLandStatus *CodeName `json:"idleLandStatus,omitempty"`
+
+	// LandCategory マスタのコードと名称
+	LandCategory *CodeName `json:"landCategory,omitempty"`
+}
+
 // RecalculateResponse defines model for RecalculateResponse.
 type RecalculateResponse struct {
-	// Enqueued ジョブがエンキューされたかどうか
+	// Enqueued ジョブがエンキューされたかどうか(保留中ジョブへの統合を含む)
 	Enqueued bool `json:"enqueued"`
 
+	// Merged 保留中の既存ジョブに統合されたかどうか
+	Merged bool `json:"merged"`
+
 	// Message 処理結果メッセージ
 	Message string `json:"message"`
 }
 
+// SoilType 土壌タイプ(大分類 -> 中分類 -> 小分類)
+type SoilType struct {
+	Description *string            `json:"description,omitempty"`
+	Id          openapi_types.UUID `json:"id"`
+
+	// LargeCode 大分類コード
+	LargeCode string `json:"largeCode"`
+
+	// MiddleCode 中分類コード
+	MiddleCode string `json:"middleCode"`
+
+	// SmallCode 小分類コード
+	SmallCode string `json:"smallCode"`
+
+	// SmallName 小分類名
+	SmallName string `json:"smallName"`
+}
+
+// SoilTypeStats defines model for SoilTypeStats.
+type SoilTypeStats struct {
+	// AreaSqm 合計面積(平方メートル)
+	AreaSqm float64 `json:"areaSqm"`
+
+	// FieldCount 圃場数
+	FieldCount int `json:"fieldCount"`
+
+	// LargeCode 土壌大分類コード
+	LargeCode string `json:"largeCode"`
+}
+
 // GetClustersParams defines parameters for GetClusters.
 type GetClustersParams struct {
 	// Zoom Google Mapsのズームレベル(1.0-22.0、少数対応)
@@ -129,13 +745,335 @@ type GetClustersParams struct {
 
 	// NeLng 北東端の経度
 	NeLng float64 `form:"ne_lng" json:"ne_lng"`
+
+	// CityCode 市区町村コード
+	CityCode *string `form:"city_code,omitempty" json:"city_code,omitempty"`
+
+	// SoilType 土壌小分類コード
+	SoilType *string `form:"soil_type,omitempty" json:"soil_type,omitempty"`
+
+	// LandCategory 土地種別コード(農地台帳)
+	LandCategory *string `form:"land_category,omitempty" json:"land_category,omitempty"`
+
+	// IdleStatus 遊休農地状況コード(農地台帳)
+	IdleStatus *string `form:"idle_status,omitempty" json:"idle_status,omitempty"`
+
+	// MinAreaSqm 最小面積(平方メートル)
+	MinAreaSqm *float64 `form:"min_area_sqm,omitempty" json:"min_area_sqm,omitempty"`
+
+	// MaxAreaSqm 最大面積(平方メートル)
+	MaxAreaSqm *float64 `form:"max_area_sqm,omitempty" json:"max_area_sqm,omitempty"`
+
+	// Format レスポンス形式(jsonはクラスター中心の座標、geojsonは六角形セルのFeatureCollection)
+	Format *GetClustersParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetClustersParamsFormat defines parameters for GetClusters.
+type GetClustersParamsFormat string
+
+// ListClusterJobsParams defines parameters for ListClusterJobs.
+type ListClusterJobsParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Status ジョブステータス
+	Status *ListClusterJobsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// CreatedFrom 作成日時の下限(この日時を含む)
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo 作成日時の上限(この日時を含まない)
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
 }
 
+// ListClusterJobsParamsStatus defines parameters for ListClusterJobs.
+type ListClusterJobsParamsStatus string
+
 // ListFieldsParams defines parameters for ListFields.
 type ListFieldsParams struct {
 	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// CityCode 市区町村コード
+	CityCode *string `form:"city_code,omitempty" json:"city_code,omitempty"`
+
+	// SoilType 土壌小分類コード
+	SoilType *string `form:"soil_type,omitempty" json:"soil_type,omitempty"`
+
+	// LandCategory 土地種別コード(農地台帳)
+	LandCategory *string `form:"land_category,omitempty" json:"land_category,omitempty"`
+
+	// IdleStatus 遊休農地状況コード(農地台帳)
+	IdleStatus *string `form:"idle_status,omitempty" json:"idle_status,omitempty"`
+
+	// MinAreaSqm 最小面積(平方メートル)
+	MinAreaSqm *float64 `form:"min_area_sqm,omitempty" json:"min_area_sqm,omitempty"`
+
+	// MaxAreaSqm 最大面積(平方メートル)
+	MaxAreaSqm *float64 `form:"max_area_sqm,omitempty" json:"max_area_sqm,omitempty"`
+
+	// SwLat 南西端の緯度
+	SwLat *float64 `form:"sw_lat,omitempty" json:"sw_lat,omitempty"`
+
+	// SwLng 南西端の経度
+	SwLng *float64 `form:"sw_lng,omitempty" json:"sw_lng,omitempty"`
+
+	// NeLat 北東端の緯度
+	NeLat *float64 `form:"ne_lat,omitempty" json:"ne_lat,omitempty"`
+
+	// NeLng 北東端の経度
+	NeLng *float64 `form:"ne_lng,omitempty" json:"ne_lng,omitempty"`
+
+	// Q 圃場名のあいまい検索キーワード(部分一致とトライグラム類似度。%と_はワイルドカードではなく文字として扱う)
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+}
+
+// CreateFieldParams defines parameters for CreateField.
+type CreateFieldParams struct {
+	// XUserID 操作ユーザーのID。created_by / updated_by に記録される
+	XUserID *openapi_types.UUID `json:"X-User-ID,omitempty"`
+}
+
+// MergeFieldsParams defines parameters for MergeFields.
+type MergeFieldsParams struct {
+	// XUserID 操作ユーザーのID。合筆先圃場のcreated_byと合筆履歴のcreated_byに記録される
+	XUserID *openapi_types.UUID `json:"X-User-ID,omitempty"`
+}
+
+// ListFieldOverlapsParams defines parameters for ListFieldOverlaps.
+type ListFieldOverlapsParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Status 対応状況
+	Status *ListFieldOverlapsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// FieldId 当事者に含まれる圃場のID
+	FieldId *openapi_types.UUID `form:"field_id,omitempty" json:"field_id,omitempty"`
+}
+
+// ListFieldOverlapsParamsStatus defines parameters for ListFieldOverlaps.
+type ListFieldOverlapsParamsStatus string
+
+// ResolveFieldOverlapParams defines parameters for ResolveFieldOverlap.
+type ResolveFieldOverlapParams struct {
+	// XUserID 操作ユーザーのID。オーバーラップの対応者とクリップした圃場のupdated_byに記録される
+	XUserID *openapi_types.UUID `json:"X-User-ID,omitempty"`
+}
+
+// UpdateFieldParams defines parameters for UpdateField.
+type UpdateFieldParams struct {
+	// XUserID 操作ユーザーのID。created_by / updated_by に記録される
+	XUserID *openapi_types.UUID `json:"X-User-ID,omitempty"`
+}
+
+// DivideFieldParams defines parameters for DivideField.
+type DivideFieldParams struct {
+	// XUserID 操作ユーザーのID。子圃場のcreated_byと分筆履歴のcreated_byに記録される
+	XUserID *openapi_types.UUID `json:"X-User-ID,omitempty"`
+}
+
+// GetFieldLineageParams defines parameters for GetFieldLineage.
+type GetFieldLineageParams struct {
+	// Depth 祖先・子孫それぞれに辿る最大世代数
+	Depth *int `form:"depth,omitempty" json:"depth,omitempty"`
 }
 
+// RequestExportJSONRequestBody defines body for RequestExport for application/json ContentType.
+type RequestExportJSONRequestBody = ExportRequest
+
+// CreateFieldJSONRequestBody defines body for CreateField for application/json ContentType.
+type CreateFieldJSONRequestBody = FieldCreateRequest
+
+// MergeFieldsJSONRequestBody defines body for MergeFields for application/json ContentType.
+type MergeFieldsJSONRequestBody = FieldMergeRequest
+
+// ResolveFieldOverlapJSONRequestBody defines body for ResolveFieldOverlap for application/json ContentType.
+type ResolveFieldOverlapJSONRequestBody = FieldOverlapResolveRequest
+
+// UpdateFieldJSONRequestBody defines body for UpdateField for application/json ContentType.
+type UpdateFieldJSONRequestBody = FieldUpdateRequest
+
+// DivideFieldJSONRequestBody defines body for DivideField for application/json ContentType.
+type DivideFieldJSONRequestBody = FieldDivideRequest
+
 // RequestImportJSONRequestBody defines body for RequestImport for application/json ContentType.
 type RequestImportJSONRequestBody = ImportRequest
+
+// AsGeoJSONPolygon returns the union data inside the ClusterFeature_Geometry as a GeoJSONPolygon
+func (t ClusterFeature_Geometry) AsGeoJSONPolygon() (GeoJSONPolygon, error) {
+	var body GeoJSONPolygon
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromGeoJSONPolygon overwrites any union data inside the ClusterFeature_Geometry as the provided GeoJSONPolygon
+func (t *ClusterFeature_Geometry) FromGeoJSONPolygon(v GeoJSONPolygon) error {
+	v.Type = "Polygon"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeGeoJSONPolygon performs a merge with any union data inside the ClusterFeature_Geometry, using the provided GeoJSONPolygon
+func (t *ClusterFeature_Geometry) MergeGeoJSONPolygon(v GeoJSONPolygon) error {
+	v.Type = "Polygon"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsGeoJSONMultiPolygon returns the union data inside the ClusterFeature_Geometry as a GeoJSONMultiPolygon
+func (t ClusterFeature_Geometry) AsGeoJSONMultiPolygon() (GeoJSONMultiPolygon, error) {
+	var body GeoJSONMultiPolygon
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromGeoJSONMultiPolygon overwrites any union data inside the ClusterFeature_Geometry as the provided GeoJSONMultiPolygon
+func (t *ClusterFeature_Geometry) FromGeoJSONMultiPolygon(v GeoJSONMultiPolygon) error {
+	v.Type = "MultiPolygon"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeGeoJSONMultiPolygon performs a merge with any union data inside the ClusterFeature_Geometry, using the provided GeoJSONMultiPolygon
+func (t *ClusterFeature_Geometry) MergeGeoJSONMultiPolygon(v GeoJSONMultiPolygon) error {
+	v.Type = "MultiPolygon"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t ClusterFeature_Geometry) Discriminator() (string, error) {
+	var discriminator struct {
+		Discriminator string `json:"type"`
+	}
+	err := json.Unmarshal(t.union, &discriminator)
+	return discriminator.Discriminator, err
+}
+
+func (t ClusterFeature_Geometry) ValueByDiscriminator() (interface{}, error) {
+	discriminator, err := t.Discriminator()
+	if err != nil {
+		return nil, err
+	}
+	switch discriminator {
+	case "MultiPolygon":
+		return t.AsGeoJSONMultiPolygon()
+	case "Polygon":
+		return t.AsGeoJSONPolygon()
+	default:
+		return nil, errors.New("unknown discriminator value: " + discriminator)
+	}
+}
+
+func (t ClusterFeature_Geometry) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *ClusterFeature_Geometry) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// AsGeoJSONPolygon returns the union data inside the FieldFeature_Geometry as a GeoJSONPolygon
+func (t FieldFeature_Geometry) AsGeoJSONPolygon() (GeoJSONPolygon, error) {
+	var body GeoJSONPolygon
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromGeoJSONPolygon overwrites any union data inside the FieldFeature_Geometry as the provided GeoJSONPolygon
+func (t *FieldFeature_Geometry) FromGeoJSONPolygon(v GeoJSONPolygon) error {
+	v.Type = "Polygon"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeGeoJSONPolygon performs a merge with any union data inside the FieldFeature_Geometry, using the provided GeoJSONPolygon
+func (t *FieldFeature_Geometry) MergeGeoJSONPolygon(v GeoJSONPolygon) error {
+	v.Type = "Polygon"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsGeoJSONMultiPolygon returns the union data inside the FieldFeature_Geometry as a GeoJSONMultiPolygon
+func (t FieldFeature_Geometry) AsGeoJSONMultiPolygon() (GeoJSONMultiPolygon, error) {
+	var body GeoJSONMultiPolygon
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromGeoJSONMultiPolygon overwrites any union data inside the FieldFeature_Geometry as the provided GeoJSONMultiPolygon
+func (t *FieldFeature_Geometry) FromGeoJSONMultiPolygon(v GeoJSONMultiPolygon) error {
+	v.Type = "MultiPolygon"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeGeoJSONMultiPolygon performs a merge with any union data inside the FieldFeature_Geometry, using the provided GeoJSONMultiPolygon
+func (t *FieldFeature_Geometry) MergeGeoJSONMultiPolygon(v GeoJSONMultiPolygon) error {
+	v.Type = "MultiPolygon"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t FieldFeature_Geometry) Discriminator() (string, error) {
+	var discriminator struct {
+		Discriminator string `json:"type"`
+	}
+	err := json.Unmarshal(t.union, &discriminator)
+	return discriminator.Discriminator, err
+}
+
+func (t FieldFeature_Geometry) ValueByDiscriminator() (interface{}, error) {
+	discriminator, err := t.Discriminator()
+	if err != nil {
+		return nil, err
+	}
+	switch discriminator {
+	case "MultiPolygon":
+		return t.AsGeoJSONMultiPolygon()
+	case "Polygon":
+		return t.AsGeoJSONPolygon()
+	default:
+		return nil, errors.New("unknown discriminator value: " + discriminator)
+	}
+}
+
+func (t FieldFeature_Geometry) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *FieldFeature_Geometry) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
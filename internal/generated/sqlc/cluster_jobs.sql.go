@@ -12,25 +12,46 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const createClusterJob = `-- name: CreateClusterJob :one
-INSERT INTO cluster_jobs (
-    id,
-    status,
-    priority,
-    created_at
-)
-VALUES ($1, 'pending', $2, NOW())
-RETURNING id, status, priority, created_at, started_at, completed_at, error_message, affected_h3_cells
+const cancelClusterJob = `-- name: CancelClusterJob :execrows
+UPDATE cluster_jobs
+SET
+    status = 'cancelled',
+    completed_at = NOW()
+WHERE id = $1 AND status = 'pending'
 `
 
-type CreateClusterJobParams struct {
-	ID       uuid.UUID `json:"id"`
-	Priority int32     `json:"priority"`
+// 保留中のジョブを取り消し済みに更新
+// 既にワーカーが取得したジョブは更新しない
+func (q *Queries) CancelClusterJob(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, cancelClusterJob, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-// クラスタージョブを作成
-func (q *Queries) CreateClusterJob(ctx context.Context, arg *CreateClusterJobParams) (*ClusterJob, error) {
-	row := q.db.QueryRow(ctx, createClusterJob, arg.ID, arg.Priority)
+const claimClusterJob = `-- name: ClaimClusterJob :one
+UPDATE cluster_jobs
+SET
+    status = 'processing',
+    started_at = NOW(),
+    worker_id = $1,
+    heartbeat_at = NOW(),
+    attempts = attempts + 1
+WHERE id = (
+    SELECT id FROM cluster_jobs
+    WHERE status = 'pending'
+    ORDER BY priority DESC, created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, status, priority, created_at, started_at, completed_at, error_message, affected_h3_cells, worker_id, heartbeat_at, attempts
+`
+
+// 最も優先度の高い保留中ジョブを処理中に更新し、指定ワーカーのリースとして取得
+// 他のワーカーやエンキューがロック中のジョブはスキップする
+func (q *Queries) ClaimClusterJob(ctx context.Context, workerID *string) (*ClusterJob, error) {
+	row := q.db.QueryRow(ctx, claimClusterJob, workerID)
 	var i ClusterJob
 	err := row.Scan(
 		&i.ID,
@@ -41,94 +62,143 @@ func (q *Queries) CreateClusterJob(ctx context.Context, arg *CreateClusterJobPar
 		&i.CompletedAt,
 		&i.ErrorMessage,
 		&i.AffectedH3Cells,
+		&i.WorkerID,
+		&i.HeartbeatAt,
+		&i.Attempts,
 	)
 	return &i, err
 }
 
-const createClusterJobWithAffectedCells = `-- name: CreateClusterJobWithAffectedCells :one
-INSERT INTO cluster_jobs (
-    id,
-    status,
-    priority,
-    affected_h3_cells,
-    created_at
-)
-VALUES ($1, 'pending', $2, $3, NOW())
-RETURNING id, status, priority, created_at, started_at, completed_at, error_message, affected_h3_cells
+const countClusterJobs = `-- name: CountClusterJobs :one
+SELECT COUNT(*)
+FROM cluster_jobs
+WHERE
+    ($1::VARCHAR IS NULL OR status = $1::VARCHAR)
+    AND ($2::TIMESTAMPTZ IS NULL OR created_at >= $2::TIMESTAMPTZ)
+    AND ($3::TIMESTAMPTZ IS NULL OR created_at < $3::TIMESTAMPTZ)
 `
 
-type CreateClusterJobWithAffectedCellsParams struct {
-	ID              uuid.UUID `json:"id"`
-	Priority        int32     `json:"priority"`
-	AffectedH3Cells []string  `json:"affected_h3_cells"`
+type CountClusterJobsParams struct {
+	Status      *string            `json:"status"`
+	CreatedFrom pgtype.Timestamptz `json:"created_from"`
+	CreatedTo   pgtype.Timestamptz `json:"created_to"`
 }
 
-// 影響セル情報付きでクラスタージョブを作成
-func (q *Queries) CreateClusterJobWithAffectedCells(ctx context.Context, arg *CreateClusterJobWithAffectedCellsParams) (*ClusterJob, error) {
-	row := q.db.QueryRow(ctx, createClusterJobWithAffectedCells, arg.ID, arg.Priority, arg.AffectedH3Cells)
-	var i ClusterJob
-	err := row.Scan(
-		&i.ID,
-		&i.Status,
-		&i.Priority,
-		&i.CreatedAt,
-		&i.StartedAt,
-		&i.CompletedAt,
-		&i.ErrorMessage,
-		&i.AffectedH3Cells,
-	)
-	return &i, err
+// 条件に一致するクラスタージョブの総数を取得(ListClusterJobsと同一条件)
+func (q *Queries) CountClusterJobs(ctx context.Context, arg *CountClusterJobsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countClusterJobs, arg.Status, arg.CreatedFrom, arg.CreatedTo)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const deleteOldCompletedJobs = `-- name: DeleteOldCompletedJobs :exec
+const deleteOldCompletedJobs = `-- name: DeleteOldCompletedJobs :execrows
 DELETE FROM cluster_jobs
-WHERE status = 'completed' AND completed_at < NOW() - INTERVAL '7 days'
+WHERE status = 'completed' AND completed_at < NOW() - make_interval(secs => $1::INT)
 `
 
-// 7日以上前に完了したジョブを削除
-func (q *Queries) DeleteOldCompletedJobs(ctx context.Context) error {
-	_, err := q.db.Exec(ctx, deleteOldCompletedJobs)
-	return err
+// 保持期間を過ぎた完了済みジョブを削除
+func (q *Queries) DeleteOldCompletedJobs(ctx context.Context, retentionSeconds int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOldCompletedJobs, retentionSeconds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const deleteOldFailedJobs = `-- name: DeleteOldFailedJobs :exec
+const deleteOldFailedJobs = `-- name: DeleteOldFailedJobs :execrows
 DELETE FROM cluster_jobs
-WHERE status = 'failed' AND completed_at < NOW() - INTERVAL '30 days'
+WHERE status IN ('failed', 'cancelled') AND completed_at < NOW() - make_interval(secs => $1::INT)
 `
 
-// 30日以上前に失敗したジョブを削除
-func (q *Queries) DeleteOldFailedJobs(ctx context.Context) error {
-	_, err := q.db.Exec(ctx, deleteOldFailedJobs)
-	return err
+// 保持期間を過ぎた失敗・取り消し済みジョブを削除
+func (q *Queries) DeleteOldFailedJobs(ctx context.Context, retentionSeconds int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOldFailedJobs, retentionSeconds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const getClusterJob = `-- name: GetClusterJob :one
-SELECT
+const enqueueClusterJob = `-- name: EnqueueClusterJob :one
+INSERT INTO cluster_jobs AS cj (
     id,
     status,
     priority,
-    created_at,
-    started_at,
-    completed_at,
-    error_message
-FROM cluster_jobs
-WHERE id = $1
+    affected_h3_cells,
+    created_at
+)
+VALUES ($1, 'pending', $2, $3, NOW())
+ON CONFLICT (status) WHERE status = 'pending'
+DO UPDATE SET
+    priority = GREATEST(cj.priority, EXCLUDED.priority),
+    affected_h3_cells = CASE
+        WHEN cj.affected_h3_cells IS NULL OR EXCLUDED.affected_h3_cells IS NULL THEN NULL
+        WHEN (
+            SELECT COUNT(DISTINCT c)
+            FROM unnest(cj.affected_h3_cells || EXCLUDED.affected_h3_cells) AS c
+        ) > $4::INT THEN NULL
+        ELSE ARRAY(
+            SELECT DISTINCT c
+            FROM unnest(cj.affected_h3_cells || EXCLUDED.affected_h3_cells) AS c
+            ORDER BY c
+        )
+    END
+RETURNING
+    cj.id,
+    cj.status,
+    cj.priority,
+    cj.affected_h3_cells,
+    cj.created_at,
+    (cj.id <> $1) AS merged
 `
 
-type GetClusterJobRow struct {
-	ID           uuid.UUID          `json:"id"`
-	Status       string             `json:"status"`
-	Priority     int32              `json:"priority"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
-	StartedAt    pgtype.Timestamptz `json:"started_at"`
-	CompletedAt  pgtype.Timestamptz `json:"completed_at"`
-	ErrorMessage *string            `json:"error_message"`
+type EnqueueClusterJobParams struct {
+	ID               uuid.UUID `json:"id"`
+	Priority         int32     `json:"priority"`
+	AffectedH3Cells  []string  `json:"affected_h3_cells"`
+	MaxAffectedCells int32     `json:"max_affected_cells"`
+}
+
+type EnqueueClusterJobRow struct {
+	ID              uuid.UUID          `json:"id"`
+	Status          string             `json:"status"`
+	Priority        int32              `json:"priority"`
+	AffectedH3Cells []string           `json:"affected_h3_cells"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	Merged          bool               `json:"merged"`
+}
+
+// クラスタージョブをエンキューする
+// 保留中ジョブは部分ユニークインデックスで1件に限定されており、既に存在する場合は新規作成せずに統合する
+// 統合時は優先度の高い方を採用し、どちらかが全範囲再計算(NULL)か統合後のセル数が上限を超える場合は全範囲再計算に昇格する
+func (q *Queries) EnqueueClusterJob(ctx context.Context, arg *EnqueueClusterJobParams) (*EnqueueClusterJobRow, error) {
+	row := q.db.QueryRow(ctx, enqueueClusterJob,
+		arg.ID,
+		arg.Priority,
+		arg.AffectedH3Cells,
+		arg.MaxAffectedCells,
+	)
+	var i EnqueueClusterJobRow
+	err := row.Scan(
+		&i.ID,
+		&i.Status,
+		&i.Priority,
+		&i.AffectedH3Cells,
+		&i.CreatedAt,
+		&i.Merged,
+	)
+	return &i, err
 }
 
+const getClusterJob = `-- name: GetClusterJob :one
+SELECT id, status, priority, created_at, started_at, completed_at, error_message, affected_h3_cells, worker_id, heartbeat_at, attempts FROM cluster_jobs WHERE id = $1
+`
+
 // クラスタージョブをIDで取得
-func (q *Queries) GetClusterJob(ctx context.Context, id uuid.UUID) (*GetClusterJobRow, error) {
+func (q *Queries) GetClusterJob(ctx context.Context, id uuid.UUID) (*ClusterJob, error) {
 	row := q.db.QueryRow(ctx, getClusterJob, id)
-	var i GetClusterJobRow
+	var i ClusterJob
 	err := row.Scan(
 		&i.ID,
 		&i.Status,
@@ -137,46 +207,32 @@ func (q *Queries) GetClusterJob(ctx context.Context, id uuid.UUID) (*GetClusterJ
 		&i.StartedAt,
 		&i.CompletedAt,
 		&i.ErrorMessage,
+		&i.AffectedH3Cells,
+		&i.WorkerID,
+		&i.HeartbeatAt,
+		&i.Attempts,
 	)
 	return &i, err
 }
 
-const getPendingClusterJobs = `-- name: GetPendingClusterJobs :many
-SELECT
-    id,
-    status,
-    priority,
-    created_at,
-    started_at,
-    completed_at,
-    error_message
-FROM cluster_jobs
-WHERE status = 'pending'
-ORDER BY priority DESC, created_at
-LIMIT $1
+const getExpiredClusterJobs = `-- name: GetExpiredClusterJobs :many
+SELECT id, status, priority, created_at, started_at, completed_at, error_message, affected_h3_cells, worker_id, heartbeat_at, attempts FROM cluster_jobs
+WHERE status = 'processing'
+  AND heartbeat_at < NOW() - make_interval(secs => $1::INT)
+ORDER BY heartbeat_at
 FOR UPDATE SKIP LOCKED
 `
 
-type GetPendingClusterJobsRow struct {
-	ID           uuid.UUID          `json:"id"`
-	Status       string             `json:"status"`
-	Priority     int32              `json:"priority"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
-	StartedAt    pgtype.Timestamptz `json:"started_at"`
-	CompletedAt  pgtype.Timestamptz `json:"completed_at"`
-	ErrorMessage *string            `json:"error_message"`
-}
-
-// 保留中のジョブを優先度順に取得(排他ロック)
-func (q *Queries) GetPendingClusterJobs(ctx context.Context, limit int32) ([]*GetPendingClusterJobsRow, error) {
-	rows, err := q.db.Query(ctx, getPendingClusterJobs, limit)
+// ハートビートがリース期間を超えて途絶えた処理中ジョブを取得(排他ロック)
+func (q *Queries) GetExpiredClusterJobs(ctx context.Context, leaseSeconds int32) ([]*ClusterJob, error) {
+	rows, err := q.db.Query(ctx, getExpiredClusterJobs, leaseSeconds)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*GetPendingClusterJobsRow{}
+	items := []*ClusterJob{}
 	for rows.Next() {
-		var i GetPendingClusterJobsRow
+		var i ClusterJob
 		if err := rows.Scan(
 			&i.ID,
 			&i.Status,
@@ -185,6 +241,10 @@ func (q *Queries) GetPendingClusterJobs(ctx context.Context, limit int32) ([]*Ge
 			&i.StartedAt,
 			&i.CompletedAt,
 			&i.ErrorMessage,
+			&i.AffectedH3Cells,
+			&i.WorkerID,
+			&i.HeartbeatAt,
+			&i.Attempts,
 		); err != nil {
 			return nil, err
 		}
@@ -196,49 +256,111 @@ func (q *Queries) GetPendingClusterJobs(ctx context.Context, limit int32) ([]*Ge
 	return items, nil
 }
 
-const getPendingClusterJobsWithAffectedCells = `-- name: GetPendingClusterJobsWithAffectedCells :many
+const hasPendingOrProcessingJob = `-- name: HasPendingOrProcessingJob :one
+SELECT EXISTS(
+    SELECT 1 FROM cluster_jobs
+    WHERE status IN ('pending', 'processing')
+) AS has_job
+`
+
+// 保留中または処理中のジョブがあるか確認
+func (q *Queries) HasPendingOrProcessingJob(ctx context.Context) (bool, error) {
+	row := q.db.QueryRow(ctx, hasPendingOrProcessingJob)
+	var has_job bool
+	err := row.Scan(&has_job)
+	return has_job, err
+}
+
+const heartbeatClusterJob = `-- name: HeartbeatClusterJob :execrows
+UPDATE cluster_jobs
+SET heartbeat_at = NOW()
+WHERE id = $1 AND worker_id = $2 AND status = 'processing'
+`
+
+type HeartbeatClusterJobParams struct {
+	ID       uuid.UUID `json:"id"`
+	WorkerID *string   `json:"worker_id"`
+}
+
+// 指定ワーカーがリースを保持している処理中ジョブのハートビートを更新
+func (q *Queries) HeartbeatClusterJob(ctx context.Context, arg *HeartbeatClusterJobParams) (int64, error) {
+	result, err := q.db.Exec(ctx, heartbeatClusterJob, arg.ID, arg.WorkerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listClusterJobs = `-- name: ListClusterJobs :many
 SELECT
     id,
     status,
     priority,
-    affected_h3_cells,
+    (affected_h3_cells IS NULL) AS full_recalculation,
+    COALESCE(cardinality(affected_h3_cells), 0)::INT AS affected_cell_count,
+    attempts,
+    worker_id,
     created_at,
     started_at,
     completed_at,
     error_message
 FROM cluster_jobs
-WHERE status = 'pending'
-ORDER BY priority DESC, created_at
-LIMIT $1
-FOR UPDATE SKIP LOCKED
+WHERE
+    ($1::VARCHAR IS NULL OR status = $1::VARCHAR)
+    AND ($2::TIMESTAMPTZ IS NULL OR created_at >= $2::TIMESTAMPTZ)
+    AND ($3::TIMESTAMPTZ IS NULL OR created_at < $3::TIMESTAMPTZ)
+ORDER BY created_at DESC, id
+LIMIT $4
+OFFSET $5
 `
 
-type GetPendingClusterJobsWithAffectedCellsRow struct {
-	ID              uuid.UUID          `json:"id"`
-	Status          string             `json:"status"`
-	Priority        int32              `json:"priority"`
-	AffectedH3Cells []string           `json:"affected_h3_cells"`
-	CreatedAt       pgtype.Timestamptz `json:"created_at"`
-	StartedAt       pgtype.Timestamptz `json:"started_at"`
-	CompletedAt     pgtype.Timestamptz `json:"completed_at"`
-	ErrorMessage    *string            `json:"error_message"`
+type ListClusterJobsParams struct {
+	Status      *string            `json:"status"`
+	CreatedFrom pgtype.Timestamptz `json:"created_from"`
+	CreatedTo   pgtype.Timestamptz `json:"created_to"`
+	RowLimit    int32              `json:"row_limit"`
+	RowOffset   int32              `json:"row_offset"`
+}
+
+type ListClusterJobsRow struct {
+	ID                uuid.UUID          `json:"id"`
+	Status            string             `json:"status"`
+	Priority          int32              `json:"priority"`
+	FullRecalculation bool               `json:"full_recalculation"`
+	AffectedCellCount int32              `json:"affected_cell_count"`
+	Attempts          int32              `json:"attempts"`
+	WorkerID          *string            `json:"worker_id"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	StartedAt         pgtype.Timestamptz `json:"started_at"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+	ErrorMessage      *string            `json:"error_message"`
 }
 
-// 保留中のジョブを影響セル情報付きで優先度順に取得(排他ロック)
-func (q *Queries) GetPendingClusterJobsWithAffectedCells(ctx context.Context, limit int32) ([]*GetPendingClusterJobsWithAffectedCellsRow, error) {
-	rows, err := q.db.Query(ctx, getPendingClusterJobsWithAffectedCells, limit)
+// 条件を指定してクラスタージョブの履歴を作成日時の新しい順に取得
+// 各条件はNULLの場合に無視される。影響セルは件数のみを取得する
+func (q *Queries) ListClusterJobs(ctx context.Context, arg *ListClusterJobsParams) ([]*ListClusterJobsRow, error) {
+	rows, err := q.db.Query(ctx, listClusterJobs,
+		arg.Status,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*GetPendingClusterJobsWithAffectedCellsRow{}
+	items := []*ListClusterJobsRow{}
 	for rows.Next() {
-		var i GetPendingClusterJobsWithAffectedCellsRow
+		var i ListClusterJobsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Status,
 			&i.Priority,
-			&i.AffectedH3Cells,
+			&i.FullRecalculation,
+			&i.AffectedCellCount,
+			&i.Attempts,
+			&i.WorkerID,
 			&i.CreatedAt,
 			&i.StartedAt,
 			&i.CompletedAt,
@@ -254,65 +376,120 @@ func (q *Queries) GetPendingClusterJobsWithAffectedCells(ctx context.Context, li
 	return items, nil
 }
 
-const hasPendingOrProcessingJob = `-- name: HasPendingOrProcessingJob :one
-SELECT EXISTS(
-    SELECT 1 FROM cluster_jobs
-    WHERE status IN ('pending', 'processing')
-) AS has_job
+const mergeIntoPendingClusterJob = `-- name: MergeIntoPendingClusterJob :one
+UPDATE cluster_jobs AS cj
+SET
+    priority = GREATEST(cj.priority, src.priority),
+    affected_h3_cells = CASE
+        WHEN cj.affected_h3_cells IS NULL OR src.affected_h3_cells IS NULL THEN NULL
+        WHEN (
+            SELECT COUNT(DISTINCT c)
+            FROM unnest(cj.affected_h3_cells || src.affected_h3_cells) AS c
+        ) > $1::INT THEN NULL
+        ELSE ARRAY(
+            SELECT DISTINCT c
+            FROM unnest(cj.affected_h3_cells || src.affected_h3_cells) AS c
+            ORDER BY c
+        )
+    END,
+    attempts = GREATEST(cj.attempts, src.attempts)
+FROM cluster_jobs AS src
+WHERE cj.status = 'pending' AND src.id = $2
+RETURNING cj.id
 `
 
-// 保留中または処理中のジョブがあるか確認
-func (q *Queries) HasPendingOrProcessingJob(ctx context.Context) (bool, error) {
-	row := q.db.QueryRow(ctx, hasPendingOrProcessingJob)
-	var has_job bool
-	err := row.Scan(&has_job)
-	return has_job, err
+type MergeIntoPendingClusterJobParams struct {
+	MaxAffectedCells int32     `json:"max_affected_cells"`
+	ID               uuid.UUID `json:"id"`
 }
 
-const updateClusterJobToCompleted = `-- name: UpdateClusterJobToCompleted :exec
+// 指定ジョブの影響セルと優先度を保留中ジョブに統合し、統合先のジョブIDを返す
+// 統合の規則はEnqueueClusterJobと同じで、実行回数は多い方を引き継ぐ
+func (q *Queries) MergeIntoPendingClusterJob(ctx context.Context, arg *MergeIntoPendingClusterJobParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, mergeIntoPendingClusterJob, arg.MaxAffectedCells, arg.ID)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const requeueClusterJob = `-- name: RequeueClusterJob :exec
 UPDATE cluster_jobs
 SET
-    status = 'completed',
-    completed_at = NOW()
+    status = 'pending',
+    started_at = NULL,
+    worker_id = NULL,
+    heartbeat_at = NULL
 WHERE id = $1
 `
 
-// ジョブを完了に更新
-func (q *Queries) UpdateClusterJobToCompleted(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, updateClusterJobToCompleted, id)
+// 処理中のジョブを保留中に戻し、リースを解放
+func (q *Queries) RequeueClusterJob(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, requeueClusterJob, id)
 	return err
 }
 
-const updateClusterJobToFailed = `-- name: UpdateClusterJobToFailed :exec
+const updateClusterJobToCompleted = `-- name: UpdateClusterJobToCompleted :execrows
+UPDATE cluster_jobs
+SET
+    status = 'completed',
+    completed_at = NOW()
+WHERE id = $1 AND worker_id = $2 AND status = 'processing'
+`
+
+type UpdateClusterJobToCompletedParams struct {
+	ID       uuid.UUID `json:"id"`
+	WorkerID *string   `json:"worker_id"`
+}
+
+// 指定ワーカーがリースを保持している処理中ジョブを完了に更新
+func (q *Queries) UpdateClusterJobToCompleted(ctx context.Context, arg *UpdateClusterJobToCompletedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateClusterJobToCompleted, arg.ID, arg.WorkerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateClusterJobToFailed = `-- name: UpdateClusterJobToFailed :execrows
 UPDATE cluster_jobs
 SET
     status = 'failed',
     completed_at = NOW(),
-    error_message = $2
-WHERE id = $1
+    error_message = $1
+WHERE id = $2 AND worker_id = $3 AND status = 'processing'
 `
 
 type UpdateClusterJobToFailedParams struct {
-	ID           uuid.UUID `json:"id"`
 	ErrorMessage *string   `json:"error_message"`
+	ID           uuid.UUID `json:"id"`
+	WorkerID     *string   `json:"worker_id"`
 }
 
-// ジョブを失敗に更新
-func (q *Queries) UpdateClusterJobToFailed(ctx context.Context, arg *UpdateClusterJobToFailedParams) error {
-	_, err := q.db.Exec(ctx, updateClusterJobToFailed, arg.ID, arg.ErrorMessage)
-	return err
+// 指定ワーカーがリースを保持している処理中ジョブを失敗に更新
+func (q *Queries) UpdateClusterJobToFailed(ctx context.Context, arg *UpdateClusterJobToFailedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateClusterJobToFailed, arg.ErrorMessage, arg.ID, arg.WorkerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const updateClusterJobToProcessing = `-- name: UpdateClusterJobToProcessing :exec
+const updateExpiredClusterJobToFailed = `-- name: UpdateExpiredClusterJobToFailed :exec
 UPDATE cluster_jobs
 SET
-    status = 'processing',
-    started_at = NOW()
+    status = 'failed',
+    completed_at = NOW(),
+    error_message = $2
 WHERE id = $1
 `
 
-// ジョブを処理中に更新
-func (q *Queries) UpdateClusterJobToProcessing(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, updateClusterJobToProcessing, id)
+type UpdateExpiredClusterJobToFailedParams struct {
+	ID           uuid.UUID `json:"id"`
+	ErrorMessage *string   `json:"error_message"`
+}
+
+// リース期限切れの処理中ジョブを失敗に更新
+func (q *Queries) UpdateExpiredClusterJobToFailed(ctx context.Context, arg *UpdateExpiredClusterJobToFailedParams) error {
+	_, err := q.db.Exec(ctx, updateExpiredClusterJobToFailed, arg.ID, arg.ErrorMessage)
 	return err
 }
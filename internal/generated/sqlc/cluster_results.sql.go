@@ -11,31 +11,100 @@ import (
 	"github.com/google/uuid"
 )
 
-const aggregateClustersByRes3 = `-- name: AggregateClustersByRes3 :many
+const aggregateClusterAttributes = `-- name: AggregateClusterAttributes :many
+WITH targets AS (
+    SELECT
+        h3_cell_to_parent(f.h3_index, $1::INT) AS h3_index,
+        f.id,
+        f.soil_type_id,
+        f.area_sqm,
+        EXISTS (
+            SELECT 1
+            FROM field_land_registries r
+            WHERE r.field_id = f.id AND r.idle_land_status_code IS NOT NULL
+        ) AS is_idle
+    FROM fields f
+    WHERE f.retired_at IS NULL
+        AND (
+            $2::TEXT[] IS NULL
+            OR EXISTS (
+                SELECT 1
+                FROM unnest($2::TEXT[], $3::TEXT[]) AS b(lower_bound, upper_bound)
+                WHERE f.h3_index BETWEEN b.lower_bound AND b.upper_bound
+            )
+        )
+),
+cells AS (
+    SELECT
+        t.h3_index,
+        COUNT(*) FILTER (WHERE t.is_idle)::INT AS idle_field_count
+    FROM targets t
+    WHERE t.h3_index IS NOT NULL
+    GROUP BY t.h3_index
+),
+categories AS (
+    SELECT
+        t.h3_index,
+        r.land_category_code,
+        COUNT(DISTINCT t.id)::INT AS field_count
+    FROM targets t
+    JOIN field_land_registries r ON r.field_id = t.id
+    WHERE r.land_category_code IS NOT NULL
+    GROUP BY t.h3_index, r.land_category_code
+),
+soils AS (
+    SELECT DISTINCT ON (t.h3_index)
+        t.h3_index,
+        s.large_code
+    FROM targets t
+    JOIN soil_types s ON s.id = t.soil_type_id
+    GROUP BY t.h3_index, s.large_code
+    ORDER BY t.h3_index, SUM(t.area_sqm) DESC NULLS LAST, s.large_code
+)
 SELECT
-    h3_index_res3 AS h3_index,
-    COUNT(*)::INT AS field_count
-FROM fields
-WHERE h3_index_res3 IS NOT NULL
-GROUP BY h3_index_res3
+    cells.h3_index::TEXT AS h3_index,
+    COALESCE((
+        SELECT jsonb_object_agg(cat.land_category_code, cat.field_count)
+        FROM categories cat
+        WHERE cat.h3_index = cells.h3_index AND cat.field_count > 0
+    ), '{}')::JSONB AS land_category_counts,
+    COALESCE(cells.idle_field_count, 0)::INT AS idle_field_count,
+    soils.large_code AS dominant_soil_large_code
+FROM cells
+LEFT JOIN soils ON soils.h3_index = cells.h3_index
 `
 
-type AggregateClustersByRes3Row struct {
-	H3Index    *string `json:"h3_index"`
-	FieldCount int32   `json:"field_count"`
+type AggregateClusterAttributesParams struct {
+	Resolution  int32    `json:"resolution"`
+	LowerBounds []string `json:"lower_bounds"`
+	UpperBounds []string `json:"upper_bounds"`
 }
 
-// H3解像度3でfieldsを集計
-func (q *Queries) AggregateClustersByRes3(ctx context.Context) ([]*AggregateClustersByRes3Row, error) {
-	rows, err := q.db.Query(ctx, aggregateClustersByRes3)
+type AggregateClusterAttributesRow struct {
+	H3Index               string  `json:"h3_index"`
+	LandCategoryCounts    []byte  `json:"land_category_counts"`
+	IdleFieldCount        int32   `json:"idle_field_count"`
+	DominantSoilLargeCode *string `json:"dominant_soil_large_code"`
+}
+
+// 指定解像度で有効なfieldsを重心のH3セルごとに属性別に集計
+// 土地種別コードごとの圃場数、遊休農地の圃場数、合計面積が最大の土壌大分類コードを返す
+// lower_boundsがNULLの場合は全範囲、指定した場合は解像度15の子孫セルの範囲に重心がある圃場のみ集計する(差分更新用)
+func (q *Queries) AggregateClusterAttributes(ctx context.Context, arg *AggregateClusterAttributesParams) ([]*AggregateClusterAttributesRow, error) {
+	rows, err := q.db.Query(ctx, aggregateClusterAttributes, arg.Resolution, arg.LowerBounds, arg.UpperBounds)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*AggregateClustersByRes3Row{}
+	items := []*AggregateClusterAttributesRow{}
 	for rows.Next() {
-		var i AggregateClustersByRes3Row
-		if err := rows.Scan(&i.H3Index, &i.FieldCount); err != nil {
+		var i AggregateClusterAttributesRow
+		if err := rows.Scan(
+			&i.H3Index,
+			&i.LandCategoryCounts,
+			&i.IdleFieldCount,
+			&i.DominantSoilLargeCode,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, &i)
@@ -46,31 +115,98 @@ func (q *Queries) AggregateClustersByRes3(ctx context.Context) ([]*AggregateClus
 	return items, nil
 }
 
-const aggregateClustersByRes3ForCells = `-- name: AggregateClustersByRes3ForCells :many
+const aggregateClusterAttributesByCoverage = `-- name: AggregateClusterAttributesByCoverage :many
+WITH targets AS (
+    SELECT
+        cv.h3_index,
+        f.id,
+        f.soil_type_id,
+        f.area_sqm * cv.area_share AS area_sqm,
+        EXISTS (
+            SELECT 1
+            FROM field_land_registries r
+            WHERE r.field_id = f.id AND r.idle_land_status_code IS NOT NULL
+        ) AS is_idle,
+        CASE WHEN $1::BOOLEAN THEN cv.area_share ELSE 1 END::DOUBLE PRECISION AS weight
+    FROM field_h3_coverages cv
+    JOIN fields f ON f.id = cv.field_id
+    WHERE cv.resolution = $2::INT
+        AND f.retired_at IS NULL
+        AND ($3::TEXT[] IS NULL OR cv.h3_index = ANY($3::TEXT[]))
+),
+cells AS (
+    SELECT
+        t.h3_index,
+        ROUND(SUM(t.weight) FILTER (WHERE t.is_idle))::INT AS idle_field_count
+    FROM targets t
+    GROUP BY t.h3_index
+),
+categories AS (
+    SELECT
+        c.h3_index,
+        c.land_category_code,
+        ROUND(SUM(c.weight))::INT AS field_count
+    FROM (
+        SELECT DISTINCT t.h3_index, t.id, t.weight, r.land_category_code
+        FROM targets t
+        JOIN field_land_registries r ON r.field_id = t.id
+        WHERE r.land_category_code IS NOT NULL
+    ) c
+    GROUP BY c.h3_index, c.land_category_code
+),
+soils AS (
+    SELECT DISTINCT ON (t.h3_index)
+        t.h3_index,
+        s.large_code
+    FROM targets t
+    JOIN soil_types s ON s.id = t.soil_type_id
+    GROUP BY t.h3_index, s.large_code
+    ORDER BY t.h3_index, SUM(t.area_sqm) DESC NULLS LAST, s.large_code
+)
 SELECT
-    h3_index_res3 AS h3_index,
-    COUNT(*)::INT AS field_count
-FROM fields
-WHERE h3_index_res3 = ANY($1::TEXT[])
-GROUP BY h3_index_res3
+    cells.h3_index::TEXT AS h3_index,
+    COALESCE((
+        SELECT jsonb_object_agg(cat.land_category_code, cat.field_count)
+        FROM categories cat
+        WHERE cat.h3_index = cells.h3_index AND cat.field_count > 0
+    ), '{}')::JSONB AS land_category_counts,
+    COALESCE(cells.idle_field_count, 0)::INT AS idle_field_count,
+    soils.large_code AS dominant_soil_large_code
+FROM cells
+LEFT JOIN soils ON soils.h3_index = cells.h3_index
 `
 
-type AggregateClustersByRes3ForCellsRow struct {
-	H3Index    *string `json:"h3_index"`
-	FieldCount int32   `json:"field_count"`
+type AggregateClusterAttributesByCoverageParams struct {
+	AreaShare  bool     `json:"area_share"`
+	Resolution int32    `json:"resolution"`
+	H3Cells    []string `json:"h3_cells"`
+}
+
+type AggregateClusterAttributesByCoverageRow struct {
+	H3Index               string  `json:"h3_index"`
+	LandCategoryCounts    []byte  `json:"land_category_counts"`
+	IdleFieldCount        int32   `json:"idle_field_count"`
+	DominantSoilLargeCode *string `json:"dominant_soil_large_code"`
 }
 
-// 指定H3セル(res3)のみfieldsを集計(差分更新用)
-func (q *Queries) AggregateClustersByRes3ForCells(ctx context.Context, h3Cells []string) ([]*AggregateClustersByRes3ForCellsRow, error) {
-	rows, err := q.db.Query(ctx, aggregateClustersByRes3ForCells, h3Cells)
+// 指定解像度で有効な圃場を被覆するH3セルごとに属性別に集計
+// area_shareがtrueの場合は圃場数をセルに含まれる面積の割合で按分し、falseの場合は覆っている圃場を1件として数える
+// 土壌の面積はセルに含まれる部分のみ合計する。h3_cellsがNULLの場合は全範囲を集計する
+func (q *Queries) AggregateClusterAttributesByCoverage(ctx context.Context, arg *AggregateClusterAttributesByCoverageParams) ([]*AggregateClusterAttributesByCoverageRow, error) {
+	rows, err := q.db.Query(ctx, aggregateClusterAttributesByCoverage, arg.AreaShare, arg.Resolution, arg.H3Cells)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*AggregateClustersByRes3ForCellsRow{}
+	items := []*AggregateClusterAttributesByCoverageRow{}
 	for rows.Next() {
-		var i AggregateClustersByRes3ForCellsRow
-		if err := rows.Scan(&i.H3Index, &i.FieldCount); err != nil {
+		var i AggregateClusterAttributesByCoverageRow
+		if err := rows.Scan(
+			&i.H3Index,
+			&i.LandCategoryCounts,
+			&i.IdleFieldCount,
+			&i.DominantSoilLargeCode,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, &i)
@@ -81,31 +217,36 @@ func (q *Queries) AggregateClustersByRes3ForCells(ctx context.Context, h3Cells [
 	return items, nil
 }
 
-const aggregateClustersByRes5 = `-- name: AggregateClustersByRes5 :many
+const aggregateClustersByAreaShare = `-- name: AggregateClustersByAreaShare :many
 SELECT
-    h3_index_res5 AS h3_index,
-    COUNT(*)::INT AS field_count
-FROM fields
-WHERE h3_index_res5 IS NOT NULL
-GROUP BY h3_index_res5
+    c.h3_index,
+    ROUND(SUM(c.area_share))::INT AS field_count,
+    COALESCE(SUM(f.area_sqm * c.area_share), 0)::DOUBLE PRECISION AS total_area_sqm
+FROM field_h3_coverages c
+JOIN fields f ON f.id = c.field_id
+WHERE c.resolution = $1 AND f.retired_at IS NULL
+GROUP BY c.h3_index
+HAVING SUM(c.area_share) >= 0.5
 `
 
-type AggregateClustersByRes5Row struct {
-	H3Index    *string `json:"h3_index"`
-	FieldCount int32   `json:"field_count"`
+type AggregateClustersByAreaShareRow struct {
+	H3Index      string  `json:"h3_index"`
+	FieldCount   int32   `json:"field_count"`
+	TotalAreaSqm float64 `json:"total_area_sqm"`
 }
 
-// H3解像度5でfieldsを集計
-func (q *Queries) AggregateClustersByRes5(ctx context.Context) ([]*AggregateClustersByRes5Row, error) {
-	rows, err := q.db.Query(ctx, aggregateClustersByRes5)
+// 指定解像度で有効な圃場をセルに含まれる面積の割合で按分して集計
+// 圃場数は割合の合計を四捨五入した値とし、0件になるセルは返さない
+func (q *Queries) AggregateClustersByAreaShare(ctx context.Context, resolution int32) ([]*AggregateClustersByAreaShareRow, error) {
+	rows, err := q.db.Query(ctx, aggregateClustersByAreaShare, resolution)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*AggregateClustersByRes5Row{}
+	items := []*AggregateClustersByAreaShareRow{}
 	for rows.Next() {
-		var i AggregateClustersByRes5Row
-		if err := rows.Scan(&i.H3Index, &i.FieldCount); err != nil {
+		var i AggregateClustersByAreaShareRow
+		if err := rows.Scan(&i.H3Index, &i.FieldCount, &i.TotalAreaSqm); err != nil {
 			return nil, err
 		}
 		items = append(items, &i)
@@ -116,31 +257,40 @@ func (q *Queries) AggregateClustersByRes5(ctx context.Context) ([]*AggregateClus
 	return items, nil
 }
 
-const aggregateClustersByRes5ForCells = `-- name: AggregateClustersByRes5ForCells :many
+const aggregateClustersByAreaShareForCells = `-- name: AggregateClustersByAreaShareForCells :many
 SELECT
-    h3_index_res5 AS h3_index,
-    COUNT(*)::INT AS field_count
-FROM fields
-WHERE h3_index_res5 = ANY($1::TEXT[])
-GROUP BY h3_index_res5
+    c.h3_index,
+    ROUND(SUM(c.area_share))::INT AS field_count,
+    COALESCE(SUM(f.area_sqm * c.area_share), 0)::DOUBLE PRECISION AS total_area_sqm
+FROM field_h3_coverages c
+JOIN fields f ON f.id = c.field_id
+WHERE c.resolution = $1 AND c.h3_index = ANY($2::TEXT[]) AND f.retired_at IS NULL
+GROUP BY c.h3_index
+HAVING SUM(c.area_share) >= 0.5
 `
 
-type AggregateClustersByRes5ForCellsRow struct {
-	H3Index    *string `json:"h3_index"`
-	FieldCount int32   `json:"field_count"`
+type AggregateClustersByAreaShareForCellsParams struct {
+	Resolution int32    `json:"resolution"`
+	H3Cells    []string `json:"h3_cells"`
 }
 
-// 指定H3セル(res5)のみfieldsを集計(差分更新用)
-func (q *Queries) AggregateClustersByRes5ForCells(ctx context.Context, h3Cells []string) ([]*AggregateClustersByRes5ForCellsRow, error) {
-	rows, err := q.db.Query(ctx, aggregateClustersByRes5ForCells, h3Cells)
+type AggregateClustersByAreaShareForCellsRow struct {
+	H3Index      string  `json:"h3_index"`
+	FieldCount   int32   `json:"field_count"`
+	TotalAreaSqm float64 `json:"total_area_sqm"`
+}
+
+// 指定H3セルのみ面積の割合で按分して集計(差分更新用)
+func (q *Queries) AggregateClustersByAreaShareForCells(ctx context.Context, arg *AggregateClustersByAreaShareForCellsParams) ([]*AggregateClustersByAreaShareForCellsRow, error) {
+	rows, err := q.db.Query(ctx, aggregateClustersByAreaShareForCells, arg.Resolution, arg.H3Cells)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*AggregateClustersByRes5ForCellsRow{}
+	items := []*AggregateClustersByAreaShareForCellsRow{}
 	for rows.Next() {
-		var i AggregateClustersByRes5ForCellsRow
-		if err := rows.Scan(&i.H3Index, &i.FieldCount); err != nil {
+		var i AggregateClustersByAreaShareForCellsRow
+		if err := rows.Scan(&i.H3Index, &i.FieldCount, &i.TotalAreaSqm); err != nil {
 			return nil, err
 		}
 		items = append(items, &i)
@@ -151,31 +301,35 @@ func (q *Queries) AggregateClustersByRes5ForCells(ctx context.Context, h3Cells [
 	return items, nil
 }
 
-const aggregateClustersByRes7 = `-- name: AggregateClustersByRes7 :many
+const aggregateClustersByCoverage = `-- name: AggregateClustersByCoverage :many
 SELECT
-    h3_index_res7 AS h3_index,
-    COUNT(*)::INT AS field_count
-FROM fields
-WHERE h3_index_res7 IS NOT NULL
-GROUP BY h3_index_res7
+    c.h3_index,
+    COUNT(*)::INT AS field_count,
+    COALESCE(SUM(f.area_sqm * c.area_share), 0)::DOUBLE PRECISION AS total_area_sqm
+FROM field_h3_coverages c
+JOIN fields f ON f.id = c.field_id
+WHERE c.resolution = $1 AND f.retired_at IS NULL
+GROUP BY c.h3_index
 `
 
-type AggregateClustersByRes7Row struct {
-	H3Index    *string `json:"h3_index"`
-	FieldCount int32   `json:"field_count"`
+type AggregateClustersByCoverageRow struct {
+	H3Index      string  `json:"h3_index"`
+	FieldCount   int32   `json:"field_count"`
+	TotalAreaSqm float64 `json:"total_area_sqm"`
 }
 
-// H3解像度7でfieldsを集計
-func (q *Queries) AggregateClustersByRes7(ctx context.Context) ([]*AggregateClustersByRes7Row, error) {
-	rows, err := q.db.Query(ctx, aggregateClustersByRes7)
+// 指定解像度で有効な圃場を被覆するH3セルごとに集計
+// 圃場は覆っている全てのセルで1件として数え、面積はセルに含まれる部分のみ合計する
+func (q *Queries) AggregateClustersByCoverage(ctx context.Context, resolution int32) ([]*AggregateClustersByCoverageRow, error) {
+	rows, err := q.db.Query(ctx, aggregateClustersByCoverage, resolution)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*AggregateClustersByRes7Row{}
+	items := []*AggregateClustersByCoverageRow{}
 	for rows.Next() {
-		var i AggregateClustersByRes7Row
-		if err := rows.Scan(&i.H3Index, &i.FieldCount); err != nil {
+		var i AggregateClustersByCoverageRow
+		if err := rows.Scan(&i.H3Index, &i.FieldCount, &i.TotalAreaSqm); err != nil {
 			return nil, err
 		}
 		items = append(items, &i)
@@ -186,31 +340,39 @@ func (q *Queries) AggregateClustersByRes7(ctx context.Context) ([]*AggregateClus
 	return items, nil
 }
 
-const aggregateClustersByRes7ForCells = `-- name: AggregateClustersByRes7ForCells :many
+const aggregateClustersByCoverageForCells = `-- name: AggregateClustersByCoverageForCells :many
 SELECT
-    h3_index_res7 AS h3_index,
-    COUNT(*)::INT AS field_count
-FROM fields
-WHERE h3_index_res7 = ANY($1::TEXT[])
-GROUP BY h3_index_res7
+    c.h3_index,
+    COUNT(*)::INT AS field_count,
+    COALESCE(SUM(f.area_sqm * c.area_share), 0)::DOUBLE PRECISION AS total_area_sqm
+FROM field_h3_coverages c
+JOIN fields f ON f.id = c.field_id
+WHERE c.resolution = $1 AND c.h3_index = ANY($2::TEXT[]) AND f.retired_at IS NULL
+GROUP BY c.h3_index
 `
 
-type AggregateClustersByRes7ForCellsRow struct {
-	H3Index    *string `json:"h3_index"`
-	FieldCount int32   `json:"field_count"`
+type AggregateClustersByCoverageForCellsParams struct {
+	Resolution int32    `json:"resolution"`
+	H3Cells    []string `json:"h3_cells"`
 }
 
-// 指定H3セル(res7)のみfieldsを集計(差分更新用)
-func (q *Queries) AggregateClustersByRes7ForCells(ctx context.Context, h3Cells []string) ([]*AggregateClustersByRes7ForCellsRow, error) {
-	rows, err := q.db.Query(ctx, aggregateClustersByRes7ForCells, h3Cells)
+type AggregateClustersByCoverageForCellsRow struct {
+	H3Index      string  `json:"h3_index"`
+	FieldCount   int32   `json:"field_count"`
+	TotalAreaSqm float64 `json:"total_area_sqm"`
+}
+
+// 指定H3セルのみ被覆で集計(差分更新用)
+func (q *Queries) AggregateClustersByCoverageForCells(ctx context.Context, arg *AggregateClustersByCoverageForCellsParams) ([]*AggregateClustersByCoverageForCellsRow, error) {
+	rows, err := q.db.Query(ctx, aggregateClustersByCoverageForCells, arg.Resolution, arg.H3Cells)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*AggregateClustersByRes7ForCellsRow{}
+	items := []*AggregateClustersByCoverageForCellsRow{}
 	for rows.Next() {
-		var i AggregateClustersByRes7ForCellsRow
-		if err := rows.Scan(&i.H3Index, &i.FieldCount); err != nil {
+		var i AggregateClustersByCoverageForCellsRow
+		if err := rows.Scan(&i.H3Index, &i.FieldCount, &i.TotalAreaSqm); err != nil {
 			return nil, err
 		}
 		items = append(items, &i)
@@ -221,31 +383,34 @@ func (q *Queries) AggregateClustersByRes7ForCells(ctx context.Context, h3Cells [
 	return items, nil
 }
 
-const aggregateClustersByRes9 = `-- name: AggregateClustersByRes9 :many
+const aggregateClustersByH3 = `-- name: AggregateClustersByH3 :many
 SELECT
-    h3_index_res9 AS h3_index,
-    COUNT(*)::INT AS field_count
+    h3_cell_to_parent(h3_index, $1::INT)::TEXT AS h3_index,
+    COUNT(*)::INT AS field_count,
+    COALESCE(SUM(area_sqm), 0)::DOUBLE PRECISION AS total_area_sqm
 FROM fields
-WHERE h3_index_res9 IS NOT NULL
-GROUP BY h3_index_res9
+WHERE h3_index IS NOT NULL AND retired_at IS NULL
+GROUP BY h3_cell_to_parent(h3_index, $1::INT)
 `
 
-type AggregateClustersByRes9Row struct {
-	H3Index    *string `json:"h3_index"`
-	FieldCount int32   `json:"field_count"`
+type AggregateClustersByH3Row struct {
+	H3Index      string  `json:"h3_index"`
+	FieldCount   int32   `json:"field_count"`
+	TotalAreaSqm float64 `json:"total_area_sqm"`
 }
 
-// H3解像度9でfieldsを集計
-func (q *Queries) AggregateClustersByRes9(ctx context.Context) ([]*AggregateClustersByRes9Row, error) {
-	rows, err := q.db.Query(ctx, aggregateClustersByRes9)
+// 指定解像度で有効なfieldsを重心のH3セルごとに集計
+// 重心のセルはfields.h3_index(解像度15)の指定解像度の親セルとする
+func (q *Queries) AggregateClustersByH3(ctx context.Context, resolution int32) ([]*AggregateClustersByH3Row, error) {
+	rows, err := q.db.Query(ctx, aggregateClustersByH3, resolution)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*AggregateClustersByRes9Row{}
+	items := []*AggregateClustersByH3Row{}
 	for rows.Next() {
-		var i AggregateClustersByRes9Row
-		if err := rows.Scan(&i.H3Index, &i.FieldCount); err != nil {
+		var i AggregateClustersByH3Row
+		if err := rows.Scan(&i.H3Index, &i.FieldCount, &i.TotalAreaSqm); err != nil {
 			return nil, err
 		}
 		items = append(items, &i)
@@ -256,31 +421,42 @@ func (q *Queries) AggregateClustersByRes9(ctx context.Context) ([]*AggregateClus
 	return items, nil
 }
 
-const aggregateClustersByRes9ForCells = `-- name: AggregateClustersByRes9ForCells :many
+const aggregateClustersByH3ForCells = `-- name: AggregateClustersByH3ForCells :many
 SELECT
-    h3_index_res9 AS h3_index,
-    COUNT(*)::INT AS field_count
-FROM fields
-WHERE h3_index_res9 = ANY($1::TEXT[])
-GROUP BY h3_index_res9
+    h3_cell_to_parent(f.h3_index, $1::INT)::TEXT AS h3_index,
+    COUNT(*)::INT AS field_count,
+    COALESCE(SUM(f.area_sqm), 0)::DOUBLE PRECISION AS total_area_sqm
+FROM fields f
+JOIN unnest($2::TEXT[], $3::TEXT[]) AS b(lower_bound, upper_bound)
+    ON f.h3_index BETWEEN b.lower_bound AND b.upper_bound
+WHERE f.retired_at IS NULL
+GROUP BY h3_cell_to_parent(f.h3_index, $1::INT)
 `
 
-type AggregateClustersByRes9ForCellsRow struct {
-	H3Index    *string `json:"h3_index"`
-	FieldCount int32   `json:"field_count"`
+type AggregateClustersByH3ForCellsParams struct {
+	Resolution  int32    `json:"resolution"`
+	LowerBounds []string `json:"lower_bounds"`
+	UpperBounds []string `json:"upper_bounds"`
 }
 
-// 指定H3セル(res9)のみfieldsを集計(差分更新用)
-func (q *Queries) AggregateClustersByRes9ForCells(ctx context.Context, h3Cells []string) ([]*AggregateClustersByRes9ForCellsRow, error) {
-	rows, err := q.db.Query(ctx, aggregateClustersByRes9ForCells, h3Cells)
+type AggregateClustersByH3ForCellsRow struct {
+	H3Index      string  `json:"h3_index"`
+	FieldCount   int32   `json:"field_count"`
+	TotalAreaSqm float64 `json:"total_area_sqm"`
+}
+
+// 指定H3セルのみ有効なfieldsを集計(差分更新用)
+// lower_bounds/upper_boundsは各セルの解像度15の子孫セルの最小値・最大値で、h3_indexの範囲検索で絞り込む
+func (q *Queries) AggregateClustersByH3ForCells(ctx context.Context, arg *AggregateClustersByH3ForCellsParams) ([]*AggregateClustersByH3ForCellsRow, error) {
+	rows, err := q.db.Query(ctx, aggregateClustersByH3ForCells, arg.Resolution, arg.LowerBounds, arg.UpperBounds)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*AggregateClustersByRes9ForCellsRow{}
+	items := []*AggregateClustersByH3ForCellsRow{}
 	for rows.Next() {
-		var i AggregateClustersByRes9ForCellsRow
-		if err := rows.Scan(&i.H3Index, &i.FieldCount); err != nil {
+		var i AggregateClustersByH3ForCellsRow
+		if err := rows.Scan(&i.H3Index, &i.FieldCount, &i.TotalAreaSqm); err != nil {
 			return nil, err
 		}
 		items = append(items, &i)
@@ -291,60 +467,215 @@ func (q *Queries) AggregateClustersByRes9ForCells(ctx context.Context, h3Cells [
 	return items, nil
 }
 
-const deleteAllClusterResults = `-- name: DeleteAllClusterResults :exec
-DELETE FROM cluster_results
+const aggregateFilteredClusters = `-- name: AggregateFilteredClusters :many
+WITH targets AS (
+    SELECT
+        h3_cell_to_parent(f.h3_index, $1::INT) AS h3_index,
+        f.id,
+        f.soil_type_id,
+        f.area_sqm,
+        EXISTS (
+            SELECT 1
+            FROM field_land_registries r
+            WHERE r.field_id = f.id AND r.idle_land_status_code IS NOT NULL
+        ) AS is_idle
+    FROM fields f
+    WHERE
+        f.retired_at IS NULL
+        AND (
+            ST_Intersects(
+                f.centroid,
+                ST_MakeEnvelope(
+                    $2::FLOAT8, $3::FLOAT8,
+                    CASE WHEN $2::FLOAT8 <= $4::FLOAT8 THEN $4::FLOAT8 ELSE 180 END, $5::FLOAT8,
+                    4326
+                )
+            )
+            OR (
+                $2::FLOAT8 > $4::FLOAT8
+                AND ST_Intersects(f.centroid, ST_MakeEnvelope(-180, $3::FLOAT8, $4::FLOAT8, $5::FLOAT8, 4326))
+            )
+        )
+        AND ($6::VARCHAR IS NULL OR f.city_code = $6::VARCHAR)
+        AND ($7::VARCHAR IS NULL OR EXISTS (
+            SELECT 1 FROM soil_types st
+            WHERE st.id = f.soil_type_id AND st.small_code = $7::VARCHAR
+        ))
+        AND ($8::VARCHAR IS NULL OR EXISTS (
+            SELECT 1 FROM field_land_registries r
+            WHERE r.field_id = f.id AND r.land_category_code = $8::VARCHAR
+        ))
+        AND ($9::VARCHAR IS NULL OR EXISTS (
+            SELECT 1 FROM field_land_registries r
+            WHERE r.field_id = f.id AND r.idle_land_status_code = $9::VARCHAR
+        ))
+        AND ($10::FLOAT8 IS NULL OR f.area_sqm >= $10::FLOAT8)
+        AND ($11::FLOAT8 IS NULL OR f.area_sqm <= $11::FLOAT8)
+),
+cells AS (
+    SELECT
+        t.h3_index,
+        COUNT(*)::INT AS field_count,
+        COALESCE(SUM(t.area_sqm), 0)::DOUBLE PRECISION AS total_area_sqm,
+        COUNT(*) FILTER (WHERE t.is_idle)::INT AS idle_field_count
+    FROM targets t
+    WHERE t.h3_index IS NOT NULL
+    GROUP BY t.h3_index
+),
+categories AS (
+    SELECT
+        t.h3_index,
+        r.land_category_code,
+        COUNT(DISTINCT t.id)::INT AS field_count
+    FROM targets t
+    JOIN field_land_registries r ON r.field_id = t.id
+    WHERE r.land_category_code IS NOT NULL
+    GROUP BY t.h3_index, r.land_category_code
+),
+soils AS (
+    SELECT DISTINCT ON (t.h3_index)
+        t.h3_index,
+        s.large_code
+    FROM targets t
+    JOIN soil_types s ON s.id = t.soil_type_id
+    GROUP BY t.h3_index, s.large_code
+    ORDER BY t.h3_index, SUM(t.area_sqm) DESC NULLS LAST, s.large_code
+)
+SELECT
+    cells.h3_index::TEXT AS h3_index,
+    cells.field_count,
+    cells.total_area_sqm,
+    COALESCE((
+        SELECT jsonb_object_agg(cat.land_category_code, cat.field_count)
+        FROM categories cat
+        WHERE cat.h3_index = cells.h3_index
+    ), '{}')::JSONB AS land_category_counts,
+    cells.idle_field_count,
+    soils.large_code AS dominant_soil_large_code
+FROM cells
+LEFT JOIN soils ON soils.h3_index = cells.h3_index
+ORDER BY cells.h3_index
 `
 
-// 全クラスター結果を削除
-func (q *Queries) DeleteAllClusterResults(ctx context.Context) error {
-	_, err := q.db.Exec(ctx, deleteAllClusterResults)
-	return err
+type AggregateFilteredClustersParams struct {
+	Resolution         int32    `json:"resolution"`
+	SwLng              float64  `json:"sw_lng"`
+	SwLat              float64  `json:"sw_lat"`
+	NeLng              float64  `json:"ne_lng"`
+	NeLat              float64  `json:"ne_lat"`
+	CityCode           *string  `json:"city_code"`
+	SoilSmallCode      *string  `json:"soil_small_code"`
+	LandCategoryCode   *string  `json:"land_category_code"`
+	IdleLandStatusCode *string  `json:"idle_land_status_code"`
+	MinAreaSqm         *float64 `json:"min_area_sqm"`
+	MaxAreaSqm         *float64 `json:"max_area_sqm"`
+}
+
+type AggregateFilteredClustersRow struct {
+	H3Index               string  `json:"h3_index"`
+	FieldCount            int32   `json:"field_count"`
+	TotalAreaSqm          float64 `json:"total_area_sqm"`
+	LandCategoryCounts    []byte  `json:"land_category_counts"`
+	IdleFieldCount        int32   `json:"idle_field_count"`
+	DominantSoilLargeCode *string `json:"dominant_soil_large_code"`
+}
+
+// 絞り込み条件に一致する有効なfieldsを重心のH3セルごとにその場で集計(cluster_resultsを使用しない)
+// 重心が指定範囲内の圃場のみ対象とし、圃場数・合計面積と属性別の集計を返す。各絞り込み条件はNULLの場合に無視される
+// 南西端の経度が北東端の経度より大きい場合は日付変更線をまたぐ範囲として東西2つの矩形で検索する
+func (q *Queries) AggregateFilteredClusters(ctx context.Context, arg *AggregateFilteredClustersParams) ([]*AggregateFilteredClustersRow, error) {
+	rows, err := q.db.Query(ctx, aggregateFilteredClusters,
+		arg.Resolution,
+		arg.SwLng,
+		arg.SwLat,
+		arg.NeLng,
+		arg.NeLat,
+		arg.CityCode,
+		arg.SoilSmallCode,
+		arg.LandCategoryCode,
+		arg.IdleLandStatusCode,
+		arg.MinAreaSqm,
+		arg.MaxAreaSqm,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*AggregateFilteredClustersRow{}
+	for rows.Next() {
+		var i AggregateFilteredClustersRow
+		if err := rows.Scan(
+			&i.H3Index,
+			&i.FieldCount,
+			&i.TotalAreaSqm,
+			&i.LandCategoryCounts,
+			&i.IdleFieldCount,
+			&i.DominantSoilLargeCode,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const deleteClusterResultsByH3Indexes = `-- name: DeleteClusterResultsByH3Indexes :exec
 DELETE FROM cluster_results
-WHERE resolution = $1 AND h3_index = ANY($2::TEXT[])
+WHERE generation = $1 AND resolution = $2 AND h3_index = ANY($3::TEXT[])
 `
 
 type DeleteClusterResultsByH3IndexesParams struct {
+	Generation int64    `json:"generation"`
 	Resolution int32    `json:"resolution"`
 	H3Indexes  []string `json:"h3_indexes"`
 }
 
-// 指定H3インデックスのクラスター結果を削除(カウント0になったセル用)
+// 指定世代の指定H3インデックスのクラスター結果を削除(カウント0になったセル用)
 func (q *Queries) DeleteClusterResultsByH3Indexes(ctx context.Context, arg *DeleteClusterResultsByH3IndexesParams) error {
-	_, err := q.db.Exec(ctx, deleteClusterResultsByH3Indexes, arg.Resolution, arg.H3Indexes)
+	_, err := q.db.Exec(ctx, deleteClusterResultsByH3Indexes, arg.Generation, arg.Resolution, arg.H3Indexes)
 	return err
 }
 
-const deleteClusterResultsByResolution = `-- name: DeleteClusterResultsByResolution :exec
-DELETE FROM cluster_results
-WHERE resolution = $1
+const getClusterResultsInRanges = `-- name: GetClusterResultsInRanges :many
+SELECT
+    cr.id,
+    cr.resolution,
+    cr.h3_index,
+    cr.field_count,
+    cr.center_lat,
+    cr.center_lng,
+    cr.calculated_at,
+    cr.total_area_sqm,
+    cr.land_category_counts,
+    cr.idle_field_count,
+    cr.dominant_soil_large_code,
+    cr.generation
+FROM cluster_results cr
+JOIN unnest($1::TEXT[], $2::TEXT[]) AS b(lower_bound, upper_bound)
+    ON cr.h3_index BETWEEN b.lower_bound AND b.upper_bound
+WHERE cr.generation = $3 AND cr.resolution = $4
+ORDER BY cr.h3_index
 `
 
-// 指定解像度のクラスター結果を全削除
-func (q *Queries) DeleteClusterResultsByResolution(ctx context.Context, resolution int32) error {
-	_, err := q.db.Exec(ctx, deleteClusterResultsByResolution, resolution)
-	return err
+type GetClusterResultsInRangesParams struct {
+	LowerBounds []string `json:"lower_bounds"`
+	UpperBounds []string `json:"upper_bounds"`
+	Generation  int64    `json:"generation"`
+	Resolution  int32    `json:"resolution"`
 }
 
-const getClusterResults = `-- name: GetClusterResults :many
-SELECT
-    id,
-    resolution,
-    h3_index,
-    field_count,
-    center_lat,
-    center_lng,
-    calculated_at
-FROM cluster_results
-WHERE resolution = $1
-ORDER BY h3_index
-`
-
-// 指定解像度のクラスター結果を取得
-func (q *Queries) GetClusterResults(ctx context.Context, resolution int32) ([]*ClusterResult, error) {
-	rows, err := q.db.Query(ctx, getClusterResults, resolution)
+// 指定世代・解像度のクラスター結果のうち、指定範囲のH3インデックスのものを取得
+// lower_bounds/upper_boundsは表示範囲を覆うセルの指定解像度の子孫セルの最小値・最大値で、h3_indexの範囲検索で絞り込む
+func (q *Queries) GetClusterResultsInRanges(ctx context.Context, arg *GetClusterResultsInRangesParams) ([]*ClusterResult, error) {
+	rows, err := q.db.Query(ctx, getClusterResultsInRanges,
+		arg.LowerBounds,
+		arg.UpperBounds,
+		arg.Generation,
+		arg.Resolution,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -360,6 +691,11 @@ func (q *Queries) GetClusterResults(ctx context.Context, resolution int32) ([]*C
 			&i.CenterLat,
 			&i.CenterLng,
 			&i.CalculatedAt,
+			&i.TotalAreaSqm,
+			&i.LandCategoryCounts,
+			&i.IdleFieldCount,
+			&i.DominantSoilLargeCode,
+			&i.Generation,
 		); err != nil {
 			return nil, err
 		}
@@ -374,40 +710,59 @@ func (q *Queries) GetClusterResults(ctx context.Context, resolution int32) ([]*C
 const upsertClusterResult = `-- name: UpsertClusterResult :exec
 INSERT INTO cluster_results (
     id,
+    generation,
     resolution,
     h3_index,
     field_count,
     center_lat,
     center_lng,
+    total_area_sqm,
+    land_category_counts,
+    idle_field_count,
+    dominant_soil_large_code,
     calculated_at
 )
-VALUES ($1, $2, $3, $4, $5, $6, NOW())
-ON CONFLICT (resolution, h3_index)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
+ON CONFLICT (generation, resolution, h3_index)
 DO UPDATE SET
     field_count = EXCLUDED.field_count,
     center_lat = EXCLUDED.center_lat,
     center_lng = EXCLUDED.center_lng,
+    total_area_sqm = EXCLUDED.total_area_sqm,
+    land_category_counts = EXCLUDED.land_category_counts,
+    idle_field_count = EXCLUDED.idle_field_count,
+    dominant_soil_large_code = EXCLUDED.dominant_soil_large_code,
     calculated_at = NOW()
 `
 
 type UpsertClusterResultParams struct {
-	ID         uuid.UUID `json:"id"`
-	Resolution int32     `json:"resolution"`
-	H3Index    string    `json:"h3_index"`
-	FieldCount int32     `json:"field_count"`
-	CenterLat  float64   `json:"center_lat"`
-	CenterLng  float64   `json:"center_lng"`
+	ID                    uuid.UUID `json:"id"`
+	Generation            int64     `json:"generation"`
+	Resolution            int32     `json:"resolution"`
+	H3Index               string    `json:"h3_index"`
+	FieldCount            int32     `json:"field_count"`
+	CenterLat             float64   `json:"center_lat"`
+	CenterLng             float64   `json:"center_lng"`
+	TotalAreaSqm          float64   `json:"total_area_sqm"`
+	LandCategoryCounts    []byte    `json:"land_category_counts"`
+	IdleFieldCount        int32     `json:"idle_field_count"`
+	DominantSoilLargeCode *string   `json:"dominant_soil_large_code"`
 }
 
-// クラスター結果をUPSERT
+// 指定世代のクラスター結果をUPSERT
 func (q *Queries) UpsertClusterResult(ctx context.Context, arg *UpsertClusterResultParams) error {
 	_, err := q.db.Exec(ctx, upsertClusterResult,
 		arg.ID,
+		arg.Generation,
 		arg.Resolution,
 		arg.H3Index,
 		arg.FieldCount,
 		arg.CenterLat,
 		arg.CenterLng,
+		arg.TotalAreaSqm,
+		arg.LandCategoryCounts,
+		arg.IdleFieldCount,
+		arg.DominantSoilLargeCode,
 	)
 	return err
 }
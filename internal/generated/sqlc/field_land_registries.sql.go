@@ -178,3 +178,168 @@ func (q *Queries) ListFieldLandRegistriesByFieldID(ctx context.Context, fieldID
 	}
 	return items, nil
 }
+
+const listFieldLandRegistriesForExport = `-- name: ListFieldLandRegistriesForExport :many
+SELECT
+    r.field_id,
+    r.farmer_number,
+    r.address,
+    r.area_sqm,
+    r.land_category_code,
+    lc.name AS land_category_name,
+    r.idle_land_status_code,
+    ils.name AS idle_land_status_name,
+    r.descriptive_study_data
+FROM field_land_registries r
+LEFT JOIN land_categories lc ON lc.code = r.land_category_code
+LEFT JOIN idle_land_statuses ils ON ils.code = r.idle_land_status_code
+WHERE r.field_id = ANY($1::UUID[])
+ORDER BY r.field_id, r.created_at
+`
+
+type ListFieldLandRegistriesForExportRow struct {
+	FieldID              uuid.UUID   `json:"field_id"`
+	FarmerNumber         *string     `json:"farmer_number"`
+	Address              *string     `json:"address"`
+	AreaSqm              *int32      `json:"area_sqm"`
+	LandCategoryCode     *string     `json:"land_category_code"`
+	LandCategoryName     *string     `json:"land_category_name"`
+	IdleLandStatusCode   *string     `json:"idle_land_status_code"`
+	IdleLandStatusName   *string     `json:"idle_land_status_name"`
+	DescriptiveStudyData pgtype.Date `json:"descriptive_study_data"`
+}
+
+// 複数の圃場IDで農地台帳一覧を土地種別名・遊休農地状況名付きで取得(エクスポート用)
+func (q *Queries) ListFieldLandRegistriesForExport(ctx context.Context, fieldIds []uuid.UUID) ([]*ListFieldLandRegistriesForExportRow, error) {
+	rows, err := q.db.Query(ctx, listFieldLandRegistriesForExport, fieldIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListFieldLandRegistriesForExportRow{}
+	for rows.Next() {
+		var i ListFieldLandRegistriesForExportRow
+		if err := rows.Scan(
+			&i.FieldID,
+			&i.FarmerNumber,
+			&i.Address,
+			&i.AreaSqm,
+			&i.LandCategoryCode,
+			&i.LandCategoryName,
+			&i.IdleLandStatusCode,
+			&i.IdleLandStatusName,
+			&i.DescriptiveStudyData,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFieldLandRegistriesWithMastersByFieldID = `-- name: ListFieldLandRegistriesWithMastersByFieldID :many
+SELECT
+    r.id,
+    r.field_id,
+    r.farmer_number,
+    r.address,
+    r.area_sqm,
+    r.land_category_code,
+    lc.name AS land_category_name,
+    r.idle_land_status_code,
+    ils.name AS idle_land_status_name,
+    r.descriptive_study_data,
+    r.created_at,
+    r.updated_at
+FROM field_land_registries r
+LEFT JOIN land_categories lc ON lc.code = r.land_category_code
+LEFT JOIN idle_land_statuses ils ON ils.code = r.idle_land_status_code
+WHERE r.field_id = $1
+ORDER BY r.created_at
+`
+
+type ListFieldLandRegistriesWithMastersByFieldIDRow struct {
+	ID                   uuid.UUID          `json:"id"`
+	FieldID              uuid.UUID          `json:"field_id"`
+	FarmerNumber         *string            `json:"farmer_number"`
+	Address              *string            `json:"address"`
+	AreaSqm              *int32             `json:"area_sqm"`
+	LandCategoryCode     *string            `json:"land_category_code"`
+	LandCategoryName     *string            `json:"land_category_name"`
+	IdleLandStatusCode   *string            `json:"idle_land_status_code"`
+	IdleLandStatusName   *string            `json:"idle_land_status_name"`
+	DescriptiveStudyData pgtype.Date        `json:"descriptive_study_data"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+}
+
+// 圃場IDで農地台帳一覧を土地種別名・遊休農地状況名付きで取得
+func (q *Queries) ListFieldLandRegistriesWithMastersByFieldID(ctx context.Context, fieldID uuid.UUID) ([]*ListFieldLandRegistriesWithMastersByFieldIDRow, error) {
+	rows, err := q.db.Query(ctx, listFieldLandRegistriesWithMastersByFieldID, fieldID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListFieldLandRegistriesWithMastersByFieldIDRow{}
+	for rows.Next() {
+		var i ListFieldLandRegistriesWithMastersByFieldIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FieldID,
+			&i.FarmerNumber,
+			&i.Address,
+			&i.AreaSqm,
+			&i.LandCategoryCode,
+			&i.LandCategoryName,
+			&i.IdleLandStatusCode,
+			&i.IdleLandStatusName,
+			&i.DescriptiveStudyData,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveFieldLandRegistries = `-- name: MoveFieldLandRegistries :exec
+UPDATE field_land_registries
+SET field_id = $1
+WHERE field_id = ANY($2::UUID[])
+`
+
+type MoveFieldLandRegistriesParams struct {
+	FieldID        uuid.UUID   `json:"field_id"`
+	SourceFieldIds []uuid.UUID `json:"source_field_ids"`
+}
+
+// 複数圃場の農地台帳をまとめて別の圃場に付け替える(合筆時の引き継ぎ用)
+func (q *Queries) MoveFieldLandRegistries(ctx context.Context, arg *MoveFieldLandRegistriesParams) error {
+	_, err := q.db.Exec(ctx, moveFieldLandRegistries, arg.FieldID, arg.SourceFieldIds)
+	return err
+}
+
+const updateFieldLandRegistryFieldID = `-- name: UpdateFieldLandRegistryFieldID :exec
+UPDATE field_land_registries
+SET field_id = $1
+WHERE id = $2
+`
+
+type UpdateFieldLandRegistryFieldIDParams struct {
+	FieldID uuid.UUID `json:"field_id"`
+	ID      uuid.UUID `json:"id"`
+}
+
+// 農地台帳の所属圃場を変更(分筆・合筆時の付け替え用)
+func (q *Queries) UpdateFieldLandRegistryFieldID(ctx context.Context, arg *UpdateFieldLandRegistryFieldIDParams) error {
+	_, err := q.db.Exec(ctx, updateFieldLandRegistryFieldID, arg.FieldID, arg.ID)
+	return err
+}
@@ -9,13 +9,69 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const checkFieldDivisionGeometries = `-- name: CheckFieldDivisionGeometries :many
+WITH children AS (
+    SELECT
+        c.ord::INTEGER AS child_index,
+        ST_GeomFromWKB(c.wkb, 4326) AS geom
+    FROM unnest($1::BYTEA[]) WITH ORDINALITY AS c(wkb, ord)
+)
+SELECT
+    c.child_index,
+    ST_Area(ST_Difference(c.geom, p.geometry)::geography)::FLOAT8 AS outside_area_sqm,
+    COALESCE((
+        SELECT SUM(ST_Area(ST_Intersection(c.geom, o.geom)::geography))
+        FROM children o
+        WHERE o.child_index > c.child_index AND ST_Intersects(c.geom, o.geom)
+    ), 0)::FLOAT8 AS overlap_area_sqm
+FROM children c
+CROSS JOIN fields p
+WHERE p.id = $2
+ORDER BY c.child_index
+`
+
+type CheckFieldDivisionGeometriesParams struct {
+	ChildGeometryWkbs [][]byte  `json:"child_geometry_wkbs"`
+	ParentID          uuid.UUID `json:"parent_id"`
+}
+
+type CheckFieldDivisionGeometriesRow struct {
+	ChildIndex     int32   `json:"child_index"`
+	OutsideAreaSqm float64 `json:"outside_area_sqm"`
+	OverlapAreaSqm float64 `json:"overlap_area_sqm"`
+}
+
+// 分筆後の子圃場が親圃場に収まり、互いに重ならないかを検証するための面積を取得
+// child_indexは入力配列の順序(1始まり)。outside_area_sqmは親圃場からはみ出した面積、
+// overlap_area_sqmは自身より後ろの子圃場と重なる面積の合計
+func (q *Queries) CheckFieldDivisionGeometries(ctx context.Context, arg *CheckFieldDivisionGeometriesParams) ([]*CheckFieldDivisionGeometriesRow, error) {
+	rows, err := q.db.Query(ctx, checkFieldDivisionGeometries, arg.ChildGeometryWkbs, arg.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*CheckFieldDivisionGeometriesRow{}
+	for rows.Next() {
+		var i CheckFieldDivisionGeometriesRow
+		if err := rows.Scan(&i.ChildIndex, &i.OutsideAreaSqm, &i.OverlapAreaSqm); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countFields = `-- name: CountFields :one
-SELECT COUNT(*) FROM fields
+SELECT COUNT(*) FROM fields WHERE retired_at IS NULL
 `
 
-// 圃場の総数を取得
+// 有効な圃場の総数を取得
 func (q *Queries) CountFields(ctx context.Context) (int64, error) {
 	row := q.db.QueryRow(ctx, countFields)
 	var count int64
@@ -23,60 +79,127 @@ func (q *Queries) CountFields(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countSearchFields = `-- name: CountSearchFields :one
+SELECT COUNT(*)
+FROM fields f
+WHERE
+    f.retired_at IS NULL
+    AND ($1::VARCHAR IS NULL OR f.city_code = $1::VARCHAR)
+    AND ($2::VARCHAR IS NULL OR EXISTS (
+        SELECT 1 FROM soil_types st
+        WHERE st.id = f.soil_type_id AND st.small_code = $2::VARCHAR
+    ))
+    AND ($3::VARCHAR IS NULL OR EXISTS (
+        SELECT 1 FROM field_land_registries r
+        WHERE r.field_id = f.id AND r.land_category_code = $3::VARCHAR
+    ))
+    AND ($4::VARCHAR IS NULL OR EXISTS (
+        SELECT 1 FROM field_land_registries r
+        WHERE r.field_id = f.id AND r.idle_land_status_code = $4::VARCHAR
+    ))
+    AND ($5::FLOAT8 IS NULL OR f.area_sqm >= $5::FLOAT8)
+    AND ($6::FLOAT8 IS NULL OR f.area_sqm <= $6::FLOAT8)
+    AND ($7::FLOAT8 IS NULL OR ST_Intersects(
+        f.geometry,
+        ST_MakeEnvelope(
+            $7::FLOAT8, $8::FLOAT8,
+            CASE WHEN $7::FLOAT8 <= $9::FLOAT8 THEN $9::FLOAT8 ELSE 180 END, $10::FLOAT8,
+            4326
+        )
+    ) OR (
+        $7::FLOAT8 > $9::FLOAT8
+        AND ST_Intersects(f.geometry, ST_MakeEnvelope(-180, $8::FLOAT8, $9::FLOAT8, $10::FLOAT8, 4326))
+    ))
+    AND ($11::TEXT IS NULL OR f.name ILIKE $12::TEXT ESCAPE '\' OR f.name % $11::TEXT)
+`
+
+type CountSearchFieldsParams struct {
+	CityCode           *string  `json:"city_code"`
+	SoilSmallCode      *string  `json:"soil_small_code"`
+	LandCategoryCode   *string  `json:"land_category_code"`
+	IdleLandStatusCode *string  `json:"idle_land_status_code"`
+	MinAreaSqm         *float64 `json:"min_area_sqm"`
+	MaxAreaSqm         *float64 `json:"max_area_sqm"`
+	SwLng              *float64 `json:"sw_lng"`
+	SwLat              *float64 `json:"sw_lat"`
+	NeLng              *float64 `json:"ne_lng"`
+	NeLat              *float64 `json:"ne_lat"`
+	Name               *string  `json:"name"`
+	NamePattern        *string  `json:"name_pattern"`
+}
+
+// 検索条件に一致する圃場の総数を取得(SearchFieldsと同一条件)
+func (q *Queries) CountSearchFields(ctx context.Context, arg *CountSearchFieldsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countSearchFields,
+		arg.CityCode,
+		arg.SoilSmallCode,
+		arg.LandCategoryCode,
+		arg.IdleLandStatusCode,
+		arg.MinAreaSqm,
+		arg.MaxAreaSqm,
+		arg.SwLng,
+		arg.SwLat,
+		arg.NeLng,
+		arg.NeLat,
+		arg.Name,
+		arg.NamePattern,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createField = `-- name: CreateField :one
 INSERT INTO fields (
+    id,
     geometry,
     centroid,
-    h3_index_res3,
-    h3_index_res5,
-    h3_index_res7,
-    h3_index_res9,
+    h3_index,
     city_code,
     name,
     soil_type_id,
-    created_by
+    created_by,
+    updated_by
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
-) RETURNING id, geometry, centroid, area_sqm, h3_index_res3, h3_index_res5, h3_index_res7, h3_index_res9, city_code, name, soil_type_id, created_at, updated_at, created_by, updated_by
+    $1,
+    ST_Multi(ST_GeomFromWKB($2::bytea, 4326)),
+    ST_GeomFromWKB($3::bytea, 4326),
+    $4, $5, $6, $7, $8, $9
+) RETURNING id, geometry, centroid, city_code, name, soil_type_id, created_at, updated_at, created_by, updated_by, retired_at, area_sqm, h3_index
 `
 
 type CreateFieldParams struct {
-	Geometry    interface{}   `json:"geometry"`
-	Centroid    interface{}   `json:"centroid"`
-	H3IndexRes3 *string       `json:"h3_index_res3"`
-	H3IndexRes5 *string       `json:"h3_index_res5"`
-	H3IndexRes7 *string       `json:"h3_index_res7"`
-	H3IndexRes9 *string       `json:"h3_index_res9"`
+	ID          uuid.UUID     `json:"id"`
+	GeometryWkb []byte        `json:"geometry_wkb"`
+	CentroidWkb []byte        `json:"centroid_wkb"`
+	H3Index     *string       `json:"h3_index"`
 	CityCode    string        `json:"city_code"`
 	Name        string        `json:"name"`
 	SoilTypeID  uuid.NullUUID `json:"soil_type_id"`
 	CreatedBy   uuid.NullUUID `json:"created_by"`
+	UpdatedBy   uuid.NullUUID `json:"updated_by"`
 }
 
 // 圃場を作成
+// geometry, centroidはWKB形式のbytea型で受け取り、ST_GeomFromWKBで変換
+// geometryはPolygonで渡されてもST_Multiでマルチポリゴンに揃える
 func (q *Queries) CreateField(ctx context.Context, arg *CreateFieldParams) (*Field, error) {
 	row := q.db.QueryRow(ctx, createField,
-		arg.Geometry,
-		arg.Centroid,
-		arg.H3IndexRes3,
-		arg.H3IndexRes5,
-		arg.H3IndexRes7,
-		arg.H3IndexRes9,
+		arg.ID,
+		arg.GeometryWkb,
+		arg.CentroidWkb,
+		arg.H3Index,
 		arg.CityCode,
 		arg.Name,
 		arg.SoilTypeID,
 		arg.CreatedBy,
+		arg.UpdatedBy,
 	)
 	var i Field
 	err := row.Scan(
 		&i.ID,
 		&i.Geometry,
 		&i.Centroid,
-		&i.AreaSqm,
-		&i.H3IndexRes3,
-		&i.H3IndexRes5,
-		&i.H3IndexRes7,
-		&i.H3IndexRes9,
 		&i.CityCode,
 		&i.Name,
 		&i.SoilTypeID,
@@ -84,6 +207,9 @@ func (q *Queries) CreateField(ctx context.Context, arg *CreateFieldParams) (*Fie
 		&i.UpdatedAt,
 		&i.CreatedBy,
 		&i.UpdatedBy,
+		&i.RetiredAt,
+		&i.AreaSqm,
+		&i.H3Index,
 	)
 	return &i, err
 }
@@ -103,18 +229,16 @@ SELECT
     id,
     geometry,
     centroid,
-    area_sqm,
-    h3_index_res3,
-    h3_index_res5,
-    h3_index_res7,
-    h3_index_res9,
     city_code,
     name,
     soil_type_id,
     created_at,
     updated_at,
     created_by,
-    updated_by
+    updated_by,
+    retired_at,
+    area_sqm,
+    h3_index
 FROM fields
 WHERE id = $1
 `
@@ -127,11 +251,6 @@ func (q *Queries) GetField(ctx context.Context, id uuid.UUID) (*Field, error) {
 		&i.ID,
 		&i.Geometry,
 		&i.Centroid,
-		&i.AreaSqm,
-		&i.H3IndexRes3,
-		&i.H3IndexRes5,
-		&i.H3IndexRes7,
-		&i.H3IndexRes9,
 		&i.CityCode,
 		&i.Name,
 		&i.SoilTypeID,
@@ -139,27 +258,72 @@ func (q *Queries) GetField(ctx context.Context, id uuid.UUID) (*Field, error) {
 		&i.UpdatedAt,
 		&i.CreatedBy,
 		&i.UpdatedBy,
+		&i.RetiredAt,
+		&i.AreaSqm,
+		&i.H3Index,
 	)
 	return &i, err
 }
 
+const getFieldTile = `-- name: GetFieldTile :one
+WITH bounds AS (
+    SELECT
+        ST_TileEnvelope($1::INTEGER, $2::INTEGER, $3::INTEGER) AS tile_geom,
+        ST_Transform(ST_TileEnvelope($1::INTEGER, $2::INTEGER, $3::INTEGER, margin => 0.015625), 4326) AS filter_geom
+),
+mvt_features AS (
+    SELECT
+        f.id::TEXT AS id,
+        f.name,
+        f.area_sqm,
+        st.small_code AS soil_small_code,
+        lr.land_category_code AS land_category,
+        lc.name AS land_category_name,
+        ST_AsMVTGeom(ST_Transform(f.geometry, 3857), b.tile_geom, 4096, 64, true) AS geom
+    FROM fields f
+    CROSS JOIN bounds b
+    LEFT JOIN soil_types st ON st.id = f.soil_type_id
+    LEFT JOIN LATERAL (
+        SELECT r.land_category_code
+        FROM field_land_registries r
+        WHERE r.field_id = f.id AND r.land_category_code IS NOT NULL
+        ORDER BY r.area_sqm DESC NULLS LAST, r.id
+        LIMIT 1
+    ) lr ON true
+    LEFT JOIN land_categories lc ON lc.code = lr.land_category_code
+    WHERE f.geometry && b.filter_geom AND f.retired_at IS NULL
+)
+SELECT COALESCE(ST_AsMVT(mvt_features.*, 'fields', 4096, 'geom'), ''::BYTEA)::BYTEA AS tile
+FROM mvt_features
+`
+
+type GetFieldTileParams struct {
+	Z int32 `json:"z"`
+	X int32 `json:"x"`
+	Y int32 `json:"y"`
+}
+
+// 指定タイルの圃場をMapbox Vector Tile(MVT)形式で取得
+// タイル範囲(EPSG:3857)をWGS84に変換してidx_fields_geometry_gistで絞り込み、ST_AsMVTGeomでタイル座標に変換する
+// 土地種別は農地台帳のうち面積が最大のものを代表値とする。廃止済みの圃場は含めない
+func (q *Queries) GetFieldTile(ctx context.Context, arg *GetFieldTileParams) ([]byte, error) {
+	row := q.db.QueryRow(ctx, getFieldTile, arg.Z, arg.X, arg.Y)
+	var tile []byte
+	err := row.Scan(&tile)
+	return tile, err
+}
+
 const getH3IndexesByFieldIDs = `-- name: GetH3IndexesByFieldIDs :many
 SELECT
     id,
-    h3_index_res3,
-    h3_index_res5,
-    h3_index_res7,
-    h3_index_res9
+    h3_index
 FROM fields
 WHERE id = ANY($1::UUID[])
 `
 
 type GetH3IndexesByFieldIDsRow struct {
-	ID          uuid.UUID `json:"id"`
-	H3IndexRes3 *string   `json:"h3_index_res3"`
-	H3IndexRes5 *string   `json:"h3_index_res5"`
-	H3IndexRes7 *string   `json:"h3_index_res7"`
-	H3IndexRes9 *string   `json:"h3_index_res9"`
+	ID      uuid.UUID `json:"id"`
+	H3Index *string   `json:"h3_index"`
 }
 
 // 指定IDのフィールドのH3インデックスを取得(差分更新のプリフェッチ用)
@@ -172,13 +336,7 @@ func (q *Queries) GetH3IndexesByFieldIDs(ctx context.Context, ids []uuid.UUID) (
 	items := []*GetH3IndexesByFieldIDsRow{}
 	for rows.Next() {
 		var i GetH3IndexesByFieldIDsRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.H3IndexRes3,
-			&i.H3IndexRes5,
-			&i.H3IndexRes7,
-			&i.H3IndexRes9,
-		); err != nil {
+		if err := rows.Scan(&i.ID, &i.H3Index); err != nil {
 			return nil, err
 		}
 		items = append(items, &i)
@@ -189,24 +347,39 @@ func (q *Queries) GetH3IndexesByFieldIDs(ctx context.Context, ids []uuid.UUID) (
 	return items, nil
 }
 
+const hasFieldLineage = `-- name: HasFieldLineage :one
+SELECT (
+    EXISTS (SELECT 1 FROM field_divisions WHERE child_field_id = $1::UUID)
+    OR EXISTS (SELECT 1 FROM field_mergers WHERE merged_field_id = $1::UUID)
+)::BOOLEAN AS has_lineage
+`
+
+// 圃場が分筆・合筆で作られた圃場かどうかを取得
+// 系譜のある圃場を削除すると分筆・合筆の履歴がカスケード削除されるため、削除の代わりに廃止する判定に使う
+func (q *Queries) HasFieldLineage(ctx context.Context, fieldID uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, hasFieldLineage, fieldID)
+	var has_lineage bool
+	err := row.Scan(&has_lineage)
+	return has_lineage, err
+}
+
 const listFields = `-- name: ListFields :many
 SELECT
     id,
     geometry,
     centroid,
-    area_sqm,
-    h3_index_res3,
-    h3_index_res5,
-    h3_index_res7,
-    h3_index_res9,
     city_code,
     name,
     soil_type_id,
     created_at,
     updated_at,
     created_by,
-    updated_by
+    updated_by,
+    retired_at,
+    area_sqm,
+    h3_index
 FROM fields
+WHERE retired_at IS NULL
 ORDER BY created_at DESC
 LIMIT $1
 OFFSET $2
@@ -217,7 +390,7 @@ type ListFieldsParams struct {
 	Offset int32 `json:"offset"`
 }
 
-// 圃場一覧を取得
+// 有効な圃場一覧を取得
 func (q *Queries) ListFields(ctx context.Context, arg *ListFieldsParams) ([]*Field, error) {
 	rows, err := q.db.Query(ctx, listFields, arg.Limit, arg.Offset)
 	if err != nil {
@@ -231,11 +404,6 @@ func (q *Queries) ListFields(ctx context.Context, arg *ListFieldsParams) ([]*Fie
 			&i.ID,
 			&i.Geometry,
 			&i.Centroid,
-			&i.AreaSqm,
-			&i.H3IndexRes3,
-			&i.H3IndexRes5,
-			&i.H3IndexRes7,
-			&i.H3IndexRes9,
 			&i.CityCode,
 			&i.Name,
 			&i.SoilTypeID,
@@ -243,6 +411,9 @@ func (q *Queries) ListFields(ctx context.Context, arg *ListFieldsParams) ([]*Fie
 			&i.UpdatedAt,
 			&i.CreatedBy,
 			&i.UpdatedBy,
+			&i.RetiredAt,
+			&i.AreaSqm,
+			&i.H3Index,
 		); err != nil {
 			return nil, err
 		}
@@ -259,20 +430,18 @@ SELECT
     id,
     geometry,
     centroid,
-    area_sqm,
-    h3_index_res3,
-    h3_index_res5,
-    h3_index_res7,
-    h3_index_res9,
     city_code,
     name,
     soil_type_id,
     created_at,
     updated_at,
     created_by,
-    updated_by
+    updated_by,
+    retired_at,
+    area_sqm,
+    h3_index
 FROM fields
-WHERE city_code = $1
+WHERE city_code = $1 AND retired_at IS NULL
 ORDER BY created_at DESC
 LIMIT $2
 OFFSET $3
@@ -284,7 +453,7 @@ type ListFieldsByCityCodeParams struct {
 	Offset   int32  `json:"offset"`
 }
 
-// 市区町村コードで圃場一覧を取得
+// 市区町村コードで有効な圃場一覧を取得
 func (q *Queries) ListFieldsByCityCode(ctx context.Context, arg *ListFieldsByCityCodeParams) ([]*Field, error) {
 	rows, err := q.db.Query(ctx, listFieldsByCityCode, arg.CityCode, arg.Limit, arg.Offset)
 	if err != nil {
@@ -298,11 +467,394 @@ func (q *Queries) ListFieldsByCityCode(ctx context.Context, arg *ListFieldsByCit
 			&i.ID,
 			&i.Geometry,
 			&i.Centroid,
+			&i.CityCode,
+			&i.Name,
+			&i.SoilTypeID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.UpdatedBy,
+			&i.RetiredAt,
+			&i.AreaSqm,
+			&i.H3Index,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFieldsForCentroidBackfill = `-- name: ListFieldsForCentroidBackfill :many
+SELECT
+    id,
+    geometry,
+    centroid,
+    h3_index,
+    retired_at
+FROM fields
+WHERE $1::UUID IS NULL OR id > $1::UUID
+ORDER BY id
+LIMIT $2
+`
+
+type ListFieldsForCentroidBackfillParams struct {
+	AfterID  uuid.NullUUID `json:"after_id"`
+	RowLimit int32         `json:"row_limit"`
+}
+
+type ListFieldsForCentroidBackfillRow struct {
+	ID        uuid.UUID          `json:"id"`
+	Geometry  interface{}        `json:"geometry"`
+	Centroid  interface{}        `json:"centroid"`
+	H3Index   *string            `json:"h3_index"`
+	RetiredAt pgtype.Timestamptz `json:"retired_at"`
+}
+
+// 重心・H3インデックスの再計算対象の圃場をID順に取得(キーセットページング)
+// 廃止済みの圃場も含め、after_idより後の圃場をrow_limit件返す
+func (q *Queries) ListFieldsForCentroidBackfill(ctx context.Context, arg *ListFieldsForCentroidBackfillParams) ([]*ListFieldsForCentroidBackfillRow, error) {
+	rows, err := q.db.Query(ctx, listFieldsForCentroidBackfill, arg.AfterID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListFieldsForCentroidBackfillRow{}
+	for rows.Next() {
+		var i ListFieldsForCentroidBackfillRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Geometry,
+			&i.Centroid,
+			&i.H3Index,
+			&i.RetiredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFieldsForExport = `-- name: ListFieldsForExport :many
+SELECT
+    f.id,
+    ST_AsBinary(f.geometry)::BYTEA AS geometry_wkb,
+    f.area_sqm,
+    f.city_code,
+    f.name,
+    st.large_code AS soil_large_code,
+    st.middle_code AS soil_middle_code,
+    st.small_code AS soil_small_code,
+    st.small_name AS soil_small_name,
+    f.created_at,
+    f.updated_at
+FROM fields f
+LEFT JOIN soil_types st ON st.id = f.soil_type_id
+WHERE
+    f.retired_at IS NULL
+    AND ($1::UUID IS NULL OR f.id > $1::UUID)
+    AND ($2::VARCHAR IS NULL OR f.city_code = $2::VARCHAR)
+    AND ($3::VARCHAR IS NULL OR st.small_code = $3::VARCHAR)
+    AND ($4::FLOAT8 IS NULL OR ST_Intersects(
+        f.geometry,
+        ST_MakeEnvelope($4::FLOAT8, $5::FLOAT8, $6::FLOAT8, $7::FLOAT8, 4326)
+    ))
+ORDER BY f.id
+LIMIT $8
+`
+
+type ListFieldsForExportParams struct {
+	AfterID       uuid.NullUUID `json:"after_id"`
+	CityCode      *string       `json:"city_code"`
+	SoilSmallCode *string       `json:"soil_small_code"`
+	SwLng         *float64      `json:"sw_lng"`
+	SwLat         *float64      `json:"sw_lat"`
+	NeLng         *float64      `json:"ne_lng"`
+	NeLat         *float64      `json:"ne_lat"`
+	RowLimit      int32         `json:"row_limit"`
+}
+
+type ListFieldsForExportRow struct {
+	ID             uuid.UUID          `json:"id"`
+	GeometryWkb    []byte             `json:"geometry_wkb"`
+	AreaSqm        *float64           `json:"area_sqm"`
+	CityCode       string             `json:"city_code"`
+	Name           string             `json:"name"`
+	SoilLargeCode  *string            `json:"soil_large_code"`
+	SoilMiddleCode *string            `json:"soil_middle_code"`
+	SoilSmallCode  *string            `json:"soil_small_code"`
+	SoilSmallName  *string            `json:"soil_small_name"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+// エクスポート対象の有効な圃場をID順に取得(キーセットページング)
+// after_idより後の圃場をrow_limit件返す。各条件はNULLの場合に無視される
+func (q *Queries) ListFieldsForExport(ctx context.Context, arg *ListFieldsForExportParams) ([]*ListFieldsForExportRow, error) {
+	rows, err := q.db.Query(ctx, listFieldsForExport,
+		arg.AfterID,
+		arg.CityCode,
+		arg.SoilSmallCode,
+		arg.SwLng,
+		arg.SwLat,
+		arg.NeLng,
+		arg.NeLat,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListFieldsForExportRow{}
+	for rows.Next() {
+		var i ListFieldsForExportRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GeometryWkb,
+			&i.AreaSqm,
+			&i.CityCode,
+			&i.Name,
+			&i.SoilLargeCode,
+			&i.SoilMiddleCode,
+			&i.SoilSmallCode,
+			&i.SoilSmallName,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockFieldForUpdate = `-- name: LockFieldForUpdate :one
+SELECT id, retired_at
+FROM fields
+WHERE id = $1
+FOR UPDATE
+`
+
+type LockFieldForUpdateRow struct {
+	ID        uuid.UUID          `json:"id"`
+	RetiredAt pgtype.Timestamptz `json:"retired_at"`
+}
+
+// 分筆・合筆・削除の対象圃場を行ロックして廃止状態を取得
+// 同一圃場への同時操作を直列化するため、トランザクション内で使用する
+func (q *Queries) LockFieldForUpdate(ctx context.Context, id uuid.UUID) (*LockFieldForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, lockFieldForUpdate, id)
+	var i LockFieldForUpdateRow
+	err := row.Scan(&i.ID, &i.RetiredAt)
+	return &i, err
+}
+
+const lockFieldsForUpdate = `-- name: LockFieldsForUpdate :many
+SELECT id, retired_at
+FROM fields
+WHERE id = ANY($1::UUID[])
+ORDER BY id
+FOR UPDATE
+`
+
+type LockFieldsForUpdateRow struct {
+	ID        uuid.UUID          `json:"id"`
+	RetiredAt pgtype.Timestamptz `json:"retired_at"`
+}
+
+// 合筆の対象圃場をID順に行ロックして廃止状態を取得
+// ロック順序を固定して同時操作によるデッドロックを避ける。存在しないIDは結果に含まれない
+func (q *Queries) LockFieldsForUpdate(ctx context.Context, ids []uuid.UUID) ([]*LockFieldsForUpdateRow, error) {
+	rows, err := q.db.Query(ctx, lockFieldsForUpdate, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*LockFieldsForUpdateRow{}
+	for rows.Next() {
+		var i LockFieldsForUpdateRow
+		if err := rows.Scan(&i.ID, &i.RetiredAt); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retireDeletedField = `-- name: RetireDeletedField :exec
+UPDATE fields
+SET
+    retired_at = $1,
+    updated_at = NOW()
+WHERE id = $2
+`
+
+type RetireDeletedFieldParams struct {
+	RetiredAt pgtype.Timestamptz `json:"retired_at"`
+	ID        uuid.UUID          `json:"id"`
+}
+
+// 削除要求された系譜のある圃場を廃止する
+// 分筆・合筆と異なり農地台帳は移動しないため、圃場名はそのまま残す
+func (q *Queries) RetireDeletedField(ctx context.Context, arg *RetireDeletedFieldParams) error {
+	_, err := q.db.Exec(ctx, retireDeletedField, arg.RetiredAt, arg.ID)
+	return err
+}
+
+const retireField = `-- name: RetireField :exec
+UPDATE fields
+SET
+    retired_at = $1,
+    name = $2,
+    updated_by = $3,
+    updated_at = NOW()
+WHERE id = $4
+`
+
+type RetireFieldParams struct {
+	RetiredAt pgtype.Timestamptz `json:"retired_at"`
+	Name      string             `json:"name"`
+	UpdatedBy uuid.NullUUID      `json:"updated_by"`
+	ID        uuid.UUID          `json:"id"`
+}
+
+// 圃場を廃止する(分筆・合筆で役目を終えた圃場用)
+// 農地台帳の移動でトリガーにより書き換わった圃場名を廃止前の名称に戻す
+func (q *Queries) RetireField(ctx context.Context, arg *RetireFieldParams) error {
+	_, err := q.db.Exec(ctx, retireField,
+		arg.RetiredAt,
+		arg.Name,
+		arg.UpdatedBy,
+		arg.ID,
+	)
+	return err
+}
+
+const searchFields = `-- name: SearchFields :many
+SELECT
+    f.id,
+    f.area_sqm,
+    f.h3_index,
+    f.city_code,
+    f.name,
+    f.soil_type_id,
+    f.created_at,
+    f.updated_at,
+    f.created_by,
+    f.updated_by
+FROM fields f
+WHERE
+    f.retired_at IS NULL
+    AND ($1::VARCHAR IS NULL OR f.city_code = $1::VARCHAR)
+    AND ($2::VARCHAR IS NULL OR EXISTS (
+        SELECT 1 FROM soil_types st
+        WHERE st.id = f.soil_type_id AND st.small_code = $2::VARCHAR
+    ))
+    AND ($3::VARCHAR IS NULL OR EXISTS (
+        SELECT 1 FROM field_land_registries r
+        WHERE r.field_id = f.id AND r.land_category_code = $3::VARCHAR
+    ))
+    AND ($4::VARCHAR IS NULL OR EXISTS (
+        SELECT 1 FROM field_land_registries r
+        WHERE r.field_id = f.id AND r.idle_land_status_code = $4::VARCHAR
+    ))
+    AND ($5::FLOAT8 IS NULL OR f.area_sqm >= $5::FLOAT8)
+    AND ($6::FLOAT8 IS NULL OR f.area_sqm <= $6::FLOAT8)
+    AND ($7::FLOAT8 IS NULL OR ST_Intersects(
+        f.geometry,
+        ST_MakeEnvelope(
+            $7::FLOAT8, $8::FLOAT8,
+            CASE WHEN $7::FLOAT8 <= $9::FLOAT8 THEN $9::FLOAT8 ELSE 180 END, $10::FLOAT8,
+            4326
+        )
+    ) OR (
+        $7::FLOAT8 > $9::FLOAT8
+        AND ST_Intersects(f.geometry, ST_MakeEnvelope(-180, $8::FLOAT8, $9::FLOAT8, $10::FLOAT8, 4326))
+    ))
+    AND ($11::TEXT IS NULL OR f.name ILIKE $12::TEXT ESCAPE '\' OR f.name % $11::TEXT)
+ORDER BY
+    CASE WHEN $11::TEXT IS NULL THEN 0 ELSE similarity(f.name, $11::TEXT) END DESC,
+    f.created_at DESC,
+    f.id
+LIMIT $13
+OFFSET $14
+`
+
+type SearchFieldsParams struct {
+	CityCode           *string  `json:"city_code"`
+	SoilSmallCode      *string  `json:"soil_small_code"`
+	LandCategoryCode   *string  `json:"land_category_code"`
+	IdleLandStatusCode *string  `json:"idle_land_status_code"`
+	MinAreaSqm         *float64 `json:"min_area_sqm"`
+	MaxAreaSqm         *float64 `json:"max_area_sqm"`
+	SwLng              *float64 `json:"sw_lng"`
+	SwLat              *float64 `json:"sw_lat"`
+	NeLng              *float64 `json:"ne_lng"`
+	NeLat              *float64 `json:"ne_lat"`
+	Name               *string  `json:"name"`
+	NamePattern        *string  `json:"name_pattern"`
+	RowLimit           int32    `json:"row_limit"`
+	RowOffset          int32    `json:"row_offset"`
+}
+
+type SearchFieldsRow struct {
+	ID         uuid.UUID          `json:"id"`
+	AreaSqm    *float64           `json:"area_sqm"`
+	H3Index    *string            `json:"h3_index"`
+	CityCode   string             `json:"city_code"`
+	Name       string             `json:"name"`
+	SoilTypeID uuid.NullUUID      `json:"soil_type_id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	CreatedBy  uuid.NullUUID      `json:"created_by"`
+	UpdatedBy  uuid.NullUUID      `json:"updated_by"`
+}
+
+// 検索条件を指定して有効な圃場一覧を取得
+// 廃止済みの圃場は除外する。各条件はNULLの場合に無視される。nameを指定した場合は類似度の高い順に並べる
+// name_patternはnameのワイルドカードをエスケープして前後に%を付けたILIKEのパターン
+// sw_lng > ne_lngの場合は日付変更線をまたぐ範囲として東西2つに分割して判定する
+func (q *Queries) SearchFields(ctx context.Context, arg *SearchFieldsParams) ([]*SearchFieldsRow, error) {
+	rows, err := q.db.Query(ctx, searchFields,
+		arg.CityCode,
+		arg.SoilSmallCode,
+		arg.LandCategoryCode,
+		arg.IdleLandStatusCode,
+		arg.MinAreaSqm,
+		arg.MaxAreaSqm,
+		arg.SwLng,
+		arg.SwLat,
+		arg.NeLng,
+		arg.NeLat,
+		arg.Name,
+		arg.NamePattern,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*SearchFieldsRow{}
+	for rows.Next() {
+		var i SearchFieldsRow
+		if err := rows.Scan(
+			&i.ID,
 			&i.AreaSqm,
-			&i.H3IndexRes3,
-			&i.H3IndexRes5,
-			&i.H3IndexRes7,
-			&i.H3IndexRes9,
+			&i.H3Index,
 			&i.CityCode,
 			&i.Name,
 			&i.SoilTypeID,
@@ -321,63 +873,94 @@ func (q *Queries) ListFieldsByCityCode(ctx context.Context, arg *ListFieldsByCit
 	return items, nil
 }
 
+const unionFieldGeometries = `-- name: UnionFieldGeometries :one
+WITH RECURSIVE sources AS (
+    SELECT id, geometry
+    FROM fields
+    WHERE id = ANY($1::UUID[])
+),
+adjacent AS (
+    SELECT a.id AS from_id, b.id AS to_id
+    FROM sources a
+    JOIN sources b
+        ON a.id <> b.id
+        AND ST_Intersects(a.geometry, b.geometry)
+        AND ST_Dimension(ST_Intersection(a.geometry, b.geometry)) >= 1
+),
+reachable AS (
+    SELECT id FROM (SELECT id FROM sources ORDER BY id LIMIT 1) first_source
+    UNION
+    SELECT adj.to_id
+    FROM reachable r
+    JOIN adjacent adj ON adj.from_id = r.id
+)
+SELECT
+    ST_AsBinary(ST_Multi(ST_Union(s.geometry)))::BYTEA AS geometry_wkb,
+    ((SELECT COUNT(*) FROM reachable) = COUNT(*))::BOOLEAN AS connected
+FROM sources s
+`
+
+type UnionFieldGeometriesRow struct {
+	GeometryWkb []byte `json:"geometry_wkb"`
+	Connected   bool   `json:"connected"`
+}
+
+// 合筆対象の圃場ジオメトリをST_Unionで結合し、マルチポリゴンのWKB形式で取得
+// 複数区画の圃場を含む場合は結合結果も複数区画になるため、区画数ではなく圃場同士のつながりで隣接を判定する
+// connectedは辺を共有するか重なる圃場同士をたどって全ての圃場がつながっている場合にtrue
+func (q *Queries) UnionFieldGeometries(ctx context.Context, ids []uuid.UUID) (*UnionFieldGeometriesRow, error) {
+	row := q.db.QueryRow(ctx, unionFieldGeometries, ids)
+	var i UnionFieldGeometriesRow
+	err := row.Scan(&i.GeometryWkb, &i.Connected)
+	return &i, err
+}
+
 const updateField = `-- name: UpdateField :one
 UPDATE fields
 SET
-    geometry = COALESCE($2, geometry),
-    centroid = COALESCE($3, centroid),
-    h3_index_res3 = COALESCE($4, h3_index_res3),
-    h3_index_res5 = COALESCE($5, h3_index_res5),
-    h3_index_res7 = COALESCE($6, h3_index_res7),
-    h3_index_res9 = COALESCE($7, h3_index_res9),
-    city_code = COALESCE($8, city_code),
-    name = COALESCE($9, name),
-    soil_type_id = $10,
-    updated_by = $11,
+    geometry = ST_Multi(ST_GeomFromWKB($1::bytea, 4326)),
+    centroid = ST_GeomFromWKB($2::bytea, 4326),
+    h3_index = $3,
+    city_code = $4,
+    name = $5,
+    soil_type_id = $6,
+    updated_by = $7,
     updated_at = NOW()
-WHERE id = $1
-RETURNING id, geometry, centroid, area_sqm, h3_index_res3, h3_index_res5, h3_index_res7, h3_index_res9, city_code, name, soil_type_id, created_at, updated_at, created_by, updated_by
+WHERE id = $8 AND retired_at IS NULL
+RETURNING id, geometry, centroid, city_code, name, soil_type_id, created_at, updated_at, created_by, updated_by, retired_at, area_sqm, h3_index
 `
 
 type UpdateFieldParams struct {
-	ID          uuid.UUID     `json:"id"`
-	Geometry    interface{}   `json:"geometry"`
-	Centroid    interface{}   `json:"centroid"`
-	H3IndexRes3 *string       `json:"h3_index_res3"`
-	H3IndexRes5 *string       `json:"h3_index_res5"`
-	H3IndexRes7 *string       `json:"h3_index_res7"`
-	H3IndexRes9 *string       `json:"h3_index_res9"`
+	GeometryWkb []byte        `json:"geometry_wkb"`
+	CentroidWkb []byte        `json:"centroid_wkb"`
+	H3Index     *string       `json:"h3_index"`
 	CityCode    string        `json:"city_code"`
 	Name        string        `json:"name"`
 	SoilTypeID  uuid.NullUUID `json:"soil_type_id"`
 	UpdatedBy   uuid.NullUUID `json:"updated_by"`
+	ID          uuid.UUID     `json:"id"`
 }
 
 // 圃場を更新
+// geometry, centroidはWKB形式のbytea型で受け取り、ST_GeomFromWKBで変換
+// geometryはPolygonで渡されてもST_Multiでマルチポリゴンに揃える
+// 読み取り後に分筆・合筆で廃止された圃場を上書きしないよう、廃止済みの圃場は更新せず行を返さない
 func (q *Queries) UpdateField(ctx context.Context, arg *UpdateFieldParams) (*Field, error) {
 	row := q.db.QueryRow(ctx, updateField,
-		arg.ID,
-		arg.Geometry,
-		arg.Centroid,
-		arg.H3IndexRes3,
-		arg.H3IndexRes5,
-		arg.H3IndexRes7,
-		arg.H3IndexRes9,
+		arg.GeometryWkb,
+		arg.CentroidWkb,
+		arg.H3Index,
 		arg.CityCode,
 		arg.Name,
 		arg.SoilTypeID,
 		arg.UpdatedBy,
+		arg.ID,
 	)
 	var i Field
 	err := row.Scan(
 		&i.ID,
 		&i.Geometry,
 		&i.Centroid,
-		&i.AreaSqm,
-		&i.H3IndexRes3,
-		&i.H3IndexRes5,
-		&i.H3IndexRes7,
-		&i.H3IndexRes9,
 		&i.CityCode,
 		&i.Name,
 		&i.SoilTypeID,
@@ -385,63 +968,78 @@ func (q *Queries) UpdateField(ctx context.Context, arg *UpdateFieldParams) (*Fie
 		&i.UpdatedAt,
 		&i.CreatedBy,
 		&i.UpdatedBy,
+		&i.RetiredAt,
+		&i.AreaSqm,
+		&i.H3Index,
 	)
 	return &i, err
 }
 
+const updateFieldCentroid = `-- name: UpdateFieldCentroid :exec
+UPDATE fields
+SET
+    centroid = ST_GeomFromWKB($1::bytea, 4326),
+    h3_index = $2
+WHERE id = $3
+`
+
+type UpdateFieldCentroidParams struct {
+	CentroidWkb []byte    `json:"centroid_wkb"`
+	H3Index     *string   `json:"h3_index"`
+	ID          uuid.UUID `json:"id"`
+}
+
+// 圃場の重心とH3インデックスのみを更新(重心の算出方法変更に伴うバックフィル用)
+// centroidはWKB形式のbytea型で受け取り、ST_GeomFromWKBで変換
+func (q *Queries) UpdateFieldCentroid(ctx context.Context, arg *UpdateFieldCentroidParams) error {
+	_, err := q.db.Exec(ctx, updateFieldCentroid, arg.CentroidWkb, arg.H3Index, arg.ID)
+	return err
+}
+
 const upsertField = `-- name: UpsertField :one
 INSERT INTO fields (
     id,
     geometry,
     centroid,
-    h3_index_res3,
-    h3_index_res5,
-    h3_index_res7,
-    h3_index_res9,
+    h3_index,
     city_code,
     soil_type_id
 ) VALUES (
     $1,
-    ST_GeomFromWKB($2::bytea, 4326),
+    ST_Multi(ST_GeomFromWKB($2::bytea, 4326)),
     ST_GeomFromWKB($3::bytea, 4326),
-    $4, $5, $6, $7, $8, $9
+    $4, $5, $6
 )
 ON CONFLICT (id) DO UPDATE SET
     geometry = EXCLUDED.geometry,
     centroid = EXCLUDED.centroid,
-    h3_index_res3 = EXCLUDED.h3_index_res3,
-    h3_index_res5 = EXCLUDED.h3_index_res5,
-    h3_index_res7 = EXCLUDED.h3_index_res7,
-    h3_index_res9 = EXCLUDED.h3_index_res9,
+    h3_index = EXCLUDED.h3_index,
     city_code = EXCLUDED.city_code,
     soil_type_id = EXCLUDED.soil_type_id,
     updated_at = NOW()
-RETURNING id, geometry, centroid, area_sqm, h3_index_res3, h3_index_res5, h3_index_res7, h3_index_res9, city_code, name, soil_type_id, created_at, updated_at, created_by, updated_by
+WHERE fields.retired_at IS NULL
+RETURNING id, geometry, centroid, city_code, name, soil_type_id, created_at, updated_at, created_by, updated_by, retired_at, area_sqm, h3_index
 `
 
 type UpsertFieldParams struct {
 	ID          uuid.UUID     `json:"id"`
 	GeometryWkb []byte        `json:"geometry_wkb"`
 	CentroidWkb []byte        `json:"centroid_wkb"`
-	H3IndexRes3 *string       `json:"h3_index_res3"`
-	H3IndexRes5 *string       `json:"h3_index_res5"`
-	H3IndexRes7 *string       `json:"h3_index_res7"`
-	H3IndexRes9 *string       `json:"h3_index_res9"`
+	H3Index     *string       `json:"h3_index"`
 	CityCode    string        `json:"city_code"`
 	SoilTypeID  uuid.NullUUID `json:"soil_type_id"`
 }
 
 // 圃場をUPSERT(wagriインポート用)
 // geometry, centroidはWKB形式のbytea型で受け取り、ST_GeomFromWKBで変換
+// geometryはPolygonで渡されてもST_Multiでマルチポリゴンに揃える
+// 分筆・合筆で廃止済みの圃場は更新せず、行を返さない
 func (q *Queries) UpsertField(ctx context.Context, arg *UpsertFieldParams) (*Field, error) {
 	row := q.db.QueryRow(ctx, upsertField,
 		arg.ID,
 		arg.GeometryWkb,
 		arg.CentroidWkb,
-		arg.H3IndexRes3,
-		arg.H3IndexRes5,
-		arg.H3IndexRes7,
-		arg.H3IndexRes9,
+		arg.H3Index,
 		arg.CityCode,
 		arg.SoilTypeID,
 	)
@@ -450,11 +1048,6 @@ func (q *Queries) UpsertField(ctx context.Context, arg *UpsertFieldParams) (*Fie
 		&i.ID,
 		&i.Geometry,
 		&i.Centroid,
-		&i.AreaSqm,
-		&i.H3IndexRes3,
-		&i.H3IndexRes5,
-		&i.H3IndexRes7,
-		&i.H3IndexRes9,
 		&i.CityCode,
 		&i.Name,
 		&i.SoilTypeID,
@@ -462,6 +1055,9 @@ func (q *Queries) UpsertField(ctx context.Context, arg *UpsertFieldParams) (*Fie
 		&i.UpdatedAt,
 		&i.CreatedBy,
 		&i.UpdatedBy,
+		&i.RetiredAt,
+		&i.AreaSqm,
+		&i.H3Index,
 	)
 	return &i, err
 }
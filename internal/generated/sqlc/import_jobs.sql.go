@@ -12,6 +12,43 @@ import (
 	"github.com/google/uuid"
 )
 
+const appendImportJobRejectedRecords = `-- name: AppendImportJobRejectedRecords :one
+UPDATE import_jobs
+SET
+    rejected_records = COALESCE(rejected_records, '[]'::jsonb) || $1::jsonb
+WHERE id = $2
+RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at, rejected_records
+`
+
+type AppendImportJobRejectedRecordsParams struct {
+	RejectedRecords json.RawMessage `json:"rejected_records"`
+	ID              uuid.UUID       `json:"id"`
+}
+
+// インポートジョブにジオメトリ検証で拒否したレコードを追記
+func (q *Queries) AppendImportJobRejectedRecords(ctx context.Context, arg *AppendImportJobRejectedRecordsParams) (*ImportJob, error) {
+	row := q.db.QueryRow(ctx, appendImportJobRejectedRecords, arg.RejectedRecords, arg.ID)
+	var i ImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.CityCode,
+		&i.Status,
+		&i.TotalRecords,
+		&i.ProcessedRecords,
+		&i.FailedRecords,
+		&i.LastProcessedBatch,
+		&i.S3Key,
+		&i.ExecutionArn,
+		&i.ErrorMessage,
+		&i.FailedRecordIds,
+		&i.CreatedAt,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.RejectedRecords,
+	)
+	return &i, err
+}
+
 const countImportJobs = `-- name: CountImportJobs :one
 SELECT COUNT(*) FROM import_jobs
 `
@@ -42,7 +79,7 @@ INSERT INTO import_jobs (
     status
 ) VALUES (
     $1, 'pending'
-) RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at
+) RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at, rejected_records
 `
 
 // インポートジョブを作成
@@ -64,6 +101,7 @@ func (q *Queries) CreateImportJob(ctx context.Context, cityCode string) (*Import
 		&i.CreatedAt,
 		&i.StartedAt,
 		&i.CompletedAt,
+		&i.RejectedRecords,
 	)
 	return &i, err
 }
@@ -83,7 +121,8 @@ SELECT
     failed_record_ids,
     created_at,
     started_at,
-    completed_at
+    completed_at,
+    rejected_records
 FROM import_jobs
 WHERE id = $1
 `
@@ -107,6 +146,7 @@ func (q *Queries) GetImportJob(ctx context.Context, id uuid.UUID) (*ImportJob, e
 		&i.CreatedAt,
 		&i.StartedAt,
 		&i.CompletedAt,
+		&i.RejectedRecords,
 	)
 	return &i, err
 }
@@ -126,7 +166,8 @@ SELECT
     failed_record_ids,
     created_at,
     started_at,
-    completed_at
+    completed_at,
+    rejected_records
 FROM import_jobs
 ORDER BY created_at DESC
 LIMIT $1
@@ -163,6 +204,7 @@ func (q *Queries) ListImportJobs(ctx context.Context, arg *ListImportJobsParams)
 			&i.CreatedAt,
 			&i.StartedAt,
 			&i.CompletedAt,
+			&i.RejectedRecords,
 		); err != nil {
 			return nil, err
 		}
@@ -189,7 +231,8 @@ SELECT
     failed_record_ids,
     created_at,
     started_at,
-    completed_at
+    completed_at,
+    rejected_records
 FROM import_jobs
 WHERE city_code = $1
 ORDER BY created_at DESC
@@ -228,6 +271,7 @@ func (q *Queries) ListImportJobsByCityCode(ctx context.Context, arg *ListImportJ
 			&i.CreatedAt,
 			&i.StartedAt,
 			&i.CompletedAt,
+			&i.RejectedRecords,
 		); err != nil {
 			return nil, err
 		}
@@ -247,7 +291,7 @@ SET
     failed_record_ids = $3,
     completed_at = NOW()
 WHERE id = $1
-RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at
+RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at, rejected_records
 `
 
 type UpdateImportJobErrorParams struct {
@@ -275,6 +319,7 @@ func (q *Queries) UpdateImportJobError(ctx context.Context, arg *UpdateImportJob
 		&i.CreatedAt,
 		&i.StartedAt,
 		&i.CompletedAt,
+		&i.RejectedRecords,
 	)
 	return &i, err
 }
@@ -284,7 +329,7 @@ UPDATE import_jobs
 SET
     execution_arn = $2
 WHERE id = $1
-RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at
+RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at, rejected_records
 `
 
 type UpdateImportJobExecutionArnParams struct {
@@ -311,6 +356,7 @@ func (q *Queries) UpdateImportJobExecutionArn(ctx context.Context, arg *UpdateIm
 		&i.CreatedAt,
 		&i.StartedAt,
 		&i.CompletedAt,
+		&i.RejectedRecords,
 	)
 	return &i, err
 }
@@ -322,7 +368,7 @@ SET
     failed_records = $3,
     last_processed_batch = $4
 WHERE id = $1
-RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at
+RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at, rejected_records
 `
 
 type UpdateImportJobProgressParams struct {
@@ -356,6 +402,7 @@ func (q *Queries) UpdateImportJobProgress(ctx context.Context, arg *UpdateImport
 		&i.CreatedAt,
 		&i.StartedAt,
 		&i.CompletedAt,
+		&i.RejectedRecords,
 	)
 	return &i, err
 }
@@ -365,7 +412,7 @@ UPDATE import_jobs
 SET
     s3_key = $2
 WHERE id = $1
-RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at
+RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at, rejected_records
 `
 
 type UpdateImportJobS3KeyParams struct {
@@ -392,6 +439,7 @@ func (q *Queries) UpdateImportJobS3Key(ctx context.Context, arg *UpdateImportJob
 		&i.CreatedAt,
 		&i.StartedAt,
 		&i.CompletedAt,
+		&i.RejectedRecords,
 	)
 	return &i, err
 }
@@ -403,7 +451,7 @@ SET
     started_at = CASE WHEN $2::VARCHAR = 'processing' AND started_at IS NULL THEN NOW() ELSE started_at END,
     completed_at = CASE WHEN $2::VARCHAR IN ('completed', 'failed', 'partially_completed') THEN NOW() ELSE completed_at END
 WHERE id = $1
-RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at
+RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at, rejected_records
 `
 
 type UpdateImportJobStatusParams struct {
@@ -430,6 +478,7 @@ func (q *Queries) UpdateImportJobStatus(ctx context.Context, arg *UpdateImportJo
 		&i.CreatedAt,
 		&i.StartedAt,
 		&i.CompletedAt,
+		&i.RejectedRecords,
 	)
 	return &i, err
 }
@@ -439,7 +488,7 @@ UPDATE import_jobs
 SET
     total_records = $2
 WHERE id = $1
-RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at
+RETURNING id, city_code, status, total_records, processed_records, failed_records, last_processed_batch, s3_key, execution_arn, error_message, failed_record_ids, created_at, started_at, completed_at, rejected_records
 `
 
 type UpdateImportJobTotalRecordsParams struct {
@@ -466,6 +515,7 @@ func (q *Queries) UpdateImportJobTotalRecords(ctx context.Context, arg *UpdateIm
 		&i.CreatedAt,
 		&i.StartedAt,
 		&i.CompletedAt,
+		&i.RejectedRecords,
 	)
 	return &i, err
 }
@@ -11,6 +11,48 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// 市区町村ごとの圃場統計
+type CityFieldStat struct {
+	// 市区町村コード
+	CityCode string `json:"city_code"`
+	// 圃場数
+	FieldCount int32 `json:"field_count"`
+	// 合計面積(平方メートル)
+	TotalAreaSqm float64 `json:"total_area_sqm"`
+	// 主な土地種別が田の圃場の合計面積(平方メートル)
+	PaddyAreaSqm float64 `json:"paddy_area_sqm"`
+	// 主な土地種別が畑の圃場の合計面積(平方メートル)
+	UplandAreaSqm float64 `json:"upland_area_sqm"`
+	// 遊休農地状況が登録された農地台帳を持つ圃場数
+	IdleFieldCount int32 `json:"idle_field_count"`
+	// 遊休農地状況が登録された農地台帳を持つ圃場の合計面積(平方メートル)
+	IdleAreaSqm float64 `json:"idle_area_sqm"`
+	// 集計日時
+	RefreshedAt pgtype.Timestamptz `json:"refreshed_at"`
+}
+
+// 市区町村・土壌大分類ごとの圃場統計
+type CitySoilStat struct {
+	// 市区町村コード
+	CityCode string `json:"city_code"`
+	// 土壌大分類コード
+	SoilLargeCode string `json:"soil_large_code"`
+	// 圃場数
+	FieldCount int32 `json:"field_count"`
+	// 合計面積(平方メートル)
+	TotalAreaSqm float64 `json:"total_area_sqm"`
+}
+
+// クラスター結果の世代
+type ClusterGeneration struct {
+	// 世代番号(作成順に増える)
+	Generation int64 `json:"generation"`
+	// 作成日時(全範囲再計算の開始日時)
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	// 有効化日時(NULLの場合は計算中または破棄待ち)
+	ActivatedAt pgtype.Timestamptz `json:"activated_at"`
+}
+
 // クラスタリングジョブ管理
 type ClusterJob struct {
 	ID uuid.UUID `json:"id"`
@@ -28,12 +70,18 @@ type ClusterJob struct {
 	ErrorMessage *string `json:"error_message"`
 	// 影響を受けたH3セルのリスト(NULLの場合は全範囲再計算)
 	AffectedH3Cells []string `json:"affected_h3_cells"`
+	// 処理中のワーカーID
+	WorkerID *string `json:"worker_id"`
+	// 処理中のワーカーの最終ハートビート日時
+	HeartbeatAt pgtype.Timestamptz `json:"heartbeat_at"`
+	// 実行回数(リース期限切れで再実行されるたびに増える)
+	Attempts int32 `json:"attempts"`
 }
 
 // H3クラスタリング結果(全圃場対象)
 type ClusterResult struct {
 	ID uuid.UUID `json:"id"`
-	// H3解像度(3, 5, 7, 9)
+	// H3解像度(0-15、CLUSTER_RESOLUTIONSで設定)
 	Resolution int32 `json:"resolution"`
 	// H3インデックス(16進数文字列)
 	H3Index string `json:"h3_index"`
@@ -45,26 +93,66 @@ type ClusterResult struct {
 	CenterLng float64 `json:"center_lng"`
 	// 計算日時
 	CalculatedAt pgtype.Timestamptz `json:"calculated_at"`
+	// クラスターに含まれる圃場の合計面積(平方メートル)
+	TotalAreaSqm float64 `json:"total_area_sqm"`
+	// 土地種別コードごとの圃場数({"土地種別コード": 圃場数})
+	LandCategoryCounts []byte `json:"land_category_counts"`
+	// 遊休農地状況が登録された農地台帳を持つ圃場数
+	IdleFieldCount int32 `json:"idle_field_count"`
+	// 合計面積が最大の土壌大分類コード
+	DominantSoilLargeCode *string `json:"dominant_soil_large_code"`
+	// 世代番号
+	Generation int64 `json:"generation"`
+}
+
+// 圃場エクスポートジョブ管理テーブル
+type ExportJob struct {
+	// 主キー
+	ID uuid.UUID `json:"id"`
+	// 出力形式(geojson/csv/kml/gpkg)
+	Format string `json:"format"`
+	// ステータス(pending/processing/completed/failed)
+	Status string `json:"status"`
+	// 絞り込み条件: 市区町村コード
+	CityCode *string `json:"city_code"`
+	// 絞り込み条件: 土壌小分類コード
+	SoilSmallCode *string `json:"soil_small_code"`
+	// 絞り込み条件: 南西端の緯度
+	SwLat *float64 `json:"sw_lat"`
+	// 絞り込み条件: 南西端の経度
+	SwLng *float64 `json:"sw_lng"`
+	// 絞り込み条件: 北東端の緯度
+	NeLat *float64 `json:"ne_lat"`
+	// 絞り込み条件: 北東端の経度
+	NeLng *float64 `json:"ne_lng"`
+	// 出力したレコード数
+	TotalRecords *int32 `json:"total_records"`
+	// 出力ファイルのS3キー
+	S3Key *string `json:"s3_key"`
+	// エラーメッセージ
+	ErrorMessage *string `json:"error_message"`
+	// 作成日時
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	// 処理開始日時
+	StartedAt pgtype.Timestamptz `json:"started_at"`
+	// 処理完了日時
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+	// 処理中のワーカーID
+	WorkerID *string `json:"worker_id"`
+	// 処理中のワーカーの最終ハートビート日時
+	HeartbeatAt pgtype.Timestamptz `json:"heartbeat_at"`
+	// 実行回数(リース期限切れで再実行されるたびに増える)
+	Attempts int32 `json:"attempts"`
 }
 
 // 圃場マスタテーブル
 type Field struct {
 	// 主キー
 	ID uuid.UUID `json:"id"`
-	// ポリゴン形状(SRID: 4326 = WGS84)
+	// マルチポリゴン形状(SRID: 4326 = WGS84、穴あき・複数区画を含む)
 	Geometry interface{} `json:"geometry"`
 	// 重心座標(SRID: 4326 = WGS84)
 	Centroid interface{} `json:"centroid"`
-	// 面積(平方メートル、自動計算)
-	AreaSqm *float64 `json:"area_sqm"`
-	// H3インデックス解像度3(約100km - 地方クラスタリング)
-	H3IndexRes3 *string `json:"h3_index_res3"`
-	// H3インデックス解像度5(約10km - 都道府県クラスタリング)
-	H3IndexRes5 *string `json:"h3_index_res5"`
-	// H3インデックス解像度7(約1km - 市区町村クラスタリング)
-	H3IndexRes7 *string `json:"h3_index_res7"`
-	// H3インデックス解像度9(約100m - 詳細クラスタリング)
-	H3IndexRes9 *string `json:"h3_index_res9"`
 	// 市区町村コード
 	CityCode string `json:"city_code"`
 	// 圃場名(field_land_registries.addressから自動生成)
@@ -79,6 +167,12 @@ type Field struct {
 	CreatedBy uuid.NullUUID `json:"created_by"`
 	// 更新者ID
 	UpdatedBy uuid.NullUUID `json:"updated_by"`
+	// 廃止日時(分筆・合筆により廃止された場合に設定、NULLの場合は有効)
+	RetiredAt pgtype.Timestamptz `json:"retired_at"`
+	// 面積(平方メートル、自動計算)
+	AreaSqm *float64 `json:"area_sqm"`
+	// H3インデックス解像度15(各解像度のセルはh3_cell_to_parentで求める)
+	H3Index *string `json:"h3_index"`
 }
 
 // 分筆履歴(親子関係のみ)
@@ -99,6 +193,22 @@ type FieldDivision struct {
 	CreatedBy uuid.NullUUID `json:"created_by"`
 }
 
+// 圃場が覆うH3セル(被覆モードのクラスター集計用)
+type FieldH3Coverage struct {
+	// 圃場ID
+	FieldID uuid.UUID `json:"field_id"`
+	// H3解像度(0-15、CLUSTER_RESOLUTIONSで設定)
+	Resolution int32 `json:"resolution"`
+	// H3インデックス(16進数文字列)
+	H3Index string `json:"h3_index"`
+	// 圃場の面積のうちこのセルに含まれる割合(解像度ごとの合計は1)
+	AreaShare float64 `json:"area_share"`
+	// 計算に使用した圃場の更新日時
+	SourceUpdatedAt pgtype.Timestamptz `json:"source_updated_at"`
+	// 計算日時
+	CalculatedAt pgtype.Timestamptz `json:"calculated_at"`
+}
+
 // 農地台帳
 type FieldLandRegistry struct {
 	// 主キー
@@ -141,6 +251,36 @@ type FieldMerger struct {
 	CreatedBy uuid.NullUUID `json:"created_by"`
 }
 
+// オーバーラップ検知記録
+type FieldOverlap struct {
+	// 主キー
+	ID uuid.UUID `json:"id"`
+	// 重なっている圃場のうちIDが小さい方
+	FieldIDA uuid.UUID `json:"field_id_a"`
+	// 重なっている圃場のうちIDが大きい方
+	FieldIDB uuid.UUID `json:"field_id_b"`
+	// 重なり部分の面積(平方メートル)
+	OverlapAreaSqm float64 `json:"overlap_area_sqm"`
+	// 面積が小さい方の圃場に対する重なり面積の割合(0-1)
+	OverlapRatio float64 `json:"overlap_ratio"`
+	// 対応状況(open: 未対応/accepted: 許容/clipped: クリップで解消)
+	Status string `json:"status"`
+	// クリップで形状を修正した圃場
+	ClippedFieldID uuid.NullUUID `json:"clipped_field_id"`
+	// 対応時の備考
+	Note *string `json:"note"`
+	// 最終検知日時
+	DetectedAt pgtype.Timestamptz `json:"detected_at"`
+	// 対応日時
+	ResolvedAt pgtype.Timestamptz `json:"resolved_at"`
+	// 対応者ID
+	ResolvedBy uuid.NullUUID `json:"resolved_by"`
+	// 作成日時
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	// 更新日時
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
 // 遊休農地状況マスタ
 type IdleLandStatus struct {
 	// 遊休農地状況コード
@@ -181,6 +321,8 @@ type ImportJob struct {
 	StartedAt pgtype.Timestamptz `json:"started_at"`
 	// 処理完了日時
 	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+	// ジオメトリ検証で拒否したレコードのJSON配列([{id, defect, reason}])
+	RejectedRecords json.RawMessage `json:"rejected_records"`
 }
 
 // 土地種別マスタ
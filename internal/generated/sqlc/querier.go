@@ -11,64 +11,139 @@ import (
 )
 
 type Querier interface {
-	// H3解像度3でfieldsを集計
-	AggregateClustersByRes3(ctx context.Context) ([]*AggregateClustersByRes3Row, error)
-	// 指定H3セル(res3)のみfieldsを集計(差分更新用)
-	AggregateClustersByRes3ForCells(ctx context.Context, h3Cells []string) ([]*AggregateClustersByRes3ForCellsRow, error)
-	// H3解像度5でfieldsを集計
-	AggregateClustersByRes5(ctx context.Context) ([]*AggregateClustersByRes5Row, error)
-	// 指定H3セル(res5)のみfieldsを集計(差分更新用)
-	AggregateClustersByRes5ForCells(ctx context.Context, h3Cells []string) ([]*AggregateClustersByRes5ForCellsRow, error)
-	// H3解像度7でfieldsを集計
-	AggregateClustersByRes7(ctx context.Context) ([]*AggregateClustersByRes7Row, error)
-	// 指定H3セル(res7)のみfieldsを集計(差分更新用)
-	AggregateClustersByRes7ForCells(ctx context.Context, h3Cells []string) ([]*AggregateClustersByRes7ForCellsRow, error)
-	// H3解像度9でfieldsを集計
-	AggregateClustersByRes9(ctx context.Context) ([]*AggregateClustersByRes9Row, error)
-	// 指定H3セル(res9)のみfieldsを集計(差分更新用)
-	AggregateClustersByRes9ForCells(ctx context.Context, h3Cells []string) ([]*AggregateClustersByRes9ForCellsRow, error)
+	// 計算が完了した世代を有効化
+	// より新しい世代が既に有効な場合は有効化しない(0件)
+	ActivateClusterGeneration(ctx context.Context, generation int64) (int64, error)
+	// 指定解像度で有効なfieldsを重心のH3セルごとに属性別に集計
+	// 土地種別コードごとの圃場数、遊休農地の圃場数、合計面積が最大の土壌大分類コードを返す
+	// lower_boundsがNULLの場合は全範囲、指定した場合は解像度15の子孫セルの範囲に重心がある圃場のみ集計する(差分更新用)
+	AggregateClusterAttributes(ctx context.Context, arg *AggregateClusterAttributesParams) ([]*AggregateClusterAttributesRow, error)
+	// 指定解像度で有効な圃場を被覆するH3セルごとに属性別に集計
+	// area_shareがtrueの場合は圃場数をセルに含まれる面積の割合で按分し、falseの場合は覆っている圃場を1件として数える
+	// 土壌の面積はセルに含まれる部分のみ合計する。h3_cellsがNULLの場合は全範囲を集計する
+	AggregateClusterAttributesByCoverage(ctx context.Context, arg *AggregateClusterAttributesByCoverageParams) ([]*AggregateClusterAttributesByCoverageRow, error)
+	// 指定解像度で有効な圃場をセルに含まれる面積の割合で按分して集計
+	// 圃場数は割合の合計を四捨五入した値とし、0件になるセルは返さない
+	AggregateClustersByAreaShare(ctx context.Context, resolution int32) ([]*AggregateClustersByAreaShareRow, error)
+	// 指定H3セルのみ面積の割合で按分して集計(差分更新用)
+	AggregateClustersByAreaShareForCells(ctx context.Context, arg *AggregateClustersByAreaShareForCellsParams) ([]*AggregateClustersByAreaShareForCellsRow, error)
+	// 指定解像度で有効な圃場を被覆するH3セルごとに集計
+	// 圃場は覆っている全てのセルで1件として数え、面積はセルに含まれる部分のみ合計する
+	AggregateClustersByCoverage(ctx context.Context, resolution int32) ([]*AggregateClustersByCoverageRow, error)
+	// 指定H3セルのみ被覆で集計(差分更新用)
+	AggregateClustersByCoverageForCells(ctx context.Context, arg *AggregateClustersByCoverageForCellsParams) ([]*AggregateClustersByCoverageForCellsRow, error)
+	// 指定解像度で有効なfieldsを重心のH3セルごとに集計
+	// 重心のセルはfields.h3_index(解像度15)の指定解像度の親セルとする
+	AggregateClustersByH3(ctx context.Context, resolution int32) ([]*AggregateClustersByH3Row, error)
+	// 指定H3セルのみ有効なfieldsを集計(差分更新用)
+	// lower_bounds/upper_boundsは各セルの解像度15の子孫セルの最小値・最大値で、h3_indexの範囲検索で絞り込む
+	AggregateClustersByH3ForCells(ctx context.Context, arg *AggregateClustersByH3ForCellsParams) ([]*AggregateClustersByH3ForCellsRow, error)
+	// 絞り込み条件に一致する有効なfieldsを重心のH3セルごとにその場で集計(cluster_resultsを使用しない)
+	// 重心が指定範囲内の圃場のみ対象とし、圃場数・合計面積と属性別の集計を返す。各絞り込み条件はNULLの場合に無視される
+	// 南西端の経度が北東端の経度より大きい場合は日付変更線をまたぐ範囲として東西2つの矩形で検索する
+	AggregateFilteredClusters(ctx context.Context, arg *AggregateFilteredClustersParams) ([]*AggregateFilteredClustersRow, error)
+	// インポートジョブにジオメトリ検証で拒否したレコードを追記
+	AppendImportJobRejectedRecords(ctx context.Context, arg *AppendImportJobRejectedRecordsParams) (*ImportJob, error)
+	// 保留中のジョブを取り消し済みに更新
+	// 既にワーカーが取得したジョブは更新しない
+	CancelClusterJob(ctx context.Context, id uuid.UUID) (int64, error)
+	// 分筆後の子圃場が親圃場に収まり、互いに重ならないかを検証するための面積を取得
+	// child_indexは入力配列の順序(1始まり)。outside_area_sqmは親圃場からはみ出した面積、
+	// overlap_area_sqmは自身より後ろの子圃場と重なる面積の合計
+	CheckFieldDivisionGeometries(ctx context.Context, arg *CheckFieldDivisionGeometriesParams) ([]*CheckFieldDivisionGeometriesRow, error)
+	// 最も優先度の高い保留中ジョブを処理中に更新し、指定ワーカーのリースとして取得
+	// 他のワーカーやエンキューがロック中のジョブはスキップする
+	ClaimClusterJob(ctx context.Context, workerID *string) (*ClusterJob, error)
+	// 最も古い保留中のジョブを処理中に更新し、指定ワーカーのリースとして取得
+	// 他のワーカーがロック中のジョブはスキップする
+	ClaimPendingExportJob(ctx context.Context, workerID *string) (*ExportJob, error)
+	// 圃場ジオメトリから相手圃場との重なり部分を取り除いた形状をMultiPolygonのWKB形式で取得
+	// geometry_countが0の場合はクリップで圃場が消滅し、2以上の場合は複数の区画に分断される
+	ClipFieldGeometry(ctx context.Context, arg *ClipFieldGeometryParams) (*ClipFieldGeometryRow, error)
+	// 条件に一致するクラスタージョブの総数を取得(ListClusterJobsと同一条件)
+	CountClusterJobs(ctx context.Context, arg *CountClusterJobsParams) (int64, error)
 	// 圃場IDで農地台帳の件数を取得
 	CountFieldLandRegistriesByFieldID(ctx context.Context, fieldID uuid.UUID) (int64, error)
-	// 圃場の総数を取得
+	// 条件に一致するオーバーラップ検知記録の総数を取得(ListFieldOverlapsと同一条件)
+	CountFieldOverlaps(ctx context.Context, arg *CountFieldOverlapsParams) (int64, error)
+	// 有効な圃場の総数を取得
 	CountFields(ctx context.Context) (int64, error)
 	// インポートジョブの総数を取得
 	CountImportJobs(ctx context.Context) (int64, error)
 	// ステータス別のインポートジョブ数を取得
 	CountImportJobsByStatus(ctx context.Context, status string) (int64, error)
-	// クラスタージョブを作成
-	CreateClusterJob(ctx context.Context, arg *CreateClusterJobParams) (*ClusterJob, error)
-	// 影響セル情報付きでクラスタージョブを作成
-	CreateClusterJobWithAffectedCells(ctx context.Context, arg *CreateClusterJobWithAffectedCellsParams) (*ClusterJob, error)
+	// 検索条件に一致する圃場の総数を取得(SearchFieldsと同一条件)
+	CountSearchFields(ctx context.Context, arg *CountSearchFieldsParams) (int64, error)
+	// 全範囲再計算の書き込み先となる世代を作成
+	CreateClusterGeneration(ctx context.Context) (int64, error)
+	// エクスポートジョブを作成
+	CreateExportJob(ctx context.Context, arg *CreateExportJobParams) (*ExportJob, error)
 	// 圃場を作成
+	// geometry, centroidはWKB形式のbytea型で受け取り、ST_GeomFromWKBで変換
+	// geometryはPolygonで渡されてもST_Multiでマルチポリゴンに揃える
 	CreateField(ctx context.Context, arg *CreateFieldParams) (*Field, error)
+	// 分筆履歴(親子関係)を作成
+	CreateFieldDivision(ctx context.Context, arg *CreateFieldDivisionParams) (*FieldDivision, error)
 	// 農地台帳を作成
 	CreateFieldLandRegistry(ctx context.Context, arg *CreateFieldLandRegistryParams) (*FieldLandRegistry, error)
+	// 合筆履歴(合筆先とソースの関係)を作成
+	CreateFieldMerger(ctx context.Context, arg *CreateFieldMergerParams) (*FieldMerger, error)
 	// インポートジョブを作成
 	CreateImportJob(ctx context.Context, cityCode string) (*ImportJob, error)
-	// 全クラスター結果を削除
-	DeleteAllClusterResults(ctx context.Context) error
-	// 指定H3インデックスのクラスター結果を削除(カウント0になったセル用)
+	// 有効化していない世代を削除(世代のクラスター結果も削除される)
+	DeleteClusterGeneration(ctx context.Context, generation int64) error
+	// 指定世代の指定H3インデックスのクラスター結果を削除(カウント0になったセル用)
 	DeleteClusterResultsByH3Indexes(ctx context.Context, arg *DeleteClusterResultsByH3IndexesParams) error
-	// 指定解像度のクラスター結果を全削除
-	DeleteClusterResultsByResolution(ctx context.Context, resolution int32) error
 	// 圃場を削除
 	DeleteField(ctx context.Context, id uuid.UUID) error
+	// 指定圃場のH3被覆を全て削除し、削除した被覆を返す(再計算前後の比較用)
+	DeleteFieldH3Coverages(ctx context.Context, fieldID uuid.UUID) ([]*DeleteFieldH3CoveragesRow, error)
 	// 圃場IDで農地台帳を削除(REPLACE方式用)
 	DeleteFieldLandRegistriesByFieldID(ctx context.Context, fieldID uuid.UUID) error
 	// 複数の圃場IDで農地台帳を一括削除(バッチREPLACE方式用)
 	DeleteFieldLandRegistriesByFieldIDs(ctx context.Context, dollar_1 []uuid.UUID) error
-	// 7日以上前に完了したジョブを削除
-	DeleteOldCompletedJobs(ctx context.Context) error
-	// 30日以上前に失敗したジョブを削除
-	DeleteOldFailedJobs(ctx context.Context) error
+	// 保持期間を過ぎた完了済みジョブを削除
+	DeleteOldCompletedJobs(ctx context.Context, retentionSeconds int32) (int64, error)
+	// 保持期間を過ぎた失敗・取り消し済みジョブを削除
+	DeleteOldFailedJobs(ctx context.Context, retentionSeconds int32) (int64, error)
+	// 削除済み・廃止済みの圃場のH3被覆を削除し、削除した被覆のセルを返す
+	DeleteOrphanFieldH3Coverages(ctx context.Context) ([]*DeleteOrphanFieldH3CoveragesRow, error)
+	// 指定圃場が関わる未対応・許容済みの記録のうち、今回の検出で見つからなかったものを削除する
+	// クリップで解消した記録は対応履歴として残す
+	DeleteStaleFieldOverlaps(ctx context.Context, arg *DeleteStaleFieldOverlapsParams) error
+	// 使用されなくなった世代を削除(世代のクラスター結果も削除される)
+	// 有効な世代と、切り替え直後に読み取り中のリクエストのため直前に有効だった世代は残す
+	// 有効化されていない世代は、作成からstale_seconds秒を過ぎたもの(異常終了したワーカーの計算途中の世代)のみ削除する
+	DeleteUnusedClusterGenerations(ctx context.Context, staleSeconds int32) (int64, error)
+	// クラスタージョブをエンキューする
+	// 保留中ジョブは部分ユニークインデックスで1件に限定されており、既に存在する場合は新規作成せずに統合する
+	// 統合時は優先度の高い方を採用し、どちらかが全範囲再計算(NULL)か統合後のセル数が上限を超える場合は全範囲再計算に昇格する
+	EnqueueClusterJob(ctx context.Context, arg *EnqueueClusterJobParams) (*EnqueueClusterJobRow, error)
+	// ハートビートがリース期間を超えて途絶え、実行回数が上限に達した処理中ジョブを失敗に更新
+	FailExpiredExportJobs(ctx context.Context, arg *FailExpiredExportJobsParams) (int64, error)
+	// 有効な世代(有効化済みの世代のうち番号が最大のもの)を取得
+	GetActiveClusterGeneration(ctx context.Context) (int64, error)
+	// 市区町村の圃場統計を取得
+	GetCityFieldStats(ctx context.Context, cityCode string) (*CityFieldStat, error)
 	// クラスタージョブをIDで取得
-	GetClusterJob(ctx context.Context, id uuid.UUID) (*GetClusterJobRow, error)
-	// 指定解像度のクラスター結果を取得
-	GetClusterResults(ctx context.Context, resolution int32) ([]*ClusterResult, error)
+	GetClusterJob(ctx context.Context, id uuid.UUID) (*ClusterJob, error)
+	// 指定世代・解像度のクラスター結果のうち、指定範囲のH3インデックスのものを取得
+	// lower_bounds/upper_boundsは表示範囲を覆うセルの指定解像度の子孫セルの最小値・最大値で、h3_indexの範囲検索で絞り込む
+	GetClusterResultsInRanges(ctx context.Context, arg *GetClusterResultsInRangesParams) ([]*ClusterResult, error)
+	// ハートビートがリース期間を超えて途絶えた処理中ジョブを取得(排他ロック)
+	GetExpiredClusterJobs(ctx context.Context, leaseSeconds int32) ([]*ClusterJob, error)
+	// エクスポートジョブをIDで取得
+	GetExportJob(ctx context.Context, id uuid.UUID) (*ExportJob, error)
 	// 圃場をIDで取得
 	GetField(ctx context.Context, id uuid.UUID) (*Field, error)
 	// 農地台帳をIDで取得
 	GetFieldLandRegistry(ctx context.Context, id uuid.UUID) (*FieldLandRegistry, error)
+	// IDでオーバーラップ検知記録を取得
+	GetFieldOverlap(ctx context.Context, id uuid.UUID) (*FieldOverlap, error)
+	// 指定タイルの圃場をMapbox Vector Tile(MVT)形式で取得
+	// タイル範囲(EPSG:3857)をWGS84に変換してidx_fields_geometry_gistで絞り込み、ST_AsMVTGeomでタイル座標に変換する
+	// 土地種別は農地台帳のうち面積が最大のものを代表値とする。廃止済みの圃場は含めない
+	GetFieldTile(ctx context.Context, arg *GetFieldTileParams) ([]byte, error)
 	// 指定IDのフィールドのH3インデックスを取得(差分更新のプリフェッチ用)
 	GetH3IndexesByFieldIDs(ctx context.Context, ids []uuid.UUID) ([]*GetH3IndexesByFieldIDsRow, error)
 	// 遊休農地状況をコードで取得
@@ -77,22 +152,59 @@ type Querier interface {
 	GetImportJob(ctx context.Context, id uuid.UUID) (*ImportJob, error)
 	// 土地種別をコードで取得
 	GetLandCategory(ctx context.Context, code string) (*LandCategory, error)
-	// 保留中のジョブを優先度順に取得(排他ロック)
-	GetPendingClusterJobs(ctx context.Context, limit int32) ([]*GetPendingClusterJobsRow, error)
-	// 保留中のジョブを影響セル情報付きで優先度順に取得(排他ロック)
-	GetPendingClusterJobsWithAffectedCells(ctx context.Context, limit int32) ([]*GetPendingClusterJobsWithAffectedCellsRow, error)
+	// 都道府県内の市区町村の圃場統計を合算して取得
+	// 都道府県コードは市区町村コードの上2桁
+	GetPrefectureFieldStats(ctx context.Context, prefectureCode string) (*GetPrefectureFieldStatsRow, error)
 	// 土壌タイプをIDで取得
 	GetSoilType(ctx context.Context, id uuid.UUID) (*SoilType, error)
 	// 土壌タイプを小分類コードで取得
 	GetSoilTypeBySmallCode(ctx context.Context, smallCode string) (*SoilType, error)
+	// 圃場が分筆・合筆で作られた圃場かどうかを取得
+	// 系譜のある圃場を削除すると分筆・合筆の履歴がカスケード削除されるため、削除の代わりに廃止する判定に使う
+	HasFieldLineage(ctx context.Context, fieldID uuid.UUID) (bool, error)
 	// 保留中または処理中のジョブがあるか確認
 	HasPendingOrProcessingJob(ctx context.Context) (bool, error)
+	// 指定ワーカーがリースを保持している処理中ジョブのハートビートを更新
+	HeartbeatClusterJob(ctx context.Context, arg *HeartbeatClusterJobParams) (int64, error)
+	// 指定ワーカーがリースを保持している処理中ジョブのハートビートを更新
+	HeartbeatExportJob(ctx context.Context, arg *HeartbeatExportJobParams) (int64, error)
+	// 指定圃場のH3被覆を一括登録する
+	// 解像度・H3インデックス・面積比率は同じ長さの配列で受け取る
+	InsertFieldH3Coverages(ctx context.Context, arg *InsertFieldH3CoveragesParams) error
+	// 市区町村の土壌大分類ごとの圃場統計を合計面積の大きい順に取得
+	ListCitySoilStats(ctx context.Context, cityCode string) ([]*ListCitySoilStatsRow, error)
+	// 条件を指定してクラスタージョブの履歴を作成日時の新しい順に取得
+	// 各条件はNULLの場合に無視される。影響セルは件数のみを取得する
+	ListClusterJobs(ctx context.Context, arg *ListClusterJobsParams) ([]*ListClusterJobsRow, error)
 	// 圃場IDで農地台帳一覧を取得
 	ListFieldLandRegistriesByFieldID(ctx context.Context, fieldID uuid.UUID) ([]*FieldLandRegistry, error)
-	// 圃場一覧を取得
+	// 複数の圃場IDで農地台帳一覧を土地種別名・遊休農地状況名付きで取得(エクスポート用)
+	ListFieldLandRegistriesForExport(ctx context.Context, fieldIds []uuid.UUID) ([]*ListFieldLandRegistriesForExportRow, error)
+	// 圃場IDで農地台帳一覧を土地種別名・遊休農地状況名付きで取得
+	ListFieldLandRegistriesWithMastersByFieldID(ctx context.Context, fieldID uuid.UUID) ([]*ListFieldLandRegistriesWithMastersByFieldIDRow, error)
+	// 分筆・合筆履歴を起点圃場から祖先方向・子孫方向に再帰的に辿り、系譜のエッジを取得
+	// エッジは旧圃場(from)から新圃場(to)の向きで、depthは起点からの世代数(1始まり)
+	// pathに辿った圃場を保持し、履歴に循環があっても同じ圃場を2度辿らない
+	// 同じエッジが複数の経路から到達した場合は重複して返る
+	ListFieldLineageEdges(ctx context.Context, arg *ListFieldLineageEdgesParams) ([]*ListFieldLineageEdgesRow, error)
+	// 系譜グラフのノードとなる圃場を取得(廃止済みの圃場を含む)
+	ListFieldLineageNodes(ctx context.Context, ids []uuid.UUID) ([]*ListFieldLineageNodesRow, error)
+	// 条件を指定してオーバーラップ検知記録の一覧を取得
+	// 各条件はNULLの場合に無視される。重なりの割合が大きい順に並べる
+	ListFieldOverlaps(ctx context.Context, arg *ListFieldOverlapsParams) ([]*FieldOverlap, error)
+	// 有効な圃場一覧を取得
 	ListFields(ctx context.Context, arg *ListFieldsParams) ([]*Field, error)
-	// 市区町村コードで圃場一覧を取得
+	// 市区町村コードで有効な圃場一覧を取得
 	ListFieldsByCityCode(ctx context.Context, arg *ListFieldsByCityCodeParams) ([]*Field, error)
+	// 重心・H3インデックスの再計算対象の圃場をID順に取得(キーセットページング)
+	// 廃止済みの圃場も含め、after_idより後の圃場をrow_limit件返す
+	ListFieldsForCentroidBackfill(ctx context.Context, arg *ListFieldsForCentroidBackfillParams) ([]*ListFieldsForCentroidBackfillRow, error)
+	// エクスポート対象の有効な圃場をID順に取得(キーセットページング)
+	// after_idより後の圃場をrow_limit件返す。各条件はNULLの場合に無視される
+	ListFieldsForExport(ctx context.Context, arg *ListFieldsForExportParams) ([]*ListFieldsForExportRow, error)
+	// H3被覆の計算が必要な有効な圃場をID順に取得(キーセットページング)
+	// 被覆が未計算、または計算後に圃場が更新された圃場をafter_idより後からrow_limit件返す
+	ListFieldsForH3CoverageRefresh(ctx context.Context, arg *ListFieldsForH3CoverageRefreshParams) ([]*ListFieldsForH3CoverageRefreshRow, error)
 	// 遊休農地状況一覧を取得
 	ListIdleLandStatuses(ctx context.Context) ([]*IdleLandStatus, error)
 	// インポートジョブ一覧を取得
@@ -101,16 +213,75 @@ type Querier interface {
 	ListImportJobsByCityCode(ctx context.Context, arg *ListImportJobsByCityCodeParams) ([]*ImportJob, error)
 	// 土地種別一覧を取得
 	ListLandCategories(ctx context.Context) ([]*LandCategory, error)
+	// 有効な世代と、それより新しい計算中の世代を取得(差分更新の書き込み先)
+	ListLiveClusterGenerations(ctx context.Context) ([]int64, error)
+	// 都道府県の土壌大分類ごとの圃場統計を合計面積の大きい順に取得
+	ListPrefectureSoilStats(ctx context.Context, prefectureCode string) ([]*ListPrefectureSoilStatsRow, error)
 	// 土壌タイプ一覧を取得
 	ListSoilTypes(ctx context.Context) ([]*SoilType, error)
-	// ジョブを完了に更新
-	UpdateClusterJobToCompleted(ctx context.Context, id uuid.UUID) error
-	// ジョブを失敗に更新
-	UpdateClusterJobToFailed(ctx context.Context, arg *UpdateClusterJobToFailedParams) error
-	// ジョブを処理中に更新
-	UpdateClusterJobToProcessing(ctx context.Context, id uuid.UUID) error
+	// 分筆・合筆・削除の対象圃場を行ロックして廃止状態を取得
+	// 同一圃場への同時操作を直列化するため、トランザクション内で使用する
+	LockFieldForUpdate(ctx context.Context, id uuid.UUID) (*LockFieldForUpdateRow, error)
+	// オーバーラップ検知記録を行ロックして取得
+	// 同一記録への同時対応を直列化するため、トランザクション内で使用する
+	LockFieldOverlapForUpdate(ctx context.Context, id uuid.UUID) (*FieldOverlap, error)
+	// 合筆の対象圃場をID順に行ロックして廃止状態を取得
+	// ロック順序を固定して同時操作によるデッドロックを避ける。存在しないIDは結果に含まれない
+	LockFieldsForUpdate(ctx context.Context, ids []uuid.UUID) ([]*LockFieldsForUpdateRow, error)
+	// 指定ジョブの影響セルと優先度を保留中ジョブに統合し、統合先のジョブIDを返す
+	// 統合の規則はEnqueueClusterJobと同じで、実行回数は多い方を引き継ぐ
+	MergeIntoPendingClusterJob(ctx context.Context, arg *MergeIntoPendingClusterJobParams) (uuid.UUID, error)
+	// 複数圃場の農地台帳をまとめて別の圃場に付け替える(合筆時の引き継ぎ用)
+	MoveFieldLandRegistries(ctx context.Context, arg *MoveFieldLandRegistriesParams) error
+	// 市区町村ごとの圃場統計を再集計
+	// CONCURRENTLYのため、再集計中も集計前の統計を参照できる
+	RefreshCityFieldStats(ctx context.Context) error
+	// 市区町村・土壌大分類ごとの圃場統計を再集計
+	RefreshCitySoilStats(ctx context.Context) error
+	// 自己交差などで不正なポリゴンをST_MakeValidで修復し、外周を反時計回りに揃えたWKB形式で取得
+	// ordは入力配列の順序(1始まり)。穴のない単一ポリゴンに修復できなかったものは結果に含まない
+	RepairPolygons(ctx context.Context, geometryWkbs [][]byte) ([]*RepairPolygonsRow, error)
+	// 処理中のジョブを保留中に戻し、リースを解放
+	RequeueClusterJob(ctx context.Context, id uuid.UUID) error
+	// ハートビートがリース期間を超えて途絶え、実行回数が上限未満の処理中ジョブを保留中に戻してリースを解放
+	RequeueExpiredExportJobs(ctx context.Context, arg *RequeueExpiredExportJobsParams) (int64, error)
+	// オーバーラップ検知記録に対応結果(許容・クリップ)を記録
+	ResolveFieldOverlap(ctx context.Context, arg *ResolveFieldOverlapParams) (*FieldOverlap, error)
+	// 削除要求された系譜のある圃場を廃止する
+	// 分筆・合筆と異なり農地台帳は移動しないため、圃場名はそのまま残す
+	RetireDeletedField(ctx context.Context, arg *RetireDeletedFieldParams) error
+	// 圃場を廃止する(分筆・合筆で役目を終えた圃場用)
+	// 農地台帳の移動でトリガーにより書き換わった圃場名を廃止前の名称に戻す
+	RetireField(ctx context.Context, arg *RetireFieldParams) error
+	// 検索条件を指定して有効な圃場一覧を取得
+	// 廃止済みの圃場は除外する。各条件はNULLの場合に無視される。nameを指定した場合は類似度の高い順に並べる
+	// name_patternはnameのワイルドカードをエスケープして前後に%を付けたILIKEのパターン
+	// sw_lng > ne_lngの場合は日付変更線をまたぐ範囲として東西2つに分割して判定する
+	SearchFields(ctx context.Context, arg *SearchFieldsParams) ([]*SearchFieldsRow, error)
+	// 合筆対象の圃場ジオメトリをST_Unionで結合し、マルチポリゴンのWKB形式で取得
+	// 複数区画の圃場を含む場合は結合結果も複数区画になるため、区画数ではなく圃場同士のつながりで隣接を判定する
+	// connectedは辺を共有するか重なる圃場同士をたどって全ての圃場がつながっている場合にtrue
+	UnionFieldGeometries(ctx context.Context, ids []uuid.UUID) (*UnionFieldGeometriesRow, error)
+	// 指定ワーカーがリースを保持している処理中ジョブを完了に更新
+	UpdateClusterJobToCompleted(ctx context.Context, arg *UpdateClusterJobToCompletedParams) (int64, error)
+	// 指定ワーカーがリースを保持している処理中ジョブを失敗に更新
+	UpdateClusterJobToFailed(ctx context.Context, arg *UpdateClusterJobToFailedParams) (int64, error)
+	// リース期限切れの処理中ジョブを失敗に更新
+	UpdateExpiredClusterJobToFailed(ctx context.Context, arg *UpdateExpiredClusterJobToFailedParams) error
+	// 指定ワーカーがリースを保持している処理中ジョブを完了に更新
+	UpdateExportJobToCompleted(ctx context.Context, arg *UpdateExportJobToCompletedParams) (int64, error)
+	// 指定ワーカーがリースを保持している処理中ジョブを失敗に更新
+	UpdateExportJobToFailed(ctx context.Context, arg *UpdateExportJobToFailedParams) (int64, error)
 	// 圃場を更新
+	// geometry, centroidはWKB形式のbytea型で受け取り、ST_GeomFromWKBで変換
+	// geometryはPolygonで渡されてもST_Multiでマルチポリゴンに揃える
+	// 読み取り後に分筆・合筆で廃止された圃場を上書きしないよう、廃止済みの圃場は更新せず行を返さない
 	UpdateField(ctx context.Context, arg *UpdateFieldParams) (*Field, error)
+	// 圃場の重心とH3インデックスのみを更新(重心の算出方法変更に伴うバックフィル用)
+	// centroidはWKB形式のbytea型で受け取り、ST_GeomFromWKBで変換
+	UpdateFieldCentroid(ctx context.Context, arg *UpdateFieldCentroidParams) error
+	// 農地台帳の所属圃場を変更(分筆・合筆時の付け替え用)
+	UpdateFieldLandRegistryFieldID(ctx context.Context, arg *UpdateFieldLandRegistryFieldIDParams) error
 	// インポートジョブのエラー情報を更新
 	UpdateImportJobError(ctx context.Context, arg *UpdateImportJobErrorParams) (*ImportJob, error)
 	// インポートジョブの実行ARNを更新
@@ -123,11 +294,17 @@ type Querier interface {
 	UpdateImportJobStatus(ctx context.Context, arg *UpdateImportJobStatusParams) (*ImportJob, error)
 	// インポートジョブの総レコード数を更新
 	UpdateImportJobTotalRecords(ctx context.Context, arg *UpdateImportJobTotalRecordsParams) (*ImportJob, error)
-	// クラスター結果をUPSERT
+	// 指定世代のクラスター結果をUPSERT
 	UpsertClusterResult(ctx context.Context, arg *UpsertClusterResultParams) error
 	// 圃場をUPSERT(wagriインポート用)
 	// geometry, centroidはWKB形式のbytea型で受け取り、ST_GeomFromWKBで変換
+	// geometryはPolygonで渡されてもST_Multiでマルチポリゴンに揃える
+	// 分筆・合筆で廃止済みの圃場は更新せず、行を返さない
 	UpsertField(ctx context.Context, arg *UpsertFieldParams) (*Field, error)
+	// 指定圃場と他の有効な圃場の重なりを検出し、圃場ペア単位で記録する
+	// ST_IntersectsでGiSTインデックスを使って候補を絞り込み、ST_Intersectionの面積が閾値未満の接触は重なりとみなさない
+	// 許容済みの重なりは面積の変化が閾値未満の間だけ許容のまま維持し、それ以外は未対応に戻す
+	UpsertFieldOverlaps(ctx context.Context, arg *UpsertFieldOverlapsParams) ([]uuid.UUID, error)
 	// 遊休農地状況をUPSERT
 	UpsertIdleLandStatus(ctx context.Context, arg *UpsertIdleLandStatusParams) (*IdleLandStatus, error)
 	// 土地種別をUPSERT
@@ -14,9 +14,21 @@ type CacheStore interface {
 	// Set はキーに値を設定する(TTL付き)
 	Set(ctx context.Context, key string, value string, expiration time.Duration) error
 
+	// MGet は複数のキーの値をまとめて取得する(存在しないキーの値はnil)
+	MGet(ctx context.Context, keys ...string) ([]*string, error)
+
+	// SetMulti は複数のキーに値をまとめて設定する(TTL付き)
+	SetMulti(ctx context.Context, values map[string]string, expiration time.Duration) error
+
 	// Delete はキーを削除する
 	Delete(ctx context.Context, key string) error
 
+	// DeleteKeys は複数のキーをまとめて削除する
+	DeleteKeys(ctx context.Context, keys ...string) error
+
+	// DeleteByPattern はパターンに一致するキーを削除する
+	DeleteByPattern(ctx context.Context, pattern string) error
+
 	// Ping は接続確認を行う
 	Ping(ctx context.Context) error
 
@@ -8,6 +8,9 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// keyChunkSize は一括取得・一括削除時に1コマンドで扱うキー数
+const keyChunkSize = 500
+
 // Client はRedis/Valkeyクライアントのラッパー構造体
 type Client struct {
 	client *redis.Client
@@ -30,11 +33,73 @@ func (c *Client) Set(ctx context.Context, key string, value string, expiration t
 	return c.client.Set(ctx, key, value, expiration).Err()
 }
 
+// MGet は複数のキーの値をまとめて取得する
+// 戻り値はkeysと同じ順序で、存在しないキーの値はnilになる
+func (c *Client) MGet(ctx context.Context, keys ...string) ([]*string, error) {
+	values := make([]*string, 0, len(keys))
+	for start := 0; start < len(keys); start += keyChunkSize {
+		end := min(start+keyChunkSize, len(keys))
+		results, err := c.client.MGet(ctx, keys[start:end]...).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			value, ok := result.(string)
+			if !ok {
+				values = append(values, nil)
+				continue
+			}
+			values = append(values, &value)
+		}
+	}
+	return values, nil
+}
+
+// SetMulti は複数のキーに値をまとめて設定する（TTL付き）
+// MSETはTTLを指定できないため、SETをパイプラインでまとめて送信する
+func (c *Client) SetMulti(ctx context.Context, values map[string]string, expiration time.Duration) error {
+	if len(values) == 0 {
+		return nil
+	}
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, value := range values {
+			pipe.Set(ctx, key, value, expiration)
+		}
+		return nil
+	})
+	return err
+}
+
 // Delete はキーを削除する
 func (c *Client) Delete(ctx context.Context, key string) error {
 	return c.client.Del(ctx, key).Err()
 }
 
+// DeleteKeys は複数のキーをまとめて削除する
+func (c *Client) DeleteKeys(ctx context.Context, keys ...string) error {
+	for start := 0; start < len(keys); start += keyChunkSize {
+		end := min(start+keyChunkSize, len(keys))
+		if err := c.client.Del(ctx, keys[start:end]...).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteByPattern はパターンに一致するキーをSCANで列挙して削除する
+// KEYSコマンドと異なりサーバーをブロックしない。列挙中の削除でカーソルがずれないよう、全件列挙後に削除する
+func (c *Client) DeleteByPattern(ctx context.Context, pattern string) error {
+	var keys []string
+	iter := c.client.Scan(ctx, 0, pattern, keyChunkSize).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	return c.DeleteKeys(ctx, keys...)
+}
+
 // Ping は接続確認を行う
 func (c *Client) Ping(ctx context.Context) error {
 	return c.client.Ping(ctx).Err()
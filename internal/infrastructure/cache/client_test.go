@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -105,6 +106,133 @@ func TestClient_Delete(t *testing.T) {
 	}
 }
 
+func TestClient_DeleteKeys(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer func() {
+		if err := client.Close(); err != nil {
+			t.Errorf("Close()でエラー発生 = %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+
+	// チャンクサイズを超える件数を設定
+	keys := make([]string, keyChunkSize+10)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("bulk-key-%d", i)
+		if err := client.Set(ctx, keys[i], "value", time.Minute); err != nil {
+			t.Fatalf("Set()でエラー発生 = %v", err)
+		}
+	}
+	if err := client.Set(ctx, "keep-key", "value", time.Minute); err != nil {
+		t.Fatalf("Set()でエラー発生 = %v", err)
+	}
+
+	if err := client.DeleteKeys(ctx, keys...); err != nil {
+		t.Errorf("DeleteKeys()でエラー発生 = %v", err)
+	}
+
+	for _, key := range keys {
+		if mr.Exists(key) {
+			t.Errorf("キー %s が削除されていない", key)
+		}
+	}
+	if !mr.Exists("keep-key") {
+		t.Error("指定していないキーは削除されるべきではない")
+	}
+
+	// 空の指定はエラーにならない
+	if err := client.DeleteKeys(ctx); err != nil {
+		t.Errorf("DeleteKeys()は空の指定でエラーを返すべきではない = %v", err)
+	}
+}
+
+func TestClient_DeleteByPattern(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer func() {
+		if err := client.Close(); err != nil {
+			t.Errorf("Close()でエラー発生 = %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+
+	for i := 0; i < keyChunkSize+10; i++ {
+		if err := client.Set(ctx, fmt.Sprintf("tile:%d", i), "value", time.Minute); err != nil {
+			t.Fatalf("Set()でエラー発生 = %v", err)
+		}
+	}
+	if err := client.Set(ctx, "cluster:results:res3", "value", time.Minute); err != nil {
+		t.Fatalf("Set()でエラー発生 = %v", err)
+	}
+
+	if err := client.DeleteByPattern(ctx, "tile:*"); err != nil {
+		t.Errorf("DeleteByPattern()でエラー発生 = %v", err)
+	}
+
+	if got := len(mr.Keys()); got != 1 {
+		t.Errorf("残りのキー数 = %d, 期待値 1", got)
+	}
+	if !mr.Exists("cluster:results:res3") {
+		t.Error("パターンに一致しないキーは削除されるべきではない")
+	}
+}
+
+func TestClient_MGetAndSetMulti(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer func() {
+		if err := client.Close(); err != nil {
+			t.Errorf("Close()でエラー発生 = %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+
+	// チャンクサイズを超える件数を設定
+	values := make(map[string]string, keyChunkSize+10)
+	keys := make([]string, 0, keyChunkSize+11)
+	for i := 0; i < keyChunkSize+10; i++ {
+		key := fmt.Sprintf("multi-key-%d", i)
+		values[key] = fmt.Sprintf("value-%d", i)
+		keys = append(keys, key)
+	}
+	keys = append(keys, "non-existent-key")
+
+	if err := client.SetMulti(ctx, values, time.Minute); err != nil {
+		t.Fatalf("SetMulti()でエラー発生 = %v", err)
+	}
+	if ttl := mr.TTL("multi-key-0"); ttl != time.Minute {
+		t.Errorf("TTL = %v, 期待値 %v", ttl, time.Minute)
+	}
+
+	got, err := client.MGet(ctx, keys...)
+	if err != nil {
+		t.Fatalf("MGet()でエラー発生 = %v", err)
+	}
+	if len(got) != len(keys) {
+		t.Fatalf("MGet()の件数 = %d, 期待値 %d", len(got), len(keys))
+	}
+	for i, key := range keys[:len(keys)-1] {
+		if got[i] == nil || *got[i] != values[key] {
+			t.Errorf("MGet()[%d] = %v, 期待値 %v", i, got[i], values[key])
+		}
+	}
+	if got[len(got)-1] != nil {
+		t.Error("MGet()は存在しないキーに対してnilを返すべき")
+	}
+
+	// 空の指定はエラーにならない
+	if err := client.SetMulti(ctx, nil, time.Minute); err != nil {
+		t.Errorf("SetMulti()は空の指定でエラーを返すべきではない = %v", err)
+	}
+	if got, err := client.MGet(ctx); err != nil || len(got) != 0 {
+		t.Errorf("MGet()は空の指定で空の結果を返すべき = %v, %v", got, err)
+	}
+}
+
 func TestClient_Ping(t *testing.T) {
 	mr, client := setupTestRedis(t)
 	defer mr.Close()
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// defaultReconnectInterval はLISTEN用接続が切断された後、再接続するまでの間隔
+const defaultReconnectInterval = 5 * time.Second
+
+// Listener はPostgreSQLのLISTEN/NOTIFYでチャネルへの通知を受け取る
+type Listener struct {
+	pool              *pgxpool.Pool
+	channel           string
+	reconnectInterval time.Duration
+	logger            *slog.Logger
+}
+
+// NewListener はListenerを作成する
+func NewListener(pool *pgxpool.Pool, channel string, logger *slog.Logger) *Listener {
+	return &Listener{
+		pool:              pool,
+		channel:           channel,
+		reconnectInterval: defaultReconnectInterval,
+		logger:            logger,
+	}
+}
+
+// Listen はチャネルへの通知を受け取るたびに値を送るチャネルを返す
+// 最初の通知からdebounceの間に届いた通知は1回にまとめる。
+// 切断中の通知は取りこぼすため、接続(再接続)直後にも1回送る。ctxが終了すると返り値のチャネルを閉じる
+func (l *Listener) Listen(ctx context.Context, debounce time.Duration) <-chan struct{} {
+	notifications := make(chan struct{}, 1)
+	go l.run(ctx, notifications)
+	return coalesce(ctx, notifications, debounce)
+}
+
+// run は接続が切れるたびに再接続しながら通知を受け取り続ける
+func (l *Listener) run(ctx context.Context, notifications chan<- struct{}) {
+	defer close(notifications)
+
+	for {
+		err := l.listen(ctx, notifications)
+		if ctx.Err() != nil {
+			return
+		}
+
+		l.logger.Warn("通知の待ち受けが中断されました。再接続します",
+			slog.String("channel", l.channel),
+			slog.Duration("reconnect_interval", l.reconnectInterval),
+			slog.String("error", err.Error()))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(l.reconnectInterval):
+		}
+	}
+}
+
+// listen はプールから取り出した接続でLISTENし、エラーになるまで通知を待ち受ける
+// 待ち受け中の接続はプールに戻せないため、プールから切り離して使い終わったら閉じる
+func (l *Listener) listen(ctx context.Context, notifications chan<- struct{}) error {
+	poolConn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("接続の取得に失敗しました: %w", err)
+	}
+	conn := poolConn.Hijack()
+	defer func() {
+		if err := conn.Close(context.Background()); err != nil {
+			l.logger.Warn("LISTEN用接続のクローズに失敗しました",
+				slog.String("error", err.Error()))
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{l.channel}.Sanitize()); err != nil {
+		return fmt.Errorf("LISTENに失敗しました: %w", err)
+	}
+
+	l.logger.Info("通知の待ち受けを開始しました",
+		slog.String("channel", l.channel))
+	notify(notifications)
+
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			return fmt.Errorf("通知の待ち受けに失敗しました: %w", err)
+		}
+		notify(notifications)
+	}
+}
+
+// notify は未処理の通知がなければ通知を送る
+func notify(ch chan<- struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// coalesce は最初の通知からwindowの間に届いた通知を1回にまとめて送る
+// 通知が途切れなくても最初の通知からwindow後には送るため、通知が続いても処理が遅れ続けることはない
+func coalesce(ctx context.Context, in <-chan struct{}, window time.Duration) <-chan struct{} {
+	out := make(chan struct{}, 1)
+
+	go func() {
+		defer close(out)
+
+		for in != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-in:
+				if !ok {
+					return
+				}
+			}
+
+			timer := time.NewTimer(window)
+		wait:
+			for {
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case _, ok := <-in:
+					if !ok {
+						in = nil
+					}
+				case <-timer.C:
+					break wait
+				}
+			}
+
+			notify(out)
+		}
+	}()
+
+	return out
+}
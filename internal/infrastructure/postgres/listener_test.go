@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCoalesce(t *testing.T) {
+	const window = 50 * time.Millisecond
+
+	t.Run("window内の通知を1回にまとめる", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		in := make(chan struct{}, 1)
+		out := coalesce(ctx, in, window)
+
+		for i := 0; i < 3; i++ {
+			in <- struct{}{}
+		}
+
+		select {
+		case <-out:
+		case <-time.After(time.Second):
+			t.Fatal("coalesce()は通知を送るべき")
+		}
+
+		select {
+		case <-out:
+			t.Error("coalesce()はwindow内の通知を1回にまとめるべき")
+		case <-time.After(2 * window):
+		}
+	})
+
+	t.Run("window経過後の通知は再度送る", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		in := make(chan struct{}, 1)
+		out := coalesce(ctx, in, window)
+
+		for i := 0; i < 2; i++ {
+			in <- struct{}{}
+			select {
+			case <-out:
+			case <-time.After(time.Second):
+				t.Fatalf("coalesce()は%d回目の通知を送るべき", i+1)
+			}
+		}
+	})
+
+	t.Run("入力チャネルが閉じられるとまとめた通知を送ってから閉じる", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		in := make(chan struct{}, 1)
+		out := coalesce(ctx, in, window)
+
+		in <- struct{}{}
+		close(in)
+
+		if _, ok := <-out; !ok {
+			t.Error("coalesce()は閉じる前にまとめた通知を送るべき")
+		}
+		if _, ok := <-out; ok {
+			t.Error("coalesce()は入力チャネルが閉じられたら出力チャネルを閉じるべき")
+		}
+	})
+
+	t.Run("ctxが終了すると閉じる", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		in := make(chan struct{}, 1)
+		out := coalesce(ctx, in, window)
+		cancel()
+
+		select {
+		case _, ok := <-out:
+			if ok {
+				t.Error("coalesce()はctx終了後に通知を送るべきではない")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("coalesce()はctx終了後に出力チャネルを閉じるべき")
+		}
+	})
+}
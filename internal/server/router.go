@@ -4,37 +4,65 @@ package server
 import (
 	"context"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mktkhr/field-manager-api/internal/features/cluster/application/usecase"
+	clusterEntity "github.com/mktkhr/field-manager-api/internal/features/cluster/domain/entity"
+	clusterQuery "github.com/mktkhr/field-manager-api/internal/features/cluster/infrastructure/query"
 	clusterRepo "github.com/mktkhr/field-manager-api/internal/features/cluster/infrastructure/repository"
 	clusterHandler "github.com/mktkhr/field-manager-api/internal/features/cluster/presentation"
+	exportPort "github.com/mktkhr/field-manager-api/internal/features/export/application/port"
+	exportUsecase "github.com/mktkhr/field-manager-api/internal/features/export/application/usecase"
+	exportRepo "github.com/mktkhr/field-manager-api/internal/features/export/infrastructure/repository"
+	exportHandler "github.com/mktkhr/field-manager-api/internal/features/export/presentation"
+	fieldUsecase "github.com/mktkhr/field-manager-api/internal/features/field/application/usecase"
+	fieldQuery "github.com/mktkhr/field-manager-api/internal/features/field/infrastructure/query"
+	fieldRepo "github.com/mktkhr/field-manager-api/internal/features/field/infrastructure/repository"
+	fieldHandler "github.com/mktkhr/field-manager-api/internal/features/field/presentation"
+	statsUsecase "github.com/mktkhr/field-manager-api/internal/features/stats/application/usecase"
+	statsRepo "github.com/mktkhr/field-manager-api/internal/features/stats/infrastructure/repository"
+	statsHandler "github.com/mktkhr/field-manager-api/internal/features/stats/presentation"
 	"github.com/mktkhr/field-manager-api/internal/generated/openapi"
 	"github.com/mktkhr/field-manager-api/internal/infrastructure/cache"
 )
 
 // StrictServerHandler はStrictServerInterfaceを実装する
 type StrictServerHandler struct {
-	clusterHandler *clusterHandler.ClusterHandler
-	logger         *slog.Logger
+	clusterHandler      *clusterHandler.ClusterHandler
+	clusterJobHandler   *clusterHandler.ClusterJobHandler
+	exportHandler       *exportHandler.ExportHandler
+	fieldHandler        *fieldHandler.FieldHandler
+	fieldTileHandler    *fieldHandler.FieldTileHandler
+	fieldLineageHandler *fieldHandler.FieldLineageHandler
+	fieldOverlapHandler *fieldHandler.FieldOverlapHandler
+	fieldStatsHandler   *statsHandler.FieldStatsHandler
+	logger              *slog.Logger
 }
 
 // NewStrictServerHandler はStrictServerHandlerを作成する
+// downloadURLExpiryはエクスポートファイルの署名付きURLの有効期間
+// clusterResolutionsはクラスターワーカーが計算するH3解像度
 func NewStrictServerHandler(
 	pool *pgxpool.Pool,
 	cacheClient *cache.Client,
+	storageClient exportPort.StorageClient,
+	downloadURLExpiry time.Duration,
+	clusterResolutions []clusterEntity.Resolution,
 	logger *slog.Logger,
 ) *StrictServerHandler {
 	// クラスター機能のDI
 	clusterRepository := clusterRepo.NewClusterPostgresRepository(pool, logger)
 	clusterCacheRepository := clusterRepo.NewClusterCacheRedisRepository(cacheClient, logger)
-	clusterJobRepository := clusterRepo.NewClusterJobPostgresRepository(pool)
+	clusterJobRepository := clusterRepo.NewClusterJobPostgresRepository(pool, logger)
 
-	getClustersUC := usecase.NewGetClustersUseCase(
+	getClustersUC := usecase.NewGetClustersUseCaseWithResolutions(
 		clusterRepository,
 		clusterCacheRepository,
 		clusterJobRepository,
+		clusterResolutions,
 		logger,
 	)
 
@@ -45,9 +73,88 @@ func NewStrictServerHandler(
 
 	clusterHdlr := clusterHandler.NewClusterHandler(getClustersUC, enqueueJobUC, logger)
 
+	clusterJobQry := clusterQuery.NewClusterJobQuery(pool)
+	clusterJobHdlr := clusterHandler.NewClusterJobHandler(
+		usecase.NewListClusterJobsUseCase(clusterJobQry),
+		usecase.NewGetClusterJobUseCase(clusterJobQry),
+		usecase.NewCancelClusterJobUseCase(clusterJobRepository, clusterJobQry, logger),
+		usecase.NewRetryClusterJobUseCase(clusterJobRepository, enqueueJobUC, clusterJobQry, logger),
+		logger,
+	)
+
+	// 圃場機能のDI
+	fieldQry := fieldQuery.NewFieldQuery(pool)
+	fieldRepository := fieldRepo.NewFieldRepository(pool, logger)
+	fieldOverlapRepository := fieldRepo.NewFieldOverlapRepository(pool, logger)
+	clusterJobEnqueuer := usecase.NewClusterJobEnqueuer(enqueueJobUC)
+
+	listFieldsUC := fieldUsecase.NewListFieldsUseCase(fieldQry)
+	getFieldUC := fieldUsecase.NewGetFieldUseCase(fieldQry)
+	createFieldUC := fieldUsecase.NewCreateFieldUseCase(fieldRepository, fieldQry, fieldOverlapRepository, clusterJobEnqueuer, logger)
+	updateFieldUC := fieldUsecase.NewUpdateFieldUseCase(fieldRepository, fieldQry, fieldOverlapRepository, clusterJobEnqueuer, logger)
+	deleteFieldUC := fieldUsecase.NewDeleteFieldUseCase(fieldRepository, clusterJobEnqueuer, logger)
+	divideFieldUC := fieldUsecase.NewDivideFieldUseCase(
+		fieldRepository,
+		fieldRepo.NewFieldDivisionRepository(pool, logger),
+		fieldQry,
+		fieldOverlapRepository,
+		clusterJobEnqueuer,
+		logger,
+	)
+	mergeFieldsUC := fieldUsecase.NewMergeFieldsUseCase(
+		fieldRepository,
+		fieldRepo.NewFieldMergerRepository(pool, logger),
+		fieldQry,
+		fieldOverlapRepository,
+		clusterJobEnqueuer,
+		logger,
+	)
+	fieldHdlr := fieldHandler.NewFieldHandler(
+		listFieldsUC,
+		getFieldUC,
+		createFieldUC,
+		updateFieldUC,
+		deleteFieldUC,
+		divideFieldUC,
+		mergeFieldsUC,
+		logger,
+	)
+
+	fieldTileCacheRepository := fieldRepo.NewFieldTileCacheRedisRepository(cacheClient, logger)
+	getFieldTileUC := fieldUsecase.NewGetFieldTileUseCase(fieldQuery.NewFieldTileQuery(pool), fieldTileCacheRepository, logger)
+	fieldTileHdlr := fieldHandler.NewFieldTileHandler(getFieldTileUC, logger)
+
+	getFieldLineageUC := fieldUsecase.NewGetFieldLineageUseCase(fieldQuery.NewFieldLineageQuery(pool))
+	fieldLineageHdlr := fieldHandler.NewFieldLineageHandler(getFieldLineageUC, logger)
+
+	listFieldOverlapsUC := fieldUsecase.NewListFieldOverlapsUseCase(fieldQuery.NewFieldOverlapQuery(pool))
+	resolveFieldOverlapUC := fieldUsecase.NewResolveFieldOverlapUseCase(fieldRepository, fieldOverlapRepository, clusterJobEnqueuer, logger)
+	fieldOverlapHdlr := fieldHandler.NewFieldOverlapHandler(listFieldOverlapsUC, resolveFieldOverlapUC, logger)
+
+	// エクスポート機能のDI
+	exportJobRepository := exportRepo.NewExportJobRepository(pool)
+	requestExportUC := exportUsecase.NewRequestExportUseCase(exportJobRepository)
+	getExportStatusUC := exportUsecase.NewGetExportStatusUseCase(exportJobRepository, storageClient, downloadURLExpiry, logger)
+	exportHdlr := exportHandler.NewExportHandler(requestExportUC, getExportStatusUC, logger)
+
+	// 統計機能のDI
+	getFieldStatsUC := statsUsecase.NewGetFieldStatsUseCase(
+		statsRepo.NewFieldStatsPostgresRepository(pool, logger),
+		statsRepo.NewFieldStatsCacheRedisRepository(cacheClient, logger),
+		logger,
+	)
+	fieldStatsHdlr := statsHandler.NewFieldStatsHandler(getFieldStatsUC, logger)
+
 	return &StrictServerHandler{
-		clusterHandler: clusterHdlr,
-		logger:         logger,
+		clusterHandler:      clusterHdlr,
+		clusterJobHandler:   clusterJobHdlr,
+		exportHandler:       exportHdlr,
+		fieldHandler:        fieldHdlr,
+		fieldTileHandler:    fieldTileHdlr,
+		fieldLineageHandler: fieldLineageHdlr,
+		fieldOverlapHandler: fieldOverlapHdlr,
+		fieldStatsHandler:   fieldStatsHdlr,
+		logger:              logger,
 	}
 }
 
@@ -61,20 +168,99 @@ func (h *StrictServerHandler) RecalculateClusters(ctx context.Context, request o
 	return h.clusterHandler.RecalculateClusters(ctx, request)
 }
 
-// ListFields は圃場一覧取得エンドポイント(未実装)
-func (h *StrictServerHandler) ListFields(_ context.Context, _ openapi.ListFieldsRequestObject) (openapi.ListFieldsResponseObject, error) {
-	return openapi.ListFields500JSONResponse{
-		Code:    "not_implemented",
-		Message: "未実装",
-	}, nil
+// ListClusterJobs はクラスタージョブ履歴取得エンドポイント
+func (h *StrictServerHandler) ListClusterJobs(ctx context.Context, request openapi.ListClusterJobsRequestObject) (openapi.ListClusterJobsResponseObject, error) {
+	return h.clusterJobHandler.ListClusterJobs(ctx, request)
 }
 
-// GetField は圃場詳細取得エンドポイント(未実装)
-func (h *StrictServerHandler) GetField(_ context.Context, _ openapi.GetFieldRequestObject) (openapi.GetFieldResponseObject, error) {
-	return openapi.GetField500JSONResponse{
-		Code:    "not_implemented",
-		Message: "未実装",
-	}, nil
+// GetClusterJob はクラスタージョブ取得エンドポイント
+func (h *StrictServerHandler) GetClusterJob(ctx context.Context, request openapi.GetClusterJobRequestObject) (openapi.GetClusterJobResponseObject, error) {
+	return h.clusterJobHandler.GetClusterJob(ctx, request)
+}
+
+// CancelClusterJob はクラスタージョブ取り消しエンドポイント
+func (h *StrictServerHandler) CancelClusterJob(ctx context.Context, request openapi.CancelClusterJobRequestObject) (openapi.CancelClusterJobResponseObject, error) {
+	return h.clusterJobHandler.CancelClusterJob(ctx, request)
+}
+
+// RetryClusterJob はクラスタージョブ再実行エンドポイント
+func (h *StrictServerHandler) RetryClusterJob(ctx context.Context, request openapi.RetryClusterJobRequestObject) (openapi.RetryClusterJobResponseObject, error) {
+	return h.clusterJobHandler.RetryClusterJob(ctx, request)
+}
+
+// ListFields は圃場一覧取得エンドポイント
+func (h *StrictServerHandler) ListFields(ctx context.Context, request openapi.ListFieldsRequestObject) (openapi.ListFieldsResponseObject, error) {
+	return h.fieldHandler.ListFields(ctx, request)
+}
+
+// GetField は圃場詳細取得エンドポイント
+func (h *StrictServerHandler) GetField(ctx context.Context, request openapi.GetFieldRequestObject) (openapi.GetFieldResponseObject, error) {
+	return h.fieldHandler.GetField(ctx, request)
+}
+
+// CreateField は圃場作成エンドポイント
+func (h *StrictServerHandler) CreateField(ctx context.Context, request openapi.CreateFieldRequestObject) (openapi.CreateFieldResponseObject, error) {
+	return h.fieldHandler.CreateField(ctx, request)
+}
+
+// UpdateField は圃場更新エンドポイント
+func (h *StrictServerHandler) UpdateField(ctx context.Context, request openapi.UpdateFieldRequestObject) (openapi.UpdateFieldResponseObject, error) {
+	return h.fieldHandler.UpdateField(ctx, request)
+}
+
+// DeleteField は圃場削除エンドポイント
+func (h *StrictServerHandler) DeleteField(ctx context.Context, request openapi.DeleteFieldRequestObject) (openapi.DeleteFieldResponseObject, error) {
+	return h.fieldHandler.DeleteField(ctx, request)
+}
+
+// DivideField は圃場分筆エンドポイント
+func (h *StrictServerHandler) DivideField(ctx context.Context, request openapi.DivideFieldRequestObject) (openapi.DivideFieldResponseObject, error) {
+	return h.fieldHandler.DivideField(ctx, request)
+}
+
+// MergeFields は圃場合筆エンドポイント
+func (h *StrictServerHandler) MergeFields(ctx context.Context, request openapi.MergeFieldsRequestObject) (openapi.MergeFieldsResponseObject, error) {
+	return h.fieldHandler.MergeFields(ctx, request)
+}
+
+// GetFieldLineage は圃場系譜取得エンドポイント
+func (h *StrictServerHandler) GetFieldLineage(ctx context.Context, request openapi.GetFieldLineageRequestObject) (openapi.GetFieldLineageResponseObject, error) {
+	return h.fieldLineageHandler.GetFieldLineage(ctx, request)
+}
+
+// ListFieldOverlaps は圃場オーバーラップ一覧取得エンドポイント
+func (h *StrictServerHandler) ListFieldOverlaps(ctx context.Context, request openapi.ListFieldOverlapsRequestObject) (openapi.ListFieldOverlapsResponseObject, error) {
+	return h.fieldOverlapHandler.ListFieldOverlaps(ctx, request)
+}
+
+// ResolveFieldOverlap は圃場オーバーラップ対応エンドポイント
+func (h *StrictServerHandler) ResolveFieldOverlap(ctx context.Context, request openapi.ResolveFieldOverlapRequestObject) (openapi.ResolveFieldOverlapResponseObject, error) {
+	return h.fieldOverlapHandler.ResolveFieldOverlap(ctx, request)
+}
+
+// GetFieldTile は圃場ベクタータイル取得エンドポイント
+func (h *StrictServerHandler) GetFieldTile(ctx context.Context, request openapi.GetFieldTileRequestObject) (openapi.GetFieldTileResponseObject, error) {
+	return h.fieldTileHandler.GetFieldTile(ctx, request)
+}
+
+// GetCityStats は市区町村の圃場統計取得エンドポイント
+func (h *StrictServerHandler) GetCityStats(ctx context.Context, request openapi.GetCityStatsRequestObject) (openapi.GetCityStatsResponseObject, error) {
+	return h.fieldStatsHandler.GetCityStats(ctx, request)
+}
+
+// GetPrefectureStats は都道府県の圃場統計取得エンドポイント
+func (h *StrictServerHandler) GetPrefectureStats(ctx context.Context, request openapi.GetPrefectureStatsRequestObject) (openapi.GetPrefectureStatsResponseObject, error) {
+	return h.fieldStatsHandler.GetPrefectureStats(ctx, request)
+}
+
+// RequestExport はエクスポートリクエストエンドポイント
+func (h *StrictServerHandler) RequestExport(ctx context.Context, request openapi.RequestExportRequestObject) (openapi.RequestExportResponseObject, error) {
+	return h.exportHandler.RequestExport(ctx, request)
+}
+
+// GetExportStatus はエクスポートステータス取得エンドポイント
+func (h *StrictServerHandler) GetExportStatus(ctx context.Context, request openapi.GetExportStatusRequestObject) (openapi.GetExportStatusResponseObject, error) {
+	return h.exportHandler.GetExportStatus(ctx, request)
 }
 
 // RequestImport はインポートリクエストエンドポイント(未実装)
@@ -103,7 +289,27 @@ func (h *StrictServerHandler) HealthCheck(_ context.Context, _ openapi.HealthChe
 // SetupRouter はGinルーターをセットアップする
 func SetupRouter(handler openapi.StrictServerInterface) *gin.Engine {
 	router := gin.Default()
+	router.Use(pathExtensionParams())
 	strictHandler := openapi.NewStrictHandler(handler, nil)
 	openapi.RegisterHandlers(router, strictHandler)
 	return router
 }
+
+// pathExtensionParams は拡張子付きパスパラメータを拡張子を除いた名前でも参照できるようにする
+// Ginは "/:y.mvt" のようなルートを "y.mvt" という名前のパラメータ(値は "2.mvt")として扱うため、
+// 生成コードが参照する c.Param("y") では値を取得できない
+func pathExtensionParams() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, param := range c.Params {
+			name, ext, found := strings.Cut(param.Key, ".")
+			if !found || c.Param(name) != "" {
+				continue
+			}
+			c.Params = append(c.Params, gin.Param{
+				Key:   name,
+				Value: strings.TrimSuffix(param.Value, "."+ext),
+			})
+		}
+		c.Next()
+	}
+}